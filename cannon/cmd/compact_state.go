@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+var (
+	CompactStateInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input state, JSON or binary snapshot. May be a binary diff snapshot if a base is specified.",
+		TakesFile: true,
+		Required:  true,
+	}
+	CompactStateOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path of output state, binary snapshot if the path ends in .bin (optionally .bin.gz or .bin.zst), JSON otherwise.",
+		TakesFile: true,
+		Required:  true,
+	}
+	CompactStateBaseFlag = &cli.PathFlag{
+		Name:      "base",
+		Usage:     "path of the state the input diff snapshot was created against. Required to resolve diff snapshots.",
+		TakesFile: true,
+		Required:  false,
+	}
+)
+
+func CompactState(ctx *cli.Context) error {
+	input := ctx.Path(CompactStateInputFlag.Name)
+	var state *mipsevm.State
+	var err error
+	if basePath := ctx.Path(CompactStateBaseFlag.Name); basePath != "" {
+		if !isBinaryStatePath(input) {
+			return fmt.Errorf("input %v must be a binary diff snapshot when a base is specified", input)
+		}
+		var base *mipsevm.State
+		base, err = loadState(basePath)
+		if err != nil {
+			return fmt.Errorf("invalid base state (%v): %w", basePath, err)
+		}
+		state, err = loadBinaryState(input, base)
+	} else {
+		state, err = loadState(input)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid input state (%v): %w", input, err)
+	}
+	output := ctx.Path(CompactStateOutputFlag.Name)
+	if err := writeState(output, state); err != nil {
+		return fmt.Errorf("failed to write state output (%v): %w", output, err)
+	}
+	return nil
+}
+
+var CompactStateCommand = &cli.Command{
+	Name:  "compact-state",
+	Usage: "Convert a Cannon state between the JSON and (compressed) binary snapshot formats",
+	Description: "Convert a Cannon state between the JSON and binary snapshot formats. " +
+		"The output format is determined by the output file extension: .bin for binary snapshots, JSON otherwise, " +
+		"optionally followed by .gz or .zst for gzip or zstd compression. " +
+		"Binary diff snapshots are resolved into full states against the state specified with --base.",
+	Action: CompactState,
+	Flags: []cli.Flag{
+		CompactStateInputFlag,
+		CompactStateOutputFlag,
+		CompactStateBaseFlag,
+	},
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 )
 
-type StepMatcher func(st *mipsevm.State) bool
+type StepMatcher func(st mipsevm.FPVMState) bool
 
 type StepMatcherFlag struct {
 	repr    string
@@ -26,11 +26,11 @@ func MustStepMatcherFlag(pattern string) *StepMatcherFlag {
 func (m *StepMatcherFlag) Set(value string) error {
 	m.repr = value
 	if value == "" || value == "never" {
-		m.matcher = func(st *mipsevm.State) bool {
+		m.matcher = func(st mipsevm.FPVMState) bool {
 			return false
 		}
 	} else if value == "always" {
-		m.matcher = func(st *mipsevm.State) bool {
+		m.matcher = func(st mipsevm.FPVMState) bool {
 			return true
 		}
 	} else if strings.HasPrefix(value, "=") {
@@ -38,16 +38,16 @@ func (m *StepMatcherFlag) Set(value string) error {
 		if err != nil {
 			return fmt.Errorf("failed to parse step number: %w", err)
 		}
-		m.matcher = func(st *mipsevm.State) bool {
-			return st.Step == when
+		m.matcher = func(st mipsevm.FPVMState) bool {
+			return st.GetStep() == when
 		}
 	} else if strings.HasPrefix(value, "%") {
 		when, err := strconv.ParseUint(value[1:], 0, 64)
 		if err != nil {
 			return fmt.Errorf("failed to parse step interval number: %w", err)
 		}
-		m.matcher = func(st *mipsevm.State) bool {
-			return st.Step%when == 0
+		m.matcher = func(st mipsevm.FPVMState) bool {
+			return st.GetStep()%when == 0
 		}
 	} else {
 		return fmt.Errorf("unrecognized step matcher: %q", value)
@@ -61,7 +61,7 @@ func (m *StepMatcherFlag) String() string {
 
 func (m *StepMatcherFlag) Matcher() StepMatcher {
 	if m.matcher == nil { // Set(value) is not called for omitted inputs, default to never matching.
-		return func(st *mipsevm.State) bool {
+		return func(st mipsevm.FPVMState) bool {
 			return false
 		}
 	}
@@ -0,0 +1,399 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+var (
+	ProfileInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input state, JSON or binary snapshot.",
+		TakesFile: true,
+		Value:     "state.json",
+		Required:  true,
+	}
+	ProfileOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path to write the full JSON profile report to. Not written if empty, use - to write to Stdout.",
+		TakesFile: true,
+		Required:  false,
+	}
+	ProfileMetaFlag = &cli.PathFlag{
+		Name:     "meta",
+		Usage:    "path to metadata file, to attribute instructions to the symbols of the program.",
+		Value:    "meta.json",
+		Required: false,
+	}
+	ProfileStopAtFlag = &cli.GenericFlag{
+		Name:     "stop-at",
+		Usage:    "step pattern to stop profiling at: " + patternHelp,
+		Value:    new(StepMatcherFlag),
+		Required: false,
+	}
+	ProfileInfoAtFlag = &cli.GenericFlag{
+		Name:     "info-at",
+		Usage:    "step pattern to print progress info at: " + patternHelp,
+		Value:    MustStepMatcherFlag("%10000000"),
+		Required: false,
+	}
+	ProfilePCRangeSizeFlag = &cli.UintFlag{
+		Name:     "pc-range-size",
+		Usage:    "size in bytes of the program counter ranges that executed instructions are grouped by. Must be a power of 2.",
+		Value:    4096,
+		Required: false,
+	}
+	ProfileMemRegionSizeFlag = &cli.UintFlag{
+		Name:     "mem-region-size",
+		Usage:    "size in bytes of the memory regions that loads and stores are grouped by. Must be a power of 2.",
+		Value:    mipsevm.PageSize,
+		Required: false,
+	}
+	ProfileTopFlag = &cli.IntFlag{
+		Name:     "top",
+		Usage:    "number of entries to print per category.",
+		Value:    20,
+		Required: false,
+	}
+)
+
+// ProfileEntry is the number of occurrences of a single item in a profile category.
+type ProfileEntry struct {
+	Name    string  `json:"name"`
+	Count   uint64  `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// PreimageProfileEntry is the number of pre-image oracle requests of a single pre-image key type.
+type PreimageProfileEntry struct {
+	KeyType string `json:"keyType"`
+	Count   uint64 `json:"count"`
+	Bytes   uint64 `json:"bytes"`
+}
+
+// ProfileReport summarizes where the steps of a program execution are spent.
+// All entries are sorted by count, in descending order.
+type ProfileReport struct {
+	StartStep uint64 `json:"startStep"`
+	Steps     uint64 `json:"steps"`
+	Exited    bool   `json:"exited"`
+
+	Symbols    []ProfileEntry         `json:"symbols"`
+	PCRanges   []ProfileEntry         `json:"pcRanges"`
+	MemRegions []ProfileEntry         `json:"memRegions"`
+	Preimages  []PreimageProfileEntry `json:"preimages"`
+	Hints      []ProfileEntry         `json:"hints"`
+}
+
+// Profiler aggregates execution statistics, step by step.
+type Profiler struct {
+	pcRangeBits   uint
+	memRegionBits uint
+
+	steps      uint64
+	pcCounts   map[uint32]uint64
+	memCounts  map[uint32]uint64
+	preimages  map[preimage.KeyType]*PreimageProfileEntry
+	hintCounts map[string]uint64
+}
+
+// NewProfiler creates a profiler that groups PCs and memory accesses by ranges of the given sizes,
+// which must be powers of 2.
+func NewProfiler(pcRangeSize, memRegionSize uint) (*Profiler, error) {
+	pcRangeBits, err := log2(pcRangeSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PC range size: %w", err)
+	}
+	memRegionBits, err := log2(memRegionSize)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory region size: %w", err)
+	}
+	return &Profiler{
+		pcRangeBits:   pcRangeBits,
+		memRegionBits: memRegionBits,
+		pcCounts:      make(map[uint32]uint64),
+		memCounts:     make(map[uint32]uint64),
+		preimages:     make(map[preimage.KeyType]*PreimageProfileEntry),
+		hintCounts:    make(map[string]uint64),
+	}, nil
+}
+
+func log2(size uint) (uint, error) {
+	if size == 0 || size&(size-1) != 0 || size > 1<<31 {
+		return 0, fmt.Errorf("%d is not a power of 2 in the 32-bit address space", size)
+	}
+	bits := uint(0)
+	for size > 1 {
+		size >>= 1
+		bits++
+	}
+	return bits, nil
+}
+
+// RecordStep records the execution of the instruction at pc.
+func (p *Profiler) RecordStep(pc uint32) {
+	p.steps++
+	p.pcCounts[pc]++
+}
+
+// RecordMemAccess records a load or store at the given address.
+func (p *Profiler) RecordMemAccess(addr uint32) {
+	p.memCounts[addr>>p.memRegionBits]++
+}
+
+// Oracle wraps the pre-image oracle, to record the pre-image requests and hints of the program.
+func (p *Profiler) Oracle(po mipsevm.PreimageOracle) mipsevm.PreimageOracle {
+	return &profilingOracle{PreimageOracle: po, p: p}
+}
+
+type profilingOracle struct {
+	mipsevm.PreimageOracle
+	p *Profiler
+}
+
+func (o *profilingOracle) Hint(v []byte) {
+	// hints are formatted as "<type> <data>"
+	typ, _, _ := bytes.Cut(v, []byte{' '})
+	o.p.hintCounts[string(typ)]++
+	o.PreimageOracle.Hint(v)
+}
+
+func (o *profilingOracle) GetPreimage(k [32]byte) []byte {
+	v := o.PreimageOracle.GetPreimage(k)
+	typ := preimage.KeyType(k[0])
+	entry, ok := o.p.preimages[typ]
+	if !ok {
+		entry = &PreimageProfileEntry{KeyType: keyTypeName(typ)}
+		o.p.preimages[typ] = entry
+	}
+	entry.Count++
+	entry.Bytes += uint64(len(v))
+	return v
+}
+
+func keyTypeName(typ preimage.KeyType) string {
+	switch typ {
+	case preimage.LocalKeyType:
+		return "local"
+	case preimage.Keccak256KeyType:
+		return "keccak256"
+	case preimage.GlobalGenericKeyType:
+		return "global-generic"
+	case preimage.Sha256KeyType:
+		return "sha256"
+	case preimage.BlobKeyType:
+		return "blob"
+	default:
+		return fmt.Sprintf("unknown-%d", typ)
+	}
+}
+
+// Report aggregates the recorded statistics. Instructions are attributed to symbols using meta.
+func (p *Profiler) Report(meta *mipsevm.Metadata) *ProfileReport {
+	symbolCounts := make(map[string]uint64)
+	rangeCounts := make(map[uint32]uint64)
+	for pc, count := range p.pcCounts {
+		symbolCounts[meta.LookupSymbol(pc)] += count
+		rangeCounts[pc>>p.pcRangeBits] += count
+	}
+	var memAccesses uint64
+	for _, count := range p.memCounts {
+		memAccesses += count
+	}
+	var hints uint64
+	for _, count := range p.hintCounts {
+		hints += count
+	}
+	preimages := make([]PreimageProfileEntry, 0, len(p.preimages))
+	for _, entry := range p.preimages {
+		preimages = append(preimages, *entry)
+	}
+	sort.Slice(preimages, func(i, j int) bool {
+		if preimages[i].Count != preimages[j].Count {
+			return preimages[i].Count > preimages[j].Count
+		}
+		return preimages[i].KeyType < preimages[j].KeyType
+	})
+	return &ProfileReport{
+		Steps:      p.steps,
+		Symbols:    profileEntries(symbolCounts, func(name string) string { return name }, p.steps),
+		PCRanges:   profileEntries(rangeCounts, rangeName(p.pcRangeBits), p.steps),
+		MemRegions: profileEntries(p.memCounts, rangeName(p.memRegionBits), memAccesses),
+		Preimages:  preimages,
+		Hints:      profileEntries(p.hintCounts, func(name string) string { return name }, hints),
+	}
+}
+
+func rangeName(bits uint) func(index uint32) string {
+	return func(index uint32) string {
+		start := uint64(index) << bits
+		end := start + (uint64(1) << bits) - 1
+		return fmt.Sprintf("%08x-%08x", start, end)
+	}
+}
+
+func profileEntries[K comparable](counts map[K]uint64, name func(K) string, total uint64) []ProfileEntry {
+	entries := make([]ProfileEntry, 0, len(counts))
+	for k, count := range counts {
+		entry := ProfileEntry{Name: name(k), Count: count}
+		if total > 0 {
+			entry.Percent = float64(count) * 100 / float64(total)
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Count != entries[j].Count {
+			return entries[i].Count > entries[j].Count
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// WriteSummary writes the top entries of every profile category as human-readable tables.
+func (r *ProfileReport) WriteSummary(w io.Writer, top int) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintf(tw, "steps: %d (from step %d), exited: %v\n", r.Steps, r.StartStep, r.Exited)
+	printEntries := func(title string, entries []ProfileEntry) {
+		_, _ = fmt.Fprintf(tw, "\n%s (%d total)\n", title, len(entries))
+		for i, e := range entries {
+			if i >= top {
+				break
+			}
+			_, _ = fmt.Fprintf(tw, "%d\t%.2f%%\t %s\n", e.Count, e.Percent, e.Name)
+		}
+	}
+	printEntries("instructions by symbol", r.Symbols)
+	printEntries("instructions by PC range", r.PCRanges)
+	printEntries("loads/stores by memory region", r.MemRegions)
+	_, _ = fmt.Fprintf(tw, "\npre-image requests by key type\n")
+	for _, e := range r.Preimages {
+		_, _ = fmt.Fprintf(tw, "%d\t%d bytes\t %s\n", e.Count, e.Bytes, e.KeyType)
+	}
+	printEntries("hints by type", r.Hints)
+	return tw.Flush()
+}
+
+func Profile(ctx *cli.Context) error {
+	state, err := loadState(ctx.Path(ProfileInputFlag.Name))
+	if err != nil {
+		return err
+	}
+	prof, err := NewProfiler(ctx.Uint(ProfilePCRangeSizeFlag.Name), ctx.Uint(ProfileMemRegionSizeFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	l := Logger(os.Stderr, log.LvlInfo)
+	outLog := &mipsevm.LoggingWriter{Name: "program std-out", Log: l}
+	errLog := &mipsevm.LoggingWriter{Name: "program std-err", Log: l}
+
+	// split CLI args after first '--'
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+
+	po, err := NewProcessPreimageOracle(args[0], args[1:])
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	stopAt := ctx.Generic(ProfileStopAtFlag.Name).(*StepMatcherFlag).Matcher()
+	infoAt := ctx.Generic(ProfileInfoAtFlag.Name).(*StepMatcherFlag).Matcher()
+
+	var meta *mipsevm.Metadata
+	if metaPath := ctx.Path(ProfileMetaFlag.Name); metaPath == "" {
+		l.Info("no metadata file specified, instructions are not attributed to symbols")
+		meta = &mipsevm.Metadata{Symbols: nil}
+	} else {
+		if m, err := loadJSON[mipsevm.Metadata](metaPath); err != nil {
+			return fmt.Errorf("failed to load metadata: %w", err)
+		} else {
+			meta = m
+		}
+	}
+
+	us := mipsevm.NewInstrumentedState(state, prof.Oracle(po), outLog, errLog)
+	stepFn := us.Step
+	if po.cmd != nil {
+		stepFn = Guard(po.cmd.ProcessState, stepFn)
+	}
+
+	startStep := state.GetStep()
+	for !state.GetExited() {
+		if state.GetStep()%100 == 0 { // don't do the ctx err check (includes lock) too often
+			if err := ctx.Context.Err(); err != nil {
+				return err
+			}
+		}
+		step := state.GetStep()
+		if infoAt(state) {
+			l.Info("profiling", "step", step, "pc", mipsevm.HexU32(state.GetPC()), "name", meta.LookupSymbol(state.GetPC()))
+		}
+		if stopAt(state) {
+			break
+		}
+		prof.RecordStep(state.GetPC())
+		if _, err := stepFn(false); err != nil {
+			return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.GetPC(), err)
+		}
+		if addr, ok := us.LastMemAccess(); ok {
+			prof.RecordMemAccess(addr)
+		}
+	}
+
+	report := prof.Report(meta)
+	report.StartStep = startStep
+	report.Exited = state.GetExited()
+	if err := writeJSON(ctx.Path(ProfileOutputFlag.Name), report); err != nil {
+		return fmt.Errorf("failed to write profile report: %w", err)
+	}
+	if ctx.Path(ProfileOutputFlag.Name) == "-" {
+		return nil
+	}
+	return report.WriteSummary(os.Stdout, ctx.Int(ProfileTopFlag.Name))
+}
+
+var ProfileCommand = &cli.Command{
+	Name:  "profile",
+	Usage: "Profile the execution of a program, to find where its steps are spent.",
+	Description: "Run the VM and report instruction counts by symbol and by PC range, " +
+		"loads and stores by memory region, and pre-image oracle requests and hints by type. " +
+		"The pre-image server command, if any, is passed after --, as with the run command.",
+	Action: Profile,
+	Flags: []cli.Flag{
+		ProfileInputFlag,
+		ProfileOutputFlag,
+		ProfileMetaFlag,
+		ProfileStopAtFlag,
+		ProfileInfoAtFlag,
+		ProfilePCRangeSizeFlag,
+		ProfileMemRegionSizeFlag,
+		ProfileTopFlag,
+	},
+}
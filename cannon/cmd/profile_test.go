@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+type profileTestOracle struct {
+	hints []string
+}
+
+func (o *profileTestOracle) Hint(v []byte) {
+	o.hints = append(o.hints, string(v))
+}
+
+func (o *profileTestOracle) GetPreimage(k [32]byte) []byte {
+	return make([]byte, k[31])
+}
+
+func TestProfiler(t *testing.T) {
+	_, err := NewProfiler(1000, 4096)
+	require.ErrorContains(t, err, "PC range size")
+	_, err = NewProfiler(4096, 0)
+	require.ErrorContains(t, err, "memory region size")
+
+	prof, err := NewProfiler(0x100, 0x1000)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		prof.RecordStep(0x1000)
+	}
+	prof.RecordStep(0x1004)
+	prof.RecordStep(0x2000)
+	prof.RecordStep(0x2104)
+	prof.RecordMemAccess(0x7fff_f000)
+	prof.RecordMemAccess(0x7fff_f004)
+	prof.RecordMemAccess(0x3000_0000)
+
+	inner := &profileTestOracle{}
+	oracle := prof.Oracle(inner)
+	oracle.Hint([]byte("l2-block-header 0x1234"))
+	oracle.Hint([]byte("l2-block-header 0x5678"))
+	oracle.Hint([]byte("l1-receipts 0x1234"))
+	require.Len(t, oracle.GetPreimage([32]byte{1, 31: 8}), 8)
+	require.Len(t, oracle.GetPreimage([32]byte{2, 31: 100}), 100)
+	require.Len(t, oracle.GetPreimage([32]byte{2, 31: 20}), 20)
+	require.Len(t, inner.hints, 3, "hints are passed through")
+
+	meta := &mipsevm.Metadata{Symbols: []mipsevm.Symbol{
+		{Name: "main.a", Start: 0x1000, Size: 0x100},
+		{Name: "main.b", Start: 0x2000, Size: 0x200},
+	}}
+	report := prof.Report(meta)
+	require.Equal(t, uint64(6), report.Steps)
+	require.Equal(t, []ProfileEntry{
+		{Name: "main.a", Count: 4, Percent: 100 * 4.0 / 6},
+		{Name: "main.b", Count: 2, Percent: 100 * 2.0 / 6},
+	}, report.Symbols)
+	require.Equal(t, []ProfileEntry{
+		{Name: "00001000-000010ff", Count: 4, Percent: 100 * 4.0 / 6},
+		{Name: "00002000-000020ff", Count: 1, Percent: 100 * 1.0 / 6},
+		{Name: "00002100-000021ff", Count: 1, Percent: 100 * 1.0 / 6},
+	}, report.PCRanges)
+	require.Equal(t, []ProfileEntry{
+		{Name: "7ffff000-7fffffff", Count: 2, Percent: 100 * 2.0 / 3},
+		{Name: "30000000-30000fff", Count: 1, Percent: 100 * 1.0 / 3},
+	}, report.MemRegions)
+	require.Equal(t, []PreimageProfileEntry{
+		{KeyType: "keccak256", Count: 2, Bytes: 120},
+		{KeyType: "local", Count: 1, Bytes: 8},
+	}, report.Preimages)
+	require.Equal(t, []ProfileEntry{
+		{Name: "l2-block-header", Count: 2, Percent: 100 * 2.0 / 3},
+		{Name: "l1-receipts", Count: 1, Percent: 100 * 1.0 / 3},
+	}, report.Hints)
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteSummary(&out, 1))
+	summary := out.String()
+	require.Contains(t, summary, "main.a")
+	require.NotContains(t, summary, "main.b", "only the top entries are printed")
+	require.True(t, strings.HasPrefix(summary, "steps: 6"))
+}
+
+func TestProfilerMemAccess(t *testing.T) {
+	state := &mipsevm.State{PC: 0, NextPC: 4, Memory: mipsevm.NewMemory()}
+	state.Registers[29] = 0x7fff_f000
+	state.Memory.SetMemory(0, 0xafa8_0004) // sw $t0, 4($sp)
+	state.Memory.SetMemory(4, 0x0000_0000) // nop
+	us := mipsevm.NewInstrumentedState(state, nil, nil, nil)
+
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	addr, ok := us.LastMemAccess()
+	require.True(t, ok)
+	require.Equal(t, uint32(0x7fff_f004), addr)
+
+	_, err = us.Step(false)
+	require.NoError(t, err)
+	_, ok = us.LastMemAccess()
+	require.False(t, ok, "nop does not access memory")
+}
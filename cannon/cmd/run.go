@@ -21,14 +21,14 @@ import (
 var (
 	RunInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input JSON state. Stdin if left empty.",
+		Usage:     "path of input state, JSON or binary snapshot. Stdin if left empty.",
 		TakesFile: true,
 		Value:     "state.json",
 		Required:  true,
 	}
 	RunOutputFlag = &cli.PathFlag{
 		Name:      "output",
-		Usage:     "path of output JSON state. Not written if empty, use - to write to Stdout.",
+		Usage:     "path of output state, binary snapshot if the path ends in .bin (optionally .bin.gz or .bin.zst), JSON otherwise. Not written if empty, use - to write to Stdout.",
 		TakesFile: true,
 		Value:     "out.json",
 		Required:  false,
@@ -54,10 +54,15 @@ var (
 	}
 	RunSnapshotFmtFlag = &cli.StringFlag{
 		Name:     "snapshot-fmt",
-		Usage:    "format for snapshot output file names.",
+		Usage:    "format for snapshot output file names. Snapshots are written as binary snapshots if the name ends in .bin (optionally .bin.gz or .bin.zst), JSON otherwise.",
 		Value:    "state-%d.json",
 		Required: false,
 	}
+	RunSnapshotDiffFlag = &cli.BoolFlag{
+		Name:     "snapshot-diff",
+		Usage:    "write each snapshot after the first as a diff against the previous snapshot, containing only the changed memory pages. Requires binary snapshots, see snapshot-fmt.",
+		Required: false,
+	}
 	RunStopAtFlag = &cli.GenericFlag{
 		Name:     "stop-at",
 		Usage:    "step pattern to stop at: " + patternHelp,
@@ -234,7 +239,7 @@ func Run(ctx *cli.Context) error {
 		defer profile.Start(profile.NoShutdownHook, profile.ProfilePath("."), profile.CPUProfile).Stop()
 	}
 
-	state, err := loadJSON[mipsevm.State](ctx.Path(RunInputFlag.Name))
+	state, err := loadState(ctx.Path(RunInputFlag.Name))
 	if err != nil {
 		return err
 	}
@@ -294,6 +299,12 @@ func Run(ctx *cli.Context) error {
 	us := mipsevm.NewInstrumentedState(state, po, outLog, errLog)
 	proofFmt := ctx.String(RunProofFmtFlag.Name)
 	snapshotFmt := ctx.String(RunSnapshotFmtFlag.Name)
+	snapshotDiff := ctx.Bool(RunSnapshotDiffFlag.Name)
+	if snapshotDiff && !isBinaryStatePath(snapshotFmt) {
+		return fmt.Errorf("snapshot diffs require a binary snapshot format, got %q", snapshotFmt)
+	}
+	// base of the next diff snapshot, nil until the first snapshot is written
+	var snapshotBase *mipsevm.SnapshotBase
 
 	stepFn := us.Step
 	if po.cmd != nil {
@@ -301,34 +312,34 @@ func Run(ctx *cli.Context) error {
 	}
 
 	start := time.Now()
-	startStep := state.Step
+	startStep := state.GetStep()
 
 	// avoid symbol lookups every instruction by preparing a matcher func
 	sleepCheck := meta.SymbolMatcher("runtime.notesleep")
 
-	for !state.Exited {
-		if state.Step%100 == 0 { // don't do the ctx err check (includes lock) too often
+	for !state.GetExited() {
+		if state.GetStep()%100 == 0 { // don't do the ctx err check (includes lock) too often
 			if err := ctx.Context.Err(); err != nil {
 				return err
 			}
 		}
 
-		step := state.Step
+		step := state.GetStep()
 
 		if infoAt(state) {
 			delta := time.Since(start)
 			l.Info("processing",
 				"step", step,
-				"pc", mipsevm.HexU32(state.PC),
-				"insn", mipsevm.HexU32(state.Memory.GetMemory(state.PC)),
+				"pc", mipsevm.HexU32(state.GetPC()),
+				"insn", mipsevm.HexU32(state.GetMemory().GetMemory(state.GetPC())),
 				"ips", float64(step-startStep)/(float64(delta)/float64(time.Second)),
-				"pages", state.Memory.PageCount(),
-				"mem", state.Memory.Usage(),
-				"name", meta.LookupSymbol(state.PC),
+				"pages", state.GetMemory().PageCount(),
+				"mem", state.GetMemory().Usage(),
+				"name", meta.LookupSymbol(state.GetPC()),
 			)
 		}
 
-		if sleepCheck(state.PC) { // don't loop forever when we get stuck because of an unexpected bad program
+		if sleepCheck(state.GetPC()) { // don't loop forever when we get stuck because of an unexpected bad program
 			return fmt.Errorf("got stuck in Go sleep at step %d", step)
 		}
 
@@ -337,12 +348,23 @@ func Run(ctx *cli.Context) error {
 		}
 
 		if snapshotAt(state) {
-			if err := writeJSON(fmt.Sprintf(snapshotFmt, step), state); err != nil {
+			snapshotPath := fmt.Sprintf(snapshotFmt, step)
+			if snapshotBase != nil {
+				err = writeStateDiff(snapshotPath, snapshotBase, state)
+			} else {
+				err = writeState(snapshotPath, state)
+			}
+			if err != nil {
 				return fmt.Errorf("failed to write state snapshot: %w", err)
 			}
+			if snapshotDiff {
+				if snapshotBase, err = mipsevm.NewSnapshotBase(state); err != nil {
+					return fmt.Errorf("failed to prepare state snapshot diff: %w", err)
+				}
+			}
 		}
 
-		prevPreimageOffset := state.PreimageOffset
+		prevPreimageOffset := state.GetPreimageOffset()
 
 		if proofAt(state) {
 			preStateHash, err := state.EncodeWitness().StateHash()
@@ -351,7 +373,7 @@ func Run(ctx *cli.Context) error {
 			}
 			witness, err := stepFn(true)
 			if err != nil {
-				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.PC, err)
+				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.GetPC(), err)
 			}
 			postStateHash, err := state.EncodeWitness().StateHash()
 			if err != nil {
@@ -375,11 +397,11 @@ func Run(ctx *cli.Context) error {
 		} else {
 			_, err = stepFn(false)
 			if err != nil {
-				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.PC, err)
+				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.GetPC(), err)
 			}
 		}
 
-		if preimageRead := state.PreimageOffset > prevPreimageOffset; preimageRead {
+		if preimageRead := state.GetPreimageOffset() > prevPreimageOffset; preimageRead {
 			if stopAtPreimageType == "any" {
 				break
 			}
@@ -388,7 +410,7 @@ func Run(ctx *cli.Context) error {
 				if stopAtPreimageType == "global" {
 					keyType = byte(preimage.Keccak256KeyType)
 				}
-				if state.PreimageKey.Bytes()[0] == keyType {
+				if state.GetPreimageKey().Bytes()[0] == keyType {
 					break
 				}
 			}
@@ -398,7 +420,7 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
-	if err := writeJSON(ctx.Path(RunOutputFlag.Name), state); err != nil {
+	if err := writeState(ctx.Path(RunOutputFlag.Name), state); err != nil {
 		return fmt.Errorf("failed to write state output: %w", err)
 	}
 	return nil
@@ -416,6 +438,7 @@ var RunCommand = &cli.Command{
 		RunProofFmtFlag,
 		RunSnapshotAtFlag,
 		RunSnapshotFmtFlag,
+		RunSnapshotDiffFlag,
 		RunStopAtFlag,
 		RunStopAtPreimageTypeFlag,
 		RunStopAtPreimageLargerThanFlag,
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+// isBinaryStatePath returns true if the state file uses the binary snapshot format.
+// Binary state files have a .bin extension, optionally followed by a .gz or .zst compression extension.
+// All other state files are JSON.
+func isBinaryStatePath(path string) bool {
+	path = strings.TrimSuffix(path, ".gz")
+	path = strings.TrimSuffix(path, ".zst")
+	return strings.HasSuffix(path, ".bin")
+}
+
+// loadState loads a JSON state, or a full binary state snapshot.
+func loadState(path string) (*mipsevm.State, error) {
+	if isBinaryStatePath(path) {
+		return loadBinaryState(path, nil)
+	}
+	return loadJSON[mipsevm.State](path)
+}
+
+// loadBinaryState loads a binary state snapshot. If the snapshot is a diff, it is applied to base.
+func loadBinaryState(path string, base *mipsevm.State) (*mipsevm.State, error) {
+	if path == "" {
+		return nil, errors.New("no path specified")
+	}
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	defer f.Close()
+	state, err := mipsevm.DeserializeState(bufio.NewReader(f), base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file %q: %w", path, err)
+	}
+	return state, nil
+}
+
+// writeState writes the state as JSON, or as binary snapshot if the path has a binary state extension.
+func writeState(path string, state mipsevm.FPVMState) error {
+	if !isBinaryStatePath(path) {
+		return writeJSON(path, state)
+	}
+	return writeBinary(path, func(w io.Writer) error {
+		return mipsevm.SerializeState(w, state)
+	})
+}
+
+// writeStateDiff writes the state as binary diff snapshot, relative to the given base.
+func writeStateDiff(path string, base *mipsevm.SnapshotBase, state mipsevm.FPVMState) error {
+	if !isBinaryStatePath(path) {
+		return fmt.Errorf("state diffs can only be written in binary format, got path %q", path)
+	}
+	return writeBinary(path, func(w io.Writer) error {
+		return mipsevm.SerializeStateDiff(w, base, state)
+	})
+}
+
+func writeBinary(outputPath string, fn func(w io.Writer) error) error {
+	f, err := ioutil.NewAtomicWriterCompressed(outputPath, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	// Ensure we close the stream even if failures occur.
+	defer f.Close()
+	out := bufio.NewWriter(f)
+	if err := fn(out); err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to flush output: %w", err)
+	}
+	// Closing the file causes it to be renamed to the final destination
+	// so make sure we handle any errors it returns
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to finish write: %w", err)
+	}
+	return nil
+}
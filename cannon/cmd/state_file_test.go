@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func stateFileTestState() *mipsevm.State {
+	state := &mipsevm.State{PC: 0x100, NextPC: 0x104, Step: 7, Memory: mipsevm.NewMemory()}
+	state.Registers[2] = 4246
+	state.Memory.SetMemory(0x100, 0x12345678)
+	state.Memory.SetMemory(0x2000_0000, 0x9abcdef0)
+	return state
+}
+
+func TestIsBinaryStatePath(t *testing.T) {
+	require.True(t, isBinaryStatePath("state.bin"))
+	require.True(t, isBinaryStatePath("state.bin.gz"))
+	require.True(t, isBinaryStatePath("state.bin.zst"))
+	require.False(t, isBinaryStatePath("state.json"))
+	require.False(t, isBinaryStatePath("state.json.gz"))
+	require.False(t, isBinaryStatePath("state.json.zst"))
+	require.False(t, isBinaryStatePath("-"))
+}
+
+func TestRoundTripState(t *testing.T) {
+	for _, name := range []string{"state.json", "state.json.gz", "state.json.zst", "state.bin", "state.bin.gz", "state.bin.zst"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			state := stateFileTestState()
+			require.NoError(t, writeState(path, state))
+
+			result, err := loadState(path)
+			require.NoError(t, err)
+			require.Equal(t, state.EncodeWitness(), result.EncodeWitness())
+			require.Equal(t, state.Memory.MerkleRoot(), result.GetMemory().MerkleRoot())
+		})
+	}
+
+}
+
+func TestStateDiff(t *testing.T) {
+	dir := t.TempDir()
+	basePath := filepath.Join(dir, "base.json")
+	diffPath := filepath.Join(dir, "diff.bin.zst")
+	state := stateFileTestState()
+	require.NoError(t, writeState(basePath, state))
+	base, err := mipsevm.NewSnapshotBase(state)
+	require.NoError(t, err)
+
+	state.Memory.SetMemory(0x104, 0x1)
+	state.Step++
+	require.NoError(t, writeStateDiff(diffPath, base, state))
+	require.ErrorContains(t, writeStateDiff(filepath.Join(dir, "diff.json"), base, state), "binary format")
+
+	_, err = loadState(diffPath)
+	require.ErrorContains(t, err, "requires a base state")
+
+	baseState, err := loadState(basePath)
+	require.NoError(t, err)
+	result, err := loadBinaryState(diffPath, baseState)
+	require.NoError(t, err)
+	require.Equal(t, state.EncodeWitness(), result.EncodeWitness())
+	require.Equal(t, state.Memory.MerkleRoot(), result.GetMemory().MerkleRoot())
+
+	fullPath := filepath.Join(dir, "full.bin")
+	require.NoError(t, writeState(fullPath, state))
+	diffInfo, err := os.Stat(diffPath)
+	require.NoError(t, err)
+	fullInfo, err := os.Stat(fullPath)
+	require.NoError(t, err)
+	require.Less(t, diffInfo.Size(), fullInfo.Size())
+}
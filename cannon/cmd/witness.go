@@ -3,15 +3,18 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
 
-	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 )
 
 var (
 	WitnessInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input JSON state.",
+		Usage:     "path of input state, JSON or binary snapshot.",
 		TakesFile: true,
 		Required:  true,
 	}
@@ -20,12 +23,47 @@ var (
 		Usage:     "path to write binary witness.",
 		TakesFile: true,
 	}
+	WitnessMemProofFlag = &cli.StringSliceFlag{
+		Name:  "mem-proof",
+		Usage: "4-byte aligned memory address to generate a Merkle proof for, against the memory of the input state. May be repeated.",
+	}
+	WitnessMemProofOutputFlag = &cli.PathFlag{
+		Name:      "mem-proof-output",
+		Usage:     "path to write the JSON memory proofs to. Written to stdout if empty or -.",
+		TakesFile: true,
+	}
 )
 
+// MemoryProofs are the memory proofs of a VM state, at the step of the state.
+type MemoryProofs struct {
+	Step      uint64                 `json:"step"`
+	StateHash common.Hash            `json:"stateHash"`
+	Proofs    []*mipsevm.MemoryProof `json:"proofs"`
+}
+
+func parseMemProofAddrs(values []string) ([]uint32, error) {
+	addrs := make([]uint32, 0, len(values))
+	for _, v := range values {
+		addr, err := strconv.ParseUint(v, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid memory address %q: %w", v, err)
+		}
+		if addr&3 != 0 {
+			return nil, fmt.Errorf("memory address %q is not 4-byte aligned", v)
+		}
+		addrs = append(addrs, uint32(addr))
+	}
+	return addrs, nil
+}
+
 func Witness(ctx *cli.Context) error {
 	input := ctx.Path(WitnessInputFlag.Name)
 	output := ctx.Path(WitnessOutputFlag.Name)
-	state, err := loadJSON[mipsevm.State](input)
+	memProofAddrs, err := parseMemProofAddrs(ctx.StringSlice(WitnessMemProofFlag.Name))
+	if err != nil {
+		return err
+	}
+	state, err := loadState(input)
 	if err != nil {
 		return fmt.Errorf("invalid input state (%v): %w", input, err)
 	}
@@ -40,16 +78,37 @@ func Witness(ctx *cli.Context) error {
 		}
 	}
 	fmt.Println(h.Hex())
+	if len(memProofAddrs) == 0 {
+		return nil
+	}
+	proofs := &MemoryProofs{Step: state.GetStep(), StateHash: h}
+	for _, addr := range memProofAddrs {
+		proof, err := state.GetMemory().ProveWord(addr)
+		if err != nil {
+			return fmt.Errorf("failed to prove memory at %08x: %w", addr, err)
+		}
+		proofs.Proofs = append(proofs.Proofs, proof)
+	}
+	proofOutput := ctx.Path(WitnessMemProofOutputFlag.Name)
+	if proofOutput == "" {
+		proofOutput = "-"
+	}
+	if err := writeJSON(proofOutput, proofs); err != nil {
+		return fmt.Errorf("failed to write memory proofs: %w", err)
+	}
 	return nil
 }
 
 var WitnessCommand = &cli.Command{
-	Name:        "witness",
-	Usage:       "Convert a Cannon JSON state into a binary witness",
-	Description: "Convert a Cannon JSON state into a binary witness. The hash of the witness is written to stdout",
-	Action:      Witness,
+	Name:  "witness",
+	Usage: "Convert a Cannon state into a binary witness",
+	Description: "Convert a Cannon JSON or binary state into a binary witness. The hash of the witness is written to stdout. " +
+		"Optionally generates Merkle proofs of memory words of the state, to construct step calldata with.",
+	Action: Witness,
 	Flags: []cli.Flag{
 		WitnessInputFlag,
 		WitnessOutputFlag,
+		WitnessMemProofFlag,
+		WitnessMemProofOutputFlag,
 	},
 }
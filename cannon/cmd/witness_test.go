@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemProofAddrs(t *testing.T) {
+	addrs, err := parseMemProofAddrs([]string{"0x1000", "4096", "0xfffffffc"})
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0x1000, 4096, 0xfffffffc}, addrs)
+
+	_, err = parseMemProofAddrs([]string{"0x1001"})
+	require.ErrorContains(t, err, "not 4-byte aligned")
+	_, err = parseMemProofAddrs([]string{"0x100000000"})
+	require.ErrorContains(t, err, "invalid memory address")
+	_, err = parseMemProofAddrs([]string{"abc"})
+	require.ErrorContains(t, err, "invalid memory address")
+}
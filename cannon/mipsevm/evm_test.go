@@ -58,6 +58,13 @@ func (m *MIPSEVM) SetTracer(tracer vm.EVMLogger) {
 
 // Step is a pure function that computes the poststate from the VM state encoded in the StepWitness.
 func (m *MIPSEVM) Step(t *testing.T, stepWitness *StepWitness) []byte {
+	evmPost, err := m.TryStep(t, stepWitness)
+	require.NoError(t, err, "evm should not fail")
+	return evmPost
+}
+
+// TryStep is like Step, but returns an error instead of failing the test if the step reverts.
+func (m *MIPSEVM) TryStep(t *testing.T, stepWitness *StepWitness) ([]byte, error) {
 	sender := common.Address{0x13, 0x37}
 	startingGas := uint64(30_000_000)
 
@@ -74,7 +81,10 @@ func (m *MIPSEVM) Step(t *testing.T, stepWitness *StepWitness) []byte {
 
 	input := encodeStepInput(t, stepWitness, LocalContext{})
 	ret, leftOverGas, err := m.env.Call(vm.AccountRef(sender), m.addrs.MIPS, input, startingGas, big.NewInt(0))
-	require.NoError(t, err, "evm should not fail")
+	if err != nil {
+		m.env.StateDB.RevertToSnapshot(snap)
+		return nil, err
+	}
 	require.Len(t, ret, 32, "expecting 32-byte state hash")
 	// remember state hash, to check it against state
 	postHash := common.Hash(*(*[32]byte)(ret))
@@ -88,7 +98,7 @@ func (m *MIPSEVM) Step(t *testing.T, stepWitness *StepWitness) []byte {
 
 	m.env.StateDB.RevertToSnapshot(snap)
 	t.Logf("EVM step took %d gas, and returned stateHash %s", startingGas-leftOverGas, postHash)
-	return evmPost
+	return evmPost, nil
 }
 
 func encodeStepInput(t *testing.T, wit *StepWitness, localContext LocalContext) []byte {
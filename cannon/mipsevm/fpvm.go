@@ -0,0 +1,42 @@
+package mipsevm
+
+import "github.com/ethereum/go-ethereum/common"
+
+// FPVMState is the state of a fault proof VM.
+type FPVMState interface {
+	GetMemory() *Memory
+
+	GetPC() uint32
+
+	GetStep() uint64
+
+	GetExited() bool
+
+	GetPreimageKey() common.Hash
+
+	GetPreimageOffset() uint32
+
+	VMStatus() uint8
+
+	// EncodeWitness returns the witness of the state, of which the hash is the state commitment.
+	EncodeWitness() StateWitness
+}
+
+var _ FPVMState = (*State)(nil)
+
+// FPVM is an instrumented fault proof VM, which executes a program step by step.
+type FPVM interface {
+	// GetState returns the state of the VM, which is updated in place by Step.
+	GetState() FPVMState
+
+	// Step executes a single step of the program, and returns the witness of the step if proof is set.
+	Step(proof bool) (*StepWitness, error)
+
+	// LastPreimage returns the last pre-image read from the pre-image oracle, including the 8-byte length prefix.
+	LastPreimage() []byte
+
+	// LastMemAccess returns the aligned address of the memory accessed by the last step, if any.
+	LastMemAccess() (addr uint32, ok bool)
+}
+
+var _ FPVM = (*InstrumentedState)(nil)
@@ -0,0 +1,153 @@
+package mipsevm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+// The instruction fuzzer differentially tests random instruction sequences:
+// every step executed by the Go VM is replayed with the onchain MIPS.sol implementation,
+// and the post-states must be identical. A step that fails in the Go VM must revert onchain, and vice versa.
+//
+// Syscalls are not generated, these are covered by the syscall specific fuzz tests.
+//
+// Failing inputs are written to testdata/fuzz/FuzzStateInstructions by `go test -fuzz`,
+// and should be committed alongside the fix, so they are replayed as regression tests by every `go test` run.
+// Run the fuzzer with `make fuzz`, or for longer with:
+//
+//	go test -run NOTAREALTEST -fuzztime 10m -fuzz=FuzzStateInstructions ./mipsevm
+
+const fuzzMaxInstructions = 64
+
+var (
+	fuzzOpcodes = []uint32{
+		0x00,       // special, see fuzzSpecialFuncts
+		0x01,       // regimm: bltz, bgez
+		0x02, 0x03, // j, jal
+		0x04, 0x05, 0x06, 0x07, // beq, bne, blez, bgtz
+		0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, // addi, addiu, slti, sltiu, andi, ori, xori, lui
+		0x1c,                                     // special2, see fuzzSpecial2Functs
+		0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, // lb, lh, lwl, lw, lbu, lhu, lwr
+		0x28, 0x29, 0x2a, 0x2b, 0x2e, // sb, sh, swl, sw, swr
+		0x30, 0x38, // ll, sc
+	}
+	fuzzSpecialFuncts = []uint32{
+		0x00, 0x02, 0x03, 0x04, 0x06, 0x07, // sll, srl, sra, sllv, srlv, srav
+		0x08, 0x09, 0x0a, 0x0b, 0x0f, // jr, jalr, movz, movn, sync
+		0x10, 0x11, 0x12, 0x13, // mfhi, mthi, mflo, mtlo
+		0x18, 0x19, 0x1a, 0x1b, // mult, multu, div, divu
+		0x20, 0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, // add, addu, sub, subu, and, or, xor, nor
+		0x2a, 0x2b, // slt, sltu
+	}
+	fuzzSpecial2Functs = []uint32{0x02, 0x20, 0x21} // mul, clz, clo
+)
+
+// fuzzInstruction shapes a random word into a supported, non-syscall instruction.
+// The opcode and function are picked from the supported instructions, the operand bits are kept as-is.
+func fuzzInstruction(w uint32) uint32 {
+	opcode := fuzzOpcodes[(w>>26)%uint32(len(fuzzOpcodes))]
+	insn := opcode<<26 | w&0x03_ff_ff_ff
+	switch opcode {
+	case 0x00:
+		insn = insn&^0x3f | fuzzSpecialFuncts[(w&0x3f)%uint32(len(fuzzSpecialFuncts))]
+	case 0x01:
+		insn &^= 0x1e << 16 // rt selects bltz (0) or bgez (1)
+	case 0x1c:
+		insn = insn&^0x3f | fuzzSpecial2Functs[(w&0x3f)%uint32(len(fuzzSpecial2Functs))]
+	}
+	return insn
+}
+
+// fuzzInstructionsState deterministically builds the pre-state for the fuzzed program.
+// Registers, HI/LO and the memory the registers point to are randomized with the seed.
+func fuzzInstructionsState(seed int64, program []byte) *State {
+	rng := rand.New(rand.NewSource(seed))
+	pc := rng.Uint32() & 0x7f_ff_ff_fc
+	state := &State{
+		PC:     pc,
+		NextPC: pc + 4,
+		LO:     rng.Uint32(),
+		HI:     rng.Uint32(),
+		Step:   uint64(rng.Uint32()),
+		Memory: NewMemory(),
+	}
+	for i := 1; i < 32; i++ {
+		switch rng.Intn(4) {
+		case 0: // small values, for shifts, comparisons and short loops
+			state.Registers[i] = uint32(rng.Intn(64))
+		case 1: // addresses near the program
+			state.Registers[i] = pc + uint32(rng.Intn(4*fuzzMaxInstructions))
+		default:
+			state.Registers[i] = rng.Uint32()
+		}
+		// make the memory that registers point to non-zero, for loads to read
+		state.Memory.SetMemory(state.Registers[i]&^3, rng.Uint32())
+	}
+	for i := 0; i+4 <= len(program) && i < 4*fuzzMaxInstructions; i += 4 {
+		state.Memory.SetMemory(pc+uint32(i), fuzzInstruction(binary.BigEndian.Uint32(program[i:])))
+	}
+	return state
+}
+
+// fuzzStep runs the step, and turns panics of the VM on invalid instructions into errors.
+func fuzzStep(step func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return step()
+}
+
+func FuzzStateInstructions(f *testing.F) {
+	contracts, addrs := testContractsSetup(f)
+	for i := int64(0); i < 16; i++ {
+		program := make([]byte, 4*32)
+		rand.New(rand.NewSource(i)).Read(program)
+		f.Add(i, program)
+	}
+	f.Fuzz(func(t *testing.T, seed int64, program []byte) {
+		state := fuzzInstructionsState(seed, program)
+		goState := NewInstrumentedState(state, nil, os.Stdout, os.Stderr)
+		evm := NewMIPSEVM(contracts, addrs)
+
+		steps := len(program) / 4
+		if steps > fuzzMaxInstructions {
+			steps = fuzzMaxInstructions
+		}
+		for i := 0; i < steps; i++ {
+			// the PC may be unaligned after a jump to a register, which the VM must reject
+			insn := state.Memory.GetMemory(state.PC &^ 3)
+			preState := state.EncodeWitness()
+			insnProof := state.Memory.MerkleProof(state.PC &^ 3)
+			var stepWitness *StepWitness
+			goErr := fuzzStep(func() (err error) {
+				stepWitness, err = goState.Step(true)
+				return err
+			})
+			if goErr != nil {
+				// The witness of a failing step is not returned, so build it from the pre-state,
+				// and the memory proof that was buffered before the step failed, if any.
+				stepWitness = &StepWitness{
+					State:    preState,
+					MemProof: append(insnProof[:], goState.memProof[:]...),
+				}
+				_, evmErr := evm.TryStep(t, stepWitness)
+				require.Errorf(t, evmErr, "EVM must fail like the Go VM on instruction %08x: %v", insn, goErr)
+				return
+			}
+
+			evmPost, evmErr := evm.TryStep(t, stepWitness)
+			require.NoErrorf(t, evmErr, "EVM failed on instruction %08x, but the Go VM did not", insn)
+			goPost := state.EncodeWitness()
+			require.Equalf(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				"mipsevm produced different state than EVM on instruction %08x", insn)
+		}
+	})
+}
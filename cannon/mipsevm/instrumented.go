@@ -84,3 +84,7 @@ func (m *InstrumentedState) Step(proof bool) (wit *StepWitness, err error) {
 func (m *InstrumentedState) LastPreimage() []byte {
 	return m.lastPreimage
 }
+
+func (m *InstrumentedState) GetState() FPVMState {
+	return m.state
+}
@@ -0,0 +1,78 @@
+package mipsevm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// MemoryProofSize is the size of a memory proof: the 32-byte leaf, followed by 27 sibling nodes.
+const MemoryProofSize = 28 * 32
+
+var ErrInvalidMemoryProof = errors.New("invalid memory proof")
+
+// MemoryProof is a Merkle proof of the 32-bit word at Addr, against the memory root of a VM state.
+type MemoryProof struct {
+	Addr  uint32      `json:"addr"`
+	Value uint32      `json:"value"`
+	Root  common.Hash `json:"root"`
+	// Proof is the 32-byte leaf that contains the word, followed by the sibling nodes from the leaf up to the root.
+	// This is the encoding of the memory proofs of the step calldata of MIPS.sol.
+	Proof hexutil.Bytes `json:"proof"`
+}
+
+// ProveWord creates a Merkle proof of the 32-bit word at the given address, which must be 4-byte aligned.
+// Unallocated memory is proven to be zero.
+func (m *Memory) ProveWord(addr uint32) (*MemoryProof, error) {
+	if addr&3 != 0 {
+		return nil, fmt.Errorf("unaligned memory address %08x", addr)
+	}
+	proof := m.MerkleProof(addr)
+	return &MemoryProof{
+		Addr:  addr,
+		Value: m.GetMemory(addr),
+		Root:  m.MerkleRoot(),
+		Proof: proof[:],
+	}, nil
+}
+
+// Verify checks that the proof is valid for its address, value and root.
+func (p *MemoryProof) Verify() error {
+	value, err := VerifyMemoryProof(p.Root, p.Addr, p.Proof)
+	if err != nil {
+		return err
+	}
+	if value != p.Value {
+		return fmt.Errorf("%w: proven value %08x does not match %08x", ErrInvalidMemoryProof, value, p.Value)
+	}
+	return nil
+}
+
+// VerifyMemoryProof verifies the proof of the word at the 4-byte aligned address against the memory root,
+// and returns the proven value of the word.
+func VerifyMemoryProof(root common.Hash, addr uint32, proof []byte) (uint32, error) {
+	if addr&3 != 0 {
+		return 0, fmt.Errorf("unaligned memory address %08x", addr)
+	}
+	if len(proof) != MemoryProofSize {
+		return 0, fmt.Errorf("%w: expected %d bytes, got %d", ErrInvalidMemoryProof, MemoryProofSize, len(proof))
+	}
+	node := *(*[32]byte)(proof[:32])
+	path := addr >> 5
+	for i := 32; i < len(proof); i += 32 {
+		sibling := *(*[32]byte)(proof[i : i+32])
+		if path&1 != 0 {
+			node = HashPair(sibling, node)
+		} else {
+			node = HashPair(node, sibling)
+		}
+		path >>= 1
+	}
+	if node != root {
+		return 0, fmt.Errorf("%w: computed root %s does not match %s", ErrInvalidMemoryProof, common.Hash(node), root)
+	}
+	return binary.BigEndian.Uint32(proof[addr&31:]), nil
+}
@@ -0,0 +1,60 @@
+package mipsevm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryProveWord(t *testing.T) {
+	m := NewMemory()
+	m.SetMemory(0x10000, 0xaabbccdd)
+	m.SetMemory(0x80004, 42)
+	m.SetMemory(0x13370000, 123)
+
+	for _, addr := range []uint32{0x10000, 0x80004, 0x80008, 0x13370000, 0x7fff_fffc, 0xffff_fffc} {
+		proof, err := m.ProveWord(addr)
+		require.NoError(t, err)
+		require.Equal(t, addr, proof.Addr)
+		require.Equal(t, m.GetMemory(addr), proof.Value)
+		require.Equal(t, common.Hash(m.MerkleRoot()), proof.Root)
+		require.Len(t, proof.Proof, MemoryProofSize)
+		require.NoError(t, proof.Verify(), "proof of %08x must verify", addr)
+	}
+
+	t.Run("unaligned", func(t *testing.T) {
+		_, err := m.ProveWord(0x10001)
+		require.ErrorContains(t, err, "unaligned")
+	})
+
+	t.Run("wrong value", func(t *testing.T) {
+		proof, err := m.ProveWord(0x80004)
+		require.NoError(t, err)
+		proof.Value = 43
+		require.ErrorIs(t, proof.Verify(), ErrInvalidMemoryProof)
+	})
+
+	t.Run("wrong address", func(t *testing.T) {
+		proof, err := m.ProveWord(0x80004)
+		require.NoError(t, err)
+		proof.Addr = 0x10004 // same offset in the leaf, different path
+		require.ErrorIs(t, proof.Verify(), ErrInvalidMemoryProof)
+	})
+
+	t.Run("stale root", func(t *testing.T) {
+		proof, err := m.ProveWord(0x80004)
+		require.NoError(t, err)
+		m.SetMemory(0x80008, 1)
+		require.NoError(t, proof.Verify(), "proof is against the root at the time of proving")
+		proof.Root = m.MerkleRoot()
+		require.ErrorIs(t, proof.Verify(), ErrInvalidMemoryProof)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		proof, err := m.ProveWord(0x80004)
+		require.NoError(t, err)
+		proof.Proof = proof.Proof[:MemoryProofSize-32]
+		require.ErrorIs(t, proof.Verify(), ErrInvalidMemoryProof)
+	})
+}
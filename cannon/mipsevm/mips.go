@@ -33,13 +33,22 @@ func (m *InstrumentedState) readPreimage(key [32]byte, offset uint32) (dat [32]b
 }
 
 func (m *InstrumentedState) trackMemAccess(effAddr uint32) {
-	if m.memProofEnabled && m.lastMemAccess != effAddr {
+	if m.lastMemAccess == effAddr {
+		return
+	}
+	if m.memProofEnabled {
 		if m.lastMemAccess != ^uint32(0) {
 			panic(fmt.Errorf("unexpected different mem access at %08x, already have access at %08x buffered", effAddr, m.lastMemAccess))
 		}
-		m.lastMemAccess = effAddr
 		m.memProof = m.state.Memory.MerkleProof(effAddr)
 	}
+	m.lastMemAccess = effAddr
+}
+
+// LastMemAccess returns the aligned address of the memory accessed by the last step, if any.
+// Instruction fetches are not included.
+func (m *InstrumentedState) LastMemAccess() (addr uint32, ok bool) {
+	return m.lastMemAccess, m.lastMemAccess != ^uint32(0)
 }
 
 func (m *InstrumentedState) handleSyscall() error {
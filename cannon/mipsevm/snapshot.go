@@ -0,0 +1,244 @@
+package mipsevm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Binary snapshots are a compact alternative to the JSON encoding of a VM state.
+// A snapshot is encoded as:
+//
+//	magic (4 bytes) | version (1 byte) | kind (1 byte) | [base state hash (32 bytes), diff snapshots only]
+//	| state length (4 bytes) | state, JSON encoded without memory
+//	| page count (4 bytes) | page count * (page index (4 bytes) | page data (PageSize bytes))
+//
+// All integers are big-endian. Pages are ordered by page index.
+// A diff snapshot only contains the pages that changed since the base state it was created against.
+// Memory pages are never freed, so a diff never has to remove pages from the base state.
+var snapshotMagic = [4]byte{'C', 'N', 'S', 'S'}
+
+const snapshotVersion = 1
+
+// SnapshotKind identifies whether a binary snapshot is a full state or a diff.
+type SnapshotKind uint8
+
+const (
+	SnapshotKindFull SnapshotKind = iota
+	SnapshotKindDiff
+)
+
+func (k SnapshotKind) IsDiff() bool {
+	return k == SnapshotKindDiff
+}
+
+// maxSnapshotStateLen bounds the JSON encoded non-memory state, to not allocate arbitrary amounts of memory on bad input.
+const maxSnapshotStateLen = 1 << 24
+
+var ErrSnapshotBaseMismatch = errors.New("diff snapshot does not apply to the base state")
+
+// SnapshotBase is the reference a diff snapshot is created against.
+// It only retains the state hash and memory page roots of the base state,
+// so the base state itself can continue to be modified in place after the base was taken.
+type SnapshotBase struct {
+	StateHash common.Hash
+	PageRoots map[uint32][32]byte
+}
+
+// NewSnapshotBase captures the state hash and page roots of the given state.
+func NewSnapshotBase(state FPVMState) (*SnapshotBase, error) {
+	stateHash, err := state.EncodeWitness().StateHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute state hash: %w", err)
+	}
+	return &SnapshotBase{
+		StateHash: stateHash,
+		PageRoots: state.GetMemory().PageRoots(),
+	}, nil
+}
+
+// PageRoots returns the merkle root of every allocated page, by page index.
+func (m *Memory) PageRoots() map[uint32][32]byte {
+	roots := make(map[uint32][32]byte, len(m.pages))
+	for k, p := range m.pages {
+		roots[k] = p.MerkleRoot()
+	}
+	return roots
+}
+
+// setPage overwrites the contents of a page, allocating it if it does not exist yet.
+func (m *Memory) setPage(pageIndex uint32, data *Page) {
+	// AllocPage replaces any existing page, so the lookup cache may not hold on to the old one.
+	m.lastPageKeys = [2]uint32{^uint32(0), ^uint32(0)}
+	m.lastPage = [2]*CachedPage{nil, nil}
+	m.AllocPage(pageIndex).Data = data
+}
+
+// SerializeState writes the full state as a binary snapshot.
+func SerializeState(w io.Writer, state FPVMState) error {
+	return writeSnapshot(w, SnapshotKindFull, common.Hash{}, state, nil)
+}
+
+// SerializeStateDiff writes the state as a binary diff snapshot,
+// containing only the memory pages that changed since the base was taken.
+func SerializeStateDiff(w io.Writer, base *SnapshotBase, state FPVMState) error {
+	return writeSnapshot(w, SnapshotKindDiff, base.StateHash, state, base.PageRoots)
+}
+
+// DeserializeState reads a binary snapshot.
+// Diff snapshots are applied to base, which must be the state the diff was created against.
+// The memory of base is updated in place and shared with the returned state.
+// The base may be nil when reading a full snapshot.
+func DeserializeState(r io.Reader, base *State) (*State, error) {
+	var header [6]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot header: %w", err)
+	}
+	if !bytes.Equal(header[:4], snapshotMagic[:]) {
+		return nil, errors.New("not a binary state snapshot")
+	}
+	if header[4] != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", header[4])
+	}
+	kind := SnapshotKind(header[5])
+	if kind > SnapshotKindDiff {
+		return nil, fmt.Errorf("unknown snapshot kind %d", kind)
+	}
+
+	var mem *Memory
+	if kind.IsDiff() {
+		var baseHash common.Hash
+		if _, err := io.ReadFull(r, baseHash[:]); err != nil {
+			return nil, fmt.Errorf("failed to read base state hash: %w", err)
+		}
+		if base == nil {
+			return nil, errors.New("diff snapshot requires a base state")
+		}
+		actualHash, err := base.EncodeWitness().StateHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute base state hash: %w", err)
+		}
+		if actualHash != baseHash {
+			return nil, fmt.Errorf("%w: expected base %s, got %s", ErrSnapshotBaseMismatch, baseHash, actualHash)
+		}
+		mem = base.GetMemory()
+	} else {
+		mem = NewMemory()
+	}
+
+	var stateLen uint32
+	if err := binary.Read(r, binary.BigEndian, &stateLen); err != nil {
+		return nil, fmt.Errorf("failed to read state length: %w", err)
+	}
+	if stateLen > maxSnapshotStateLen {
+		return nil, fmt.Errorf("state length %d exceeds limit", stateLen)
+	}
+	stateData := make([]byte, stateLen)
+	if _, err := io.ReadFull(r, stateData); err != nil {
+		return nil, fmt.Errorf("failed to read state: %w", err)
+	}
+	var state State
+	if err := json.Unmarshal(stateData, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+	state.Memory = mem
+
+	var pageCount uint32
+	if err := binary.Read(r, binary.BigEndian, &pageCount); err != nil {
+		return nil, fmt.Errorf("failed to read page count: %w", err)
+	}
+	// Pages are read into a separate map first, so a truncated diff does not leave the base memory half-updated.
+	pages := make(map[uint32]*Page)
+	for i := uint32(0); i < pageCount; i++ {
+		var pageIndex uint32
+		if err := binary.Read(r, binary.BigEndian, &pageIndex); err != nil {
+			return nil, fmt.Errorf("failed to read index of page entry %d: %w", i, err)
+		}
+		if pageIndex >= 1<<(32-PageAddrSize) {
+			return nil, fmt.Errorf("invalid page index %d in entry %d", pageIndex, i)
+		}
+		if _, ok := pages[pageIndex]; ok {
+			return nil, fmt.Errorf("cannot load duplicate page, entry %d, page index %d", i, pageIndex)
+		}
+		data := new(Page)
+		if _, err := io.ReadFull(r, data[:]); err != nil {
+			return nil, fmt.Errorf("failed to read data of page %d: %w", pageIndex, err)
+		}
+		pages[pageIndex] = data
+	}
+	for pageIndex, data := range pages {
+		mem.setPage(pageIndex, data)
+	}
+	return &state, nil
+}
+
+// stateWithoutMemory returns a shallow copy of the state, with the memory removed, for JSON encoding.
+func stateWithoutMemory(state FPVMState) any {
+	switch s := state.(type) {
+	case *State:
+		cpy := *s
+		cpy.Memory = nil
+		return &cpy
+	default:
+		panic(fmt.Errorf("unsupported state type %T", state))
+	}
+}
+
+// writeSnapshot writes the snapshot. If baseRoots is not nil, pages with a matching root are left out.
+func writeSnapshot(w io.Writer, kind SnapshotKind, baseHash common.Hash, state FPVMState, baseRoots map[uint32][32]byte) error {
+	stateData, err := json.Marshal(stateWithoutMemory(state))
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	mem := state.GetMemory()
+	indices := make([]uint32, 0, mem.PageCount())
+	for pageIndex, p := range mem.pages {
+		if baseRoots != nil {
+			if root, ok := baseRoots[pageIndex]; ok && root == p.MerkleRoot() {
+				continue
+			}
+		}
+		indices = append(indices, pageIndex)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	var buf [4]byte
+	if _, err := w.Write(snapshotMagic[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{snapshotVersion, byte(kind)}); err != nil {
+		return err
+	}
+	if kind.IsDiff() {
+		if _, err := w.Write(baseHash[:]); err != nil {
+			return err
+		}
+	}
+	binary.BigEndian.PutUint32(buf[:], uint32(len(stateData)))
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(stateData); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(buf[:], uint32(len(indices)))
+	if _, err := w.Write(buf[:]); err != nil {
+		return err
+	}
+	for _, pageIndex := range indices {
+		binary.BigEndian.PutUint32(buf[:], pageIndex)
+		if _, err := w.Write(buf[:]); err != nil {
+			return err
+		}
+		if _, err := w.Write(mem.pages[pageIndex].Data[:]); err != nil {
+			return fmt.Errorf("failed to write page %d: %w", pageIndex, err)
+		}
+	}
+	return nil
+}
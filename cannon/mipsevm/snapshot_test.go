@@ -0,0 +1,92 @@
+package mipsevm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func snapshotTestState() *State {
+	st := &State{PC: 0x100, NextPC: 0x104, Heap: 0x2000_0000, Step: 42, Memory: NewMemory()}
+	st.Registers[29] = 0x7fff_f000
+	st.PreimageKey[0] = 2
+	st.PreimageOffset = 8
+	st.LastHint = []byte{0, 0, 0, 3, 'a', 'b'}
+	st.Memory.SetMemory(0x100, 0xaabbccdd)
+	st.Memory.SetMemory(0x1000_0000, 0x11223344)
+	st.Memory.SetMemory(0x7fff_eff0, 0x55667788)
+	return st
+}
+
+func requireSameState(t *testing.T, expected, actual FPVMState) {
+	require.Equal(t, expected.EncodeWitness(), actual.EncodeWitness())
+	require.Equal(t, expected.GetMemory().PageCount(), actual.GetMemory().PageCount())
+	require.Equal(t, expected.GetMemory().PageRoots(), actual.GetMemory().PageRoots())
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	t.Run("full", func(t *testing.T) {
+		st := snapshotTestState()
+		var buf bytes.Buffer
+		require.NoError(t, SerializeState(&buf, st))
+
+		out, err := DeserializeState(&buf, nil)
+		require.NoError(t, err)
+		require.Equal(t, st.LastHint, out.LastHint)
+		requireSameState(t, st, out)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := DeserializeState(bytes.NewReader([]byte(`{"memory":[]}`)), nil)
+		require.ErrorContains(t, err, "not a binary state snapshot")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, SerializeState(&buf, snapshotTestState()))
+		_, err := DeserializeState(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	st := snapshotTestState()
+	var full bytes.Buffer
+	require.NoError(t, SerializeState(&full, st))
+	base, err := NewSnapshotBase(st)
+	require.NoError(t, err)
+
+	// modify one existing page, and allocate a new one
+	st.Memory.SetMemory(0x104, 0x01020304)
+	st.Memory.SetMemory(0x3000_0000, 0x05060708)
+	st.PC = 0x104
+	st.NextPC = 0x108
+	st.Step++
+	var diff bytes.Buffer
+	require.NoError(t, SerializeStateDiff(&diff, base, st))
+	require.Less(t, diff.Len(), full.Len())
+
+	t.Run("apply", func(t *testing.T) {
+		baseState, err := DeserializeState(bytes.NewReader(full.Bytes()), nil)
+		require.NoError(t, err)
+		// read from the base memory first, to populate the page lookup cache that applying the diff has to reset
+		require.Equal(t, uint32(0), baseState.GetMemory().GetMemory(0x104))
+
+		out, err := DeserializeState(bytes.NewReader(diff.Bytes()), baseState)
+		require.NoError(t, err)
+		requireSameState(t, st, out)
+		require.Equal(t, uint32(0x01020304), out.GetMemory().GetMemory(0x104))
+	})
+
+	t.Run("missing base", func(t *testing.T) {
+		_, err := DeserializeState(bytes.NewReader(diff.Bytes()), nil)
+		require.ErrorContains(t, err, "requires a base state")
+	})
+
+	t.Run("wrong base", func(t *testing.T) {
+		_, err := DeserializeState(bytes.NewReader(diff.Bytes()), st)
+		require.ErrorIs(t, err, ErrSnapshotBaseMismatch)
+	})
+
+}
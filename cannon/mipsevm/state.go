@@ -42,6 +42,30 @@ type State struct {
 	LastHint hexutil.Bytes `json:"lastHint,omitempty"`
 }
 
+func (s *State) GetMemory() *Memory {
+	return s.Memory
+}
+
+func (s *State) GetPC() uint32 {
+	return s.PC
+}
+
+func (s *State) GetStep() uint64 {
+	return s.Step
+}
+
+func (s *State) GetExited() bool {
+	return s.Exited
+}
+
+func (s *State) GetPreimageKey() common.Hash {
+	return s.PreimageKey
+}
+
+func (s *State) GetPreimageOffset() uint32 {
+	return s.PreimageOffset
+}
+
 func (s *State) VMStatus() uint8 {
 	return vmStatus(s.Exited, s.ExitCode)
 }
@@ -1,8 +1,10 @@
 package batcher
 
 import (
+	"cmp"
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -49,6 +51,65 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollup
 	}, nil
 }
 
+// newRestoredChannel creates a channel from a pending channel of a persisted queue state, with the given
+// blocks of the channel. Its confirmed frames keep their inclusion blocks, so that the channel still times out.
+// Its other frames are submitted again, since they may have been in flight before the restart.
+func newRestoredChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollupCfg *rollup.Config, pc PendingChannel, blocks []*types.Block) (*channel, error) {
+	confirmed := make(map[txID]eth.BlockID)
+	var frames []frameData
+	for _, f := range pc.Frames {
+		id := frameID{chID: pc.ID, frameNumber: f.Number}
+		if f.InclusionBlock != nil {
+			confirmed[id] = *f.InclusionBlock
+		} else {
+			frames = append(frames, frameData{data: f.Data, id: id})
+		}
+	}
+	cfg.UseBlobs = pc.UseBlobs
+	cb, err := newRestoredChannelBuilder(cfg, *rollupCfg, pc.ID, blocks, frames, len(pc.Frames))
+	if err != nil {
+		return nil, fmt.Errorf("restoring channel: %w", err)
+	}
+	return &channel{
+		log:                   log,
+		metr:                  metr,
+		cfg:                   cfg,
+		channelBuilder:        cb,
+		pendingTransactions:   make(map[txID]txData),
+		confirmedTransactions: confirmed,
+		confirmedTxUpdated:    len(confirmed) > 0,
+	}, nil
+}
+
+// pendingState returns the state of the channel to persist, with all of its frames in order.
+// It returns false if the channel has no blocks.
+func (s *channel) pendingState() (PendingChannel, bool) {
+	blocks := s.channelBuilder.Blocks()
+	if len(blocks) == 0 {
+		return PendingChannel{}, false
+	}
+	pc := PendingChannel{
+		ID:         s.ID(),
+		FirstBlock: eth.ToBlockID(blocks[0]),
+		LastBlock:  eth.ToBlockID(blocks[len(blocks)-1]),
+		UseBlobs:   s.cfg.UseBlobs,
+	}
+	for id, inclusionBlock := range s.confirmedTransactions {
+		inclusionBlock := inclusionBlock
+		pc.Frames = append(pc.Frames, PendingFrame{Number: id.frameNumber, InclusionBlock: &inclusionBlock})
+	}
+	for _, tx := range s.pendingTransactions {
+		pc.Frames = append(pc.Frames, PendingFrame{Number: tx.frame.id.frameNumber, Data: tx.frame.data})
+	}
+	for _, frame := range s.channelBuilder.frames {
+		pc.Frames = append(pc.Frames, PendingFrame{Number: frame.id.frameNumber, Data: frame.data})
+	}
+	slices.SortFunc(pc.Frames, func(a, b PendingFrame) int {
+		return cmp.Compare(a.Number, b.Number)
+	})
+	return pc, true
+}
+
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
 // in the failed transaction.
 func (s *channel) TxFailed(id txID) {
@@ -122,6 +183,17 @@ func (s *channel) updateInclusionBlocks() {
 	s.confirmedTxUpdated = false
 }
 
+// lastInclusionBlock returns the L1 block that the last confirmed tx of the channel was included in.
+func (s *channel) lastInclusionBlock() eth.BlockID {
+	var last eth.BlockID
+	for _, inclusionBlock := range s.confirmedTransactions {
+		if inclusionBlock.Number >= last.Number {
+			last = inclusionBlock
+		}
+	}
+	return last
+}
+
 // pendingChannelIsTimedOut returns true if submitted channel has timed out.
 // A channel has timed out if the difference in L1 Inclusion blocks between
 // the first & last included block is greater than or equal to the channel timeout.
@@ -149,7 +221,7 @@ func (s *channel) ID() derive.ChannelID {
 func (s *channel) NextTxData() txData {
 	frame := s.channelBuilder.NextFrame()
 
-	txdata := txData{frame}
+	txdata := txData{frame: frame, asBlob: s.cfg.UseBlobs}
 	id := txdata.ID()
 
 	s.log.Trace("returning next tx data", "id", id)
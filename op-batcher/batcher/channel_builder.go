@@ -20,6 +20,7 @@ var (
 	ErrChannelTimeoutClose   = errors.New("close to channel timeout")
 	ErrSeqWindowClose        = errors.New("close to sequencer window timeout")
 	ErrTerminated            = errors.New("channel terminated")
+	ErrChannelRestored       = errors.New("channel restored from persisted queue state")
 )
 
 type ChannelFullError struct {
@@ -56,12 +57,21 @@ type ChannelConfig struct {
 	SubSafetyMargin uint64
 	// The maximum byte-size a frame can have.
 	MaxFrameSize uint64
+	// UseBlobs indicates whether the channel's frames are submitted in blobs instead of calldata.
+	UseBlobs bool
 
 	// CompressorConfig contains the configuration for creating new compressors.
 	CompressorConfig compressor.Config
 
 	// BatchType indicates whether the channel uses SingularBatch or SpanBatch.
 	BatchType uint
+
+	// MaxPendingChannels is the maximum number of channels that are open or have frames left to submit.
+	// If greater than 0, new blocks keep being added to channels while the frames of earlier channels
+	// are submitted, so compression does not hold up submission. The limit bounds the memory used by channels.
+	//
+	// If 0, blocks are only added to a channel once all the frames built so far are submitted.
+	MaxPendingChannels uint64
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -124,6 +134,8 @@ type channelBuilder struct {
 	fullErr error
 	// current channel
 	co derive.ChannelOut
+	// compressor of the channel out
+	compressor derive.Compressor
 	// list of blocks in the channel. Saved in case the channel must be rebuilt
 	blocks []*types.Block
 	// frames data queue, to be send as txs
@@ -151,12 +163,42 @@ func newChannelBuilder(cfg ChannelConfig, rollupCfg rollup.Config) (*channelBuil
 	}
 
 	return &channelBuilder{
-		cfg:       cfg,
-		rollupCfg: rollupCfg,
-		co:        co,
+		cfg:        cfg,
+		rollupCfg:  rollupCfg,
+		co:         co,
+		compressor: c,
 	}, nil
 }
 
+// newRestoredChannelBuilder creates a closed channel builder for a channel restored from a persisted queue state.
+// All frames of the channel were output before the restart. Only the given frames are left to submit.
+func newRestoredChannelBuilder(cfg ChannelConfig, rollupCfg rollup.Config, id derive.ChannelID, blocks []*types.Block, frames []frameData, numFrames int) (*channelBuilder, error) {
+	c, err := newChannelBuilder(cfg, rollupCfg)
+	if err != nil {
+		return nil, err
+	}
+	c.co = &restoredChannelOut{ChannelOut: c.co, id: id}
+	c.blocks = blocks
+	c.frames = frames
+	c.numFrames = numFrames
+	for _, frame := range frames {
+		c.outputBytes += len(frame.data)
+	}
+	c.setFullErr(ErrChannelRestored)
+	return c, nil
+}
+
+// restoredChannelOut is the channel out of a restored channel. It has the ID of the persisted channel,
+// but none of its data, since all frames were output before the restart.
+type restoredChannelOut struct {
+	derive.ChannelOut
+	id derive.ChannelID
+}
+
+func (co *restoredChannelOut) ID() derive.ChannelID {
+	return co.id
+}
+
 func (c *channelBuilder) ID() derive.ChannelID {
 	return c.co.ID()
 }
@@ -176,6 +218,19 @@ func (c *channelBuilder) OutputBytes() int {
 	return c.outputBytes
 }
 
+// CompressionComparison returns the alternative compression algorithm that the channel data is
+// compressed with for comparison, and the size of its output. It returns false if the channel
+// doesn't compare compression algorithms, or if the alternative compression failed.
+// The size is only final once the channel is full.
+func (c *channelBuilder) CompressionComparison() (derive.CompressionAlgo, int, bool) {
+	cc, ok := c.compressor.(*compressor.ComparingCompressor)
+	if !ok {
+		return "", 0, false
+	}
+	algo, size, err := cc.Comparison()
+	return algo, size, err == nil
+}
+
 // Blocks returns a backup list of all blocks that were added to the channel. It
 // can be used in case the channel needs to be rebuilt.
 func (c *channelBuilder) Blocks() []*types.Block {
@@ -316,7 +371,8 @@ func (c *channelBuilder) IsFull() bool {
 //   - ErrMaxDurationReached if the max channel duration got reached,
 //   - ErrChannelTimeoutClose if the consensus channel timeout got too close,
 //   - ErrSeqWindowClose if the end of the sequencer window got too close,
-//   - ErrTerminated if the channel was explicitly terminated.
+//   - ErrTerminated if the channel was explicitly terminated,
+//   - ErrChannelRestored if the channel was closed before a restart.
 func (c *channelBuilder) FullErr() error {
 	return c.fullErr
 }
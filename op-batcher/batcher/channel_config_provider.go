@@ -0,0 +1,189 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// daSwitchThreshold is the percentage by which the data availability type that is not currently in
+// use must be cheaper before the batcher switches to it. This avoids flapping between blobs and
+// calldata when their costs are close.
+const daSwitchThreshold = 10
+
+// ChannelConfigProvider provides the config for each new channel.
+type ChannelConfigProvider interface {
+	ChannelConfig() ChannelConfig
+	// ChannelConfigApplied is called with the config that a new channel was created with.
+	// It differs from the provided config while the channel manager defers switching the data availability type.
+	ChannelConfigApplied(cfg ChannelConfig)
+}
+
+// ChannelConfig implements [ChannelConfigProvider] by always providing the static config itself.
+func (cc ChannelConfig) ChannelConfig() ChannelConfig {
+	return cc
+}
+
+func (cc ChannelConfig) ChannelConfigApplied(ChannelConfig) {}
+
+type GasPricer interface {
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// DynamicEthChannelConfig provides a blob or calldata channel config for each new channel,
+// depending on which data availability type is estimated to be cheaper at the current L1 fees.
+type DynamicEthChannelConfig struct {
+	log       log.Logger
+	metr      metrics.Metricer
+	timeout   time.Duration // query timeout
+	gasPricer GasPricer
+
+	blobConfig     ChannelConfig
+	calldataConfig ChannelConfig
+
+	// mu guards lastConfig and the last estimated costs. It is not held while querying L1.
+	mu         sync.Mutex
+	lastConfig *ChannelConfig
+	// blobPerByte and calldataPerByte are the last estimated costs per byte of each data availability type.
+	blobPerByte     float64
+	calldataPerByte float64
+}
+
+func NewDynamicEthChannelConfig(lgr log.Logger, metr metrics.Metricer, reqTimeout time.Duration, gasPricer GasPricer,
+	blobConfig ChannelConfig, calldataConfig ChannelConfig,
+) *DynamicEthChannelConfig {
+	blobConfig.UseBlobs = true
+	calldataConfig.UseBlobs = false
+	return &DynamicEthChannelConfig{
+		log:            lgr,
+		metr:           metr,
+		timeout:        reqTimeout,
+		gasPricer:      gasPricer,
+		blobConfig:     blobConfig,
+		calldataConfig: calldataConfig,
+	}
+}
+
+// ChannelConfig returns the config of the data availability type with the lower estimated cost per byte.
+// It only switches away from the last applied config if the alternative is cheaper by more than
+// daSwitchThreshold percent. If the L1 fees cannot be queried, the last applied config is reused.
+// It queries L1, so callers shouldn't hold any locks when calling it.
+func (dec *DynamicEthChannelConfig) ChannelConfig() ChannelConfig {
+	ctx, cancel := context.WithTimeout(context.Background(), dec.timeout)
+	defer cancel()
+	tipCap, err := dec.gasPricer.SuggestGasTipCap(ctx)
+	if err != nil {
+		dec.log.Warn("Error querying gas tip cap, returning last config", "err", err)
+		return dec.fallbackConfig()
+	}
+	head, err := dec.gasPricer.HeaderByNumber(ctx, nil)
+	if err != nil {
+		dec.log.Warn("Error querying L1 head, returning last config", "err", err)
+		return dec.fallbackConfig()
+	}
+
+	dec.mu.Lock()
+	defer dec.mu.Unlock()
+	if head.BaseFee == nil || head.ExcessBlobGas == nil {
+		dec.log.Warn("L1 head has no base fee or blob base fee, using calldata channel config")
+		dec.blobPerByte, dec.calldataPerByte = 0, 0
+		return dec.calldataConfig
+	}
+	blobBaseFee := eip4844.CalcBlobFee(*head.ExcessBlobGas)
+
+	// The costs are estimated for a transaction carrying a single full frame. Compressed channel
+	// data has few zero bytes, so all calldata bytes are priced as non-zero bytes.
+	calldataPrice := new(big.Int).Add(head.BaseFee, tipCap)
+	calldataBytes := dec.calldataConfig.MaxFrameSize + 1 // + 1 version byte
+	calldataGas := big.NewInt(int64(calldataBytes*params.TxDataNonZeroGasEIP2028 + params.TxGas))
+	calldataCost := new(big.Int).Mul(calldataGas, calldataPrice)
+
+	// Blob transactions still pay the intrinsic gas at the calldata price.
+	blobBytes := uint64(eth.MaxBlobDataSize)
+	blobCost := new(big.Int).Mul(big.NewInt(params.BlobTxBlobGasPerBlob), blobBaseFee)
+	blobCost.Add(blobCost, new(big.Int).Mul(big.NewInt(int64(params.TxGas)), calldataPrice))
+
+	// Compare the costs per byte, blobCost/blobBytes against calldataCost/calldataBytes.
+	blobPerByte := new(big.Float).Quo(new(big.Float).SetInt(blobCost), new(big.Float).SetUint64(blobBytes))
+	calldataPerByte := new(big.Float).Quo(new(big.Float).SetInt(calldataCost), new(big.Float).SetUint64(calldataBytes))
+	blobF, _ := blobPerByte.Float64()
+	calldataF, _ := calldataPerByte.Float64()
+	dec.blobPerByte, dec.calldataPerByte = blobF, calldataF
+
+	useBlobs := dec.useBlobs(calldataF, blobF)
+	dec.log.Info("Selected channel config by L1 fees", "use_blobs", useBlobs,
+		"base_fee", head.BaseFee, "blob_base_fee", blobBaseFee, "tip_cap", tipCap,
+		"calldata_cost", calldataCost, "calldata_bytes", calldataBytes,
+		"blob_cost", blobCost, "blob_bytes", blobBytes, "estimated_savings", dec.savings(useBlobs))
+	if useBlobs {
+		return dec.blobConfig
+	}
+	return dec.calldataConfig
+}
+
+// useBlobs returns whether blobs should be used, given the estimated costs per byte.
+// The lock must be held when calling this method.
+// A different data availability type than the last selected one is only used if
+// it is cheaper by more than daSwitchThreshold percent.
+func (dec *DynamicEthChannelConfig) useBlobs(calldataPerByte, blobPerByte float64) bool {
+	useBlobs := blobPerByte < calldataPerByte
+	if dec.lastConfig == nil || dec.lastConfig.UseBlobs == useBlobs {
+		return useBlobs
+	}
+	current, alternative := calldataPerByte, blobPerByte
+	if dec.lastConfig.UseBlobs {
+		current, alternative = blobPerByte, calldataPerByte
+	}
+	if alternative*(100+daSwitchThreshold) < current*100 {
+		return useBlobs
+	}
+	return dec.lastConfig.UseBlobs
+}
+
+// ChannelConfigApplied records the data availability type of cfg as the one in use, and reports its
+// estimated savings compared to the other type.
+func (dec *DynamicEthChannelConfig) ChannelConfigApplied(cfg ChannelConfig) {
+	dec.mu.Lock()
+	defer dec.mu.Unlock()
+	if dec.lastConfig != nil && dec.lastConfig.UseBlobs != cfg.UseBlobs {
+		dec.log.Info("Switching data availability type", "use_blobs", cfg.UseBlobs)
+	}
+	dec.lastConfig = &dec.calldataConfig
+	if cfg.UseBlobs {
+		dec.lastConfig = &dec.blobConfig
+	}
+	dec.metr.RecordDAType(cfg.UseBlobs, dec.savings(cfg.UseBlobs))
+}
+
+// savings returns the last estimated relative savings of using blobs, if useBlobs is set, or calldata otherwise,
+// compared to the other data availability type. The lock must be held when calling this method.
+func (dec *DynamicEthChannelConfig) savings(useBlobs bool) float64 {
+	chosen, other := dec.calldataPerByte, dec.blobPerByte
+	if useBlobs {
+		chosen, other = dec.blobPerByte, dec.calldataPerByte
+	}
+	if other <= 0 {
+		return 0
+	}
+	return 1 - chosen/other
+}
+
+// fallbackConfig returns the last applied config, or the blob config if none was applied yet.
+func (dec *DynamicEthChannelConfig) fallbackConfig() ChannelConfig {
+	dec.mu.Lock()
+	defer dec.mu.Unlock()
+	if dec.lastConfig == nil {
+		return dec.blobConfig
+	}
+	return *dec.lastConfig
+}
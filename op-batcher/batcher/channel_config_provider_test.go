@@ -0,0 +1,132 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type mockGasPricer struct {
+	err           error
+	tipCap        int64
+	baseFee       int64
+	excessBlobGas *uint64
+}
+
+func (gp *mockGasPricer) SuggestGasTipCap(context.Context) (*big.Int, error) {
+	if gp.err != nil {
+		return nil, gp.err
+	}
+	return big.NewInt(gp.tipCap), nil
+}
+
+func (gp *mockGasPricer) HeaderByNumber(context.Context, *big.Int) (*types.Header, error) {
+	if gp.err != nil {
+		return nil, gp.err
+	}
+	return &types.Header{
+		BaseFee:       big.NewInt(gp.baseFee),
+		ExcessBlobGas: gp.excessBlobGas,
+	}, nil
+}
+
+func excessBlobGas(v uint64) *uint64 {
+	return &v
+}
+
+func TestDynamicEthChannelConfig_ChannelConfig(t *testing.T) {
+	calldataCfg := ChannelConfig{MaxFrameSize: 120_000 - 1}
+	blobCfg := ChannelConfig{MaxFrameSize: 130_000 - 1}
+
+	// A blob base fee of roughly e^30 wei makes blobs more expensive than calldata at a base fee of 10 gwei.
+	expensiveBlobs := excessBlobGas(params.BlobTxBlobGaspriceUpdateFraction * 30)
+
+	tests := []struct {
+		name         string
+		gasPricer    *mockGasPricer
+		wantUseBlobs bool
+	}{
+		{
+			name:         "blobs-cheaper",
+			gasPricer:    &mockGasPricer{tipCap: 1e9, baseFee: 10e9, excessBlobGas: excessBlobGas(0)},
+			wantUseBlobs: true,
+		},
+		{
+			name:         "calldata-cheaper",
+			gasPricer:    &mockGasPricer{tipCap: 1e9, baseFee: 10e9, excessBlobGas: expensiveBlobs},
+			wantUseBlobs: false,
+		},
+		{
+			name:         "pre-cancun",
+			gasPricer:    &mockGasPricer{tipCap: 1e9, baseFee: 10e9},
+			wantUseBlobs: false,
+		},
+		{
+			name:         "error-defaults-to-blobs",
+			gasPricer:    &mockGasPricer{err: errors.New("gp-error")},
+			wantUseBlobs: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lgr := testlog.Logger(t, log.LvlCrit)
+			dec := NewDynamicEthChannelConfig(lgr, metrics.NoopMetrics, time.Second, tt.gasPricer, blobCfg, calldataCfg)
+			cc := dec.ChannelConfig()
+			require.Equal(t, tt.wantUseBlobs, cc.UseBlobs)
+			if tt.wantUseBlobs {
+				require.Equal(t, blobCfg.MaxFrameSize, cc.MaxFrameSize)
+			} else {
+				require.Equal(t, calldataCfg.MaxFrameSize, cc.MaxFrameSize)
+			}
+		})
+	}
+
+	t.Run("error-keeps-last-config", func(t *testing.T) {
+		lgr := testlog.Logger(t, log.LvlCrit)
+		gp := &mockGasPricer{tipCap: 1e9, baseFee: 10e9, excessBlobGas: expensiveBlobs}
+		dec := NewDynamicEthChannelConfig(lgr, metrics.NoopMetrics, time.Second, gp, blobCfg, calldataCfg)
+		dec.ChannelConfigApplied(dec.ChannelConfig())
+
+		gp.err = errors.New("gp-error")
+		require.False(t, dec.ChannelConfig().UseBlobs)
+	})
+}
+
+func TestDynamicEthChannelConfig_OnlyRecordsAppliedConfig(t *testing.T) {
+	lgr := testlog.Logger(t, log.LvlCrit)
+	gp := &mockGasPricer{tipCap: 1e9, baseFee: 10e9, excessBlobGas: excessBlobGas(0)}
+	dec := NewDynamicEthChannelConfig(lgr, metrics.NoopMetrics, time.Second, gp, ChannelConfig{}, ChannelConfig{})
+	require.True(t, dec.ChannelConfig().UseBlobs)
+	require.Nil(t, dec.lastConfig, "should not record the config before it is applied")
+
+	// The channel manager deferred switching to blobs, so calldata stays in use.
+	dec.ChannelConfigApplied(dec.calldataConfig)
+	require.False(t, dec.lastConfig.UseBlobs)
+	gp.err = errors.New("gp-error")
+	require.False(t, dec.ChannelConfig().UseBlobs, "should fall back to the applied config")
+}
+
+func TestDynamicEthChannelConfig_Hysteresis(t *testing.T) {
+	lgr := testlog.Logger(t, log.LvlCrit)
+	dec := NewDynamicEthChannelConfig(lgr, metrics.NoopMetrics, time.Second, &mockGasPricer{}, ChannelConfig{}, ChannelConfig{})
+
+	require.True(t, dec.useBlobs(100, 99), "cheaper type selected without previous config")
+
+	dec.lastConfig = &dec.blobConfig
+	require.True(t, dec.useBlobs(95, 100), "slightly cheaper calldata doesn't switch from blobs")
+	require.False(t, dec.useBlobs(80, 100), "much cheaper calldata switches from blobs")
+
+	dec.lastConfig = &dec.calldataConfig
+	require.False(t, dec.useBlobs(100, 95), "slightly cheaper blobs don't switch from calldata")
+	require.True(t, dec.useBlobs(100, 80), "much cheaper blobs switch from calldata")
+}
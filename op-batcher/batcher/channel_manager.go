@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -20,14 +23,16 @@ var ErrReorg = errors.New("block does not extend existing chain")
 // channelManager stores a contiguous set of blocks & turns them into channels.
 // Upon receiving tx confirmation (or a tx failure), it does channel error handling.
 //
-// For simplicity, it only creates a single pending channel at a time & waits for
-// the channel to either successfully be submitted or timeout before creating a new
-// channel.
+// Frames are submitted in order of the channels. Unless channel building is pipelined,
+// with ChannelConfig.MaxPendingChannels, blocks are only added to a channel once all
+// the frames built so far have been submitted.
 // Public functions on channelManager are safe for concurrent access.
 type channelManager struct {
-	mu        sync.Mutex
-	log       log.Logger
-	metr      metrics.Metricer
+	mu          sync.Mutex
+	log         log.Logger
+	metr        metrics.Metricer
+	cfgProvider ChannelConfigProvider
+	// cfg is the channel config that was last used to create a channel.
 	cfg       ChannelConfig
 	rollupCfg *rollup.Config
 
@@ -35,6 +40,10 @@ type channelManager struct {
 	blocks []*types.Block
 	// last block hash - for reorg detection
 	tip common.Hash
+	// last L2 block of which all batch data is confirmed in finalized L1 blocks
+	confirmedTip eth.BlockID
+	// channels confirmed on L1 after the confirmedTip, of which the inclusion blocks are not finalized yet
+	unfinalized []ConfirmedChannel
 
 	// channel to write new block data to
 	currentChannel *channel
@@ -47,13 +56,14 @@ type channelManager struct {
 	closed bool
 }
 
-func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollupCfg *rollup.Config) *channelManager {
+func NewChannelManager(log log.Logger, metr metrics.Metricer, cfgProvider ChannelConfigProvider, rollupCfg *rollup.Config) *channelManager {
 	return &channelManager{
-		log:        log,
-		metr:       metr,
-		cfg:        cfg,
-		rollupCfg:  rollupCfg,
-		txChannels: make(map[txID]*channel),
+		log:         log,
+		metr:        metr,
+		cfgProvider: cfgProvider,
+		cfg:         cfgProvider.ChannelConfig(),
+		rollupCfg:   rollupCfg,
+		txChannels:  make(map[txID]*channel),
 	}
 }
 
@@ -65,6 +75,8 @@ func (s *channelManager) Clear() {
 	s.log.Trace("clearing channel manager state")
 	s.blocks = s.blocks[:0]
 	s.tip = common.Hash{}
+	s.confirmedTip = eth.BlockID{}
+	s.unfinalized = nil
 	s.closed = false
 	s.currentChannel = nil
 	s.channelQueue = nil
@@ -100,6 +112,17 @@ func (s *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 		done, blocks := channel.TxConfirmed(id, inclusionBlock)
 		s.blocks = append(blocks, s.blocks...)
 		if done {
+			// Only the oldest channel is recorded as confirmed, so all blocks up to it are confirmed.
+			// If a later channel completes first, its blocks are conservatively treated as unconfirmed.
+			// The confirmed tip only advances once the inclusion block is finalized, see FinalizeConfirmations.
+			if len(blocks) == 0 && len(s.channelQueue) > 0 && s.channelQueue[0] == channel {
+				if chBlocks := channel.channelBuilder.Blocks(); len(chBlocks) > 0 {
+					s.unfinalized = append(s.unfinalized, ConfirmedChannel{
+						LastBlock:      eth.ToBlockID(chBlocks[len(chBlocks)-1]),
+						InclusionBlock: channel.lastInclusionBlock(),
+					})
+				}
+			}
 			s.removePendingChannel(channel)
 		}
 	} else {
@@ -145,32 +168,25 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 // full, it only returns the remaining frames of this channel until it got
 // successfully fully sent to L1. It returns io.EOF if there's no pending frame.
 func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
+	// The config provider may query L1, so the config for a new channel is fetched before taking the lock.
+	nextCfg := s.nextChannelConfig()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	var firstWithFrame *channel
-	for _, ch := range s.channelQueue {
-		if ch.HasFrame() {
-			firstWithFrame = ch
-			break
-		}
-	}
+	defer s.recordPendingChannels()
+	firstWithFrame := s.firstChannelWithFrame()
 
-	dataPending := firstWithFrame != nil && firstWithFrame.HasFrame()
+	dataPending := firstWithFrame != nil
 	s.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", dataPending, "blocks_pending", len(s.blocks))
 
-	// Short circuit if there is a pending frame or the channel manager is closed.
-	if dataPending || s.closed {
+	// Short circuit if there are no blocks to add to a channel, the channel manager is closed,
+	// or if there is a pending frame and building the next channel has to wait for it.
+	// If we have no saved blocks, we will not be able to create valid frames.
+	if len(s.blocks) == 0 || s.closed || (dataPending && !s.canBuildAhead()) {
 		return s.nextTxData(firstWithFrame)
 	}
 
-	// No pending frame, so we have to add new blocks to the channel
-
-	// If we have no saved blocks, we will not be able to create valid frames
-	if len(s.blocks) == 0 {
-		return txData{}, io.EOF
-	}
-
-	if err := s.ensureChannelWithSpace(l1Head); err != nil {
+	if err := s.ensureChannelWithSpace(l1Head, nextCfg); err != nil {
 		return txData{}, err
 	}
 
@@ -187,18 +203,104 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 		return txData{}, err
 	}
 
-	return s.nextTxData(s.currentChannel)
+	return s.nextTxData(s.firstChannelWithFrame())
+}
+
+// firstChannelWithFrame returns the oldest channel that has frames left to submit, or nil if there is none.
+func (s *channelManager) firstChannelWithFrame() *channel {
+	for _, ch := range s.channelQueue {
+		if ch.HasFrame() {
+			return ch
+		}
+	}
+	return nil
+}
+
+// canBuildAhead returns whether blocks can be added to channels while earlier frames are still to be submitted.
+// Blocks can always be added to the current channel if it is still open,
+// but a new channel is only opened while there are fewer than MaxPendingChannels pending channels.
+func (s *channelManager) canBuildAhead() bool {
+	if s.cfg.MaxPendingChannels == 0 {
+		return false
+	}
+	if s.currentChannel != nil && !s.currentChannel.IsFull() {
+		return true
+	}
+	return uint64(s.pendingChannels()) < s.cfg.MaxPendingChannels
+}
+
+// hasUnconfirmedData returns whether any channel submitting data as blobs, if useBlobs is set,
+// or as calldata otherwise, has data that is not yet confirmed.
+func (s *channelManager) hasUnconfirmedData(useBlobs bool) bool {
+	for _, ch := range s.channelQueue {
+		if ch.cfg.UseBlobs == useBlobs && !ch.isFullySubmitted() {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingChannels returns the number of channels that are open, or have frames left to submit.
+func (s *channelManager) pendingChannels() int {
+	var count int
+	for _, ch := range s.channelQueue {
+		if ch.HasFrame() || !ch.IsFull() {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *channelManager) recordPendingChannels() {
+	var frames int
+	for _, ch := range s.channelQueue {
+		frames += ch.PendingFrames()
+	}
+	s.metr.RecordPendingChannels(s.pendingChannels(), frames)
+}
+
+// nextChannelConfig returns the config from the config provider if TxData is about to create a new
+// channel, or nil otherwise.
+func (s *channelManager) nextChannelConfig() *ChannelConfig {
+	s.mu.Lock()
+	needed := len(s.blocks) > 0 && !s.closed &&
+		(s.firstChannelWithFrame() == nil || s.canBuildAhead()) &&
+		(s.currentChannel == nil || s.currentChannel.IsFull())
+	s.mu.Unlock()
+	if !needed {
+		return nil
+	}
+	cfg := s.cfgProvider.ChannelConfig()
+	return &cfg
 }
 
 // ensureChannelWithSpace ensures currentChannel is populated with a channel that has
 // space for more data (i.e. channel.IsFull returns false). If currentChannel is nil
-// or full, a new channel is created.
-func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
+// or full, a new channel is created with nextCfg, or the last used config if nextCfg is nil.
+func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID, nextCfg *ChannelConfig) error {
 	if s.currentChannel != nil && !s.currentChannel.IsFull() {
 		return nil
 	}
 
-	pc, err := newChannel(s.log, s.metr, s.cfg, s.rollupCfg)
+	// Each channel may use a different config, e.g. to switch between blobs and calldata.
+	// The data availability type is only switched once all data of the previous type is confirmed,
+	// since the transaction manager cannot send blob and calldata transactions from the same account
+	// while either is pending.
+	if nextCfg != nil {
+		if nextCfg.UseBlobs != s.cfg.UseBlobs && s.hasUnconfirmedData(s.cfg.UseBlobs) {
+			s.log.Info("Waiting for pending data to be confirmed before switching data availability type",
+				"use_blobs", nextCfg.UseBlobs)
+		} else {
+			s.cfg = *nextCfg
+		}
+		s.cfgProvider.ChannelConfigApplied(s.cfg)
+	}
+	cfg := s.cfg
+	if algo := cfg.CompressorConfig.CompressionAlgo; algo == derive.Zstd && !s.zstdChannelActive() {
+		s.log.Info("Compressing channel with zlib until zstd channels are active", "compression_algo", algo)
+		cfg.CompressorConfig.CompressionAlgo = derive.Zlib
+	}
+	pc, err := newChannel(s.log, s.metr, cfg, s.rollupCfg)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
 	}
@@ -210,12 +312,208 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 		"blocks_pending", len(s.blocks),
 		"batch_type", s.cfg.BatchType,
 		"max_frame_size", s.cfg.MaxFrameSize,
+		"use_blobs", s.cfg.UseBlobs,
+		"compression_algo", cfg.CompressorConfig.CompressionAlgo,
 	)
 	s.metr.RecordChannelOpened(pc.ID(), len(s.blocks))
 
 	return nil
 }
 
+// zstdChannelActive returns whether zstd compressed channel data is accepted by derivation at the
+// L1 origin of the oldest pending block. A channel is always included in an L1 block after the
+// L1 origins of its blocks, so its frames are then never included before zstd channels are active.
+func (s *channelManager) zstdChannelActive() bool {
+	if len(s.blocks) == 0 {
+		return false
+	}
+	block := s.blocks[0]
+	if len(block.Transactions()) == 0 {
+		return false
+	}
+	l1Info, err := derive.L1BlockInfoFromBytes(s.rollupCfg, block.Time(), block.Transactions()[0].Data())
+	if err != nil {
+		s.log.Warn("Failed to read L1 origin of pending block", "block", eth.ToBlockID(block), "err", err)
+		return false
+	}
+	return s.rollupCfg.IsZstdChannel(l1Info.Time)
+}
+
+// FlushCurrentChannel closes the current channel, so that all of its data is output as frames
+// and submitted, without waiting for the channel to fill up or time out.
+// Pending blocks are added to a new channel.
+func (s *channelManager) FlushCurrentChannel() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.currentChannel == nil || s.currentChannel.IsFull() {
+		s.log.Info("No open channel to flush")
+		return nil
+	}
+	s.log.Info("Flushing channel", "id", s.currentChannel.ID())
+	s.currentChannel.Close()
+	if err := s.outputFrames(); err != nil {
+		return fmt.Errorf("outputting frames during flush: %w", err)
+	}
+	return nil
+}
+
+// State returns a snapshot of the in-memory channel state.
+func (s *channelManager) State(now time.Time) rpc.ChannelState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := rpc.ChannelState{
+		BlocksPending:   len(s.blocks),
+		PendingChannels: s.pendingChannels(),
+	}
+	for _, ch := range s.channelQueue {
+		state.FramesQueued += ch.PendingFrames()
+	}
+	if ch := s.currentChannel; ch != nil && !ch.IsFull() {
+		open := &rpc.OpenChannelState{
+			ID:          ch.ID(),
+			Blocks:      len(ch.channelBuilder.Blocks()),
+			InputBytes:  ch.InputBytes(),
+			OutputBytes: ch.OutputBytes() + ch.ReadyBytes(),
+		}
+		if open.InputBytes > 0 {
+			open.ComprRatio = float64(open.OutputBytes) / float64(open.InputBytes)
+		}
+		state.OpenChannel = open
+	}
+
+	// Blocks of a channel are only submitted once all of its frames are confirmed,
+	// so the oldest unsubmitted block is the first block of the oldest channel.
+	var oldest *types.Block
+	for _, ch := range s.channelQueue {
+		if blocks := ch.channelBuilder.Blocks(); len(blocks) > 0 {
+			oldest = blocks[0]
+			break
+		}
+	}
+	if oldest == nil && len(s.blocks) > 0 {
+		oldest = s.blocks[0]
+	}
+	if oldest != nil {
+		id := eth.ToBlockID(oldest)
+		state.OldestUnsubmittedBlock = &id
+		if blockTime := time.Unix(int64(oldest.Time()), 0); now.After(blockTime) {
+			state.OldestUnsubmittedBlockAge = uint64(now.Sub(blockTime) / time.Second)
+		}
+	}
+	return state
+}
+
+// QueueState returns the state of the queue to persist. This is the L2 tip of which all batch data is confirmed
+// in finalized L1 blocks, the channels after it that are confirmed in L1 blocks that are not finalized yet,
+// and the closed channels of which not all frames are confirmed yet.
+// Pending channels are only persisted while their blocks are contiguous, so that a restarted batcher can
+// continue after them. The channels after a gap, e.g. from a later channel that was confirmed first, are rebuilt.
+func (s *channelManager) QueueState() QueueState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []PendingChannel
+	for _, ch := range s.channelQueue {
+		if !ch.IsFull() {
+			break
+		}
+		pc, ok := ch.pendingState()
+		if !ok || (len(pending) > 0 && pc.FirstBlock.Number != pending[len(pending)-1].LastBlock.Number+1) {
+			break
+		}
+		pending = append(pending, pc)
+	}
+	return QueueState{
+		ConfirmedTip: s.confirmedTip,
+		Unfinalized:  slices.Clone(s.unfinalized),
+		Pending:      pending,
+	}
+}
+
+// UnfinalizedConfirmations returns the channels, in order, that are confirmed on L1 after the confirmed tip,
+// but of which the inclusion blocks are not finalized yet.
+func (s *channelManager) UnfinalizedConfirmations() []ConfirmedChannel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.unfinalized)
+}
+
+// FinalizeConfirmations advances the confirmed tip to the last block of the given confirmed channel,
+// once its inclusion block and those of all channels before it are finalized.
+func (s *channelManager) FinalizeConfirmations(upTo ConfirmedChannel) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := slices.Index(s.unfinalized, upTo)
+	if i < 0 {
+		s.log.Warn("finalized channel confirmation not found", "last_block", upTo.LastBlock, "inclusion_block", upTo.InclusionBlock)
+		return
+	}
+	s.confirmedTip = upTo.LastBlock
+	s.unfinalized = slices.Delete(s.unfinalized, 0, i+1)
+}
+
+// DropUnfinalizedConfirmations forgets all channel confirmations of which the inclusion blocks
+// are not finalized yet. It is used when an inclusion block was reorged out of the L1 chain.
+func (s *channelManager) DropUnfinalizedConfirmations() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unfinalized = nil
+}
+
+// PendingDABytes returns the estimated batch size of all L2 blocks that are not fully submitted to L1 yet.
+// These are the blocks in channels with unconfirmed frames, and the blocks not added to a channel yet.
+func (s *channelManager) PendingDABytes() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var size uint64
+	for _, ch := range s.channelQueue {
+		for _, block := range ch.channelBuilder.Blocks() {
+			size += metrics.EstimateBatchSize(block)
+		}
+	}
+	for _, block := range s.blocks {
+		size += metrics.EstimateBatchSize(block)
+	}
+	return size
+}
+
+// ResumeFrom makes the state continue after the given L2 block, of which all batch data is confirmed in finalized L1 blocks.
+// It is used on startup, to resume after the persisted confirmed tip. The next block added must extend it.
+func (s *channelManager) ResumeFrom(tip eth.BlockID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tip = tip.Hash
+	s.confirmedTip = tip
+}
+
+// RestoreChannels restores the pending channels of a persisted queue state, with the L2 blocks of each channel,
+// so that their remaining frames are submitted instead of building new channels from their blocks.
+// It is used on startup, after the state was resumed right before the first block of the channels.
+// The next block added must extend the last block of the channels.
+func (s *channelManager) RestoreChannels(pending []PendingChannel, blocks [][]*types.Block) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(pending) != len(blocks) {
+		return fmt.Errorf("got blocks for %d channels, but %d pending channels", len(blocks), len(pending))
+	}
+	for i, pc := range pending {
+		chBlocks := blocks[i]
+		if len(chBlocks) == 0 || eth.ToBlockID(chBlocks[0]) != pc.FirstBlock || eth.ToBlockID(chBlocks[len(chBlocks)-1]) != pc.LastBlock {
+			return fmt.Errorf("blocks of channel %v don't match its blocks %v to %v", pc.ID, pc.FirstBlock, pc.LastBlock)
+		}
+		ch, err := newRestoredChannel(s.log, s.metr, s.cfg, s.rollupCfg, pc, chBlocks)
+		if err != nil {
+			return err
+		}
+		s.channelQueue = append(s.channelQueue, ch)
+		// New channels may only switch the data availability type once the restored frames are confirmed.
+		s.cfg.UseBlobs = pc.UseBlobs
+		s.tip = pc.LastBlock.Hash
+		s.log.Info("Restored channel", "id", ch.ID(), "first_block", pc.FirstBlock, "last_block", pc.LastBlock,
+			"num_frames", ch.TotalFrames(), "pending_frames", ch.PendingFrames())
+	}
+	return nil
+}
+
 // registerL1Block registers the given block at the pending channel.
 func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
 	s.currentChannel.RegisterL1Block(l1Head.Number)
@@ -298,7 +596,7 @@ func (s *channelManager) outputFrames() error {
 	if inBytes > 0 {
 		comprRatio = float64(outBytes) / float64(inBytes)
 	}
-	s.log.Info("Channel closed",
+	lgr := s.log.New(
 		"id", s.currentChannel.ID(),
 		"blocks_pending", len(s.blocks),
 		"num_frames", s.currentChannel.TotalFrames(),
@@ -307,6 +605,11 @@ func (s *channelManager) outputFrames() error {
 		"full_reason", s.currentChannel.FullErr(),
 		"compr_ratio", comprRatio,
 	)
+	if algo, compareBytes, ok := s.currentChannel.channelBuilder.CompressionComparison(); ok {
+		s.metr.RecordCompressionComparison(algo, compareBytes)
+		lgr = lgr.New("compare_algo", algo, "compare_output_bytes", compareBytes)
+	}
+	lgr.Info("Channel closed")
 	return nil
 }
 
@@ -155,7 +155,7 @@ func ChannelManager_Clear(t *testing.T, batchType uint) {
 	require.NoError(m.AddL2Block(a))
 
 	// Make sure there is a channel
-	require.NoError(m.ensureChannelWithSpace(l1BlockID))
+	require.NoError(m.ensureChannelWithSpace(l1BlockID, nil))
 	require.NotNil(m.currentChannel)
 	require.Len(m.currentChannel.confirmedTransactions, 0)
 
@@ -478,3 +478,271 @@ func ChannelManagerCloseAllTxsFailed(t *testing.T, batchType uint) {
 	_, err = m.TxData(eth.BlockID{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+// TestChannelManager_PipelinedChannels ensures that the channel manager only
+// builds new channels while frames of earlier channels are still pending if
+// pipelining is enabled, and then at most up to the configured limit.
+func TestChannelManager_PipelinedChannels(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	blocks := make([]*types.Block, 3)
+	for i := range blocks {
+		b := derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)
+		if i > 0 {
+			h := b.Header()
+			h.Number = new(big.Int).Add(blocks[i-1].Number(), big.NewInt(1))
+			h.ParentHash = blocks[i-1].Hash()
+			b = b.WithSeal(h)
+		}
+		blocks[i] = b
+	}
+
+	setup := func(t *testing.T, maxPending uint64) *channelManager {
+		log := testlog.Logger(t, log.LvlCrit)
+		m := NewChannelManager(log, metrics.NoopMetrics,
+			ChannelConfig{
+				MaxFrameSize:       200,
+				ChannelTimeout:     1000,
+				MaxPendingChannels: maxPending,
+				CompressorConfig: compressor.Config{
+					TargetNumFrames:  1,
+					TargetFrameSize:  1,
+					ApproxComprRatio: 1.0,
+				},
+			},
+			&defaultTestRollupConfig,
+		)
+		m.Clear()
+		for _, b := range blocks {
+			require.NoError(t, m.AddL2Block(b))
+		}
+		return m
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		m := setup(t, 0)
+		_, err := m.TxData(eth.BlockID{})
+		require.NoError(t, err)
+		require.Len(t, m.blocks, 2)
+		require.True(t, m.channelQueue[0].HasFrame(), "first channel should consist of multiple frames")
+
+		_, err = m.TxData(eth.BlockID{})
+		require.NoError(t, err)
+		require.Len(t, m.channelQueue, 1, "no channel should be built while frames are pending")
+		require.Len(t, m.blocks, 2)
+	})
+
+	t.Run("limited", func(t *testing.T) {
+		m := setup(t, 2)
+		txdata, err := m.TxData(eth.BlockID{})
+		require.NoError(t, err)
+		require.Len(t, m.channelQueue, 1)
+		first := m.channelQueue[0]
+		require.Equal(t, first.ID(), txdata.ID().chID)
+
+		txdata, err = m.TxData(eth.BlockID{})
+		require.NoError(t, err)
+		require.Len(t, m.channelQueue, 2, "second channel should be built while frames are pending")
+		require.Len(t, m.blocks, 1)
+		require.Equal(t, first.ID(), txdata.ID().chID, "frames must be submitted in channel order")
+
+		_, err = m.TxData(eth.BlockID{})
+		require.NoError(t, err)
+		require.Len(t, m.channelQueue, 2, "no channel should be built beyond the limit")
+		require.Len(t, m.blocks, 1)
+
+		// Drain all frames, which must be returned in channel order.
+		var ids []derive.ChannelID
+		for {
+			txdata, err := m.TxData(eth.BlockID{})
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if len(ids) == 0 || ids[len(ids)-1] != txdata.ID().chID {
+				ids = append(ids, txdata.ID().chID)
+			}
+		}
+		require.Len(t, ids, 3)
+		require.Equal(t, first.ID(), ids[0])
+		require.Empty(t, m.blocks)
+	})
+}
+
+// TestChannelManager_FlushAndState ensures that flushing closes the open channel,
+// and that the state snapshot reports the pending blocks, channels and frames.
+func TestChannelManager_FlushAndState(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(123))
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   120_000,
+			ChannelTimeout: 1000,
+			CompressorConfig: compressor.Config{
+				TargetNumFrames:  1,
+				TargetFrameSize:  120_000,
+				ApproxComprRatio: 1.0,
+			},
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+
+	state := m.State(time.Now())
+	require.Zero(state.BlocksPending)
+	require.Nil(state.OpenChannel)
+	require.Nil(state.OldestUnsubmittedBlock)
+	require.NoError(m.FlushCurrentChannel(), "flushing without open channel is a no-op")
+
+	a := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
+	require.NoError(m.AddL2Block(a))
+	now := time.Unix(int64(a.Time())+30, 0)
+	state = m.State(now)
+	require.Equal(1, state.BlocksPending)
+	require.Equal(eth.ToBlockID(a), *state.OldestUnsubmittedBlock)
+	require.EqualValues(30, state.OldestUnsubmittedBlockAge)
+
+	// The block is added to a new channel, which stays open without frames.
+	_, err := m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+	state = m.State(now)
+	require.Zero(state.BlocksPending)
+	require.Equal(1, state.PendingChannels)
+	require.Zero(state.FramesQueued)
+	require.NotNil(state.OpenChannel)
+	require.Equal(1, state.OpenChannel.Blocks)
+	require.Positive(state.OpenChannel.InputBytes)
+	require.Equal(eth.ToBlockID(a), *state.OldestUnsubmittedBlock)
+
+	require.NoError(m.FlushCurrentChannel())
+	require.ErrorIs(m.currentChannel.FullErr(), ErrTerminated)
+	state = m.State(now)
+	require.Nil(state.OpenChannel)
+	require.Equal(1, state.FramesQueued)
+
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	m.TxConfirmed(txdata.ID(), eth.BlockID{})
+	state = m.State(now)
+	require.Zero(state.PendingChannels)
+	require.Nil(state.OldestUnsubmittedBlock)
+}
+
+func TestChannelManager_QueueState(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(456))
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   120_000,
+			ChannelTimeout: 1000,
+			CompressorConfig: compressor.Config{
+				TargetNumFrames:  1,
+				TargetFrameSize:  120_000,
+				ApproxComprRatio: 1.0,
+			},
+		},
+		&defaultTestRollupConfig,
+	)
+	m.Clear()
+	require.Equal(QueueState{}, m.QueueState())
+
+	a := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
+	tip := eth.BlockID{Number: a.NumberU64() - 1, Hash: a.ParentHash()}
+	m.ResumeFrom(tip)
+	require.ErrorIs(m.AddL2Block(derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)), ErrReorg,
+		"blocks must extend the resumed tip")
+	require.NoError(m.AddL2Block(a))
+	require.Equal(QueueState{ConfirmedTip: tip}, m.QueueState())
+
+	_, err := m.TxData(eth.BlockID{})
+	require.ErrorIs(err, io.EOF)
+	require.NoError(m.FlushCurrentChannel())
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(err)
+	pending := PendingChannel{
+		ID:         txdata.ID().chID,
+		FirstBlock: eth.ToBlockID(a),
+		LastBlock:  eth.ToBlockID(a),
+		Frames:     []PendingFrame{{Number: 0, Data: txdata.frame.data}},
+	}
+	require.Equal(QueueState{ConfirmedTip: tip, Pending: []PendingChannel{pending}}, m.QueueState(),
+		"in-flight frames must be persisted with their data")
+
+	inclusion := eth.BlockID{Number: 100, Hash: common.Hash{0x01}}
+	m.TxConfirmed(txdata.ID(), inclusion)
+	confirmed := ConfirmedChannel{LastBlock: eth.ToBlockID(a), InclusionBlock: inclusion}
+	require.Equal(QueueState{ConfirmedTip: tip, Unfinalized: []ConfirmedChannel{confirmed}}, m.QueueState(),
+		"confirmed tip must not advance before the inclusion block is finalized")
+	require.Equal([]ConfirmedChannel{confirmed}, m.UnfinalizedConfirmations())
+
+	m.FinalizeConfirmations(confirmed)
+	state := m.QueueState()
+	require.Equal(eth.ToBlockID(a), state.ConfirmedTip)
+	require.Empty(state.Unfinalized)
+
+	m.Clear()
+	require.Equal(QueueState{}, m.QueueState())
+}
+
+// TestChannelManager_SwitchDATypeAfterConfirmation ensures that the data availability type is
+// only switched for a new channel once all data of the previous type is confirmed.
+func TestChannelManager_SwitchDATypeAfterConfirmation(t *testing.T) {
+	require := require.New(t)
+	blobCfg := ChannelConfig{MaxFrameSize: 120_000, ChannelTimeout: 1000, UseBlobs: true}
+	calldataCfg := ChannelConfig{MaxFrameSize: 120_000, ChannelTimeout: 1000}
+	provider := &recordingConfigProvider{cfg: blobCfg}
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, provider, &defaultTestRollupConfig)
+
+	require.NoError(m.ensureChannelWithSpace(eth.BlockID{}, nil))
+	first := m.currentChannel
+	require.True(first.cfg.UseBlobs)
+	first.Close()
+	first.pendingTransactions[txID{chID: first.ID()}] = txData{asBlob: true}
+
+	require.NoError(m.ensureChannelWithSpace(eth.BlockID{}, &calldataCfg))
+	second := m.currentChannel
+	require.NotSame(first, second)
+	require.True(second.cfg.UseBlobs, "should not switch while blob data is pending")
+	require.True(provider.applied[len(provider.applied)-1].UseBlobs, "should report the config that is still in use")
+
+	second.Close()
+	delete(first.pendingTransactions, txID{chID: first.ID()})
+	require.NoError(m.ensureChannelWithSpace(eth.BlockID{}, &calldataCfg))
+	require.False(m.currentChannel.cfg.UseBlobs, "should switch once blob data is confirmed")
+	require.False(provider.applied[len(provider.applied)-1].UseBlobs)
+}
+
+// recordingConfigProvider provides a static config and records the configs reported as applied.
+type recordingConfigProvider struct {
+	cfg     ChannelConfig
+	applied []ChannelConfig
+}
+
+func (p *recordingConfigProvider) ChannelConfig() ChannelConfig {
+	return p.cfg
+}
+
+func (p *recordingConfigProvider) ChannelConfigApplied(cfg ChannelConfig) {
+	p.applied = append(p.applied, cfg)
+}
+
+func TestChannelManager_ZstdChannelActivation(t *testing.T) {
+	require := require.New(t)
+	cfg := ChannelConfig{MaxFrameSize: 120_000, ChannelTimeout: 1000}
+	cfg.CompressorConfig.CompressionAlgo = derive.Zstd
+	rollupCfg := defaultTestRollupConfig
+	activation := uint64(1)
+	rollupCfg.ZstdChannelTime = &activation
+	m := NewChannelManager(testlog.Logger(t, log.LvlCrit), metrics.NoopMetrics, cfg, &rollupCfg)
+
+	// The L1 origin of the pending block is before the activation.
+	require.NoError(m.AddL2Block(newMiniL2Block(0)))
+	require.NoError(m.ensureChannelWithSpace(eth.BlockID{}, nil))
+	require.Equal(derive.Zlib, m.currentChannel.cfg.CompressorConfig.CompressionAlgo)
+
+	activation = 0
+	m.currentChannel.Close()
+	require.NoError(m.ensureChannelWithSpace(eth.BlockID{}, nil))
+	require.Equal(derive.Zstd, m.currentChannel.cfg.CompressorConfig.CompressionAlgo)
+}
@@ -53,6 +53,11 @@ type CLIConfig struct {
 	// MaxL1TxSize is the maximum size of a batch tx submitted to L1.
 	MaxL1TxSize uint64
 
+	// MaxPendingChannels is the maximum number of channels that are open or have frames left to submit.
+	// If greater than 0, the next channels are built while the frames of earlier channels are submitted.
+	// If 0, channels are only built once all earlier frames were submitted.
+	MaxPendingChannels uint64
+
 	Stopped bool
 
 	BatchType uint
@@ -61,6 +66,23 @@ type CLIConfig struct {
 	// the data availability type to use for posting batches, e.g. blobs vs calldata.
 	DataAvailabilityType flags.DataAvailabilityType
 
+	// AdminJWTSecretPath is the path to the JWT secret that RPC requests must be authenticated with.
+	// RPC requests are not authenticated if empty.
+	AdminJWTSecretPath string
+
+	// QueueStateFile is the file that the queue state is persisted in, to resume after a restart.
+	// The data of unsubmitted frames is stored in a directory next to it, with the .frames suffix.
+	// The queue state is not persisted if empty.
+	QueueStateFile string
+
+	// ThrottleThreshold is the estimated batch size of the unsubmitted L2 blocks, above which the sequencer
+	// is asked to limit the data availability size of its blocks. Throttling is disabled if 0.
+	ThrottleThreshold uint64
+	// ThrottleTxSize is the data availability size limit of a single transaction while throttling.
+	ThrottleTxSize uint64
+	// ThrottleBlockSize is the data availability size limit of a block while throttling.
+	ThrottleBlockSize uint64
+
 	// ActiveSequencerCheckDuration is the duration between checks to determine the active sequencer endpoint.
 	ActiveSequencerCheckDuration time.Duration
 
@@ -97,12 +119,18 @@ func (c *CLIConfig) Check() error {
 	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
 		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
 	}
+	if c.ThrottleThreshold != 0 && (c.ThrottleTxSize == 0 || c.ThrottleBlockSize == 0) {
+		return errors.New("throttle tx size and block size must be set if throttling is enabled")
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.CompressorConfig.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -126,9 +154,15 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		MaxPendingTransactions:       ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
 		MaxChannelDuration:           ctx.Uint64(flags.MaxChannelDurationFlag.Name),
 		MaxL1TxSize:                  ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
+		MaxPendingChannels:           ctx.Uint64(flags.MaxPendingChannelsFlag.Name),
 		Stopped:                      ctx.Bool(flags.StoppedFlag.Name),
 		BatchType:                    ctx.Uint(flags.BatchTypeFlag.Name),
 		DataAvailabilityType:         flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
+		AdminJWTSecretPath:           ctx.String(flags.AdminJWTSecretFlag.Name),
+		QueueStateFile:               ctx.String(flags.QueueStateFileFlag.Name),
+		ThrottleThreshold:            ctx.Uint64(flags.ThrottleThresholdFlag.Name),
+		ThrottleTxSize:               ctx.Uint64(flags.ThrottleTxSizeFlag.Name),
+		ThrottleBlockSize:            ctx.Uint64(flags.ThrottleBlockSizeFlag.Name),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
 		TxMgrConfig:                  txmgr.ReadCLIConfig(ctx),
 		LogConfig:                    oplog.ReadCLIConfig(ctx),
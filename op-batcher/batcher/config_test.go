@@ -87,6 +87,14 @@ func TestBatcherConfig(t *testing.T) {
 			override:  func(c *batcher.CLIConfig) { c.DataAvailabilityType = "foo" },
 			errString: "unknown data availability type: \"foo\"",
 		},
+		{
+			name: "throttling without block size",
+			override: func(c *batcher.CLIConfig) {
+				c.ThrottleThreshold = 1_000_000
+				c.ThrottleTxSize = 300
+			},
+			errString: "throttle tx size and block size must be set",
+		},
 	}
 
 	for _, test := range tests {
@@ -8,13 +8,17 @@ import (
 	"math/big"
 	_ "net/http/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
@@ -24,6 +28,13 @@ import (
 
 var ErrBatcherNotRunning = errors.New("batcher is not running")
 
+// SetMaxDASizeMethod is the RPC method of the sequencer's execution engine to limit the
+// data availability size of single transactions and of blocks. Limits of 0 remove them.
+const SetMaxDASizeMethod = "miner_setMaxDASize"
+
+// methodNotFoundErrorCode is the JSON-RPC error code of calls to methods that are not available.
+const methodNotFoundErrorCode = -32601
+
 type L1Client interface {
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 }
@@ -45,7 +56,9 @@ type DriverSetup struct {
 	Txmgr            txmgr.TxManager
 	L1Client         L1Client
 	EndpointProvider dial.L2EndpointProvider
-	ChannelConfig    ChannelConfig
+	ChannelConfig    ChannelConfigProvider
+	// QueuePersistence persists the queue state, to resume after a restart. The state is not persisted if nil.
+	QueuePersistence QueuePersistence
 }
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -63,19 +76,33 @@ type BatchSubmitter struct {
 	mutex   sync.Mutex
 	running bool
 
+	// paused prevents the submission of batcher transactions, except when draining the state.
+	paused atomic.Bool
+
 	// lastStoredBlock is the last block loaded into `state`. If it is empty it should be set to the l2 safe head.
 	lastStoredBlock eth.BlockID
 	lastL1Tip       eth.L1BlockRef
+	// resumeState is the persisted queue state to continue after, if it's ahead of the L2 safe head on startup.
+	resumeState QueueState
 
 	state *channelManager
+
+	// throttling is whether the sequencer was last successfully asked to limit the data availability size of its blocks.
+	throttling bool
+	// throttleUnsupported is whether the sequencer's execution engine does not support SetMaxDASizeMethod.
+	throttleUnsupported bool
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter driver from a preconfigured DriverSetup
 func NewBatchSubmitter(setup DriverSetup) *BatchSubmitter {
-	return &BatchSubmitter{
+	l := &BatchSubmitter{
 		DriverSetup: setup,
 		state:       NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig),
 	}
+	if l.QueuePersistence == nil {
+		l.QueuePersistence = DisabledQueuePersistence{}
+	}
+	return l
 }
 
 func (l *BatchSubmitter) StartBatchSubmitting() error {
@@ -93,6 +120,7 @@ func (l *BatchSubmitter) StartBatchSubmitting() error {
 	l.killCtx, l.cancelKillCtx = context.WithCancel(context.Background())
 	l.state.Clear()
 	l.lastStoredBlock = eth.BlockID{}
+	l.resumeState = l.loadQueueState()
 
 	l.wg.Add(1)
 	go l.loop()
@@ -101,6 +129,33 @@ func (l *BatchSubmitter) StartBatchSubmitting() error {
 	return nil
 }
 
+// PauseSubmission pauses the submission of batcher transactions. L2 blocks are still loaded into the state.
+// Pending state is still submitted on shutdown and L2 reorgs.
+func (l *BatchSubmitter) PauseSubmission() {
+	if !l.paused.Swap(true) {
+		l.Log.Info("Paused batcher transaction submission")
+	}
+}
+
+// ResumeSubmission resumes the submission of batcher transactions.
+func (l *BatchSubmitter) ResumeSubmission() {
+	if l.paused.Swap(false) {
+		l.Log.Info("Resumed batcher transaction submission")
+	}
+}
+
+// FlushChannel closes the open channel, so that its data is submitted without waiting for it to fill up.
+func (l *BatchSubmitter) FlushChannel() error {
+	return l.state.FlushCurrentChannel()
+}
+
+// ChannelState returns a snapshot of the in-memory state of the batcher.
+func (l *BatchSubmitter) ChannelState() rpc.ChannelState {
+	state := l.state.State(time.Now())
+	state.Paused = l.paused.Load()
+	return state
+}
+
 func (l *BatchSubmitter) StopBatchSubmittingIfRunning(ctx context.Context) error {
 	err := l.StopBatchSubmitting(ctx)
 	if errors.Is(err, ErrBatcherNotRunning) {
@@ -132,6 +187,7 @@ func (l *BatchSubmitter) StopBatchSubmitting(ctx context.Context) error {
 
 	l.cancelShutdownCtx()
 	l.wg.Wait()
+	l.removeThrottling(ctx)
 	l.cancelKillCtx()
 
 	l.Log.Info("Batch Submitter stopped")
@@ -183,6 +239,21 @@ func (l *BatchSubmitter) loadBlocksIntoState(ctx context.Context) error {
 
 // loadBlockIntoState fetches & stores a single block into `state`. It returns the block it loaded.
 func (l *BatchSubmitter) loadBlockIntoState(ctx context.Context, blockNumber uint64) (*types.Block, error) {
+	block, err := l.fetchL2Block(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.state.AddL2Block(block); err != nil {
+		return nil, fmt.Errorf("adding L2 block to state: %w", err)
+	}
+
+	l.Log.Info("added L2 block to local state", "block", eth.ToBlockID(block), "tx_count", len(block.Transactions()), "time", block.Time())
+	return block, nil
+}
+
+// fetchL2Block fetches a single L2 block by number.
+func (l *BatchSubmitter) fetchL2Block(ctx context.Context, blockNumber uint64) (*types.Block, error) {
 	ctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
 	defer cancel()
 	l2Client, err := l.EndpointProvider.EthClient(ctx)
@@ -193,12 +264,6 @@ func (l *BatchSubmitter) loadBlockIntoState(ctx context.Context, blockNumber uin
 	if err != nil {
 		return nil, fmt.Errorf("getting L2 block: %w", err)
 	}
-
-	if err := l.state.AddL2Block(block); err != nil {
-		return nil, fmt.Errorf("adding L2 block to state: %w", err)
-	}
-
-	l.Log.Info("added L2 block to local state", "block", eth.ToBlockID(block), "tx_count", len(block.Transactions()), "time", block.Time())
 	return block, nil
 }
 
@@ -223,12 +288,26 @@ func (l *BatchSubmitter) calculateL2BlockRangeToStore(ctx context.Context) (eth.
 	// Check last stored to see if it needs to be set on startup OR set if is lagged behind.
 	// It lagging implies that the op-node processed some batches that were submitted prior to the current instance of the batcher being alive.
 	if l.lastStoredBlock == (eth.BlockID{}) {
-		l.Log.Info("Starting batch-submitter work at safe-head", "safe", syncStatus.SafeL2)
-		l.lastStoredBlock = syncStatus.SafeL2.ID()
+		if resumeFrom := l.resumeTip(ctx, syncStatus.FinalizedL1); resumeFrom.Number > syncStatus.SafeL2.Number {
+			l.Log.Info("Resuming batch-submitter work after persisted confirmed tip", "confirmed_tip", resumeFrom, "safe", syncStatus.SafeL2)
+			l.lastStoredBlock = resumeFrom
+			l.state.ResumeFrom(resumeFrom)
+		} else {
+			l.Log.Info("Starting batch-submitter work at safe-head", "safe", syncStatus.SafeL2)
+			l.lastStoredBlock = syncStatus.SafeL2.ID()
+		}
+		if tip, err := l.restorePendingChannels(ctx, l.lastStoredBlock); err != nil {
+			l.Log.Warn("Not restoring pending channels of persisted queue state, their blocks are added to new channels", "err", err)
+		} else if tip != (eth.BlockID{}) {
+			l.lastStoredBlock = tip
+		}
+		// The persisted state is only valid on startup. After a reorg, work restarts at the safe head.
+		l.resumeState = QueueState{}
 	} else if l.lastStoredBlock.Number < syncStatus.SafeL2.Number {
 		l.Log.Warn("last submitted block lagged behind L2 safe head: batch submission will continue from the safe head now", "last", l.lastStoredBlock, "safe", syncStatus.SafeL2)
 		l.lastStoredBlock = syncStatus.SafeL2.ID()
 	}
+	l.finalizeConfirmations(ctx, syncStatus.FinalizedL1)
 
 	// Check if we should even attempt to load any blocks. TODO: May not need this check
 	if syncStatus.SafeL2.Number >= syncStatus.UnsafeL2.Number {
@@ -272,9 +351,12 @@ func (l *BatchSubmitter) loop() {
 				}
 				l.publishStateToL1(queue, receiptsCh, true)
 				l.state.Clear()
+				l.persistQueueState()
 				continue
 			}
+			l.updateThrottling(l.shutdownCtx)
 			l.publishStateToL1(queue, receiptsCh, false)
+			l.persistQueueState()
 		case r := <-receiptsCh:
 			l.handleReceipt(r)
 		case <-l.shutdownCtx.Done():
@@ -289,15 +371,248 @@ func (l *BatchSubmitter) loop() {
 				}
 			}
 			l.publishStateToL1(queue, receiptsCh, true)
+			l.persistQueueState()
 			l.Log.Info("Finished publishing all remaining channel data")
 			return
 		}
 	}
 }
 
+// updateThrottling asks the sequencer to limit the data availability size of its blocks while the estimated
+// batch size of the unsubmitted L2 blocks exceeds the throttle threshold, and to remove the limits again once
+// it doesn't. This prevents unbounded growth of unsubmitted data, e.g. while L1 is congested.
+// To not toggle the limits on every call near the threshold, throttling only stops once the pending data
+// drops below throttleReleaseThreshold.
+// While throttling, the limits are set on every call, so that they are also set on a new active sequencer.
+func (l *BatchSubmitter) updateThrottling(ctx context.Context) {
+	if l.Config.ThrottleThreshold == 0 || l.throttleUnsupported {
+		return
+	}
+	pendingBytes := l.state.PendingDABytes()
+	throttle := pendingBytes > l.Config.ThrottleThreshold
+	if l.throttling {
+		throttle = pendingBytes >= throttleReleaseThreshold(l.Config.ThrottleThreshold)
+	}
+	if throttle || l.throttling {
+		l.setThrottling(ctx, throttle, pendingBytes)
+	}
+	l.Metr.RecordThrottling(l.throttling, pendingBytes)
+}
+
+func (l *BatchSubmitter) setThrottling(ctx context.Context, throttle bool, pendingBytes uint64) {
+	var maxTxSize, maxBlockSize uint64
+	if throttle {
+		maxTxSize, maxBlockSize = l.Config.ThrottleTxSize, l.Config.ThrottleBlockSize
+	}
+	if err := l.setMaxDASize(ctx, maxTxSize, maxBlockSize); isMethodNotFound(err) {
+		l.Log.Error("Sequencer does not support "+SetMaxDASizeMethod+", throttling is disabled", "err", err)
+		l.throttleUnsupported = true
+		return
+	} else if err != nil {
+		l.Log.Error("Failed to set max DA size on sequencer", "throttle", throttle, "pending_bytes", pendingBytes, "err", err)
+		return
+	}
+	if throttle && !l.throttling {
+		l.Log.Warn("Pending data exceeds throttle threshold, throttling sequencer", "pending_bytes", pendingBytes,
+			"threshold", l.Config.ThrottleThreshold, "max_tx_size", maxTxSize, "max_block_size", maxBlockSize)
+	} else if !throttle {
+		l.Log.Info("Pending data below throttle release threshold, stopped throttling sequencer", "pending_bytes", pendingBytes,
+			"release_threshold", throttleReleaseThreshold(l.Config.ThrottleThreshold))
+	}
+	l.throttling = throttle
+}
+
+// removeThrottling removes the limits if the sequencer is throttled, as nothing else would remove them
+// once the batcher is stopped.
+func (l *BatchSubmitter) removeThrottling(ctx context.Context) {
+	if !l.throttling {
+		return
+	}
+	if err := l.setMaxDASize(ctx, 0, 0); err != nil {
+		l.Log.Error("Failed to remove max DA size limits of sequencer", "err", err)
+		return
+	}
+	l.Log.Info("Removed max DA size limits of sequencer")
+	l.throttling = false
+}
+
+// throttleReleaseThreshold returns the estimated batch size of the unsubmitted L2 blocks,
+// below which throttling stops: three quarters of the threshold.
+func throttleReleaseThreshold(threshold uint64) uint64 {
+	return threshold - threshold/4
+}
+
+func isMethodNotFound(err error) bool {
+	var rpcErr gethrpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundErrorCode
+}
+
+// setMaxDASize sets the data availability size limits on the sequencer's execution engine.
+func (l *BatchSubmitter) setMaxDASize(ctx context.Context, maxTxSize, maxBlockSize uint64) error {
+	ctx, cancel := context.WithTimeout(ctx, l.Config.NetworkTimeout)
+	defer cancel()
+	cl, err := l.EndpointProvider.EthClient(ctx)
+	if err != nil {
+		return fmt.Errorf("getting L2 client: %w", err)
+	}
+	var success bool
+	if err := cl.Client().CallContext(ctx, &success, SetMaxDASizeMethod, hexutil.Uint64(maxTxSize), hexutil.Uint64(maxBlockSize)); err != nil {
+		return fmt.Errorf("calling %s: %w", SetMaxDASizeMethod, err)
+	}
+	if !success {
+		return fmt.Errorf("%s was not successful", SetMaxDASizeMethod)
+	}
+	return nil
+}
+
+// loadQueueState loads the persisted queue state to resume after.
+func (l *BatchSubmitter) loadQueueState() QueueState {
+	state, err := l.QueuePersistence.Load()
+	if err != nil {
+		l.Log.Warn("Failed to load persisted queue state, starting at safe head", "err", err)
+		return QueueState{}
+	}
+	if state.ConfirmedTip != (eth.BlockID{}) || len(state.Unfinalized) > 0 || len(state.Pending) > 0 {
+		l.Log.Info("Loaded persisted queue state", "confirmed_tip", state.ConfirmedTip, "unfinalized", len(state.Unfinalized), "pending", len(state.Pending))
+	}
+	return state
+}
+
+// resumeTip returns the last L2 block of the persisted queue state of which all batch data is confirmed in
+// finalized L1 blocks. Channels that were confirmed in L1 blocks that weren't finalized yet when the state was
+// persisted are only resumed after if their inclusion blocks are finalized and still canonical now.
+func (l *BatchSubmitter) resumeTip(ctx context.Context, l1Finalized eth.L1BlockRef) eth.BlockID {
+	tip := l.resumeState.ConfirmedTip
+	finalized, err := l.finalizedConfirmations(ctx, l.resumeState.Unfinalized, l1Finalized)
+	if err != nil {
+		l.Log.Warn("Failed to verify unfinalized channel confirmations of persisted queue state", "err", err)
+		return tip
+	}
+	if finalized > 0 {
+		tip = l.resumeState.Unfinalized[finalized-1].LastBlock
+	}
+	return tip
+}
+
+// restorePendingChannels restores the pending channels of the persisted queue state, if they continue right after
+// the given start block, and their L2 blocks and the L1 inclusion blocks of their confirmed frames are still canonical.
+// It returns the last block of the restored channels, or an empty block ID if there are none.
+func (l *BatchSubmitter) restorePendingChannels(ctx context.Context, start eth.BlockID) (eth.BlockID, error) {
+	pending := l.resumeState.Pending
+	if len(pending) == 0 {
+		return eth.BlockID{}, nil
+	}
+	if first := pending[0].FirstBlock; first.Number != start.Number+1 {
+		return eth.BlockID{}, fmt.Errorf("first pending block %v doesn't follow start block %v", first, start)
+	}
+	var last eth.BlockID
+	for _, pc := range pending {
+		for _, f := range pc.Frames {
+			if f.InclusionBlock != nil && f.InclusionBlock.Number >= last.Number {
+				last = *f.InclusionBlock
+			}
+		}
+	}
+	// The last inclusion block being canonical implies that all earlier inclusion blocks are too.
+	if last != (eth.BlockID{}) {
+		header, err := l.L1Client.HeaderByNumber(ctx, new(big.Int).SetUint64(last.Number))
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("getting L1 header %d: %w", last.Number, err)
+		}
+		if header.Hash() != last.Hash {
+			return eth.BlockID{}, fmt.Errorf("%w: %v", errInclusionBlockReorged, last)
+		}
+	}
+
+	blocks := make([][]*types.Block, 0, len(pending))
+	parent := start.Hash
+	for _, pc := range pending {
+		var chBlocks []*types.Block
+		for n := pc.FirstBlock.Number; n <= pc.LastBlock.Number; n++ {
+			block, err := l.fetchL2Block(ctx, n)
+			if err != nil {
+				return eth.BlockID{}, err
+			}
+			if block.ParentHash() != parent {
+				return eth.BlockID{}, fmt.Errorf("pending block %d doesn't extend %v: %w", n, parent, ErrReorg)
+			}
+			parent = block.Hash()
+			chBlocks = append(chBlocks, block)
+		}
+		blocks = append(blocks, chBlocks)
+	}
+	if err := l.state.RestoreChannels(pending, blocks); err != nil {
+		return eth.BlockID{}, err
+	}
+	return pending[len(pending)-1].LastBlock, nil
+}
+
+// finalizeConfirmations advances the confirmed L2 tip over the channels that are confirmed in finalized L1 blocks,
+// so that only batch data that can't be reorged out of L1 anymore is persisted as confirmed.
+func (l *BatchSubmitter) finalizeConfirmations(ctx context.Context, l1Finalized eth.L1BlockRef) {
+	confirmations := l.state.UnfinalizedConfirmations()
+	finalized, err := l.finalizedConfirmations(ctx, confirmations, l1Finalized)
+	if errors.Is(err, errInclusionBlockReorged) {
+		l.Log.Warn("Channel confirmations were reorged out of L1, not persisting them", "err", err)
+		l.state.DropUnfinalizedConfirmations()
+		return
+	} else if err != nil {
+		l.Log.Warn("Failed to finalize channel confirmations", "err", err)
+		return
+	}
+	if finalized > 0 {
+		l.state.FinalizeConfirmations(confirmations[finalized-1])
+	}
+}
+
+var errInclusionBlockReorged = errors.New("inclusion block is not canonical")
+
+// finalizedConfirmations returns the number of leading confirmations of which the inclusion blocks are finalized.
+// Their last inclusion block is checked to be canonical, which implies that all earlier inclusion blocks are too.
+func (l *BatchSubmitter) finalizedConfirmations(ctx context.Context, confirmations []ConfirmedChannel, l1Finalized eth.L1BlockRef) (int, error) {
+	var finalized int
+	var last eth.BlockID
+	for _, c := range confirmations {
+		if c.InclusionBlock.Number > l1Finalized.Number {
+			break
+		}
+		if c.InclusionBlock.Number >= last.Number {
+			last = c.InclusionBlock
+		}
+		finalized++
+	}
+	if finalized == 0 {
+		return 0, nil
+	}
+	canonical := l1Finalized.Hash
+	if last.Number != l1Finalized.Number {
+		header, err := l.L1Client.HeaderByNumber(ctx, new(big.Int).SetUint64(last.Number))
+		if err != nil {
+			return 0, fmt.Errorf("getting L1 header %d: %w", last.Number, err)
+		}
+		canonical = header.Hash()
+	}
+	if canonical != last.Hash {
+		return 0, fmt.Errorf("%w: %v", errInclusionBlockReorged, last)
+	}
+	return finalized, nil
+}
+
+// persistQueueState persists the queue state, so that a restarted batcher can resume after the confirmed L2 tip,
+// and submit the remaining frames of its pending channels.
+func (l *BatchSubmitter) persistQueueState() {
+	if err := l.QueuePersistence.Persist(l.state.QueueState()); err != nil {
+		l.Log.Error("Failed to persist queue state", "err", err)
+	}
+}
+
 // publishStateToL1 loops through the block data loaded into `state` and
 // submits the associated data to the L1 in the form of channel frames.
 func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData], drain bool) {
+	if !drain && l.paused.Load() {
+		l.Log.Debug("Batcher transaction submission is paused")
+		return
+	}
 	txDone := make(chan struct{})
 	// send/wait and receipt reading must be on a separate goroutines to avoid deadlocks
 	go func() {
@@ -363,7 +678,7 @@ func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txDat
 	data := txdata.Bytes()
 
 	var candidate *txmgr.TxCandidate
-	if l.Config.UseBlobs {
+	if txdata.asBlob {
 		var err error
 		if candidate, err = l.blobTxCandidate(data); err != nil {
 			// We could potentially fall through and try a calldata tx instead, but this would
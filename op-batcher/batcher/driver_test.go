@@ -0,0 +1,347 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type mockMinerAPI struct {
+	calls [][2]uint64
+	err   error
+}
+
+func (m *mockMinerAPI) SetMaxDASize(maxTxSize, maxBlockSize hexutil.Uint64) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	m.calls = append(m.calls, [2]uint64{uint64(maxTxSize), uint64(maxBlockSize)})
+	return true, nil
+}
+
+type testEndpointProvider struct {
+	ethClient dial.EthClientInterface
+}
+
+func (p *testEndpointProvider) EthClient(context.Context) (dial.EthClientInterface, error) {
+	return p.ethClient, nil
+}
+
+func (p *testEndpointProvider) RollupClient(context.Context) (dial.RollupClientInterface, error) {
+	return nil, errors.New("no rollup client")
+}
+
+func (p *testEndpointProvider) Close() {}
+
+func TestBatchSubmitter_Throttling(t *testing.T) {
+	require := require.New(t)
+	miner := new(mockMinerAPI)
+	srv := rpc.NewServer()
+	require.NoError(srv.RegisterName("miner", miner))
+	t.Cleanup(srv.Stop)
+	cl := ethclient.NewClient(rpc.DialInProc(srv))
+	t.Cleanup(cl.Close)
+
+	const threshold = 1000
+	l := NewBatchSubmitter(DriverSetup{
+		Log:  testlog.Logger(t, log.LvlCrit),
+		Metr: metrics.NoopMetrics,
+		Config: BatcherConfig{
+			NetworkTimeout:    time.Second,
+			ThrottleThreshold: threshold,
+			ThrottleTxSize:    300,
+			ThrottleBlockSize: 21_000,
+		},
+		RollupConfig:     &defaultTestRollupConfig,
+		EndpointProvider: &testEndpointProvider{ethClient: cl},
+		ChannelConfig:    defaultTestChannelConfig,
+	})
+	ctx := context.Background()
+
+	l.updateThrottling(ctx)
+	require.Empty(miner.calls, "no limits are set below the threshold")
+
+	rng := rand.New(rand.NewSource(1234))
+	for l.state.PendingDABytes() <= threshold {
+		require.NoError(l.state.AddL2Block(derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)))
+	}
+	l.updateThrottling(ctx)
+	require.True(l.throttling)
+	require.Equal([][2]uint64{{300, 21_000}}, miner.calls)
+
+	// The limits are set again while throttling, but not updated after failures.
+	miner.err = errors.New("miner error")
+	l.state.Clear()
+	l.updateThrottling(ctx)
+	require.True(l.throttling)
+	require.Len(miner.calls, 1)
+
+	// Throttling continues between the release threshold and the threshold.
+	miner.err = nil
+	for l.state.PendingDABytes() < throttleReleaseThreshold(threshold) {
+		require.NoError(l.state.AddL2Block(derivetest.RandomL2BlockWithChainId(rng, 1, defaultTestRollupConfig.L2ChainID)))
+	}
+	require.LessOrEqual(l.state.PendingDABytes(), uint64(threshold))
+	l.updateThrottling(ctx)
+	require.True(l.throttling)
+	require.Equal([][2]uint64{{300, 21_000}, {300, 21_000}}, miner.calls)
+	miner.calls = miner.calls[:1]
+	l.state.Clear()
+
+	miner.err = nil
+	l.updateThrottling(ctx)
+	require.False(l.throttling)
+	require.Equal([][2]uint64{{300, 21_000}, {0, 0}}, miner.calls)
+
+	l.updateThrottling(ctx)
+	require.Len(miner.calls, 2, "limits are only removed once")
+
+	t.Run("RemoveOnStop", func(t *testing.T) {
+		miner.calls = nil
+		l.throttling = true
+		l.removeThrottling(ctx)
+		require.False(l.throttling)
+		require.Equal([][2]uint64{{0, 0}}, miner.calls)
+
+		l.removeThrottling(ctx)
+		require.Len(miner.calls, 1, "limits are only removed while throttling")
+	})
+}
+
+func TestBatchSubmitter_ThrottlingUnsupported(t *testing.T) {
+	require := require.New(t)
+	srv := rpc.NewServer()
+	t.Cleanup(srv.Stop)
+	cl := ethclient.NewClient(rpc.DialInProc(srv))
+	t.Cleanup(cl.Close)
+
+	l := NewBatchSubmitter(DriverSetup{
+		Log:  testlog.Logger(t, log.LvlCrit),
+		Metr: metrics.NoopMetrics,
+		Config: BatcherConfig{
+			NetworkTimeout:    time.Second,
+			ThrottleThreshold: 1,
+			ThrottleTxSize:    300,
+			ThrottleBlockSize: 21_000,
+		},
+		RollupConfig:     &defaultTestRollupConfig,
+		EndpointProvider: &testEndpointProvider{ethClient: cl},
+		ChannelConfig:    defaultTestChannelConfig,
+	})
+	rng := rand.New(rand.NewSource(1234))
+	require.NoError(l.state.AddL2Block(derivetest.RandomL2BlockWithChainId(rng, 10, defaultTestRollupConfig.L2ChainID)))
+
+	l.updateThrottling(context.Background())
+	require.False(l.throttling)
+	require.True(l.throttleUnsupported, "throttling is disabled if the sequencer does not support it")
+}
+
+type stubL1Client struct {
+	headers map[uint64]*types.Header
+}
+
+func (c *stubL1Client) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if header, ok := c.headers[number.Uint64()]; ok {
+		return header, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+func TestBatchSubmitter_FinalizeConfirmations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l1 := &stubL1Client{headers: make(map[uint64]*types.Header)}
+	l1Block := func(n uint64) eth.BlockID {
+		header := &types.Header{Number: new(big.Int).SetUint64(n), Extra: testutils.RandomData(rng, 8)}
+		l1.headers[n] = header
+		return eth.BlockID{Number: n, Hash: header.Hash()}
+	}
+	l2Block := func(n uint64) eth.BlockID {
+		return eth.BlockID{Number: n, Hash: testutils.RandomHash(rng)}
+	}
+	l := NewBatchSubmitter(DriverSetup{
+		Log:           testlog.Logger(t, log.LvlCrit),
+		Metr:          metrics.NoopMetrics,
+		RollupConfig:  &defaultTestRollupConfig,
+		L1Client:      l1,
+		ChannelConfig: defaultTestChannelConfig,
+	})
+	ctx := context.Background()
+	finalizedRef := func(n uint64) eth.L1BlockRef {
+		return eth.L1BlockRef{Number: n, Hash: l1.headers[n].Hash()}
+	}
+
+	tip := l2Block(10)
+	first := ConfirmedChannel{LastBlock: l2Block(12), InclusionBlock: l1Block(100)}
+	second := ConfirmedChannel{LastBlock: l2Block(15), InclusionBlock: l1Block(102)}
+	l1Block(101)
+
+	t.Run("Resume", func(t *testing.T) {
+		l.resumeState = QueueState{ConfirmedTip: tip, Unfinalized: []ConfirmedChannel{first, second}}
+		require.Equal(t, tip, l.resumeTip(ctx, eth.L1BlockRef{Number: 99}), "inclusion blocks not finalized")
+		require.Equal(t, first.LastBlock, l.resumeTip(ctx, finalizedRef(101)))
+		require.Equal(t, second.LastBlock, l.resumeTip(ctx, finalizedRef(102)))
+
+		reorged := second
+		reorged.InclusionBlock.Hash = testutils.RandomHash(rng)
+		l.resumeState.Unfinalized = []ConfirmedChannel{first, reorged}
+		require.Equal(t, tip, l.resumeTip(ctx, finalizedRef(102)), "reorged inclusion block")
+	})
+
+	t.Run("Finalize", func(t *testing.T) {
+		l.state.ResumeFrom(tip)
+		l.state.unfinalized = []ConfirmedChannel{first, second}
+		l.finalizeConfirmations(ctx, eth.L1BlockRef{Number: 99})
+		require.Equal(t, QueueState{ConfirmedTip: tip, Unfinalized: []ConfirmedChannel{first, second}}, l.state.QueueState())
+
+		l.finalizeConfirmations(ctx, finalizedRef(101))
+		require.Equal(t, QueueState{ConfirmedTip: first.LastBlock, Unfinalized: []ConfirmedChannel{second}}, l.state.QueueState())
+
+		// A reorged inclusion block drops the unfinalized confirmations.
+		l1Block(102)
+		l.finalizeConfirmations(ctx, finalizedRef(102))
+		require.Equal(t, QueueState{ConfirmedTip: first.LastBlock}, l.state.QueueState())
+	})
+}
+
+type stubL2Client struct {
+	dial.EthClientInterface
+	blocks map[uint64]*types.Block
+}
+
+func (c *stubL2Client) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	if block, ok := c.blocks[number.Uint64()]; ok {
+		return block, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+// TestBatchSubmitter_RestorePendingChannels restarts the batcher in the middle of submitting a channel,
+// and ensures that only its remaining frames are submitted after the restart.
+func TestBatchSubmitter_RestorePendingChannels(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l1 := &stubL1Client{headers: make(map[uint64]*types.Header)}
+	inclusionHeader := &types.Header{Number: big.NewInt(100), Extra: testutils.RandomData(rng, 8)}
+	l1.headers[100] = inclusionHeader
+	inclusion := eth.BlockID{Number: 100, Hash: inclusionHeader.Hash()}
+
+	l2 := &stubL2Client{blocks: make(map[uint64]*types.Block)}
+	var parent common.Hash
+	for n := uint64(0); n <= 4; n++ {
+		block := newMiniL2BlockWithNumberParent(10, new(big.Int).SetUint64(n), parent)
+		l2.blocks[n] = block
+		parent = block.Hash()
+	}
+	start := eth.ToBlockID(l2.blocks[0])
+
+	cfg := defaultTestChannelConfig
+	cfg.MaxFrameSize = 100
+	persistence := NewQueuePersistence(filepath.Join(t.TempDir(), "queue.json"))
+	newBatcher := func() *BatchSubmitter {
+		return NewBatchSubmitter(DriverSetup{
+			Log:              testlog.Logger(t, log.LvlCrit),
+			Metr:             metrics.NoopMetrics,
+			Config:           BatcherConfig{NetworkTimeout: time.Second},
+			RollupConfig:     &defaultTestRollupConfig,
+			L1Client:         l1,
+			EndpointProvider: &testEndpointProvider{ethClient: l2},
+			ChannelConfig:    cfg,
+			QueuePersistence: persistence,
+		})
+	}
+	ctx := context.Background()
+
+	// Submit the first frame of a channel and send the second one, before stopping.
+	l := newBatcher()
+	l.state.ResumeFrom(start)
+	for n := uint64(1); n <= 3; n++ {
+		require.NoError(t, l.state.AddL2Block(l2.blocks[n]))
+	}
+	_, err := l.state.TxData(eth.BlockID{})
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, l.state.FlushCurrentChannel())
+	first, err := l.state.TxData(eth.BlockID{})
+	require.NoError(t, err)
+	l.state.TxConfirmed(first.ID(), inclusion)
+	inFlight, err := l.state.TxData(eth.BlockID{})
+	require.NoError(t, err)
+	var remaining []txData
+	for {
+		txdata, err := l.state.TxData(eth.BlockID{})
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		remaining = append(remaining, txdata)
+	}
+	require.NotEmpty(t, remaining, "channel should have more than two frames")
+	l.persistQueueState()
+
+	t.Run("Restore", func(t *testing.T) {
+		l := newBatcher()
+		l.resumeState = l.loadQueueState()
+		tip, err := l.restorePendingChannels(ctx, start)
+		require.NoError(t, err)
+		require.Equal(t, eth.ToBlockID(l2.blocks[3]), tip)
+
+		// Only the frames that were not confirmed before the restart are submitted.
+		for _, expected := range append([]txData{inFlight}, remaining...) {
+			txdata, err := l.state.TxData(eth.BlockID{})
+			require.NoError(t, err)
+			require.Equal(t, expected.ID(), txdata.ID())
+			require.Equal(t, expected.Bytes(), txdata.Bytes())
+			l.state.TxConfirmed(txdata.ID(), inclusion)
+		}
+		_, err = l.state.TxData(eth.BlockID{})
+		require.ErrorIs(t, err, io.EOF)
+		require.Equal(t, []ConfirmedChannel{{LastBlock: tip, InclusionBlock: inclusion}}, l.state.UnfinalizedConfirmations())
+		require.NoError(t, l.state.AddL2Block(l2.blocks[4]), "next block must extend the restored channels")
+	})
+
+	t.Run("StartMismatch", func(t *testing.T) {
+		l := newBatcher()
+		l.resumeState = l.loadQueueState()
+		_, err := l.restorePendingChannels(ctx, eth.ToBlockID(l2.blocks[1]))
+		require.ErrorContains(t, err, "doesn't follow start block")
+		require.Empty(t, l.state.QueueState().Pending)
+	})
+
+	t.Run("ReorgedInclusionBlock", func(t *testing.T) {
+		l := newBatcher()
+		l.resumeState = l.loadQueueState()
+		l1.headers[100] = &types.Header{Number: big.NewInt(100), Extra: testutils.RandomData(rng, 8)}
+		t.Cleanup(func() { l1.headers[100] = inclusionHeader })
+		_, err := l.restorePendingChannels(ctx, start)
+		require.ErrorIs(t, err, errInclusionBlockReorged)
+	})
+
+	t.Run("ReorgedL2Block", func(t *testing.T) {
+		l := newBatcher()
+		l.resumeState = l.loadQueueState()
+		reorged := l2.blocks[2]
+		l2.blocks[2] = newMiniL2BlockWithNumberParent(1, big.NewInt(2), l2.blocks[1].Hash())
+		t.Cleanup(func() { l2.blocks[2] = reorged })
+		_, err := l.restorePendingChannels(ctx, start)
+		require.ErrorIs(t, err, ErrReorg)
+	})
+}
@@ -0,0 +1,205 @@
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+// QueueState is the state of the batcher queue that is persisted, so that a restarted batcher
+// resumes where it left off instead of resubmitting all blocks since the safe head.
+type QueueState struct {
+	// ConfirmedTip is the last L2 block of which all batch data is confirmed in finalized L1 blocks.
+	// It is empty if no channel was fully confirmed yet.
+	ConfirmedTip eth.BlockID `json:"confirmedTip"`
+	// Unfinalized are the channels after the ConfirmedTip, in order, that are fully confirmed on L1,
+	// but in L1 blocks that were not finalized yet. A restarted batcher only resumes after them
+	// if their inclusion blocks are finalized and canonical by then.
+	Unfinalized []ConfirmedChannel `json:"unfinalized,omitempty"`
+	// Pending are the closed channels, in order, of which not all frames are confirmed yet.
+	// A restarted batcher submits their remaining frames, instead of building new channels from their
+	// blocks, if it starts right before their first block and their blocks are still canonical.
+	// The open channel is not persisted, since its compression state can't be resumed.
+	Pending []PendingChannel `json:"pending,omitempty"`
+}
+
+// ConfirmedChannel is a channel of which all frames are confirmed on L1.
+type ConfirmedChannel struct {
+	// LastBlock is the last L2 block of the channel.
+	LastBlock eth.BlockID `json:"lastBlock"`
+	// InclusionBlock is the L1 block that the last frame of the channel was included in.
+	InclusionBlock eth.BlockID `json:"inclusionBlock"`
+}
+
+// PendingChannel is a closed channel of which not all frames are confirmed on L1 yet.
+type PendingChannel struct {
+	ID derive.ChannelID `json:"id"`
+	// FirstBlock and LastBlock are the first and last L2 blocks of the channel.
+	FirstBlock eth.BlockID `json:"firstBlock"`
+	LastBlock  eth.BlockID `json:"lastBlock"`
+	// UseBlobs is whether the frames of the channel are submitted in blobs instead of calldata.
+	UseBlobs bool `json:"useBlobs"`
+	// Frames are all frames of the channel, in order.
+	Frames []PendingFrame `json:"frames"`
+}
+
+// PendingFrame is a frame of a pending channel. Only frames that are not confirmed yet keep their data.
+type PendingFrame struct {
+	Number uint16 `json:"number"`
+	// Data is the frame data, if the frame is not confirmed yet.
+	// It is persisted in binary form in a separate file per frame, since it doesn't change once the channel is closed.
+	Data []byte `json:"-"`
+	// InclusionBlock is the L1 block that the frame was included in, if it is confirmed.
+	InclusionBlock *eth.BlockID `json:"inclusionBlock,omitempty"`
+}
+
+type QueuePersistence interface {
+	Persist(state QueueState) error
+	Load() (QueueState, error)
+}
+
+var _ QueuePersistence = (*ActiveQueuePersistence)(nil)
+var _ QueuePersistence = DisabledQueuePersistence{}
+
+type ActiveQueuePersistence struct {
+	lock sync.Mutex
+	file string
+	// index is the encoded queue state without frame data that was last persisted.
+	index []byte
+	// frameFiles are the frame data files that exist in the frames dir.
+	frameFiles map[string]bool
+}
+
+func NewQueuePersistence(file string) *ActiveQueuePersistence {
+	return &ActiveQueuePersistence{file: file, frameFiles: make(map[string]bool)}
+}
+
+// Persist writes the queue state, if it changed since it was last persisted.
+// The data of the unconfirmed frames is written to a binary file per frame once, before the index of channels and
+// frames referencing it is written. Frame files that are no longer referenced are removed afterwards.
+// All files are written atomically, so the persisted state isn't corrupted by IO errors during writing.
+func (p *ActiveQueuePersistence) Persist(state QueueState) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	index, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal queue state: %w", err)
+	}
+	if bytes.Equal(index, p.index) {
+		return nil
+	}
+	if err := os.MkdirAll(p.framesDir(), 0755); err != nil {
+		return fmt.Errorf("create queue state dir (%v): %w", p.file, err)
+	}
+	referenced := make(map[string]bool)
+	for _, ch := range state.Pending {
+		for _, frame := range ch.Frames {
+			if frame.InclusionBlock != nil {
+				continue
+			}
+			name := frameFileName(ch.ID, frame.Number)
+			referenced[name] = true
+			if p.frameFiles[name] {
+				continue
+			}
+			if err := writeFrameFile(filepath.Join(p.framesDir(), name), frame.Data); err != nil {
+				return fmt.Errorf("write frame data (%v): %w", name, err)
+			}
+			p.frameFiles[name] = true
+		}
+	}
+	if err := ioutil.WriteAtomicJSON(p.file, state, 0644); err != nil {
+		return fmt.Errorf("write queue state (%v): %w", p.file, err)
+	}
+	p.index = index
+	for name := range p.frameFiles {
+		if referenced[name] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(p.framesDir(), name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove frame data (%v): %w", name, err)
+		}
+		delete(p.frameFiles, name)
+	}
+	return nil
+}
+
+// Load reads the persisted queue state. It returns an empty state if none was persisted yet.
+func (p *ActiveQueuePersistence) Load() (QueueState, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	// Track existing frame files, so that those not referenced by the state are removed when it is next persisted.
+	entries, err := os.ReadDir(p.framesDir())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return QueueState{}, fmt.Errorf("read frames dir (%v): %w", p.framesDir(), err)
+	}
+	for _, entry := range entries {
+		p.frameFiles[entry.Name()] = true
+	}
+	data, err := os.ReadFile(p.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return QueueState{}, nil
+	} else if err != nil {
+		return QueueState{}, fmt.Errorf("read queue state file (%v): %w", p.file, err)
+	}
+	var state QueueState
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err = dec.Decode(&state); err != nil {
+		return QueueState{}, fmt.Errorf("invalid queue state file (%v): %w", p.file, err)
+	}
+	for _, ch := range state.Pending {
+		for i, frame := range ch.Frames {
+			if frame.InclusionBlock != nil {
+				continue
+			}
+			name := frameFileName(ch.ID, frame.Number)
+			data, err := os.ReadFile(filepath.Join(p.framesDir(), name))
+			if err != nil {
+				return QueueState{}, fmt.Errorf("read frame data (%v): %w", name, err)
+			}
+			ch.Frames[i].Data = data
+		}
+	}
+	return state, nil
+}
+
+// framesDir is the directory that the frame data files are stored in, next to the queue state file.
+func (p *ActiveQueuePersistence) framesDir() string {
+	return p.file + ".frames"
+}
+
+func frameFileName(id derive.ChannelID, number uint16) string {
+	return fmt.Sprintf("%x-%d.bin", id[:], number)
+}
+
+func writeFrameFile(path string, data []byte) error {
+	out, err := ioutil.NewAtomicWriterCompressed(path, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write(data); err != nil {
+		_ = out.Abort()
+		return err
+	}
+	return out.Close()
+}
+
+// DisabledQueuePersistence doesn't persist the queue state, so the batcher always starts at the safe head.
+type DisabledQueuePersistence struct{}
+
+func (d DisabledQueuePersistence) Persist(QueueState) error {
+	return nil
+}
+
+func (d DisabledQueuePersistence) Load() (QueueState, error) {
+	return QueueState{}, nil
+}
@@ -0,0 +1,141 @@
+package batcher
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestActiveQueuePersistence(t *testing.T) {
+	create := func() *ActiveQueuePersistence {
+		return NewQueuePersistence(filepath.Join(t.TempDir(), "batcher", "queue.json"))
+	}
+
+	t.Run("EmptyWhenFileDoesNotExist", func(t *testing.T) {
+		state, err := create().Load()
+		require.NoError(t, err)
+		require.Equal(t, QueueState{}, state)
+	})
+
+	t.Run("PersistAndLoad", func(t *testing.T) {
+		p1 := create()
+		rng := rand.New(rand.NewSource(1234))
+		block := func(n uint64) eth.BlockID {
+			return eth.BlockID{Number: n, Hash: testutils.RandomHash(rng)}
+		}
+		inclusion := block(102)
+		state := QueueState{
+			ConfirmedTip: block(10),
+			Unfinalized: []ConfirmedChannel{
+				{LastBlock: block(12), InclusionBlock: block(100)},
+				{LastBlock: block(15), InclusionBlock: block(101)},
+			},
+			Pending: []PendingChannel{{
+				ID:         derive.ChannelID{0x01, 0x02},
+				FirstBlock: block(16),
+				LastBlock:  block(18),
+				UseBlobs:   true,
+				Frames: []PendingFrame{
+					{Number: 0, InclusionBlock: &inclusion},
+					{Number: 1, Data: []byte{0xaa, 0xbb}},
+				},
+			}},
+		}
+		require.NoError(t, p1.Persist(state))
+
+		p2 := NewQueuePersistence(p1.file)
+		loaded, err := p2.Load()
+		require.NoError(t, err)
+		require.Equal(t, state, loaded)
+
+		// Persisting again replaces the previous state.
+		require.NoError(t, p2.Persist(QueueState{ConfirmedTip: block(13)}))
+		loaded, err = p1.Load()
+		require.NoError(t, err)
+		require.Equal(t, uint64(13), loaded.ConfirmedTip.Number)
+		require.Empty(t, loaded.Unfinalized)
+		require.Empty(t, loaded.Pending)
+	})
+
+	t.Run("StoresFrameDataSeparately", func(t *testing.T) {
+		p := create()
+		inclusion := eth.BlockID{Number: 102}
+		unconfirmed := QueueState{Pending: []PendingChannel{{
+			ID:         derive.ChannelID{0x01},
+			FirstBlock: eth.BlockID{Number: 16},
+			LastBlock:  eth.BlockID{Number: 18},
+			Frames:     []PendingFrame{{Number: 0, Data: []byte{0xaa, 0xbb}}},
+		}}}
+		require.NoError(t, p.Persist(unconfirmed))
+		index, err := os.ReadFile(p.file)
+		require.NoError(t, err)
+		require.NotContains(t, string(index), "aabb", "should not store frame data in the index")
+		frameFile := filepath.Join(p.framesDir(), frameFileName(derive.ChannelID{0x01}, 0))
+		data, err := os.ReadFile(frameFile)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0xaa, 0xbb}, data)
+
+		confirmed := unconfirmed
+		confirmed.Pending = []PendingChannel{unconfirmed.Pending[0]}
+		confirmed.Pending[0].Frames = []PendingFrame{{Number: 0, InclusionBlock: &inclusion}}
+		require.NoError(t, p.Persist(confirmed))
+		require.NoFileExists(t, frameFile, "should remove data of confirmed frames")
+	})
+
+	t.Run("SkipsUnchangedState", func(t *testing.T) {
+		p := create()
+		state := QueueState{ConfirmedTip: eth.BlockID{Number: 10}}
+		require.NoError(t, p.Persist(state))
+		require.NoError(t, os.Remove(p.file))
+		require.NoError(t, p.Persist(state))
+		require.NoFileExists(t, p.file, "should not write unchanged state again")
+
+		require.NoError(t, p.Persist(QueueState{ConfirmedTip: eth.BlockID{Number: 11}}))
+		require.FileExists(t, p.file)
+	})
+
+	t.Run("RemovesUnreferencedFrameFiles", func(t *testing.T) {
+		p := create()
+		require.NoError(t, os.MkdirAll(p.framesDir(), 0755))
+		stale := filepath.Join(p.framesDir(), frameFileName(derive.ChannelID{0x02}, 3))
+		require.NoError(t, os.WriteFile(stale, []byte{0x01}, 0644))
+		_, err := p.Load()
+		require.NoError(t, err)
+		require.NoError(t, p.Persist(QueueState{ConfirmedTip: eth.BlockID{Number: 10}}))
+		require.NoFileExists(t, stale)
+	})
+
+	t.Run("MissingFrameData", func(t *testing.T) {
+		p := create()
+		require.NoError(t, p.Persist(QueueState{Pending: []PendingChannel{{
+			ID:     derive.ChannelID{0x01},
+			Frames: []PendingFrame{{Number: 0, Data: []byte{0xaa}}},
+		}}}))
+		require.NoError(t, os.RemoveAll(p.framesDir()))
+		_, err := NewQueuePersistence(p.file).Load()
+		require.ErrorContains(t, err, "read frame data")
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		p := create()
+		require.NoError(t, os.MkdirAll(filepath.Dir(p.file), 0755))
+		require.NoError(t, os.WriteFile(p.file, []byte(`{"unknown":1}`), 0644))
+		_, err := p.Load()
+		require.ErrorContains(t, err, "invalid queue state file")
+	})
+}
+
+func TestDisabledQueuePersistence(t *testing.T) {
+	p := DisabledQueuePersistence{}
+	require.NoError(t, p.Persist(QueueState{ConfirmedTip: eth.BlockID{Number: 1}}))
+	state, err := p.Load()
+	require.NoError(t, err)
+	require.Equal(t, QueueState{}, state)
+}
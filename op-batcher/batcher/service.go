@@ -19,12 +19,14 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/shutdown"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -37,8 +39,12 @@ type BatcherConfig struct {
 	PollInterval           time.Duration
 	MaxPendingTransactions uint64
 
-	// UseBlobs is true if the batcher should use blobs instead of calldata for posting blobs
-	UseBlobs bool
+	// ThrottleThreshold is the estimated batch size of the unsubmitted L2 blocks, above which the
+	// sequencer is asked to limit the data availability size of its blocks. Disabled if 0.
+	ThrottleThreshold uint64
+	// ThrottleTxSize and ThrottleBlockSize are the data availability size limits while throttling.
+	ThrottleTxSize    uint64
+	ThrottleBlockSize uint64
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -49,6 +55,7 @@ type BatcherService struct {
 	L1Client         *ethclient.Client
 	EndpointProvider dial.L2EndpointProvider
 	TxManager        txmgr.TxManager
+	QueuePersistence QueuePersistence
 
 	BatcherConfig
 
@@ -56,6 +63,9 @@ type BatcherService struct {
 
 	// Channel builder parameters
 	ChannelConfig ChannelConfig
+	// ChannelConfigProvider provides the config of each new channel. It is ChannelConfig itself,
+	// unless the data availability type is selected by the L1 fees.
+	ChannelConfigProvider ChannelConfigProvider
 
 	driver *BatchSubmitter
 
@@ -92,6 +102,9 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.PollInterval = cfg.PollInterval
 	bs.MaxPendingTransactions = cfg.MaxPendingTransactions
 	bs.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
+	bs.ThrottleThreshold = cfg.ThrottleThreshold
+	bs.ThrottleTxSize = cfg.ThrottleTxSize
+	bs.ThrottleBlockSize = cfg.ThrottleBlockSize
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
@@ -141,6 +154,13 @@ func (bs *BatcherService) initRPCClients(ctx context.Context, cfg *CLIConfig) er
 	}
 	bs.EndpointProvider = endpointProvider
 
+	if cfg.QueueStateFile != "" {
+		bs.QueuePersistence = NewQueuePersistence(cfg.QueueStateFile)
+		bs.Log.Info("Persisting queue state", "file", cfg.QueueStateFile)
+	} else {
+		bs.QueuePersistence = DisabledQueuePersistence{}
+	}
+
 	return nil
 }
 
@@ -182,37 +202,51 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 		SeqWindowSize:      bs.RollupConfig.SeqWindowSize,
 		ChannelTimeout:     bs.RollupConfig.ChannelTimeout,
 		MaxChannelDuration: cfg.MaxChannelDuration,
+		MaxPendingChannels: cfg.MaxPendingChannels,
 		SubSafetyMargin:    cfg.SubSafetyMargin,
 		CompressorConfig:   cfg.CompressorConfig.Config(),
 		BatchType:          cfg.BatchType,
 	}
 
+	calldataConfig := bs.ChannelConfig
+	calldataConfig.MaxFrameSize = cfg.MaxL1TxSize - 1 // subtract 1 byte for version
+
 	switch cfg.DataAvailabilityType {
-	case flags.BlobsType:
-		bs.ChannelConfig.MaxFrameSize = eth.MaxBlobDataSize
-		bs.UseBlobs = true
+	case flags.BlobsType, flags.AutoType:
+		bs.ChannelConfig.MaxFrameSize = eth.MaxBlobDataSize - 1 // subtract 1 byte for version
+		bs.ChannelConfig.UseBlobs = true
 	case flags.CalldataType:
-		bs.ChannelConfig.MaxFrameSize = cfg.MaxL1TxSize
-		bs.UseBlobs = false
+		bs.ChannelConfig = calldataConfig
 	default:
 		return fmt.Errorf("unknown data availability type: %v", cfg.DataAvailabilityType)
 	}
-	bs.ChannelConfig.MaxFrameSize-- // subtract 1 byte for version
 
-	if bs.UseBlobs && !bs.RollupConfig.IsEcotone(uint64(time.Now().Unix())) {
-		bs.Log.Error("Cannot use Blob data before Ecotone!") // log only, the batcher may not be actively running.
-	}
-	if !bs.UseBlobs && bs.RollupConfig.IsEcotone(uint64(time.Now().Unix())) {
-		bs.Log.Warn("Ecotone upgrade is active, but batcher is not configured to use Blobs!")
+	if cfg.DataAvailabilityType == flags.AutoType {
+		// Before Ecotone, the L1 fees make the provider select calldata.
+		if err := calldataConfig.Check(); err != nil {
+			return fmt.Errorf("invalid calldata channel configuration: %w", err)
+		}
+		bs.ChannelConfigProvider = NewDynamicEthChannelConfig(bs.Log, bs.Metrics, cfg.TxMgrConfig.NetworkTimeout,
+			bs.L1Client, bs.ChannelConfig, calldataConfig)
+	} else {
+		if bs.ChannelConfig.UseBlobs && !bs.RollupConfig.IsEcotone(uint64(time.Now().Unix())) {
+			bs.Log.Error("Cannot use Blob data before Ecotone!") // log only, the batcher may not be actively running.
+		}
+		if !bs.ChannelConfig.UseBlobs && bs.RollupConfig.IsEcotone(uint64(time.Now().Unix())) {
+			bs.Log.Warn("Ecotone upgrade is active, but batcher is not configured to use Blobs!")
+		}
+		bs.ChannelConfigProvider = bs.ChannelConfig
 	}
 
 	if err := bs.ChannelConfig.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
 	}
 	bs.Log.Info("Initialized channel-config",
-		"use_blobs", bs.UseBlobs,
+		"data_availability_type", cfg.DataAvailabilityType,
+		"use_blobs", bs.ChannelConfig.UseBlobs,
 		"max_frame_size", bs.ChannelConfig.MaxFrameSize,
 		"max_channel_duration", bs.ChannelConfig.MaxChannelDuration,
+		"max_pending_channels", bs.ChannelConfig.MaxPendingChannels,
 		"channel_timeout", bs.ChannelConfig.ChannelTimeout,
 		"batch_type", bs.ChannelConfig.BatchType,
 		"sub_safety_margin", bs.ChannelConfig.SubSafetyMargin)
@@ -229,14 +263,7 @@ func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
 }
 
 func (bs *BatcherService) initPProf(cfg *CLIConfig) error {
-	bs.pprofService = oppprof.New(
-		cfg.PprofConfig.ListenEnabled,
-		cfg.PprofConfig.ListenAddr,
-		cfg.PprofConfig.ListenPort,
-		cfg.PprofConfig.ProfileType,
-		cfg.PprofConfig.ProfileDir,
-		cfg.PprofConfig.ProfileFilename,
-	)
+	bs.pprofService = oppprof.NewFromCLIConfig(cfg.PprofConfig)
 
 	if err := bs.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -271,18 +298,30 @@ func (bs *BatcherService) initDriver() {
 		RollupConfig:     bs.RollupConfig,
 		Config:           bs.BatcherConfig,
 		Txmgr:            bs.TxManager,
-		L1Client:         bs.L1Client,
+		L1Client:         client.NewInstrumentedClient(bs.L1Client.Client(), bs.Metrics),
 		EndpointProvider: bs.EndpointProvider,
-		ChannelConfig:    bs.ChannelConfig,
+		ChannelConfig:    bs.ChannelConfigProvider,
+		QueuePersistence: bs.QueuePersistence,
 	})
 }
 
 func (bs *BatcherService) initRPCServer(cfg *CLIConfig) error {
+	opts := []oprpc.ServerOption{oprpc.WithLogger(bs.Log)}
+	if cfg.AdminJWTSecretPath != "" {
+		secret, err := oprpc.ReadJWTSecret(cfg.AdminJWTSecretPath)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, oprpc.WithJWTSecret(secret[:]))
+		bs.Log.Info("RPC authentication enabled")
+	} else if cfg.RPC.EnableAdmin {
+		bs.Log.Warn("Admin RPC enabled without authentication, consider configuring a JWT secret")
+	}
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		bs.Version,
-		oprpc.WithLogger(bs.Log),
+		opts...,
 	)
 	if cfg.RPC.EnableAdmin {
 		adminAPI := rpc.NewAdminAPI(bs.driver, bs.Metrics, bs.Log)
@@ -335,48 +374,42 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 		bs.TxManager.Close()
 	}
 
-	var result error
-	if bs.driver != nil {
-		if err := bs.driver.StopBatchSubmittingIfRunning(ctx); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to stop batch submitting: %w", err))
-		}
-	}
+	result := bs.shutdownManager().Stop(ctx)
 
-	if bs.rpcServer != nil {
-		// TODO(7685): the op-service RPC server is not built on top of op-service httputil Server, and has poor shutdown
-		if err := bs.rpcServer.Stop(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to stop RPC server: %w", err))
-		}
-	}
-	if bs.pprofService != nil {
-		if err := bs.pprofService.Stop(ctx); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to stop PProf server: %w", err))
-		}
-	}
-	if bs.balanceMetricer != nil {
-		if err := bs.balanceMetricer.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close balance metricer: %w", err))
-		}
+	if result == nil {
+		bs.stopped.Store(true)
+		bs.Log.Info("Batch Submitter stopped")
 	}
+	return result
+}
 
+// shutdownManager registers the initialized subsystems of the batcher, after the subsystems they use.
+func (bs *BatcherService) shutdownManager() *shutdown.Manager {
+	m := shutdown.NewManager(bs.Log)
 	if bs.metricsSrv != nil {
-		if err := bs.metricsSrv.Stop(ctx); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to stop metrics server: %w", err))
-		}
+		m.Register("metrics-server", bs.metricsSrv.Stop)
+	}
+	if bs.pprofService != nil {
+		m.Register("pprof", bs.pprofService.Stop)
 	}
-
 	if bs.L1Client != nil {
-		bs.L1Client.Close()
+		m.Register("l1-client", shutdown.Closer(bs.L1Client.Close))
 	}
 	if bs.EndpointProvider != nil {
-		bs.EndpointProvider.Close()
+		m.Register("l2-endpoints", shutdown.Closer(bs.EndpointProvider.Close))
 	}
-
-	if result == nil {
-		bs.stopped.Store(true)
-		bs.Log.Info("Batch Submitter stopped")
+	if bs.balanceMetricer != nil {
+		m.Register("balance-metrics", shutdown.ErrCloser(bs.balanceMetricer.Close), shutdown.DependsOn("l1-client"))
 	}
-	return result
+	if bs.driver != nil {
+		m.Register("batch-submitter", bs.driver.StopBatchSubmittingIfRunning,
+			shutdown.DependsOn("l1-client", "l2-endpoints", "metrics-server"))
+	}
+	if bs.rpcServer != nil {
+		// TODO(7685): the op-service RPC server is not built on top of op-service httputil Server, and has poor shutdown
+		m.Register("rpc-server", shutdown.ErrCloser(bs.rpcServer.Stop), shutdown.DependsOn("batch-submitter"))
+	}
+	return m
 }
 
 var _ cliapp.Lifecycle = (*BatcherService)(nil)
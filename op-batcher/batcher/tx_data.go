@@ -13,6 +13,8 @@ import (
 // different channels.
 type txData struct {
 	frame frameData
+	// asBlob is true if the data is submitted in a blob instead of calldata.
+	asBlob bool
 }
 
 // ID returns the id for this transaction data. It can be used as a map key.
@@ -1,9 +1,12 @@
 package compressor
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	"github.com/urfave/cli/v2"
 )
 
@@ -12,6 +15,8 @@ const (
 	TargetNumFramesFlagName     = "target-num-frames"
 	ApproxComprRatioFlagName    = "approx-compr-ratio"
 	KindFlagName                = "compressor"
+	CompressionAlgoFlagName     = "compression-algo"
+	CompareAlgoFlagName         = "compression-compare-algo"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -40,6 +45,19 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSOR"),
 			Value:   ShadowKind,
 		},
+		&cli.StringFlag{
+			Name: CompressionAlgoFlagName,
+			Usage: "The algorithm to compress channels with. Channels are compressed with zlib until zstd channels are active (rollup config zstd_channel_time). " +
+				"Valid options: " + openum.EnumString(derive.CompressionAlgos),
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSION_ALGO"),
+			Value:   derive.Zlib.String(),
+		},
+		&cli.StringFlag{
+			Name: CompareAlgoFlagName,
+			Usage: "An alternative algorithm to compress channels with as well, only to compare its output size in the metrics. " +
+				"Valid options: " + openum.EnumString(derive.CompressionAlgos),
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSION_COMPARE_ALGO"),
+		},
 	}
 }
 
@@ -57,6 +75,20 @@ type CLIConfig struct {
 	ApproxComprRatio float64
 	// Type of compressor to use. Must be one of KindKeys.
 	Kind string
+	// CompressionAlgo to compress channels with. Zlib if unset.
+	CompressionAlgo derive.CompressionAlgo
+	// CompareAlgo is an alternative compression algorithm to compare the output size with. Optional.
+	CompareAlgo derive.CompressionAlgo
+}
+
+func (c *CLIConfig) Check() error {
+	if c.CompressionAlgo != "" && !derive.ValidCompressionAlgo(c.CompressionAlgo) {
+		return fmt.Errorf("unknown compression algorithm: %q", c.CompressionAlgo)
+	}
+	if c.CompareAlgo != "" && !derive.ValidCompressionAlgo(c.CompareAlgo) {
+		return fmt.Errorf("unknown compression algorithm to compare: %q", c.CompareAlgo)
+	}
+	return nil
 }
 
 func (c *CLIConfig) Config() Config {
@@ -65,6 +97,8 @@ func (c *CLIConfig) Config() Config {
 		TargetNumFrames:  c.TargetNumFrames,
 		ApproxComprRatio: c.ApproxComprRatio,
 		Kind:             c.Kind,
+		CompressionAlgo:  c.CompressionAlgo,
+		CompareAlgo:      c.CompareAlgo,
 	}
 }
 
@@ -74,5 +108,7 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		TargetL1TxSizeBytes: ctx.Uint64(TargetL1TxSizeBytesFlagName),
 		TargetNumFrames:     ctx.Int(TargetNumFramesFlagName),
 		ApproxComprRatio:    ctx.Float64(ApproxComprRatioFlagName),
+		CompressionAlgo:     derive.CompressionAlgo(ctx.String(CompressionAlgoFlagName)),
+		CompareAlgo:         derive.CompressionAlgo(ctx.String(CompareAlgoFlagName)),
 	}
 }
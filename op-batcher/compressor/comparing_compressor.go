@@ -0,0 +1,68 @@
+package compressor
+
+import (
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// ComparingCompressor wraps a derive.Compressor, and compresses all data written to it with
+// an alternative compression algorithm as well. Only the size of the alternative output is
+// tracked, so it can be compared to the output of the wrapped compressor.
+type ComparingCompressor struct {
+	derive.Compressor
+
+	algo     derive.CompressionAlgo
+	counter  countingWriter
+	compress derive.CompressionWriter
+	err      error
+}
+
+// NewComparingCompressor creates a ComparingCompressor that compares the output of the given
+// compressor to the output of the given alternative compression algorithm.
+func NewComparingCompressor(comp derive.Compressor, algo derive.CompressionAlgo) (*ComparingCompressor, error) {
+	c := &ComparingCompressor{
+		Compressor: comp,
+		algo:       algo,
+	}
+	var err error
+	c.compress, err = derive.NewCompressionWriter(algo, &c.counter)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (t *ComparingCompressor) Write(p []byte) (int, error) {
+	n, err := t.Compressor.Write(p)
+	if n > 0 && t.err == nil {
+		_, t.err = t.compress.Write(p[:n])
+	}
+	return n, err
+}
+
+func (t *ComparingCompressor) Close() error {
+	if t.err == nil {
+		t.err = t.compress.Close()
+	}
+	return t.Compressor.Close()
+}
+
+func (t *ComparingCompressor) Reset() {
+	t.Compressor.Reset()
+	t.counter = 0
+	t.compress.Reset(&t.counter)
+	t.err = nil
+}
+
+// Comparison returns the alternative compression algorithm, and the size of its output.
+// The size is only final after Close. It returns an error if the alternative compression failed.
+func (t *ComparingCompressor) Comparison() (derive.CompressionAlgo, int, error) {
+	return t.algo, int(t.counter), t.err
+}
+
+// countingWriter discards all data written to it, and only counts its size.
+type countingWriter int
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,63 @@
+package compressor_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+func TestComparingCompressor(t *testing.T) {
+	c, err := compressor.Config{
+		TargetFrameSize: 1 << 20,
+		TargetNumFrames: 1,
+		Kind:            compressor.ShadowKind,
+		CompareAlgo:     derive.Zstd,
+	}.NewCompressor()
+	require.NoError(t, err)
+	cc, ok := c.(*compressor.ComparingCompressor)
+	require.True(t, ok, "compressor must compare algorithms")
+
+	// Write compressible data, so that both algorithms' outputs are much smaller than the input.
+	data := bytes.Repeat([]byte("channel data "), 1000)
+	_, err = cc.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, cc.Close())
+
+	algo, size, err := cc.Comparison()
+	require.NoError(t, err)
+	require.Equal(t, derive.Zstd, algo)
+	require.Positive(t, size)
+	require.Less(t, size, len(data)/10)
+	require.Positive(t, cc.Len())
+
+	cc.Reset()
+	_, size, err = cc.Comparison()
+	require.NoError(t, err)
+	require.Equal(t, 1, size, "only the zstd version byte after reset")
+	require.Zero(t, cc.Len())
+
+	// Random data is incompressible with either algorithm.
+	random := make([]byte, 1000)
+	_, err = rand.Read(random)
+	require.NoError(t, err)
+	_, err = cc.Write(random)
+	require.NoError(t, err)
+	require.NoError(t, cc.Close())
+	_, size, err = cc.Comparison()
+	require.NoError(t, err)
+	require.Greater(t, size, len(random))
+}
+
+func TestComparingCompressorUnknownAlgo(t *testing.T) {
+	_, err := compressor.Config{
+		TargetFrameSize: 1 << 20,
+		TargetNumFrames: 1,
+		CompareAlgo:     "brotli",
+	}.NewCompressor()
+	require.Error(t, err)
+}
@@ -19,12 +19,30 @@ type Config struct {
 	// Kind of compressor to use. Must be one of KindKeys. If unset, NewCompressor
 	// will default to RatioKind.
 	Kind string
+	// CompressionAlgo to compress the channel data with. If unset, zlib is used.
+	// The NoneKind compressor always uses zlib, without compression.
+	CompressionAlgo derive.CompressionAlgo
+	// CompareAlgo is an alternative compression algorithm to compress the channel data with as well,
+	// to compare its output size. The output of the alternative is discarded. Disabled if unset.
+	CompareAlgo derive.CompressionAlgo
 }
 
 func (c Config) NewCompressor() (derive.Compressor, error) {
-	if k, ok := Kinds[c.Kind]; ok {
-		return k(c)
+	k, ok := Kinds[c.Kind]
+	if !ok {
+		// default to RatioCompressor
+		k = Kinds[RatioKind]
 	}
-	// default to RatioCompressor
-	return Kinds[RatioKind](c)
+	comp, err := k(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.CompareAlgo == "" {
+		return comp, nil
+	}
+	cc, err := NewComparingCompressor(comp, c.CompareAlgo)
+	if err != nil {
+		return nil, err
+	}
+	return cc, nil
 }
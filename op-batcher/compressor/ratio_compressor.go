@@ -2,7 +2,6 @@ package compressor
 
 import (
 	"bytes"
-	"compress/zlib"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
@@ -12,7 +11,7 @@ type RatioCompressor struct {
 
 	inputBytes int
 	buf        bytes.Buffer
-	compress   *zlib.Writer
+	compress   derive.CompressionWriter
 }
 
 // NewRatioCompressor creates a new derive.Compressor implementation that uses the target
@@ -25,7 +24,7 @@ func NewRatioCompressor(config Config) (derive.Compressor, error) {
 		config: config,
 	}
 
-	compress, err := zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	compress, err := derive.NewCompressionWriter(config.CompressionAlgo, &c.buf)
 	if err != nil {
 		return nil, err
 	}
@@ -2,7 +2,6 @@ package compressor
 
 import (
 	"bytes"
-	"compress/zlib"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
@@ -22,10 +21,10 @@ type ShadowCompressor struct {
 	config Config
 
 	buf      bytes.Buffer
-	compress *zlib.Writer
+	compress derive.CompressionWriter
 
 	shadowBuf      bytes.Buffer
-	shadowCompress *zlib.Writer
+	shadowCompress derive.CompressionWriter
 
 	fullErr error
 
@@ -45,11 +44,11 @@ func NewShadowCompressor(config Config) (derive.Compressor, error) {
 	}
 
 	var err error
-	c.compress, err = zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	c.compress, err = derive.NewCompressionWriter(config.CompressionAlgo, &c.buf)
 	if err != nil {
 		return nil, err
 	}
-	c.shadowCompress, err = zlib.NewWriterLevel(&c.shadowBuf, zlib.BestCompression)
+	c.shadowCompress, err = derive.NewCompressionWriter(config.CompressionAlgo, &c.shadowBuf)
 	if err != nil {
 		return nil, err
 	}
@@ -66,6 +66,13 @@ var (
 		Value:   0,
 		EnvVars: prefixEnvVars("MAX_CHANNEL_DURATION"),
 	}
+	MaxPendingChannelsFlag = &cli.Uint64Flag{
+		Name: "max-pending-channels",
+		Usage: "The maximum number of channels that are open or have frames left to submit. " +
+			"If greater than 0, the next channels are built while earlier channels are submitted. 0 to disable pipelining.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_PENDING_CHANNELS"),
+	}
 	MaxL1TxSizeBytesFlag = &cli.Uint64Flag{
 		Name:    "max-l1-tx-size-bytes",
 		Usage:   "The maximum size of a batch tx submitted to L1.",
@@ -93,6 +100,40 @@ var (
 		}(),
 		EnvVars: prefixEnvVars("DATA_AVAILABILITY_TYPE"),
 	}
+	AdminJWTSecretFlag = &cli.StringFlag{
+		Name: "admin.jwt-secret",
+		Usage: "Path to a file with a hex-encoded 32 byte JWT secret. If set, RPC requests must be authenticated " +
+			"with a JWT signed by it. Recommended when the admin API is enabled.",
+		EnvVars:   prefixEnvVars("ADMIN_JWT_SECRET"),
+		TakesFile: true,
+	}
+	QueueStateFileFlag = &cli.StringFlag{
+		Name: "queue-state-file",
+		Usage: "File to persist the batcher queue state in, so that a restarted batcher resumes after the last " +
+			"L2 block confirmed in finalized L1 blocks, instead of resubmitting all blocks since the safe head. Disabled if empty.",
+		EnvVars:   prefixEnvVars("QUEUE_STATE_FILE"),
+		TakesFile: true,
+	}
+	ThrottleThresholdFlag = &cli.Uint64Flag{
+		Name: "throttle-threshold",
+		Usage: "Estimated batch size in bytes of the L2 blocks not yet submitted to L1, above which the sequencer is " +
+			"asked to limit the data availability size of its blocks, until it drops below three quarters of the threshold. " +
+			"Requires miner_setMaxDASize support of the sequencer's execution engine, throttling is disabled if it is not supported. 0 to disable.",
+		Value:   0,
+		EnvVars: prefixEnvVars("THROTTLE_THRESHOLD"),
+	}
+	ThrottleTxSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-tx-size",
+		Usage:   "The data availability size limit of a single transaction while throttling.",
+		Value:   300,
+		EnvVars: prefixEnvVars("THROTTLE_TX_SIZE"),
+	}
+	ThrottleBlockSizeFlag = &cli.Uint64Flag{
+		Name:    "throttle-block-size",
+		Usage:   "The data availability size limit of a block while throttling.",
+		Value:   21_000,
+		EnvVars: prefixEnvVars("THROTTLE_BLOCK_SIZE"),
+	}
 	ActiveSequencerCheckDurationFlag = &cli.DurationFlag{
 		Name:    "active-sequencer-check-duration",
 		Usage:   "The duration between checks to determine the active sequencer endpoint. ",
@@ -114,11 +155,17 @@ var optionalFlags = []cli.Flag{
 	PollIntervalFlag,
 	MaxPendingTransactionsFlag,
 	MaxChannelDurationFlag,
+	MaxPendingChannelsFlag,
 	MaxL1TxSizeBytesFlag,
 	StoppedFlag,
 	SequencerHDPathFlag,
 	BatchTypeFlag,
 	DataAvailabilityTypeFlag,
+	AdminJWTSecretFlag,
+	QueueStateFileFlag,
+	ThrottleThresholdFlag,
+	ThrottleTxSizeFlag,
+	ThrottleBlockSizeFlag,
 	ActiveSequencerCheckDurationFlag,
 }
 
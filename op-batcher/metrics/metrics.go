@@ -41,12 +41,16 @@ type Metricer interface {
 	RecordChannelClosed(id derive.ChannelID, numPendingBlocks int, numFrames int, inputBytes int, outputComprBytes int, reason error)
 	RecordChannelFullySubmitted(id derive.ChannelID)
 	RecordChannelTimedOut(id derive.ChannelID)
+	RecordPendingChannels(numChannels int, numFrames int)
 
 	RecordBatchTxSubmitted()
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
 
 	RecordBlobUsedBytes(num int)
+	RecordDAType(useBlobs bool, estimatedSavings float64)
+	RecordThrottling(active bool, pendingBytes uint64)
+	RecordCompressionComparison(algo derive.CompressionAlgo, outputBytes int)
 
 	Document() []opmetrics.DocumentedMetric
 }
@@ -80,9 +84,20 @@ type Metrics struct {
 	channelInputBytesTotal  prometheus.Counter
 	channelOutputBytesTotal prometheus.Counter
 
+	pendingChannels prometheus.Gauge
+	pendingFrames   prometheus.Gauge
+
 	batcherTxEvs opmetrics.EventVec
 
 	blobUsedBytes prometheus.Histogram
+
+	daUsesBlobs        prometheus.Gauge
+	daEstimatedSavings prometheus.Gauge
+
+	throttlingActive    prometheus.Gauge
+	throttlePendingSize prometheus.Gauge
+
+	compareOutputBytesTotal *prometheus.CounterVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -185,12 +200,48 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "output_bytes_total",
 			Help:      "Total number of compressed output bytes from a channel.",
 		}),
+		pendingChannels: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "pending_channels",
+			Help:      "Number of channels that are open, or have frames left to submit.",
+		}),
+		pendingFrames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "pending_frames",
+			Help:      "Number of frames of all channels that are left to submit.",
+		}),
 		blobUsedBytes: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: ns,
 			Name:      "blob_used_bytes",
 			Help:      "Blob size in bytes being submitted.",
 			Buckets:   prometheus.LinearBuckets(0.0, eth.MaxBlobDataSize/13, 13),
 		}),
+		daUsesBlobs: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "da_uses_blobs",
+			Help:      "1 if the last created channel is submitted in blobs, 0 if in calldata.",
+		}),
+		daEstimatedSavings: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "da_estimated_savings",
+			Help:      "Estimated fraction of the L1 data cost saved by the selected data availability type over the alternative.",
+		}),
+		compareOutputBytesTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "compare_output_bytes_total",
+			Help:      "Total number of bytes that closed channels would have been compressed to with the compared algorithm, to compare to output_bytes_total.",
+		}, []string{"algo"}),
+
+		throttlingActive: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttling_active",
+			Help:      "1 if the data availability size of the sequencer's blocks is limited, because too much data is pending, 0 otherwise.",
+		}),
+		throttlePendingSize: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "throttle_pending_bytes",
+			Help:      "Estimated batch size of the L2 blocks that are not submitted to L1 yet, compared to the throttle threshold.",
+		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
 	}
@@ -278,13 +329,13 @@ func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int,
 }
 
 func (m *Metrics) RecordL2BlockInPendingQueue(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesTotal.Add(size)
 	m.pendingBlocksBytesCurrent.Add(size)
 }
 
 func (m *Metrics) RecordL2BlockInChannel(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesCurrent.Add(-1 * size)
 	// Refer to RecordL2BlocksAdded to see the current + count of bytes added to a channel
 }
@@ -302,6 +353,11 @@ func (m *Metrics) RecordChannelTimedOut(id derive.ChannelID) {
 	m.channelEvs.Record(StageTimedOut)
 }
 
+func (m *Metrics) RecordPendingChannels(numChannels int, numFrames int) {
+	m.pendingChannels.Set(float64(numChannels))
+	m.pendingFrames.Set(float64(numFrames))
+}
+
 func (m *Metrics) RecordBatchTxSubmitted() {
 	m.batcherTxEvs.Record(TxStageSubmitted)
 }
@@ -318,8 +374,30 @@ func (m *Metrics) RecordBlobUsedBytes(num int) {
 	m.blobUsedBytes.Observe(float64(num))
 }
 
-// estimateBatchSize estimates the size of the batch
-func estimateBatchSize(block *types.Block) uint64 {
+func (m *Metrics) RecordDAType(useBlobs bool, estimatedSavings float64) {
+	if useBlobs {
+		m.daUsesBlobs.Set(1)
+	} else {
+		m.daUsesBlobs.Set(0)
+	}
+	m.daEstimatedSavings.Set(estimatedSavings)
+}
+
+func (m *Metrics) RecordCompressionComparison(algo derive.CompressionAlgo, outputBytes int) {
+	m.compareOutputBytesTotal.WithLabelValues(algo.String()).Add(float64(outputBytes))
+}
+
+func (m *Metrics) RecordThrottling(active bool, pendingBytes uint64) {
+	if active {
+		m.throttlingActive.Set(1)
+	} else {
+		m.throttlingActive.Set(0)
+	}
+	m.throttlePendingSize.Set(float64(pendingBytes))
+}
+
+// EstimateBatchSize estimates the size of the batch
+func EstimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
 	for _, tx := range block.Transactions() {
 		// Don't include deposit transactions in the batch.
@@ -38,11 +38,16 @@ func (*noopMetrics) RecordChannelClosed(derive.ChannelID, int, int, int, int, er
 
 func (*noopMetrics) RecordChannelFullySubmitted(derive.ChannelID) {}
 func (*noopMetrics) RecordChannelTimedOut(derive.ChannelID)       {}
+func (*noopMetrics) RecordPendingChannels(int, int)               {}
 
-func (*noopMetrics) RecordBatchTxSubmitted() {}
-func (*noopMetrics) RecordBatchTxSuccess()   {}
-func (*noopMetrics) RecordBatchTxFailed()    {}
-func (*noopMetrics) RecordBlobUsedBytes(int) {}
+func (*noopMetrics) RecordBatchTxSubmitted()       {}
+func (*noopMetrics) RecordBatchTxSuccess()         {}
+func (*noopMetrics) RecordBatchTxFailed()          {}
+func (*noopMetrics) RecordBlobUsedBytes(int)       {}
+func (*noopMetrics) RecordDAType(bool, float64)    {}
+func (*noopMetrics) RecordThrottling(bool, uint64) {}
+
+func (*noopMetrics) RecordCompressionComparison(derive.CompressionAlgo, int) {}
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
@@ -6,6 +6,8 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
 )
@@ -13,6 +15,39 @@ import (
 type BatcherDriver interface {
 	StartBatchSubmitting() error
 	StopBatchSubmitting(ctx context.Context) error
+	PauseSubmission()
+	ResumeSubmission()
+	FlushChannel() error
+	ChannelState() ChannelState
+}
+
+// ChannelState is a snapshot of the in-memory state of the batcher.
+type ChannelState struct {
+	// Paused is true if the submission of batcher transactions is paused.
+	Paused bool `json:"paused"`
+	// BlocksPending is the number of L2 blocks that are not yet added to a channel.
+	BlocksPending int `json:"blocks_pending"`
+	// PendingChannels is the number of channels that are open, or have frames left to submit.
+	PendingChannels int `json:"pending_channels"`
+	// FramesQueued is the number of frames of all channels that are left to submit.
+	FramesQueued int `json:"frames_queued"`
+	// OpenChannel is the channel that blocks are currently added to, if any.
+	OpenChannel *OpenChannelState `json:"open_channel,omitempty"`
+	// OldestUnsubmittedBlock is the oldest L2 block that is not yet fully submitted to L1, if any.
+	OldestUnsubmittedBlock *eth.BlockID `json:"oldest_unsubmitted_block,omitempty"`
+	// OldestUnsubmittedBlockAge is the age of OldestUnsubmittedBlock, in seconds.
+	OldestUnsubmittedBlockAge uint64 `json:"oldest_unsubmitted_block_age"`
+}
+
+// OpenChannelState describes the channel that blocks are currently added to.
+type OpenChannelState struct {
+	ID     derive.ChannelID `json:"id"`
+	Blocks int              `json:"blocks"`
+	// InputBytes is the number of uncompressed bytes added to the channel.
+	InputBytes int `json:"input_bytes"`
+	// OutputBytes is the number of compressed bytes output by the channel so far.
+	OutputBytes int     `json:"output_bytes"`
+	ComprRatio  float64 `json:"compr_ratio"`
 }
 
 type adminAPI struct {
@@ -41,3 +76,23 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.StopBatchSubmitting(ctx)
 }
+
+// PauseSubmission pauses the submission of batcher transactions. L2 blocks are still loaded into channels.
+func (a *adminAPI) PauseSubmission(_ context.Context) error {
+	a.b.PauseSubmission()
+	return nil
+}
+
+func (a *adminAPI) ResumeSubmission(_ context.Context) error {
+	a.b.ResumeSubmission()
+	return nil
+}
+
+// FlushChannel closes the open channel, so that its data is submitted without waiting for it to fill up.
+func (a *adminAPI) FlushChannel(_ context.Context) error {
+	return a.b.FlushChannel()
+}
+
+func (a *adminAPI) ChannelState(_ context.Context) (ChannelState, error) {
+	return a.b.ChannelState(), nil
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"math"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	EnabledFlagName       = "api.enabled"
+	HealthEnabledFlagName = "api.health"
+	ListenAddrFlagName    = "api.addr"
+	PortFlagName          = "api.port"
+	AuthTokenFlagName     = "api.auth-token"
+	defaultListenAddr     = "127.0.0.1"
+	defaultListenPort     = 8548
+)
+
+var (
+	ErrMissingAuthToken = errors.New("missing api auth token")
+	ErrInvalidPort      = errors.New("invalid api port")
+)
+
+func DefaultCLIConfig() CLIConfig {
+	return CLIConfig{
+		Enabled:    false,
+		ListenAddr: defaultListenAddr,
+		ListenPort: defaultListenPort,
+	}
+}
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    EnabledFlagName,
+			Usage:   "Enable the operator API server",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "API_ENABLED"),
+		},
+		&cli.BoolFlag{
+			Name:    HealthEnabledFlagName,
+			Usage:   "Serve the unauthenticated /healthz and /readyz endpoints on the API listening address, even if the operator API is disabled",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "API_HEALTH"),
+		},
+		&cli.StringFlag{
+			Name:    ListenAddrFlagName,
+			Usage:   "Operator API listening address",
+			Value:   defaultListenAddr,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "API_ADDR"),
+		},
+		&cli.IntFlag{
+			Name:    PortFlagName,
+			Usage:   "Operator API listening port",
+			Value:   defaultListenPort,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "API_PORT"),
+		},
+		&cli.StringFlag{
+			Name:    AuthTokenFlagName,
+			Usage:   "Bearer token required to access the operator API",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "API_AUTH_TOKEN"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	// Enabled enables the authenticated operator API
+	Enabled bool
+	// HealthEnabled enables the unauthenticated health endpoints, which are also served if the operator API is enabled
+	HealthEnabled bool
+	ListenAddr    string
+	ListenPort    int
+	AuthToken     string
+}
+
+// ServerEnabled returns true if the API server has to be started, to serve the operator API or the health endpoints.
+func (c CLIConfig) ServerEnabled() bool {
+	return c.Enabled || c.HealthEnabled
+}
+
+func (c CLIConfig) Check() error {
+	if !c.ServerEnabled() {
+		return nil
+	}
+	if c.ListenPort < 0 || c.ListenPort > math.MaxUint16 {
+		return ErrInvalidPort
+	}
+	if c.Enabled && c.AuthToken == "" {
+		return ErrMissingAuthToken
+	}
+	return nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:       ctx.Bool(EnabledFlagName),
+		HealthEnabled: ctx.Bool(HealthEnabledFlagName),
+		ListenAddr:    ctx.String(ListenAddrFlagName),
+		ListenPort:    ctx.Int(PortFlagName),
+		AuthToken:     ctx.String(AuthTokenFlagName),
+	}
+}
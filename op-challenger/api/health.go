@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// healthCheckTimeout is the maximum time each subsystem health check may take.
+const healthCheckTimeout = 10 * time.Second
+
+// HealthCheck returns an error if the subsystem is unhealthy.
+type HealthCheck func(ctx context.Context) error
+
+// CachedHealthCheck creates a health check which reuses the result of check for ttl.
+// It is used for checks that are expensive or have side effects, such as requesting a signature from a remote
+// signer, so they aren't run on every probe. Concurrent probes wait for a single run of check.
+func CachedHealthCheck(cl clock.Clock, ttl time.Duration, check HealthCheck) HealthCheck {
+	var mu sync.Mutex
+	var checked time.Time
+	var lastErr error
+	return func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if !checked.IsZero() && cl.Now().Sub(checked) < ttl {
+			return lastErr
+		}
+		lastErr = check(ctx)
+		checked = cl.Now()
+		return lastErr
+	}
+}
+
+// SubsystemHealth is the health of a single subsystem.
+// Errors are logged rather than reported as health endpoints do not require authentication.
+type SubsystemHealth struct {
+	Healthy bool `json:"healthy"`
+}
+
+// HealthStatus is the combined health of a set of subsystems.
+type HealthStatus struct {
+	Healthy    bool                       `json:"healthy"`
+	Subsystems map[string]SubsystemHealth `json:"subsystems"`
+}
+
+type subsystem struct {
+	name     string
+	critical bool
+	check    HealthCheck
+}
+
+// HealthChecker checks the health of each of the challenger's subsystems independently.
+// Liveness only considers critical subsystems, which are expected to recover if the challenger is restarted.
+// Readiness considers all subsystems, including those that depend on external services such as the L1 node.
+type HealthChecker struct {
+	log        log.Logger
+	subsystems []subsystem
+}
+
+func NewHealthChecker(logger log.Logger) *HealthChecker {
+	return &HealthChecker{log: logger}
+}
+
+// Add registers a health check for the named subsystem.
+func (h *HealthChecker) Add(name string, critical bool, check HealthCheck) {
+	h.subsystems = append(h.subsystems, subsystem{name: name, critical: critical, check: check})
+}
+
+// Liveness reports the health of critical subsystems.
+func (h *HealthChecker) Liveness(ctx context.Context) HealthStatus {
+	return h.checkAll(ctx, true)
+}
+
+// Readiness reports the health of all subsystems.
+func (h *HealthChecker) Readiness(ctx context.Context) HealthStatus {
+	return h.checkAll(ctx, false)
+}
+
+func (h *HealthChecker) checkAll(ctx context.Context, criticalOnly bool) HealthStatus {
+	status := HealthStatus{Healthy: true, Subsystems: make(map[string]SubsystemHealth)}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, sub := range h.subsystems {
+		if criticalOnly && !sub.critical {
+			continue
+		}
+		sub := sub
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+			err := sub.check(ctx)
+			if err != nil {
+				h.log.Warn("Subsystem unhealthy", "subsystem", sub.name, "err", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			status.Subsystems[sub.name] = SubsystemHealth{Healthy: err == nil}
+			status.Healthy = status.Healthy && err == nil
+		}()
+	}
+	wg.Wait()
+	return status
+}
+
+// HealthHandler creates a handler for GET requests which responds with the JSON encoding of the status returned
+// by fn. The response status code is 503 if any subsystem is unhealthy so it can be used directly by orchestrators.
+func HealthHandler(logger log.Logger, fn func(ctx context.Context) HealthStatus) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		status := fn(r.Context())
+		w.Header().Set("Content-Type", "application/json")
+		if !status.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(status); err != nil {
+			logger.Warn("Failed to write health response", "path", r.URL.Path, "err", err)
+		}
+	})
+}
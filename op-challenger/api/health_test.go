@@ -0,0 +1,107 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthChecker(t *testing.T) {
+	healthy := func(_ context.Context) error { return nil }
+	unhealthy := func(_ context.Context) error { return errors.New("boom") }
+
+	t.Run("AllHealthy", func(t *testing.T) {
+		checker := NewHealthChecker(testlog.Logger(t, log.LvlInfo))
+		checker.Add("critical", true, healthy)
+		checker.Add("other", false, healthy)
+		require.Equal(t, HealthStatus{
+			Healthy:    true,
+			Subsystems: map[string]SubsystemHealth{"critical": {Healthy: true}},
+		}, checker.Liveness(context.Background()))
+		require.Equal(t, HealthStatus{
+			Healthy: true,
+			Subsystems: map[string]SubsystemHealth{
+				"critical": {Healthy: true},
+				"other":    {Healthy: true},
+			},
+		}, checker.Readiness(context.Background()))
+	})
+
+	t.Run("NonCriticalUnhealthy", func(t *testing.T) {
+		checker := NewHealthChecker(testlog.Logger(t, log.LvlCrit))
+		checker.Add("critical", true, healthy)
+		checker.Add("other", false, unhealthy)
+		require.True(t, checker.Liveness(context.Background()).Healthy)
+		readiness := checker.Readiness(context.Background())
+		require.False(t, readiness.Healthy)
+		require.True(t, readiness.Subsystems["critical"].Healthy)
+		require.False(t, readiness.Subsystems["other"].Healthy)
+	})
+
+	t.Run("CriticalUnhealthy", func(t *testing.T) {
+		checker := NewHealthChecker(testlog.Logger(t, log.LvlCrit))
+		checker.Add("critical", true, unhealthy)
+		checker.Add("other", false, healthy)
+		require.False(t, checker.Liveness(context.Background()).Healthy)
+		require.False(t, checker.Readiness(context.Background()).Healthy)
+	})
+}
+
+func TestCachedHealthCheck(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	var calls int
+	var result error
+	check := CachedHealthCheck(cl, time.Minute, func(_ context.Context) error {
+		calls++
+		return result
+	})
+
+	require.NoError(t, check(context.Background()))
+	require.Equal(t, 1, calls)
+
+	result = errors.New("boom")
+	cl.AdvanceTime(59 * time.Second)
+	require.NoError(t, check(context.Background()), "should reuse result within ttl")
+	require.Equal(t, 1, calls)
+
+	cl.AdvanceTime(time.Second)
+	require.ErrorIs(t, check(context.Background()), result)
+	require.Equal(t, 2, calls)
+	require.ErrorIs(t, check(context.Background()), result, "should cache errors")
+	require.Equal(t, 2, calls)
+}
+
+func TestHealthHandler(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	request := func(t *testing.T, status HealthStatus) (*httptest.ResponseRecorder, HealthStatus) {
+		handler := HealthHandler(logger, func(_ context.Context) HealthStatus { return status })
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		var result HealthStatus
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&result))
+		return rec, result
+	}
+
+	t.Run("Healthy", func(t *testing.T) {
+		status := HealthStatus{Healthy: true, Subsystems: map[string]SubsystemHealth{"l1": {Healthy: true}}}
+		rec, result := request(t, status)
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, status, result)
+	})
+
+	t.Run("Unhealthy", func(t *testing.T) {
+		status := HealthStatus{Healthy: false, Subsystems: map[string]SubsystemHealth{"l1": {Healthy: false}}}
+		rec, result := request(t, status)
+		require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		require.Equal(t, status, result)
+	})
+}
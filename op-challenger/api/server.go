@@ -0,0 +1,121 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Server is an authenticated HTTP server exposing operator information about the challenger.
+// Handlers must be registered before the server is started.
+// Without an auth token, only the unauthenticated handlers can be accessed.
+type Server struct {
+	log       log.Logger
+	cfg       CLIConfig
+	mux       *http.ServeMux
+	httpSrv   *httputil.HTTPServer
+	authToken []byte
+	// public are the paths which do not require authentication
+	public map[string]bool
+}
+
+func NewServer(logger log.Logger, cfg CLIConfig) *Server {
+	return &Server{
+		log:       logger,
+		cfg:       cfg,
+		mux:       http.NewServeMux(),
+		authToken: []byte(cfg.AuthToken),
+		public:    make(map[string]bool),
+	}
+}
+
+// Handle registers the handler for the given path. All requests must be authenticated with the
+// configured bearer token.
+func (s *Server) Handle(path string, handler http.Handler) {
+	s.mux.Handle(path, handler)
+}
+
+// HandleUnauthenticated registers the handler for the given path without requiring authentication.
+// Handlers must not expose sensitive information.
+func (s *Server) HandleUnauthenticated(path string, handler http.Handler) {
+	s.public[path] = true
+	s.mux.Handle(path, handler)
+}
+
+// HandleHealth registers unauthenticated /healthz and /readyz endpoints reporting the liveness and readiness
+// of the subsystems in checker.
+func (s *Server) HandleHealth(checker *HealthChecker) {
+	s.HandleUnauthenticated("/healthz", HealthHandler(s.log, checker.Liveness))
+	s.HandleUnauthenticated("/readyz", HealthHandler(s.log, checker.Readiness))
+}
+
+// HandleJSON registers a handler for GET requests to path which responds with the JSON encoding of the
+// value returned by fn.
+func (s *Server) HandleJSON(path string, fn func(r *http.Request) (any, error)) {
+	s.Handle(path, JSONHandler(s.log, fn))
+}
+
+func (s *Server) Start() error {
+	addr := net.JoinHostPort(s.cfg.ListenAddr, strconv.Itoa(s.cfg.ListenPort))
+	srv, err := httputil.StartHTTPServer(addr, s.authenticate(s.mux))
+	if err != nil {
+		return fmt.Errorf("failed to start api server: %w", err)
+	}
+	s.log.Info("Started operator API server", "addr", srv.Addr())
+	s.httpSrv = srv
+	return nil
+}
+
+func (s *Server) Addr() net.Addr {
+	return s.httpSrv.Addr()
+}
+
+func (s *Server) Stop(ctx context.Context) error {
+	if s.httpSrv == nil {
+		return nil
+	}
+	return s.httpSrv.Stop(ctx)
+}
+
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.public[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || len(s.authToken) == 0 || subtle.ConstantTimeCompare([]byte(token), s.authToken) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// JSONHandler creates a handler for GET requests which responds with the JSON encoding of the value returned by fn.
+func JSONHandler(logger log.Logger, fn func(r *http.Request) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		result, err := fn(r)
+		if err != nil {
+			logger.Warn("API request failed", "path", r.URL.Path, "err", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(result); err != nil {
+			logger.Warn("Failed to write API response", "path", r.URL.Path, "err", err)
+		}
+	})
+}
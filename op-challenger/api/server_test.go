@@ -0,0 +1,156 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServer(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := CLIConfig{
+		Enabled:    true,
+		ListenAddr: "127.0.0.1",
+		ListenPort: 0,
+		AuthToken:  "secret",
+	}
+	server := NewServer(logger, cfg)
+	server.HandleJSON("/value", func(_ *http.Request) (any, error) {
+		return map[string]int{"value": 42}, nil
+	})
+	server.HandleJSON("/error", func(_ *http.Request) (any, error) {
+		return nil, errors.New("boom")
+	})
+	checker := NewHealthChecker(logger)
+	checker.Add("test", true, func(_ context.Context) error { return nil })
+	server.HandleHealth(checker)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(context.Background()))
+	})
+
+	request := func(t *testing.T, method string, path string, token string) *http.Response {
+		req, err := http.NewRequest(method, fmt.Sprintf("http://%v%v", server.Addr(), path), nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = resp.Body.Close()
+		})
+		return resp
+	}
+
+	t.Run("RejectMissingToken", func(t *testing.T) {
+		resp := request(t, http.MethodGet, "/value", "")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("RejectIncorrectToken", func(t *testing.T) {
+		resp := request(t, http.MethodGet, "/value", "wrong")
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("ReturnJSON", func(t *testing.T) {
+		resp := request(t, http.MethodGet, "/value", "secret")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		var result map[string]int
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		require.Equal(t, 42, result["value"])
+	})
+
+	t.Run("RejectNonGet", func(t *testing.T) {
+		resp := request(t, http.MethodPost, "/value", "secret")
+		require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	})
+
+	t.Run("HealthDoesNotRequireToken", func(t *testing.T) {
+		resp := request(t, http.MethodGet, "/healthz", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		resp = request(t, http.MethodGet, "/readyz", "")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("ReportError", func(t *testing.T) {
+		resp := request(t, http.MethodGet, "/error", "secret")
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestServer_HealthOnly(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := CLIConfig{
+		HealthEnabled: true,
+		ListenAddr:    "127.0.0.1",
+		ListenPort:    0,
+	}
+	server := NewServer(logger, cfg)
+	server.HandleJSON("/value", func(_ *http.Request) (any, error) {
+		return map[string]int{"value": 42}, nil
+	})
+	checker := NewHealthChecker(logger)
+	checker.Add("test", true, func(_ context.Context) error { return nil })
+	server.HandleHealth(checker)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(context.Background()))
+	})
+
+	get := func(t *testing.T, path string, header string) int {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%v%v", server.Addr(), path), nil)
+		require.NoError(t, err)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp.StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, get(t, "/healthz", ""))
+	require.Equal(t, http.StatusOK, get(t, "/readyz", ""))
+	// Without an auth token, no request can be authenticated.
+	require.Equal(t, http.StatusUnauthorized, get(t, "/value", ""))
+	require.Equal(t, http.StatusUnauthorized, get(t, "/value", "Bearer "))
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		require.NoError(t, DefaultCLIConfig().Check())
+	})
+
+	t.Run("RequireAuthToken", func(t *testing.T) {
+		cfg := DefaultCLIConfig()
+		cfg.Enabled = true
+		require.ErrorIs(t, cfg.Check(), ErrMissingAuthToken)
+		cfg.AuthToken = "secret"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("HealthDoesNotRequireAuthToken", func(t *testing.T) {
+		cfg := DefaultCLIConfig()
+		cfg.HealthEnabled = true
+		require.NoError(t, cfg.Check())
+		cfg.ListenPort = 70000
+		require.ErrorIs(t, cfg.Check(), ErrInvalidPort)
+	})
+
+	t.Run("InvalidPort", func(t *testing.T) {
+		cfg := DefaultCLIConfig()
+		cfg.Enabled = true
+		cfg.AuthToken = "secret"
+		cfg.ListenPort = 70000
+		require.ErrorIs(t, cfg.Check(), ErrInvalidPort)
+	})
+}
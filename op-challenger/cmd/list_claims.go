@@ -3,13 +3,18 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
-	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
@@ -19,6 +24,11 @@ var (
 		Usage:   "Address of the fault game contract.",
 		EnvVars: opservice.PrefixEnvVar("OP_CHALLENGER", "GAME_FACTORY_ADDRESS"),
 	}
+	CompareTraceFlag = &cli.BoolFlag{
+		Name:    "compare-trace",
+		Usage:   "Annotate each claim as honest or dishonest by comparing it to the locally derived trace. Requires the full challenger trace configuration.",
+		EnvVars: opservice.PrefixEnvVar("OP_CHALLENGER", "COMPARE_TRACE"),
+	}
 )
 
 func ListClaims(ctx *cli.Context) error {
@@ -41,15 +51,34 @@ func ListClaims(ctx *cli.Context) error {
 	}
 	defer l1Client.Close()
 
+	var verdicts []fault.ClaimVerdict
+	if ctx.Bool(CompareTraceFlag.Name) {
+		cfg, err := flags.NewConfigFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+		report, err := replayGame(ctx.Context, logger, cfg, l1Client, gameAddr)
+		if err != nil {
+			return err
+		}
+		verdicts = report.Claims
+	}
+	head, err := l1Client.HeaderByNumber(ctx.Context, nil)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve L1 head: %w", err)
+	}
+
 	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
 	contract, err := contracts.NewFaultDisputeGameContract(gameAddr, caller)
 	if err != nil {
 		return fmt.Errorf("failed to create dispute game bindings: %w", err)
 	}
-	return listClaims(ctx.Context, contract)
+	return listClaims(ctx.Context, contract, time.Unix(int64(head.Time), 0), verdicts)
 }
 
-func listClaims(ctx context.Context, game *contracts.FaultDisputeGameContract) error {
+// listClaims prints the claim tree of game. Each claim is annotated with the time remaining at now for the opposing
+// side to counter it and, if verdicts is not empty, whether it agrees with the locally derived honest trace.
+func listClaims(ctx context.Context, game *contracts.FaultDisputeGameContract, now time.Time, verdicts []fault.ClaimVerdict) error {
 	maxDepth, err := game.GetMaxGameDepth(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve max depth: %w", err)
@@ -66,37 +95,99 @@ func listClaims(ctx context.Context, game *contracts.FaultDisputeGameContract) e
 	if err != nil {
 		return fmt.Errorf("failed to retrieve status: %w", err)
 	}
+	gameDuration, err := game.GetGameDuration(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve game duration: %w", err)
+	}
 
 	claims, err := game.GetAllClaims(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve claims: %w", err)
 	}
+	fmt.Printf("Status: %v - L2 Block: %v - Split Depth: %v - Max Depth: %v - Claim count: %v\n",
+		status, l2BlockNum, splitDepth, maxDepth, len(claims))
+	if len(claims) == 0 {
+		return nil
+	}
+	fmt.Print(formatClaimTree(claims, verdicts, maxDepth, time.Duration(gameDuration)*time.Second, now))
+	return nil
+}
 
-	info := fmt.Sprintf("Claim count: %v\n", len(claims))
+func formatClaimTree(claims []types.Claim, verdicts []fault.ClaimVerdict, maxDepth types.Depth, gameDuration time.Duration, now time.Time) string {
+	children := make(map[int][]int)
 	for i, claim := range claims {
+		if claim.IsRoot() {
+			continue
+		}
+		children[claim.ParentContractIndex] = append(children[claim.ParentContractIndex], i)
+	}
+	// Time remaining for each side to counter the uncountered claims of the other side.
+	remaining := make(map[string]time.Duration)
+
+	var info strings.Builder
+	var visit func(idx int)
+	visit = func(idx int) {
+		claim := claims[idx]
 		pos := claim.Position
-		info = info + fmt.Sprintf("%v - Position: %v, Depth: %v, IndexAtDepth: %v Trace Index: %v, Value: %v, Countered: %v, ParentIndex: %v\n",
-			i, pos.ToGIndex(), pos.Depth(), pos.IndexAtDepth(), pos.TraceIndex(maxDepth), claim.Value.Hex(), claim.CounteredBy, claim.ParentContractIndex)
+		var parentClock types.Clock
+		if !claim.IsRoot() {
+			parentClock = claims[claim.ParentContractIndex].Clock
+		}
+		responder := claimSide(pos.Depth() + 1)
+		timeLeft := types.ChessClockRemaining(gameDuration, parentClock, claim, now)
+		if claim.CounteredBy == (common.Address{}) && timeLeft > remaining[responder] {
+			remaining[responder] = timeLeft
+		}
+		info.WriteString(fmt.Sprintf("%v%v - Side: %v, Position: %v, Depth: %v, IndexAtDepth: %v, Trace Index: %v, Value: %v, Claimant: %v, Countered: %v, Clock Used: %v, %v Remaining: %v",
+			strings.Repeat("  ", int(pos.Depth())), idx, claimSide(pos.Depth()), pos.ToGIndex(), pos.Depth(), pos.IndexAtDepth(), pos.TraceIndex(maxDepth),
+			claim.Value.Hex(), claim.Claimant, claim.CounteredBy, claim.Clock.Duration, responder, timeLeft))
+		if idx < len(verdicts) {
+			info.WriteString(", Result: " + formatVerdict(verdicts[idx]))
+		}
+		info.WriteString("\n")
+		for _, child := range children[idx] {
+			visit(child)
+		}
 	}
-	fmt.Printf("Status: %v - L2 Block: %v - Split Depth: %v - Max Depth: %v:\n%v\n",
-		status, l2BlockNum, splitDepth, maxDepth, info)
-	return nil
+	visit(0)
+	info.WriteString(fmt.Sprintf("Time Remaining - Defender: %v, Challenger: %v\n", remaining[sideDefender], remaining[sideChallenger]))
+	return info.String()
 }
 
-var listClaimsFlags = []cli.Flag{
-	flags.L1EthRpcFlag,
-	GameAddressFlag,
+const (
+	sideDefender   = "Defender"
+	sideChallenger = "Challenger"
+)
+
+// claimSide returns the side that posts claims at depth. The root claim is made by the defender.
+func claimSide(depth types.Depth) string {
+	if depth%2 == 0 {
+		return sideDefender
+	}
+	return sideChallenger
+}
+
+func formatVerdict(verdict fault.ClaimVerdict) string {
+	if verdict.Err != nil {
+		return fmt.Sprintf("unknown (%v)", verdict.Err)
+	} else if !verdict.Honest {
+		return fmt.Sprintf("dishonest (expected %v)", verdict.HonestValue.Hex())
+	}
+	return "honest"
 }
 
-func init() {
-	listClaimsFlags = append(listClaimsFlags, oplog.CLIFlags("OP_CHALLENGER")...)
+func listClaimsFlags() []cli.Flag {
+	listFlags := make([]cli.Flag, 0, len(flags.Flags)+2)
+	listFlags = append(listFlags, flags.Flags...)
+	listFlags = append(listFlags, GameAddressFlag, CompareTraceFlag)
+	return cliapp.ProtectFlags(listFlags)
 }
 
 var ListClaimsCommand = &cli.Command{
 	Name:        "list-claims",
 	Usage:       "List the claims in a dispute game",
-	Description: "Lists the claims in a dispute game",
+	Description: "Prints the claim tree of a dispute game with the chess clock time remaining for each side. With --compare-trace, each claim is also compared to the locally derived honest trace using the same trace configuration as the challenger.",
 	Action:      ListClaims,
-	Flags:       listClaimsFlags,
+	Flags:       listClaimsFlags(),
 	Hidden:      true,
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatClaimTree(t *testing.T) {
+	root := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xaa}, Position: types.NewPositionFromGIndex(big.NewInt(1))},
+		CounteredBy:         common.Address{0x01},
+		Clock:               types.NewClock(0, 1000),
+		ParentContractIndex: math.MaxUint32,
+	}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xbb}, Position: root.Position.Attack()},
+		Clock:               types.NewClock(600, 1600),
+		ContractIndex:       1,
+		ParentContractIndex: 0,
+	}
+	claims := []types.Claim{root, attack}
+	verdicts := []fault.ClaimVerdict{
+		{Claim: root, HonestValue: root.Value, Honest: true},
+		{Claim: attack, HonestValue: common.Hash{0xcc}},
+	}
+	out := formatClaimTree(claims, verdicts, 4, 2*time.Hour, time.Unix(2200, 0))
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 3)
+	require.True(t, strings.HasPrefix(lines[0], "0 - Side: Defender"))
+	require.Contains(t, lines[0], "Result: honest")
+	require.True(t, strings.HasPrefix(lines[1], "  1 - Side: Challenger"))
+	require.Contains(t, lines[1], "Result: dishonest (expected "+common.Hash{0xcc}.Hex()+")")
+	// Defender's clock resumes from the root claim's duration (0) and has run for 600s since the attack.
+	require.Contains(t, lines[1], "Defender Remaining: 50m0s")
+	require.Equal(t, "Time Remaining - Defender: 50m0s, Challenger: 0s", lines[2])
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 	"time"
 
@@ -28,6 +31,11 @@ var (
 	datadir                 = "./test_data"
 	cannonL2                = "http://example.com:9545"
 	rollupRpc               = "http://example.com:8555"
+	asteriscNetwork         = "op-mainnet"
+	asteriscBin             = "./bin/asterisc"
+	asteriscServer          = "./bin/op-program"
+	asteriscPreState        = "./pre.json"
+	asteriscL2              = "http://example.com:9545"
 )
 
 func TestLogLevel(t *testing.T) {
@@ -160,6 +168,52 @@ func TestGameAllowlist(t *testing.T) {
 	})
 }
 
+func TestHonestActors(t *testing.T) {
+	t.Run("Optional", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.HonestActors)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		addr1 := common.Address{0xbb}
+		addr2 := common.Address{0xcc}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--honest-actors="+addr1.Hex(), "--honest-actors="+addr2.Hex()))
+		require.Equal(t, []common.Address{addr1, addr2}, cfg.HonestActors)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid address: foo", addRequiredArgs(config.TraceTypeAlphabet, "--honest-actors=foo"))
+	})
+}
+
+func TestLargePreimageClaimantLists(t *testing.T) {
+	for _, name := range []string{"large-preimage-claimant-allowlist", "large-preimage-claimant-blocklist"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Run("Optional", func(t *testing.T) {
+				cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+				require.Empty(t, cfg.LargePreimageClaimantAllowlist)
+				require.Empty(t, cfg.LargePreimageClaimantBlocklist)
+			})
+
+			t.Run("Invalid", func(t *testing.T) {
+				verifyArgsInvalid(t, "invalid address: foo", addRequiredArgs(config.TraceTypeAlphabet, "--"+name+"=foo"))
+			})
+		})
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		addr1 := common.Address{0xbb}
+		addr2 := common.Address{0xcc}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--large-preimage-claimant-allowlist="+addr1.Hex(),
+			"--large-preimage-claimant-allowlist="+addr2.Hex(),
+			"--large-preimage-claimant-blocklist="+addr2.Hex()))
+		require.Equal(t, []common.Address{addr1, addr2}, cfg.LargePreimageClaimantAllowlist)
+		require.Equal(t, []common.Address{addr2}, cfg.LargePreimageClaimantBlocklist)
+	})
+}
+
 func TestTxManagerFlagsSupported(t *testing.T) {
 	// Not a comprehensive list of flags, just enough to sanity check the txmgr.CLIFlags were defined
 	cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--"+txmgr.NumConfirmationsFlagName, "7"))
@@ -188,6 +242,161 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestLargePreimageWorkers(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.EqualValues(t, runtime.NumCPU(), cfg.LargePreimageWorkers)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-workers", "7"))
+		require.Equal(t, uint(7), cfg.LargePreimageWorkers)
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"large-preimage-workers must not be 0",
+			addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-workers", "0"))
+	})
+}
+
+func TestLargePreimageBatchSize(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultLargePreimageBatchSize, cfg.LargePreimageBatchSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-fetch-batch-size", "25"))
+		require.Equal(t, uint(25), cfg.LargePreimageBatchSize)
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"large-preimage-fetch-batch-size must not be 0",
+			addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-fetch-batch-size", "0"))
+	})
+}
+
+func TestLargePreimageGasPadding(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultLargePreimageGasPadding, cfg.LargePreimageGasPadding)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-gas-padding", "50"))
+		require.Equal(t, uint64(50), cfg.LargePreimageGasPadding)
+	})
+}
+
+func TestLargePreimageSnapshotInterval(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.LargePreimageSnapshotInterval)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-snapshot-interval", "1000"))
+		require.Equal(t, uint64(1000), cfg.LargePreimageSnapshotInterval)
+	})
+}
+
+func TestLargePreimageGasCap(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultLargePreimageGasCap, cfg.LargePreimageGasCap)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-gas-cap", "1000000"))
+		require.Equal(t, uint64(1_000_000), cfg.LargePreimageGasCap)
+	})
+
+	t.Run("Zero", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"large-preimage-gas-cap must not be 0",
+			addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-gas-cap", "0"))
+	})
+}
+
+func TestLargePreimageUrgentBlocks(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultLargePreimageUrgentBlocks, cfg.LargePreimageUrgentBlocks)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-urgent-blocks", "8"))
+		require.Equal(t, uint64(8), cfg.LargePreimageUrgentBlocks)
+	})
+}
+
+func TestLargePreimageUrgentTipCap(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Equal(t, config.DefaultLargePreimageUrgentTipCapGwei, cfg.LargePreimageUrgentTipCapGwei)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-urgent-tip-cap", "2.5"))
+		require.Equal(t, 2.5, cfg.LargePreimageUrgentTipCapGwei)
+	})
+}
+
+func TestLargePreimageDryRun(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.LargePreimageDryRun)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--large-preimage-dry-run"))
+		require.True(t, cfg.LargePreimageDryRun)
+	})
+}
+
+func TestAdditionalPreimageOracles(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.AdditionalPreimageOracles)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		addr1 := common.Address{0xaa}
+		addr2 := common.Address{0xbb}
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--additional-preimage-oracles", addr1.Hex(),
+			"--additional-preimage-oracles", addr2.Hex(),
+			"--additional-preimage-oracles", addr1.Hex()))
+		require.Equal(t, []common.Address{addr1, addr2}, cfg.AdditionalPreimageOracles)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"invalid address: foo",
+			addRequiredArgs(config.TraceTypeAlphabet, "--additional-preimage-oracles", "foo"))
+	})
+}
+
+func TestL1EthRpcFallbacks(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.L1EthRpcFallbacks)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet,
+			"--l1-eth-rpc-fallbacks", "http://example.com:1234",
+			"--l1-eth-rpc-fallbacks", "http://example.com:5678"))
+		require.Equal(t, []string{"http://example.com:1234", "http://example.com:5678"}, cfg.L1EthRpcFallbacks)
+	})
+}
+
 func TestMaxPendingTx(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		expected := uint64(345)
@@ -264,13 +473,27 @@ func TestCannonAbsolutePrestate(t *testing.T) {
 	})
 
 	t.Run("Required", func(t *testing.T) {
-		verifyArgsInvalid(t, "flag cannon-prestate is required", addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate"))
+		verifyArgsInvalid(t, "flag cannon-prestate or cannon-prestates-url is required", addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate"))
 	})
 
 	t.Run("Valid", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate", "--cannon-prestate=./pre.json"))
 		require.Equal(t, "./pre.json", cfg.CannonAbsolutePreState)
 	})
+
+	t.Run("PrestatesURL", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate",
+			"--cannon-prestates-url=https://example.com/prestates", "--cannon-prestates-url=https://example.org"))
+		require.Empty(t, cfg.CannonAbsolutePreState)
+		require.Len(t, cfg.CannonPrestatesURLs, 2)
+		require.Equal(t, "https://example.com/prestates", cfg.CannonPrestatesURLs[0].String())
+		require.Equal(t, "https://example.org", cfg.CannonPrestatesURLs[1].String())
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("InvalidPrestatesURL", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid cannon-prestates-url", addRequiredArgsExcept(config.TraceTypeCannon, "--cannon-prestate", "--cannon-prestates-url=:foo"))
+	})
 }
 
 func TestDataDir(t *testing.T) {
@@ -352,6 +575,52 @@ func TestCannonInfoFreq(t *testing.T) {
 	})
 }
 
+func TestCannonMaxConcurrency(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.Equal(t, uint(runtime.NumCPU()), cfg.CannonMaxConcurrency)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--cannon-max-concurrency=3"))
+		require.Equal(t, uint(3), cfg.CannonMaxConcurrency)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid value \"abc\" for flag -cannon-max-concurrency",
+			addRequiredArgs(config.TraceTypeCannon, "--cannon-max-concurrency=abc"))
+	})
+}
+
+func TestGameTypeAllowlist(t *testing.T) {
+	t.Run("DefaultsToAllGameTypes", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.GameTypeAllowlist)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--game-type-allowlist=0", "--game-type-allowlist=254"))
+		require.Equal(t, []uint32{0, 254}, cfg.GameTypeAllowlist)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid value \"4294967296\" for flag -game-type-allowlist",
+			addRequiredArgs(config.TraceTypeAlphabet, "--game-type-allowlist=4294967296"))
+	})
+}
+
+func TestMinGameCreationTime(t *testing.T) {
+	t.Run("DefaultsToUnlimited", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MinGameCreationTime)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--min-game-creation-time=1700000000"))
+		require.Equal(t, uint64(1700000000), cfg.MinGameCreationTime)
+	})
+}
+
 func TestGameWindow(t *testing.T) {
 	t.Run("UsesDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
@@ -369,6 +638,134 @@ func TestGameWindow(t *testing.T) {
 	})
 }
 
+func TestChains(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.ChainName)
+		require.Empty(t, cfg.Chains)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "chains.json")
+		require.NoError(t, os.WriteFile(path, []byte(`[{
+			"name": "other",
+			"l1EthRpc": "http://other-l1",
+			"gameFactoryAddress": "0xdd00000000000000000000000000000000000000",
+			"rollupRpc": "http://other-rollup"
+		}]`), 0o644))
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--chain-name=main", "--chains-config="+path))
+		require.Equal(t, "main", cfg.ChainName)
+		require.Equal(t, []config.ChainConfig{{
+			Name:               "other",
+			L1EthRpc:           "http://other-l1",
+			GameFactoryAddress: common.Address{0xdd},
+			RollupRpc:          "http://other-rollup",
+		}}, cfg.Chains)
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		verifyArgsInvalid(t, "failed to read chains config", addRequiredArgs(config.TraceTypeAlphabet, "--chains-config=/does/not/exist.json"))
+	})
+}
+
+func TestSubscribeGameEvents(t *testing.T) {
+	t.Run("DefaultsToDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.SubscribeGameEvents)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--l1-eth-rpc", "--l1-eth-rpc=ws://example.com:8546", "--subscribe-game-events"))
+		require.True(t, cfg.SubscribeGameEvents)
+	})
+
+	t.Run("RequiresWebsocket", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--subscribe-game-events"))
+		require.ErrorIs(t, cfg.Check(), config.ErrGameEventsRequireWebsocket)
+	})
+}
+
+func TestResolutionBatchSize(t *testing.T) {
+	t.Run("DefaultsToDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.ResolutionBatchSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--resolution-batch-size=25"))
+		require.Equal(t, uint(25), cfg.ResolutionBatchSize)
+	})
+}
+
+func TestSpendBudget(t *testing.T) {
+	t.Run("DefaultsToUnlimited", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxGameSpendEth)
+		require.Zero(t, cfg.MaxTotalSpendEth)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--max-game-spend=1.5", "--max-total-spend=20"))
+		require.Equal(t, 1.5, cfg.MaxGameSpendEth)
+		require.Equal(t, float64(20), cfg.MaxTotalSpendEth)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid value \"abc\" for flag -max-game-spend",
+			addRequiredArgs(config.TraceTypeAlphabet, "--max-game-spend=abc"))
+	})
+}
+
+func TestMoveFees(t *testing.T) {
+	t.Run("DefaultsToDisabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxMoveFeeCapGwei)
+		require.Zero(t, cfg.MoveUrgencyThreshold)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--max-move-fee-cap=150", "--move-urgency-threshold=2h"))
+		require.Equal(t, float64(150), cfg.MaxMoveFeeCapGwei)
+		require.Equal(t, 2*time.Hour, cfg.MoveUrgencyThreshold)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid value \"abc\" for flag -move-urgency-threshold",
+			addRequiredArgs(config.TraceTypeAlphabet, "--move-urgency-threshold=abc"))
+	})
+}
+
+func TestMaxPlayDepth(t *testing.T) {
+	t.Run("DefaultsToUnlimited", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxPlayDepth)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--max-play-depth=30"))
+		require.Equal(t, uint64(30), cfg.MaxPlayDepth)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid value \"abc\" for flag -max-play-depth",
+			addRequiredArgs(config.TraceTypeAlphabet, "--max-play-depth=abc"))
+	})
+}
+
+func TestArchive(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.Empty(t, cfg.ArchiveDriver)
+		require.Empty(t, cfg.ArchiveDSN)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--archive-db-driver=pgx", "--archive-db-dsn=postgres://localhost/games"))
+		require.Equal(t, "pgx", cfg.ArchiveDriver)
+		require.Equal(t, "postgres://localhost/games", cfg.ArchiveDSN)
+	})
+}
+
 func TestRequireEitherCannonNetworkOrRollupAndGenesis(t *testing.T) {
 	verifyArgsInvalid(
 		t,
@@ -430,6 +827,83 @@ func TestCannonL2Genesis(t *testing.T) {
 	})
 }
 
+func TestAsteriscRequiredArgs(t *testing.T) {
+	for _, name := range []string{"asterisc-bin", "asterisc-server", "asterisc-l2"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			t.Run("NotRequiredForCannonTrace", func(t *testing.T) {
+				configForArgs(t, addRequiredArgsExcept(config.TraceTypeCannon, "--"+name))
+			})
+
+			t.Run("Required", func(t *testing.T) {
+				verifyArgsInvalid(t, "flag "+name+" is required", addRequiredArgsExcept(config.TraceTypeAsterisc, "--"+name))
+			})
+		})
+	}
+
+	t.Run("asterisc-prestate", func(t *testing.T) {
+		t.Run("NotRequiredForCannonTrace", func(t *testing.T) {
+			configForArgs(t, addRequiredArgsExcept(config.TraceTypeCannon, "--asterisc-prestate"))
+		})
+
+		t.Run("Required", func(t *testing.T) {
+			verifyArgsInvalid(t, "flag asterisc-prestate or asterisc-prestates-url is required", addRequiredArgsExcept(config.TraceTypeAsterisc, "--asterisc-prestate"))
+		})
+
+		t.Run("PrestatesURL", func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeAsterisc, "--asterisc-prestate", "--asterisc-prestates-url=https://example.com"))
+			require.Len(t, cfg.AsteriscPrestatesURLs, 1)
+			require.NoError(t, cfg.Check())
+		})
+	})
+
+	t.Run("RequireEitherNetworkOrRollupAndGenesis", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"flag asterisc-network or asterisc-rollup-config and asterisc-l2-genesis is required",
+			addRequiredArgsExcept(config.TraceTypeAsterisc, "--asterisc-network", "--asterisc-rollup-config=rollup.json"))
+	})
+
+	t.Run("MustNotSpecifyNetworkAndRollup", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"flag asterisc-network can not be used with asterisc-rollup-config and asterisc-l2-genesis",
+			addRequiredArgs(config.TraceTypeAsterisc, "--asterisc-rollup-config=rollup.json"))
+	})
+
+	t.Run("RollupRpcRequired", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag rollup-rpc is required", addRequiredArgsExcept(config.TraceTypeAsterisc, "--rollup-rpc"))
+	})
+}
+
+func TestAsteriscConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAsterisc))
+		require.Equal(t, []config.TraceType{config.TraceTypeAsterisc}, cfg.TraceTypes)
+		require.Equal(t, asteriscNetwork, cfg.AsteriscNetwork)
+		require.Equal(t, asteriscBin, cfg.AsteriscBin)
+		require.Equal(t, asteriscServer, cfg.AsteriscServer)
+		require.Equal(t, asteriscPreState, cfg.AsteriscAbsolutePreState)
+		require.Equal(t, asteriscL2, cfg.AsteriscL2)
+		require.Equal(t, config.DefaultAsteriscSnapshotFreq, cfg.AsteriscSnapshotFreq)
+		require.Equal(t, config.DefaultAsteriscInfoFreq, cfg.AsteriscInfoFreq)
+	})
+
+	t.Run("RollupAndGenesis", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeAsterisc, "--asterisc-network",
+			"--asterisc-rollup-config=rollup.json", "--asterisc-l2-genesis=genesis.json"))
+		require.Equal(t, "", cfg.AsteriscNetwork)
+		require.Equal(t, "rollup.json", cfg.AsteriscRollupConfigPath)
+		require.Equal(t, "genesis.json", cfg.AsteriscL2GenesisPath)
+	})
+
+	t.Run("Frequencies", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAsterisc, "--asterisc-snapshot-freq=1234", "--asterisc-info-freq=5678"))
+		require.Equal(t, uint(1234), cfg.AsteriscSnapshotFreq)
+		require.Equal(t, uint(5678), cfg.AsteriscInfoFreq)
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := dryRunWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -479,6 +953,8 @@ func requiredArgs(traceType config.TraceType) map[string]string {
 	switch traceType {
 	case config.TraceTypeCannon:
 		addRequiredCannonArgs(args)
+	case config.TraceTypeAsterisc:
+		addRequiredAsteriscArgs(args)
 	case config.TraceTypeAlphabet:
 		addRequiredOutputArgs(args)
 	}
@@ -494,6 +970,15 @@ func addRequiredCannonArgs(args map[string]string) {
 	addRequiredOutputArgs(args)
 }
 
+func addRequiredAsteriscArgs(args map[string]string) {
+	args["--asterisc-network"] = asteriscNetwork
+	args["--asterisc-bin"] = asteriscBin
+	args["--asterisc-server"] = asteriscServer
+	args["--asterisc-prestate"] = asteriscPreState
+	args["--asterisc-l2"] = asteriscL2
+	addRequiredOutputArgs(args)
+}
+
 func addRequiredOutputArgs(args map[string]string) {
 	args["--rollup-rpc"] = rollupRpc
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+func ReplayGame(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	cfg, err := flags.NewConfigFromCLI(ctx)
+	if err != nil {
+		return err
+	}
+	gameAddr, err := opservice.ParseAddress(ctx.String(GameAddressFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, cfg.L1EthRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+	report, err := replayGame(ctx.Context, logger, cfg, l1Client, gameAddr)
+	if err != nil {
+		return err
+	}
+	printReplayReport(report)
+	return nil
+}
+
+// replayGame dials the rollup and L2 clients required by the configured trace types and replays the game at gameAddr
+// against the locally derived honest trace.
+func replayGame(ctx context.Context, logger log.Logger, cfg *config.Config, l1Client *ethclient.Client, gameAddr common.Address) (*fault.ReplayReport, error) {
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, logger, cfg.RollupRpc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial rollup client: %w", err)
+	}
+	defer rollupClient.Close()
+	var cannonL2, asteriscL2 *ethclient.Client
+	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
+		cannonL2, err = ethclient.DialContext(ctx, cfg.CannonL2)
+		if err != nil {
+			return nil, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
+		}
+		defer cannonL2.Close()
+	}
+	if cfg.TraceTypeEnabled(config.TraceTypeAsterisc) {
+		asteriscL2, err = ethclient.DialContext(ctx, cfg.AsteriscL2)
+		if err != nil {
+			return nil, fmt.Errorf("dial asterisc l2 client %v: %w", cfg.AsteriscL2, err)
+		}
+		defer asteriscL2.Close()
+	}
+
+	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
+	report, err := fault.ReplayGame(ctx, logger, metrics.NoopMetrics, cfg, rollupClient, cannonL2, asteriscL2, caller, gameAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay game: %w", err)
+	}
+	return report, nil
+}
+
+func printReplayReport(report *fault.ReplayReport) {
+	info := fmt.Sprintf("Claim count: %v\n", len(report.Claims))
+	for i, verdict := range report.Claims {
+		claim := verdict.Claim
+		result := formatVerdict(verdict)
+		info = info + fmt.Sprintf("%v - Position: %v, Depth: %v, Value: %v, Claimant: %v, ParentIndex: %v, Result: %v\n",
+			i, claim.Position.ToGIndex(), claim.Position.Depth(), claim.Value.Hex(), claim.Claimant, claim.ParentContractIndex, result)
+	}
+	fmt.Printf("Game: %v - Type: %v - Status: %v - Expected Status: %v - Resolution Matches: %v\n%v",
+		report.Game.Proxy, report.Game.GameType, report.Status, report.ExpectedStatus, report.ResolutionMatches(), info)
+}
+
+func replayGameFlags() []cli.Flag {
+	replayFlags := make([]cli.Flag, 0, len(flags.Flags)+1)
+	replayFlags = append(replayFlags, flags.Flags...)
+	replayFlags = append(replayFlags, GameAddressFlag)
+	return cliapp.ProtectFlags(replayFlags)
+}
+
+var ReplayGameCommand = &cli.Command{
+	Name:        "replay-game",
+	Usage:       "Replay a finished dispute game against the locally derived honest trace",
+	Description: "Loads all claims from a dispute game, derives the honest trace locally and reports which claims were honest and whether the game resolved to the honest outcome. Uses the same trace configuration as the challenger.",
+	Action:      ReplayGame,
+	Flags:       replayGameFlags(),
+	Hidden:      true,
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrMissingChainName   = errors.New("missing chain name")
+	ErrDuplicateChainName = errors.New("duplicate chain name")
+)
+
+// ChainConfig specifies an additional chain for the challenger to play games on. Each chain is played with its own
+// L1 connection, game factory and trace providers. Chains settling on the same L1 share a transaction manager, as
+// they send from the same account. Settings not specified are inherited from the base Config.
+type ChainConfig struct {
+	Name                      string           `json:"name"`
+	L1EthRpc                  string           `json:"l1EthRpc"`
+	L1EthRpcFallbacks         []string         `json:"l1EthRpcFallbacks,omitempty"`
+	GameFactoryAddress        common.Address   `json:"gameFactoryAddress"`
+	GameAllowlist             []common.Address `json:"gameAllowlist,omitempty"`
+	GameTypeAllowlist         []uint32         `json:"gameTypeAllowlist,omitempty"`
+	MinGameCreationTime       uint64           `json:"minGameCreationTime,omitempty"`
+	AdditionalPreimageOracles []common.Address `json:"additionalPreimageOracles,omitempty"`
+	RollupRpc                 string           `json:"rollupRpc"`
+	Datadir                   string           `json:"datadir,omitempty"`
+	TraceTypes                []TraceType      `json:"traceTypes,omitempty"`
+
+	CannonNetwork          string `json:"cannonNetwork,omitempty"`
+	CannonRollupConfigPath string `json:"cannonRollupConfigPath,omitempty"`
+	CannonL2GenesisPath    string `json:"cannonL2GenesisPath,omitempty"`
+	CannonAbsolutePreState string `json:"cannonAbsolutePreState,omitempty"`
+	CannonL2               string `json:"cannonL2,omitempty"`
+}
+
+// LoadChainConfigs reads the list of additional chains from a JSON file.
+func LoadChainConfigs(path string) ([]ChainConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chains config: %w", err)
+	}
+	var chains []ChainConfig
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return nil, fmt.Errorf("failed to parse chains config: %w", err)
+	}
+	return chains, nil
+}
+
+// ChainConfigs returns the config for each additional chain, derived by applying the chain settings to this config.
+// Process wide services such as metrics, pprof and the API server are disabled in the derived configs.
+func (c Config) ChainConfigs() []Config {
+	configs := make([]Config, 0, len(c.Chains))
+	for _, chain := range c.Chains {
+		cfg := c
+		cfg.ChainName = chain.Name
+		cfg.Chains = nil
+		cfg.L1EthRpc = chain.L1EthRpc
+		cfg.L1EthRpcFallbacks = chain.L1EthRpcFallbacks
+		cfg.GameFactoryAddress = chain.GameFactoryAddress
+		cfg.GameAllowlist = chain.GameAllowlist
+		if len(chain.GameTypeAllowlist) > 0 {
+			cfg.GameTypeAllowlist = chain.GameTypeAllowlist
+		}
+		if chain.MinGameCreationTime != 0 {
+			cfg.MinGameCreationTime = chain.MinGameCreationTime
+		}
+		cfg.AdditionalPreimageOracles = chain.AdditionalPreimageOracles
+		cfg.RollupRpc = chain.RollupRpc
+		cfg.Datadir = chain.Datadir
+		if cfg.Datadir == "" {
+			cfg.Datadir = filepath.Join(c.Datadir, chain.Name)
+		}
+		if len(chain.TraceTypes) > 0 {
+			cfg.TraceTypes = chain.TraceTypes
+		}
+		if chain.CannonNetwork != "" || chain.CannonRollupConfigPath != "" || chain.CannonL2GenesisPath != "" {
+			cfg.CannonNetwork = chain.CannonNetwork
+			cfg.CannonRollupConfigPath = chain.CannonRollupConfigPath
+			cfg.CannonL2GenesisPath = chain.CannonL2GenesisPath
+		}
+		if chain.CannonAbsolutePreState != "" {
+			cfg.CannonAbsolutePreState = chain.CannonAbsolutePreState
+		}
+		if chain.CannonL2 != "" {
+			cfg.CannonL2 = chain.CannonL2
+		}
+		cfg.TxMgrConfig.L1RPCURL = chain.L1EthRpc
+		cfg.MetricsConfig.Enabled = false
+		cfg.PprofConfig.ListenEnabled = false
+		cfg.APIConfig.Enabled = false
+		configs = append(configs, cfg)
+	}
+	return configs
+}
+
+func (c Config) checkChains() error {
+	if len(c.Chains) == 0 {
+		return nil
+	}
+	if c.ChainName == "" {
+		return ErrMissingChainName
+	}
+	names := []string{c.ChainName}
+	for _, cfg := range c.ChainConfigs() {
+		if cfg.ChainName == "" {
+			return ErrMissingChainName
+		}
+		if slices.Contains(names, cfg.ChainName) {
+			return fmt.Errorf("%w: %v", ErrDuplicateChainName, cfg.ChainName)
+		}
+		names = append(names, cfg.ChainName)
+		for _, traceType := range cfg.TraceTypes {
+			if !ValidTraceType(traceType) {
+				return fmt.Errorf("chain %v: unknown trace type: %q", cfg.ChainName, traceType)
+			}
+		}
+		if err := cfg.Check(); err != nil {
+			return fmt.Errorf("chain %v: %w", cfg.ChainName, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func validChain(name string) ChainConfig {
+	return ChainConfig{
+		Name:               name,
+		L1EthRpc:           "http://" + name + "-l1",
+		GameFactoryAddress: common.Address{0xdd},
+		RollupRpc:          "http://" + name + "-rollup",
+	}
+}
+
+func TestChainConfigs(t *testing.T) {
+	t.Run("Inherit", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.ChainName = "main"
+		cfg.MetricsConfig.Enabled = true
+		cfg.APIConfig.Enabled = true
+		cfg.APIConfig.AuthToken = "token"
+		cfg.Chains = []ChainConfig{validChain("other")}
+		require.NoError(t, cfg.Check())
+
+		chains := cfg.ChainConfigs()
+		require.Len(t, chains, 1)
+		chain := chains[0]
+		require.Equal(t, "other", chain.ChainName)
+		require.Nil(t, chain.Chains)
+		require.Equal(t, "http://other-l1", chain.L1EthRpc)
+		require.Equal(t, "http://other-l1", chain.TxMgrConfig.L1RPCURL)
+		require.Equal(t, "http://other-rollup", chain.RollupRpc)
+		require.Equal(t, common.Address{0xdd}, chain.GameFactoryAddress)
+		require.Equal(t, filepath.Join(validDatadir, "other"), chain.Datadir)
+		require.Equal(t, cfg.TraceTypes, chain.TraceTypes)
+		require.Equal(t, cfg.CannonNetwork, chain.CannonNetwork)
+		require.Equal(t, cfg.CannonAbsolutePreState, chain.CannonAbsolutePreState)
+		require.Equal(t, cfg.GameTypeAllowlist, chain.GameTypeAllowlist)
+		require.Equal(t, cfg.MinGameCreationTime, chain.MinGameCreationTime)
+		require.False(t, chain.MetricsConfig.Enabled)
+		require.False(t, chain.APIConfig.Enabled)
+		require.False(t, chain.PprofConfig.ListenEnabled)
+	})
+
+	t.Run("Override", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.ChainName = "main"
+		chain := validChain("other")
+		chain.Datadir = "/other"
+		chain.TraceTypes = []TraceType{TraceTypeAlphabet}
+		chain.CannonRollupConfigPath = "rollup.json"
+		chain.CannonL2GenesisPath = "genesis.json"
+		chain.CannonAbsolutePreState = "other-prestate.json"
+		chain.CannonL2 = "http://other-l2"
+		chain.GameTypeAllowlist = []uint32{254}
+		chain.MinGameCreationTime = 1700000000
+		cfg.Chains = []ChainConfig{chain}
+		require.NoError(t, cfg.Check())
+
+		actual := cfg.ChainConfigs()[0]
+		require.Equal(t, "/other", actual.Datadir)
+		require.Equal(t, []TraceType{TraceTypeAlphabet}, actual.TraceTypes)
+		require.Equal(t, "", actual.CannonNetwork)
+		require.Equal(t, "rollup.json", actual.CannonRollupConfigPath)
+		require.Equal(t, "genesis.json", actual.CannonL2GenesisPath)
+		require.Equal(t, "other-prestate.json", actual.CannonAbsolutePreState)
+		require.Equal(t, "http://other-l2", actual.CannonL2)
+		require.Equal(t, []uint32{254}, actual.GameTypeAllowlist)
+		require.Equal(t, uint64(1700000000), actual.MinGameCreationTime)
+	})
+
+	t.Run("RequireChainName", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.Chains = []ChainConfig{validChain("other")}
+		require.ErrorIs(t, cfg.Check(), ErrMissingChainName)
+
+		cfg.ChainName = "main"
+		cfg.Chains = []ChainConfig{validChain("")}
+		require.ErrorIs(t, cfg.Check(), ErrMissingChainName)
+	})
+
+	t.Run("UniqueChainNames", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ChainName = "main"
+		cfg.Chains = []ChainConfig{validChain("other"), validChain("main")}
+		require.ErrorIs(t, cfg.Check(), ErrDuplicateChainName)
+	})
+
+	t.Run("CheckChainConfig", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ChainName = "main"
+		chain := validChain("other")
+		chain.GameFactoryAddress = common.Address{}
+		cfg.Chains = []ChainConfig{chain}
+		require.ErrorIs(t, cfg.Check(), ErrMissingGameFactoryAddress)
+	})
+
+	t.Run("InvalidTraceType", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ChainName = "main"
+		chain := validChain("other")
+		chain.TraceTypes = []TraceType{"foo"}
+		cfg.Chains = []ChainConfig{chain}
+		require.ErrorContains(t, cfg.Check(), "unknown trace type")
+	})
+}
+
+func TestLoadChainConfigs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chains.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{
+		"name": "other",
+		"l1EthRpc": "http://other-l1",
+		"gameFactoryAddress": "0xdd00000000000000000000000000000000000000",
+		"rollupRpc": "http://other-rollup",
+		"traceTypes": ["alphabet"]
+	}]`), 0o644))
+	chains, err := LoadChainConfigs(path)
+	require.NoError(t, err)
+	expected := validChain("other")
+	expected.TraceTypes = []TraceType{TraceTypeAlphabet}
+	require.Equal(t, []ChainConfig{expected}, chains)
+
+	_, err = LoadChainConfigs(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorContains(t, err, "failed to read chains config")
+}
@@ -3,12 +3,15 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"runtime"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/api"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -19,6 +22,10 @@ var (
 	ErrMissingTraceType              = errors.New("no supported trace types specified")
 	ErrMissingDatadir                = errors.New("missing datadir")
 	ErrMaxConcurrencyZero            = errors.New("max concurrency must not be 0")
+	ErrLargePreimageWorkersZero      = errors.New("large preimage workers must not be 0")
+	ErrLargePreimageBatchSizeZero    = errors.New("large preimage fetch batch size must not be 0")
+	ErrLargePreimageGasCapZero       = errors.New("large preimage challenge gas cap must not be 0")
+	ErrNegativeUrgentTipCap          = errors.New("large preimage urgent tip cap must not be negative")
 	ErrMissingCannonL2               = errors.New("missing cannon L2")
 	ErrMissingCannonBin              = errors.New("missing cannon bin")
 	ErrMissingCannonServer           = errors.New("missing cannon server")
@@ -27,12 +34,34 @@ var (
 	ErrMissingGameFactoryAddress     = errors.New("missing game factory address")
 	ErrMissingCannonSnapshotFreq     = errors.New("missing cannon snapshot freq")
 	ErrMissingCannonInfoFreq         = errors.New("missing cannon info freq")
+	ErrCannonMaxConcurrencyZero      = errors.New("cannon max concurrency must not be 0")
 	ErrMissingCannonRollupConfig     = errors.New("missing cannon network or rollup config path")
 	ErrMissingCannonL2Genesis        = errors.New("missing cannon network or l2 genesis path")
 	ErrCannonNetworkAndRollupConfig  = errors.New("only specify one of network or rollup config path")
 	ErrCannonNetworkAndL2Genesis     = errors.New("only specify one of network or l2 genesis path")
 	ErrCannonNetworkUnknown          = errors.New("unknown cannon network")
 	ErrMissingRollupRpc              = errors.New("missing rollup rpc url")
+	ErrNegativeSpendBudget           = errors.New("spend budget must not be negative")
+	ErrNegativeMoveFeeCap            = errors.New("max move fee cap must not be negative")
+	ErrNegativeMoveUrgency           = errors.New("move urgency threshold must not be negative")
+	ErrMissingArchiveDriver          = errors.New("missing archive database driver")
+	ErrMissingArchiveDSN             = errors.New("missing archive database dsn")
+	ErrCannonAbsolutePreStateAndURLs = errors.New("only specify one of cannon absolute pre-state or pre-states url")
+
+	ErrMissingAsteriscL2               = errors.New("missing asterisc L2")
+	ErrMissingAsteriscBin              = errors.New("missing asterisc bin")
+	ErrMissingAsteriscServer           = errors.New("missing asterisc server")
+	ErrMissingAsteriscAbsolutePreState = errors.New("missing asterisc absolute pre-state")
+	ErrMissingAsteriscSnapshotFreq     = errors.New("missing asterisc snapshot freq")
+	ErrMissingAsteriscInfoFreq         = errors.New("missing asterisc info freq")
+	ErrMissingAsteriscRollupConfig     = errors.New("missing asterisc network or rollup config path")
+	ErrMissingAsteriscL2Genesis        = errors.New("missing asterisc network or l2 genesis path")
+	ErrAsteriscNetworkAndRollupConfig  = errors.New("only specify one of asterisc network or rollup config path")
+	ErrAsteriscNetworkAndL2Genesis     = errors.New("only specify one of asterisc network or l2 genesis path")
+	ErrAsteriscNetworkUnknown          = errors.New("unknown asterisc network")
+	ErrAsteriscAbsolutePreStateAndURLs = errors.New("only specify one of asterisc absolute pre-state or pre-states url")
+
+	ErrGameEventsRequireWebsocket = errors.New("game event subscriptions require a websocket l1 eth rpc url")
 )
 
 type TraceType string
@@ -40,19 +69,24 @@ type TraceType string
 const (
 	TraceTypeAlphabet TraceType = "alphabet"
 	TraceTypeCannon   TraceType = "cannon"
+	TraceTypeAsterisc TraceType = "asterisc"
 
 	// Mainnet games
 	CannonFaultGameID = 0
 
+	// Experimental games
+	AsteriscFaultGameID = 2
+
 	// Devnet games
 	AlphabetFaultGameID = 255
 )
 
-var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon}
+var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypeAsterisc}
 
 // GameIdToString maps game IDs to their string representation.
 var GameIdToString = map[uint8]string{
 	CannonFaultGameID:   "Cannon",
+	AsteriscFaultGameID: "Asterisc",
 	AlphabetFaultGameID: "Alphabet",
 }
 
@@ -84,15 +118,26 @@ func ValidTraceType(value TraceType) bool {
 }
 
 const (
-	DefaultPollInterval       = time.Second * 12
-	DefaultCannonSnapshotFreq = uint(1_000_000_000)
-	DefaultCannonInfoFreq     = uint(10_000_000)
+	DefaultPollInterval         = time.Second * 12
+	DefaultCannonSnapshotFreq   = uint(1_000_000_000)
+	DefaultCannonInfoFreq       = uint(10_000_000)
+	DefaultAsteriscSnapshotFreq = uint(1_000_000_000)
+	DefaultAsteriscInfoFreq     = uint(10_000_000)
 	// DefaultGameWindow is the default maximum time duration in the past
 	// that the challenger will look for games to progress.
 	// The default value is 11 days, which is a 4 day resolution buffer
 	// and bond claiming buffer plus the 7 day game finalization window.
-	DefaultGameWindow   = time.Duration(11 * 24 * time.Hour)
-	DefaultMaxPendingTx = 10
+	DefaultGameWindow             = time.Duration(11 * 24 * time.Hour)
+	DefaultMaxPendingTx           = 10
+	DefaultLargePreimageBatchSize = uint(100)
+	// DefaultLargePreimageGasPadding is the percentage added to the estimated gas of challenge transactions.
+	DefaultLargePreimageGasPadding = uint64(20)
+	// DefaultLargePreimageGasCap is the maximum gas limit of challenge transactions, equal to the L1 block gas limit.
+	DefaultLargePreimageGasCap = uint64(30_000_000)
+	// DefaultLargePreimageUrgentBlocks is the number of L1 blocks before a proposal is squeezable that its
+	// challenge is escalated.
+	DefaultLargePreimageUrgentBlocks     = uint64(25)
+	DefaultLargePreimageUrgentTipCapGwei = float64(5)
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -100,13 +145,34 @@ const (
 // It is used to initialize the challenger.
 type Config struct {
 	L1EthRpc           string           // L1 RPC Url
+	L1EthRpcFallbacks  []string         // Additional L1 RPC Urls used when fetching large preimage data
 	GameFactoryAddress common.Address   // Address of the dispute game factory
 	GameAllowlist      []common.Address // Allowlist of fault game addresses
+	GameTypeAllowlist  []uint32         // Allowlist of game types to play (all if empty)
+	HonestActors       []common.Address // Addresses trusted to counter claims honestly, avoiding duplicate counters
 	GameWindow         time.Duration    // Maximum time duration to look for games to progress
 	Datadir            string           // Data Directory
 	MaxConcurrency     uint             // Maximum number of threads to use when progressing games
 	PollInterval       time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
 
+	MinGameCreationTime uint64 // Unix timestamp before which created games are not played (0 == no limit)
+
+	SubscribeGameEvents bool // Subscribe to game creation and move events to progress games without waiting for the next L1 head
+
+	LargePreimageWorkers          uint    // Maximum number of large preimage proposals to verify concurrently
+	LargePreimageDryRun           bool    // Verify large preimages and log challenges without sending them
+	LargePreimageBatchSize        uint    // Maximum number of receipts to request in each batch when fetching large preimage leaves
+	LargePreimageSnapshotInterval uint64  // Number of leaves between logged large preimage verification snapshots (0 to disable)
+	LargePreimageGasPadding       uint64  // Percentage to add to the estimated gas of large preimage challenge transactions
+	LargePreimageGasCap           uint64  // Maximum gas limit for large preimage challenge transactions
+	LargePreimageUrgentBlocks     uint64  // Number of L1 blocks before a proposal is squeezable that its challenge is escalated
+	LargePreimageUrgentTipCapGwei float64 // Minimum gas tip cap (in gwei) for escalated large preimage challenges
+
+	AdditionalPreimageOracles []common.Address // Additional preimage oracle contracts to monitor for large preimage proposals
+
+	LargePreimageClaimantAllowlist []common.Address // Trusted claimants to never verify large preimage proposals from
+	LargePreimageClaimantBlocklist []common.Address // Only verify large preimage proposals from these claimants (all if empty)
+
 	TraceTypes []TraceType // Type of traces supported
 
 	// Specific to the output cannon trace type
@@ -122,12 +188,45 @@ type Config struct {
 	CannonL2               string // L2 RPC Url
 	CannonSnapshotFreq     uint   // Frequency of snapshots to create when executing cannon (in VM instructions)
 	CannonInfoFreq         uint   // Frequency of cannon progress log messages (in VM instructions)
+	CannonMaxConcurrency   uint   // Maximum number of cannon and asterisc executions to run concurrently across all games
+
+	CannonPrestatesURLs []*url.URL // Base URLs to download the cannon absolute pre-state required by each game from
+
+	// Specific to the asterisc trace provider
+	AsteriscBin              string // Path to the asterisc executable to run when generating trace data
+	AsteriscServer           string // Path to the op-program executable that provides the pre-image oracle server
+	AsteriscAbsolutePreState string // File to load the absolute pre-state for Asterisc traces from
+	AsteriscNetwork          string
+	AsteriscRollupConfigPath string
+	AsteriscL2GenesisPath    string
+	AsteriscL2               string // L2 RPC Url
+	AsteriscSnapshotFreq     uint   // Frequency of snapshots to create when executing asterisc (in VM instructions)
+	AsteriscInfoFreq         uint   // Frequency of asterisc progress log messages (in VM instructions)
+
+	AsteriscPrestatesURLs []*url.URL // Base URLs to download the asterisc absolute pre-state required by each game from
 
 	MaxPendingTx uint64 // Maximum number of pending transactions (0 == no limit)
 
+	MaxMoveFeeCapGwei    float64       // Maximum gas fee cap (in gwei) for move and step transactions (0 == no limit)
+	MoveUrgencyThreshold time.Duration // Time before a chess clock expires that move and step fees are bumped immediately (0 == disabled)
+
+	MaxPlayDepth uint64 // Maximum depth to post claims at, leaving deeper responses to an operator (0 == no limit)
+
+	MaxGameSpendEth  float64 // Maximum ETH to spend on bonds and gas in a single game (0 == no limit)
+	MaxTotalSpendEth float64 // Maximum ETH to spend on bonds and gas across all games (0 == no limit)
+
+	ResolutionBatchSize uint // Maximum number of claims to resolve in a single multicall transaction (0 or 1 == no batching)
+
+	ArchiveDriver string // database/sql driver used to archive resolved games (archiving disabled if empty)
+	ArchiveDSN    string // Data source name of the database to archive resolved games to
+
+	ChainName string        // Name of the chain, used to label metrics when playing games on multiple chains
+	Chains    []ChainConfig // Additional chains to play games on
+
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
+	APIConfig     api.CLIConfig
 }
 
 func NewConfig(
@@ -142,6 +241,13 @@ func NewConfig(
 		MaxConcurrency:     uint(runtime.NumCPU()),
 		PollInterval:       DefaultPollInterval,
 
+		LargePreimageWorkers:          uint(runtime.NumCPU()),
+		LargePreimageBatchSize:        DefaultLargePreimageBatchSize,
+		LargePreimageGasPadding:       DefaultLargePreimageGasPadding,
+		LargePreimageGasCap:           DefaultLargePreimageGasCap,
+		LargePreimageUrgentBlocks:     DefaultLargePreimageUrgentBlocks,
+		LargePreimageUrgentTipCapGwei: DefaultLargePreimageUrgentTipCapGwei,
+
 		TraceTypes: supportedTraceTypes,
 
 		MaxPendingTx: DefaultMaxPendingTx,
@@ -149,12 +255,16 @@ func NewConfig(
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
+		APIConfig:     api.DefaultCLIConfig(),
 
 		Datadir: datadir,
 
-		CannonSnapshotFreq: DefaultCannonSnapshotFreq,
-		CannonInfoFreq:     DefaultCannonInfoFreq,
-		GameWindow:         DefaultGameWindow,
+		CannonSnapshotFreq:   DefaultCannonSnapshotFreq,
+		CannonInfoFreq:       DefaultCannonInfoFreq,
+		CannonMaxConcurrency: uint(runtime.NumCPU()),
+		AsteriscSnapshotFreq: DefaultAsteriscSnapshotFreq,
+		AsteriscInfoFreq:     DefaultAsteriscInfoFreq,
+		GameWindow:           DefaultGameWindow,
 	}
 }
 
@@ -169,6 +279,9 @@ func (c Config) Check() error {
 	if c.RollupRpc == "" {
 		return ErrMissingRollupRpc
 	}
+	if c.SubscribeGameEvents && !strings.HasPrefix(c.L1EthRpc, "ws://") && !strings.HasPrefix(c.L1EthRpc, "wss://") {
+		return ErrGameEventsRequireWebsocket
+	}
 	if c.GameFactoryAddress == (common.Address{}) {
 		return ErrMissingGameFactoryAddress
 	}
@@ -181,6 +294,18 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
+	if c.LargePreimageWorkers == 0 {
+		return ErrLargePreimageWorkersZero
+	}
+	if c.LargePreimageBatchSize == 0 {
+		return ErrLargePreimageBatchSizeZero
+	}
+	if c.LargePreimageGasCap == 0 {
+		return ErrLargePreimageGasCapZero
+	}
+	if c.LargePreimageUrgentTipCapGwei < 0 {
+		return ErrNegativeUrgentTipCap
+	}
 	if c.TraceTypeEnabled(TraceTypeCannon) {
 		if c.CannonBin == "" {
 			return ErrMissingCannonBin
@@ -206,9 +331,12 @@ func (c Config) Check() error {
 				return fmt.Errorf("%w: %v", ErrCannonNetworkUnknown, c.CannonNetwork)
 			}
 		}
-		if c.CannonAbsolutePreState == "" {
+		if c.CannonAbsolutePreState == "" && len(c.CannonPrestatesURLs) == 0 {
 			return ErrMissingCannonAbsolutePreState
 		}
+		if c.CannonAbsolutePreState != "" && len(c.CannonPrestatesURLs) > 0 {
+			return ErrCannonAbsolutePreStateAndURLs
+		}
 		if c.CannonL2 == "" {
 			return ErrMissingCannonL2
 		}
@@ -219,6 +347,65 @@ func (c Config) Check() error {
 			return ErrMissingCannonInfoFreq
 		}
 	}
+	if c.TraceTypeEnabled(TraceTypeAsterisc) {
+		if c.AsteriscBin == "" {
+			return ErrMissingAsteriscBin
+		}
+		if c.AsteriscServer == "" {
+			return ErrMissingAsteriscServer
+		}
+		if c.AsteriscNetwork == "" {
+			if c.AsteriscRollupConfigPath == "" {
+				return ErrMissingAsteriscRollupConfig
+			}
+			if c.AsteriscL2GenesisPath == "" {
+				return ErrMissingAsteriscL2Genesis
+			}
+		} else {
+			if c.AsteriscRollupConfigPath != "" {
+				return ErrAsteriscNetworkAndRollupConfig
+			}
+			if c.AsteriscL2GenesisPath != "" {
+				return ErrAsteriscNetworkAndL2Genesis
+			}
+			if ch := chaincfg.ChainByName(c.AsteriscNetwork); ch == nil {
+				return fmt.Errorf("%w: %v", ErrAsteriscNetworkUnknown, c.AsteriscNetwork)
+			}
+		}
+		if c.AsteriscAbsolutePreState == "" && len(c.AsteriscPrestatesURLs) == 0 {
+			return ErrMissingAsteriscAbsolutePreState
+		}
+		if c.AsteriscAbsolutePreState != "" && len(c.AsteriscPrestatesURLs) > 0 {
+			return ErrAsteriscAbsolutePreStateAndURLs
+		}
+		if c.AsteriscL2 == "" {
+			return ErrMissingAsteriscL2
+		}
+		if c.AsteriscSnapshotFreq == 0 {
+			return ErrMissingAsteriscSnapshotFreq
+		}
+		if c.AsteriscInfoFreq == 0 {
+			return ErrMissingAsteriscInfoFreq
+		}
+	}
+	if (c.TraceTypeEnabled(TraceTypeCannon) || c.TraceTypeEnabled(TraceTypeAsterisc)) && c.CannonMaxConcurrency == 0 {
+		return ErrCannonMaxConcurrencyZero
+	}
+	if c.MaxGameSpendEth < 0 || c.MaxTotalSpendEth < 0 {
+		return ErrNegativeSpendBudget
+	}
+	if c.MaxMoveFeeCapGwei < 0 {
+		return ErrNegativeMoveFeeCap
+	}
+	if c.MoveUrgencyThreshold < 0 {
+		return ErrNegativeMoveUrgency
+	}
+	if c.ArchiveDriver != "" && c.ArchiveDSN == "" {
+		return ErrMissingArchiveDSN
+	}
+	if c.ArchiveDSN != "" && c.ArchiveDriver == "" {
+		return ErrMissingArchiveDriver
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -228,5 +415,11 @@ func (c Config) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.APIConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.checkChains(); err != nil {
+		return err
+	}
 	return nil
 }
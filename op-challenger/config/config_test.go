@@ -1,8 +1,10 @@
 package config
 
 import (
+	"net/url"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -20,6 +22,12 @@ var (
 	validDatadir               = "/tmp/data"
 	validCannonL2              = "http://localhost:9545"
 	validRollupRpc             = "http://localhost:8555"
+
+	validAsteriscBin             = "./bin/asterisc"
+	validAsteriscOpProgramBin    = "./bin/op-program"
+	validAsteriscNetwork         = "mainnet"
+	validAsteriscAbsolutPreState = "pre.json"
+	validAsteriscL2              = "http://localhost:9545"
 )
 
 func validConfig(traceType TraceType) Config {
@@ -31,6 +39,13 @@ func validConfig(traceType TraceType) Config {
 		cfg.CannonL2 = validCannonL2
 		cfg.CannonNetwork = validCannonNetwork
 	}
+	if traceType == TraceTypeAsterisc {
+		cfg.AsteriscBin = validAsteriscBin
+		cfg.AsteriscServer = validAsteriscOpProgramBin
+		cfg.AsteriscAbsolutePreState = validAsteriscAbsolutPreState
+		cfg.AsteriscL2 = validAsteriscL2
+		cfg.AsteriscNetwork = validAsteriscNetwork
+	}
 	cfg.RollupRpc = validRollupRpc
 	return cfg
 }
@@ -90,6 +105,24 @@ func TestCannonAbsolutePreStateRequired(t *testing.T) {
 	require.ErrorIs(t, config.Check(), ErrMissingCannonAbsolutePreState)
 }
 
+func TestCannonPrestatesURLs(t *testing.T) {
+	prestates, err := url.Parse("https://example.com/prestates")
+	require.NoError(t, err)
+
+	t.Run("ReplacesAbsolutePreState", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		config.CannonAbsolutePreState = ""
+		config.CannonPrestatesURLs = []*url.URL{prestates}
+		require.NoError(t, config.Check())
+	})
+
+	t.Run("NotBothAbsolutePreStateAndURLs", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		config.CannonPrestatesURLs = []*url.URL{prestates}
+		require.ErrorIs(t, config.Check(), ErrCannonAbsolutePreStateAndURLs)
+	})
+}
+
 func TestDatadirRequired(t *testing.T) {
 	config := validConfig(TraceTypeAlphabet)
 	config.Datadir = ""
@@ -109,6 +142,40 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestLargePreimageWorkers(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		config.LargePreimageWorkers = 0
+		require.ErrorIs(t, config.Check(), ErrLargePreimageWorkersZero)
+	})
+
+	t.Run("DefaultToNumberOfCPUs", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		require.EqualValues(t, runtime.NumCPU(), config.LargePreimageWorkers)
+	})
+}
+
+func TestLargePreimageBatchSize(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Equal(t, DefaultLargePreimageBatchSize, config.LargePreimageBatchSize)
+	config.LargePreimageBatchSize = 0
+	require.ErrorIs(t, config.Check(), ErrLargePreimageBatchSizeZero)
+}
+
+func TestLargePreimageGasCap(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Equal(t, DefaultLargePreimageGasCap, config.LargePreimageGasCap)
+	config.LargePreimageGasCap = 0
+	require.ErrorIs(t, config.Check(), ErrLargePreimageGasCapZero)
+}
+
+func TestLargePreimageUrgentTipCap(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	require.Equal(t, DefaultLargePreimageUrgentTipCapGwei, config.LargePreimageUrgentTipCapGwei)
+	config.LargePreimageUrgentTipCapGwei = -1
+	require.ErrorIs(t, config.Check(), ErrNegativeUrgentTipCap)
+}
+
 func TestHttpPollInterval(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		config := validConfig(TraceTypeAlphabet)
@@ -150,6 +217,20 @@ func TestCannonInfoFreq(t *testing.T) {
 	})
 }
 
+func TestCannonMaxConcurrency(t *testing.T) {
+	t.Run("MustNotBeZero", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.CannonMaxConcurrency = 0
+		require.ErrorIs(t, cfg.Check(), ErrCannonMaxConcurrencyZero)
+	})
+
+	t.Run("NotRequiredForAlphabetTrace", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.CannonMaxConcurrency = 0
+		require.NoError(t, cfg.Check())
+	})
+}
+
 func TestCannonNetworkOrRollupConfigRequired(t *testing.T) {
 	cfg := validConfig(TraceTypeCannon)
 	cfg.CannonNetwork = ""
@@ -188,6 +269,50 @@ func TestNetworkMustBeValid(t *testing.T) {
 	require.ErrorIs(t, cfg.Check(), ErrCannonNetworkUnknown)
 }
 
+func TestAsteriscRequiredArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected error
+	}{
+		{"Bin", func(cfg *Config) { cfg.AsteriscBin = "" }, ErrMissingAsteriscBin},
+		{"Server", func(cfg *Config) { cfg.AsteriscServer = "" }, ErrMissingAsteriscServer},
+		{"AbsolutePreState", func(cfg *Config) { cfg.AsteriscAbsolutePreState = "" }, ErrMissingAsteriscAbsolutePreState},
+		{"AbsolutePreStateAndURLs", func(cfg *Config) {
+			cfg.AsteriscPrestatesURLs = []*url.URL{{Scheme: "https", Host: "example.com"}}
+		}, ErrAsteriscAbsolutePreStateAndURLs},
+		{"L2", func(cfg *Config) { cfg.AsteriscL2 = "" }, ErrMissingAsteriscL2},
+		{"SnapshotFreq", func(cfg *Config) { cfg.AsteriscSnapshotFreq = 0 }, ErrMissingAsteriscSnapshotFreq},
+		{"InfoFreq", func(cfg *Config) { cfg.AsteriscInfoFreq = 0 }, ErrMissingAsteriscInfoFreq},
+		{"MaxConcurrency", func(cfg *Config) { cfg.CannonMaxConcurrency = 0 }, ErrCannonMaxConcurrencyZero},
+		{"RollupConfig", func(cfg *Config) {
+			cfg.AsteriscNetwork = ""
+			cfg.AsteriscL2GenesisPath = "genesis.json"
+		}, ErrMissingAsteriscRollupConfig},
+		{"L2Genesis", func(cfg *Config) {
+			cfg.AsteriscNetwork = ""
+			cfg.AsteriscRollupConfigPath = "rollup.json"
+		}, ErrMissingAsteriscL2Genesis},
+		{"NetworkAndRollupConfig", func(cfg *Config) { cfg.AsteriscRollupConfigPath = "rollup.json" }, ErrAsteriscNetworkAndRollupConfig},
+		{"NetworkAndL2Genesis", func(cfg *Config) { cfg.AsteriscL2GenesisPath = "genesis.json" }, ErrAsteriscNetworkAndL2Genesis},
+		{"UnknownNetwork", func(cfg *Config) { cfg.AsteriscNetwork = "unknown" }, ErrAsteriscNetworkUnknown},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			cfg := validConfig(TraceTypeAsterisc)
+			test.modify(&cfg)
+			require.ErrorIs(t, cfg.Check(), test.expected)
+		})
+	}
+
+	t.Run("NotRequiredForCannon", func(t *testing.T) {
+		cfg := validConfig(TraceTypeCannon)
+		cfg.AsteriscBin = ""
+		require.NoError(t, cfg.Check())
+	})
+}
+
 func TestRequireConfigForMultipleTraceTypes(t *testing.T) {
 	cfg := validConfig(TraceTypeCannon)
 	cfg.TraceTypes = []TraceType{TraceTypeCannon, TraceTypeAlphabet}
@@ -204,3 +329,90 @@ func TestRequireConfigForMultipleTraceTypes(t *testing.T) {
 	cfg.RollupRpc = ""
 	require.ErrorIs(t, cfg.Check(), ErrMissingRollupRpc)
 }
+
+func TestArchiveConfig(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ArchiveDriver = "sqlite"
+		cfg.ArchiveDSN = "games.db"
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("MissingDSN", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ArchiveDriver = "sqlite"
+		require.ErrorIs(t, cfg.Check(), ErrMissingArchiveDSN)
+	})
+
+	t.Run("MissingDriver", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.ArchiveDSN = "games.db"
+		require.ErrorIs(t, cfg.Check(), ErrMissingArchiveDriver)
+	})
+}
+
+func TestSubscribeGameEvents(t *testing.T) {
+	t.Run("RequiresWebsocket", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.SubscribeGameEvents = true
+		cfg.L1EthRpc = "http://localhost:8545"
+		require.ErrorIs(t, cfg.Check(), ErrGameEventsRequireWebsocket)
+	})
+
+	for _, url := range []string{"ws://localhost:8546", "wss://example.com"} {
+		url := url
+		t.Run("Valid-"+url, func(t *testing.T) {
+			cfg := validConfig(TraceTypeAlphabet)
+			cfg.SubscribeGameEvents = true
+			cfg.L1EthRpc = url
+			require.NoError(t, cfg.Check())
+		})
+	}
+}
+
+func TestSpendBudget(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.MaxGameSpendEth = 1.5
+		cfg.MaxTotalSpendEth = 10
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("NegativeGameSpend", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.MaxGameSpendEth = -1
+		require.ErrorIs(t, cfg.Check(), ErrNegativeSpendBudget)
+	})
+
+	t.Run("NegativeTotalSpend", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.MaxTotalSpendEth = -1
+		require.ErrorIs(t, cfg.Check(), ErrNegativeSpendBudget)
+	})
+}
+
+func TestMoveFees(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.MaxMoveFeeCapGwei = 150
+		cfg.MoveUrgencyThreshold = time.Hour
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("NegativeFeeCap", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.MaxMoveFeeCapGwei = -1
+		require.ErrorIs(t, cfg.Check(), ErrNegativeMoveFeeCap)
+	})
+
+	t.Run("NegativeUrgencyThreshold", func(t *testing.T) {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.MoveUrgencyThreshold = -time.Second
+		require.ErrorIs(t, cfg.Check(), ErrNegativeMoveUrgency)
+	})
+}
@@ -2,6 +2,7 @@ package flags
 
 import (
 	"fmt"
+	"net/url"
 	"runtime"
 	"slices"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/api"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
@@ -34,6 +36,12 @@ var (
 		Usage:   "HTTP provider URL for L1.",
 		EnvVars: prefixEnvVars("L1_ETH_RPC"),
 	}
+	L1EthRpcFallbacksFlag = &cli.StringSliceFlag{
+		Name: "l1-eth-rpc-fallbacks",
+		Usage: "List of additional HTTP provider URLs for L1 to fail over to when fetching large preimage data " +
+			"if the primary L1 provider fails.",
+		EnvVars: prefixEnvVars("L1_ETH_RPC_FALLBACKS"),
+	}
 	FactoryAddressFlag = &cli.StringFlag{
 		Name:    "game-factory-address",
 		Usage:   "Address of the fault game factory contract.",
@@ -45,6 +53,23 @@ var (
 			"If empty, the challenger will play all games.",
 		EnvVars: prefixEnvVars("GAME_ALLOWLIST"),
 	}
+	GameTypeAllowlistFlag = &cli.UintSliceFlag{
+		Name:    "game-type-allowlist",
+		Usage:   "List of game types the challenger is allowed to play. If empty, the challenger will play all game types.",
+		EnvVars: prefixEnvVars("GAME_TYPE_ALLOWLIST"),
+	}
+	MinGameCreationTimeFlag = &cli.Uint64Flag{
+		Name: "min-game-creation-time",
+		Usage: "Unix timestamp before which created games are not played, for example when upgrading the trace provider. " +
+			"Bonds are still claimed from ignored games. 0 disables the limit.",
+		EnvVars: prefixEnvVars("MIN_GAME_CREATION_TIME"),
+	}
+	HonestActorsFlag = &cli.StringSliceFlag{
+		Name: "honest-actors",
+		Usage: "List of addresses known to play games honestly. Claims already countered by one of these addresses are " +
+			"not countered again, avoiding duplicate bonds. Counters from other addresses are not trusted.",
+		EnvVars: prefixEnvVars("HONEST_ACTORS"),
+	}
 	TraceTypeFlag = &cli.StringSliceFlag{
 		Name:    "trace-type",
 		Usage:   "The trace types to support. Valid options: " + openum.EnumString(config.TraceTypes),
@@ -63,6 +88,70 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   uint(runtime.NumCPU()),
 	}
+	LargePreimageWorkersFlag = &cli.UintFlag{
+		Name:    "large-preimage-workers",
+		Usage:   "Maximum number of large preimage proposals to verify concurrently",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_WORKERS"),
+		Value:   uint(runtime.NumCPU()),
+	}
+	LargePreimageDryRunFlag = &cli.BoolFlag{
+		Name:    "large-preimage-dry-run",
+		Usage:   "Verify large preimages and log the challenges that would be sent without sending any transactions",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_DRY_RUN"),
+	}
+	LargePreimageBatchSizeFlag = &cli.UintFlag{
+		Name:    "large-preimage-fetch-batch-size",
+		Usage:   "Maximum number of transaction receipts to request in each batch when fetching large preimage leaves",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_FETCH_BATCH_SIZE"),
+		Value:   config.DefaultLargePreimageBatchSize,
+	}
+	LargePreimageSnapshotIntervalFlag = &cli.Uint64Flag{
+		Name:    "large-preimage-snapshot-interval",
+		Usage:   "Number of leaves absorbed between logged snapshots of the keccak state when verifying large preimages (0 to disable)",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_SNAPSHOT_INTERVAL"),
+	}
+	LargePreimageGasPaddingFlag = &cli.Uint64Flag{
+		Name:    "large-preimage-gas-padding",
+		Usage:   "Percentage to add to the estimated gas of large preimage challenge transactions",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_GAS_PADDING"),
+		Value:   config.DefaultLargePreimageGasPadding,
+	}
+	LargePreimageGasCapFlag = &cli.Uint64Flag{
+		Name:    "large-preimage-gas-cap",
+		Usage:   "Maximum gas limit for large preimage challenge transactions",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_GAS_CAP"),
+		Value:   config.DefaultLargePreimageGasCap,
+	}
+	LargePreimageUrgentBlocksFlag = &cli.Uint64Flag{
+		Name: "large-preimage-urgent-blocks",
+		Usage: "Number of L1 blocks before an invalid large preimage proposal can be squeezed that its challenge is escalated " +
+			"by sending it immediately with a higher priority fee",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_URGENT_BLOCKS"),
+		Value:   config.DefaultLargePreimageUrgentBlocks,
+	}
+	LargePreimageUrgentTipCapFlag = &cli.Float64Flag{
+		Name:    "large-preimage-urgent-tip-cap",
+		Usage:   "Minimum gas tip cap (in gwei) for escalated large preimage challenge transactions",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_URGENT_TIP_CAP"),
+		Value:   config.DefaultLargePreimageUrgentTipCapGwei,
+	}
+	LargePreimageClaimantAllowlistFlag = &cli.StringSliceFlag{
+		Name:    "large-preimage-claimant-allowlist",
+		Usage:   "List of trusted claimant addresses, such as our own proposer, to skip verification of large preimage proposals from",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_CLAIMANT_ALLOWLIST"),
+	}
+	LargePreimageClaimantBlocklistFlag = &cli.StringSliceFlag{
+		Name: "large-preimage-claimant-blocklist",
+		Usage: "List of claimant addresses to exclusively verify large preimage proposals from. " +
+			"If empty, proposals from all claimants not in the allowlist are verified. Takes precedence over the allowlist.",
+		EnvVars: prefixEnvVars("LARGE_PREIMAGE_CLAIMANT_BLOCKLIST"),
+	}
+	AdditionalPreimageOraclesFlag = &cli.StringSliceFlag{
+		Name: "additional-preimage-oracles",
+		Usage: "List of additional PreimageOracle contract addresses to monitor for large preimage proposals. " +
+			"The oracles used by supported game types are always monitored.",
+		EnvVars: prefixEnvVars("ADDITIONAL_PREIMAGE_ORACLES"),
+	}
 	MaxPendingTransactionsFlag = &cli.Uint64Flag{
 		Name:    "max-pending-tx",
 		Usage:   "The maximum number of pending transactions. 0 for no limit.",
@@ -75,6 +164,12 @@ var (
 		EnvVars: prefixEnvVars("HTTP_POLL_INTERVAL"),
 		Value:   config.DefaultPollInterval,
 	}
+	SubscribeGameEventsFlag = &cli.BoolFlag{
+		Name: "subscribe-game-events",
+		Usage: "Subscribe to new game and move events so games are progressed as soon as they change instead of " +
+			"waiting for the next L1 head. Requires a websocket L1 RPC.",
+		EnvVars: prefixEnvVars("SUBSCRIBE_GAME_EVENTS"),
+	}
 	RollupRpcFlag = &cli.StringFlag{
 		Name:    "rollup-rpc",
 		Usage:   "HTTP provider URL for the rollup node",
@@ -113,14 +208,20 @@ var (
 		Usage:   "Path to absolute prestate to use when generating trace data (cannon trace type only)",
 		EnvVars: prefixEnvVars("CANNON_PRESTATE"),
 	}
+	CannonPreStatesURLFlag = &cli.StringSliceFlag{
+		Name:    "cannon-prestates-url",
+		Usage:   "Base URLs to download absolute prestates from, selected by the prestate hash each game requires. Prestates are verified against the hash and cached in the datadir. (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_PRESTATES_URL"),
+	}
 	CannonL2Flag = &cli.StringFlag{
 		Name:    "cannon-l2",
 		Usage:   "L2 Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)  (cannon trace type only)",
 		EnvVars: prefixEnvVars("CANNON_L2"),
 	}
 	CannonSnapshotFreqFlag = &cli.UintFlag{
-		Name:    "cannon-snapshot-freq",
-		Usage:   "Frequency of cannon snapshots to generate in VM steps (cannon trace type only)",
+		Name: "cannon-snapshot-freq",
+		Usage: "Frequency of cannon snapshots to generate in VM steps (cannon trace type only). " +
+			"Snapshots are kept for each in-progress game so trace generation resumes from the closest snapshot after a restart.",
 		EnvVars: prefixEnvVars("CANNON_SNAPSHOT_FREQ"),
 		Value:   config.DefaultCannonSnapshotFreq,
 	}
@@ -130,6 +231,68 @@ var (
 		EnvVars: prefixEnvVars("CANNON_INFO_FREQ"),
 		Value:   config.DefaultCannonInfoFreq,
 	}
+	CannonMaxConcurrencyFlag = &cli.UintFlag{
+		Name: "cannon-max-concurrency",
+		Usage: "Maximum number of cannon and asterisc executions to run concurrently across all games (cannon and asterisc trace types only). " +
+			"Executions beyond the limit are queued, prioritising games with the least time remaining.",
+		EnvVars: prefixEnvVars("CANNON_MAX_CONCURRENCY"),
+		Value:   uint(runtime.NumCPU()),
+	}
+	AsteriscNetworkFlag = &cli.StringFlag{
+		Name: "asterisc-network",
+		Usage: fmt.Sprintf(
+			"Predefined network selection. Available networks: %s (asterisc trace type only)",
+			strings.Join(chaincfg.AvailableNetworks(), ", "),
+		),
+		EnvVars: prefixEnvVars("ASTERISC_NETWORK"),
+	}
+	AsteriscRollupConfigFlag = &cli.StringFlag{
+		Name:    "asterisc-rollup-config",
+		Usage:   "Rollup chain parameters (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_ROLLUP_CONFIG"),
+	}
+	AsteriscL2GenesisFlag = &cli.StringFlag{
+		Name:    "asterisc-l2-genesis",
+		Usage:   "Path to the op-geth genesis file (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_L2_GENESIS"),
+	}
+	AsteriscBinFlag = &cli.StringFlag{
+		Name:    "asterisc-bin",
+		Usage:   "Path to asterisc executable to use when generating trace data (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_BIN"),
+	}
+	AsteriscServerFlag = &cli.StringFlag{
+		Name:    "asterisc-server",
+		Usage:   "Path to executable to use as pre-image oracle server when generating trace data (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_SERVER"),
+	}
+	AsteriscPreStateFlag = &cli.StringFlag{
+		Name:    "asterisc-prestate",
+		Usage:   "Path to absolute prestate to use when generating trace data (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_PRESTATE"),
+	}
+	AsteriscPreStatesURLFlag = &cli.StringSliceFlag{
+		Name:    "asterisc-prestates-url",
+		Usage:   "Base URLs to download absolute prestates from, selected by the prestate hash each game requires. Prestates are verified against the hash and cached in the datadir. (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_PRESTATES_URL"),
+	}
+	AsteriscL2Flag = &cli.StringFlag{
+		Name:    "asterisc-l2",
+		Usage:   "L2 Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)  (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_L2"),
+	}
+	AsteriscSnapshotFreqFlag = &cli.UintFlag{
+		Name:    "asterisc-snapshot-freq",
+		Usage:   "Frequency of asterisc snapshots to generate in VM steps (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_SNAPSHOT_FREQ"),
+		Value:   config.DefaultAsteriscSnapshotFreq,
+	}
+	AsteriscInfoFreqFlag = &cli.UintFlag{
+		Name:    "asterisc-info-freq",
+		Usage:   "Frequency of asterisc info log messages to generate in VM steps (asterisc trace type only)",
+		EnvVars: prefixEnvVars("ASTERISC_INFO_FREQ"),
+		Value:   config.DefaultAsteriscInfoFreq,
+	}
 	GameWindowFlag = &cli.DurationFlag{
 		Name: "game-window",
 		Usage: "The time window which the challenger will look for games to progress and claim bonds. " +
@@ -137,6 +300,64 @@ var (
 		EnvVars: prefixEnvVars("GAME_WINDOW"),
 		Value:   config.DefaultGameWindow,
 	}
+	ChainNameFlag = &cli.StringFlag{
+		Name:    "chain-name",
+		Usage:   "Name of the chain, used to label metrics. Required when additional chains are configured.",
+		EnvVars: prefixEnvVars("CHAIN_NAME"),
+	}
+	ChainsConfigFlag = &cli.PathFlag{
+		Name: "chains-config",
+		Usage: "Path to a JSON file listing additional chains to play games on. Each chain specifies its name, L1 RPC, " +
+			"game factory address and rollup RPC and may override the datadir, trace types and cannon chain settings.",
+		EnvVars: prefixEnvVars("CHAINS_CONFIG"),
+	}
+	MaxGameSpendFlag = &cli.Float64Flag{
+		Name: "max-game-spend",
+		Usage: "Maximum amount of ETH to spend on bonds and gas in a single game. " +
+			"New claims are not posted on a game once exceeded. 0 disables the limit.",
+		EnvVars: prefixEnvVars("MAX_GAME_SPEND"),
+	}
+	MaxTotalSpendFlag = &cli.Float64Flag{
+		Name: "max-total-spend",
+		Usage: "Maximum amount of ETH to spend on bonds and gas across all games. Spending is stored in the datadir " +
+			"and shared by all chains. New claims are not posted on any game once exceeded. 0 disables the limit.",
+		EnvVars: prefixEnvVars("MAX_TOTAL_SPEND"),
+	}
+	MaxMoveFeeCapFlag = &cli.Float64Flag{
+		Name: "max-move-fee-cap",
+		Usage: "Maximum gas fee cap in gwei for move and step transactions. " +
+			"Fees are not bumped beyond this limit, leaving the transaction pending until fees fall. 0 disables the limit.",
+		EnvVars: prefixEnvVars("MAX_MOVE_FEE_CAP"),
+	}
+	MoveUrgencyThresholdFlag = &cli.DurationFlag{
+		Name: "move-urgency-threshold",
+		Usage: "Time before the chess clock expires at which fees for pending move and step transactions are bumped " +
+			"immediately instead of waiting for the next resubmission. 0 disables urgent fee bumps.",
+		EnvVars: prefixEnvVars("MOVE_URGENCY_THRESHOLD"),
+	}
+	MaxPlayDepthFlag = &cli.Uint64Flag{
+		Name: "max-play-depth",
+		Usage: "Maximum depth to post claims at. Claims requiring a deeper response are not countered and instead " +
+			"raise an alert so an operator can respond. Games are still monitored and resolved. 0 disables the limit.",
+		EnvVars: prefixEnvVars("MAX_PLAY_DEPTH"),
+	}
+	ResolutionBatchSizeFlag = &cli.UintFlag{
+		Name: "resolution-batch-size",
+		Usage: "Maximum number of claims, across all games, to resolve in a single transaction via the Multicall3 contract. " +
+			"Claims are resolved individually if the batched transaction fails. 0 or 1 disables batching.",
+		EnvVars: prefixEnvVars("RESOLUTION_BATCH_SIZE"),
+	}
+	ArchiveDriverFlag = &cli.StringFlag{
+		Name: "archive-db-driver",
+		Usage: "database/sql driver used to archive resolved games: 'sqlite' for SQLite or 'pgx' for Postgres. " +
+			"Other drivers must be added to the build. Archiving is disabled if not set.",
+		EnvVars: prefixEnvVars("ARCHIVE_DB_DRIVER"),
+	}
+	ArchiveDSNFlag = &cli.StringFlag{
+		Name:    "archive-db-dsn",
+		Usage:   "Data source name of the database to archive resolved games to, such as a file path for SQLite or a postgres:// URL.",
+		EnvVars: prefixEnvVars("ARCHIVE_DB_DSN"),
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
@@ -149,21 +370,59 @@ var requiredFlags = []cli.Flag{
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
 	TraceTypeFlag,
+	L1EthRpcFallbacksFlag,
 	MaxConcurrencyFlag,
+	LargePreimageWorkersFlag,
+	LargePreimageDryRunFlag,
+	LargePreimageBatchSizeFlag,
+	LargePreimageSnapshotIntervalFlag,
+	LargePreimageGasPaddingFlag,
+	LargePreimageGasCapFlag,
+	LargePreimageUrgentBlocksFlag,
+	LargePreimageUrgentTipCapFlag,
+	LargePreimageClaimantAllowlistFlag,
+	LargePreimageClaimantBlocklistFlag,
+	AdditionalPreimageOraclesFlag,
 	MaxPendingTransactionsFlag,
 	HTTPPollInterval,
+	SubscribeGameEventsFlag,
 	RollupRpcFlag,
 	GameAllowlistFlag,
+	GameTypeAllowlistFlag,
+	MinGameCreationTimeFlag,
+	HonestActorsFlag,
 	CannonNetworkFlag,
 	CannonRollupConfigFlag,
 	CannonL2GenesisFlag,
 	CannonBinFlag,
 	CannonServerFlag,
 	CannonPreStateFlag,
+	CannonPreStatesURLFlag,
 	CannonL2Flag,
 	CannonSnapshotFreqFlag,
 	CannonInfoFreqFlag,
+	CannonMaxConcurrencyFlag,
+	AsteriscNetworkFlag,
+	AsteriscRollupConfigFlag,
+	AsteriscL2GenesisFlag,
+	AsteriscBinFlag,
+	AsteriscServerFlag,
+	AsteriscPreStateFlag,
+	AsteriscPreStatesURLFlag,
+	AsteriscL2Flag,
+	AsteriscSnapshotFreqFlag,
+	AsteriscInfoFreqFlag,
 	GameWindowFlag,
+	ChainNameFlag,
+	ChainsConfigFlag,
+	MaxGameSpendFlag,
+	MaxTotalSpendFlag,
+	MaxMoveFeeCapFlag,
+	MoveUrgencyThresholdFlag,
+	MaxPlayDepthFlag,
+	ResolutionBatchSizeFlag,
+	ArchiveDriverFlag,
+	ArchiveDSNFlag,
 }
 
 func init() {
@@ -171,6 +430,7 @@ func init() {
 	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(envVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, api.CLIFlags(envVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -195,8 +455,8 @@ func CheckCannonFlags(ctx *cli.Context) error {
 	if !ctx.IsSet(CannonServerFlag.Name) {
 		return fmt.Errorf("flag %s is required", CannonServerFlag.Name)
 	}
-	if !ctx.IsSet(CannonPreStateFlag.Name) {
-		return fmt.Errorf("flag %s is required", CannonPreStateFlag.Name)
+	if !ctx.IsSet(CannonPreStateFlag.Name) && !ctx.IsSet(CannonPreStatesURLFlag.Name) {
+		return fmt.Errorf("flag %s or %s is required", CannonPreStateFlag.Name, CannonPreStatesURLFlag.Name)
 	}
 	if !ctx.IsSet(CannonL2Flag.Name) {
 		return fmt.Errorf("flag %s is required", CannonL2Flag.Name)
@@ -204,6 +464,32 @@ func CheckCannonFlags(ctx *cli.Context) error {
 	return nil
 }
 
+func CheckAsteriscFlags(ctx *cli.Context) error {
+	if !ctx.IsSet(AsteriscNetworkFlag.Name) &&
+		!(ctx.IsSet(AsteriscRollupConfigFlag.Name) && ctx.IsSet(AsteriscL2GenesisFlag.Name)) {
+		return fmt.Errorf("flag %v or %v and %v is required",
+			AsteriscNetworkFlag.Name, AsteriscRollupConfigFlag.Name, AsteriscL2GenesisFlag.Name)
+	}
+	if ctx.IsSet(AsteriscNetworkFlag.Name) &&
+		(ctx.IsSet(AsteriscRollupConfigFlag.Name) || ctx.IsSet(AsteriscL2GenesisFlag.Name)) {
+		return fmt.Errorf("flag %v can not be used with %v and %v",
+			AsteriscNetworkFlag.Name, AsteriscRollupConfigFlag.Name, AsteriscL2GenesisFlag.Name)
+	}
+	if !ctx.IsSet(AsteriscBinFlag.Name) {
+		return fmt.Errorf("flag %s is required", AsteriscBinFlag.Name)
+	}
+	if !ctx.IsSet(AsteriscServerFlag.Name) {
+		return fmt.Errorf("flag %s is required", AsteriscServerFlag.Name)
+	}
+	if !ctx.IsSet(AsteriscPreStateFlag.Name) && !ctx.IsSet(AsteriscPreStatesURLFlag.Name) {
+		return fmt.Errorf("flag %s or %s is required", AsteriscPreStateFlag.Name, AsteriscPreStatesURLFlag.Name)
+	}
+	if !ctx.IsSet(AsteriscL2Flag.Name) {
+		return fmt.Errorf("flag %s is required", AsteriscL2Flag.Name)
+	}
+	return nil
+}
+
 func CheckRequired(ctx *cli.Context, traceTypes []config.TraceType) error {
 	for _, f := range requiredFlags {
 		if !ctx.IsSet(f.Names()[0]) {
@@ -219,6 +505,13 @@ func CheckRequired(ctx *cli.Context, traceTypes []config.TraceType) error {
 			if !ctx.IsSet(RollupRpcFlag.Name) {
 				return fmt.Errorf("flag %s is required", RollupRpcFlag.Name)
 			}
+		case config.TraceTypeAsterisc:
+			if err := CheckAsteriscFlags(ctx); err != nil {
+				return err
+			}
+			if !ctx.IsSet(RollupRpcFlag.Name) {
+				return fmt.Errorf("flag %s is required", RollupRpcFlag.Name)
+			}
 		case config.TraceTypeAlphabet:
 			if !ctx.IsSet(RollupRpcFlag.Name) {
 				return fmt.Errorf("flag %s is required", RollupRpcFlag.Name)
@@ -257,6 +550,10 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	var allowedGameTypes []uint32
+	for _, gameType := range ctx.UintSlice(GameTypeAllowlistFlag.Name) {
+		allowedGameTypes = append(allowedGameTypes, uint32(gameType))
+	}
 	var allowedGames []common.Address
 	if ctx.StringSlice(GameAllowlistFlag.Name) != nil {
 		for _, addr := range ctx.StringSlice(GameAllowlistFlag.Name) {
@@ -268,37 +565,154 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		}
 	}
 
+	var additionalOracles []common.Address
+	for _, addr := range ctx.StringSlice(AdditionalPreimageOraclesFlag.Name) {
+		oracleAddress, err := opservice.ParseAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(additionalOracles, oracleAddress) {
+			additionalOracles = append(additionalOracles, oracleAddress)
+		}
+	}
+
+	claimantAllowlist, err := parseAddresses(ctx.StringSlice(LargePreimageClaimantAllowlistFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	claimantBlocklist, err := parseAddresses(ctx.StringSlice(LargePreimageClaimantBlocklistFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	honestActors, err := parseAddresses(ctx.StringSlice(HonestActorsFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	cannonPrestatesURLs, err := parseURLs(ctx.StringSlice(CannonPreStatesURLFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v: %w", CannonPreStatesURLFlag.Name, err)
+	}
+	asteriscPrestatesURLs, err := parseURLs(ctx.StringSlice(AsteriscPreStatesURLFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v: %w", AsteriscPreStatesURLFlag.Name, err)
+	}
+
+	var chains []config.ChainConfig
+	if ctx.IsSet(ChainsConfigFlag.Name) {
+		chains, err = config.LoadChainConfigs(ctx.Path(ChainsConfigFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	txMgrConfig := txmgr.ReadCLIConfig(ctx)
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
+	apiConfig := api.ReadCLIConfig(ctx)
 
 	maxConcurrency := ctx.Uint(MaxConcurrencyFlag.Name)
 	if maxConcurrency == 0 {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
+	largePreimageWorkers := ctx.Uint(LargePreimageWorkersFlag.Name)
+	if largePreimageWorkers == 0 {
+		return nil, fmt.Errorf("%v must not be 0", LargePreimageWorkersFlag.Name)
+	}
+	largePreimageBatchSize := ctx.Uint(LargePreimageBatchSizeFlag.Name)
+	if largePreimageBatchSize == 0 {
+		return nil, fmt.Errorf("%v must not be 0", LargePreimageBatchSizeFlag.Name)
+	}
+	largePreimageGasCap := ctx.Uint64(LargePreimageGasCapFlag.Name)
+	if largePreimageGasCap == 0 {
+		return nil, fmt.Errorf("%v must not be 0", LargePreimageGasCapFlag.Name)
+	}
 	return &config.Config{
 		// Required Flags
-		L1EthRpc:               ctx.String(L1EthRpcFlag.Name),
-		TraceTypes:             traceTypes,
-		GameFactoryAddress:     gameFactoryAddress,
-		GameAllowlist:          allowedGames,
-		GameWindow:             ctx.Duration(GameWindowFlag.Name),
-		MaxConcurrency:         maxConcurrency,
-		MaxPendingTx:           ctx.Uint64(MaxPendingTransactionsFlag.Name),
-		PollInterval:           ctx.Duration(HTTPPollInterval.Name),
-		RollupRpc:              ctx.String(RollupRpcFlag.Name),
-		CannonNetwork:          ctx.String(CannonNetworkFlag.Name),
-		CannonRollupConfigPath: ctx.String(CannonRollupConfigFlag.Name),
-		CannonL2GenesisPath:    ctx.String(CannonL2GenesisFlag.Name),
-		CannonBin:              ctx.String(CannonBinFlag.Name),
-		CannonServer:           ctx.String(CannonServerFlag.Name),
-		CannonAbsolutePreState: ctx.String(CannonPreStateFlag.Name),
-		Datadir:                ctx.String(DatadirFlag.Name),
-		CannonL2:               ctx.String(CannonL2Flag.Name),
-		CannonSnapshotFreq:     ctx.Uint(CannonSnapshotFreqFlag.Name),
-		CannonInfoFreq:         ctx.Uint(CannonInfoFreqFlag.Name),
-		TxMgrConfig:            txMgrConfig,
-		MetricsConfig:          metricsConfig,
-		PprofConfig:            pprofConfig,
+		L1EthRpc:                       ctx.String(L1EthRpcFlag.Name),
+		L1EthRpcFallbacks:              ctx.StringSlice(L1EthRpcFallbacksFlag.Name),
+		TraceTypes:                     traceTypes,
+		GameFactoryAddress:             gameFactoryAddress,
+		GameAllowlist:                  allowedGames,
+		GameTypeAllowlist:              allowedGameTypes,
+		MinGameCreationTime:            ctx.Uint64(MinGameCreationTimeFlag.Name),
+		HonestActors:                   honestActors,
+		GameWindow:                     ctx.Duration(GameWindowFlag.Name),
+		MaxConcurrency:                 maxConcurrency,
+		LargePreimageWorkers:           largePreimageWorkers,
+		LargePreimageDryRun:            ctx.Bool(LargePreimageDryRunFlag.Name),
+		LargePreimageBatchSize:         largePreimageBatchSize,
+		LargePreimageSnapshotInterval:  ctx.Uint64(LargePreimageSnapshotIntervalFlag.Name),
+		LargePreimageGasPadding:        ctx.Uint64(LargePreimageGasPaddingFlag.Name),
+		LargePreimageGasCap:            largePreimageGasCap,
+		LargePreimageUrgentBlocks:      ctx.Uint64(LargePreimageUrgentBlocksFlag.Name),
+		LargePreimageUrgentTipCapGwei:  ctx.Float64(LargePreimageUrgentTipCapFlag.Name),
+		AdditionalPreimageOracles:      additionalOracles,
+		LargePreimageClaimantAllowlist: claimantAllowlist,
+		LargePreimageClaimantBlocklist: claimantBlocklist,
+		MaxPendingTx:                   ctx.Uint64(MaxPendingTransactionsFlag.Name),
+		PollInterval:                   ctx.Duration(HTTPPollInterval.Name),
+		SubscribeGameEvents:            ctx.Bool(SubscribeGameEventsFlag.Name),
+		RollupRpc:                      ctx.String(RollupRpcFlag.Name),
+		CannonNetwork:                  ctx.String(CannonNetworkFlag.Name),
+		CannonRollupConfigPath:         ctx.String(CannonRollupConfigFlag.Name),
+		CannonL2GenesisPath:            ctx.String(CannonL2GenesisFlag.Name),
+		CannonBin:                      ctx.String(CannonBinFlag.Name),
+		CannonServer:                   ctx.String(CannonServerFlag.Name),
+		CannonAbsolutePreState:         ctx.String(CannonPreStateFlag.Name),
+		CannonPrestatesURLs:            cannonPrestatesURLs,
+		Datadir:                        ctx.String(DatadirFlag.Name),
+		CannonL2:                       ctx.String(CannonL2Flag.Name),
+		CannonSnapshotFreq:             ctx.Uint(CannonSnapshotFreqFlag.Name),
+		CannonInfoFreq:                 ctx.Uint(CannonInfoFreqFlag.Name),
+		CannonMaxConcurrency:           ctx.Uint(CannonMaxConcurrencyFlag.Name),
+		AsteriscNetwork:                ctx.String(AsteriscNetworkFlag.Name),
+		AsteriscRollupConfigPath:       ctx.String(AsteriscRollupConfigFlag.Name),
+		AsteriscL2GenesisPath:          ctx.String(AsteriscL2GenesisFlag.Name),
+		AsteriscBin:                    ctx.String(AsteriscBinFlag.Name),
+		AsteriscServer:                 ctx.String(AsteriscServerFlag.Name),
+		AsteriscAbsolutePreState:       ctx.String(AsteriscPreStateFlag.Name),
+		AsteriscPrestatesURLs:          asteriscPrestatesURLs,
+		AsteriscL2:                     ctx.String(AsteriscL2Flag.Name),
+		AsteriscSnapshotFreq:           ctx.Uint(AsteriscSnapshotFreqFlag.Name),
+		AsteriscInfoFreq:               ctx.Uint(AsteriscInfoFreqFlag.Name),
+		ChainName:                      ctx.String(ChainNameFlag.Name),
+		Chains:                         chains,
+		MaxGameSpendEth:                ctx.Float64(MaxGameSpendFlag.Name),
+		MaxTotalSpendEth:               ctx.Float64(MaxTotalSpendFlag.Name),
+		MaxMoveFeeCapGwei:              ctx.Float64(MaxMoveFeeCapFlag.Name),
+		MoveUrgencyThreshold:           ctx.Duration(MoveUrgencyThresholdFlag.Name),
+		MaxPlayDepth:                   ctx.Uint64(MaxPlayDepthFlag.Name),
+		ResolutionBatchSize:            ctx.Uint(ResolutionBatchSizeFlag.Name),
+		ArchiveDriver:                  ctx.String(ArchiveDriverFlag.Name),
+		ArchiveDSN:                     ctx.String(ArchiveDSNFlag.Name),
+		TxMgrConfig:                    txMgrConfig,
+		MetricsConfig:                  metricsConfig,
+		PprofConfig:                    pprofConfig,
+		APIConfig:                      apiConfig,
 	}, nil
 }
+
+func parseAddresses(values []string) ([]common.Address, error) {
+	var addrs []common.Address
+	for _, value := range values {
+		addr, err := opservice.ParseAddress(value)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+func parseURLs(values []string) ([]*url.URL, error) {
+	var urls []*url.URL
+	for _, value := range values {
+		u, err := url.Parse(value)
+		if err != nil {
+			return nil, err
+		}
+		urls = append(urls, u)
+	}
+	return urls, nil
+}
@@ -0,0 +1,225 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+
+	// Registers the "pgx" driver for Postgres.
+	_ "github.com/jackc/pgx/v5/stdlib"
+	// Registers the "sqlite" driver for SQLite.
+	_ "modernc.org/sqlite"
+)
+
+// schema is written to work with both SQLite and Postgres.
+// Amounts are stored as decimal strings to avoid overflowing integer columns.
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS games (
+		address VARCHAR(42) PRIMARY KEY,
+		game_type INTEGER NOT NULL,
+		created_at BIGINT NOT NULL,
+		status INTEGER NOT NULL,
+		claim_count INTEGER NOT NULL,
+		gas_used BIGINT NOT NULL,
+		gas_cost VARCHAR(78) NOT NULL,
+		bonds_posted VARCHAR(78) NOT NULL,
+		bonds_lost VARCHAR(78) NOT NULL,
+		credit VARCHAR(78) NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS claims (
+		game VARCHAR(42) NOT NULL,
+		claim_index INTEGER NOT NULL,
+		parent_index INTEGER NOT NULL,
+		position VARCHAR(78) NOT NULL,
+		value VARCHAR(66) NOT NULL,
+		bond VARCHAR(78) NOT NULL,
+		claimant VARCHAR(42) NOT NULL,
+		countered_by VARCHAR(42) NOT NULL,
+		clock BIGINT NOT NULL,
+		PRIMARY KEY (game, claim_index)
+	)`,
+	`CREATE TABLE IF NOT EXISTS gas_spent (
+		game VARCHAR(42) PRIMARY KEY,
+		gas_used BIGINT NOT NULL,
+		gas_cost VARCHAR(78) NOT NULL
+	)`,
+}
+
+// GameRecord is the archived summary of a resolved game.
+type GameRecord struct {
+	Game   types.GameMetadata
+	Status types.GameStatus
+	Claims []faultTypes.Claim
+	// GasUsed is the total gas used by transactions the challenger sent for the game.
+	GasUsed uint64
+	// GasCost is the total cost (in wei) of transactions the challenger sent for the game.
+	GasCost *big.Int
+	// BondsPosted is the total bond (in wei) posted by the challenger for its claims.
+	BondsPosted *big.Int
+	// BondsLost is the total bond (in wei) posted by the challenger for claims that were countered.
+	BondsLost *big.Int
+	// Credit is the total amount (in wei) paid out to the challenger by the game, including returned bonds.
+	Credit *big.Int
+}
+
+// BondsWon is the total bond (in wei) paid to the challenger from claims posted by other participants.
+func (r GameRecord) BondsWon() *big.Int {
+	returned := new(big.Int).Sub(r.BondsPosted, r.BondsLost)
+	won := new(big.Int).Sub(r.Credit, returned)
+	if won.Sign() < 0 {
+		return new(big.Int)
+	}
+	return won
+}
+
+// Archive persists records of resolved games to a SQL database for later analysis.
+type Archive struct {
+	db *sql.DB
+}
+
+// NewArchive opens the database using the specified database/sql driver and creates the schema if required.
+// The driver must be registered by the build. The "pgx" driver for Postgres and "sqlite" driver for SQLite are included.
+func NewArchive(ctx context.Context, driver string, dsn string) (*Archive, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive database: %w", err)
+	}
+	for _, stmt := range schema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return nil, errors.Join(fmt.Errorf("failed to create archive schema: %w", err), db.Close())
+		}
+	}
+	return &Archive{db: db}, nil
+}
+
+// ArchiveGame stores the record for a game, replacing any existing record for the same game.
+func (a *Archive) ArchiveGame(ctx context.Context, record GameRecord) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin archive transaction: %w", err)
+	}
+	if err := insertGame(ctx, tx, record); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit archive transaction: %w", err)
+	}
+	return nil
+}
+
+func insertGame(ctx context.Context, tx *sql.Tx, record GameRecord) error {
+	addr := record.Game.Proxy.Hex()
+	_, err := tx.ExecContext(ctx,
+		`INSERT INTO games (address, game_type, created_at, status, claim_count, gas_used, gas_cost, bonds_posted, bonds_lost, credit)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (address) DO UPDATE SET
+			status = excluded.status,
+			claim_count = excluded.claim_count,
+			gas_used = excluded.gas_used,
+			gas_cost = excluded.gas_cost,
+			bonds_posted = excluded.bonds_posted,
+			bonds_lost = excluded.bonds_lost,
+			credit = excluded.credit`,
+		addr, record.Game.GameType, record.Game.Timestamp, uint8(record.Status), len(record.Claims), record.GasUsed,
+		bigString(record.GasCost), bigString(record.BondsPosted), bigString(record.BondsLost), bigString(record.Credit))
+	if err != nil {
+		return fmt.Errorf("failed to archive game %v: %w", addr, err)
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM claims WHERE game = $1`, addr); err != nil {
+		return fmt.Errorf("failed to remove previous claims for game %v: %w", addr, err)
+	}
+	for _, claim := range record.Claims {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO claims (game, claim_index, parent_index, position, value, bond, claimant, countered_by, clock)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			addr, claim.ContractIndex, claim.ParentContractIndex, claim.Position.ToGIndex().String(), claim.Value.Hex(),
+			bigString(claim.Bond), claim.Claimant.Hex(), claim.CounteredBy.Hex(), claim.Clock.Timestamp.Unix())
+		if err != nil {
+			return fmt.Errorf("failed to archive claim %v of game %v: %w", claim.ContractIndex, addr, err)
+		}
+	}
+	return nil
+}
+
+// IsArchived returns true if a record for the game has already been archived.
+func (a *Archive) IsArchived(ctx context.Context, game common.Address) (bool, error) {
+	var count int
+	err := a.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM games WHERE address = $1`, game.Hex()).Scan(&count)
+	if err != nil {
+		return false, fmt.Errorf("failed to check if game %v is archived: %w", game, err)
+	}
+	return count > 0, nil
+}
+
+// RecordGasSpent stores the total gas used and cost (in wei) of transactions sent for a game so far.
+// The totals are kept until the game is archived, so they survive the challenger restarting.
+func (a *Archive) RecordGasSpent(ctx context.Context, game common.Address, gasUsed uint64, gasCost *big.Int) error {
+	_, err := a.db.ExecContext(ctx,
+		`INSERT INTO gas_spent (game, gas_used, gas_cost) VALUES ($1, $2, $3)
+		ON CONFLICT (game) DO UPDATE SET gas_used = excluded.gas_used, gas_cost = excluded.gas_cost`,
+		game.Hex(), gasUsed, bigString(gasCost))
+	if err != nil {
+		return fmt.Errorf("failed to record gas spent for game %v: %w", game, err)
+	}
+	return nil
+}
+
+// GasSpent returns the total gas used and cost (in wei) previously recorded for a game.
+// Returns zero if nothing has been recorded.
+func (a *Archive) GasSpent(ctx context.Context, game common.Address) (uint64, *big.Int, error) {
+	var gasUsed uint64
+	var gasCost string
+	err := a.db.QueryRowContext(ctx, `SELECT gas_used, gas_cost FROM gas_spent WHERE game = $1`, game.Hex()).Scan(&gasUsed, &gasCost)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, new(big.Int), nil
+	} else if err != nil {
+		return 0, nil, fmt.Errorf("failed to load gas spent for game %v: %w", game, err)
+	}
+	cost, ok := new(big.Int).SetString(gasCost, 10)
+	if !ok {
+		return 0, nil, fmt.Errorf("invalid gas cost recorded for game %v: %q", game, gasCost)
+	}
+	return gasUsed, cost, nil
+}
+
+func (a *Archive) Close() error {
+	return a.db.Close()
+}
+
+// NewGameRecord creates the record for a resolved game, calculating the bonds posted and lost by the challenger.
+func NewGameRecord(game types.GameMetadata, status types.GameStatus, claims []faultTypes.Claim, challenger common.Address, credit *big.Int, gasUsed uint64, gasCost *big.Int) GameRecord {
+	posted := new(big.Int)
+	lost := new(big.Int)
+	for _, claim := range claims {
+		if claim.Claimant != challenger || claim.Bond == nil {
+			continue
+		}
+		posted.Add(posted, claim.Bond)
+		if claim.CounteredBy != (common.Address{}) {
+			lost.Add(lost, claim.Bond)
+		}
+	}
+	return GameRecord{
+		Game:        game,
+		Status:      status,
+		Claims:      claims,
+		GasUsed:     gasUsed,
+		GasCost:     gasCost,
+		BondsPosted: posted,
+		BondsLost:   lost,
+		Credit:      credit,
+	}
+}
+
+func bigString(v *big.Int) string {
+	if v == nil {
+		return "0"
+	}
+	return v.String()
+}
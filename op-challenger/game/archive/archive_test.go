@@ -0,0 +1,179 @@
+package archive
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGameRecord(t *testing.T) {
+	challenger := common.Address{0xaa}
+	other := common.Address{0xbb}
+	claims := []faultTypes.Claim{
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(10)}, Claimant: other, CounteredBy: challenger},
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(20)}, Claimant: challenger},
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(30)}, Claimant: challenger, CounteredBy: other},
+	}
+	game := types.GameMetadata{Proxy: common.Address{0xcc}}
+	record := NewGameRecord(game, types.GameStatusChallengerWon, claims, challenger, big.NewInt(30), 100, big.NewInt(2000))
+	require.Equal(t, game, record.Game)
+	require.Equal(t, types.GameStatusChallengerWon, record.Status)
+	require.Equal(t, big.NewInt(50), record.BondsPosted)
+	require.Equal(t, big.NewInt(30), record.BondsLost)
+	require.Equal(t, big.NewInt(10), record.BondsWon())
+	require.Equal(t, uint64(100), record.GasUsed)
+	require.Equal(t, big.NewInt(2000), record.GasCost)
+
+	record.Credit = big.NewInt(0)
+	require.Equal(t, big.NewInt(0), record.BondsWon(), "should not report negative winnings")
+}
+
+func TestArchiveGame(t *testing.T) {
+	ctx := context.Background()
+	archive := newTestArchive(t)
+
+	claims := []faultTypes.Claim{
+		{ClaimData: faultTypes.ClaimData{Value: common.Hash{0x01}, Bond: big.NewInt(10), Position: faultTypes.NewPositionFromGIndex(big.NewInt(1))}, ContractIndex: 0},
+		{ClaimData: faultTypes.ClaimData{Value: common.Hash{0x02}, Bond: big.NewInt(20), Position: faultTypes.NewPositionFromGIndex(big.NewInt(2))}, ContractIndex: 1, ParentContractIndex: 0},
+	}
+	game := types.GameMetadata{GameType: 1, Timestamp: 1234, Proxy: common.Address{0xcc}}
+	record := NewGameRecord(game, types.GameStatusDefenderWon, claims, common.Address{0xaa}, big.NewInt(0), 100, big.NewInt(2000))
+	require.NoError(t, archive.ArchiveGame(ctx, record))
+
+	var gameType, createdAt, status, claimCount, gasUsed int64
+	var gasCost, bondsPosted, bondsLost, credit string
+	err := archive.db.QueryRowContext(ctx,
+		`SELECT game_type, created_at, status, claim_count, gas_used, gas_cost, bonds_posted, bonds_lost, credit FROM games WHERE address = $1`,
+		game.Proxy.Hex()).Scan(&gameType, &createdAt, &status, &claimCount, &gasUsed, &gasCost, &bondsPosted, &bondsLost, &credit)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 1234, int64(types.GameStatusDefenderWon), 2, 100}, []int64{gameType, createdAt, status, claimCount, gasUsed})
+	require.Equal(t, []string{"2000", "0", "0", "0"}, []string{gasCost, bondsPosted, bondsLost, credit})
+	require.Equal(t, []string{common.Hash{0x01}.Hex(), common.Hash{0x02}.Hex()}, archivedClaimValues(t, archive, game.Proxy))
+
+	var position, bond string
+	var parent int64
+	err = archive.db.QueryRowContext(ctx, `SELECT parent_index, position, bond FROM claims WHERE game = $1 AND claim_index = $2`,
+		game.Proxy.Hex(), 1).Scan(&parent, &position, &bond)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), parent)
+	require.Equal(t, "2", position)
+	require.Equal(t, "20", bond)
+
+	// Archiving the game again replaces the previous record
+	record.Claims = claims[:1]
+	record.Status = types.GameStatusChallengerWon
+	require.NoError(t, archive.ArchiveGame(ctx, record))
+	err = archive.db.QueryRowContext(ctx, `SELECT status, claim_count FROM games WHERE address = $1`, game.Proxy.Hex()).Scan(&status, &claimCount)
+	require.NoError(t, err)
+	require.Equal(t, int64(types.GameStatusChallengerWon), status)
+	require.Equal(t, int64(1), claimCount)
+	require.Equal(t, []string{common.Hash{0x01}.Hex()}, archivedClaimValues(t, archive, game.Proxy))
+}
+
+func TestArchiveGameRollsBackOnFailure(t *testing.T) {
+	ctx := context.Background()
+	archive := newTestArchive(t)
+	game := types.GameMetadata{Proxy: common.Address{0xcc}}
+	// Duplicate claim indices violate the primary key of the claims table
+	claims := []faultTypes.Claim{
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(10), Position: faultTypes.NewPositionFromGIndex(big.NewInt(1))}, ContractIndex: 0},
+		{ClaimData: faultTypes.ClaimData{Bond: big.NewInt(10), Position: faultTypes.NewPositionFromGIndex(big.NewInt(1))}, ContractIndex: 0},
+	}
+	record := NewGameRecord(game, types.GameStatusDefenderWon, claims, common.Address{0xaa}, big.NewInt(0), 0, big.NewInt(0))
+	require.Error(t, archive.ArchiveGame(ctx, record))
+
+	archived, err := archive.IsArchived(ctx, game.Proxy)
+	require.NoError(t, err)
+	require.False(t, archived, "should not store game when claims fail")
+	require.Empty(t, archivedClaimValues(t, archive, game.Proxy))
+}
+
+func TestGasSpent(t *testing.T) {
+	ctx := context.Background()
+	archive := newTestArchive(t)
+	game := common.Address{0xcc}
+
+	// Nothing recorded
+	gasUsed, gasCost, err := archive.GasSpent(ctx, game)
+	require.NoError(t, err)
+	require.Zero(t, gasUsed)
+	require.Equal(t, big.NewInt(0), gasCost)
+
+	require.NoError(t, archive.RecordGasSpent(ctx, game, 100, big.NewInt(2000)))
+	gasUsed, gasCost, err = archive.GasSpent(ctx, game)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), gasUsed)
+	require.Equal(t, big.NewInt(2000), gasCost)
+
+	// Amounts larger than a 64-bit integer are stored without overflowing
+	largeCost, ok := new(big.Int).SetString("1000000000000000000000000", 10)
+	require.True(t, ok)
+	require.NoError(t, archive.RecordGasSpent(ctx, game, 150, largeCost))
+	gasUsed, gasCost, err = archive.GasSpent(ctx, game)
+	require.NoError(t, err)
+	require.Equal(t, uint64(150), gasUsed)
+	require.Equal(t, largeCost, gasCost)
+}
+
+func TestIsArchived(t *testing.T) {
+	ctx := context.Background()
+	archive := newTestArchive(t)
+	game := types.GameMetadata{Proxy: common.Address{0xcc}}
+
+	archived, err := archive.IsArchived(ctx, game.Proxy)
+	require.NoError(t, err)
+	require.False(t, archived)
+
+	record := NewGameRecord(game, types.GameStatusDefenderWon, nil, common.Address{0xaa}, big.NewInt(0), 0, big.NewInt(0))
+	require.NoError(t, archive.ArchiveGame(ctx, record))
+	archived, err = archive.IsArchived(ctx, game.Proxy)
+	require.NoError(t, err)
+	require.True(t, archived)
+}
+
+func TestReopenArchive(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "games.db")
+	archive, err := NewArchive(ctx, "sqlite", path)
+	require.NoError(t, err)
+	game := types.GameMetadata{Proxy: common.Address{0xcc}}
+	record := NewGameRecord(game, types.GameStatusDefenderWon, nil, common.Address{0xaa}, big.NewInt(0), 0, big.NewInt(0))
+	require.NoError(t, archive.ArchiveGame(ctx, record))
+	require.NoError(t, archive.Close())
+
+	reopened, err := NewArchive(ctx, "sqlite", path)
+	require.NoError(t, err)
+	defer reopened.Close()
+	archived, err := reopened.IsArchived(ctx, game.Proxy)
+	require.NoError(t, err)
+	require.True(t, archived)
+}
+
+func newTestArchive(t *testing.T) *Archive {
+	archive, err := NewArchive(context.Background(), "sqlite", filepath.Join(t.TempDir(), "games.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, archive.Close())
+	})
+	return archive
+}
+
+func archivedClaimValues(t *testing.T, archive *Archive, game common.Address) []string {
+	rows, err := archive.db.QueryContext(context.Background(), `SELECT value FROM claims WHERE game = $1 ORDER BY claim_index`, game.Hex())
+	require.NoError(t, err)
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var value string
+		require.NoError(t, rows.Scan(&value))
+		values = append(values, value)
+	}
+	require.NoError(t, rows.Err())
+	return values
+}
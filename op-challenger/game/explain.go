@@ -0,0 +1,61 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type playerSource interface {
+	Player(ctx context.Context, addr common.Address) (scheduler.GamePlayer, error)
+}
+
+// playerSources looks up players from each source in turn, returning the first player found.
+type playerSources []playerSource
+
+func (p playerSources) Player(ctx context.Context, addr common.Address) (scheduler.GamePlayer, error) {
+	for _, source := range p {
+		player, err := source.Player(ctx, addr)
+		if errors.Is(err, scheduler.ErrUnknownGame) {
+			continue
+		}
+		return player, err
+	}
+	return nil, fmt.Errorf("%w: %v", scheduler.ErrUnknownGame, addr)
+}
+
+type claimExplainer interface {
+	ExplainClaim(ctx context.Context, claimIdx uint64) (solver.ClaimExplanation, error)
+}
+
+// explainClaimHandler creates an API handler which explains the action the challenger would take in response to
+// a claim. The game and claim index are specified by the game and claim query parameters.
+func explainClaimHandler(players playerSource) func(r *http.Request) (any, error) {
+	return func(r *http.Request) (any, error) {
+		query := r.URL.Query()
+		addr, err := opservice.ParseAddress(query.Get("game"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid game address: %w", err)
+		}
+		claimIdx, err := strconv.ParseUint(query.Get("claim"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid claim index: %w", err)
+		}
+		player, err := players.Player(r.Context(), addr)
+		if err != nil {
+			return nil, err
+		}
+		explainer, ok := player.(claimExplainer)
+		if !ok {
+			return nil, fmt.Errorf("game %v does not support claim explanations", addr)
+		}
+		return explainer.ExplainClaim(r.Context(), claimIdx)
+	}
+}
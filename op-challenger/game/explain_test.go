@@ -0,0 +1,91 @@
+package game
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainClaimHandler(t *testing.T) {
+	gameAddr := common.Address{0xaa}
+	unsupportedAddr := common.Address{0xbb}
+	players := &stubPlayerSource{
+		players: map[common.Address]scheduler.GamePlayer{
+			gameAddr:        &stubExplainingPlayer{},
+			unsupportedAddr: &test.StubGamePlayer{},
+		},
+	}
+	handler := explainClaimHandler(players)
+
+	t.Run("Explain", func(t *testing.T) {
+		result, err := handler(httptest.NewRequest("GET", "/games/explain?game="+gameAddr.Hex()+"&claim=3", nil))
+		require.NoError(t, err)
+		require.Equal(t, solver.ClaimExplanation{ClaimIdx: 3, Action: solver.ExplainedActionDefend}, result)
+	})
+
+	t.Run("InvalidGame", func(t *testing.T) {
+		_, err := handler(httptest.NewRequest("GET", "/games/explain?game=foo&claim=3", nil))
+		require.ErrorContains(t, err, "invalid game address")
+	})
+
+	t.Run("InvalidClaim", func(t *testing.T) {
+		_, err := handler(httptest.NewRequest("GET", "/games/explain?game="+gameAddr.Hex()+"&claim=-1", nil))
+		require.ErrorContains(t, err, "invalid claim index")
+	})
+
+	t.Run("UnknownGame", func(t *testing.T) {
+		_, err := handler(httptest.NewRequest("GET", "/games/explain?game="+common.Address{0xcc}.Hex()+"&claim=3", nil))
+		require.ErrorIs(t, err, scheduler.ErrUnknownGame)
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := handler(httptest.NewRequest("GET", "/games/explain?game="+unsupportedAddr.Hex()+"&claim=3", nil))
+		require.ErrorContains(t, err, "does not support claim explanations")
+	})
+}
+
+type stubPlayerSource struct {
+	players map[common.Address]scheduler.GamePlayer
+}
+
+func (s *stubPlayerSource) Player(_ context.Context, addr common.Address) (scheduler.GamePlayer, error) {
+	player, ok := s.players[addr]
+	if !ok {
+		return nil, scheduler.ErrUnknownGame
+	}
+	return player, nil
+}
+
+type stubExplainingPlayer struct {
+	test.StubGamePlayer
+}
+
+func (s *stubExplainingPlayer) ExplainClaim(_ context.Context, claimIdx uint64) (solver.ClaimExplanation, error) {
+	return solver.ClaimExplanation{ClaimIdx: claimIdx, Action: solver.ExplainedActionDefend}, nil
+}
+
+func TestPlayerSources(t *testing.T) {
+	player1 := &test.StubGamePlayer{Addr: common.Address{0xaa}}
+	player2 := &test.StubGamePlayer{Addr: common.Address{0xbb}}
+	sources := playerSources{
+		&stubPlayerSource{players: map[common.Address]scheduler.GamePlayer{player1.Addr: player1}},
+		&stubPlayerSource{players: map[common.Address]scheduler.GamePlayer{player2.Addr: player2}},
+	}
+
+	actual, err := sources.Player(context.Background(), player1.Addr)
+	require.NoError(t, err)
+	require.Same(t, player1, actual)
+
+	actual, err = sources.Player(context.Background(), player2.Addr)
+	require.NoError(t, err)
+	require.Same(t, player2, actual)
+
+	_, err = sources.Player(context.Background(), common.Address{0xcc})
+	require.ErrorIs(t, err, scheduler.ErrUnknownGame)
+}
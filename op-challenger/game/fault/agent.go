@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -14,6 +15,8 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+var ErrExplanationUnavailable = errors.New("claim explanations not yet available")
+
 // Responder takes a response action & executes.
 // For full op-challenger this means executing the transaction on chain.
 type Responder interface {
@@ -29,22 +32,43 @@ type ClaimLoader interface {
 }
 
 type Agent struct {
-	metrics   metrics.Metricer
-	solver    *solver.GameSolver
-	loader    ClaimLoader
-	responder Responder
-	maxDepth  types.Depth
-	log       log.Logger
+	metrics         metrics.Metricer
+	solver          *solver.GameSolver
+	loader          ClaimLoader
+	responder       Responder
+	maxDepth        types.Depth
+	maxGameDuration time.Duration
+	log             log.Logger
+	self            common.Address
+
+	// reportedInvalidClaims records the indices of our own claims already reported as invalid
+	reportedInvalidClaims map[int]bool
+
+	// explainedClaims are the claims the current explanations were calculated from.
+	// Only accessed while acting so does not require locking.
+	explainedClaims []types.Claim
+	// explanationsLock guards explanations, which are read by the API concurrently with the game being progressed.
+	explanationsLock sync.RWMutex
+	explanations     []claimExplanation
+}
+
+type claimExplanation struct {
+	explanation solver.ClaimExplanation
+	err         error
 }
 
-func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth types.Depth, trace types.TraceAccessor, responder Responder, log log.Logger) *Agent {
+func NewAgent(m metrics.Metricer, loader ClaimLoader, maxDepth types.Depth, maxPlayDepth types.Depth, maxGameDuration time.Duration, trace types.TraceAccessor, responder Responder, log log.Logger, honestActors []common.Address, self common.Address) *Agent {
 	return &Agent{
-		metrics:   m,
-		solver:    solver.NewGameSolver(maxDepth, trace),
-		loader:    loader,
-		responder: responder,
-		maxDepth:  maxDepth,
-		log:       log,
+		metrics:         m,
+		solver:          solver.NewGameSolver(maxDepth, maxPlayDepth, trace, honestActors),
+		loader:          loader,
+		responder:       responder,
+		maxDepth:        maxDepth,
+		maxGameDuration: maxGameDuration,
+		log:             log,
+		self:            self,
+
+		reportedInvalidClaims: make(map[int]bool),
 	}
 }
 
@@ -63,6 +87,8 @@ func (a *Agent) Act(ctx context.Context) error {
 	if err != nil {
 		log.Error("Failed to calculate all required moves", "err", err)
 	}
+	a.checkUnplayedClaims(ctx, game)
+	a.checkOwnClaims(ctx, game)
 
 	// Perform the actions
 	for _, action := range actions {
@@ -81,15 +107,116 @@ func (a *Agent) Act(ctx context.Context) error {
 		case types.ActionTypeStep:
 			a.metrics.RecordGameStep()
 		}
-		log.Info("Performing action")
+		action.Deadline = a.actionDeadline(game, action)
+		log.Info("Performing action", "deadline", action.Deadline)
 		err := a.responder.PerformAction(ctx, action)
 		if err != nil {
 			log.Error("Action failed", "err", err)
 		}
 	}
+	// Explanations are only recalculated once the actions have been performed so they never delay responding.
+	a.updateExplanations(ctx, game)
 	return nil
 }
 
+// checkOwnClaims alerts operators to claims posted from our own address that the local trace disagrees with.
+// Each invalid claim is only reported once.
+func (a *Agent) checkOwnClaims(ctx context.Context, game types.Game) {
+	claims, err := a.solver.InvalidClaims(ctx, game, a.self)
+	if err != nil {
+		a.log.Error("Failed to check own claims", "err", err)
+		return
+	}
+	for _, claim := range claims {
+		if a.reportedInvalidClaims[claim.ContractIndex] {
+			continue
+		}
+		a.reportedInvalidClaims[claim.ContractIndex] = true
+		a.metrics.RecordInvalidOwnClaim()
+		a.log.Error("Own claim disagrees with local trace, another challenger may be using this address with a different configuration",
+			"claimIdx", claim.ContractIndex, "depth", claim.Depth(), "value", claim.Value)
+	}
+}
+
+// checkUnplayedClaims alerts operators to claims that the agent will not counter because they are beyond the
+// maximum play depth. The game continues to be monitored so it can still be resolved.
+func (a *Agent) checkUnplayedClaims(ctx context.Context, game types.Game) {
+	claims, err := a.solver.UnplayedClaims(ctx, game)
+	if err != nil {
+		a.log.Error("Failed to check for claims beyond max play depth", "err", err)
+		return
+	}
+	if len(claims) == 0 {
+		return
+	}
+	a.metrics.RecordMaxPlayDepthReached()
+	for _, claim := range claims {
+		a.log.Error("Claim requires manual response beyond max play depth",
+			"claimIdx", claim.ContractIndex, "depth", claim.Depth(), "value", claim.Value,
+			"deadline", a.actionDeadline(game, types.Action{ParentIdx: claim.ContractIndex}))
+	}
+}
+
+// actionDeadline returns the time at which the chess clock for responding to the action's parent claim expires.
+func (a *Agent) actionDeadline(game types.Game, action types.Action) time.Time {
+	claims := game.Claims()
+	parent := claims[action.ParentIdx]
+	var grandparentClock types.Clock
+	if !parent.IsRoot() {
+		grandparentClock = claims[parent.ParentContractIndex].Clock
+	}
+	return types.ChessClockDeadline(a.maxGameDuration, grandparentClock, parent)
+}
+
+// updateExplanations recalculates the explanation for every claim in the game so they can be served without
+// accessing the trace concurrently with the game being progressed.
+// Explanations only depend on the claims in the game, so are only recalculated when the claims have changed.
+func (a *Agent) updateExplanations(ctx context.Context, game types.Game) {
+	claims := game.Claims()
+	if !claimsChanged(a.explainedClaims, claims) {
+		return
+	}
+	explanations := make([]claimExplanation, len(claims))
+	for i := range claims {
+		explanation, err := a.solver.ExplainClaim(ctx, game, uint64(i))
+		explanations[i] = claimExplanation{explanation: explanation, err: err}
+	}
+	a.explainedClaims = claims
+	a.explanationsLock.Lock()
+	defer a.explanationsLock.Unlock()
+	a.explanations = explanations
+}
+
+// claimsChanged returns true if next contains different claims to prev.
+// Claims are only ever appended to a game and the only field of a claim that changes is who countered it.
+func claimsChanged(prev []types.Claim, next []types.Claim) bool {
+	if len(prev) != len(next) {
+		return true
+	}
+	for i, claim := range next {
+		if claim.CounteredBy != prev[i].CounteredBy {
+			return true
+		}
+	}
+	return false
+}
+
+// ExplainClaim explains the action that would be taken in response to the claim at claimIdx without performing it.
+// The explanation reflects the claims in the game as of the last time the agent acted and never waits for the
+// game to be progressed. Returns ErrExplanationUnavailable if the agent has not yet acted on the game.
+func (a *Agent) ExplainClaim(_ context.Context, claimIdx uint64) (solver.ClaimExplanation, error) {
+	a.explanationsLock.RLock()
+	defer a.explanationsLock.RUnlock()
+	if a.explanations == nil {
+		return solver.ClaimExplanation{}, ErrExplanationUnavailable
+	}
+	if claimIdx >= uint64(len(a.explanations)) {
+		return solver.ClaimExplanation{}, fmt.Errorf("%w: %v", solver.ErrUnknownClaim, claimIdx)
+	}
+	result := a.explanations[claimIdx]
+	return result.explanation, result.err
+}
+
 // tryResolve resolves the game if it is in a winning state
 // Returns true if the game is resolvable (regardless of whether it was actually resolved)
 func (a *Agent) tryResolve(ctx context.Context) bool {
@@ -5,12 +5,15 @@ import (
 	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -71,13 +74,153 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestActionDeadlineFromParentClock(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+
+	root := claimBuilder.CreateRootClaim(false)
+	root.Clock = types.NewClock(0, 1000)
+	claimLoader.claims = []types.Claim{root}
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	require.Len(t, responder.actions, 1)
+	require.Equal(t, time.Unix(1000, 0).Add(testMaxGameDuration/2), responder.actions[0].Deadline)
+}
+
+func TestDoNotPlayBeyondMaxPlayDepth(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	claimLoader := &stubClaimLoader{}
+	depth := types.Depth(4)
+	provider := alphabet.NewTraceProvider(big.NewInt(0), depth)
+	responder := &stubResponder{
+		callResolveErr:      errors.New("game is not resolvable"),
+		callResolveClaimErr: errors.New("claim is not resolvable"),
+	}
+	m := &stubAgentMetrics{}
+	agent := NewAgent(m, claimLoader, depth, 1, testMaxGameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, nil, testSelf)
+	claimBuilder := test.NewClaimBuilder(t, depth, provider)
+
+	t.Run("PlayAboveMaxPlayDepth", func(t *testing.T) {
+		claimLoader.claims = []types.Claim{claimBuilder.CreateRootClaim(false)}
+		require.NoError(t, agent.Act(context.Background()))
+		require.Len(t, responder.actions, 1)
+		require.Zero(t, m.maxPlayDepthReached)
+	})
+
+	t.Run("AlertBeyondMaxPlayDepth", func(t *testing.T) {
+		responder.actions = nil
+		responder.callResolveClaimCount = 0
+		root := claimBuilder.CreateRootClaim(false)
+		honest := claimBuilder.AttackClaim(root, true)
+		honest.ContractIndex = 1
+		dishonest := claimBuilder.AttackClaim(honest, false)
+		dishonest.ContractIndex = 2
+		claimLoader.claims = []types.Claim{root, honest, dishonest}
+		require.NoError(t, agent.Act(context.Background()))
+		require.Empty(t, responder.actions)
+		require.Equal(t, 1, m.maxPlayDepthReached)
+		require.Equal(t, 3, responder.callResolveClaimCount, "should continue checking claims for resolution")
+	})
+}
+
+func TestReportInvalidOwnClaims(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	claimLoader := &stubClaimLoader{}
+	depth := types.Depth(4)
+	provider := alphabet.NewTraceProvider(big.NewInt(0), depth)
+	responder := &stubResponder{
+		callResolveErr:      errors.New("game is not resolvable"),
+		callResolveClaimErr: errors.New("claim is not resolvable"),
+	}
+	m := &stubAgentMetrics{}
+	agent := NewAgent(m, claimLoader, depth, 0, testMaxGameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, nil, testSelf)
+	claimBuilder := test.NewClaimBuilder(t, depth, provider)
+
+	root := claimBuilder.CreateRootClaim(false)
+	valid := claimBuilder.AttackClaim(root, true)
+	valid.ContractIndex = 1
+	valid.Claimant = testSelf
+	invalid := claimBuilder.AttackClaim(root, false)
+	invalid.ContractIndex = 2
+	invalid.Claimant = testSelf
+	other := claimBuilder.AttackClaim(valid, false)
+	other.ContractIndex = 3
+	other.Claimant = common.Address{0xbb}
+	claimLoader.claims = []types.Claim{root, valid, invalid, other}
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, m.invalidOwnClaims)
+
+	// Only report each invalid claim once
+	require.NoError(t, agent.Act(context.Background()))
+	require.Equal(t, 1, m.invalidOwnClaims)
+}
+
+func TestExplainClaimFromLastAct(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+
+	_, err := agent.ExplainClaim(context.Background(), 0)
+	require.ErrorIs(t, err, ErrExplanationUnavailable)
+
+	root := claimBuilder.CreateRootClaim(false)
+	claimLoader.claims = []types.Claim{root}
+	require.NoError(t, agent.Act(context.Background()))
+
+	explanation, err := agent.ExplainClaim(context.Background(), 0)
+	require.NoError(t, err)
+	require.False(t, explanation.Agree)
+	require.Equal(t, solver.ExplainedActionAttack, explanation.Action)
+
+	_, err = agent.ExplainClaim(context.Background(), 1)
+	require.ErrorIs(t, err, solver.ErrUnknownClaim)
+
+	// Explanations are updated when the claims change
+	counter := claimBuilder.AttackClaim(root, true)
+	counter.ContractIndex = 1
+	claimLoader.claims = []types.Claim{root, counter}
+	require.NoError(t, agent.Act(context.Background()))
+
+	explanation, err = agent.ExplainClaim(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, solver.ExplainedActionNone, explanation.Action)
+	explanation, err = agent.ExplainClaim(context.Background(), 1)
+	require.NoError(t, err)
+	require.True(t, explanation.Agree)
+}
+
+type stubAgentMetrics struct {
+	metrics.NoopMetricsImpl
+	maxPlayDepthReached int
+	invalidOwnClaims    int
+}
+
+func (s *stubAgentMetrics) RecordMaxPlayDepthReached() {
+	s.maxPlayDepthReached++
+}
+
+func (s *stubAgentMetrics) RecordInvalidOwnClaim() {
+	s.invalidOwnClaims++
+}
+
+const testMaxGameDuration = 24 * time.Hour
+
+var testSelf = common.Address{0xaa}
+
 func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	claimLoader := &stubClaimLoader{}
 	depth := types.Depth(4)
 	provider := alphabet.NewTraceProvider(big.NewInt(0), depth)
 	responder := &stubResponder{}
-	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, trace.NewSimpleTraceAccessor(provider), responder, logger)
+	agent := NewAgent(metrics.NoopMetrics, claimLoader, depth, 0, testMaxGameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, nil, testSelf)
 	return agent, claimLoader, responder
 }
 
@@ -102,6 +245,8 @@ type stubResponder struct {
 	callResolveClaimCount int
 	callResolveClaimErr   error
 	resolveClaimCount     int
+
+	actions []types.Action
 }
 
 func (s *stubResponder) CallResolve(ctx context.Context) (gameTypes.GameStatus, error) {
@@ -125,5 +270,6 @@ func (s *stubResponder) ResolveClaim(clainIdx uint64) error {
 }
 
 func (s *stubResponder) PerformAction(ctx context.Context, response types.Action) error {
+	s.actions = append(s.actions, response)
 	return nil
 }
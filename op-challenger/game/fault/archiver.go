@@ -0,0 +1,124 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/archive"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// gasRecordTimeout is the maximum time to wait for the gas spent on a game to be stored.
+const gasRecordTimeout = 10 * time.Second
+
+// GameArchiver stores the records of resolved games.
+type GameArchiver interface {
+	ArchiveGame(ctx context.Context, record archive.GameRecord) error
+	IsArchived(ctx context.Context, game common.Address) (bool, error)
+	RecordGasSpent(ctx context.Context, game common.Address, gasUsed uint64, gasCost *big.Int) error
+	GasSpent(ctx context.Context, game common.Address) (uint64, *big.Int, error)
+}
+
+type archiveFn func(ctx context.Context, status gameTypes.GameStatus) error
+
+// archiveLoader loads the state of a game required to archive it.
+type archiveLoader interface {
+	ClaimLoader
+	GetCredit(ctx context.Context, recipient common.Address) (*big.Int, error)
+}
+
+// gasTrackingTxSender records the total gas used by transactions sent for a single game.
+// The totals are stored by the archiver after every send so they are not lost if the challenger restarts.
+type gasTrackingTxSender struct {
+	gameTypes.TxSender
+	logger   log.Logger
+	archiver GameArchiver
+	game     common.Address
+
+	lock    sync.Mutex
+	gasUsed uint64
+	gasCost *big.Int
+}
+
+// newGasTrackingTxSender creates a tracker which continues from the gas previously recorded for the game.
+func newGasTrackingTxSender(ctx context.Context, logger log.Logger, sender gameTypes.TxSender, archiver GameArchiver, game common.Address) (*gasTrackingTxSender, error) {
+	gasUsed, gasCost, err := archiver.GasSpent(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	return &gasTrackingTxSender{
+		TxSender: sender,
+		logger:   logger,
+		archiver: archiver,
+		game:     game,
+		gasUsed:  gasUsed,
+		gasCost:  gasCost,
+	}, nil
+}
+
+func (s *gasTrackingTxSender) SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	receipts, err := s.TxSender.SendAndWait(txPurpose, txs...)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		s.gasUsed += receipt.GasUsed
+		if receipt.EffectiveGasPrice != nil {
+			s.gasCost.Add(s.gasCost, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice))
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), gasRecordTimeout)
+	defer cancel()
+	if recordErr := s.archiver.RecordGasSpent(ctx, s.game, s.gasUsed, s.gasCost); recordErr != nil {
+		s.logger.Error("Failed to record gas spent on game", "err", recordErr)
+	}
+	return receipts, err
+}
+
+func (s *gasTrackingTxSender) GasSpent() (uint64, *big.Int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.gasUsed, new(big.Int).Set(s.gasCost)
+}
+
+// backfillArchive archives a game that was already resolved when the challenger started, unless it has already
+// been archived. Games that resolve while the challenger is not running are otherwise never archived.
+func backfillArchive(ctx context.Context, logger log.Logger, archiver GameArchiver, game gameTypes.GameMetadata, loader archiveLoader, txSender gameTypes.TxSender, status gameTypes.GameStatus) error {
+	archived, err := archiver.IsArchived(ctx, game.Proxy)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return nil
+	}
+	gasTracker, err := newGasTrackingTxSender(ctx, logger, txSender, archiver, game.Proxy)
+	if err != nil {
+		return err
+	}
+	logger.Info("Archiving already resolved game")
+	return newGameArchiveFn(archiver, game, loader, gasTracker)(ctx, status)
+}
+
+func newGameArchiveFn(archiver GameArchiver, game gameTypes.GameMetadata, loader archiveLoader, sender *gasTrackingTxSender) archiveFn {
+	return func(ctx context.Context, status gameTypes.GameStatus) error {
+		claims, err := loader.GetAllClaims(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load claims: %w", err)
+		}
+		credit, err := loader.GetCredit(ctx, sender.From())
+		if err != nil {
+			return fmt.Errorf("failed to load credit: %w", err)
+		}
+		gasUsed, gasCost := sender.GasSpent()
+		return archiver.ArchiveGame(ctx, archive.NewGameRecord(game, status, claims, sender.From(), credit, gasUsed, gasCost))
+	}
+}
@@ -0,0 +1,139 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/archive"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasTrackingTxSender(t *testing.T) {
+	sender := &stubReceiptSender{
+		receipts: []*ethtypes.Receipt{
+			{GasUsed: 100, EffectiveGasPrice: big.NewInt(3)},
+			{GasUsed: 50, EffectiveGasPrice: big.NewInt(2)},
+		},
+	}
+	archiver := newStubArchiver()
+	game := common.Address{0xcc}
+	archiver.gasUsed[game] = 1000
+	archiver.gasCost[game] = big.NewInt(5000)
+	tracker, err := newGasTrackingTxSender(context.Background(), testlog.Logger(t, log.LvlInfo), sender, archiver, game)
+	require.NoError(t, err)
+	receipts, err := tracker.SendAndWait("test", txmgr.TxCandidate{}, txmgr.TxCandidate{})
+	require.NoError(t, err)
+	require.Len(t, receipts, 2)
+
+	// Continues from the previously recorded totals and records the new totals
+	gasUsed, gasCost := tracker.GasSpent()
+	require.Equal(t, uint64(1150), gasUsed)
+	require.Equal(t, big.NewInt(5400), gasCost)
+	require.Equal(t, uint64(1150), archiver.gasUsed[game])
+	require.Equal(t, big.NewInt(5400), archiver.gasCost[game])
+
+	// Receipts returned alongside an error are still recorded since the gas has been spent
+	sender.err = errors.New("boom")
+	sender.receipts = []*ethtypes.Receipt{{GasUsed: 10, EffectiveGasPrice: big.NewInt(1)}, nil}
+	_, err = tracker.SendAndWait("test", txmgr.TxCandidate{}, txmgr.TxCandidate{})
+	require.ErrorIs(t, err, sender.err)
+	gasUsed, gasCost = tracker.GasSpent()
+	require.Equal(t, uint64(1160), gasUsed)
+	require.Equal(t, big.NewInt(5410), gasCost)
+	require.Equal(t, uint64(1160), archiver.gasUsed[game])
+}
+
+func TestBackfillArchive(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	game := gameTypes.GameMetadata{Proxy: common.Address{0xcc}}
+	loader := &stubArchiveLoader{}
+
+	t.Run("ArchiveUnarchivedGame", func(t *testing.T) {
+		archiver := newStubArchiver()
+		archiver.gasUsed[game.Proxy] = 100
+		archiver.gasCost[game.Proxy] = big.NewInt(200)
+		require.NoError(t, backfillArchive(context.Background(), logger, archiver, game, loader, &stubReceiptSender{}, gameTypes.GameStatusChallengerWon))
+		require.Len(t, archiver.archived, 1)
+		record := archiver.archived[0]
+		require.Equal(t, game, record.Game)
+		require.Equal(t, gameTypes.GameStatusChallengerWon, record.Status)
+		require.Equal(t, uint64(100), record.GasUsed)
+		require.Equal(t, big.NewInt(200), record.GasCost)
+	})
+
+	t.Run("SkipArchivedGame", func(t *testing.T) {
+		archiver := newStubArchiver()
+		archiver.isArchived = true
+		require.NoError(t, backfillArchive(context.Background(), logger, archiver, game, loader, &stubReceiptSender{}, gameTypes.GameStatusChallengerWon))
+		require.Empty(t, archiver.archived)
+	})
+}
+
+type stubArchiveLoader struct{}
+
+func (s *stubArchiveLoader) GetAllClaims(_ context.Context) ([]faultTypes.Claim, error) {
+	return nil, nil
+}
+
+func (s *stubArchiveLoader) GetCredit(_ context.Context, _ common.Address) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+type stubArchiver struct {
+	isArchived bool
+	archived   []archive.GameRecord
+	gasUsed    map[common.Address]uint64
+	gasCost    map[common.Address]*big.Int
+}
+
+func newStubArchiver() *stubArchiver {
+	return &stubArchiver{
+		gasUsed: make(map[common.Address]uint64),
+		gasCost: make(map[common.Address]*big.Int),
+	}
+}
+
+func (s *stubArchiver) ArchiveGame(_ context.Context, record archive.GameRecord) error {
+	s.archived = append(s.archived, record)
+	return nil
+}
+
+func (s *stubArchiver) IsArchived(_ context.Context, _ common.Address) (bool, error) {
+	return s.isArchived, nil
+}
+
+func (s *stubArchiver) RecordGasSpent(_ context.Context, game common.Address, gasUsed uint64, gasCost *big.Int) error {
+	s.gasUsed[game] = gasUsed
+	s.gasCost[game] = new(big.Int).Set(gasCost)
+	return nil
+}
+
+func (s *stubArchiver) GasSpent(_ context.Context, game common.Address) (uint64, *big.Int, error) {
+	gasCost, ok := s.gasCost[game]
+	if !ok {
+		gasCost = new(big.Int)
+	}
+	return s.gasUsed[game], new(big.Int).Set(gasCost), nil
+}
+
+type stubReceiptSender struct {
+	receipts []*ethtypes.Receipt
+	err      error
+}
+
+func (s *stubReceiptSender) From() common.Address {
+	return common.Address{0xaa}
+}
+
+func (s *stubReceiptSender) SendAndWait(_ string, _ ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	return s.receipts, s.err
+}
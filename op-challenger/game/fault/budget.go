@@ -0,0 +1,239 @@
+package fault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrBudgetExceeded = errors.New("spending budget exceeded")
+
+type BudgetMetrics interface {
+	RecordBudgetExceeded()
+	RecordTotalSpend(amount *big.Int)
+}
+
+// SpendBudget tracks the ETH spent on bonds and gas for each game and limits further spending once the budget for a
+// single game or the total budget across all games is exceeded.
+// Spending of transactions that are still being sent is reserved, so concurrent games can't exceed the budget together.
+// If a path is provided, spending is stored so the budget is not reset when the challenger restarts.
+type SpendBudget struct {
+	logger     log.Logger
+	m          BudgetMetrics
+	maxPerGame *big.Int
+	maxTotal   *big.Int
+	path       string
+
+	lock  sync.Mutex
+	games map[common.Address]*big.Int
+	total *big.Int
+
+	// reserved is the spending of transactions that are being sent, which is not stored.
+	reserved      map[common.Address]*big.Int
+	totalReserved *big.Int
+}
+
+type persistedSpend struct {
+	Games map[common.Address]*big.Int `json:"games"`
+	Total *big.Int                    `json:"total"`
+}
+
+// NewSpendBudget creates a new SpendBudget, loading any previously stored spending from path.
+// A nil or zero limit disables that limit. Spending is not stored if path is empty.
+func NewSpendBudget(logger log.Logger, m BudgetMetrics, maxPerGame *big.Int, maxTotal *big.Int, path string) (*SpendBudget, error) {
+	b := &SpendBudget{
+		logger:     logger,
+		m:          m,
+		maxPerGame: maxPerGame,
+		maxTotal:   maxTotal,
+		path:       path,
+		games:      make(map[common.Address]*big.Int),
+		total:      new(big.Int),
+
+		reserved:      make(map[common.Address]*big.Int),
+		totalReserved: new(big.Int),
+	}
+	if path == "" {
+		return b, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return b, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read spending budget: %w", err)
+	}
+	var stored persistedSpend
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse spending budget: %w", err)
+	}
+	for game, spent := range stored.Games {
+		if spent != nil {
+			b.games[game] = new(big.Int).Set(spent)
+		}
+	}
+	if stored.Total != nil {
+		b.total.Set(stored.Total)
+	}
+	m.RecordTotalSpend(b.total)
+	return b, nil
+}
+
+// RecordSpend adds amount to the spending recorded for game.
+func (b *SpendBudget) RecordSpend(game common.Address, amount *big.Int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.recordSpend(game, amount)
+}
+
+// recordSpend adds amount to the spending recorded for game. The lock must be held when calling this method.
+func (b *SpendBudget) recordSpend(game common.Address, amount *big.Int) {
+	if amount.Sign() == 0 {
+		return
+	}
+	spent, ok := b.games[game]
+	if !ok {
+		spent = new(big.Int)
+		b.games[game] = spent
+	}
+	spent.Add(spent, amount)
+	b.total.Add(b.total, amount)
+	b.m.RecordTotalSpend(b.total)
+	if err := b.store(); err != nil {
+		b.logger.Error("Failed to store spending budget", "path", b.path, "err", err)
+	}
+}
+
+// store writes the current spending to path. The lock must be held when calling this method.
+func (b *SpendBudget) store() error {
+	if b.path == "" {
+		return nil
+	}
+	return ioutil.WriteAtomicJSON(b.path, persistedSpend{Games: b.games, Total: b.total}, 0o644)
+}
+
+// CheckSpend returns an error wrapping ErrBudgetExceeded if spending amount on game would exceed either budget.
+func (b *SpendBudget) CheckSpend(game common.Address, amount *big.Int) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.checkSpend(game, amount)
+}
+
+// Reserve checks that spending amount on game would not exceed either budget, and if so reserves amount until the
+// spending is settled. Settle must be called with the reserved amount once the spending is known.
+func (b *SpendBudget) Reserve(game common.Address, amount *big.Int) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if err := b.checkSpend(game, amount); err != nil {
+		return err
+	}
+	reserved, ok := b.reserved[game]
+	if !ok {
+		reserved = new(big.Int)
+		b.reserved[game] = reserved
+	}
+	reserved.Add(reserved, amount)
+	b.totalReserved.Add(b.totalReserved, amount)
+	return nil
+}
+
+// Settle releases the amount reserved for game and records the amount actually spent.
+func (b *SpendBudget) Settle(game common.Address, reserved *big.Int, spent *big.Int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if gameReserved, ok := b.reserved[game]; ok {
+		gameReserved.Sub(gameReserved, reserved)
+		if gameReserved.Sign() <= 0 {
+			delete(b.reserved, game)
+		}
+	}
+	b.totalReserved.Sub(b.totalReserved, reserved)
+	b.recordSpend(game, spent)
+}
+
+// checkSpend returns an error wrapping ErrBudgetExceeded if spending amount on game, in addition to the spending
+// already recorded and reserved, would exceed either budget. The lock must be held when calling this method.
+func (b *SpendBudget) checkSpend(game common.Address, amount *big.Int) error {
+	spent := new(big.Int)
+	if gameSpent, ok := b.games[game]; ok {
+		spent.Add(spent, gameSpent)
+	}
+	if gameReserved, ok := b.reserved[game]; ok {
+		spent.Add(spent, gameReserved)
+	}
+	if exceeds(spent, amount, b.maxPerGame) {
+		b.m.RecordBudgetExceeded()
+		return fmt.Errorf("%w: game %v has spent or reserved %v wei of %v wei budget", ErrBudgetExceeded, game, spent, b.maxPerGame)
+	}
+	total := new(big.Int).Add(b.total, b.totalReserved)
+	if exceeds(total, amount, b.maxTotal) {
+		b.m.RecordBudgetExceeded()
+		return fmt.Errorf("%w: all games have spent or reserved %v wei of %v wei budget", ErrBudgetExceeded, total, b.maxTotal)
+	}
+	return nil
+}
+
+func exceeds(spent *big.Int, amount *big.Int, limit *big.Int) bool {
+	if limit == nil || limit.Sign() == 0 {
+		return false
+	}
+	return new(big.Int).Add(spent, amount).Cmp(limit) > 0
+}
+
+// budgetTxSender records the spending of transactions sent for a game and refuses to post new claims once the
+// spending budget has been exceeded. Transactions that don't post a bond, such as resolving claims, are always sent.
+type budgetTxSender struct {
+	gameTypes.TxSender
+	logger log.Logger
+	budget *SpendBudget
+	game   common.Address
+}
+
+func newBudgetTxSender(logger log.Logger, sender gameTypes.TxSender, budget *SpendBudget, game common.Address) *budgetTxSender {
+	return &budgetTxSender{
+		TxSender: sender,
+		logger:   logger,
+		budget:   budget,
+		game:     game,
+	}
+}
+
+func (s *budgetTxSender) SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	bonds := new(big.Int)
+	for _, tx := range txs {
+		if tx.Value != nil {
+			bonds.Add(bonds, tx.Value)
+		}
+	}
+	// Reserve the bonds before sending so concurrent games can't all pass the check and together exceed the budget.
+	if bonds.Sign() > 0 {
+		if err := s.budget.Reserve(s.game, bonds); err != nil {
+			s.logger.Error("Spending budget exceeded, not posting new claims", "purpose", txPurpose, "bonds", bonds, "err", err)
+			return nil, err
+		}
+	}
+	receipts, err := s.TxSender.SendAndWait(txPurpose, txs...)
+	spent := new(big.Int)
+	for i, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		if receipt.EffectiveGasPrice != nil {
+			spent.Add(spent, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice))
+		}
+		if receipt.Status == ethtypes.ReceiptStatusSuccessful && i < len(txs) && txs[i].Value != nil {
+			spent.Add(spent, txs[i].Value)
+		}
+	}
+	s.budget.Settle(s.game, bonds, spent)
+	return receipts, err
+}
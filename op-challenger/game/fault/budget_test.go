@@ -0,0 +1,167 @@
+package fault
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpendBudget(t *testing.T) {
+	gameA := common.Address{0xaa}
+	gameB := common.Address{0xbb}
+
+	t.Run("Unlimited", func(t *testing.T) {
+		budget := newTestSpendBudget(t, &stubBudgetMetrics{}, nil, big.NewInt(0))
+		budget.RecordSpend(gameA, big.NewInt(1_000_000))
+		require.NoError(t, budget.CheckSpend(gameA, big.NewInt(1_000_000)))
+	})
+
+	t.Run("PerGame", func(t *testing.T) {
+		m := &stubBudgetMetrics{}
+		budget := newTestSpendBudget(t, m, big.NewInt(100), nil)
+		budget.RecordSpend(gameA, big.NewInt(60))
+		require.NoError(t, budget.CheckSpend(gameA, big.NewInt(40)))
+		require.ErrorIs(t, budget.CheckSpend(gameA, big.NewInt(41)), ErrBudgetExceeded)
+		require.NoError(t, budget.CheckSpend(gameB, big.NewInt(100)), "should track games separately")
+		require.Equal(t, 1, m.exceeded)
+		require.Equal(t, big.NewInt(60), m.total)
+	})
+
+	t.Run("Total", func(t *testing.T) {
+		m := &stubBudgetMetrics{}
+		budget := newTestSpendBudget(t, m, big.NewInt(100), big.NewInt(150))
+		budget.RecordSpend(gameA, big.NewInt(60))
+		budget.RecordSpend(gameB, big.NewInt(60))
+		require.NoError(t, budget.CheckSpend(gameA, big.NewInt(30)))
+		require.ErrorIs(t, budget.CheckSpend(gameA, big.NewInt(31)), ErrBudgetExceeded)
+		require.Equal(t, big.NewInt(120), m.total)
+	})
+
+	t.Run("Reserve", func(t *testing.T) {
+		budget := newTestSpendBudget(t, &stubBudgetMetrics{}, big.NewInt(100), big.NewInt(150))
+		require.NoError(t, budget.Reserve(gameA, big.NewInt(60)))
+		require.ErrorIs(t, budget.Reserve(gameA, big.NewInt(41)), ErrBudgetExceeded, "should include reserved spend for game")
+		require.NoError(t, budget.Reserve(gameB, big.NewInt(90)))
+		require.ErrorIs(t, budget.CheckSpend(gameB, big.NewInt(1)), ErrBudgetExceeded, "should include reserved spend in total")
+
+		budget.Settle(gameA, big.NewInt(60), big.NewInt(20))
+		require.NoError(t, budget.CheckSpend(gameA, big.NewInt(40)))
+		require.ErrorIs(t, budget.CheckSpend(gameA, big.NewInt(41)), ErrBudgetExceeded)
+
+		budget.Settle(gameB, big.NewInt(90), big.NewInt(0))
+		require.NoError(t, budget.CheckSpend(gameB, big.NewInt(100)), "should release unspent reservation")
+	})
+
+	t.Run("PersistAcrossRestarts", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "budget.json")
+		logger := testlog.Logger(t, log.LvlInfo)
+		budget, err := NewSpendBudget(logger, &stubBudgetMetrics{}, big.NewInt(100), big.NewInt(150), path)
+		require.NoError(t, err)
+		budget.RecordSpend(gameA, big.NewInt(60))
+		budget.RecordSpend(gameB, big.NewInt(60))
+
+		m := &stubBudgetMetrics{}
+		reloaded, err := NewSpendBudget(logger, m, big.NewInt(100), big.NewInt(150), path)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(120), m.total)
+		require.ErrorIs(t, reloaded.CheckSpend(gameA, big.NewInt(41)), ErrBudgetExceeded)
+		require.ErrorIs(t, reloaded.CheckSpend(gameB, big.NewInt(31)), ErrBudgetExceeded)
+		require.NoError(t, reloaded.CheckSpend(gameB, big.NewInt(30)))
+	})
+}
+
+func newTestSpendBudget(t *testing.T, m BudgetMetrics, maxPerGame *big.Int, maxTotal *big.Int) *SpendBudget {
+	budget, err := NewSpendBudget(testlog.Logger(t, log.LvlInfo), m, maxPerGame, maxTotal, "")
+	require.NoError(t, err)
+	return budget
+}
+
+func TestBudgetTxSender(t *testing.T) {
+	game := common.Address{0xaa}
+	bondTx := txmgr.TxCandidate{Value: big.NewInt(50)}
+	newSender := func(maxPerGame int64) (*budgetTxSender, *stubReceiptSender, *SpendBudget) {
+		sender := &stubReceiptSender{}
+		budget := newTestSpendBudget(t, metrics.NoopMetrics, big.NewInt(maxPerGame), nil)
+		return newBudgetTxSender(testlog.Logger(t, log.LvlInfo), sender, budget, game), sender, budget
+	}
+
+	t.Run("RecordBondsAndGas", func(t *testing.T) {
+		budgetSender, sender, budget := newSender(1000)
+		sender.receipts = []*ethtypes.Receipt{
+			{Status: ethtypes.ReceiptStatusSuccessful, GasUsed: 10, EffectiveGasPrice: big.NewInt(2)},
+			{Status: ethtypes.ReceiptStatusFailed, GasUsed: 5, EffectiveGasPrice: big.NewInt(2)},
+		}
+		_, err := budgetSender.SendAndWait("test", bondTx, bondTx)
+		require.NoError(t, err)
+		// Bond is only spent for the successful transaction but gas is spent for both
+		require.ErrorIs(t, budget.CheckSpend(game, big.NewInt(1000-50-30+1)), ErrBudgetExceeded)
+		require.NoError(t, budget.CheckSpend(game, big.NewInt(1000-50-30)))
+	})
+
+	t.Run("DoNotPostClaimsWhenExceeded", func(t *testing.T) {
+		budgetSender, sender, budget := newSender(100)
+		budget.RecordSpend(game, big.NewInt(60))
+		sender.err = errors.New("should not send")
+		_, err := budgetSender.SendAndWait("test", bondTx)
+		require.ErrorIs(t, err, ErrBudgetExceeded)
+	})
+
+	t.Run("ReserveBondsWhileSending", func(t *testing.T) {
+		budgetSender, sender, budget := newSender(100)
+		sender.receipts = []*ethtypes.Receipt{{Status: ethtypes.ReceiptStatusSuccessful}}
+		concurrent := &sendDuringSend{stubReceiptSender: sender}
+		budgetSender.TxSender = concurrent
+		concurrent.during = func() {
+			// Another claim is sent while the first is still waiting for its receipt.
+			other := newBudgetTxSender(testlog.Logger(t, log.LvlInfo), sender, budget, game)
+			_, concurrent.err = other.SendAndWait("test", bondTx, bondTx)
+		}
+		_, err := budgetSender.SendAndWait("test", bondTx)
+		require.NoError(t, err)
+		require.ErrorIs(t, concurrent.err, ErrBudgetExceeded)
+		require.ErrorIs(t, budget.CheckSpend(game, big.NewInt(51)), ErrBudgetExceeded, "should record settled bond")
+		require.NoError(t, budget.CheckSpend(game, big.NewInt(50)))
+	})
+
+	t.Run("AllowTransactionsWithoutBonds", func(t *testing.T) {
+		budgetSender, sender, budget := newSender(100)
+		budget.RecordSpend(game, big.NewInt(100))
+		sender.receipts = []*ethtypes.Receipt{{Status: ethtypes.ReceiptStatusSuccessful}}
+		_, err := budgetSender.SendAndWait("resolve", txmgr.TxCandidate{})
+		require.NoError(t, err)
+	})
+}
+
+// sendDuringSend calls during while sending transactions, before the receipts are returned.
+type sendDuringSend struct {
+	*stubReceiptSender
+	during func()
+	err    error
+}
+
+func (s *sendDuringSend) SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	s.during()
+	return s.stubReceiptSender.SendAndWait(txPurpose, txs...)
+}
+
+type stubBudgetMetrics struct {
+	exceeded int
+	total    *big.Int
+}
+
+func (s *stubBudgetMetrics) RecordBudgetExceeded() {
+	s.exceeded++
+}
+
+func (s *stubBudgetMetrics) RecordTotalSpend(amount *big.Int) {
+	s.total = new(big.Int).Set(amount)
+}
@@ -6,14 +6,25 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/pnl"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+var ErrClaimReverted = errors.New("credit claim transaction reverted")
+
 type BondClaimMetrics interface {
-	RecordBondClaimed(amount uint64)
+	RecordBondClaimed(amount *big.Int)
+	RecordBondsPending(amount *big.Int)
+}
+
+// ProfitRecorder records the credit recovered from games and the gas spent claiming it.
+type ProfitRecorder interface {
+	RecordGasSpent(gameType uint32, amount *big.Int)
+	RecordBondRecovered(gameType uint32, amount *big.Int)
 }
 
 type BondContract interface {
@@ -28,52 +39,98 @@ type Claimer struct {
 	metrics         BondClaimMetrics
 	contractCreator BondContractCreator
 	txSender        types.TxSender
+	profits         ProfitRecorder
 }
 
 var _ BondClaimer = (*Claimer)(nil)
 
-func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender types.TxSender) *Claimer {
+func NewBondClaimer(l log.Logger, m BondClaimMetrics, contractCreator BondContractCreator, txSender types.TxSender, profits ProfitRecorder) *Claimer {
 	return &Claimer{
 		logger:          l,
 		metrics:         m,
 		contractCreator: contractCreator,
 		txSender:        txSender,
+		profits:         profits,
 	}
 }
 
-func (c *Claimer) ClaimBonds(ctx context.Context, games []types.GameMetadata) (err error) {
+// ClaimBonds claims the credit owed to the challenger by the games.
+// All claims are sent as a single batch of transactions which are then awaited together.
+func (c *Claimer) ClaimBonds(ctx context.Context, games []types.GameMetadata) error {
+	var errs []error
+	var claims []pendingClaim
+	pending := new(big.Int)
 	for _, game := range games {
-		err = errors.Join(err, c.claimBond(ctx, game))
+		claim, err := c.prepareClaim(ctx, game)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if claim == nil {
+			continue
+		}
+		pending.Add(pending, claim.credit)
+		claims = append(claims, *claim)
+	}
+	c.metrics.RecordBondsPending(pending)
+	if len(claims) == 0 {
+		return errors.Join(errs...)
+	}
+
+	c.logger.Info("Claiming bonds", "games", len(claims), "credit", pending)
+	candidates := make([]txmgr.TxCandidate, len(claims))
+	for i, claim := range claims {
+		candidates[i] = claim.tx
+	}
+	receipts, err := c.txSender.SendAndWait("claim credit", candidates...)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to claim credit: %w", err))
+	}
+	for i, claim := range claims {
+		if i >= len(receipts) || receipts[i] == nil {
+			continue
+		}
+		c.profits.RecordGasSpent(claim.gameType, pnl.GasCost(receipts[i]))
+		if receipts[i].Status != ethtypes.ReceiptStatusSuccessful {
+			errs = append(errs, fmt.Errorf("%w: game %v", ErrClaimReverted, claim.game))
+			continue
+		}
+		c.metrics.RecordBondClaimed(claim.credit)
+		c.profits.RecordBondRecovered(claim.gameType, claim.credit)
+		pending.Sub(pending, claim.credit)
 	}
-	return err
+	c.metrics.RecordBondsPending(pending)
+	return errors.Join(errs...)
+}
+
+type pendingClaim struct {
+	game     common.Address
+	gameType uint32
+	credit   *big.Int
+	tx       txmgr.TxCandidate
 }
 
-func (c *Claimer) claimBond(ctx context.Context, game types.GameMetadata) error {
+// prepareClaim returns the claim to send for game or nil if the challenger has no credit to claim.
+func (c *Claimer) prepareClaim(ctx context.Context, game types.GameMetadata) (*pendingClaim, error) {
 	c.logger.Debug("Attempting to claim bonds for", "game", game.Proxy)
 
 	contract, err := c.contractCreator(game)
 	if err != nil {
-		return fmt.Errorf("failed to create bond contract bindings: %w", err)
+		return nil, fmt.Errorf("failed to create bond contract bindings: %w", err)
 	}
 	credit, err := contract.GetCredit(ctx, c.txSender.From())
 	if err != nil {
-		return fmt.Errorf("failed to get credit: %w", err)
+		return nil, fmt.Errorf("failed to get credit: %w", err)
 	}
 
 	if credit.Cmp(big.NewInt(0)) == 0 {
 		c.logger.Debug("No credit to claim", "game", game.Proxy)
-		return nil
+		return nil, nil
 	}
 
 	candidate, err := contract.ClaimCredit(c.txSender.From())
 	if err != nil {
-		return fmt.Errorf("failed to create credit claim tx: %w", err)
+		return nil, fmt.Errorf("failed to create credit claim tx: %w", err)
 	}
-
-	if _, err = c.txSender.SendAndWait("claim credit", candidate); err != nil {
-		return fmt.Errorf("failed to claim credit: %w", err)
-	}
-
-	c.metrics.RecordBondClaimed(credit.Uint64())
-	return nil
+	return &pendingClaim{game: game.Proxy, gameType: game.GameType, credit: credit, tx: candidate}, nil
 }
@@ -26,8 +26,10 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		contract.credit = 1
 		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}, {Proxy: gameAddr}, {Proxy: gameAddr}})
 		require.NoError(t, err)
-		require.Equal(t, 3, txSender.sends)
+		require.Equal(t, 1, txSender.sends, "should batch claims")
+		require.Equal(t, 3, txSender.txs)
 		require.Equal(t, 3, m.RecordBondClaimedCalls)
+		require.Zero(t, m.pending.Uint64())
 	})
 
 	t.Run("BondClaimSucceeds", func(t *testing.T) {
@@ -40,6 +42,18 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		require.Equal(t, 1, m.RecordBondClaimedCalls)
 	})
 
+	t.Run("RecordsProfits", func(t *testing.T) {
+		gameAddr := common.HexToAddress("0x1234")
+		c, _, contract, _ := newTestClaimer(t, gameAddr)
+		profits := &stubProfitRecorder{recovered: make(map[uint32]int64), gas: make(map[uint32]int64)}
+		c.profits = profits
+		contract.credit = 4
+		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{GameType: 0, Proxy: gameAddr}, {GameType: 2, Proxy: gameAddr}, {GameType: 2, Proxy: gameAddr}})
+		require.NoError(t, err)
+		require.Equal(t, map[uint32]int64{0: 4, 2: 8}, profits.recovered)
+		require.Equal(t, map[uint32]int64{0: 21000 * 10, 2: 2 * 21000 * 10}, profits.gas)
+	})
+
 	t.Run("BondClaimFails", func(t *testing.T) {
 		gameAddr := common.HexToAddress("0x1234")
 		c, m, contract, txSender := newTestClaimer(t, gameAddr)
@@ -68,8 +82,22 @@ func TestClaimer_ClaimBonds(t *testing.T) {
 		txSender.sendFails = true
 		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}, {Proxy: gameAddr}, {Proxy: gameAddr}})
 		require.ErrorIs(t, err, mockTxMgrSendError)
-		require.Equal(t, 3, txSender.sends)
+		require.Equal(t, 1, txSender.sends)
+		require.Equal(t, 3, txSender.txs)
+		require.Equal(t, 0, m.RecordBondClaimedCalls)
+		require.Equal(t, uint64(3), m.pending.Uint64())
+	})
+
+	t.Run("BondClaimReverts", func(t *testing.T) {
+		gameAddr := common.HexToAddress("0x1234")
+		c, m, contract, txSender := newTestClaimer(t, gameAddr)
+		txSender.statusFail = true
+		contract.credit = 5
+		err := c.ClaimBonds(context.Background(), []types.GameMetadata{{Proxy: gameAddr}, {Proxy: gameAddr}})
+		require.ErrorIs(t, err, ErrClaimReverted)
+		require.Equal(t, 1, txSender.sends)
 		require.Equal(t, 0, m.RecordBondClaimedCalls)
+		require.Equal(t, uint64(10), m.pending.Uint64())
 	})
 }
 
@@ -81,20 +109,43 @@ func newTestClaimer(t *testing.T, gameAddr common.Address) (*Claimer, *mockClaim
 	contractCreator := func(game types.GameMetadata) (BondContract, error) {
 		return bondContract, nil
 	}
-	c := NewBondClaimer(logger, m, contractCreator, txSender)
+	c := NewBondClaimer(logger, m, contractCreator, txSender, &stubProfitRecorder{})
 	return c, m, bondContract, txSender
 }
 
 type mockClaimMetrics struct {
 	RecordBondClaimedCalls int
+	pending                *big.Int
 }
 
-func (m *mockClaimMetrics) RecordBondClaimed(amount uint64) {
+func (m *mockClaimMetrics) RecordBondClaimed(amount *big.Int) {
 	m.RecordBondClaimedCalls++
 }
 
+func (m *mockClaimMetrics) RecordBondsPending(amount *big.Int) {
+	m.pending = new(big.Int).Set(amount)
+}
+
+type stubProfitRecorder struct {
+	gas       map[uint32]int64
+	recovered map[uint32]int64
+}
+
+func (s *stubProfitRecorder) RecordGasSpent(gameType uint32, amount *big.Int) {
+	if s.gas != nil {
+		s.gas[gameType] += amount.Int64()
+	}
+}
+
+func (s *stubProfitRecorder) RecordBondRecovered(gameType uint32, amount *big.Int) {
+	if s.recovered != nil {
+		s.recovered[gameType] += amount.Int64()
+	}
+}
+
 type mockTxSender struct {
 	sends      int
+	txs        int
 	sendFails  bool
 	statusFail bool
 }
@@ -103,15 +154,21 @@ func (s *mockTxSender) From() common.Address {
 	return common.HexToAddress("0x33333")
 }
 
-func (s *mockTxSender) SendAndWait(_ string, _ ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+func (s *mockTxSender) SendAndWait(_ string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
 	s.sends++
+	s.txs += len(txs)
 	if s.sendFails {
 		return nil, mockTxMgrSendError
 	}
+	status := ethtypes.ReceiptStatusSuccessful
 	if s.statusFail {
-		return []*ethtypes.Receipt{{Status: ethtypes.ReceiptStatusFailed}}, nil
+		status = ethtypes.ReceiptStatusFailed
+	}
+	receipts := make([]*ethtypes.Receipt, len(txs))
+	for i := range receipts {
+		receipts[i] = &ethtypes.Receipt{Status: status, GasUsed: 21000, EffectiveGasPrice: big.NewInt(10)}
 	}
-	return []*ethtypes.Receipt{{Status: ethtypes.ReceiptStatusSuccessful}}, nil
+	return receipts, nil
 }
 
 type stubBondContract struct {
@@ -0,0 +1,155 @@
+package claims
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrResolveNotSent  = errors.New("resolve claim transaction not sent")
+	ErrResolveReverted = errors.New("resolve claim transaction reverted")
+)
+
+type Multicall interface {
+	Aggregate3Tx(txs ...txmgr.TxCandidate) (txmgr.TxCandidate, error)
+}
+
+// ResolutionBatcher groups resolveClaim transactions from all games into multicall transactions.
+// Resolutions are collected until either maxBatchSize resolutions are pending or delay has elapsed since the first
+// resolution in the batch was requested. If the batched transaction fails, the resolutions are retried as individual
+// transactions so that one claim that can't be resolved does not prevent the others from being resolved.
+// Batched transactions are sent with txSender as they may resolve claims from multiple games. Individual
+// transactions are sent with the sender supplied for the resolution, so they are attributed to their game.
+type ResolutionBatcher struct {
+	logger       log.Logger
+	clock        clock.Clock
+	multicall    Multicall
+	txSender     types.TxSender
+	maxBatchSize int
+	delay        time.Duration
+
+	lock    sync.Mutex
+	pending []*pendingResolution
+	timer   clock.Timer
+}
+
+type pendingResolution struct {
+	tx     txmgr.TxCandidate
+	sender types.TxSender
+	result chan error
+}
+
+func NewResolutionBatcher(logger log.Logger, cl clock.Clock, multicall Multicall, txSender types.TxSender, maxBatchSize int, delay time.Duration) *ResolutionBatcher {
+	return &ResolutionBatcher{
+		logger:       logger,
+		clock:        cl,
+		multicall:    multicall,
+		txSender:     txSender,
+		maxBatchSize: maxBatchSize,
+		delay:        delay,
+	}
+}
+
+// ResolveClaim adds tx to the next batch of resolutions and waits for the batch to be sent.
+// If tx has to be sent individually, it is sent with sender.
+func (b *ResolutionBatcher) ResolveClaim(tx txmgr.TxCandidate, sender types.TxSender) error {
+	req := &pendingResolution{tx: tx, sender: sender, result: make(chan error, 1)}
+	b.lock.Lock()
+	b.pending = append(b.pending, req)
+	var batch []*pendingResolution
+	if len(b.pending) >= b.maxBatchSize {
+		if b.timer != nil {
+			b.timer.Stop()
+		}
+		batch = b.takeBatch()
+	} else if b.timer == nil {
+		b.timer = b.clock.AfterFunc(b.delay, b.flush)
+	}
+	b.lock.Unlock()
+	if batch != nil {
+		b.send(batch)
+	}
+	return <-req.result
+}
+
+// flush sends all pending resolutions. Called when the batch timer fires.
+func (b *ResolutionBatcher) flush() {
+	b.lock.Lock()
+	batch := b.takeBatch()
+	b.lock.Unlock()
+	if len(batch) > 0 {
+		b.send(batch)
+	}
+}
+
+// takeBatch removes all pending resolutions. The lock must be held when calling this method.
+func (b *ResolutionBatcher) takeBatch() []*pendingResolution {
+	b.timer = nil
+	batch := b.pending
+	b.pending = nil
+	return batch
+}
+
+func (b *ResolutionBatcher) send(batch []*pendingResolution) {
+	if len(batch) == 1 {
+		b.sendIndividually(batch)
+		return
+	}
+	txs := make([]txmgr.TxCandidate, len(batch))
+	for i, req := range batch {
+		txs[i] = req.tx
+	}
+	candidate, err := b.multicall.Aggregate3Tx(txs...)
+	if err != nil {
+		b.logger.Warn("Failed to create batched claim resolution, sending individually", "err", err)
+		b.sendIndividually(batch)
+		return
+	}
+	b.logger.Info("Resolving claims in batch", "claims", len(batch))
+	receipts, err := b.txSender.SendAndWait("resolve claims", candidate)
+	if err != nil || len(receipts) == 0 || receipts[0] == nil || receipts[0].Status != ethtypes.ReceiptStatusSuccessful {
+		b.logger.Warn("Batched claim resolution failed, sending individually", "claims", len(batch), "err", err)
+		b.sendIndividually(batch)
+		return
+	}
+	for _, req := range batch {
+		req.result <- nil
+	}
+}
+
+// sendIndividually sends each resolution with its own sender, concurrently so that the transactions can be
+// included in the same block.
+func (b *ResolutionBatcher) sendIndividually(batch []*pendingResolution) {
+	var wg sync.WaitGroup
+	for _, req := range batch {
+		req := req
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req.result <- sendResolution(req)
+		}()
+	}
+	wg.Wait()
+}
+
+func sendResolution(req *pendingResolution) error {
+	receipts, err := req.sender.SendAndWait("resolve claim", req.tx)
+	if err != nil {
+		return err
+	}
+	if len(receipts) == 0 || receipts[0] == nil {
+		return fmt.Errorf("%w: no receipt", ErrResolveNotSent)
+	}
+	if receipts[0].Status != ethtypes.ReceiptStatusSuccessful {
+		return fmt.Errorf("%w: %v", ErrResolveReverted, receipts[0].TxHash)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package claims
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var multicallAddr = common.Address{0xca, 0x11}
+
+func TestResolutionBatcher(t *testing.T) {
+	gameA := common.Address{0xaa}
+	gameB := common.Address{0xbb}
+	txA := txmgr.TxCandidate{To: &gameA, TxData: []byte{1}}
+	txB := txmgr.TxCandidate{To: &gameB, TxData: []byte{2}}
+
+	t.Run("SendWhenBatchFull", func(t *testing.T) {
+		batcher, _, sender := newTestResolutionBatcher(t, 2)
+		senderA, senderB := newStubResolveSender(), newStubResolveSender()
+		errs := resolveAll(batcher, resolution{txA, senderA}, resolution{txB, senderB})
+		require.NoError(t, errors.Join(errs...))
+		require.Equal(t, []int{2}, sender.multicallSends)
+		require.Empty(t, sender.individualSends)
+		require.Empty(t, senderA.individualSends)
+		require.Empty(t, senderB.individualSends)
+	})
+
+	t.Run("SendAfterDelay", func(t *testing.T) {
+		batcher, cl, sender := newTestResolutionBatcher(t, 10)
+		senderA := newStubResolveSender()
+		result := make(chan error, 1)
+		go func() {
+			result <- batcher.ResolveClaim(txA, senderA)
+		}()
+		require.True(t, cl.WaitForNewPendingTaskWithTimeout(10*time.Second))
+		cl.AdvanceTime(time.Second)
+		require.NoError(t, <-result)
+		require.Empty(t, sender.multicallSends, "should not use multicall for a single resolution")
+		require.Empty(t, sender.individualSends, "should send individual resolutions with the game's sender")
+		require.Equal(t, []int{1}, senderA.individualSends)
+	})
+
+	t.Run("FallbackWhenBatchReverts", func(t *testing.T) {
+		batcher, _, sender := newTestResolutionBatcher(t, 2)
+		sender.multicallStatus = ethtypes.ReceiptStatusFailed
+		senderA, senderB := newStubResolveSender(), newStubResolveSender()
+		errs := resolveAll(batcher, resolution{txA, senderA}, resolution{txB, senderB})
+		require.NoError(t, errors.Join(errs...))
+		require.Equal(t, []int{2}, sender.multicallSends)
+		require.Empty(t, sender.individualSends)
+		require.Equal(t, []int{1}, senderA.individualSends)
+		require.Equal(t, []int{1}, senderB.individualSends)
+	})
+
+	t.Run("FallbackWhenBatchFails", func(t *testing.T) {
+		batcher, _, sender := newTestResolutionBatcher(t, 2)
+		sender.multicallErr = errors.New("boom")
+		senderA, senderB := newStubResolveSender(), newStubResolveSender()
+		errs := resolveAll(batcher, resolution{txA, senderA}, resolution{txB, senderB})
+		require.NoError(t, errors.Join(errs...))
+		require.Equal(t, []int{1}, senderA.individualSends)
+		require.Equal(t, []int{1}, senderB.individualSends)
+	})
+
+	t.Run("IndividualSendFails", func(t *testing.T) {
+		batcher, _, sender := newTestResolutionBatcher(t, 2)
+		sender.multicallErr = errors.New("boom")
+		senderA, senderB := newStubResolveSender(), newStubResolveSender()
+		senderA.individualErr = errors.New("nope")
+		errs := resolveAll(batcher, resolution{txA, senderA}, resolution{txB, senderB})
+		require.ErrorIs(t, errs[0], senderA.individualErr)
+		require.NoError(t, errs[1])
+	})
+
+	t.Run("IndividualSendReverts", func(t *testing.T) {
+		batcher, _, sender := newTestResolutionBatcher(t, 2)
+		sender.multicallErr = errors.New("boom")
+		senderA, senderB := newStubResolveSender(), newStubResolveSender()
+		senderB.individualStatus = ethtypes.ReceiptStatusFailed
+		errs := resolveAll(batcher, resolution{txA, senderA}, resolution{txB, senderB})
+		require.NoError(t, errs[0])
+		require.ErrorIs(t, errs[1], ErrResolveReverted)
+	})
+}
+
+type resolution struct {
+	tx     txmgr.TxCandidate
+	sender *stubResolveSender
+}
+
+func resolveAll(batcher *ResolutionBatcher, resolutions ...resolution) []error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(resolutions))
+	for i, r := range resolutions {
+		i, r := i, r
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = batcher.ResolveClaim(r.tx, r.sender)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+func newTestResolutionBatcher(t *testing.T, maxBatchSize int) (*ResolutionBatcher, *clock.DeterministicClock, *stubResolveSender) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	sender := newStubResolveSender()
+	return NewResolutionBatcher(logger, cl, &stubMulticall{}, sender, maxBatchSize, time.Second), cl, sender
+}
+
+type stubMulticall struct{}
+
+func (s *stubMulticall) Aggregate3Tx(txs ...txmgr.TxCandidate) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{To: &multicallAddr, TxData: []byte{byte(len(txs))}}, nil
+}
+
+type stubResolveSender struct {
+	lock             sync.Mutex
+	multicallSends   []int
+	multicallStatus  uint64
+	multicallErr     error
+	individualSends  []int
+	individualStatus uint64
+	individualErr    error
+}
+
+func newStubResolveSender() *stubResolveSender {
+	return &stubResolveSender{
+		multicallStatus:  ethtypes.ReceiptStatusSuccessful,
+		individualStatus: ethtypes.ReceiptStatusSuccessful,
+	}
+}
+
+func (s *stubResolveSender) From() common.Address {
+	return common.Address{0x33}
+}
+
+func (s *stubResolveSender) SendAndWait(_ string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(txs) == 1 && *txs[0].To == multicallAddr {
+		s.multicallSends = append(s.multicallSends, int(txs[0].TxData[0]))
+		if s.multicallErr != nil {
+			return nil, s.multicallErr
+		}
+		return []*ethtypes.Receipt{{Status: s.multicallStatus}}, nil
+	}
+	s.individualSends = append(s.individualSends, len(txs))
+	if s.individualErr != nil {
+		return make([]*ethtypes.Receipt, len(txs)), s.individualErr
+	}
+	receipts := make([]*ethtypes.Receipt, len(txs))
+	for i := range receipts {
+		receipts[i] = &ethtypes.Receipt{Status: s.individualStatus}
+	}
+	return receipts, nil
+}
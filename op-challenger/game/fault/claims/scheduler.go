@@ -46,7 +46,11 @@ func (s *BondClaimScheduler) Start(ctx context.Context) {
 	go s.run(ctx)
 }
 
+// Close stops claiming bonds. It is safe to call if the scheduler was never started.
 func (s *BondClaimScheduler) Close() error {
+	if s.cancel == nil {
+		return nil
+	}
 	s.cancel()
 	s.wg.Wait()
 	return nil
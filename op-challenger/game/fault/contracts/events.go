@@ -0,0 +1,58 @@
+package contracts
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	eventDisputeGameCreated = "DisputeGameCreated"
+	eventMove               = "Move"
+)
+
+// GameEvents identifies the logs that indicate games need to be progressed: games created by the factory and claims
+// posted to existing games.
+type GameEvents struct {
+	factory     common.Address
+	gameCreated common.Hash
+	move        common.Hash
+}
+
+func NewGameEvents(factory common.Address) (*GameEvents, error) {
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dispute game factory ABI: %w", err)
+	}
+	gameAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load fault dispute game ABI: %w", err)
+	}
+	return &GameEvents{
+		factory:     factory,
+		gameCreated: factoryAbi.Events[eventDisputeGameCreated].ID,
+		move:        gameAbi.Events[eventMove].ID,
+	}, nil
+}
+
+// FilterQuery returns a query matching both DisputeGameCreated and Move events, emitted by the factory or the games.
+// The set of games changes over time, so a new query is required when new games are created.
+func (e *GameEvents) FilterQuery(games []common.Address) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: append([]common.Address{e.factory}, games...),
+		Topics:    [][]common.Hash{{e.gameCreated, e.move}},
+	}
+}
+
+// IsGameCreated returns true if l is a DisputeGameCreated event emitted by the factory.
+func (e *GameEvents) IsGameCreated(l ethtypes.Log) bool {
+	return l.Address == e.factory && len(l.Topics) > 0 && l.Topics[0] == e.gameCreated
+}
+
+// IsMove returns true if l is a Move event.
+func (e *GameEvents) IsMove(l ethtypes.Log) bool {
+	return len(l.Topics) > 0 && l.Topics[0] == e.move
+}
@@ -0,0 +1,35 @@
+package contracts
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameEvents(t *testing.T) {
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	gameAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	createdTopic := factoryAbi.Events[eventDisputeGameCreated].ID
+	moveTopic := gameAbi.Events[eventMove].ID
+	otherTopic := common.Hash{0xdd}
+
+	events, err := NewGameEvents(factoryAddr)
+	require.NoError(t, err)
+	game := common.Address{0xaa}
+	require.Equal(t, [][]common.Hash{{createdTopic, moveTopic}}, events.FilterQuery(nil).Topics)
+	require.Equal(t, []common.Address{factoryAddr}, events.FilterQuery(nil).Addresses)
+	require.Equal(t, []common.Address{factoryAddr, game}, events.FilterQuery([]common.Address{game}).Addresses)
+
+	require.True(t, events.IsGameCreated(ethtypes.Log{Address: factoryAddr, Topics: []common.Hash{createdTopic}}))
+	require.False(t, events.IsGameCreated(ethtypes.Log{Address: common.Address{0xaa}, Topics: []common.Hash{createdTopic}}))
+	require.False(t, events.IsGameCreated(ethtypes.Log{Address: factoryAddr, Topics: []common.Hash{otherTopic}}))
+	require.False(t, events.IsGameCreated(ethtypes.Log{Address: factoryAddr}))
+
+	require.True(t, events.IsMove(ethtypes.Log{Address: common.Address{0xaa}, Topics: []common.Hash{moveTopic}}))
+	require.False(t, events.IsMove(ethtypes.Log{Address: common.Address{0xaa}, Topics: []common.Hash{createdTopic}}))
+}
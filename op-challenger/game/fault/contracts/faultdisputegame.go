@@ -3,6 +3,7 @@ package contracts
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
@@ -35,6 +36,7 @@ var (
 	methodRequiredBond       = "getRequiredBond"
 	methodClaimCredit        = "claimCredit"
 	methodCredit             = "credit"
+	methodGameType           = "gameType"
 )
 
 type FaultDisputeGameContract struct {
@@ -180,6 +182,14 @@ func (f *FaultDisputeGameContract) GetL1Head(ctx context.Context) (common.Hash,
 	return result.GetHash(0), nil
 }
 
+func (f *FaultDisputeGameContract) GetGameType(ctx context.Context) (uint32, error) {
+	result, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, f.contract.Call(methodGameType))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch game type: %w", err)
+	}
+	return result.GetUint32(0), nil
+}
+
 func (f *FaultDisputeGameContract) GetStatus(ctx context.Context) (gameTypes.GameStatus, error) {
 	result, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, f.contract.Call(methodStatus))
 	if err != nil {
@@ -243,6 +253,20 @@ func (f *FaultDisputeGameContract) StepTx(claimIdx uint64, isAttack bool, stateD
 	return call.ToTxCandidate()
 }
 
+// SimulateTx executes tx from the sender against the latest block via eth_call without sending it.
+// If the tx would revert, the returned error wraps ErrSimulationReverted and includes the revert reason.
+func (f *FaultDisputeGameContract) SimulateTx(ctx context.Context, from common.Address, tx txmgr.TxCandidate) error {
+	_, err := f.multiCaller.CallTx(ctx, batching.BlockLatest, from, tx)
+	if err == nil {
+		return nil
+	}
+	reason, reverted := revertReason(f.contract, err)
+	if !reverted {
+		return fmt.Errorf("failed to simulate tx: %w", err)
+	}
+	return fmt.Errorf("%w: %v", ErrSimulationReverted, reason)
+}
+
 func (f *FaultDisputeGameContract) CallResolveClaim(ctx context.Context, claimIdx uint64) error {
 	call := f.resolveClaimCall(claimIdx)
 	_, err := f.multiCaller.SingleCall(ctx, batching.BlockLatest, call)
@@ -279,6 +303,13 @@ func (f *FaultDisputeGameContract) resolveCall() *batching.ContractCall {
 	return f.contract.Call(methodResolve)
 }
 
+// decodeClock splits the packed uint128 clock into the duration (high 64 bits) and timestamp (low 64 bits).
+func decodeClock(clock *big.Int) types.Clock {
+	duration := new(big.Int).Rsh(clock, 64)
+	timestamp := new(big.Int).And(clock, new(big.Int).SetUint64(math.MaxUint64))
+	return types.NewClock(duration.Uint64(), timestamp.Uint64())
+}
+
 func (f *FaultDisputeGameContract) decodeClaim(result *batching.CallResult, contractIndex int) types.Claim {
 	parentIndex := result.GetUint32(0)
 	counteredBy := result.GetAddress(1)
@@ -295,7 +326,7 @@ func (f *FaultDisputeGameContract) decodeClaim(result *batching.CallResult, cont
 		},
 		CounteredBy:         counteredBy,
 		Claimant:            claimant,
-		Clock:               clock.Uint64(),
+		Clock:               decodeClock(clock),
 		ContractIndex:       contractIndex,
 		ParentContractIndex: int(parentIndex),
 	}
@@ -2,6 +2,7 @@ package contracts
 
 import (
 	"context"
+	"errors"
 	"math"
 	"math/big"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,6 +40,14 @@ func TestSimpleGetters(t *testing.T) {
 				return game.GetStatus(context.Background())
 			},
 		},
+		{
+			methodAlias: "gameType",
+			method:      methodGameType,
+			result:      uint32(2),
+			call: func(game *FaultDisputeGameContract) (any, error) {
+				return game.GetGameType(context.Background())
+			},
+		},
 		{
 			methodAlias: "gameDuration",
 			method:      methodGameDuration,
@@ -124,8 +134,8 @@ func TestGetClaim(t *testing.T) {
 	bond := big.NewInt(5)
 	value := common.Hash{0xab}
 	position := big.NewInt(2)
-	clock := big.NewInt(1234)
-	stubRpc.SetResponse(fdgAddr, methodClaim, batching.BlockLatest, []interface{}{idx}, []interface{}{parentIndex, counteredBy, claimant, bond, value, position, clock})
+	clock := faultTypes.NewClock(5, 1234)
+	stubRpc.SetResponse(fdgAddr, methodClaim, batching.BlockLatest, []interface{}{idx}, []interface{}{parentIndex, counteredBy, claimant, bond, value, position, packClock(clock)})
 	status, err := game.GetClaim(context.Background(), idx.Uint64())
 	require.NoError(t, err)
 	require.Equal(t, faultTypes.Claim{
@@ -136,7 +146,7 @@ func TestGetClaim(t *testing.T) {
 		},
 		CounteredBy:         counteredBy,
 		Claimant:            claimant,
-		Clock:               1234,
+		Clock:               clock,
 		ContractIndex:       int(idx.Uint64()),
 		ParentContractIndex: 1,
 	}, status)
@@ -152,7 +162,7 @@ func TestGetAllClaims(t *testing.T) {
 		},
 		CounteredBy:         common.Address{0x01},
 		Claimant:            common.Address{0x02},
-		Clock:               1234,
+		Clock:               faultTypes.NewClock(5, 1234),
 		ContractIndex:       0,
 		ParentContractIndex: math.MaxUint32,
 	}
@@ -164,7 +174,7 @@ func TestGetAllClaims(t *testing.T) {
 		},
 		CounteredBy:         common.Address{0x02},
 		Claimant:            common.Address{0x01},
-		Clock:               4455,
+		Clock:               faultTypes.NewClock(8, 4455),
 		ContractIndex:       1,
 		ParentContractIndex: 0,
 	}
@@ -175,7 +185,7 @@ func TestGetAllClaims(t *testing.T) {
 			Bond:     big.NewInt(5),
 		},
 		Claimant:            common.Address{0x02},
-		Clock:               7777,
+		Clock:               faultTypes.NewClock(13, 7777),
 		ContractIndex:       2,
 		ParentContractIndex: 1,
 	}
@@ -240,6 +250,63 @@ func TestStepTx(t *testing.T) {
 	stubRpc.VerifyTxCandidate(tx)
 }
 
+func TestSimulateTx(t *testing.T) {
+	from := common.Address{0xbb}
+	value := common.Hash{0xaa}
+
+	t.Run("Success", func(t *testing.T) {
+		stubRpc, game := setupFaultDisputeGameTest(t)
+		stubRpc.SetResponse(fdgAddr, methodAttack, batching.BlockLatest, []interface{}{big.NewInt(111), value}, nil)
+		tx, err := game.AttackTx(111, value)
+		require.NoError(t, err)
+		require.NoError(t, game.SimulateTx(context.Background(), from, tx))
+	})
+
+	t.Run("CustomError", func(t *testing.T) {
+		stubRpc, game := setupFaultDisputeGameTest(t)
+		fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+		require.NoError(t, err)
+		revertData := hexutil.Encode(fdgAbi.Errors["ClaimAlreadyExists"].ID.Bytes()[:4])
+		stubRpc.SetError(fdgAddr, methodAttack, batching.BlockLatest, []interface{}{big.NewInt(111), value}, &revertError{data: revertData})
+		tx, err := game.AttackTx(111, value)
+		require.NoError(t, err)
+		err = game.SimulateTx(context.Background(), from, tx)
+		require.ErrorIs(t, err, ErrSimulationReverted)
+		require.ErrorContains(t, err, "ClaimAlreadyExists")
+	})
+
+	t.Run("RevertWithoutData", func(t *testing.T) {
+		stubRpc, game := setupFaultDisputeGameTest(t)
+		stubRpc.SetError(fdgAddr, methodAttack, batching.BlockLatest, []interface{}{big.NewInt(111), value}, errors.New("execution reverted"))
+		tx, err := game.AttackTx(111, value)
+		require.NoError(t, err)
+		require.ErrorIs(t, game.SimulateTx(context.Background(), from, tx), ErrSimulationReverted)
+	})
+
+	t.Run("RpcFailure", func(t *testing.T) {
+		stubRpc, game := setupFaultDisputeGameTest(t)
+		stubRpc.SetError(fdgAddr, methodAttack, batching.BlockLatest, []interface{}{big.NewInt(111), value}, errors.New("connection refused"))
+		tx, err := game.AttackTx(111, value)
+		require.NoError(t, err)
+		err = game.SimulateTx(context.Background(), from, tx)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrSimulationReverted)
+	})
+}
+
+// revertError mimics the error returned by geth when an eth_call reverts with data.
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string {
+	return "execution reverted"
+}
+
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
 func expectGetClaim(stubRpc *batchingTest.AbiBasedRpc, claim faultTypes.Claim) {
 	stubRpc.SetResponse(
 		fdgAddr,
@@ -253,7 +320,7 @@ func expectGetClaim(stubRpc *batchingTest.AbiBasedRpc, claim faultTypes.Claim) {
 			claim.Bond,
 			claim.Value,
 			claim.Position.ToGIndex(),
-			big.NewInt(int64(claim.Clock)),
+			packClock(claim.Clock),
 		})
 }
 
@@ -335,3 +402,9 @@ func setupFaultDisputeGameTest(t *testing.T) (*batchingTest.AbiBasedRpc, *FaultD
 	require.NoError(t, err)
 	return stubRpc, game
 }
+
+func packClock(c faultTypes.Clock) *big.Int {
+	duration := new(big.Int).SetUint64(uint64(c.Duration.Seconds()))
+	encoded := new(big.Int).Lsh(duration, 64)
+	return new(big.Int).Or(encoded, new(big.Int).SetUint64(uint64(c.Timestamp.Unix())))
+}
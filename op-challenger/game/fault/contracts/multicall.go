@@ -0,0 +1,55 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	methodAggregate3 = "aggregate3"
+)
+
+var ErrUnsupportedMulticallTx = errors.New("transaction can not be included in a multicall")
+
+// Multicall3Contract is a binding for the Multicall3 contract, used to combine multiple calls into a single transaction.
+type Multicall3Contract struct {
+	addr     common.Address
+	contract *batching.BoundContract
+}
+
+func NewMulticall3Contract(addr common.Address) (*Multicall3Contract, error) {
+	multicallAbi, err := bindings.MultiCall3MetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load multicall3 ABI: %w", err)
+	}
+	return &Multicall3Contract{
+		addr:     addr,
+		contract: batching.NewBoundContract(multicallAbi, addr),
+	}, nil
+}
+
+func (c *Multicall3Contract) Addr() common.Address {
+	return c.addr
+}
+
+// Aggregate3Tx creates a transaction that executes each of txs in order.
+// The calls are not allowed to fail so if any of them revert, the entire transaction reverts.
+// Transactions that send value or create contracts can not be aggregated.
+func (c *Multicall3Contract) Aggregate3Tx(txs ...txmgr.TxCandidate) (txmgr.TxCandidate, error) {
+	calls := make([]bindings.Multicall3Call3, 0, len(txs))
+	for i, tx := range txs {
+		if tx.To == nil || (tx.Value != nil && tx.Value.Sign() != 0) {
+			return txmgr.TxCandidate{}, fmt.Errorf("%w: tx %v", ErrUnsupportedMulticallTx, i)
+		}
+		calls = append(calls, bindings.Multicall3Call3{
+			Target:   *tx.To,
+			CallData: tx.TxData,
+		})
+	}
+	return c.contract.Call(methodAggregate3, calls).ToTxCandidate()
+}
@@ -0,0 +1,58 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMulticall3Contract_Aggregate3Tx(t *testing.T) {
+	multicallAddr := common.Address{0xca, 0x11}
+	contract, err := NewMulticall3Contract(multicallAddr)
+	require.NoError(t, err)
+	require.Equal(t, multicallAddr, contract.Addr())
+
+	gameA := common.Address{0xaa}
+	gameB := common.Address{0xbb}
+	tx, err := contract.Aggregate3Tx(
+		txmgr.TxCandidate{To: &gameA, TxData: []byte{1, 2, 3}},
+		txmgr.TxCandidate{To: &gameB, TxData: []byte{4, 5}, Value: big.NewInt(0)})
+	require.NoError(t, err)
+	require.Equal(t, &multicallAddr, tx.To)
+
+	multicallAbi, err := bindings.MultiCall3MetaData.GetAbi()
+	require.NoError(t, err)
+	method := multicallAbi.Methods[methodAggregate3]
+	require.Equal(t, method.ID, tx.TxData[:4])
+	args, err := method.Inputs.Unpack(tx.TxData[4:])
+	require.NoError(t, err)
+	calls := *abiConvert[[]bindings.Multicall3Call3](t, args[0])
+	require.Equal(t, []bindings.Multicall3Call3{
+		{Target: gameA, CallData: []byte{1, 2, 3}},
+		{Target: gameB, CallData: []byte{4, 5}},
+	}, calls)
+}
+
+func TestMulticall3Contract_Aggregate3TxRejectsUnsupportedTxs(t *testing.T) {
+	contract, err := NewMulticall3Contract(common.Address{0xca, 0x11})
+	require.NoError(t, err)
+	game := common.Address{0xaa}
+
+	_, err = contract.Aggregate3Tx(txmgr.TxCandidate{TxData: []byte{1}})
+	require.ErrorIs(t, err, ErrUnsupportedMulticallTx)
+
+	_, err = contract.Aggregate3Tx(txmgr.TxCandidate{To: &game, Value: big.NewInt(1)})
+	require.ErrorIs(t, err, ErrUnsupportedMulticallTx)
+}
+
+func abiConvert[T any](t *testing.T, value interface{}) *T {
+	var out T
+	converted := abi.ConvertType(value, &out)
+	require.IsType(t, &out, converted)
+	return converted.(*T)
+}
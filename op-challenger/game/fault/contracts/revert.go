@@ -0,0 +1,43 @@
+package contracts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrSimulationReverted is returned when simulating a transaction shows that it would revert.
+var ErrSimulationReverted = errors.New("simulated transaction reverted")
+
+// revertReason returns a readable reason for a reverted eth_call, decoding custom errors from the contract's ABI.
+// Returns false if err was not caused by the call reverting.
+func revertReason(contract *batching.BoundContract, err error) (string, bool) {
+	if !strings.Contains(err.Error(), "execution reverted") {
+		return "", false
+	}
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err.Error(), true
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err.Error(), true
+	}
+	data, decodeErr := hexutil.Decode(hexData)
+	if decodeErr != nil {
+		return err.Error(), true
+	}
+	if reason, unpackErr := abi.UnpackRevert(data); unpackErr == nil {
+		return reason, true
+	}
+	name, _, decodeErr := contract.DecodeError(data)
+	if decodeErr != nil {
+		return fmt.Sprintf("unknown error %v", hexData), true
+	}
+	return name, true
+}
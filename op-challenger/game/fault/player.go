@@ -2,11 +2,16 @@ package fault
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
@@ -14,15 +19,23 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+var ErrGameResolved = errors.New("game already resolved")
+
 type actor func(ctx context.Context) error
 
+type explainer func(ctx context.Context, claimIdx uint64) (solver.ClaimExplanation, error)
+
 type GameInfo interface {
 	GetStatus(context.Context) (gameTypes.GameStatus, error)
 	GetClaimCount(context.Context) (uint64, error)
 }
 
 type GamePlayer struct {
+	// statusLock guards status so explanations can check it while the game is being progressed
+	statusLock         sync.RWMutex
 	act                actor
+	explain            explainer
+	archive            archiveFn
 	loader             GameInfo
 	logger             log.Logger
 	prestateValidators []Validator
@@ -36,7 +49,9 @@ type GameContract interface {
 	ClaimLoader
 	GetStatus(ctx context.Context) (gameTypes.GameStatus, error)
 	GetMaxGameDepth(ctx context.Context) (types.Depth, error)
+	GetGameDuration(ctx context.Context) (uint64, error)
 	GetOracle(ctx context.Context) (*contracts.PreimageOracleContract, error)
+	GetCredit(ctx context.Context, recipient common.Address) (*big.Int, error)
 }
 
 type resourceCreator func(ctx context.Context, logger log.Logger, gameDepth types.Depth, dir string) (types.TraceAccessor, error)
@@ -47,13 +62,19 @@ func NewGamePlayer(
 	logger log.Logger,
 	m metrics.Metricer,
 	dir string,
-	addr common.Address,
+	game gameTypes.GameMetadata,
 	txSender gameTypes.TxSender,
 	loader GameContract,
 	validators []Validator,
 	creator resourceCreator,
+	archiver GameArchiver,
+	honestActors []common.Address,
+	maxPlayDepth types.Depth,
+	budget *SpendBudget,
+	resolver responder.ClaimResolver,
+	fees responder.FeeConfig,
 ) (*GamePlayer, error) {
-	logger = logger.New("game", addr)
+	logger = logger.New("game", game.Proxy)
 
 	status, err := loader.GetStatus(ctx)
 	if err != nil {
@@ -61,6 +82,11 @@ func NewGamePlayer(
 	}
 	if status != gameTypes.GameStatusInProgress {
 		logger.Info("Game already resolved", "status", status)
+		if archiver != nil {
+			if err := backfillArchive(ctx, logger, archiver, game, loader, txSender, status); err != nil {
+				logger.Error("Failed to archive already resolved game", "err", err)
+			}
+		}
 		// Game is already complete so skip creating the trace provider, loading game inputs etc.
 		return &GamePlayer{
 			logger:             logger,
@@ -78,6 +104,10 @@ func NewGamePlayer(
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch the game depth: %w", err)
 	}
+	gameDuration, err := loader.GetGameDuration(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the game duration: %w", err)
+	}
 
 	accessor, err := creator(ctx, logger, gameDepth, dir)
 	if err != nil {
@@ -89,6 +119,19 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to load oracle: %w", err)
 	}
 
+	if budget != nil {
+		txSender = newBudgetTxSender(logger, txSender, budget, game.Proxy)
+	}
+	var archive archiveFn
+	if archiver != nil {
+		gasTracker, err := newGasTrackingTxSender(ctx, logger, txSender, archiver, game.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load gas spent on game: %w", err)
+		}
+		txSender = gasTracker
+		archive = newGameArchiveFn(archiver, game, loader, gasTracker)
+	}
+
 	minLargePreimageSize, err := oracle.MinLargePreimageSize(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load min large preimage size: %w", err)
@@ -96,17 +139,19 @@ func NewGamePlayer(
 	direct := preimages.NewDirectPreimageUploader(logger, txSender, loader)
 	large := preimages.NewLargePreimageUploader(logger, cl, txSender, oracle)
 	uploader := preimages.NewSplitPreimageUploader(direct, large, minLargePreimageSize)
-	responder, err := responder.NewFaultResponder(logger, txSender, loader, uploader, oracle)
+	responder, err := responder.NewFaultResponder(logger, m, txSender, loader, uploader, oracle, resolver, fees)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
-	agent := NewAgent(m, loader, gameDepth, accessor, responder, logger)
+	agent := NewAgent(m, loader, gameDepth, maxPlayDepth, time.Duration(gameDuration)*time.Second, accessor, responder, logger, honestActors, txSender.From())
 	return &GamePlayer{
-		act:    agent.Act,
-		loader: loader,
-		logger: logger,
-		status: status,
+		act:     agent.Act,
+		explain: agent.ExplainClaim,
+		archive: archive,
+		loader:  loader,
+		logger:  logger,
+		status:  status,
 	}, nil
 }
 
@@ -120,6 +165,8 @@ func (g *GamePlayer) ValidatePrestate(ctx context.Context) error {
 }
 
 func (g *GamePlayer) Status() gameTypes.GameStatus {
+	g.statusLock.RLock()
+	defer g.statusLock.RUnlock()
 	return g.status
 }
 
@@ -139,10 +186,29 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 		return gameTypes.GameStatusInProgress
 	}
 	g.logGameStatus(ctx, status)
+	if status != gameTypes.GameStatusInProgress && g.archive != nil {
+		if err := g.archive(ctx, status); err != nil {
+			g.logger.Error("Failed to archive game", "err", err)
+		}
+	}
+	g.statusLock.Lock()
 	g.status = status
+	g.statusLock.Unlock()
 	return status
 }
 
+// ExplainClaim explains the action that would be taken in response to the claim at claimIdx without performing it.
+// It does not wait for the game to be progressed. Returns ErrGameResolved if the game is already complete.
+func (g *GamePlayer) ExplainClaim(ctx context.Context, claimIdx uint64) (solver.ClaimExplanation, error) {
+	g.statusLock.RLock()
+	status := g.status
+	g.statusLock.RUnlock()
+	if status != gameTypes.GameStatusInProgress {
+		return solver.ClaimExplanation{}, ErrGameResolved
+	}
+	return g.explain(ctx, claimIdx)
+}
+
 func (g *GamePlayer) logGameStatus(ctx context.Context, status gameTypes.GameStatus) {
 	if status == gameTypes.GameStatusInProgress {
 		claimCount, err := g.loader.GetClaimCount(ctx)
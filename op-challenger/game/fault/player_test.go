@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
@@ -23,14 +24,12 @@ func TestProgressGame_LogErrorFromAct(t *testing.T) {
 	status := game.ProgressGame(context.Background())
 	require.Equal(t, types.GameStatusInProgress, status)
 	require.Equal(t, 1, actor.callCount, "should perform next actions")
-	errLog := handler.FindLog(log.LvlError, "Error when acting on game")
+	errLog := handler.FindMatch(testlog.Match().Level(log.LvlError).Message("Error when acting on game").Attr("err", actor.actErr))
 	require.NotNil(t, errLog, "should log error")
-	require.Equal(t, actor.actErr, errLog.GetContextValue("err"))
 
 	// Should still log game status
-	msg := handler.FindLog(log.LvlInfo, "Game info")
+	msg := handler.FindMatch(testlog.Match().Level(log.LvlInfo).Message("Game info").Attr("claims", uint64(1)))
 	require.NotNil(t, msg)
-	require.Equal(t, uint64(1), msg.GetContextValue("claims"))
 }
 
 func TestProgressGame_LogGameStatus(t *testing.T) {
@@ -64,9 +63,8 @@ func TestProgressGame_LogGameStatus(t *testing.T) {
 			status := game.ProgressGame(context.Background())
 			require.Equal(t, 1, gameState.callCount, "should perform next actions")
 			require.Equal(t, test.status, status)
-			errLog := handler.FindLog(log.LvlInfo, test.logMsg)
-			require.NotNil(t, errLog, "should log game result")
-			require.Equal(t, test.status, errLog.GetContextValue("status"))
+			resultLog := handler.FindMatch(testlog.Match().Level(log.LvlInfo).Message(test.logMsg).Attr("status", test.status))
+			require.NotNil(t, resultLog, "should log game result")
 		})
 	}
 }
@@ -89,6 +87,53 @@ func TestDoNotActOnCompleteGame(t *testing.T) {
 	}
 }
 
+func TestProgressGame_ArchiveResolvedGame(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t)
+	var archived []types.GameStatus
+	game.archive = func(ctx context.Context, status types.GameStatus) error {
+		archived = append(archived, status)
+		return nil
+	}
+	game.ProgressGame(context.Background())
+	require.Empty(t, archived, "should not archive in progress game")
+
+	gameState.status = types.GameStatusDefenderWon
+	game.ProgressGame(context.Background())
+	require.Equal(t, []types.GameStatus{types.GameStatusDefenderWon}, archived)
+
+	game.ProgressGame(context.Background())
+	require.Len(t, archived, 1, "should only archive game once")
+}
+
+func TestProgressGame_LogArchiveError(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t)
+	gameState.status = types.GameStatusChallengerWon
+	archiveErr := errors.New("boom")
+	game.archive = func(ctx context.Context, status types.GameStatus) error {
+		return archiveErr
+	}
+	status := game.ProgressGame(context.Background())
+	require.Equal(t, types.GameStatusChallengerWon, status)
+	errLog := handler.FindMatch(testlog.Match().Level(log.LvlError).Message("Failed to archive game").Attr("err", archiveErr))
+	require.NotNil(t, errLog)
+}
+
+func TestExplainClaim(t *testing.T) {
+	_, game, _ := setupProgressGameTest(t)
+	game.status = types.GameStatusInProgress
+	game.explain = func(ctx context.Context, claimIdx uint64) (solver.ClaimExplanation, error) {
+		return solver.ClaimExplanation{ClaimIdx: claimIdx, Action: solver.ExplainedActionAttack}, nil
+	}
+	explanation, err := game.ExplainClaim(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), explanation.ClaimIdx)
+	require.Equal(t, solver.ExplainedActionAttack, explanation.Action)
+
+	game.status = types.GameStatusDefenderWon
+	_, err = game.ExplainClaim(context.Background(), 3)
+	require.ErrorIs(t, err, ErrGameResolved)
+}
+
 func TestValidatePrestate(t *testing.T) {
 	tests := []struct {
 		name       string
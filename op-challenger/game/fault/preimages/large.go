@@ -34,6 +34,11 @@ const MaxBlocksPerChunk = 300
 // The max chunk size is roughly 0.04MB to avoid memory expansion.
 const MaxChunkSize = MaxBlocksPerChunk * keccakTypes.BlockSize
 
+// MaxLeafTxsPerBatch is the maximum number of addLeavesLPP transactions submitted before waiting for them to be
+// included. Limiting the batch size ensures later transactions are priced against current fees and that progress is
+// recorded onchain so an interrupted upload can resume without resending data.
+const MaxLeafTxsPerBatch = 10
+
 // LargePreimageUploader handles uploading large preimages by
 // streaming the merkleized preimage to the PreimageOracle contract,
 // tightly packed across multiple transactions.
@@ -74,16 +79,21 @@ func (p *LargePreimageUploader) UploadPreimage(ctx context.Context, parent uint6
 	}
 
 	// Filter out any chunks that have already been uploaded to the Preimage Oracle.
+	startingBlock := uint64(0)
 	if len(metadata) > 0 {
-		numSkip := metadata[0].BytesProcessed / MaxChunkSize
+		numSkip := int(metadata[0].BytesProcessed / MaxChunkSize)
+		if numSkip > len(calls) {
+			numSkip = len(calls)
+		}
 		calls = calls[numSkip:]
+		startingBlock = uint64(numSkip) * MaxBlocksPerChunk
 		// If the timestamp is non-zero, the preimage has been finalized.
 		if metadata[0].Timestamp != 0 {
 			calls = calls[len(calls):]
 		}
 	}
 
-	err = p.addLargePreimageData(uuid, calls)
+	err = p.addLargePreimageData(uuid, startingBlock, calls)
 	if err != nil {
 		return fmt.Errorf("failed to add leaves to large preimage with uuid: %s: %w", uuid, err)
 	}
@@ -171,21 +181,32 @@ func (p *LargePreimageUploader) initLargePreimage(uuid *big.Int, partOffset uint
 	return nil
 }
 
-// addLargePreimageData adds data to the large preimage proposal.
+// addLargePreimageData adds data to the large preimage proposal, starting at the block index startingBlock.
 // This method **must** be called after calling [initLargePreimage].
 // SAFETY: submits transactions in a [Queue] for latency while preserving submission order.
-func (p *LargePreimageUploader) addLargePreimageData(uuid *big.Int, chunks []keccakTypes.InputData) error {
+// Transactions are sent in batches of at most [MaxLeafTxsPerBatch], waiting for each batch to be included before
+// sending the next.
+func (p *LargePreimageUploader) addLargePreimageData(uuid *big.Int, startingBlock uint64, chunks []keccakTypes.InputData) error {
 	txs := make([]txmgr.TxCandidate, len(chunks))
-	blocksProcessed := int64(0)
+	blocksProcessed := new(big.Int).SetUint64(startingBlock)
 	for i, chunk := range chunks {
-		tx, err := p.contract.AddLeaves(uuid, big.NewInt(blocksProcessed), chunk.Input, chunk.Commitments, chunk.Finalize)
+		tx, err := p.contract.AddLeaves(uuid, new(big.Int).Set(blocksProcessed), chunk.Input, chunk.Commitments, chunk.Finalize)
 		if err != nil {
 			return fmt.Errorf("failed to create pre-image oracle tx: %w", err)
 		}
-		blocksProcessed += int64(len(chunk.Input) / keccakTypes.BlockSize)
+		blocksProcessed.Add(blocksProcessed, big.NewInt(int64(len(chunk.Input)/keccakTypes.BlockSize)))
 		txs[i] = tx
 	}
-	p.log.Info("Adding large preimage leaves", "uuid", uuid, "blocksProcessed", blocksProcessed, "txs", len(txs))
-	_, err := p.txSender.SendAndWait("add leaf to large preimage", txs...)
-	return err
+	p.log.Info("Adding large preimage leaves", "uuid", uuid, "startingBlock", startingBlock, "blocksProcessed", blocksProcessed, "txs", len(txs))
+	for start := 0; start < len(txs); start += MaxLeafTxsPerBatch {
+		end := start + MaxLeafTxsPerBatch
+		if end > len(txs) {
+			end = len(txs)
+		}
+		if _, err := p.txSender.SendAndWait("add leaf to large preimage", txs[start:end]...); err != nil {
+			return err
+		}
+		p.log.Info("Added large preimage leaves", "uuid", uuid, "sent", end, "total", len(txs))
+	}
+	return nil
 }
@@ -110,6 +110,31 @@ func TestLargePreimageUploader_UploadPreimage_EdgeCases(t *testing.T) {
 		require.Equal(t, 6, contract.addCalls)
 	})
 
+	t.Run("ResumeFromBytesProcessed", func(t *testing.T) {
+		oracle, _, _, contract := newTestLargePreimageUploader(t)
+		data := mockPreimageOracleData()
+		contract.bytesProcessed = 2 * MaxChunkSize
+		contract.claimedSize = uint32(len(data.GetPreimageWithoutSize()))
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.NoError(t, err)
+		require.Equal(t, 0, contract.initCalls)
+		require.Equal(t, 4, contract.addCalls)
+		require.Equal(t, data.GetPreimageWithoutSize()[2*MaxChunkSize:], contract.addData)
+		for i, start := range contract.addStartBlocks {
+			require.Equal(t, big.NewInt(int64((i+2)*MaxBlocksPerChunk)), start)
+		}
+	})
+
+	t.Run("BatchLeafTxs", func(t *testing.T) {
+		oracle, _, txSender, contract := newTestLargePreimageUploader(t)
+		data := makePreimageData(make([]byte, 2*MaxLeafTxsPerBatch*MaxChunkSize+1), 0)
+		err := oracle.UploadPreimage(context.Background(), 0, data)
+		require.NoError(t, err)
+		require.Equal(t, 2*MaxLeafTxsPerBatch+1, contract.addCalls)
+		// One init, three batches of leaves and one squeeze
+		require.Equal(t, 5, txSender.sends)
+	})
+
 	t.Run("ChallengePeriodNotElapsed", func(t *testing.T) {
 		oracle, cl, _, contract := newTestLargePreimageUploader(t)
 		data := mockPreimageOracleData()
@@ -271,6 +296,7 @@ type mockPreimageOracleContract struct {
 	addCalls             int
 	addFails             bool
 	addData              []byte
+	addStartBlocks       []*big.Int
 	squeezeCalls         int
 	squeezeFails         bool
 	squeezeCallFails     bool
@@ -287,8 +313,9 @@ func (s *mockPreimageOracleContract) InitLargePreimage(_ *big.Int, _ uint32, _ u
 	return txmgr.TxCandidate{}, nil
 }
 
-func (s *mockPreimageOracleContract) AddLeaves(_ *big.Int, _ *big.Int, input []byte, _ []common.Hash, _ bool) (txmgr.TxCandidate, error) {
+func (s *mockPreimageOracleContract) AddLeaves(_ *big.Int, startingBlockIndex *big.Int, input []byte, _ []common.Hash, _ bool) (txmgr.TxCandidate, error) {
 	s.addCalls++
+	s.addStartBlocks = append(s.addStartBlocks, startingBlockIndex)
 	s.addData = append(s.addData, input...)
 	if s.addFails {
 		return txmgr.TxCandidate{}, mockAddLeavesError
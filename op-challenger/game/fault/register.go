@@ -3,27 +3,42 @@ package fault
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/prestates"
 	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/pnl"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 var (
 	cannonGameType   = uint32(0)
+	asteriscGameType = uint32(2)
 	alphabetGameType = uint32(255)
 )
 
+const (
+	cannonPrestatesDir   = "cannon-prestates"
+	asteriscPrestatesDir = "asterisc-prestates"
+)
+
 type CloseFunc func()
 
 type Registry interface {
@@ -31,6 +46,82 @@ type Registry interface {
 	RegisterBondContract(gameType uint32, creator claims.BondContractCreator)
 }
 
+// OutputGameContract is the set of contract bindings required to play an output root based fault dispute game.
+type OutputGameContract interface {
+	GameContract
+	claims.BondContract
+	cannon.L1HeadSource
+	GetBlockRange(ctx context.Context) (prestateBlock uint64, poststateBlock uint64, retErr error)
+	GetSplitDepth(ctx context.Context) (faultTypes.Depth, error)
+	GetAbsolutePrestateHash(ctx context.Context) (common.Hash, error)
+	GetGenesisOutputRoot(ctx context.Context) (common.Hash, error)
+	GetGameDuration(ctx context.Context) (uint64, error)
+}
+
+// ContractCreator creates the contract bindings for a game.
+type ContractCreator func(game types.GameMetadata, caller *batching.MultiCaller) (OutputGameContract, error)
+
+// GameInputs are the values available when creating the trace accessor for a game.
+type GameInputs struct {
+	Game             types.GameMetadata
+	Config           *config.Config
+	Metrics          metrics.Metricer
+	Contract         OutputGameContract
+	RollupClient     outputs.OutputRollupClient
+	PrestateProvider faultTypes.PrestateProvider
+	PrestateBlock    uint64
+	PoststateBlock   uint64
+	Dir              string
+	// Resources limits concurrent trace generation across games. May be nil if not limited.
+	Resources *scheduler.ResourceManager
+}
+
+// TraceAccessorCreator creates the trace accessor used to play a game.
+type TraceAccessorCreator func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, inputs GameInputs) (faultTypes.TraceAccessor, error)
+
+// BottomTraceCreator creates the trace providers used below the split depth of a game that bisects over output roots.
+type BottomTraceCreator func(ctx context.Context, logger log.Logger, inputs GameInputs) (outputs.ProposalTraceProviderCreator, error)
+
+// NewSplitTraceCreator creates a TraceAccessorCreator for games that bisect over output roots down to the game's split
+// depth and over the execution traces from bottomCreator below it. Combined with RegisterCustomGameType this allows
+// playing output root games that use any fault proof VM.
+func NewSplitTraceCreator(metricsLabel string, bottomCreator BottomTraceCreator) TraceAccessorCreator {
+	return func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, inputs GameInputs) (faultTypes.TraceAccessor, error) {
+		splitDepth, err := inputs.Contract.GetSplitDepth(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load split depth: %w", err)
+		}
+		bottom, err := bottomCreator(ctx, logger, inputs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create bottom trace provider: %w", err)
+		}
+		return outputs.NewOutputTraceAccessor(logger, inputs.Metrics, metricsLabel, inputs.PrestateProvider, inputs.RollupClient, splitDepth, inputs.PrestateBlock, inputs.PoststateBlock, bottom), nil
+	}
+}
+
+type customGameType struct {
+	traceCreator    TraceAccessorCreator
+	contractCreator ContractCreator
+}
+
+var (
+	customGameTypesLock sync.Mutex
+	customGameTypes     = make(map[uint32]customGameType)
+)
+
+// RegisterCustomGameType adds support for an additional output root based game type, allowing builds to play custom
+// game types without modifying the challenger. Custom game types are registered with every challenger service
+// created after this call so it is typically called from an init function.
+// Panics if the same game type is registered multiple times, since this indicates a significant programmer error.
+func RegisterCustomGameType(gameType uint32, traceCreator TraceAccessorCreator, contractCreator ContractCreator) {
+	customGameTypesLock.Lock()
+	defer customGameTypesLock.Unlock()
+	if _, ok := customGameTypes[gameType]; ok {
+		panic(fmt.Errorf("duplicate custom game type registered: %v", gameType))
+	}
+	customGameTypes[gameType] = customGameType{traceCreator: traceCreator, contractCreator: contractCreator}
+}
+
 func RegisterGameTypes(
 	registry Registry,
 	ctx context.Context,
@@ -42,8 +133,18 @@ func RegisterGameTypes(
 	txSender types.TxSender,
 	gameFactory *contracts.DisputeGameFactoryContract,
 	caller *batching.MultiCaller,
+	archiver GameArchiver,
+	resources *scheduler.ResourceManager,
+	budget *SpendBudget,
+	resolver responder.ClaimResolver,
+	profits *pnl.Tracker,
 ) (CloseFunc, error) {
-	var closer CloseFunc
+	var closers []CloseFunc
+	closer := func() {
+		for _, c := range closers {
+			c()
+		}
+	}
 	var l2Client *ethclient.Client
 	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
 		l2, err := ethclient.DialContext(ctx, cfg.CannonL2)
@@ -51,87 +152,181 @@ func RegisterGameTypes(
 			return nil, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
 		}
 		l2Client = l2
-		closer = l2Client.Close
+		closers = append(closers, l2Client.Close)
+	}
+	var asteriscL2Client *ethclient.Client
+	if cfg.TraceTypeEnabled(config.TraceTypeAsterisc) {
+		l2, err := ethclient.DialContext(ctx, cfg.AsteriscL2)
+		if err != nil {
+			closer()
+			return nil, fmt.Errorf("dial asterisc l2 client %v: %w", cfg.AsteriscL2, err)
+		}
+		asteriscL2Client = l2
+		closers = append(closers, asteriscL2Client.Close)
 	}
 	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
-		if err := registerCannon(registry, ctx, cl, logger, m, cfg, rollupClient, txSender, gameFactory, caller, l2Client); err != nil {
+		if err := RegisterGameType(registry, ctx, cl, logger, m, cfg, rollupClient, txSender, gameFactory, caller, archiver, resources, budget, resolver, profits, cannonGameType, cannonTraceCreator(l2Client, cannonPrestateSource(logger, cfg)), faultDisputeGameContract); err != nil {
 			return nil, fmt.Errorf("failed to register cannon game type: %w", err)
 		}
 	}
+	if cfg.TraceTypeEnabled(config.TraceTypeAsterisc) {
+		if err := RegisterGameType(registry, ctx, cl, logger, m, cfg, rollupClient, txSender, gameFactory, caller, archiver, resources, budget, resolver, profits, asteriscGameType, asteriscTraceCreator(asteriscL2Client, asteriscPrestateSource(logger, cfg)), faultDisputeGameContract); err != nil {
+			return nil, fmt.Errorf("failed to register asterisc game type: %w", err)
+		}
+	}
 	if cfg.TraceTypeEnabled(config.TraceTypeAlphabet) {
-		if err := registerAlphabet(registry, ctx, cl, logger, m, rollupClient, txSender, gameFactory, caller); err != nil {
+		if err := RegisterGameType(registry, ctx, cl, logger, m, cfg, rollupClient, txSender, gameFactory, caller, archiver, resources, budget, resolver, profits, alphabetGameType, alphabetTraceCreator, faultDisputeGameContract); err != nil {
 			return nil, fmt.Errorf("failed to register alphabet game type: %w", err)
 		}
 	}
+	customGameTypesLock.Lock()
+	defer customGameTypesLock.Unlock()
+	for gameType, custom := range customGameTypes {
+		if err := RegisterGameType(registry, ctx, cl, logger, m, cfg, rollupClient, txSender, gameFactory, caller, archiver, resources, budget, resolver, profits, gameType, custom.traceCreator, custom.contractCreator); err != nil {
+			return nil, fmt.Errorf("failed to register custom game type %v: %w", gameType, err)
+		}
+	}
 	return closer, nil
 }
 
-func registerAlphabet(
-	registry Registry,
-	ctx context.Context,
-	cl faultTypes.ClockReader,
-	logger log.Logger,
-	m metrics.Metricer,
-	rollupClient outputs.OutputRollupClient,
-	txSender types.TxSender,
-	gameFactory *contracts.DisputeGameFactoryContract,
-	caller *batching.MultiCaller,
-) error {
-	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+// lookupGameType returns the trace accessor and contract creators used to play gameType.
+// Returns false if gameType is not supported by cfg.
+func lookupGameType(logger log.Logger, cfg *config.Config, gameType uint32, cannonL2 cannon.L2HeaderSource, asteriscL2 cannon.L2HeaderSource) (TraceAccessorCreator, ContractCreator, bool) {
+	switch {
+	case gameType == cannonGameType && cfg.TraceTypeEnabled(config.TraceTypeCannon):
+		return cannonTraceCreator(cannonL2, cannonPrestateSource(logger, cfg)), faultDisputeGameContract, true
+	case gameType == asteriscGameType && cfg.TraceTypeEnabled(config.TraceTypeAsterisc):
+		return asteriscTraceCreator(asteriscL2, asteriscPrestateSource(logger, cfg)), faultDisputeGameContract, true
+	case gameType == alphabetGameType && cfg.TraceTypeEnabled(config.TraceTypeAlphabet):
+		return alphabetTraceCreator, faultDisputeGameContract, true
+	}
+	customGameTypesLock.Lock()
+	defer customGameTypesLock.Unlock()
+	custom, ok := customGameTypes[gameType]
+	return custom.traceCreator, custom.contractCreator, ok
+}
+
+func faultDisputeGameContract(game types.GameMetadata, caller *batching.MultiCaller) (OutputGameContract, error) {
+	return contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+}
+
+func alphabetTraceCreator(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, inputs GameInputs) (faultTypes.TraceAccessor, error) {
+	splitDepth, err := inputs.Contract.GetSplitDepth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load split depth: %w", err)
+	}
+	return outputs.NewOutputAlphabetTraceAccessor(logger, inputs.Metrics, inputs.PrestateProvider, inputs.RollupClient, splitDepth, inputs.PrestateBlock, inputs.PoststateBlock)
+}
+
+func asteriscTraceCreator(l2Client cannon.L2HeaderSource, prestateSource prestates.PrestateSource) TraceAccessorCreator {
+	return func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, inputs GameInputs) (faultTypes.TraceAccessor, error) {
+		splitDepth, err := inputs.Contract.GetSplitDepth(ctx)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to load split depth: %w", err)
 		}
-		prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
+		limiter, err := gameRunLimiter(ctx, inputs)
 		if err != nil {
 			return nil, err
 		}
-		prestateProvider := outputs.NewPrestateProvider(ctx, logger, rollupClient, prestateBlock)
-		splitDepth, err := contract.GetSplitDepth(ctx)
+		prestatePath, err := gamePrestatePath(ctx, inputs, prestateSource)
 		if err != nil {
 			return nil, err
 		}
-		creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
-			accessor, err := outputs.NewOutputAlphabetTraceAccessor(logger, m, prestateProvider, rollupClient, splitDepth, prestateBlock, poststateBlock)
-			if err != nil {
-				return nil, err
-			}
-			return accessor, nil
-		}
-		prestateValidator := NewPrestateValidator(contract.GetAbsolutePrestateHash, prestateProvider)
-		genesisValidator := NewPrestateValidator(contract.GetGenesisOutputRoot, prestateProvider)
-		return NewGamePlayer(ctx, cl, logger, m, dir, game.Proxy, txSender, contract, []Validator{prestateValidator, genesisValidator}, creator)
+		vmCfg := *inputs.Config
+		vmCfg.AsteriscAbsolutePreState = prestatePath
+		return outputs.NewOutputAsteriscTraceAccessor(logger, inputs.Metrics, &vmCfg, l2Client, inputs.Contract, inputs.PrestateProvider, inputs.RollupClient, inputs.Dir, splitDepth, inputs.PrestateBlock, inputs.PoststateBlock, limiter)
+	}
+}
+
+// gameRunLimiter returns the limiter for VM executions for the game, or nil if executions are not limited.
+// Executions are prioritised for games that will run out of time first.
+func gameRunLimiter(ctx context.Context, inputs GameInputs) (cannon.RunLimiter, error) {
+	if inputs.Resources == nil {
+		return nil, nil
 	}
-	oracle, err := createOracle(ctx, gameFactory, caller, alphabetGameType)
+	duration, err := inputs.Contract.GetGameDuration(ctx)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("failed to load game duration: %w", err)
 	}
-	registry.RegisterGameType(alphabetGameType, playerCreator, oracle)
+	return inputs.Resources.ForGame(time.Unix(int64(inputs.Game.Timestamp+duration), 0)), nil
+}
 
-	contractCreator := func(game types.GameMetadata) (claims.BondContract, error) {
-		return contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+func cannonTraceCreator(l2Client cannon.L2HeaderSource, prestateSource prestates.PrestateSource) TraceAccessorCreator {
+	return func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, inputs GameInputs) (faultTypes.TraceAccessor, error) {
+		splitDepth, err := inputs.Contract.GetSplitDepth(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load split depth: %w", err)
+		}
+		limiter, err := gameRunLimiter(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+		prestatePath, err := gamePrestatePath(ctx, inputs, prestateSource)
+		if err != nil {
+			return nil, err
+		}
+		vmCfg := *inputs.Config
+		vmCfg.CannonAbsolutePreState = prestatePath
+		return outputs.NewOutputCannonTraceAccessor(logger, inputs.Metrics, &vmCfg, l2Client, inputs.Contract, inputs.PrestateProvider, inputs.RollupClient, inputs.Dir, splitDepth, inputs.PrestateBlock, inputs.PoststateBlock, limiter)
 	}
-	registry.RegisterBondContract(alphabetGameType, contractCreator)
-	return nil
 }
 
-func createOracle(ctx context.Context, gameFactory *contracts.DisputeGameFactoryContract, caller *batching.MultiCaller, gameType uint32) (*contracts.PreimageOracleContract, error) {
-	implAddr, err := gameFactory.GetGameImpl(ctx, gameType)
+// gamePrestatePath returns the path to the absolute prestate required by the game.
+func gamePrestatePath(ctx context.Context, inputs GameInputs, prestateSource prestates.PrestateSource) (string, error) {
+	prestateHash, err := inputs.Contract.GetAbsolutePrestateHash(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load implementation for game type %v: %w", gameType, err)
+		return "", fmt.Errorf("failed to load absolute prestate hash: %w", err)
 	}
-	contract, err := contracts.NewFaultDisputeGameContract(implAddr, caller)
+	path, err := prestateSource.PrestatePath(ctx, prestateHash)
 	if err != nil {
-		return nil, err
+		return "", fmt.Errorf("failed to load absolute prestate: %w", err)
 	}
-	oracle, err := contract.GetOracle(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to load oracle address: %w", err)
+	return path, nil
+}
+
+// cannonPrestateSource returns the source of cannon absolute prestates. If prestate URLs are configured the prestate
+// is selected by the hash each game requires, otherwise the configured prestate is used for all games.
+func cannonPrestateSource(logger log.Logger, cfg *config.Config) prestates.PrestateSource {
+	if len(cfg.CannonPrestatesURLs) == 0 {
+		return prestates.NewSinglePrestateSource(cfg.CannonAbsolutePreState)
 	}
-	return oracle, nil
+	return prestates.NewMultiPrestateSource(logger, cfg.CannonPrestatesURLs, filepath.Join(cfg.Datadir, cannonPrestatesDir),
+		func(ctx context.Context, path string) (common.Hash, error) {
+			return cannon.NewPrestateProvider(path).AbsolutePreStateCommitment(ctx)
+		})
+}
+
+// asteriscPrestateSource returns the source of asterisc absolute prestates. If prestate URLs are configured the
+// prestate is selected by the hash each game requires, otherwise the configured prestate is used for all games.
+func asteriscPrestateSource(logger log.Logger, cfg *config.Config) prestates.PrestateSource {
+	if len(cfg.AsteriscPrestatesURLs) == 0 {
+		return prestates.NewSinglePrestateSource(cfg.AsteriscAbsolutePreState)
+	}
+	return prestates.NewMultiPrestateSource(logger, cfg.AsteriscPrestatesURLs, filepath.Join(cfg.Datadir, asteriscPrestatesDir),
+		func(ctx context.Context, path string) (common.Hash, error) {
+			return asterisc.NewPrestateProvider(path).AbsolutePreStateCommitment(ctx)
+		})
+}
+
+// moveFeeConfig returns the fee limits for move and step transactions.
+func moveFeeConfig(cfg *config.Config) (responder.FeeConfig, error) {
+	fees := responder.FeeConfig{UrgencyThreshold: cfg.MoveUrgencyThreshold}
+	if cfg.MaxMoveFeeCapGwei > 0 {
+		maxFeeCap, err := eth.GweiToWei(cfg.MaxMoveFeeCapGwei)
+		if err != nil {
+			return responder.FeeConfig{}, fmt.Errorf("invalid max move fee cap: %w", err)
+		}
+		fees.MaxGasFeeCap = maxFeeCap
+	}
+	return fees, nil
 }
 
-func registerCannon(
+// RegisterGameType registers an output root based game type with the registry. Games are played using the contract
+// bindings from contractCreator and the trace accessor from traceCreator. If archiver is not nil, a record of each
+// game is stored once it is resolved. If budget is not nil, new claims are not posted once the budget is exceeded.
+// If resolver is not nil, claims are resolved via resolver so they can be batched with resolutions from other games.
+// If profits is not nil, the gas and bonds spent playing games are recorded against gameType.
+func RegisterGameType(
 	registry Registry,
 	ctx context.Context,
 	cl faultTypes.ClockReader,
@@ -142,10 +337,24 @@ func registerCannon(
 	txSender types.TxSender,
 	gameFactory *contracts.DisputeGameFactoryContract,
 	caller *batching.MultiCaller,
-	l2Client cannon.L2HeaderSource,
+	archiver GameArchiver,
+	resources *scheduler.ResourceManager,
+	budget *SpendBudget,
+	resolver responder.ClaimResolver,
+	profits *pnl.Tracker,
+	gameType uint32,
+	traceCreator TraceAccessorCreator,
+	contractCreator ContractCreator,
 ) error {
+	if profits != nil {
+		txSender = pnl.NewTxSender(txSender, profits, gameType)
+	}
+	fees, err := moveFeeConfig(cfg)
+	if err != nil {
+		return err
+	}
 	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
-		contract, err := contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+		contract, err := contractCreator(game, caller)
 		if err != nil {
 			return nil, err
 		}
@@ -155,29 +364,48 @@ func registerCannon(
 		}
 		prestateProvider := outputs.NewPrestateProvider(ctx, logger, rollupClient, prestateBlock)
 		creator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, dir string) (faultTypes.TraceAccessor, error) {
-			splitDepth, err := contract.GetSplitDepth(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to load split depth: %w", err)
-			}
-			accessor, err := outputs.NewOutputCannonTraceAccessor(logger, m, cfg, l2Client, contract, prestateProvider, rollupClient, dir, splitDepth, prestateBlock, poststateBlock)
-			if err != nil {
-				return nil, err
-			}
-			return accessor, nil
+			return traceCreator(ctx, logger, gameDepth, GameInputs{
+				Game:             game,
+				Config:           cfg,
+				Metrics:          m,
+				Contract:         contract,
+				RollupClient:     rollupClient,
+				PrestateProvider: prestateProvider,
+				PrestateBlock:    prestateBlock,
+				PoststateBlock:   poststateBlock,
+				Dir:              dir,
+				Resources:        resources,
+			})
 		}
 		prestateValidator := NewPrestateValidator(contract.GetAbsolutePrestateHash, prestateProvider)
 		genesisValidator := NewPrestateValidator(contract.GetGenesisOutputRoot, prestateProvider)
-		return NewGamePlayer(ctx, cl, logger, m, dir, game.Proxy, txSender, contract, []Validator{prestateValidator, genesisValidator}, creator)
+		return NewGamePlayer(ctx, cl, logger, m, dir, game, txSender, contract, []Validator{prestateValidator, genesisValidator}, creator, archiver, cfg.HonestActors, faultTypes.Depth(cfg.MaxPlayDepth), budget, resolver, fees)
 	}
-	oracle, err := createOracle(ctx, gameFactory, caller, cannonGameType)
+	oracle, err := createOracle(ctx, gameFactory, caller, gameType, contractCreator)
 	if err != nil {
 		return err
 	}
-	registry.RegisterGameType(cannonGameType, playerCreator, oracle)
+	registry.RegisterGameType(gameType, playerCreator, oracle)
 
-	contractCreator := func(game types.GameMetadata) (claims.BondContract, error) {
-		return contracts.NewFaultDisputeGameContract(game.Proxy, caller)
+	bondCreator := func(game types.GameMetadata) (claims.BondContract, error) {
+		return contractCreator(game, caller)
 	}
-	registry.RegisterBondContract(cannonGameType, contractCreator)
+	registry.RegisterBondContract(gameType, bondCreator)
 	return nil
 }
+
+func createOracle(ctx context.Context, gameFactory *contracts.DisputeGameFactoryContract, caller *batching.MultiCaller, gameType uint32, contractCreator ContractCreator) (*contracts.PreimageOracleContract, error) {
+	implAddr, err := gameFactory.GetGameImpl(ctx, gameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load implementation for game type %v: %w", gameType, err)
+	}
+	contract, err := contractCreator(types.GameMetadata{GameType: gameType, Proxy: implAddr}, caller)
+	if err != nil {
+		return nil, err
+	}
+	oracle, err := contract.GetOracle(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load oracle address: %w", err)
+	}
+	return oracle, nil
+}
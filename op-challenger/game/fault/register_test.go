@@ -0,0 +1,89 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterCustomGameType(t *testing.T) {
+	gameType := uint32(4242)
+	t.Cleanup(func() {
+		customGameTypesLock.Lock()
+		defer customGameTypesLock.Unlock()
+		delete(customGameTypes, gameType)
+	})
+	traceCreator := func(ctx context.Context, logger log.Logger, gameDepth faultTypes.Depth, inputs GameInputs) (faultTypes.TraceAccessor, error) {
+		return nil, nil
+	}
+	contractCreator := func(game types.GameMetadata, caller *batching.MultiCaller) (OutputGameContract, error) {
+		return nil, nil
+	}
+
+	RegisterCustomGameType(gameType, traceCreator, contractCreator)
+	require.Contains(t, customGameTypes, gameType)
+
+	require.Panics(t, func() {
+		RegisterCustomGameType(gameType, traceCreator, contractCreator)
+	}, "should not allow duplicate registration")
+}
+
+func TestNewSplitTraceCreator(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	bottom := func(ctx context.Context, localContext common.Hash, depth faultTypes.Depth, agreed contracts.Proposal, claimed contracts.Proposal) (faultTypes.TraceProvider, error) {
+		return nil, nil
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		var bottomInputs GameInputs
+		creator := NewSplitTraceCreator("test", func(ctx context.Context, logger log.Logger, inputs GameInputs) (outputs.ProposalTraceProviderCreator, error) {
+			bottomInputs = inputs
+			return bottom, nil
+		})
+		inputs := GameInputs{Metrics: metrics.NoopMetrics, Contract: &stubSplitDepthContract{splitDepth: 4}, PrestateBlock: 10, PoststateBlock: 20}
+		accessor, err := creator(context.Background(), logger, 8, inputs)
+		require.NoError(t, err)
+		require.NotNil(t, accessor)
+		require.Equal(t, inputs, bottomInputs)
+	})
+
+	t.Run("SplitDepthError", func(t *testing.T) {
+		splitErr := errors.New("boom")
+		creator := NewSplitTraceCreator("test", func(ctx context.Context, logger log.Logger, inputs GameInputs) (outputs.ProposalTraceProviderCreator, error) {
+			return bottom, nil
+		})
+		_, err := creator(context.Background(), logger, 8, GameInputs{Contract: &stubSplitDepthContract{err: splitErr}})
+		require.ErrorIs(t, err, splitErr)
+	})
+
+	t.Run("BottomCreatorError", func(t *testing.T) {
+		bottomErr := errors.New("boom")
+		creator := NewSplitTraceCreator("test", func(ctx context.Context, logger log.Logger, inputs GameInputs) (outputs.ProposalTraceProviderCreator, error) {
+			return nil, bottomErr
+		})
+		_, err := creator(context.Background(), logger, 8, GameInputs{Contract: &stubSplitDepthContract{splitDepth: 4}})
+		require.ErrorIs(t, err, bottomErr)
+	})
+}
+
+type stubSplitDepthContract struct {
+	OutputGameContract
+	splitDepth faultTypes.Depth
+	err        error
+}
+
+func (s *stubSplitDepthContract) GetSplitDepth(_ context.Context) (faultTypes.Depth, error) {
+	return s.splitDepth, s.err
+}
@@ -0,0 +1,153 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrUnsupportedGameType = errors.New("unsupported game type")
+	ErrNoClaims            = errors.New("game has no claims")
+)
+
+const replayDirPrefix = "replay-"
+
+// ClaimVerdict compares a claim posted to a game with the value from the locally derived honest trace.
+type ClaimVerdict struct {
+	Claim faultTypes.Claim
+	// HonestValue is the value an honest actor would have posted at the claim's position.
+	HonestValue common.Hash
+	// Honest is true if the claim's value matches HonestValue.
+	Honest bool
+	// Err is set if the honest value could not be derived, in which case Honest and HonestValue are not set.
+	Err error
+}
+
+// ReplayReport is the result of replaying a game against the locally derived honest trace.
+type ReplayReport struct {
+	Game types.GameMetadata
+	// Status is the current status of the game on chain.
+	Status types.GameStatus
+	// ExpectedStatus is the status the game should resolve to if it was played correctly.
+	ExpectedStatus types.GameStatus
+	Claims         []ClaimVerdict
+}
+
+// ResolutionMatches returns true if the game resolved to the honest outcome.
+func (r *ReplayReport) ResolutionMatches() bool {
+	return r.Status == r.ExpectedStatus
+}
+
+type replayLoader interface {
+	ClaimLoader
+	GetStatus(ctx context.Context) (types.GameStatus, error)
+}
+
+// ReplayGame loads all claims from the game at addr and compares them to the honest trace derived locally using the
+// trace provider configured for the game's type. Trace data is stored in a replay specific directory under the
+// configured datadir so it does not interfere with games being actively played.
+func ReplayGame(
+	ctx context.Context,
+	logger log.Logger,
+	m metrics.Metricer,
+	cfg *config.Config,
+	rollupClient outputs.OutputRollupClient,
+	cannonL2 cannon.L2HeaderSource,
+	asteriscL2 cannon.L2HeaderSource,
+	caller *batching.MultiCaller,
+	addr common.Address,
+) (*ReplayReport, error) {
+	fdg, err := contracts.NewFaultDisputeGameContract(addr, caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dispute game bindings: %w", err)
+	}
+	gameType, err := fdg.GetGameType(ctx)
+	if err != nil {
+		return nil, err
+	}
+	traceCreator, contractCreator, ok := lookupGameType(logger, cfg, gameType, cannonL2, asteriscL2)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedGameType, gameType)
+	}
+	game := types.GameMetadata{GameType: gameType, Proxy: addr}
+	contract, err := contractCreator(game, caller)
+	if err != nil {
+		return nil, err
+	}
+	prestateBlock, poststateBlock, err := contract.GetBlockRange(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gameDepth, err := contract.GetMaxGameDepth(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the game depth: %w", err)
+	}
+	accessor, err := traceCreator(ctx, logger, gameDepth, GameInputs{
+		Game:             game,
+		Config:           cfg,
+		Metrics:          m,
+		Contract:         contract,
+		RollupClient:     rollupClient,
+		PrestateProvider: outputs.NewPrestateProvider(ctx, logger, rollupClient, prestateBlock),
+		PrestateBlock:    prestateBlock,
+		PoststateBlock:   poststateBlock,
+		Dir:              filepath.Join(cfg.Datadir, replayDirPrefix+addr.Hex()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace accessor: %w", err)
+	}
+	return replayGame(ctx, game, contract, gameDepth, accessor)
+}
+
+func replayGame(ctx context.Context, game types.GameMetadata, loader replayLoader, gameDepth faultTypes.Depth, accessor faultTypes.TraceAccessor) (*ReplayReport, error) {
+	status, err := loader.GetStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch game status: %w", err)
+	}
+	claims, err := loader.GetAllClaims(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load claims: %w", err)
+	}
+	if len(claims) == 0 {
+		return nil, ErrNoClaims
+	}
+	state := faultTypes.NewGameState(claims, gameDepth)
+	report := &ReplayReport{
+		Game:   game,
+		Status: status,
+	}
+	for _, claim := range claims {
+		verdict := ClaimVerdict{Claim: claim}
+		honestValue, err := accessor.Get(ctx, state, claim, claim.Position)
+		if err != nil {
+			verdict.Err = err
+		} else {
+			verdict.HonestValue = honestValue
+			verdict.Honest = honestValue == claim.Value
+		}
+		report.Claims = append(report.Claims, verdict)
+	}
+	root := report.Claims[0]
+	if root.Err != nil {
+		return nil, fmt.Errorf("failed to derive honest root claim: %w", root.Err)
+	}
+	if root.Honest {
+		report.ExpectedStatus = types.GameStatusDefenderWon
+	} else {
+		report.ExpectedStatus = types.GameStatusChallengerWon
+	}
+	return report, nil
+}
@@ -0,0 +1,99 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayGame(t *testing.T) {
+	maxDepth := faultTypes.Depth(4)
+	builder := test.NewAlphabetClaimBuilder(t, big.NewInt(10), maxDepth)
+	accessor := trace.NewSimpleTraceAccessor(builder.CorrectTraceProvider())
+	game := types.GameMetadata{Proxy: common.Address{0xaa}}
+
+	t.Run("HonestRootDefenderWon", func(t *testing.T) {
+		gameBuilder := builder.GameBuilder(true)
+		gameBuilder.Seq().Attack(common.Hash{0xbb}).AttackCorrect()
+		loader := &stubReplayLoader{status: types.GameStatusDefenderWon, claims: gameBuilder.Game.Claims()}
+		report, err := replayGame(context.Background(), game, loader, maxDepth, accessor)
+		require.NoError(t, err)
+		require.Equal(t, game, report.Game)
+		require.Equal(t, types.GameStatusDefenderWon, report.ExpectedStatus)
+		require.True(t, report.ResolutionMatches())
+		require.Len(t, report.Claims, 3)
+		require.True(t, report.Claims[0].Honest)
+		require.False(t, report.Claims[1].Honest)
+		require.Equal(t, builder.CorrectClaimAtPosition(report.Claims[1].Claim.Position), report.Claims[1].HonestValue)
+		require.True(t, report.Claims[2].Honest)
+	})
+
+	t.Run("DishonestRootDefenderWon", func(t *testing.T) {
+		gameBuilder := builder.GameBuilder(false)
+		gameBuilder.Seq().AttackCorrect()
+		loader := &stubReplayLoader{status: types.GameStatusDefenderWon, claims: gameBuilder.Game.Claims()}
+		report, err := replayGame(context.Background(), game, loader, maxDepth, accessor)
+		require.NoError(t, err)
+		require.Equal(t, types.GameStatusChallengerWon, report.ExpectedStatus)
+		require.False(t, report.ResolutionMatches())
+		require.False(t, report.Claims[0].Honest)
+		require.True(t, report.Claims[1].Honest)
+	})
+
+	t.Run("TraceError", func(t *testing.T) {
+		traceErr := errors.New("boom")
+		gameBuilder := builder.GameBuilder(true)
+		gameBuilder.Seq().AttackCorrect()
+		loader := &stubReplayLoader{status: types.GameStatusDefenderWon, claims: gameBuilder.Game.Claims()}
+		failing := &erroringAccessor{TraceAccessor: accessor, errPos: loader.claims[1].Position, err: traceErr}
+		report, err := replayGame(context.Background(), game, loader, maxDepth, failing)
+		require.NoError(t, err)
+		require.True(t, report.Claims[0].Honest)
+		require.ErrorIs(t, report.Claims[1].Err, traceErr)
+		require.False(t, report.Claims[1].Honest)
+
+		failing.errPos = loader.claims[0].Position
+		_, err = replayGame(context.Background(), game, loader, maxDepth, failing)
+		require.ErrorIs(t, err, traceErr)
+	})
+
+	t.Run("NoClaims", func(t *testing.T) {
+		loader := &stubReplayLoader{status: types.GameStatusInProgress}
+		_, err := replayGame(context.Background(), game, loader, maxDepth, accessor)
+		require.ErrorIs(t, err, ErrNoClaims)
+	})
+}
+
+type stubReplayLoader struct {
+	status types.GameStatus
+	claims []faultTypes.Claim
+}
+
+func (s *stubReplayLoader) GetStatus(_ context.Context) (types.GameStatus, error) {
+	return s.status, nil
+}
+
+func (s *stubReplayLoader) GetAllClaims(_ context.Context) ([]faultTypes.Claim, error) {
+	return s.claims, nil
+}
+
+type erroringAccessor struct {
+	faultTypes.TraceAccessor
+	errPos faultTypes.Position
+	err    error
+}
+
+func (a *erroringAccessor) Get(ctx context.Context, game faultTypes.Game, ref faultTypes.Claim, pos faultTypes.Position) (common.Hash, error) {
+	if pos.ToGIndex().Cmp(a.errPos.ToGIndex()) == 0 {
+		return common.Hash{}, a.err
+	}
+	return a.TraceAccessor.Get(ctx, game, ref, pos)
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -26,29 +28,56 @@ type GameContract interface {
 	GetRequiredBond(ctx context.Context, position types.Position) (*big.Int, error)
 	GetCredit(ctx context.Context, receipient common.Address) (*big.Int, error)
 	ClaimCredit(receipient common.Address) (txmgr.TxCandidate, error)
+	SimulateTx(ctx context.Context, from common.Address, tx txmgr.TxCandidate) error
+}
+
+type Metrics interface {
+	RecordActionSimulationReverted(actionType string)
 }
 
 type Oracle interface {
 	GlobalDataExists(ctx context.Context, data *types.PreimageOracleData) (bool, error)
 }
 
+// ClaimResolver sends resolveClaim transactions, potentially combining them with resolutions from other games.
+// Transactions that are not combined are sent with the supplied sender.
+type ClaimResolver interface {
+	ResolveClaim(tx txmgr.TxCandidate, sender gameTypes.TxSender) error
+}
+
+// FeeConfig controls the fees paid for move and step transactions.
+type FeeConfig struct {
+	// MaxGasFeeCap is the maximum gas fee cap for moves and steps (nil for no limit).
+	MaxGasFeeCap *big.Int
+	// UrgencyThreshold is how long before the chess clock expires that fees are bumped immediately
+	// instead of waiting for the next resubmission (0 to disable).
+	UrgencyThreshold time.Duration
+}
+
 // FaultResponder implements the [Responder] interface to send onchain transactions.
 type FaultResponder struct {
 	log      log.Logger
+	metrics  Metrics
 	sender   gameTypes.TxSender
 	contract GameContract
 	uploader preimages.PreimageUploader
 	oracle   Oracle
+	resolver ClaimResolver
+	fees     FeeConfig
 }
 
 // NewFaultResponder returns a new [FaultResponder].
-func NewFaultResponder(logger log.Logger, sender gameTypes.TxSender, contract GameContract, uploader preimages.PreimageUploader, oracle Oracle) (*FaultResponder, error) {
+// If resolver is not nil, resolveClaim transactions are sent via resolver instead of sender.
+func NewFaultResponder(logger log.Logger, m Metrics, sender gameTypes.TxSender, contract GameContract, uploader preimages.PreimageUploader, oracle Oracle, resolver ClaimResolver, fees FeeConfig) (*FaultResponder, error) {
 	return &FaultResponder{
 		log:      logger,
+		metrics:  m,
 		sender:   sender,
 		contract: contract,
 		uploader: uploader,
 		oracle:   oracle,
+		resolver: resolver,
+		fees:     fees,
 	}, nil
 }
 
@@ -80,6 +109,9 @@ func (r *FaultResponder) ResolveClaim(claimIdx uint64) error {
 	if err != nil {
 		return err
 	}
+	if r.resolver != nil {
+		return r.resolver.ResolveClaim(candidate, r.sender)
+	}
 	return r.sendTxAndWait("resolve claim", candidate)
 }
 
@@ -97,7 +129,8 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 		if !preimageExists {
 			err := r.uploader.UploadPreimage(ctx, uint64(action.ParentIdx), action.OracleData)
 			if errors.Is(err, preimages.ErrChallengePeriodNotOver) {
-				r.log.Debug("Large Preimage Squeeze failed, challenge period not over")
+				// The step will be retried on a later action once the preimage can be squeezed.
+				r.log.Info("Waiting for large preimage challenge period before step", "key", action.OracleData.OracleKey)
 				return nil
 			} else if err != nil {
 				return fmt.Errorf("failed to upload preimage: %w", err)
@@ -128,6 +161,17 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 	if err != nil {
 		return err
 	}
+	candidate.MaxGasFeeCap = r.fees.MaxGasFeeCap
+	if r.fees.UrgencyThreshold > 0 && !action.Deadline.IsZero() {
+		candidate.UrgentAt = action.Deadline.Add(-r.fees.UrgencyThreshold)
+	}
+	// Simulate the action first to avoid spending gas on a transaction that will revert.
+	if err := r.contract.SimulateTx(ctx, r.sender.From(), candidate); errors.Is(err, contracts.ErrSimulationReverted) {
+		r.metrics.RecordActionSimulationReverted(action.Type.String())
+		return fmt.Errorf("not sending %v: %w", action.Type, err)
+	} else if err != nil {
+		r.log.Warn("Failed to simulate action, sending anyway", "type", action.Type, "err", err)
+	}
 	return r.sendTxAndWait("perform action", candidate)
 }
 
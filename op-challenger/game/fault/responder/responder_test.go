@@ -3,9 +3,12 @@ package responder
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -95,6 +98,17 @@ func TestResolveClaim(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 1, mockTxMgr.sends)
 	})
+
+	t.Run("UsesResolver", func(t *testing.T) {
+		responder, mockTxMgr, _, _, _ := newTestFaultResponder(t)
+		resolver := &mockClaimResolver{}
+		responder.resolver = resolver
+		err := responder.ResolveClaim(0)
+		require.NoError(t, err)
+		require.Equal(t, 1, resolver.resolves)
+		require.Same(t, mockTxMgr, resolver.sender, "should supply the game's sender for individual resolutions")
+		require.Equal(t, 0, mockTxMgr.sends)
+	})
 }
 
 // TestRespond tests the [Responder.Respond] method.
@@ -278,17 +292,103 @@ func TestPerformAction(t *testing.T) {
 		require.Equal(t, 0, uploader.updates)
 		require.Equal(t, 1, oracle.existCalls)
 	})
+
+	t.Run("simulationReverts", func(t *testing.T) {
+		responder, mockTxMgr, contract, _, _, m := newTestFaultResponderWithMetrics(t)
+		contract.simulateErr = fmt.Errorf("%w: ClaimAlreadyExists", contracts.ErrSimulationReverted)
+		action := types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  true,
+			Value:     common.Hash{0xaa},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.ErrorIs(t, err, contracts.ErrSimulationReverted)
+		require.Len(t, mockTxMgr.sent, 0)
+		require.Equal(t, 1, contract.simulations)
+		require.Equal(t, 1, m.simulationReverts[types.ActionTypeMove.String()])
+	})
+
+	t.Run("simulationFailsStillSends", func(t *testing.T) {
+		responder, mockTxMgr, contract, _, _, m := newTestFaultResponderWithMetrics(t)
+		contract.simulateErr = errors.New("connection refused")
+		action := types.Action{
+			Type:      types.ActionTypeStep,
+			ParentIdx: 123,
+			IsAttack:  true,
+			PreState:  []byte{1, 2, 3},
+			ProofData: []byte{4, 5, 6},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.NoError(t, err)
+		require.Len(t, mockTxMgr.sent, 1)
+		require.Empty(t, m.simulationReverts)
+	})
+
+	t.Run("moveWithFeeConfig", func(t *testing.T) {
+		responder, mockTxMgr, _, _, _ := newTestFaultResponder(t)
+		responder.fees = FeeConfig{MaxGasFeeCap: big.NewInt(500), UrgencyThreshold: time.Hour}
+		deadline := time.Unix(10_000, 0)
+		action := types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  true,
+			Value:     common.Hash{0xaa},
+			Deadline:  deadline,
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.NoError(t, err)
+
+		require.Len(t, mockTxMgr.sent, 1)
+		require.Equal(t, big.NewInt(500), mockTxMgr.sent[0].MaxGasFeeCap)
+		require.Equal(t, deadline.Add(-time.Hour), mockTxMgr.sent[0].UrgentAt)
+	})
+
+	t.Run("stepWithoutDeadlineIsNotUrgent", func(t *testing.T) {
+		responder, mockTxMgr, _, _, _ := newTestFaultResponder(t)
+		responder.fees = FeeConfig{UrgencyThreshold: time.Hour}
+		action := types.Action{
+			Type:      types.ActionTypeStep,
+			ParentIdx: 123,
+			IsAttack:  true,
+			PreState:  []byte{1, 2, 3},
+			ProofData: []byte{4, 5, 6},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.NoError(t, err)
+
+		require.Len(t, mockTxMgr.sent, 1)
+		require.Nil(t, mockTxMgr.sent[0].MaxGasFeeCap)
+		require.True(t, mockTxMgr.sent[0].UrgentAt.IsZero())
+	})
 }
 
 func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mockContract, *mockPreimageUploader, *mockOracle) {
+	responder, mockTxMgr, contract, uploader, oracle, _ := newTestFaultResponderWithMetrics(t)
+	return responder, mockTxMgr, contract, uploader, oracle
+}
+
+func newTestFaultResponderWithMetrics(t *testing.T) (*FaultResponder, *mockTxManager, *mockContract, *mockPreimageUploader, *mockOracle, *mockMetrics) {
 	log := testlog.Logger(t, log.LvlError)
 	mockTxMgr := &mockTxManager{}
 	contract := &mockContract{}
 	uploader := &mockPreimageUploader{}
 	oracle := &mockOracle{}
-	responder, err := NewFaultResponder(log, mockTxMgr, contract, uploader, oracle)
+	m := &mockMetrics{}
+	responder, err := NewFaultResponder(log, m, mockTxMgr, contract, uploader, oracle, nil, FeeConfig{})
 	require.NoError(t, err)
-	return responder, mockTxMgr, contract, uploader, oracle
+	return responder, mockTxMgr, contract, uploader, oracle, m
+}
+
+type mockClaimResolver struct {
+	resolves int
+	sender   gameTypes.TxSender
+}
+
+func (m *mockClaimResolver) ResolveClaim(_ txmgr.TxCandidate, sender gameTypes.TxSender) error {
+	m.resolves++
+	m.sender = sender
+	return nil
 }
 
 type mockPreimageUploader struct {
@@ -361,6 +461,24 @@ type mockContract struct {
 	stepArgs             []interface{}
 	updateOracleClaimIdx uint64
 	updateOracleArgs     *types.PreimageOracleData
+	simulations          int
+	simulateErr          error
+}
+
+func (m *mockContract) SimulateTx(_ context.Context, _ common.Address, _ txmgr.TxCandidate) error {
+	m.simulations++
+	return m.simulateErr
+}
+
+type mockMetrics struct {
+	simulationReverts map[string]int
+}
+
+func (m *mockMetrics) RecordActionSimulationReverted(actionType string) {
+	if m.simulationReverts == nil {
+		m.simulationReverts = make(map[string]int)
+	}
+	m.simulationReverts[actionType]++
 }
 
 func (m *mockContract) CallResolve(_ context.Context) (gameTypes.GameStatus, error) {
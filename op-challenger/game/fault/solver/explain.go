@@ -0,0 +1,135 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownClaim = errors.New("unknown claim")
+
+type ExplainedAction string
+
+const (
+	ExplainedActionNone   ExplainedAction = "none"
+	ExplainedActionAttack ExplainedAction = "attack"
+	ExplainedActionDefend ExplainedAction = "defend"
+	ExplainedActionStep   ExplainedAction = "step"
+)
+
+// ClaimExplanation describes the reasoning the solver applies to a single claim and the action it would take.
+type ClaimExplanation struct {
+	ClaimIdx   uint64      `json:"claimIdx"`
+	Depth      types.Depth `json:"depth"`
+	TraceIndex *big.Int    `json:"traceIndex"`
+	// ClaimedValue is the state hash claimed on chain
+	ClaimedValue common.Hash `json:"claimedValue"`
+	// LocalValue is the state hash computed by the local trace provider for the claim's position
+	LocalValue common.Hash     `json:"localValue"`
+	Agree      bool            `json:"agree"`
+	Action     ExplainedAction `json:"action"`
+	// IsAttack indicates whether a step attacks or defends the claim. Only set when Action is step.
+	IsAttack bool `json:"isAttack,omitempty"`
+	// Response is the value that would be posted in response. Only set when Action is attack or defend.
+	Response *common.Hash `json:"response,omitempty"`
+	Reason   string       `json:"reason"`
+}
+
+// ExplainClaim calculates the action that would be taken in response to the claim at claimIdx, without
+// performing it, along with the reasoning that led to it.
+func (s *GameSolver) ExplainClaim(ctx context.Context, game types.Game, claimIdx uint64) (ClaimExplanation, error) {
+	claims := game.Claims()
+	if claimIdx >= uint64(len(claims)) {
+		return ClaimExplanation{}, fmt.Errorf("%w: %v", ErrUnknownClaim, claimIdx)
+	}
+	claim := claims[claimIdx]
+	localValue, err := s.claimSolver.trace.Get(ctx, game, claim, claim.Position)
+	if err != nil {
+		return ClaimExplanation{}, fmt.Errorf("failed to get local value for claim %v: %w", claimIdx, err)
+	}
+	explanation := ClaimExplanation{
+		ClaimIdx:     claimIdx,
+		Depth:        claim.Depth(),
+		TraceIndex:   claim.Position.TraceIndex(game.MaxDepth()),
+		ClaimedValue: claim.Value,
+		LocalValue:   localValue,
+		Agree:        localValue == claim.Value,
+		Action:       ExplainedActionNone,
+	}
+
+	agreeWithRootClaim, err := s.AgreeWithRootClaim(ctx, game)
+	if err != nil {
+		return ClaimExplanation{}, fmt.Errorf("failed to determine if root claim is correct: %w", err)
+	}
+	if s.beyondMaxPlayDepth(claim) {
+		explanation.Reason = "response would be deeper than the maximum play depth"
+		return explanation, nil
+	}
+	if claim.Depth() == game.MaxDepth() {
+		return s.explainStep(ctx, game, agreeWithRootClaim, claim, explanation)
+	}
+	return s.explainMove(ctx, game, agreeWithRootClaim, claim, explanation)
+}
+
+func (s *GameSolver) explainStep(ctx context.Context, game types.Game, agreeWithRootClaim bool, claim types.Claim, explanation ClaimExplanation) (ClaimExplanation, error) {
+	if claim.CounteredBy != (common.Address{}) {
+		explanation.Reason = "claim has already been countered by a step"
+		return explanation, nil
+	}
+	if game.AgreeWithClaimLevel(claim, agreeWithRootClaim) {
+		explanation.Reason = "claim is at a depth we support"
+		return explanation, nil
+	}
+	step, err := s.claimSolver.AttemptStep(ctx, game, claim)
+	if errors.Is(err, ErrStepIgnoreInvalidPath) {
+		explanation.Reason = "claim disputes an invalid path"
+		return explanation, nil
+	}
+	if err != nil {
+		return ClaimExplanation{}, err
+	}
+	explanation.Action = ExplainedActionStep
+	explanation.IsAttack = step.IsAttack
+	if step.IsAttack {
+		explanation.Reason = "local state disagrees with the leaf claim, step from the previous trace index"
+	} else {
+		explanation.Reason = "local state agrees with the leaf claim, step from it to the next trace index"
+	}
+	return explanation, nil
+}
+
+func (s *GameSolver) explainMove(ctx context.Context, game types.Game, agreeWithRootClaim bool, claim types.Claim, explanation ClaimExplanation) (ClaimExplanation, error) {
+	if game.AgreeWithClaimLevel(claim, agreeWithRootClaim) {
+		explanation.Reason = "claim is at a depth we support"
+		return explanation, nil
+	}
+	if s.counteredByHonestActor(game, claim) {
+		explanation.Reason = "claim has already been countered by a known honest actor"
+		return explanation, nil
+	}
+	move, err := s.claimSolver.NextMove(ctx, claim, game)
+	if err != nil {
+		return ClaimExplanation{}, fmt.Errorf("failed to calculate next move for claim index %v: %w", claim.ContractIndex, err)
+	}
+	if move == nil {
+		explanation.Reason = "claim responds to an invalid path"
+		return explanation, nil
+	}
+	if game.IsDuplicate(*move) {
+		explanation.Reason = "response has already been posted"
+		return explanation, nil
+	}
+	if game.DefendsParent(*move) {
+		explanation.Action = ExplainedActionDefend
+		explanation.Reason = "local state agrees with the claim, defend at the next trace index"
+	} else {
+		explanation.Action = ExplainedActionAttack
+		explanation.Reason = "local state disagrees with the claim, attack to bisect the trace"
+	}
+	explanation.Response = &move.Value
+	return explanation, nil
+}
@@ -0,0 +1,110 @@
+package solver
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	faulttest "github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainClaim(t *testing.T) {
+	maxDepth := types.Depth(4)
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, big.NewInt(0), maxDepth)
+	newSolver := func() *GameSolver {
+		return NewGameSolver(maxDepth, 0, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()), nil)
+	}
+
+	t.Run("AttackRootClaim", func(t *testing.T) {
+		builder := claimBuilder.GameBuilder(false)
+		game := builder.Game
+		root := game.Claims()[0]
+
+		explanation, err := newSolver().ExplainClaim(context.Background(), game, 0)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), explanation.ClaimIdx)
+		require.Equal(t, types.Depth(0), explanation.Depth)
+		require.Equal(t, root.Position.TraceIndex(maxDepth), explanation.TraceIndex)
+		require.Equal(t, root.Value, explanation.ClaimedValue)
+		require.Equal(t, claimBuilder.CorrectClaimAtPosition(root.Position), explanation.LocalValue)
+		require.False(t, explanation.Agree)
+		require.Equal(t, ExplainedActionAttack, explanation.Action)
+		expected := claimBuilder.CorrectClaimAtPosition(root.Position.Attack())
+		require.Equal(t, &expected, explanation.Response)
+	})
+
+	t.Run("DuplicateMove", func(t *testing.T) {
+		builder := claimBuilder.GameBuilder(false)
+		builder.Seq().AttackCorrect()
+
+		explanation, err := newSolver().ExplainClaim(context.Background(), builder.Game, 0)
+		require.NoError(t, err)
+		require.Equal(t, ExplainedActionNone, explanation.Action)
+		require.Equal(t, "response has already been posted", explanation.Reason)
+
+		explanation, err = newSolver().ExplainClaim(context.Background(), builder.Game, 1)
+		require.NoError(t, err)
+		require.True(t, explanation.Agree)
+		require.Equal(t, ExplainedActionNone, explanation.Action)
+		require.Equal(t, "claim is at a depth we support", explanation.Reason)
+	})
+
+	t.Run("CounteredByHonestActor", func(t *testing.T) {
+		honestActor := common.Address{0xee}
+		builder := claimBuilder.GameBuilder(false)
+		builder.Seq().AttackBy(common.Hash{0xaa}, honestActor)
+
+		solver := NewGameSolver(maxDepth, 0, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()), []common.Address{honestActor})
+		explanation, err := solver.ExplainClaim(context.Background(), builder.Game, 0)
+		require.NoError(t, err)
+		require.Equal(t, ExplainedActionNone, explanation.Action)
+		require.Equal(t, "claim has already been countered by a known honest actor", explanation.Reason)
+	})
+
+	t.Run("BeyondMaxPlayDepth", func(t *testing.T) {
+		builder := claimBuilder.GameBuilder(false)
+		builder.Seq().AttackCorrect().Attack(common.Hash{0xaa})
+
+		solver := NewGameSolver(maxDepth, 2, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()), nil)
+		explanation, err := solver.ExplainClaim(context.Background(), builder.Game, 2)
+		require.NoError(t, err)
+		require.False(t, explanation.Agree)
+		require.Equal(t, ExplainedActionNone, explanation.Action)
+		require.Equal(t, "response would be deeper than the maximum play depth", explanation.Reason)
+	})
+
+	t.Run("Step", func(t *testing.T) {
+		builder := claimBuilder.GameBuilder(false)
+		lastHonestClaim := builder.Seq().
+			AttackCorrect().
+			AttackCorrect().
+			DefendCorrect()
+		lastHonestClaim.AttackCorrect()
+		lastHonestClaim.Attack(common.Hash{0xdd})
+		solver := newSolver()
+
+		explanation, err := solver.ExplainClaim(context.Background(), builder.Game, 4)
+		require.NoError(t, err)
+		require.True(t, explanation.Agree)
+		require.Equal(t, ExplainedActionStep, explanation.Action)
+		require.False(t, explanation.IsAttack)
+		require.Nil(t, explanation.Response)
+
+		explanation, err = solver.ExplainClaim(context.Background(), builder.Game, 5)
+		require.NoError(t, err)
+		require.False(t, explanation.Agree)
+		require.Equal(t, common.Hash{0xdd}, explanation.ClaimedValue)
+		require.Equal(t, ExplainedActionStep, explanation.Action)
+		require.True(t, explanation.IsAttack)
+	})
+
+	t.Run("UnknownClaim", func(t *testing.T) {
+		builder := claimBuilder.GameBuilder(false)
+		_, err := newSolver().ExplainClaim(context.Background(), builder.Game, 1)
+		require.ErrorIs(t, err, ErrUnknownClaim)
+	})
+}
@@ -4,18 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 type GameSolver struct {
-	claimSolver *claimSolver
+	claimSolver  *claimSolver
+	honestActors []common.Address
+	maxPlayDepth types.Depth
 }
 
-func NewGameSolver(gameDepth types.Depth, trace types.TraceAccessor) *GameSolver {
+// NewGameSolver creates a solver that calculates the honest actions for a game.
+// Claims that have already been countered by one of honestActors are not countered again to avoid wasting bonds.
+// Counters posted by any other address are not trusted, so the solver still responds to those claims itself.
+// If maxPlayDepth is non-zero, no claims are posted deeper than maxPlayDepth. Claims that would require a deeper
+// response are left for an operator to counter and can be found with UnplayedClaims.
+func NewGameSolver(gameDepth types.Depth, maxPlayDepth types.Depth, trace types.TraceAccessor, honestActors []common.Address) *GameSolver {
 	return &GameSolver{
-		claimSolver: newClaimSolver(gameDepth, trace),
+		claimSolver:  newClaimSolver(gameDepth, trace),
+		honestActors: honestActors,
+		maxPlayDepth: maxPlayDepth,
 	}
 }
 
@@ -31,6 +41,9 @@ func (s *GameSolver) CalculateNextActions(ctx context.Context, game types.Game)
 	var errs []error
 	var actions []types.Action
 	for _, claim := range game.Claims() {
+		if s.beyondMaxPlayDepth(claim) {
+			continue
+		}
 		var action *types.Action
 		var err error
 		if claim.Depth() == game.MaxDepth() {
@@ -50,6 +63,57 @@ func (s *GameSolver) CalculateNextActions(ctx context.Context, game types.Game)
 	return actions, errors.Join(errs...)
 }
 
+// UnplayedClaims returns the claims that may require a response but are not played because the response would be
+// deeper than the maximum play depth. Claims that have already been countered are not included.
+func (s *GameSolver) UnplayedClaims(ctx context.Context, game types.Game) ([]types.Claim, error) {
+	if s.maxPlayDepth == 0 {
+		return nil, nil
+	}
+	agreeWithRootClaim, err := s.AgreeWithRootClaim(ctx, game)
+	if err != nil {
+		return nil, fmt.Errorf("failed to determine if root claim is correct: %w", err)
+	}
+	var unplayed []types.Claim
+	for _, claim := range game.Claims() {
+		if !s.beyondMaxPlayDepth(claim) || game.AgreeWithClaimLevel(claim, agreeWithRootClaim) {
+			continue
+		}
+		if claim.Depth() == game.MaxDepth() && claim.CounteredBy != (common.Address{}) {
+			continue
+		}
+		if s.counteredByHonestActor(game, claim) {
+			continue
+		}
+		unplayed = append(unplayed, claim)
+	}
+	return unplayed, nil
+}
+
+// InvalidClaims returns the claims posted by claimant that disagree with the local trace.
+// An honest challenger only posts claims it agrees with, so any such claim indicates that another instance using the
+// same claimant address is running with a different configuration or trace provider.
+func (s *GameSolver) InvalidClaims(ctx context.Context, game types.Game, claimant common.Address) ([]types.Claim, error) {
+	var invalid []types.Claim
+	for _, claim := range game.Claims() {
+		if claim.IsRoot() || claim.Claimant != claimant {
+			continue
+		}
+		agree, err := s.claimSolver.agreeWithClaim(ctx, game, claim)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check claim %v: %w", claim.ContractIndex, err)
+		}
+		if !agree {
+			invalid = append(invalid, claim)
+		}
+	}
+	return invalid, nil
+}
+
+// beyondMaxPlayDepth returns true if responding to claim would require playing deeper than the maximum play depth.
+func (s *GameSolver) beyondMaxPlayDepth(claim types.Claim) bool {
+	return s.maxPlayDepth != 0 && claim.Depth() >= s.maxPlayDepth
+}
+
 func (s *GameSolver) calculateStep(ctx context.Context, game types.Game, agreeWithRootClaim bool, claim types.Claim) (*types.Action, error) {
 	if claim.CounteredBy != (common.Address{}) {
 		return nil, nil
@@ -79,6 +143,9 @@ func (s *GameSolver) calculateMove(ctx context.Context, game types.Game, agreeWi
 	if game.AgreeWithClaimLevel(claim, agreeWithRootClaim) {
 		return nil, nil
 	}
+	if s.counteredByHonestActor(game, claim) {
+		return nil, nil
+	}
 	move, err := s.claimSolver.NextMove(ctx, claim, game)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate next move for claim index %v: %w", claim.ContractIndex, err)
@@ -94,3 +161,19 @@ func (s *GameSolver) calculateMove(ctx context.Context, game types.Game, agreeWi
 		Value:          move.Value,
 	}, nil
 }
+
+// counteredByHonestActor returns true if one of the known honest actors has already posted a claim countering claim.
+func (s *GameSolver) counteredByHonestActor(game types.Game, claim types.Claim) bool {
+	if len(s.honestActors) == 0 {
+		return false
+	}
+	for _, other := range game.Claims() {
+		if other.ContractIndex == claim.ContractIndex || other.ParentContractIndex != claim.ContractIndex {
+			continue
+		}
+		if slices.Contains(s.honestActors, other.Claimant) {
+			return true
+		}
+	}
+	return false
+}
@@ -18,9 +18,12 @@ func TestCalculateNextActions(t *testing.T) {
 	startingL2BlockNumber := big.NewInt(0)
 	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, startingL2BlockNumber, maxDepth)
 
+	honestActor := common.Address{0xee}
 	tests := []struct {
 		name             string
 		rootClaimCorrect bool
+		honestActors     []common.Address
+		maxPlayDepth     types.Depth
 		setupGame        func(builder *faulttest.GameBuilder)
 	}{
 		{
@@ -55,6 +58,26 @@ func TestCalculateNextActions(t *testing.T) {
 				honestClaim.Defend(common.Hash{0xdd}).ExpectAttack()
 			},
 		},
+		{
+			name:         "DoNotCounterClaimsCounteredByHonestActor",
+			honestActors: []common.Address{honestActor},
+			setupGame: func(builder *faulttest.GameBuilder) {
+				honestClaim := builder.Seq().AttackCorrect()
+				honestClaim.Attack(common.Hash{0xaa}).AttackBy(common.Hash{0xbb}, honestActor)
+				honestClaim.Defend(common.Hash{0xcc}).DefendBy(common.Hash{0xdd}, honestActor)
+			},
+		},
+		{
+			name:         "CounterClaimsCounteredByFreeloader",
+			honestActors: []common.Address{honestActor},
+			setupGame: func(builder *faulttest.GameBuilder) {
+				honestClaim := builder.Seq().AttackCorrect()
+				dishonestClaim := honestClaim.Attack(common.Hash{0xaa})
+				dishonestClaim.ExpectAttack()
+				// Counter posted by an unknown address is not trusted
+				dishonestClaim.AttackBy(common.Hash{0xbb}, common.Address{0xff})
+			},
+		},
 		{
 			name: "StepAtMaxDepth",
 			setupGame: func(builder *faulttest.GameBuilder) {
@@ -66,6 +89,31 @@ func TestCalculateNextActions(t *testing.T) {
 				lastHonestClaim.Attack(common.Hash{0xdd}).ExpectStepAttack()
 			},
 		},
+		{
+			name:         "MoveAboveMaxPlayDepth",
+			maxPlayDepth: 2,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				builder.Seq().ExpectAttack()
+			},
+		},
+		{
+			name:         "DoNotMoveBeyondMaxPlayDepth",
+			maxPlayDepth: 2,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				builder.Seq().AttackCorrect().Attack(common.Hash{0xaa})
+			},
+		},
+		{
+			name:         "DoNotStepBeyondMaxPlayDepth",
+			maxPlayDepth: 3,
+			setupGame: func(builder *faulttest.GameBuilder) {
+				builder.Seq().
+					AttackCorrect().
+					AttackCorrect().
+					DefendCorrect().
+					Attack(common.Hash{0xdd})
+			},
+		},
 		{
 			name: "PoisonedPreState",
 			setupGame: func(builder *faulttest.GameBuilder) {
@@ -97,7 +145,7 @@ func TestCalculateNextActions(t *testing.T) {
 					i, claim.Position.ToGIndex(), claim.Position.TraceIndex(maxDepth), claim.ParentContractIndex, claim.CounteredBy, claim.Value)
 			}
 
-			solver := NewGameSolver(maxDepth, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()))
+			solver := NewGameSolver(maxDepth, test.maxPlayDepth, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()), test.honestActors)
 			actions, err := solver.CalculateNextActions(context.Background(), game)
 			require.NoError(t, err)
 			for i, action := range actions {
@@ -115,3 +163,58 @@ func TestCalculateNextActions(t *testing.T) {
 		})
 	}
 }
+
+func TestUnplayedClaims(t *testing.T) {
+	maxDepth := types.Depth(4)
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, big.NewInt(0), maxDepth)
+	honestActor := common.Address{0xee}
+	newSolver := func(maxPlayDepth types.Depth) *GameSolver {
+		return NewGameSolver(maxDepth, maxPlayDepth, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()), []common.Address{honestActor})
+	}
+
+	t.Run("NoneWhenUnlimited", func(t *testing.T) {
+		builder := claimBuilder.GameBuilder(false)
+		builder.Seq().AttackCorrect().Attack(common.Hash{0xaa})
+		claims, err := newSolver(0).UnplayedClaims(context.Background(), builder.Game)
+		require.NoError(t, err)
+		require.Empty(t, claims)
+	})
+
+	t.Run("IncludeUncounteredClaimsBeyondMaxPlayDepth", func(t *testing.T) {
+		builder := claimBuilder.GameBuilder(false)
+		honestClaim := builder.Seq().AttackCorrect()
+		honestClaim.Attack(common.Hash{0xaa})
+		honestClaim.Defend(common.Hash{0xbb}).AttackBy(common.Hash{0xcc}, honestActor)
+		claims, err := newSolver(2).UnplayedClaims(context.Background(), builder.Game)
+		require.NoError(t, err)
+		require.Len(t, claims, 1)
+		require.Equal(t, common.Hash{0xaa}, claims[0].Value)
+	})
+
+	t.Run("ExcludeClaimsAtAgreeingLevel", func(t *testing.T) {
+		builder := claimBuilder.GameBuilder(false)
+		builder.Seq().AttackCorrect().Attack(common.Hash{0xaa}).AttackCorrect()
+		claims, err := newSolver(2).UnplayedClaims(context.Background(), builder.Game)
+		require.NoError(t, err)
+		require.Len(t, claims, 1)
+		require.Equal(t, common.Hash{0xaa}, claims[0].Value)
+	})
+}
+
+func TestInvalidClaims(t *testing.T) {
+	maxDepth := types.Depth(4)
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, big.NewInt(0), maxDepth)
+	self := common.Address{0xaa}
+	solver := NewGameSolver(maxDepth, 0, trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()), nil)
+
+	builder := claimBuilder.GameBuilder(false)
+	honestClaim := builder.Seq().AttackBy(claimBuilder.CorrectClaimAtPosition(types.NewPosition(1, big.NewInt(0))), self)
+	honestClaim.AttackBy(common.Hash{0xbb}, self)
+	honestClaim.Attack(common.Hash{0xcc})
+
+	claims, err := solver.InvalidClaims(context.Background(), builder.Game, self)
+	require.NoError(t, err)
+	require.Len(t, claims, 1)
+	require.Equal(t, common.Hash{0xbb}, claims[0].Value)
+	require.Equal(t, self, claims[0].Claimant)
+}
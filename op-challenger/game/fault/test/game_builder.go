@@ -86,6 +86,30 @@ func (s *GameBuilderSeq) Defend(value common.Hash) *GameBuilderSeq {
 	}
 }
 
+// AttackBy posts an attack with the specified value on behalf of claimant.
+func (s *GameBuilderSeq) AttackBy(value common.Hash, claimant common.Address) *GameBuilderSeq {
+	claim := s.builder.AttackClaimWithValue(s.lastClaim, value)
+	claim.Claimant = claimant
+	s.addClaimToGame(&claim)
+	return &GameBuilderSeq{
+		gameBuilder: s.gameBuilder,
+		builder:     s.builder,
+		lastClaim:   claim,
+	}
+}
+
+// DefendBy posts a defense with the specified value on behalf of claimant.
+func (s *GameBuilderSeq) DefendBy(value common.Hash, claimant common.Address) *GameBuilderSeq {
+	claim := s.builder.DefendClaimWithValue(s.lastClaim, value)
+	claim.Claimant = claimant
+	s.addClaimToGame(&claim)
+	return &GameBuilderSeq{
+		gameBuilder: s.gameBuilder,
+		builder:     s.builder,
+		lastClaim:   claim,
+	}
+}
+
 func (s *GameBuilderSeq) ExpectAttack() *GameBuilderSeq {
 	newPos := s.lastClaim.Position.Attack()
 	value := s.builder.CorrectClaimAtPosition(newPos)
@@ -0,0 +1,151 @@
+package asterisc
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	snapsDir     = "snapshots"
+	preimagesDir = "preimages"
+	finalState   = "final.json.gz"
+)
+
+type snapshotSelect func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error)
+type cmdExecutor func(ctx context.Context, l log.Logger, binary string, args ...string) error
+
+type Executor struct {
+	logger           log.Logger
+	metrics          AsteriscMetricer
+	l1               string
+	l2               string
+	inputs           cannon.LocalGameInputs
+	asterisc         string
+	server           string
+	network          string
+	rollupConfig     string
+	l2Genesis        string
+	absolutePreState string
+	snapshotFreq     uint
+	infoFreq         uint
+	selectSnapshot   snapshotSelect
+	cmdExecutor      cmdExecutor
+	limiter          cannon.RunLimiter
+}
+
+// NewExecutor creates an executor to run asterisc for a game. If limiter is nil, executions are not limited.
+func NewExecutor(logger log.Logger, m AsteriscMetricer, cfg *config.Config, inputs cannon.LocalGameInputs, limiter cannon.RunLimiter) *Executor {
+	return &Executor{
+		logger:           logger,
+		metrics:          m,
+		l1:               cfg.L1EthRpc,
+		l2:               cfg.AsteriscL2,
+		inputs:           inputs,
+		asterisc:         cfg.AsteriscBin,
+		server:           cfg.AsteriscServer,
+		network:          cfg.AsteriscNetwork,
+		rollupConfig:     cfg.AsteriscRollupConfigPath,
+		l2Genesis:        cfg.AsteriscL2GenesisPath,
+		absolutePreState: cfg.AsteriscAbsolutePreState,
+		snapshotFreq:     cfg.AsteriscSnapshotFreq,
+		infoFreq:         cfg.AsteriscInfoFreq,
+		selectSnapshot:   cannon.FindStartingSnapshot,
+		cmdExecutor:      runCmd,
+		limiter:          limiter,
+	}
+}
+
+// GenerateProof executes asterisc to generate a proof at the specified trace index.
+// The proof is stored at the specified directory.
+// Execution starts from the closest available snapshot before the trace index.
+func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) error {
+	snapshotDir := filepath.Join(dir, snapsDir)
+	start, err := e.selectSnapshot(e.logger, snapshotDir, e.absolutePreState, i)
+	if err != nil {
+		return fmt.Errorf("find starting snapshot: %w", err)
+	}
+	proofDir := filepath.Join(dir, proofsDir)
+	dataDir := filepath.Join(dir, preimagesDir)
+	lastGeneratedState := filepath.Join(dir, finalState)
+	args := []string{
+		"run",
+		"--input", start,
+		"--output", lastGeneratedState,
+		"--meta", "",
+		"--info-at", "%" + strconv.FormatUint(uint64(e.infoFreq), 10),
+		"--proof-at", "=" + strconv.FormatUint(i, 10),
+		"--proof-fmt", filepath.Join(proofDir, "%d.json.gz"),
+		"--snapshot-at", "%" + strconv.FormatUint(uint64(e.snapshotFreq), 10),
+		"--snapshot-fmt", filepath.Join(snapshotDir, "%d.json.gz"),
+	}
+	if i < math.MaxUint64 {
+		args = append(args, "--stop-at", "="+strconv.FormatUint(i+1, 10))
+	}
+	args = append(args,
+		"--",
+		e.server, "--server",
+		"--l1", e.l1,
+		"--l2", e.l2,
+		"--datadir", dataDir,
+		"--l1.head", e.inputs.L1Head.Hex(),
+		"--l2.head", e.inputs.L2Head.Hex(),
+		"--l2.outputroot", e.inputs.L2OutputRoot.Hex(),
+		"--l2.claim", e.inputs.L2Claim.Hex(),
+		"--l2.blocknumber", e.inputs.L2BlockNumber.Text(10),
+	)
+	if e.network != "" {
+		args = append(args, "--network", e.network)
+	}
+	if e.rollupConfig != "" {
+		args = append(args, "--rollup.config", e.rollupConfig)
+	}
+	if e.l2Genesis != "" {
+		args = append(args, "--l2.genesis", e.l2Genesis)
+	}
+
+	if err := os.MkdirAll(snapshotDir, 0755); err != nil {
+		return fmt.Errorf("could not create snapshot directory %v: %w", snapshotDir, err)
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return fmt.Errorf("could not create preimage cache directory %v: %w", dataDir, err)
+	}
+	if err := os.MkdirAll(proofDir, 0755); err != nil {
+		return fmt.Errorf("could not create proofs directory %v: %w", proofDir, err)
+	}
+	if e.limiter != nil {
+		release, err := e.limiter.Acquire(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to acquire resources to run asterisc: %w", err)
+		}
+		defer release()
+	}
+	e.logger.Info("Generating trace", "proof", i, "cmd", e.asterisc, "args", strings.Join(args, ", "))
+	execStart := time.Now()
+	err = e.cmdExecutor(ctx, e.logger.New("proof", i), e.asterisc, args...)
+	e.metrics.RecordAsteriscExecutionTime(time.Since(execStart).Seconds())
+	return err
+}
+
+func runCmd(ctx context.Context, l log.Logger, binary string, args ...string) error {
+	cmd := exec.CommandContext(ctx, binary, args...)
+	stdOut := oplog.NewWriter(l, log.LvlInfo)
+	defer stdOut.Close()
+	// Keep stdErr at info level because asterisc uses stderr for progress messages
+	stdErr := oplog.NewWriter(l, log.LvlInfo)
+	defer stdErr.Close()
+	cmd.Stdout = stdOut
+	cmd.Stderr = stdErr
+	return cmd.Run()
+}
@@ -0,0 +1,27 @@
+package asterisc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ types.PrestateProvider = (*AsteriscPrestateProvider)(nil)
+
+type AsteriscPrestateProvider struct {
+	prestate string
+}
+
+func NewPrestateProvider(prestate string) *AsteriscPrestateProvider {
+	return &AsteriscPrestateProvider{prestate}
+}
+
+func (p *AsteriscPrestateProvider) AbsolutePreStateCommitment(_ context.Context) (common.Hash, error) {
+	state, err := parseState(p.prestate)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("cannot load absolute pre-state: %w", err)
+	}
+	return state.StateHash, nil
+}
@@ -0,0 +1,32 @@
+package asterisc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsolutePreStateCommitment(t *testing.T) {
+	t.Run("StateUnavailable", func(t *testing.T) {
+		provider := NewPrestateProvider("/dir/does/not/exist/state.json")
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("InvalidStateFile", func(t *testing.T) {
+		provider := NewPrestateProvider(filepath.Join("test_data", "invalid.json"))
+		_, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.ErrorContains(t, err, "invalid asterisc state")
+	})
+
+	t.Run("ExpectedAbsolutePreState", func(t *testing.T) {
+		provider := NewPrestateProvider(filepath.Join("test_data", "state.json"))
+		actual, err := provider.AbsolutePreStateCommitment(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, common.HexToHash("0x03ababababababababababababababababababababababababababababababab"), actual)
+	})
+}
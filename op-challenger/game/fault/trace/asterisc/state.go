@@ -0,0 +1,56 @@
+package asterisc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// asteriscWitnessLen is the length of the encoded asterisc state witness.
+const asteriscWitnessLen = 362
+
+// VMState is the subset of the asterisc state that is required by the trace provider.
+// The state file contains additional fields specific to the VM implementation which are ignored.
+type VMState struct {
+	PC        uint64        `json:"pc"`
+	Exited    bool          `json:"exited"`
+	Step      uint64        `json:"step"`
+	Witness   hexutil.Bytes `json:"witness"`
+	StateHash common.Hash   `json:"stateHash"`
+}
+
+// validate checks that the witness has the expected length and that the VM status encoded in the first byte of the
+// state hash is consistent with the exited flag.
+func (s *VMState) validate() error {
+	if len(s.Witness) != asteriscWitnessLen {
+		return fmt.Errorf("invalid witness: expected length %v but got %v", asteriscWitnessLen, len(s.Witness))
+	}
+	status := s.StateHash[0]
+	if status > mipsevm.VMStatusUnfinished {
+		return fmt.Errorf("invalid state hash: unknown VM status %v", status)
+	}
+	if s.Exited == (status == mipsevm.VMStatusUnfinished) {
+		return fmt.Errorf("invalid state hash: VM status %v does not match exited %v", status, s.Exited)
+	}
+	return nil
+}
+
+func parseState(path string) (*VMState, error) {
+	file, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open state file (%v): %w", path, err)
+	}
+	defer file.Close()
+	var state VMState
+	if err := json.NewDecoder(file).Decode(&state); err != nil {
+		return nil, fmt.Errorf("invalid asterisc state (%v): %w", path, err)
+	}
+	if err := state.validate(); err != nil {
+		return nil, fmt.Errorf("invalid asterisc state (%v): %w", path, err)
+	}
+	return &state, nil
+}
@@ -0,0 +1,68 @@
+package asterisc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseState(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		state, err := parseState(filepath.Join("test_data", "state.json"))
+		require.NoError(t, err)
+		require.False(t, state.Exited)
+		require.Len(t, state.Witness, asteriscWitnessLen)
+		require.Equal(t, common.HexToHash("0x03ababababababababababababababababababababababababababababababab"), state.StateHash)
+	})
+
+	t.Run("InvalidWitness", func(t *testing.T) {
+		_, err := parseState(filepath.Join("test_data", "invalid.json"))
+		require.ErrorContains(t, err, "invalid witness")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := parseState(filepath.Join(t.TempDir(), "state.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestValidateState(t *testing.T) {
+	tests := []struct {
+		name   string
+		exited bool
+		status byte
+		valid  bool
+	}{
+		{name: "Unfinished", exited: false, status: 3, valid: true},
+		{name: "ExitedValid", exited: true, status: 0, valid: true},
+		{name: "ExitedInvalid", exited: true, status: 1, valid: true},
+		{name: "ExitedPanic", exited: true, status: 2, valid: true},
+		{name: "UnfinishedButExited", exited: true, status: 3, valid: false},
+		{name: "ExitedButNotMarkedExited", exited: false, status: 0, valid: false},
+		{name: "UnknownStatus", exited: true, status: 4, valid: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			state := VMState{
+				Exited:    test.exited,
+				Witness:   make([]byte, asteriscWitnessLen),
+				StateHash: common.Hash{test.status},
+			}
+			path := filepath.Join(t.TempDir(), "state.json")
+			data, err := json.Marshal(state)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(path, data, 0o644))
+			_, err = parseState(path)
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, "invalid state hash")
+			}
+		})
+	}
+}
@@ -2,18 +2,22 @@ package cannon
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -26,9 +30,19 @@ const (
 
 var snapshotNameRegexp = regexp.MustCompile(`^[0-9]+\.json.gz$`)
 
+// snapshotIndexFile is the file in the snapshot dir recording the snapshots that have been verified,
+// so each snapshot is only decompressed in full once.
+const snapshotIndexFile = "verified.json"
+
 type snapshotSelect func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error)
 type cmdExecutor func(ctx context.Context, l log.Logger, binary string, args ...string) error
 
+// RunLimiter limits the number of cannon executions that run concurrently.
+type RunLimiter interface {
+	// Acquire blocks until cannon may be executed, returning a function to call when execution completes.
+	Acquire(ctx context.Context) (func(), error)
+}
+
 type Executor struct {
 	logger           log.Logger
 	metrics          CannonMetricer
@@ -45,9 +59,11 @@ type Executor struct {
 	infoFreq         uint
 	selectSnapshot   snapshotSelect
 	cmdExecutor      cmdExecutor
+	limiter          RunLimiter
 }
 
-func NewExecutor(logger log.Logger, m CannonMetricer, cfg *config.Config, inputs LocalGameInputs) *Executor {
+// NewExecutor creates an executor to run cannon for a game. If limiter is nil, executions are not limited.
+func NewExecutor(logger log.Logger, m CannonMetricer, cfg *config.Config, inputs LocalGameInputs, limiter RunLimiter) *Executor {
 	return &Executor{
 		logger:           logger,
 		metrics:          m,
@@ -62,8 +78,9 @@ func NewExecutor(logger log.Logger, m CannonMetricer, cfg *config.Config, inputs
 		absolutePreState: cfg.CannonAbsolutePreState,
 		snapshotFreq:     cfg.CannonSnapshotFreq,
 		infoFreq:         cfg.CannonInfoFreq,
-		selectSnapshot:   findStartingSnapshot,
+		selectSnapshot:   FindStartingSnapshot,
 		cmdExecutor:      runCmd,
+		limiter:          limiter,
 	}
 }
 
@@ -82,6 +99,9 @@ func (e *Executor) generateProof(ctx context.Context, dir string, begin uint64,
 	if err != nil {
 		return fmt.Errorf("find starting snapshot: %w", err)
 	}
+	if start != e.absolutePreState {
+		e.logger.Info("Resuming trace generation from snapshot", "snapshot", start, "proof", end)
+	}
 	proofDir := filepath.Join(dir, proofsDir)
 	dataDir := filepath.Join(dir, preimagesDir)
 	lastGeneratedState := filepath.Join(dir, finalState)
@@ -131,6 +151,13 @@ func (e *Executor) generateProof(ctx context.Context, dir string, begin uint64,
 	if err := os.MkdirAll(proofDir, 0755); err != nil {
 		return fmt.Errorf("could not create proofs directory %v: %w", proofDir, err)
 	}
+	if e.limiter != nil {
+		release, err := e.limiter.Acquire(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to acquire resources to run cannon: %w", err)
+		}
+		defer release()
+	}
 	e.logger.Info("Generating trace", "proof", end, "cmd", e.cannon, "args", strings.Join(args, ", "))
 	execStart := time.Now()
 	err = e.cmdExecutor(ctx, e.logger.New("proof", end), e.cannon, args...)
@@ -150,9 +177,12 @@ func runCmd(ctx context.Context, l log.Logger, binary string, args ...string) er
 	return cmd.Run()
 }
 
-// findStartingSnapshot finds the closest snapshot before the specified traceIndex in snapDir.
+// FindStartingSnapshot finds the closest snapshot before the specified traceIndex in snapDir.
+// Snapshots persist across restarts so trace generation resumes from the closest snapshot rather than re-executing
+// from the absolute pre-state. Snapshots that cannot be read, e.g. because they were corrupted by a crash, are removed.
+// Verified snapshots are recorded in an index in snapDir so they are not verified again unless they change.
 // If no suitable snapshot can be found it returns absolutePreState.
-func findStartingSnapshot(logger log.Logger, snapDir string, absolutePreState string, traceIndex uint64) (string, error) {
+func FindStartingSnapshot(logger log.Logger, snapDir string, absolutePreState string, traceIndex uint64) (string, error) {
 	// Find the closest snapshot to start from
 	entries, err := os.ReadDir(snapDir)
 	if err != nil {
@@ -161,13 +191,16 @@ func findStartingSnapshot(logger log.Logger, snapDir string, absolutePreState st
 		}
 		return "", fmt.Errorf("list snapshots in %v: %w", snapDir, err)
 	}
-	bestSnap := uint64(0)
+	var candidates []uint64
 	for _, entry := range entries {
 		if entry.IsDir() {
 			logger.Warn("Unexpected directory in snapshots dir", "parent", snapDir, "child", entry.Name())
 			continue
 		}
 		name := entry.Name()
+		if name == snapshotIndexFile {
+			continue
+		}
 		if !snapshotNameRegexp.MatchString(name) {
 			logger.Warn("Unexpected file in snapshots dir", "parent", snapDir, "child", entry.Name())
 			continue
@@ -177,14 +210,82 @@ func findStartingSnapshot(logger log.Logger, snapDir string, absolutePreState st
 			logger.Error("Unable to parse trace index of snapshot file", "parent", snapDir, "child", entry.Name())
 			continue
 		}
-		if index > bestSnap && index < traceIndex {
-			bestSnap = index
+		if index > 0 && index < traceIndex {
+			candidates = append(candidates, index)
 		}
 	}
-	if bestSnap == 0 {
-		return absolutePreState, nil
+	slices.Sort(candidates)
+	index := loadSnapshotIndex(logger, snapDir)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		name := fmt.Sprintf("%v.json.gz", candidates[i])
+		startFrom := filepath.Join(snapDir, name)
+		info, err := os.Stat(startFrom)
+		if err != nil {
+			return "", fmt.Errorf("failed to stat snapshot %v: %w", startFrom, err)
+		}
+		if entry, ok := index[name]; ok && entry.matches(info) {
+			return startFrom, nil
+		}
+		if err := checkSnapshot(startFrom); err != nil {
+			logger.Warn("Removing invalid snapshot", "snapshot", startFrom, "err", err)
+			if err := os.Remove(startFrom); err != nil {
+				return "", fmt.Errorf("failed to remove invalid snapshot %v: %w", startFrom, err)
+			}
+			delete(index, name)
+			continue
+		}
+		index[name] = snapshotIndexEntry{Size: info.Size(), ModTime: info.ModTime().UnixNano()}
+		if err := storeSnapshotIndex(snapDir, index); err != nil {
+			// The snapshot is still valid, it will just be verified again next time.
+			logger.Warn("Failed to store snapshot index", "dir", snapDir, "err", err)
+		}
+		return startFrom, nil
 	}
-	startFrom := fmt.Sprintf("%v/%v.json.gz", snapDir, bestSnap)
+	return absolutePreState, nil
+}
+
+// snapshotIndexEntry identifies the version of a snapshot file that was verified.
+type snapshotIndexEntry struct {
+	Size    int64 `json:"size"`
+	ModTime int64 `json:"modTime"`
+}
+
+func (e snapshotIndexEntry) matches(info os.FileInfo) bool {
+	return e.Size == info.Size() && e.ModTime == info.ModTime().UnixNano()
+}
+
+// loadSnapshotIndex loads the index of verified snapshots in snapDir.
+// A missing or unreadable index is treated as empty so all snapshots are verified again.
+func loadSnapshotIndex(logger log.Logger, snapDir string) map[string]snapshotIndexEntry {
+	index := make(map[string]snapshotIndexEntry)
+	data, err := os.ReadFile(filepath.Join(snapDir, snapshotIndexFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return index
+	} else if err != nil {
+		logger.Warn("Failed to read snapshot index", "dir", snapDir, "err", err)
+		return index
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		logger.Warn("Failed to parse snapshot index", "dir", snapDir, "err", err)
+		return make(map[string]snapshotIndexEntry)
+	}
+	return index
+}
+
+// storeSnapshotIndex atomically writes the index of verified snapshots to snapDir.
+func storeSnapshotIndex(snapDir string, index map[string]snapshotIndexEntry) error {
+	return ioutil.WriteAtomicJSON(filepath.Join(snapDir, snapshotIndexFile), index, 0o644)
+}
 
-	return startFrom, nil
+// checkSnapshot verifies that the snapshot file can be fully read, detecting truncated or corrupted files.
+func checkSnapshot(path string) error {
+	file, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := io.Copy(io.Discard, file); err != nil {
+		return err
+	}
+	return nil
 }
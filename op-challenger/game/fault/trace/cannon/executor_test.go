@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -41,7 +42,7 @@ func TestGenerateProof(t *testing.T) {
 	}
 	captureExec := func(t *testing.T, cfg config.Config, proofAt uint64) (string, string, map[string]string) {
 		m := &cannonDurationMetrics{}
-		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), m, &cfg, inputs)
+		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), m, &cfg, inputs, nil)
 		executor.selectSnapshot = func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error) {
 			return input, nil
 		}
@@ -128,6 +129,55 @@ func TestGenerateProof(t *testing.T) {
 	})
 }
 
+func TestGenerateProofLimited(t *testing.T) {
+	tempDir := t.TempDir()
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", tempDir, config.TraceTypeCannon)
+	newExecutor := func(limiter RunLimiter) (*Executor, *bool) {
+		executor := NewExecutor(testlog.Logger(t, log.LvlInfo), &cannonDurationMetrics{}, &cfg, LocalGameInputs{L2BlockNumber: big.NewInt(1)}, limiter)
+		executor.selectSnapshot = func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error) {
+			return "starting.json", nil
+		}
+		executed := false
+		executor.cmdExecutor = func(ctx context.Context, l log.Logger, b string, a ...string) error {
+			require.Equal(t, 1, limiter.(*stubRunLimiter).active, "should hold resources while executing")
+			executed = true
+			return nil
+		}
+		return executor, &executed
+	}
+
+	t.Run("ReleaseAfterExecution", func(t *testing.T) {
+		limiter := &stubRunLimiter{}
+		executor, executed := newExecutor(limiter)
+		require.NoError(t, executor.GenerateProof(context.Background(), tempDir, 10))
+		require.True(t, *executed)
+		require.Equal(t, 1, limiter.acquired)
+		require.Equal(t, 0, limiter.active)
+	})
+
+	t.Run("DoNotExecuteWhenAcquireFails", func(t *testing.T) {
+		limiter := &stubRunLimiter{err: context.Canceled}
+		executor, executed := newExecutor(limiter)
+		require.ErrorIs(t, executor.GenerateProof(context.Background(), tempDir, 10), context.Canceled)
+		require.False(t, *executed)
+	})
+}
+
+type stubRunLimiter struct {
+	err      error
+	acquired int
+	active   int
+}
+
+func (s *stubRunLimiter) Acquire(_ context.Context) (func(), error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.acquired++
+	s.active++
+	return func() { s.active-- }, nil
+}
+
 func TestRunCmdLogsOutput(t *testing.T) {
 	bin := "/bin/echo"
 	if _, err := os.Stat(bin); err != nil {
@@ -148,32 +198,36 @@ func TestFindStartingSnapshot(t *testing.T) {
 	withSnapshots := func(t *testing.T, files ...string) string {
 		dir := t.TempDir()
 		for _, file := range files {
-			require.NoError(t, os.WriteFile(fmt.Sprintf("%v/%v", dir, file), nil, 0o644))
+			out, err := ioutil.OpenCompressed(fmt.Sprintf("%v/%v", dir, file), os.O_WRONLY|os.O_CREATE, 0o644)
+			require.NoError(t, err)
+			_, err = out.Write([]byte("{}"))
+			require.NoError(t, err)
+			require.NoError(t, out.Close())
 		}
 		return dir
 	}
 
 	t.Run("UsePrestateWhenSnapshotsDirDoesNotExist", func(t *testing.T) {
 		dir := t.TempDir()
-		snapshot, err := findStartingSnapshot(logger, filepath.Join(dir, "doesNotExist"), execTestCannonPrestate, 1200)
+		snapshot, err := FindStartingSnapshot(logger, filepath.Join(dir, "doesNotExist"), execTestCannonPrestate, 1200)
 		require.NoError(t, err)
 		require.Equal(t, execTestCannonPrestate, snapshot)
 	})
 
 	t.Run("UsePrestateWhenSnapshotsDirEmpty", func(t *testing.T) {
 		dir := withSnapshots(t)
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 1200)
+		snapshot, err := FindStartingSnapshot(logger, dir, execTestCannonPrestate, 1200)
 		require.NoError(t, err)
 		require.Equal(t, execTestCannonPrestate, snapshot)
 	})
 
 	t.Run("UsePrestateWhenNoSnapshotBeforeTraceIndex", func(t *testing.T) {
 		dir := withSnapshots(t, "100.json", "200.json")
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 99)
+		snapshot, err := FindStartingSnapshot(logger, dir, execTestCannonPrestate, 99)
 		require.NoError(t, err)
 		require.Equal(t, execTestCannonPrestate, snapshot)
 
-		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 100)
+		snapshot, err = FindStartingSnapshot(logger, dir, execTestCannonPrestate, 100)
 		require.NoError(t, err)
 		require.Equal(t, execTestCannonPrestate, snapshot)
 	})
@@ -181,19 +235,19 @@ func TestFindStartingSnapshot(t *testing.T) {
 	t.Run("UseClosestAvailableSnapshot", func(t *testing.T) {
 		dir := withSnapshots(t, "100.json.gz", "123.json.gz", "250.json.gz")
 
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 101)
+		snapshot, err := FindStartingSnapshot(logger, dir, execTestCannonPrestate, 101)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
 
-		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 123)
+		snapshot, err = FindStartingSnapshot(logger, dir, execTestCannonPrestate, 123)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
 
-		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 124)
+		snapshot, err = FindStartingSnapshot(logger, dir, execTestCannonPrestate, 124)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(dir, "123.json.gz"), snapshot)
 
-		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 256)
+		snapshot, err = FindStartingSnapshot(logger, dir, execTestCannonPrestate, 256)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(dir, "250.json.gz"), snapshot)
 	})
@@ -201,16 +255,64 @@ func TestFindStartingSnapshot(t *testing.T) {
 	t.Run("IgnoreDirectories", func(t *testing.T) {
 		dir := withSnapshots(t, "100.json.gz")
 		require.NoError(t, os.Mkdir(filepath.Join(dir, "120.json.gz"), 0o777))
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
+		snapshot, err := FindStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
 	})
 
 	t.Run("IgnoreUnexpectedFiles", func(t *testing.T) {
 		dir := withSnapshots(t, ".file", "100.json.gz", "foo", "bar.json.gz")
-		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
+		snapshot, err := FindStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
+	})
+
+	t.Run("SkipAndRemoveInvalidSnapshots", func(t *testing.T) {
+		dir := withSnapshots(t, "100.json.gz", "200.json.gz")
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "300.json.gz"), []byte("truncated"), 0o644))
+		valid, err := os.ReadFile(filepath.Join(dir, "200.json.gz"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "200.json.gz"), valid[:len(valid)-4], 0o644))
+
+		snapshot, err := FindStartingSnapshot(logger, dir, execTestCannonPrestate, 350)
 		require.NoError(t, err)
 		require.Equal(t, filepath.Join(dir, "100.json.gz"), snapshot)
+		require.NoFileExists(t, filepath.Join(dir, "300.json.gz"))
+		require.NoFileExists(t, filepath.Join(dir, "200.json.gz"))
+	})
+
+	t.Run("UsePrestateWhenAllSnapshotsInvalid", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "100.json.gz"), nil, 0o644))
+		snapshot, err := FindStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
+		require.NoError(t, err)
+		require.Equal(t, execTestCannonPrestate, snapshot)
+	})
+
+	t.Run("VerifySnapshotOnlyOnce", func(t *testing.T) {
+		dir := withSnapshots(t, "100.json.gz")
+		path := filepath.Join(dir, "100.json.gz")
+		snapshot, err := FindStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
+		require.NoError(t, err)
+		require.Equal(t, path, snapshot)
+		require.FileExists(t, filepath.Join(dir, snapshotIndexFile))
+
+		// Corrupt the snapshot without changing its size or modification time.
+		// The index records it as verified so it is not decompressed again.
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, make([]byte, info.Size()), 0o644))
+		require.NoError(t, os.Chtimes(path, info.ModTime(), info.ModTime()))
+		snapshot, err = FindStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
+		require.NoError(t, err)
+		require.Equal(t, path, snapshot)
+
+		// Once the snapshot changes, it is verified again.
+		require.NoError(t, os.WriteFile(path, []byte("truncated"), 0o644))
+		snapshot, err = FindStartingSnapshot(logger, dir, execTestCannonPrestate, 150)
+		require.NoError(t, err)
+		require.Equal(t, execTestCannonPrestate, snapshot)
+		require.NoFileExists(t, path)
 	})
 }
 
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
@@ -24,6 +25,12 @@ import (
 const (
 	proofsDir      = "proofs"
 	diskStateCache = "state.json.gz"
+
+	// proofCacheSize is the number of proofs kept in memory by each provider.
+	// Bisection requests each position in the execution trace game at most a few times and a game can't be deeper
+	// than 256 so this comfortably covers all positions requested while playing a game.
+	proofCacheSize = 256
+	proofCacheName = "cannon_proofs"
 )
 
 type proofData struct {
@@ -39,6 +46,11 @@ type CannonMetricer interface {
 	RecordCannonExecutionTime(t float64)
 }
 
+type ProviderMetricer interface {
+	CannonMetricer
+	caching.Metrics
+}
+
 type ProofGenerator interface {
 	// GenerateProof executes cannon to generate a proof at the specified trace index in dataDir.
 	GenerateProof(ctx context.Context, dataDir string, proofAt uint64) error
@@ -51,21 +63,32 @@ type CannonTraceProvider struct {
 	generator ProofGenerator
 	gameDepth types.Depth
 
+	// proofs caches proofs already loaded from disk, keyed by trace index.
+	// Proofs are stored on disk once generated so cannon doesn't need to be run again, even after a restart.
+	proofs *caching.LRUCache[uint64, *proofData]
+
 	// lastStep stores the last step in the actual trace if known. 0 indicates unknown.
 	// Cached as an optimisation to avoid repeatedly attempting to execute beyond the end of the trace.
 	lastStep uint64
 }
 
-func NewTraceProvider(logger log.Logger, m CannonMetricer, cfg *config.Config, localInputs LocalGameInputs, dir string, gameDepth types.Depth) *CannonTraceProvider {
+func NewTraceProvider(logger log.Logger, m ProviderMetricer, cfg *config.Config, localInputs LocalGameInputs, dir string, gameDepth types.Depth, limiter RunLimiter) *CannonTraceProvider {
 	return &CannonTraceProvider{
 		logger:    logger,
 		dir:       dir,
 		prestate:  cfg.CannonAbsolutePreState,
-		generator: NewExecutor(logger, m, cfg, localInputs),
+		generator: NewExecutor(logger, m, cfg, localInputs, limiter),
 		gameDepth: gameDepth,
+		proofs:    newProofCache(m),
 	}
 }
 
+// newProofCache creates the proof cache of a provider.
+// All providers report under the same metrics label, as a label per game would add series that are never removed.
+func newProofCache(m caching.Metrics) *caching.LRUCache[uint64, *proofData] {
+	return caching.NewLRUCache[uint64, *proofData](m, proofCacheName, proofCacheSize)
+}
+
 func (p *CannonTraceProvider) SetMaxDepth(gameDepth types.Depth) {
 	p.gameDepth = gameDepth
 }
@@ -147,6 +170,19 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*proofDa
 	if p.lastStep != 0 && i > p.lastStep {
 		i = p.lastStep
 	}
+	if proof, ok := p.proofs.Get(i); ok {
+		return proof, nil
+	}
+	proof, err := p.readOrGenerateProof(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+	p.proofs.Add(i, proof)
+	return proof, nil
+}
+
+// readOrGenerateProof reads the proof at the specified index from disk, executing cannon to generate it if required.
+func (p *CannonTraceProvider) readOrGenerateProof(ctx context.Context, i uint64) (*proofData, error) {
 	path := filepath.Join(p.dir, proofsDir, fmt.Sprintf("%d.json.gz", i))
 	file, err := ioutil.OpenDecompressed(path)
 	if errors.Is(err, os.ErrNotExist) {
@@ -264,13 +300,14 @@ func PreimageLargerThan(size int) PreimageOpt {
 	}
 }
 
-func NewTraceProviderForTest(logger log.Logger, m CannonMetricer, cfg *config.Config, localInputs LocalGameInputs, dir string, gameDepth types.Depth) *CannonTraceProviderForTest {
+func NewTraceProviderForTest(logger log.Logger, m ProviderMetricer, cfg *config.Config, localInputs LocalGameInputs, dir string, gameDepth types.Depth) *CannonTraceProviderForTest {
 	p := &CannonTraceProvider{
 		logger:    logger,
 		dir:       dir,
 		prestate:  cfg.CannonAbsolutePreState,
-		generator: NewExecutor(logger, m, cfg, localInputs),
+		generator: NewExecutor(logger, m, cfg, localInputs, nil),
 		gameDepth: gameDepth,
+		proofs:    newProofCache(m),
 	}
 	return &CannonTraceProviderForTest{p}
 }
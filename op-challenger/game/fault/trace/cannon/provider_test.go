@@ -38,6 +38,29 @@ func TestGet(t *testing.T) {
 		require.Empty(t, generator.generated)
 	})
 
+	t.Run("CachesLoadedProofs", func(t *testing.T) {
+		dataDir, prestate := setupTestData(t)
+		provider, generator := setupWithTestData(t, dataDir, prestate)
+		pos := PositionFromTraceIndex(provider, common.Big0)
+		expected, err := provider.Get(context.Background(), pos)
+		require.NoError(t, err)
+
+		// Proof should be served from memory without reading from disk or regenerating it
+		require.NoError(t, os.Remove(filepath.Join(dataDir, proofsDir, "0.json.gz")))
+		value, err := provider.Get(context.Background(), pos)
+		require.NoError(t, err)
+		require.Equal(t, expected, value)
+		require.Empty(t, generator.generated)
+	})
+
+	t.Run("ProofCacheMetricsUseFixedLabel", func(t *testing.T) {
+		m := &stubCacheMetrics{sizes: make(map[string]int)}
+		newProofCache(m).Add(0, &proofData{})
+		newProofCache(m).Add(0, &proofData{})
+		require.Len(t, m.sizes, 1, "should not add a metrics label per provider")
+		require.Contains(t, m.sizes, proofCacheName)
+	})
+
 	t.Run("ErrorsTraceIndexOutOfBounds", func(t *testing.T) {
 		provider, generator := setupWithTestData(t, dataDir, prestate)
 		largePosition := PositionFromTraceIndex(provider, new(big.Int).Mul(new(big.Int).SetUint64(math.MaxUint64), big.NewInt(2)))
@@ -240,9 +263,20 @@ func setupWithTestData(t *testing.T, dataDir string, prestate string) (*CannonTr
 		generator: generator,
 		prestate:  filepath.Join(dataDir, prestate),
 		gameDepth: 63,
+		proofs:    newProofCache(nil),
 	}, generator
 }
 
+type stubCacheMetrics struct {
+	sizes map[string]int
+}
+
+func (s *stubCacheMetrics) CacheAdd(label string, cacheSize int, _ bool) {
+	s.sizes[label] = cacheSize
+}
+
+func (s *stubCacheMetrics) CacheGet(string, bool) {}
+
 type stubGenerator struct {
 	generated  []int // Using int makes assertions easier
 	finalState *mipsevm.State
@@ -0,0 +1,44 @@
+package outputs
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/asterisc"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func NewOutputAsteriscTraceAccessor(
+	logger log.Logger,
+	m metrics.Metricer,
+	cfg *config.Config,
+	l2Client cannon.L2HeaderSource,
+	contract cannon.L1HeadSource,
+	prestateProvider types.PrestateProvider,
+	rollupClient OutputRollupClient,
+	dir string,
+	splitDepth types.Depth,
+	prestateBlock uint64,
+	poststateBlock uint64,
+	limiter cannon.RunLimiter,
+) (*trace.Accessor, error) {
+	asteriscCreator := func(ctx context.Context, localContext common.Hash, depth types.Depth, agreed contracts.Proposal, claimed contracts.Proposal) (types.TraceProvider, error) {
+		logger := logger.New("pre", agreed.OutputRoot, "post", claimed.OutputRoot, "localContext", localContext)
+		subdir := filepath.Join(dir, localContext.Hex())
+		localInputs, err := cannon.FetchLocalInputsFromProposals(ctx, contract, l2Client, agreed, claimed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch asterisc local inputs: %w", err)
+		}
+		provider := asterisc.NewTraceProvider(logger, m, cfg, localInputs, subdir, depth, limiter)
+		return provider, nil
+	}
+	return NewOutputTraceAccessor(logger, m, "output_asterisc_provider", prestateProvider, rollupClient, splitDepth, prestateBlock, poststateBlock, asteriscCreator), nil
+}
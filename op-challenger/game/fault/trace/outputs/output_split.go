@@ -0,0 +1,29 @@
+package outputs
+
+import (
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// NewOutputTraceAccessor creates a trace accessor for games that bisect over output roots down to splitDepth and
+// over the execution trace from bottomCreator below it. Bottom providers are cached by their local context and
+// the cache metrics are reported using metricsLabel.
+func NewOutputTraceAccessor(
+	logger log.Logger,
+	m metrics.Metricer,
+	metricsLabel string,
+	prestateProvider types.PrestateProvider,
+	rollupClient OutputRollupClient,
+	splitDepth types.Depth,
+	prestateBlock uint64,
+	poststateBlock uint64,
+	bottomCreator ProposalTraceProviderCreator,
+) *trace.Accessor {
+	outputProvider := NewTraceProviderFromInputs(logger, prestateProvider, rollupClient, splitDepth, prestateBlock, poststateBlock)
+	cache := NewProviderCache(m, metricsLabel, bottomCreator)
+	selector := split.NewSplitProviderSelector(outputProvider, splitDepth, OutputRootSplitAdapter(outputProvider, cache.GetOrCreate))
+	return trace.NewAccessor(selector)
+}
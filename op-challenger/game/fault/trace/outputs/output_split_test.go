@@ -0,0 +1,49 @@
+package outputs
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputTraceAccessor(t *testing.T) {
+	prestateBlock := uint64(20)
+	poststateBlock := uint64(40)
+	splitDepth := types.Depth(2)
+	rollupClient := &stubRollupClient{outputs: make(map[uint64]*eth.OutputResponse)}
+	for i := prestateBlock; i <= poststateBlock; i++ {
+		rollupClient.outputs[i] = &eth.OutputResponse{OutputRoot: eth.Bytes32{byte(i)}}
+	}
+	prestateProvider := &stubPrestateProvider{absolutePrestate: common.Hash{byte(prestateBlock)}}
+	creator := &capturingCreator{}
+	accessor := NewOutputTraceAccessor(testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics, "test", prestateProvider, rollupClient, splitDepth, prestateBlock, poststateBlock, creator.Create)
+
+	claims := []types.Claim{
+		{ClaimData: types.ClaimData{Value: common.Hash{0xaa}, Position: types.NewPosition(0, big.NewInt(0))}, ContractIndex: 0},
+		{ClaimData: types.ClaimData{Value: common.Hash{0xbb}, Position: types.NewPosition(1, big.NewInt(0))}, ContractIndex: 1, ParentContractIndex: 0},
+		{ClaimData: types.ClaimData{Value: common.Hash{0xcc}, Position: types.NewPosition(2, big.NewInt(0))}, ContractIndex: 2, ParentContractIndex: 1},
+	}
+	game := types.NewGameState(claims, 4)
+
+	t.Run("UseOutputRootsAboveSplitDepth", func(t *testing.T) {
+		value, err := accessor.Get(context.Background(), game, claims[2], types.NewPosition(1, big.NewInt(0)))
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{22}, value)
+	})
+
+	t.Run("UseBottomProviderBelowSplitDepth", func(t *testing.T) {
+		_, err := accessor.Get(context.Background(), game, claims[2], types.NewPosition(3, big.NewInt(0)))
+		require.ErrorIs(t, err, creatorError)
+		require.Equal(t, big.NewInt(int64(prestateBlock)), creator.agreed.L2BlockNumber)
+		require.Equal(t, big.NewInt(int64(prestateBlock+1)), creator.claimed.L2BlockNumber)
+		require.Equal(t, claims[2].Value, creator.claimed.OutputRoot)
+	})
+}
@@ -0,0 +1,132 @@
+package prestates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrPrestateUnavailable = errors.New("prestate unavailable")
+	ErrPrestateMismatch    = errors.New("downloaded prestate does not match expected hash")
+)
+
+// PrestateSource provides the path to the absolute prestate file with a given hash.
+type PrestateSource interface {
+	PrestatePath(ctx context.Context, hash common.Hash) (string, error)
+}
+
+// StateHasher computes the hash of the prestate stored at path.
+type StateHasher func(ctx context.Context, path string) (common.Hash, error)
+
+// SinglePrestateSource always uses the same prestate file regardless of the hash the game expects.
+type SinglePrestateSource struct {
+	path string
+}
+
+func NewSinglePrestateSource(path string) *SinglePrestateSource {
+	return &SinglePrestateSource{path: path}
+}
+
+func (s *SinglePrestateSource) PrestatePath(_ context.Context, _ common.Hash) (string, error) {
+	return s.path, nil
+}
+
+// MultiPrestateSource selects the prestate by hash, downloading it from the first of baseUrls that has a prestate
+// matching the hash. Prestates are fetched from <baseUrl>/<hash>.json and cached in dataDir once verified.
+type MultiPrestateSource struct {
+	logger   log.Logger
+	baseUrls []*url.URL
+	dataDir  string
+	hasher   StateHasher
+	client   *http.Client
+
+	lock sync.Mutex
+}
+
+func NewMultiPrestateSource(logger log.Logger, baseUrls []*url.URL, dataDir string, hasher StateHasher) *MultiPrestateSource {
+	return &MultiPrestateSource{
+		logger:   logger,
+		baseUrls: baseUrls,
+		dataDir:  dataDir,
+		hasher:   hasher,
+		client:   http.DefaultClient,
+	}
+}
+
+func (m *MultiPrestateSource) PrestatePath(ctx context.Context, hash common.Hash) (string, error) {
+	// Serialise downloads so concurrent games requiring the same prestate only fetch it once.
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	path := filepath.Join(m.dataDir, prestateFilename(hash))
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to check for cached prestate %v: %w", hash, err)
+	}
+	if err := os.MkdirAll(m.dataDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create prestate dir: %w", err)
+	}
+	var errs []error
+	for _, baseUrl := range m.baseUrls {
+		err := m.fetch(ctx, baseUrl.JoinPath(prestateFilename(hash)), hash, path)
+		if err == nil {
+			m.logger.Info("Downloaded prestate", "hash", hash, "url", baseUrl)
+			return path, nil
+		}
+		errs = append(errs, err)
+	}
+	return "", fmt.Errorf("%w: %v: %w", ErrPrestateUnavailable, hash, errors.Join(errs...))
+}
+
+// fetch downloads the prestate from src to a temporary file and moves it to dest only if its hash matches.
+func (m *MultiPrestateSource) fetch(ctx context.Context, src *url.URL, hash common.Hash, dest string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, src.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request for %v: %w", src, err)
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch prestate from %v: %w", src, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch prestate from %v: status %v", src, resp.StatusCode)
+	}
+	tmp, err := os.CreateTemp(m.dataDir, "download-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary prestate file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to download prestate from %v: %w", src, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write prestate from %v: %w", src, err)
+	}
+	actual, err := m.hasher(ctx, tmp.Name())
+	if err != nil {
+		return fmt.Errorf("failed to hash prestate from %v: %w", src, err)
+	}
+	if actual != hash {
+		return fmt.Errorf("%w: %v expected %v but was %v", ErrPrestateMismatch, src, hash, actual)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return fmt.Errorf("failed to store prestate: %w", err)
+	}
+	return nil
+}
+
+func prestateFilename(hash common.Hash) string {
+	return hash.Hex() + ".json"
+}
@@ -0,0 +1,103 @@
+package prestates
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSinglePrestateSource(t *testing.T) {
+	source := NewSinglePrestateSource("/foo/prestate.json")
+	path, err := source.PrestatePath(context.Background(), common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, "/foo/prestate.json", path)
+}
+
+func TestMultiPrestateSource(t *testing.T) {
+	content := []byte("prestate")
+	hash := crypto.Keccak256Hash(content)
+
+	t.Run("DownloadAndCache", func(t *testing.T) {
+		server, requests := newPrestateServer(t, map[string][]byte{"/" + prestateFilename(hash): content})
+		source, dir := newTestSource(t, server.URL)
+		path, err := source.PrestatePath(context.Background(), hash)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, prestateFilename(hash)), path)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, content, data)
+
+		_, err = source.PrestatePath(context.Background(), hash)
+		require.NoError(t, err)
+		require.Equal(t, 1, *requests, "should use cached prestate")
+	})
+
+	t.Run("TryNextUrl", func(t *testing.T) {
+		missing, _ := newPrestateServer(t, map[string][]byte{})
+		server, _ := newPrestateServer(t, map[string][]byte{"/prestates/" + prestateFilename(hash): content})
+		source, _ := newTestSource(t, missing.URL, server.URL+"/prestates")
+		_, err := source.PrestatePath(context.Background(), hash)
+		require.NoError(t, err)
+	})
+
+	t.Run("RejectMismatchedHash", func(t *testing.T) {
+		server, _ := newPrestateServer(t, map[string][]byte{"/" + prestateFilename(hash): []byte("wrong")})
+		source, dir := newTestSource(t, server.URL)
+		_, err := source.PrestatePath(context.Background(), hash)
+		require.ErrorIs(t, err, ErrPrestateUnavailable)
+		require.ErrorIs(t, err, ErrPrestateMismatch)
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries, "should not keep unverified prestate")
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		server, _ := newPrestateServer(t, map[string][]byte{})
+		source, _ := newTestSource(t, server.URL)
+		_, err := source.PrestatePath(context.Background(), hash)
+		require.ErrorIs(t, err, ErrPrestateUnavailable)
+	})
+}
+
+func newTestSource(t *testing.T, urls ...string) (*MultiPrestateSource, string) {
+	var baseUrls []*url.URL
+	for _, u := range urls {
+		parsed, err := url.Parse(u)
+		require.NoError(t, err)
+		baseUrls = append(baseUrls, parsed)
+	}
+	dir := t.TempDir()
+	hasher := func(_ context.Context, path string) (common.Hash, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return crypto.Keccak256Hash(data), nil
+	}
+	return NewMultiPrestateSource(testlog.Logger(t, log.LvlInfo), baseUrls, dir, hasher), dir
+}
+
+func newPrestateServer(t *testing.T, files map[string][]byte) (*httptest.Server, *int) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		data, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
@@ -1,6 +1,10 @@
 package types
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type ActionType string
 
@@ -18,6 +22,8 @@ type Action struct {
 	ParentIdx      int
 	ParentPosition Position
 	IsAttack       bool
+	// Deadline is when the chess clock for responding to the parent claim expires (zero if unknown).
+	Deadline time.Time
 
 	// Moves
 	Value common.Hash
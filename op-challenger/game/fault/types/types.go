@@ -122,13 +122,46 @@ type Claim struct {
 	//       to be changed/removed to avoid invalid/stale contract state.
 	CounteredBy common.Address
 	Claimant    common.Address
-	Clock       uint64
+	Clock       Clock
 	// Location of the claim & it's parent inside the contract. Does not exist
 	// for claims that have not made it to the contract.
 	ContractIndex       int
 	ParentContractIndex int
 }
 
+// Clock is the chess clock of a claim.
+type Clock struct {
+	// Duration is the total time used by the claimant's team, up to and including this claim.
+	Duration time.Duration
+	// Timestamp is the time the claim was made.
+	Timestamp time.Time
+}
+
+// NewClock creates a Clock from a duration and timestamp in seconds, as stored onchain.
+func NewClock(duration uint64, timestamp uint64) Clock {
+	return Clock{
+		Duration:  time.Duration(duration) * time.Second,
+		Timestamp: time.Unix(int64(timestamp), 0),
+	}
+}
+
+// ChessClockRemaining returns the time remaining for the opposing team to respond to a claim at now.
+// The responding team's clock resumes from its duration at grandparentClock, the clock of the claim's parent, and has
+// been running since the claim was made. grandparentClock is the zero Clock if the claim is the root claim.
+func ChessClockRemaining(maxGameDuration time.Duration, grandparentClock Clock, claim Claim, now time.Time) time.Duration {
+	remaining := ChessClockDeadline(maxGameDuration, grandparentClock, claim).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// ChessClockDeadline returns the time at which the opposing team's clock to respond to a claim expires.
+// grandparentClock is interpreted as for ChessClockRemaining.
+func ChessClockDeadline(maxGameDuration time.Duration, grandparentClock Clock, claim Claim) time.Time {
+	return claim.Clock.Timestamp.Add(maxGameDuration/2 - grandparentClock.Duration)
+}
+
 // IsRoot returns true if this claim is the root claim.
 func (c *Claim) IsRoot() bool {
 	return c.Position.IsRootPosition()
@@ -3,6 +3,7 @@ package types
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -54,3 +55,34 @@ func TestIsRootPosition(t *testing.T) {
 		})
 	}
 }
+
+func TestChessClockRemaining(t *testing.T) {
+	maxGameDuration := 10 * time.Hour
+	claimTime := time.Unix(1000, 0)
+	claim := Claim{Clock: NewClock(3600, uint64(claimTime.Unix()))}
+
+	t.Run("RootClaim", func(t *testing.T) {
+		remaining := ChessClockRemaining(maxGameDuration, Clock{}, claim, claimTime.Add(time.Hour))
+		require.Equal(t, 4*time.Hour, remaining)
+	})
+
+	t.Run("IncludeGrandparentDuration", func(t *testing.T) {
+		grandparent := NewClock(7200, 0)
+		remaining := ChessClockRemaining(maxGameDuration, grandparent, claim, claimTime.Add(time.Hour))
+		require.Equal(t, 2*time.Hour, remaining)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		remaining := ChessClockRemaining(maxGameDuration, Clock{}, claim, claimTime.Add(6*time.Hour))
+		require.Zero(t, remaining)
+	})
+}
+
+func TestChessClockDeadline(t *testing.T) {
+	maxGameDuration := 10 * time.Hour
+	claimTime := time.Unix(1000, 0)
+	claim := Claim{Clock: NewClock(3600, uint64(claimTime.Unix()))}
+	grandparent := NewClock(7200, 0)
+	require.Equal(t, claimTime.Add(5*time.Hour), ChessClockDeadline(maxGameDuration, Clock{}, claim))
+	require.Equal(t, claimTime.Add(3*time.Hour), ChessClockDeadline(maxGameDuration, grandparent, claim))
+}
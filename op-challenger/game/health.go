@@ -0,0 +1,80 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/api"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// signerCheckTTL is how long the result of checking the signer is reused, so probes don't request a signature
+// from the signer every time.
+const signerCheckTTL = time.Minute
+
+// errNotStarted is reported by the readiness check until the service has started.
+var errNotStarted = errors.New("service not started")
+
+// healthCheck checks the health of one of the service's subsystems.
+// Critical subsystems are expected to recover if the challenger is restarted.
+type healthCheck struct {
+	name     string
+	critical bool
+	check    api.HealthCheck
+}
+
+func (s *Service) initHealthChecks(cfg *config.Config) {
+	s.healthChecks = []healthCheck{
+		{name: "started", check: func(_ context.Context) error {
+			if !s.started.Load() {
+				return errNotStarted
+			}
+			return nil
+		}},
+		{name: "l1", check: func(ctx context.Context) error {
+			_, err := s.l1Client.BlockNumber(ctx)
+			return err
+		}},
+		{name: "txmgr-signer", check: api.CachedHealthCheck(clock.SystemClock, signerCheckTTL, s.txMgr.CheckSigner)},
+		{name: "keccak-scheduler", critical: true, check: s.preimages.CheckHealth},
+	}
+	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
+		s.healthChecks = append(s.healthChecks, healthCheck{
+			name:  "cannon",
+			check: filesExistCheck(cfg.CannonBin, cfg.CannonServer, cfg.CannonAbsolutePreState),
+		})
+	}
+	if cfg.TraceTypeEnabled(config.TraceTypeAsterisc) {
+		s.healthChecks = append(s.healthChecks, healthCheck{
+			name:  "asterisc",
+			check: filesExistCheck(cfg.AsteriscBin, cfg.AsteriscServer, cfg.AsteriscAbsolutePreState),
+		})
+	}
+}
+
+// addHealthChecks registers the service's health checks with checker, prefixing each subsystem name with prefix.
+func (s *Service) addHealthChecks(checker *api.HealthChecker, prefix string) {
+	for _, c := range s.healthChecks {
+		checker.Add(prefix+c.name, c.critical, c.check)
+	}
+}
+
+// filesExistCheck creates a health check which verifies that each of the non-empty paths exist.
+// Absolute prestates downloaded by hash are not checked as they are only fetched when a game requires them.
+func filesExistCheck(paths ...string) api.HealthCheck {
+	return func(_ context.Context) error {
+		for _, path := range paths {
+			if path == "" {
+				continue
+			}
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("failed to access %v: %w", path, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,20 @@
+package game
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesExistCheck(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "cannon")
+	require.NoError(t, os.WriteFile(existing, []byte{}, 0o644))
+
+	require.NoError(t, filesExistCheck(existing, "")(context.Background()))
+	err := filesExistCheck(existing, filepath.Join(dir, "missing"))(context.Background())
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,171 @@
+package keccak
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const verifiedCacheFile = "verified-preimages.json"
+
+type verifiedKey struct {
+	Oracle       common.Address `json:"oracle"`
+	Claimant     common.Address `json:"claimant"`
+	UUID         string         `json:"uuid"`
+	MetaDataHash common.Hash    `json:"metaDataHash"`
+}
+
+func newVerifiedKey(oracle common.Address, preimage keccakTypes.LargePreimageMetaData) verifiedKey {
+	return verifiedKey{
+		Oracle:       oracle,
+		Claimant:     preimage.Claimant,
+		UUID:         preimage.UUID.String(),
+		MetaDataHash: preimage.MetaDataHash(),
+	}
+}
+
+// VerifiedPreimageCache is a persistent record of large preimage proposals that have been verified as valid.
+// Entries are keyed by the oracle, proposal identifier and a hash of the proposal metadata so that a proposal is
+// only skipped if its metadata is unchanged since it was verified.
+type VerifiedPreimageCache struct {
+	log     log.Logger
+	path    string
+	lock    sync.Mutex
+	entries map[verifiedKey]bool
+}
+
+// NewVerifiedPreimageCache creates a cache persisted to a file in dir, loading any existing entries.
+// An empty dir creates a cache which is only held in memory.
+func NewVerifiedPreimageCache(logger log.Logger, dir string) (*VerifiedPreimageCache, error) {
+	c := &VerifiedPreimageCache{
+		log:     logger,
+		entries: make(map[verifiedKey]bool),
+	}
+	if dir == "" {
+		return c, nil
+	}
+	c.path = filepath.Join(dir, verifiedCacheFile)
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// IsVerified returns true if the preimage has previously been verified as valid.
+func (c *VerifiedPreimageCache) IsVerified(oracle common.Address, preimage keccakTypes.LargePreimageMetaData) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.entries[newVerifiedKey(oracle, preimage)]
+}
+
+// RecordVerified records that the preimage has been verified as valid.
+func (c *VerifiedPreimageCache) RecordVerified(oracle common.Address, preimage keccakTypes.LargePreimageMetaData) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	key := newVerifiedKey(oracle, preimage)
+	if c.entries[key] {
+		return nil
+	}
+	c.entries[key] = true
+	return c.save()
+}
+
+// Prune removes entries for the oracle which are no longer included in active or which no longer require
+// verification because they have been finalized or countered.
+func (c *VerifiedPreimageCache) Prune(oracle common.Address, active []keccakTypes.LargePreimageMetaData) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	keep := make(map[verifiedKey]bool, len(active))
+	for _, preimage := range active {
+		if preimage.ShouldVerify() {
+			keep[newVerifiedKey(oracle, preimage)] = true
+		}
+	}
+	pruned := 0
+	for key := range c.entries {
+		if key.Oracle == oracle && !keep[key] {
+			c.log.Debug("Pruning verified preimage", "oracle", oracle, "claimant", key.Claimant, "uuid", key.UUID)
+			delete(c.entries, key)
+			pruned++
+		}
+	}
+	if pruned == 0 {
+		return nil
+	}
+	return c.save()
+}
+
+func (c *VerifiedPreimageCache) load() error {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read verified preimage cache: %w", err)
+	}
+	var keys []verifiedKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		c.log.Warn("Ignoring corrupt verified preimage cache", "path", c.path, "err", err)
+		return nil
+	}
+	for _, key := range keys {
+		if _, ok := new(big.Int).SetString(key.UUID, 10); !ok {
+			continue
+		}
+		c.entries[key] = true
+	}
+	return nil
+}
+
+func (c *VerifiedPreimageCache) save() error {
+	if c.path == "" {
+		return nil
+	}
+	keys := make([]verifiedKey, 0, len(c.entries))
+	for key := range c.entries {
+		keys = append(keys, key)
+	}
+	if err := ioutil.WriteAtomicJSON(c.path, keys, 0o644); err != nil {
+		return fmt.Errorf("failed to write verified preimage cache: %w", err)
+	}
+	return nil
+}
+
+// CachingVerifier is a Verifier that skips verification of preimages that have previously been verified as valid.
+type CachingVerifier struct {
+	log      log.Logger
+	verifier Verifier
+	cache    *VerifiedPreimageCache
+}
+
+func NewCachingVerifier(logger log.Logger, verifier Verifier, cache *VerifiedPreimageCache) *CachingVerifier {
+	return &CachingVerifier{
+		log:      logger,
+		verifier: verifier,
+		cache:    cache,
+	}
+}
+
+func (v *CachingVerifier) CreateChallenge(ctx context.Context, blockHash common.Hash, oracle fetcher.Oracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error) {
+	if v.cache.IsVerified(oracle.Addr(), preimage) {
+		return keccakTypes.Challenge{}, matrix.ErrValid
+	}
+	challenge, err := v.verifier.CreateChallenge(ctx, blockHash, oracle, preimage)
+	if errors.Is(err, matrix.ErrValid) {
+		if err := v.cache.RecordVerified(oracle.Addr(), preimage); err != nil {
+			v.log.Warn("Failed to record verified preimage", "claimant", preimage.Claimant, "uuid", preimage.UUID, "err", err)
+		}
+	}
+	return challenge, err
+}
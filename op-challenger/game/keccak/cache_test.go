@@ -0,0 +1,139 @@
+package keccak
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifiedPreimageCache(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	oracle := common.Address{0xaa}
+	preimage := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{
+			Claimant: common.Address{0xbb},
+			UUID:     big.NewInt(42),
+		},
+		Timestamp:   1234,
+		ClaimedSize: 500,
+	}
+
+	t.Run("RecordAndQuery", func(t *testing.T) {
+		cache, err := NewVerifiedPreimageCache(logger, t.TempDir())
+		require.NoError(t, err)
+		require.False(t, cache.IsVerified(oracle, preimage))
+		require.NoError(t, cache.RecordVerified(oracle, preimage))
+		require.True(t, cache.IsVerified(oracle, preimage))
+		require.False(t, cache.IsVerified(common.Address{0xcc}, preimage), "should be specific to oracle")
+	})
+
+	t.Run("ChangedDataNotVerified", func(t *testing.T) {
+		cache, err := NewVerifiedPreimageCache(logger, t.TempDir())
+		require.NoError(t, err)
+		require.NoError(t, cache.RecordVerified(oracle, preimage))
+		modified := preimage
+		modified.BytesProcessed = 12
+		require.False(t, cache.IsVerified(oracle, modified))
+	})
+
+	t.Run("PersistAcrossRestarts", func(t *testing.T) {
+		dir := t.TempDir()
+		cache, err := NewVerifiedPreimageCache(logger, dir)
+		require.NoError(t, err)
+		require.NoError(t, cache.RecordVerified(oracle, preimage))
+
+		reloaded, err := NewVerifiedPreimageCache(logger, dir)
+		require.NoError(t, err)
+		require.True(t, reloaded.IsVerified(oracle, preimage))
+	})
+
+	t.Run("IgnoreCorruptFile", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, verifiedCacheFile), []byte("garbage"), 0o644))
+		cache, err := NewVerifiedPreimageCache(logger, dir)
+		require.NoError(t, err)
+		require.False(t, cache.IsVerified(oracle, preimage))
+	})
+
+	t.Run("PruneInactive", func(t *testing.T) {
+		dir := t.TempDir()
+		cache, err := NewVerifiedPreimageCache(logger, dir)
+		require.NoError(t, err)
+		otherOracle := common.Address{0xcc}
+		countered := preimage
+		countered.UUID = big.NewInt(43)
+		removed := preimage
+		removed.UUID = big.NewInt(44)
+		require.NoError(t, cache.RecordVerified(oracle, preimage))
+		require.NoError(t, cache.RecordVerified(oracle, countered))
+		require.NoError(t, cache.RecordVerified(oracle, removed))
+		require.NoError(t, cache.RecordVerified(otherOracle, removed))
+
+		countered.Countered = true
+		require.NoError(t, cache.Prune(oracle, []keccakTypes.LargePreimageMetaData{preimage, countered}))
+		require.True(t, cache.IsVerified(oracle, preimage))
+		countered.Countered = false
+		require.False(t, cache.IsVerified(oracle, countered))
+		require.False(t, cache.IsVerified(oracle, removed))
+		require.True(t, cache.IsVerified(otherOracle, removed), "should not prune other oracles")
+
+		reloaded, err := NewVerifiedPreimageCache(logger, dir)
+		require.NoError(t, err)
+		require.True(t, reloaded.IsVerified(oracle, preimage))
+		require.False(t, reloaded.IsVerified(oracle, removed))
+	})
+}
+
+func TestCachingVerifier(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	oracle := &stubOracle{addr: common.Address{0xaa}}
+	valid := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0x01}, UUID: big.NewInt(1)},
+		Timestamp:          1234,
+	}
+	invalid := keccakTypes.LargePreimageMetaData{
+		LargePreimageIdent: keccakTypes.LargePreimageIdent{Claimant: common.Address{0x02}, UUID: big.NewInt(2)},
+		Timestamp:          1234,
+	}
+	inner := &countingVerifier{stubVerifier: stubVerifier{
+		challenges: map[keccakTypes.LargePreimageIdent]keccakTypes.Challenge{
+			invalid.LargePreimageIdent: {StateMatrix: keccakTypes.StateSnapshot{0x01}},
+		},
+	}}
+	cache, err := NewVerifiedPreimageCache(logger, "")
+	require.NoError(t, err)
+	verifier := NewCachingVerifier(logger, inner, cache)
+
+	for i := 0; i < 3; i++ {
+		_, err := verifier.CreateChallenge(context.Background(), common.Hash{0xbb}, oracle, valid)
+		require.ErrorIs(t, err, matrix.ErrValid)
+		challenge, err := verifier.CreateChallenge(context.Background(), common.Hash{0xbb}, oracle, invalid)
+		require.NoError(t, err)
+		require.Equal(t, keccakTypes.StateSnapshot{0x01}, challenge.StateMatrix)
+	}
+	require.Equal(t, 1, inner.calls[valid.LargePreimageIdent], "should only verify valid preimage once")
+	require.Equal(t, 3, inner.calls[invalid.LargePreimageIdent], "should always verify invalid preimage")
+}
+
+type countingVerifier struct {
+	stubVerifier
+	calls map[keccakTypes.LargePreimageIdent]int
+}
+
+func (s *countingVerifier) CreateChallenge(ctx context.Context, blockHash common.Hash, oracle fetcher.Oracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error) {
+	if s.calls == nil {
+		s.calls = make(map[keccakTypes.LargePreimageIdent]int)
+	}
+	s.calls[preimage.LargePreimageIdent]++
+	return s.stubVerifier.CreateChallenge(ctx, blockHash, oracle, preimage)
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -23,6 +26,9 @@ type Oracle interface {
 type ChallengeMetrics interface {
 	RecordPreimageChallenged()
 	RecordPreimageChallengeFailed()
+	RecordPreimageChallengeDryRun()
+	RecordPreimageChallengeGasEstimationFailed()
+	RecordLargePreimageChallengeTime(t time.Duration)
 }
 
 type Verifier interface {
@@ -33,60 +39,175 @@ type Sender interface {
 	SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*types.Receipt, error)
 }
 
+type GasLimiter interface {
+	GasLimit(ctx context.Context, tx txmgr.TxCandidate) (uint64, error)
+}
+
+type StatusRecorder interface {
+	RecordValid(oracle common.Address, ident keccakTypes.LargePreimageIdent)
+	RecordInvalid(oracle common.Address, ident keccakTypes.LargePreimageIdent, challenge keccakTypes.Challenge)
+	RecordChallengeTx(oracle common.Address, ident keccakTypes.LargePreimageIdent, txHash common.Hash)
+	TimeSinceDetected(oracle common.Address, ident keccakTypes.LargePreimageIdent) (time.Duration, bool)
+}
+
 type PreimageChallenger struct {
 	log      log.Logger
 	metrics  ChallengeMetrics
 	verifier Verifier
 	sender   Sender
+	gas      GasLimiter
+	status   StatusRecorder
+	workers  uint
+	dryRun   bool
+
+	urgentTipCap *big.Int
 }
 
-func NewPreimageChallenger(logger log.Logger, metrics ChallengeMetrics, verifier Verifier, sender Sender) *PreimageChallenger {
+func NewPreimageChallenger(logger log.Logger, metrics ChallengeMetrics, verifier Verifier, sender Sender, gas GasLimiter, status StatusRecorder, workers uint, dryRun bool, urgentTipCap *big.Int) *PreimageChallenger {
+	if workers == 0 {
+		workers = 1
+	}
 	return &PreimageChallenger{
 		log:      logger,
 		metrics:  metrics,
 		verifier: verifier,
 		sender:   sender,
+		gas:      gas,
+		status:   status,
+		workers:  workers,
+		dryRun:   dryRun,
+
+		urgentTipCap: urgentTipCap,
 	}
 }
 
+// Challenge verifies the supplied preimages using up to the configured number of workers and sends
+// challenge transactions for any invalid preimages in a single batch.
+// In dry-run mode the challenge transactions are logged but not sent.
 func (c *PreimageChallenger) Challenge(ctx context.Context, blockHash common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData) error {
 	var txLock sync.Mutex
-	var wg sync.WaitGroup
 	var txs []txmgr.TxCandidate
-	for _, preimage := range preimages {
-		preimage := preimage
+	var idents []keccakTypes.LargePreimageIdent
+	c.verify(ctx, blockHash, oracle, preimages, func(tx txmgr.TxCandidate, ident keccakTypes.LargePreimageIdent) {
+		txLock.Lock()
+		defer txLock.Unlock()
+		txs = append(txs, tx)
+		idents = append(idents, ident)
+	})
+	c.log.Debug("Created preimage challenge transactions", "count", len(txs))
+	return c.send(oracle, "challenge preimages", txs, idents)
+}
+
+// ChallengeUrgent verifies the supplied preimages which are close to being squeezable. Each challenge transaction
+// is sent as soon as it is created, rather than waiting for the whole batch to be verified, and with a gas tip cap
+// of at least the configured urgent tip cap so the challenge is not beaten by the squeeze.
+func (c *PreimageChallenger) ChallengeUrgent(ctx context.Context, blockHash common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData) error {
+	var errLock sync.Mutex
+	var errs error
+	var wg sync.WaitGroup
+	c.verify(ctx, blockHash, oracle, preimages, func(tx txmgr.TxCandidate, ident keccakTypes.LargePreimageIdent) {
+		c.log.Warn("Escalating challenge of preimage near squeeze eligibility", "oracle", oracle.Addr(), "claimant", ident.Claimant, "uuid", ident.UUID, "minTipCap", c.urgentTipCap)
+		tx.MinGasTipCap = c.urgentTipCap
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			logger := c.log.New("oracle", oracle.Addr(), "claimant", preimage.Claimant, "uuid", preimage.UUID)
-			challenge, err := c.verifier.CreateChallenge(ctx, blockHash, oracle, preimage)
-			if errors.Is(err, matrix.ErrValid) {
-				logger.Debug("Preimage is valid")
-				return
-			} else if err != nil {
-				logger.Error("Failed to verify large preimage", "err", err)
-				return
-			}
-			logger.Info("Challenging preimage", "block", challenge.Poststate.Index)
-			tx, err := oracle.ChallengeTx(preimage.LargePreimageIdent, challenge)
-			if err != nil {
-				logger.Error("Failed to create challenge transaction", "err", err)
-				return
+			err := c.send(oracle, "challenge urgent preimage", []txmgr.TxCandidate{tx}, []keccakTypes.LargePreimageIdent{ident})
+			errLock.Lock()
+			defer errLock.Unlock()
+			errs = errors.Join(errs, err)
+		}()
+	})
+	wg.Wait()
+	return errs
+}
+
+// verify verifies preimages using up to the configured number of workers, calling onChallenge from the worker
+// goroutine with the challenge transaction for each invalid preimage.
+func (c *PreimageChallenger) verify(ctx context.Context, blockHash common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData, onChallenge func(tx txmgr.TxCandidate, ident keccakTypes.LargePreimageIdent)) {
+	var wg sync.WaitGroup
+	queue := make(chan keccakTypes.LargePreimageMetaData)
+	for i := uint(0); i < c.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for preimage := range queue {
+				tx, ok := c.createChallengeTx(ctx, blockHash, oracle, preimage)
+				if !ok {
+					continue
+				}
+				onChallenge(tx, preimage.LargePreimageIdent)
 			}
-			txLock.Lock()
-			defer txLock.Unlock()
-			txs = append(txs, tx)
 		}()
 	}
+	for _, preimage := range preimages {
+		queue <- preimage
+	}
+	close(queue)
 	wg.Wait()
-	c.log.Debug("Created preimage challenge transactions", "count", len(txs))
-	if len(txs) > 0 {
-		_, err := c.sender.SendAndWait("challenge preimages", txs...)
-		if err != nil {
-			c.metrics.RecordPreimageChallengeFailed()
-			return fmt.Errorf("failed to send challenge txs: %w", err)
+}
+
+// send sends the challenge transactions and waits for them to be included.
+// In dry-run mode the transactions are not sent.
+func (c *PreimageChallenger) send(oracle Oracle, txPurpose string, txs []txmgr.TxCandidate, idents []keccakTypes.LargePreimageIdent) error {
+	if c.dryRun {
+		for range txs {
+			c.metrics.RecordPreimageChallengeDryRun()
 		}
-		c.metrics.RecordPreimageChallenged()
+		return nil
+	}
+	if len(txs) == 0 {
+		return nil
 	}
+	receipts, err := c.sender.SendAndWait(txPurpose, txs...)
+	for i, rcpt := range receipts {
+		if rcpt == nil || i >= len(idents) {
+			continue
+		}
+		c.status.RecordChallengeTx(oracle.Addr(), idents[i], rcpt.TxHash)
+		if rcpt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		if elapsed, ok := c.status.TimeSinceDetected(oracle.Addr(), idents[i]); ok {
+			c.metrics.RecordLargePreimageChallengeTime(elapsed)
+		}
+	}
+	if err != nil {
+		c.metrics.RecordPreimageChallengeFailed()
+		return fmt.Errorf("failed to send challenge txs: %w", err)
+	}
+	c.metrics.RecordPreimageChallenged()
 	return nil
 }
+
+func (c *PreimageChallenger) createChallengeTx(ctx context.Context, blockHash common.Hash, oracle Oracle, preimage keccakTypes.LargePreimageMetaData) (txmgr.TxCandidate, bool) {
+	logger := c.log.New("oracle", oracle.Addr(), "claimant", preimage.Claimant, "uuid", preimage.UUID)
+	challenge, err := c.verifier.CreateChallenge(ctx, blockHash, oracle, preimage)
+	if errors.Is(err, matrix.ErrValid) {
+		logger.Debug("Preimage is valid")
+		c.status.RecordValid(oracle.Addr(), preimage.LargePreimageIdent)
+		return txmgr.TxCandidate{}, false
+	} else if err != nil {
+		logger.Error("Failed to verify large preimage", "err", err)
+		return txmgr.TxCandidate{}, false
+	}
+	c.status.RecordInvalid(oracle.Addr(), preimage.LargePreimageIdent, challenge)
+	tx, err := oracle.ChallengeTx(preimage.LargePreimageIdent, challenge)
+	if err != nil {
+		logger.Error("Failed to create challenge transaction", "err", err)
+		return txmgr.TxCandidate{}, false
+	}
+	gasLimit, err := c.gas.GasLimit(ctx, tx)
+	if err != nil {
+		// The challenge would likely revert so don't send it. It will be retried when the next block is processed.
+		logger.Error("Failed to estimate gas for challenge transaction", "err", err)
+		c.metrics.RecordPreimageChallengeGasEstimationFailed()
+		return txmgr.TxCandidate{}, false
+	}
+	tx.GasLimit = gasLimit
+	if c.dryRun {
+		logger.Info("Dry run: would challenge preimage", "block", challenge.Poststate.Index, "to", tx.To, "gasLimit", tx.GasLimit, "calldata", hexutil.Encode(tx.TxData))
+	} else {
+		logger.Info("Challenging preimage", "block", challenge.Poststate.Index, "gasLimit", tx.GasLimit)
+	}
+	return tx, true
+}
@@ -4,19 +4,33 @@ import (
 	"context"
 	"errors"
 	"math/big"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
+// isErr matches log attribute values that are errors wrapping target.
+func isErr(target error) func(v any) bool {
+	return func(v any) bool {
+		err, ok := v.(error)
+		return ok && errors.Is(err, target)
+	}
+}
+
 func TestChallenge(t *testing.T) {
 	preimages := []keccakTypes.LargePreimageMetaData{
 		{
@@ -53,10 +67,91 @@ func TestChallenge(t *testing.T) {
 		for ident, challenge := range verifier.challenges {
 			tx, err := oracle.ChallengeTx(ident, challenge)
 			require.NoError(t, err)
+			tx.GasLimit = stubGasLimit
 			require.Contains(t, sender.sent[0], tx)
 		}
 	})
 
+	t.Run("LimitConcurrentVerification", func(t *testing.T) {
+		verifier := &concurrencyTrackingVerifier{}
+		sender := &stubSender{}
+		oracle := &stubChallengerOracle{}
+		challenger := NewPreimageChallenger(logger, &mockChallengeMetrics{}, verifier, sender, &stubGasLimiter{}, NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0))), 2, false, urgentTipCap)
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+		require.EqualValues(t, len(preimages), verifier.calls.Load())
+		require.LessOrEqual(t, verifier.maxActive.Load(), int32(2))
+		require.Len(t, sender.sent, 1, "Should send a single batch of transactions")
+		require.Len(t, sender.sent[0], len(preimages))
+	})
+
+	t.Run("RecordTimeFromDetectionToChallenge", func(t *testing.T) {
+		verifier := &stubVerifier{
+			challenges: make(map[keccakTypes.LargePreimageIdent]keccakTypes.Challenge),
+		}
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		status := NewStatusTracker(cl)
+		trackedPreimages := make([]keccakTypes.LargePreimageMetaData, len(preimages))
+		for i, preimage := range preimages {
+			preimage.Timestamp = 1234
+			trackedPreimages[i] = preimage
+		}
+		status.UpdateActive(common.Address{}, trackedPreimages)
+		cl.AdvanceTime(90 * time.Second)
+		metrics := &mockChallengeMetrics{}
+		challenger := NewPreimageChallenger(logger, metrics, verifier, &stubSender{}, &stubGasLimiter{}, status, 4, false, urgentTipCap)
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, &stubChallengerOracle{}, trackedPreimages)
+		require.NoError(t, err)
+		require.Equal(t, []time.Duration{90 * time.Second}, metrics.challengeTimes)
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		logs := testlog.Capture(logger)
+		verifier := &stubVerifier{
+			challenges: make(map[keccakTypes.LargePreimageIdent]keccakTypes.Challenge),
+		}
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		sender := &stubSender{}
+		oracle := &stubChallengerOracle{}
+		metrics := &mockChallengeMetrics{}
+		challenger := NewPreimageChallenger(logger, metrics, verifier, sender, &stubGasLimiter{}, NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0))), 4, true, urgentTipCap)
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+		require.Empty(t, sender.sent, "Should not send transactions")
+		require.EqualValues(t, 1, metrics.dryRun.Load())
+
+		tx, err := oracle.ChallengeTx(preimages[1].LargePreimageIdent, verifier.challenges[preimages[1].LargePreimageIdent])
+		require.NoError(t, err)
+		dryRunLog := logs.FindLog(log.LvlInfo, "Dry run: would challenge preimage")
+		require.NotNil(t, dryRunLog)
+		require.Equal(t, hexutil.Encode(tx.TxData), dryRunLog.GetContextValue("calldata"))
+	})
+
+	t.Run("ChallengeUrgent", func(t *testing.T) {
+		verifier, sender, oracle, challenger := setupChallengerTest(logger)
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		verifier.challenges[preimages[2].LargePreimageIdent] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x02}}
+		err := challenger.ChallengeUrgent(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+
+		// Each challenge is sent individually with the urgent tip cap
+		sent := sender.Sent()
+		require.Len(t, sent, 2, "Should send each challenge separately")
+		for _, txs := range sent {
+			require.Len(t, txs, 1)
+			require.Equal(t, urgentTipCap, txs[0].MinGasTipCap)
+		}
+	})
+
+	t.Run("ChallengeUrgentReturnsSendErrors", func(t *testing.T) {
+		verifier, sender, oracle, challenger := setupChallengerTest(logger)
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		sender.err = errors.New("boom")
+		err := challenger.ChallengeUrgent(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.ErrorIs(t, err, sender.err)
+	})
+
 	t.Run("ReturnErrorWhenSendingFails", func(t *testing.T) {
 		verifier, sender, oracle, challenger := setupChallengerTest(logger)
 		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
@@ -74,8 +169,31 @@ func TestChallenge(t *testing.T) {
 		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
 		require.NoError(t, err)
 
-		errLog := logs.FindLog(log.LvlError, "Failed to create challenge transaction")
-		require.ErrorIs(t, errLog.GetContextValue("err").(error), oracle.err)
+		errLog := logs.FindMatch(testlog.Match().Level(log.LvlError).Message("Failed to create challenge transaction").AttrMatches("err", isErr(oracle.err)))
+		require.NotNil(t, errLog)
+	})
+
+	t.Run("SkipChallengeWhenGasEstimationFails", func(t *testing.T) {
+		logs := testlog.Capture(logger)
+		verifier := &stubVerifier{
+			challenges: make(map[keccakTypes.LargePreimageIdent]keccakTypes.Challenge),
+		}
+		verifier.challenges[preimages[1].LargePreimageIdent] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}
+		verifier.challenges[preimages[2].LargePreimageIdent] = keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x02}}
+		sender := &stubSender{}
+		oracle := &stubChallengerOracle{}
+		metrics := &mockChallengeMetrics{}
+		gas := &stubGasLimiter{failFor: map[common.Address]bool{preimages[1].Claimant: true}}
+		challenger := NewPreimageChallenger(logger, metrics, verifier, sender, gas, NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0))), 4, false, urgentTipCap)
+		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, metrics.gasEstimationFailed.Load())
+		require.Len(t, sender.sent, 1)
+		require.Len(t, sender.sent[0], 1, "Should only send challenge with successful gas estimate")
+		require.Equal(t, preimages[2].Claimant, *sender.sent[0][0].To)
+
+		errLog := logs.FindLog(log.LvlError, "Failed to estimate gas for challenge transaction")
+		require.NotNil(t, errLog)
 	})
 
 	t.Run("LogErrorWhenVerifierFails", func(t *testing.T) {
@@ -87,8 +205,8 @@ func TestChallenge(t *testing.T) {
 		err := challenger.Challenge(context.Background(), common.Hash{0xaa}, oracle, preimages)
 		require.NoError(t, err)
 
-		errLog := logs.FindLog(log.LvlError, "Failed to verify large preimage")
-		require.ErrorIs(t, errLog.GetContextValue("err").(error), verifier.err)
+		errLog := logs.FindMatch(testlog.Match().Level(log.LvlError).Message("Failed to verify large preimage").AttrMatches("err", isErr(verifier.err)))
+		require.NotNil(t, errLog)
 	})
 
 	t.Run("DoNotLogErrValid", func(t *testing.T) {
@@ -107,6 +225,8 @@ func TestChallenge(t *testing.T) {
 	})
 }
 
+var urgentTipCap = big.NewInt(5_000_000_000)
+
 func setupChallengerTest(logger log.Logger) (*stubVerifier, *stubSender, *stubChallengerOracle, *PreimageChallenger) {
 	verifier := &stubVerifier{
 		challenges: make(map[keccakTypes.LargePreimageIdent]keccakTypes.Challenge),
@@ -114,14 +234,27 @@ func setupChallengerTest(logger log.Logger) (*stubVerifier, *stubSender, *stubCh
 	sender := &stubSender{}
 	oracle := &stubChallengerOracle{}
 	metrics := &mockChallengeMetrics{}
-	challenger := NewPreimageChallenger(logger, metrics, verifier, sender)
+	challenger := NewPreimageChallenger(logger, metrics, verifier, sender, &stubGasLimiter{}, NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0))), 4, false, urgentTipCap)
 	return verifier, sender, oracle, challenger
 }
 
-type mockChallengeMetrics struct{}
+type mockChallengeMetrics struct {
+	dryRun              atomic.Int32
+	gasEstimationFailed atomic.Int32
+	challengeTimes      []time.Duration
+}
 
 func (m *mockChallengeMetrics) RecordPreimageChallenged()      {}
 func (m *mockChallengeMetrics) RecordPreimageChallengeFailed() {}
+func (m *mockChallengeMetrics) RecordPreimageChallengeDryRun() {
+	m.dryRun.Add(1)
+}
+func (m *mockChallengeMetrics) RecordPreimageChallengeGasEstimationFailed() {
+	m.gasEstimationFailed.Add(1)
+}
+func (m *mockChallengeMetrics) RecordLargePreimageChallengeTime(t time.Duration) {
+	m.challengeTimes = append(m.challengeTimes, t)
+}
 
 type stubVerifier struct {
 	challenges map[keccakTypes.LargePreimageIdent]keccakTypes.Challenge
@@ -139,17 +272,63 @@ func (s *stubVerifier) CreateChallenge(_ context.Context, _ common.Hash, _ fetch
 	return challenge, nil
 }
 
+type concurrencyTrackingVerifier struct {
+	active    atomic.Int32
+	maxActive atomic.Int32
+	calls     atomic.Int32
+}
+
+func (s *concurrencyTrackingVerifier) CreateChallenge(_ context.Context, _ common.Hash, _ fetcher.Oracle, _ keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error) {
+	s.calls.Add(1)
+	active := s.active.Add(1)
+	defer s.active.Add(-1)
+	for {
+		prev := s.maxActive.Load()
+		if active <= prev || s.maxActive.CompareAndSwap(prev, active) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return keccakTypes.Challenge{StateMatrix: keccakTypes.StateSnapshot{0x01}}, nil
+}
+
+const stubGasLimit = uint64(123_456)
+
+type stubGasLimiter struct {
+	failFor map[common.Address]bool
+}
+
+func (s *stubGasLimiter) GasLimit(_ context.Context, tx txmgr.TxCandidate) (uint64, error) {
+	if s.failFor[*tx.To] {
+		return 0, errors.New("execution reverted")
+	}
+	return stubGasLimit, nil
+}
+
 type stubSender struct {
+	m    sync.Mutex
 	err  error
 	sent [][]txmgr.TxCandidate
 }
 
 func (s *stubSender) SendAndWait(_ string, txs ...txmgr.TxCandidate) ([]*types.Receipt, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
 	if s.err != nil {
 		return nil, s.err
 	}
 	s.sent = append(s.sent, txs)
-	return nil, nil
+	rcpts := make([]*types.Receipt, len(txs))
+	for i := range txs {
+		rcpts[i] = &types.Receipt{TxHash: common.Hash{byte(i)}, Status: types.ReceiptStatusSuccessful}
+	}
+	return rcpts, nil
+}
+
+func (s *stubSender) Sent() [][]txmgr.TxCandidate {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return slices.Clone(s.sent)
 }
 
 type stubChallengerOracle struct {
@@ -0,0 +1,32 @@
+package keccak
+
+import (
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ClaimantFilter selects the large preimage proposals to verify based on their claimant.
+type ClaimantFilter struct {
+	allowlist []common.Address
+	blocklist []common.Address
+}
+
+// NewClaimantFilter creates a new ClaimantFilter.
+// Claimants in the allowlist are trusted, so their proposals are never verified.
+// If blocklist is not empty, only proposals from claimants in the blocklist are verified.
+// A claimant in both lists is verified.
+func NewClaimantFilter(allowlist []common.Address, blocklist []common.Address) *ClaimantFilter {
+	return &ClaimantFilter{
+		allowlist: allowlist,
+		blocklist: blocklist,
+	}
+}
+
+// ShouldVerify returns true if proposals from claimant should be verified.
+func (f *ClaimantFilter) ShouldVerify(claimant common.Address) bool {
+	if len(f.blocklist) > 0 {
+		return slices.Contains(f.blocklist, claimant)
+	}
+	return !slices.Contains(f.allowlist, claimant)
+}
@@ -0,0 +1,41 @@
+package keccak
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimantFilter(t *testing.T) {
+	claimantA := common.Address{0xaa}
+	claimantB := common.Address{0xbb}
+	claimantC := common.Address{0xcc}
+
+	t.Run("NoLists", func(t *testing.T) {
+		filter := NewClaimantFilter(nil, nil)
+		require.True(t, filter.ShouldVerify(claimantA))
+		require.True(t, filter.ShouldVerify(claimantB))
+	})
+
+	t.Run("AllowlistSkipsTrustedClaimants", func(t *testing.T) {
+		filter := NewClaimantFilter([]common.Address{claimantA, claimantB}, nil)
+		require.False(t, filter.ShouldVerify(claimantA))
+		require.False(t, filter.ShouldVerify(claimantB))
+		require.True(t, filter.ShouldVerify(claimantC))
+	})
+
+	t.Run("BlocklistOnlyTargetsListedClaimants", func(t *testing.T) {
+		filter := NewClaimantFilter(nil, []common.Address{claimantA})
+		require.True(t, filter.ShouldVerify(claimantA))
+		require.False(t, filter.ShouldVerify(claimantB))
+		require.False(t, filter.ShouldVerify(claimantC))
+	})
+
+	t.Run("BlocklistTakesPrecedence", func(t *testing.T) {
+		filter := NewClaimantFilter([]common.Address{claimantA, claimantB}, []common.Address{claimantA, claimantC})
+		require.True(t, filter.ShouldVerify(claimantA))
+		require.False(t, filter.ShouldVerify(claimantB))
+		require.True(t, filter.ShouldVerify(claimantC))
+	})
+}
@@ -0,0 +1,67 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type BlockSource interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// BatchingL1Source is an L1Source that retrieves transaction receipts using batched RPC requests.
+// The PreimageOracle does not emit events when leaves are added so the receipts must be retrieved individually,
+// but batching them avoids a round trip per leaf transaction for long proposals.
+type BatchingL1Source struct {
+	BlockSource
+	rpc       BatchCaller
+	batchSize int
+}
+
+// NewBatchingL1Source creates a new BatchingL1Source that requests up to batchSize receipts in each batch.
+func NewBatchingL1Source(blocks BlockSource, rpc BatchCaller, batchSize uint) *BatchingL1Source {
+	return &BatchingL1Source{
+		BlockSource: blocks,
+		rpc:         rpc,
+		batchSize:   int(max(batchSize, 1)),
+	}
+}
+
+func (s *BatchingL1Source) TransactionReceipts(ctx context.Context, txHashes []common.Hash) ([]*types.Receipt, error) {
+	rcpts := make([]*types.Receipt, len(txHashes))
+	for start := 0; start < len(txHashes); start += s.batchSize {
+		end := min(start+s.batchSize, len(txHashes))
+		batch := make([]rpc.BatchElem, 0, end-start)
+		for i := start; i < end; i++ {
+			rcpts[i] = new(types.Receipt)
+			batch = append(batch, rpc.BatchElem{
+				Method: "eth_getTransactionReceipt",
+				Args:   []any{txHashes[i]},
+				Result: &rcpts[i],
+			})
+		}
+		if err := s.rpc.BatchCallContext(ctx, batch); err != nil {
+			return nil, fmt.Errorf("failed to request receipts: %w", err)
+		}
+		for i, elem := range batch {
+			if elem.Error != nil {
+				return nil, fmt.Errorf("failed to retrieve receipt for tx %v: %w", txHashes[start+i], elem.Error)
+			}
+			if rcpts[start+i] == nil {
+				return nil, fmt.Errorf("receipt for tx %v: %w", txHashes[start+i], ethereum.NotFound)
+			}
+		}
+	}
+	return rcpts, nil
+}
@@ -0,0 +1,90 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchingL1Source_TransactionReceipts(t *testing.T) {
+	txHashes := make([]common.Hash, 7)
+	for i := range txHashes {
+		txHashes[i] = common.Hash{byte(i + 1)}
+	}
+
+	t.Run("SplitIntoBatches", func(t *testing.T) {
+		caller := &stubBatchCaller{}
+		source := NewBatchingL1Source(nil, caller, 3)
+		rcpts, err := source.TransactionReceipts(context.Background(), txHashes)
+		require.NoError(t, err)
+		require.Len(t, rcpts, len(txHashes))
+		for i, rcpt := range rcpts {
+			require.Equal(t, txHashes[i], rcpt.TxHash)
+		}
+		require.Equal(t, []int{3, 3, 1}, caller.batchSizes)
+	})
+
+	t.Run("NoHashes", func(t *testing.T) {
+		caller := &stubBatchCaller{}
+		source := NewBatchingL1Source(nil, caller, 3)
+		rcpts, err := source.TransactionReceipts(context.Background(), nil)
+		require.NoError(t, err)
+		require.Empty(t, rcpts)
+		require.Empty(t, caller.batchSizes)
+	})
+
+	t.Run("BatchError", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		source := NewBatchingL1Source(nil, &stubBatchCaller{err: errBoom}, 3)
+		_, err := source.TransactionReceipts(context.Background(), txHashes)
+		require.ErrorIs(t, err, errBoom)
+	})
+
+	t.Run("ElementError", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		source := NewBatchingL1Source(nil, &stubBatchCaller{elemErrs: map[common.Hash]error{txHashes[4]: errBoom}}, 3)
+		_, err := source.TransactionReceipts(context.Background(), txHashes)
+		require.ErrorIs(t, err, errBoom)
+	})
+
+	t.Run("ReceiptNotFound", func(t *testing.T) {
+		source := NewBatchingL1Source(nil, &stubBatchCaller{missing: map[common.Hash]bool{txHashes[2]: true}}, 3)
+		_, err := source.TransactionReceipts(context.Background(), txHashes)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+}
+
+type stubBatchCaller struct {
+	err        error
+	elemErrs   map[common.Hash]error
+	missing    map[common.Hash]bool
+	batchSizes []int
+}
+
+func (s *stubBatchCaller) BatchCallContext(_ context.Context, b []rpc.BatchElem) error {
+	s.batchSizes = append(s.batchSizes, len(b))
+	if s.err != nil {
+		return s.err
+	}
+	for i := range b {
+		txHash := b[i].Args[0].(common.Hash)
+		if err := s.elemErrs[txHash]; err != nil {
+			b[i].Error = err
+			continue
+		}
+		result := b[i].Result.(**types.Receipt)
+		if s.missing[txHash] {
+			*result = nil
+			continue
+		}
+		*result = &types.Receipt{TxHash: txHash, BlockNumber: big.NewInt(1)}
+	}
+	return nil
+}
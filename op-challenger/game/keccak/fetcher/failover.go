@@ -0,0 +1,80 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const DefaultMaxAttempts = 5
+
+// FailoverL1Source is an L1Source that fails over between multiple L1 endpoints and retries with exponential
+// backoff when all endpoints fail. This prevents a single rate-limited or unreliable provider from causing
+// a large preimage challenge to be missed.
+type FailoverL1Source struct {
+	log         log.Logger
+	sources     []L1Source
+	maxAttempts int
+	strategy    retry.Strategy
+
+	// preferred is the index of the source that last completed a request successfully.
+	preferred atomic.Int32
+}
+
+// NewFailoverL1Source creates a new FailoverL1Source. Requests are sent to the first source until it fails.
+func NewFailoverL1Source(logger log.Logger, sources ...L1Source) *FailoverL1Source {
+	return &FailoverL1Source{
+		log:         logger,
+		sources:     sources,
+		maxAttempts: DefaultMaxAttempts,
+		strategy:    retry.Exponential(),
+	}
+}
+
+func (s *FailoverL1Source) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return withFailover(ctx, s, "BlockByNumber", func(source L1Source) (*types.Block, error) {
+		return source.BlockByNumber(ctx, number)
+	})
+}
+
+func (s *FailoverL1Source) TransactionReceipts(ctx context.Context, txHashes []common.Hash) ([]*types.Receipt, error) {
+	return withFailover(ctx, s, "TransactionReceipts", func(source L1Source) ([]*types.Receipt, error) {
+		return source.TransactionReceipts(ctx, txHashes)
+	})
+}
+
+func (s *FailoverL1Source) ChainID(ctx context.Context) (*big.Int, error) {
+	return withFailover(ctx, s, "ChainID", func(source L1Source) (*big.Int, error) {
+		return source.ChainID(ctx)
+	})
+}
+
+// withFailover attempts the request against each source in turn, starting from the preferred source.
+// If all sources fail, the request is retried with backoff up to the maximum number of attempts.
+func withFailover[T any](ctx context.Context, s *FailoverL1Source, method string, fn func(source L1Source) (T, error)) (T, error) {
+	return retry.Do(ctx, s.maxAttempts, s.strategy, func() (T, error) {
+		var errs error
+		start := int(s.preferred.Load())
+		for i := 0; i < len(s.sources); i++ {
+			idx := (start + i) % len(s.sources)
+			result, err := fn(s.sources[idx])
+			if err == nil {
+				s.preferred.Store(int32(idx))
+				return result, nil
+			}
+			if ctx.Err() != nil {
+				return result, err
+			}
+			s.log.Debug("L1 request failed", "method", method, "source", idx, "err", err)
+			errs = errors.Join(errs, err)
+		}
+		var empty T
+		return empty, errs
+	})
+}
@@ -0,0 +1,112 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFailoverL1Source(t *testing.T) {
+	errBoom := errors.New("boom")
+	setup := func(t *testing.T, sources ...L1Source) *FailoverL1Source {
+		source := NewFailoverL1Source(testlog.Logger(t, log.LvlInfo), sources...)
+		source.strategy = retry.Fixed(0)
+		return source
+	}
+
+	t.Run("UsePrimaryWhenHealthy", func(t *testing.T) {
+		primary := &flakySource{chainID: big.NewInt(1)}
+		secondary := &flakySource{chainID: big.NewInt(2)}
+		source := setup(t, primary, secondary)
+		chainID, err := source.ChainID(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1), chainID)
+		require.Equal(t, 1, primary.calls)
+		require.Zero(t, secondary.calls)
+	})
+
+	t.Run("FailoverToSecondary", func(t *testing.T) {
+		primary := &flakySource{chainID: big.NewInt(1), failures: 100, err: errBoom}
+		secondary := &flakySource{chainID: big.NewInt(2)}
+		source := setup(t, primary, secondary)
+		chainID, err := source.ChainID(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(2), chainID)
+
+		// Should continue using the secondary once it succeeds
+		_, err = source.ChainID(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, primary.calls)
+		require.Equal(t, 2, secondary.calls)
+	})
+
+	t.Run("RetryWhenAllFail", func(t *testing.T) {
+		primary := &flakySource{chainID: big.NewInt(1), failures: 2, err: errBoom}
+		secondary := &flakySource{chainID: big.NewInt(2), failures: 2, err: errBoom}
+		source := setup(t, primary, secondary)
+		chainID, err := source.ChainID(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(1), chainID)
+		require.Equal(t, 3, primary.calls)
+		require.Equal(t, 2, secondary.calls)
+	})
+
+	t.Run("FailAfterMaxAttempts", func(t *testing.T) {
+		primary := &flakySource{failures: 100, err: errBoom}
+		secondary := &flakySource{failures: 100, err: errBoom}
+		source := setup(t, primary, secondary)
+		_, err := source.BlockByNumber(context.Background(), big.NewInt(1))
+		require.ErrorIs(t, err, errBoom)
+		require.Equal(t, DefaultMaxAttempts, primary.calls)
+		require.Equal(t, DefaultMaxAttempts, secondary.calls)
+	})
+}
+
+type flakySource struct {
+	chainID  *big.Int
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flakySource) fail() error {
+	f.calls++
+	if f.failures > 0 {
+		f.failures--
+		return f.err
+	}
+	return nil
+}
+
+func (f *flakySource) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return types.NewBlockWithHeader(&types.Header{Number: number}), nil
+}
+
+func (f *flakySource) TransactionReceipts(_ context.Context, txHashes []common.Hash) ([]*types.Receipt, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	rcpts := make([]*types.Receipt, len(txHashes))
+	for i, txHash := range txHashes {
+		rcpts[i] = &types.Receipt{TxHash: txHash}
+	}
+	return rcpts, nil
+}
+
+func (f *flakySource) ChainID(_ context.Context) (*big.Int, error) {
+	if err := f.fail(); err != nil {
+		return nil, err
+	}
+	return f.chainID, nil
+}
@@ -20,7 +20,8 @@ var (
 
 type L1Source interface {
 	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
-	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	// TransactionReceipts retrieves the receipts for the specified transactions, in the same order.
+	TransactionReceipts(ctx context.Context, txHashes []common.Hash) ([]*types.Receipt, error)
 	ChainID(ctx context.Context) (*big.Int, error)
 }
 
@@ -31,48 +32,118 @@ type Oracle interface {
 }
 
 type InputFetcher struct {
-	log    log.Logger
-	source L1Source
+	log              log.Logger
+	source           L1Source
+	receiptBatchSize int
 }
 
-func (f *InputFetcher) FetchInputs(ctx context.Context, blockHash common.Hash, oracle Oracle, ident keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error) {
+// pendingBlock holds the candidate inputs from a block until the receipts of their transactions are retrieved.
+type pendingBlock struct {
+	num        uint64
+	txHashes   []common.Hash
+	candidates []keccakTypes.InputData
+}
+
+// FetchInputs retrieves the inputs added to the large preimage, passing each one to onInput in the order they were added.
+// Blocks are retrieved until they contain at least a full batch of candidate transactions, so the receipts of
+// proposals spread over many blocks are still retrieved in full batches. Only the inputs of those blocks are held in
+// memory at a time.
+// Fetching stops at the first error returned by onInput, which is returned unwrapped.
+func (f *InputFetcher) FetchInputs(ctx context.Context, blockHash common.Hash, oracle Oracle, ident keccakTypes.LargePreimageIdent, onInput func(input keccakTypes.InputData) error) error {
 	blockNums, err := oracle.GetInputDataBlocks(ctx, batching.BlockByHash(blockHash), ident)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve leaf block nums: %w", err)
+		return fmt.Errorf("failed to retrieve leaf block nums: %w", err)
 	}
 	chainID, err := f.source.ChainID(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve L1 chain ID: %w", err)
+		return fmt.Errorf("failed to retrieve L1 chain ID: %w", err)
 	}
 	signer := types.LatestSignerForChainID(chainID)
-	var inputs []keccakTypes.InputData
-	for _, blockNum := range blockNums {
-		foundRelevantTx := false
-		block, err := f.source.BlockByNumber(ctx, new(big.Int).SetUint64(blockNum))
+	var pending []pendingBlock
+	pendingTxs := 0
+	for i, blockNum := range blockNums {
+		block, err := f.fetchBlockCandidates(ctx, signer, oracle, ident, blockNum)
 		if err != nil {
-			return nil, fmt.Errorf("failed getting tx for block %v: %w", blockNum, err)
+			return err
 		}
-		for _, tx := range block.Transactions() {
-			inputData, err := f.extractRelevantLeavesFromTx(ctx, oracle, signer, tx, ident)
-			if err != nil {
-				return nil, err
-			}
-			if inputData != nil {
-				foundRelevantTx = true
-				inputs = append(inputs, *inputData)
+		pending = append(pending, block)
+		pendingTxs += len(block.txHashes)
+		if pendingTxs < f.receiptBatchSize && i < len(blockNums)-1 {
+			continue
+		}
+		if err := f.processBlocks(ctx, pending, onInput); err != nil {
+			return err
+		}
+		pending = pending[:0]
+		pendingTxs = 0
+	}
+	return nil
+}
+
+// fetchBlockCandidates retrieves the transactions in the block that may have added inputs to the large preimage.
+func (f *InputFetcher) fetchBlockCandidates(ctx context.Context, signer types.Signer, oracle Oracle, ident keccakTypes.LargePreimageIdent, blockNum uint64) (pendingBlock, error) {
+	block, err := f.source.BlockByNumber(ctx, new(big.Int).SetUint64(blockNum))
+	if err != nil {
+		return pendingBlock{}, fmt.Errorf("failed getting tx for block %v: %w", blockNum, err)
+	}
+	pending := pendingBlock{num: blockNum}
+	for _, tx := range block.Transactions() {
+		inputData, err := f.extractRelevantLeavesFromTx(oracle, signer, tx, ident)
+		if err != nil {
+			return pendingBlock{}, err
+		}
+		if inputData != nil {
+			pending.txHashes = append(pending.txHashes, tx.Hash())
+			pending.candidates = append(pending.candidates, *inputData)
+		}
+	}
+	if len(pending.candidates) == 0 {
+		// The contract said there was a relevant transaction in this block that we failed to find.
+		// There was either a reorg or the extraction logic is broken.
+		// Either way, abort this attempt to validate the preimage.
+		return pendingBlock{}, fmt.Errorf("%w %v", ErrNoLeavesFound, blockNum)
+	}
+	return pending, nil
+}
+
+// processBlocks retrieves the receipts for all candidate transactions in blocks together so the source can batch
+// the requests, then passes the inputs added by successful transactions to onInput.
+func (f *InputFetcher) processBlocks(ctx context.Context, blocks []pendingBlock, onInput func(input keccakTypes.InputData) error) error {
+	var txHashes []common.Hash
+	for _, block := range blocks {
+		txHashes = append(txHashes, block.txHashes...)
+	}
+	rcpts, err := f.source.TransactionReceipts(ctx, txHashes)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve receipts: %w", err)
+	}
+	if len(rcpts) != len(txHashes) {
+		return fmt.Errorf("expected %v receipts but got %v", len(txHashes), len(rcpts))
+	}
+	for _, block := range blocks {
+		blockRcpts := rcpts[:len(block.txHashes)]
+		rcpts = rcpts[len(block.txHashes):]
+		var inputs []keccakTypes.InputData
+		for i, input := range block.candidates {
+			if blockRcpts[i].Status != types.ReceiptStatusSuccessful {
+				f.log.Trace("Skipping transaction with failed receipt status", "tx", block.txHashes[i], "status", blockRcpts[i].Status)
+				continue
 			}
+			inputs = append(inputs, input)
 		}
-		if !foundRelevantTx {
-			// The contract said there was a relevant transaction in this block that we failed to find.
-			// There was either a reorg or the extraction logic is broken.
-			// Either way, abort this attempt to validate the preimage.
-			return nil, fmt.Errorf("%w %v", ErrNoLeavesFound, blockNum)
+		if len(inputs) == 0 {
+			return fmt.Errorf("%w %v", ErrNoLeavesFound, block.num)
+		}
+		for _, input := range inputs {
+			if err := onInput(input); err != nil {
+				return err
+			}
 		}
 	}
-	return inputs, nil
+	return nil
 }
 
-func (f *InputFetcher) extractRelevantLeavesFromTx(ctx context.Context, oracle Oracle, signer types.Signer, tx *types.Transaction, ident keccakTypes.LargePreimageIdent) (*keccakTypes.InputData, error) {
+func (f *InputFetcher) extractRelevantLeavesFromTx(oracle Oracle, signer types.Signer, tx *types.Transaction, ident keccakTypes.LargePreimageIdent) (*keccakTypes.InputData, error) {
 	if tx.To() == nil || *tx.To() != oracle.Addr() {
 		f.log.Trace("Skip tx with incorrect to addr", "tx", tx.Hash(), "expected", oracle.Addr(), "actual", tx.To())
 		return nil, nil
@@ -97,20 +168,15 @@ func (f *InputFetcher) extractRelevantLeavesFromTx(ctx context.Context, oracle O
 		f.log.Trace("Skipping transaction with incorrect sender", "tx", tx.Hash(), "expected", ident.Claimant, "actual", sender)
 		return nil, nil
 	}
-	rcpt, err := f.source.TransactionReceipt(ctx, tx.Hash())
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve receipt for tx %v: %w", tx.Hash(), err)
-	}
-	if rcpt.Status != types.ReceiptStatusSuccessful {
-		f.log.Trace("Skipping transaction with failed receipt status", "tx", tx.Hash(), "status", rcpt.Status)
-		return nil, nil
-	}
 	return &inputData, nil
 }
 
-func NewPreimageFetcher(logger log.Logger, source L1Source) *InputFetcher {
+// NewPreimageFetcher creates an InputFetcher that retrieves the receipts of at least receiptBatchSize transactions
+// together where the proposal spans enough blocks.
+func NewPreimageFetcher(logger log.Logger, source L1Source, receiptBatchSize uint) *InputFetcher {
 	return &InputFetcher{
-		log:    logger,
-		source: source,
+		log:              logger,
+		source:           source,
+		receiptBatchSize: int(max(receiptBatchSize, 1)),
 	}
 }
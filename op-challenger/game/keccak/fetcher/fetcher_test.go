@@ -49,7 +49,7 @@ var (
 func TestFetchLeaves_NoBlocks(t *testing.T) {
 	fetcher, oracle, _ := setupFetcherTest(t)
 	oracle.leafBlocks = []uint64{}
-	leaves, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	leaves, err := fetchAllInputs(fetcher, oracle)
 	require.NoError(t, err)
 	require.Empty(t, leaves)
 }
@@ -59,7 +59,7 @@ func TestFetchLeaves_SingleTx(t *testing.T) {
 	blockNum := uint64(7)
 	oracle.leafBlocks = []uint64{blockNum}
 	l1Source.txs[blockNum] = types.Transactions{oracle.txForInput(ValidTx, input1)}
-	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	inputs, err := fetchAllInputs(fetcher, oracle)
 	require.NoError(t, err)
 	require.Equal(t, []keccakTypes.InputData{input1}, inputs)
 }
@@ -73,9 +73,61 @@ func TestFetchLeaves_MultipleBlocksAndLeaves(t *testing.T) {
 	l1Source.txs[block1] = types.Transactions{oracle.txForInput(ValidTx, input1)}
 	l1Source.txs[block2] = types.Transactions{oracle.txForInput(ValidTx, input2)}
 	l1Source.txs[block3] = types.Transactions{oracle.txForInput(ValidTx, input3), oracle.txForInput(ValidTx, input4)}
-	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	inputs, err := fetchAllInputs(fetcher, oracle)
 	require.NoError(t, err)
 	require.Equal(t, []keccakTypes.InputData{input1, input2, input3, input4}, inputs)
+	require.Equal(t, 3, l1Source.rcptRequests, "should request the receipts of each block together")
+}
+
+func TestFetchLeaves_RequestsReceiptsAcrossBlocks(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	fetcher.receiptBatchSize = 2
+	block1 := uint64(7)
+	block2 := uint64(15)
+	block3 := uint64(20)
+	oracle.leafBlocks = []uint64{block1, block2, block3}
+	l1Source.txs[block1] = types.Transactions{oracle.txForInput(ValidTx, input1)}
+	l1Source.txs[block2] = types.Transactions{oracle.txForInput(ValidTx, input2)}
+	l1Source.txs[block3] = types.Transactions{oracle.txForInput(ValidTx, input3)}
+	var blocksFetched []int
+	err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident, func(input keccakTypes.InputData) error {
+		blocksFetched = append(blocksFetched, l1Source.blockRequests)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 2, 3}, blocksFetched, "should fetch blocks until a full batch of receipts is available")
+	require.Equal(t, 2, l1Source.rcptRequests, "should request receipts from multiple blocks together")
+}
+
+func TestFetchLeaves_YieldsInputsOneBlockAtATime(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	block1 := uint64(7)
+	block2 := uint64(15)
+	oracle.leafBlocks = []uint64{block1, block2}
+	l1Source.txs[block1] = types.Transactions{oracle.txForInput(ValidTx, input1), oracle.txForInput(ValidTx, input2)}
+	l1Source.txs[block2] = types.Transactions{oracle.txForInput(ValidTx, input3)}
+	var blocksFetched []int
+	err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident, func(input keccakTypes.InputData) error {
+		blocksFetched = append(blocksFetched, l1Source.blockRequests)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 1, 2}, blocksFetched, "should pass on inputs before fetching later blocks")
+}
+
+func TestFetchLeaves_StopsOnInputError(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	block1 := uint64(7)
+	block2 := uint64(15)
+	oracle.leafBlocks = []uint64{block1, block2}
+	l1Source.txs[block1] = types.Transactions{oracle.txForInput(ValidTx, input1)}
+	l1Source.txs[block2] = types.Transactions{oracle.txForInput(ValidTx, input2)}
+	inputErr := errors.New("boom")
+	err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident, func(input keccakTypes.InputData) error {
+		return inputErr
+	})
+	require.ErrorIs(t, err, inputErr)
+	require.Equal(t, 1, l1Source.blockRequests, "should not fetch later blocks")
 }
 
 func TestFetchLeaves_SkipTxToWrongContract(t *testing.T) {
@@ -89,7 +141,7 @@ func TestFetchLeaves_SkipTxToWrongContract(t *testing.T) {
 	// Valid tx to the correct contract
 	tx3 := oracle.txForInput(ValidTx, input1)
 	l1Source.txs[blockNum] = types.Transactions{tx1, tx2, tx3}
-	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	inputs, err := fetchAllInputs(fetcher, oracle)
 	require.NoError(t, err)
 	require.Equal(t, []keccakTypes.InputData{input1}, inputs)
 }
@@ -103,7 +155,7 @@ func TestFetchLeaves_SkipTxWithDifferentUUID(t *testing.T) {
 	// Valid tx
 	tx2 := oracle.txForInput(ValidTx, input1)
 	l1Source.txs[blockNum] = types.Transactions{tx1, tx2}
-	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	inputs, err := fetchAllInputs(fetcher, oracle)
 	require.NoError(t, err)
 	require.Equal(t, []keccakTypes.InputData{input1}, inputs)
 }
@@ -117,7 +169,7 @@ func TestFetchLeaves_SkipTxWithInvalidCall(t *testing.T) {
 	// Valid tx
 	tx2 := oracle.txForInput(ValidTx, input1)
 	l1Source.txs[blockNum] = types.Transactions{tx1, tx2}
-	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	inputs, err := fetchAllInputs(fetcher, oracle)
 	require.NoError(t, err)
 	require.Equal(t, []keccakTypes.InputData{input1}, inputs)
 }
@@ -134,7 +186,7 @@ func TestFetchLeaves_SkipTxWithInvalidSender(t *testing.T) {
 	// Valid tx
 	tx3 := oracle.txForInput(ValidTx, input1)
 	l1Source.txs[blockNum] = types.Transactions{tx1, tx2, tx3}
-	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	inputs, err := fetchAllInputs(fetcher, oracle)
 	require.NoError(t, err)
 	require.Equal(t, []keccakTypes.InputData{input1}, inputs)
 }
@@ -149,7 +201,7 @@ func TestFetchLeaves_SkipTxWithReceiptStatusFail(t *testing.T) {
 	// Valid tx
 	tx2 := oracle.txForInput(ValidTx, input1)
 	l1Source.txs[blockNum] = types.Transactions{tx1, tx2}
-	inputs, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	inputs, err := fetchAllInputs(fetcher, oracle)
 	require.NoError(t, err)
 	require.Equal(t, []keccakTypes.InputData{input1}, inputs)
 }
@@ -162,10 +214,32 @@ func TestFetchLeaves_ErrorsWhenNoValidLeavesInBlock(t *testing.T) {
 	tx1 := oracle.txForInput(WithUUID(big.NewInt(492)), input2)
 	l1Source.rcptStatus[tx1.Hash()] = types.ReceiptStatusFailed
 	l1Source.txs[blockNum] = types.Transactions{tx1}
-	_, err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident)
+	_, err := fetchAllInputs(fetcher, oracle)
+	require.ErrorIs(t, err, ErrNoLeavesFound)
+}
+
+func TestFetchLeaves_ErrorsWhenAllLeavesInBlockFailed(t *testing.T) {
+	fetcher, oracle, l1Source := setupFetcherTest(t)
+	block1 := uint64(7)
+	block2 := uint64(8)
+	oracle.leafBlocks = []uint64{block1, block2}
+	l1Source.txs[block1] = types.Transactions{oracle.txForInput(ValidTx, input1)}
+	tx := oracle.txForInput(ValidTx, input2)
+	l1Source.rcptStatus[tx.Hash()] = types.ReceiptStatusFailed
+	l1Source.txs[block2] = types.Transactions{tx}
+	_, err := fetchAllInputs(fetcher, oracle)
 	require.ErrorIs(t, err, ErrNoLeavesFound)
 }
 
+func fetchAllInputs(fetcher *InputFetcher, oracle Oracle) ([]keccakTypes.InputData, error) {
+	var inputs []keccakTypes.InputData
+	err := fetcher.FetchInputs(context.Background(), blockHash, oracle, ident, func(input keccakTypes.InputData) error {
+		inputs = append(inputs, input)
+		return nil
+	})
+	return inputs, err
+}
+
 func setupFetcherTest(t *testing.T) (*InputFetcher, *stubOracle, *stubL1Source) {
 	oracle := &stubOracle{
 		txInputs: make(map[byte]keccakTypes.InputData),
@@ -174,7 +248,7 @@ func setupFetcherTest(t *testing.T) (*InputFetcher, *stubOracle, *stubL1Source)
 		txs:        make(map[uint64]types.Transactions),
 		rcptStatus: make(map[common.Hash]uint64),
 	}
-	fetcher := NewPreimageFetcher(testlog.Logger(t, log.LvlTrace), l1Source)
+	fetcher := NewPreimageFetcher(testlog.Logger(t, log.LvlTrace), l1Source, 1)
 	return fetcher, oracle, l1Source
 }
 
@@ -275,8 +349,10 @@ func (o *stubOracle) txForInput(txMod TxModifier, input keccakTypes.InputData) *
 }
 
 type stubL1Source struct {
-	txs        map[uint64]types.Transactions
-	rcptStatus map[common.Hash]uint64
+	txs           map[uint64]types.Transactions
+	rcptStatus    map[common.Hash]uint64
+	rcptRequests  int
+	blockRequests int
 }
 
 func (s *stubL1Source) ChainID(_ context.Context) (*big.Int, error) {
@@ -284,6 +360,7 @@ func (s *stubL1Source) ChainID(_ context.Context) (*big.Int, error) {
 }
 
 func (s *stubL1Source) BlockByNumber(_ context.Context, number *big.Int) (*types.Block, error) {
+	s.blockRequests++
 	txs, ok := s.txs[number.Uint64()]
 	if !ok {
 		return nil, errors.New("not found")
@@ -291,10 +368,15 @@ func (s *stubL1Source) BlockByNumber(_ context.Context, number *big.Int) (*types
 	return (&types.Block{}).WithBody(txs, nil), nil
 }
 
-func (s *stubL1Source) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
-	rcptStatus, ok := s.rcptStatus[txHash]
-	if !ok {
-		rcptStatus = types.ReceiptStatusSuccessful
+func (s *stubL1Source) TransactionReceipts(_ context.Context, txHashes []common.Hash) ([]*types.Receipt, error) {
+	s.rcptRequests++
+	rcpts := make([]*types.Receipt, len(txHashes))
+	for i, txHash := range txHashes {
+		rcptStatus, ok := s.rcptStatus[txHash]
+		if !ok {
+			rcptStatus = types.ReceiptStatusSuccessful
+		}
+		rcpts[i] = &types.Receipt{TxHash: txHash, Status: rcptStatus}
 	}
-	return &types.Receipt{Status: rcptStatus}, nil
+	return rcpts, nil
 }
@@ -0,0 +1,55 @@
+package keccak
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type GasEstimator interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+// ChallengeGasLimiter estimates the gas limit for challenge transactions.
+// The estimate is padded by a percentage to allow for variation in gas usage between estimation and inclusion,
+// which is significant when the state matrix proof is large, and then capped at a maximum gas limit.
+type ChallengeGasLimiter struct {
+	log            log.Logger
+	client         GasEstimator
+	from           common.Address
+	paddingPercent uint64
+	cap            uint64
+}
+
+func NewChallengeGasLimiter(logger log.Logger, client GasEstimator, from common.Address, paddingPercent uint64, cap uint64) *ChallengeGasLimiter {
+	return &ChallengeGasLimiter{
+		log:            logger,
+		client:         client,
+		from:           from,
+		paddingPercent: paddingPercent,
+		cap:            cap,
+	}
+}
+
+// GasLimit returns the padded and capped gas limit to use for tx.
+func (l *ChallengeGasLimiter) GasLimit(ctx context.Context, tx txmgr.TxCandidate) (uint64, error) {
+	estimate, err := l.client.EstimateGas(ctx, ethereum.CallMsg{
+		From:  l.from,
+		To:    tx.To,
+		Value: tx.Value,
+		Data:  tx.TxData,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	limit := estimate + estimate*l.paddingPercent/100
+	if limit > l.cap {
+		l.log.Warn("Capping challenge transaction gas limit", "estimate", estimate, "padded", limit, "cap", l.cap)
+		limit = l.cap
+	}
+	return limit, nil
+}
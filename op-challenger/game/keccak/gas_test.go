@@ -0,0 +1,57 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeGasLimiter(t *testing.T) {
+	from := common.Address{0xaa}
+	to := common.Address{0xbb}
+	tx := txmgr.TxCandidate{To: &to, TxData: []byte{0x01, 0x02}}
+
+	t.Run("AddPadding", func(t *testing.T) {
+		client := &stubGasEstimator{estimate: 100_000}
+		limiter := NewChallengeGasLimiter(testlog.Logger(t, log.LvlInfo), client, from, 25, 1_000_000)
+		gas, err := limiter.GasLimit(context.Background(), tx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(125_000), gas)
+		require.Equal(t, from, client.msg.From)
+		require.Equal(t, &to, client.msg.To)
+		require.Equal(t, tx.TxData, client.msg.Data)
+	})
+
+	t.Run("ApplyCap", func(t *testing.T) {
+		client := &stubGasEstimator{estimate: 900_000}
+		limiter := NewChallengeGasLimiter(testlog.Logger(t, log.LvlInfo), client, from, 25, 1_000_000)
+		gas, err := limiter.GasLimit(context.Background(), tx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1_000_000), gas)
+	})
+
+	t.Run("EstimationFails", func(t *testing.T) {
+		client := &stubGasEstimator{err: errors.New("execution reverted")}
+		limiter := NewChallengeGasLimiter(testlog.Logger(t, log.LvlInfo), client, from, 25, 1_000_000)
+		_, err := limiter.GasLimit(context.Background(), tx)
+		require.ErrorIs(t, err, client.err)
+	})
+}
+
+type stubGasEstimator struct {
+	estimate uint64
+	err      error
+	msg      ethereum.CallMsg
+}
+
+func (s *stubGasEstimator) EstimateGas(_ context.Context, msg ethereum.CallMsg) (uint64, error) {
+	s.msg = msg
+	return s.estimate, s.err
+}
@@ -0,0 +1,123 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type L1HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type InclusionMetrics interface {
+	RecordPreimageChallengeReorged()
+	RecordPreimageInclusionCheckFailed()
+}
+
+type inclusion struct {
+	blockNum  uint64
+	blockHash common.Hash
+}
+
+// ChallengeInclusionTracker is a Sender that tracks the L1 blocks that challenge transactions were included in
+// until they are finalized.
+// If an inclusion block is reorged out before finality the proposal is no longer countered on the canonical chain.
+// CheckInclusions reports the reorg and must be called before verifying proposals so that the subsequent
+// verification re-submits the challenge.
+type ChallengeInclusionTracker struct {
+	log     log.Logger
+	metrics InclusionMetrics
+	sender  Sender
+	l1      L1HeaderSource
+
+	lock     sync.Mutex
+	included map[common.Hash]inclusion
+}
+
+func NewChallengeInclusionTracker(logger log.Logger, metrics InclusionMetrics, sender Sender, l1 L1HeaderSource) *ChallengeInclusionTracker {
+	return &ChallengeInclusionTracker{
+		log:      logger,
+		metrics:  metrics,
+		sender:   sender,
+		l1:       l1,
+		included: make(map[common.Hash]inclusion),
+	}
+}
+
+// SendAndWait sends the transactions using the underlying sender and tracks the inclusion block of each
+// successful transaction.
+func (t *ChallengeInclusionTracker) SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*types.Receipt, error) {
+	rcpts, err := t.sender.SendAndWait(txPurpose, txs...)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, rcpt := range rcpts {
+		if rcpt == nil || rcpt.Status != types.ReceiptStatusSuccessful || rcpt.BlockNumber == nil {
+			continue
+		}
+		t.included[rcpt.TxHash] = inclusion{blockNum: rcpt.BlockNumber.Uint64(), blockHash: rcpt.BlockHash}
+	}
+	return rcpts, err
+}
+
+// CheckInclusions checks that the inclusion block of each tracked challenge transaction is still canonical.
+// Transactions included in finalized blocks are no longer tracked.
+// Transactions that could not be checked remain tracked and are checked again on the next call. The failure is
+// recorded in metrics and returned.
+func (t *ChallengeInclusionTracker) CheckInclusions(ctx context.Context) error {
+	// Check a copy of the tracked transactions so the lock is not held while making RPC requests.
+	t.lock.Lock()
+	included := maps.Clone(t.included)
+	t.lock.Unlock()
+	if len(included) == 0 {
+		return nil
+	}
+	finalized, err := t.l1.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		t.metrics.RecordPreimageInclusionCheckFailed()
+		return fmt.Errorf("failed to retrieve finalized L1 head: %w", err)
+	}
+	var errs error
+	for txHash, incl := range included {
+		header, err := t.l1.HeaderByNumber(ctx, new(big.Int).SetUint64(incl.blockNum))
+		if errors.Is(err, ethereum.NotFound) {
+			header = nil
+		} else if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to retrieve L1 block %v: %w", incl.blockNum, err))
+			continue
+		}
+		if header == nil || header.Hash() != incl.blockHash {
+			t.log.Warn("Preimage challenge transaction reorged out, will re-challenge", "tx", txHash, "block", incl.blockNum, "blockHash", incl.blockHash)
+			t.metrics.RecordPreimageChallengeReorged()
+			t.untrack(txHash, incl)
+			continue
+		}
+		if incl.blockNum <= finalized.Number.Uint64() {
+			t.log.Debug("Preimage challenge transaction finalized", "tx", txHash, "block", incl.blockNum)
+			t.untrack(txHash, incl)
+		}
+	}
+	if errs != nil {
+		t.metrics.RecordPreimageInclusionCheckFailed()
+	}
+	return errs
+}
+
+// untrack stops tracking the transaction, unless it was included again in a different block while being checked.
+func (t *ChallengeInclusionTracker) untrack(txHash common.Hash, incl inclusion) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.included[txHash] == incl {
+		delete(t.included, txHash)
+	}
+}
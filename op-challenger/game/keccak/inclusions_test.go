@@ -0,0 +1,150 @@
+package keccak
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChallengeInclusionTracker(t *testing.T) {
+	setup := func(t *testing.T, rcpts ...*types.Receipt) (*ChallengeInclusionTracker, *stubHeaderSource, *stubInclusionMetrics, *testlog.CapturingHandler) {
+		logger := testlog.Logger(t, log.LvlDebug)
+		logs := testlog.Capture(logger)
+		l1 := &stubHeaderSource{headers: make(map[uint64]*types.Header)}
+		metrics := &stubInclusionMetrics{}
+		tracker := NewChallengeInclusionTracker(logger, metrics, &receiptSender{rcpts: rcpts}, l1)
+		_, err := tracker.SendAndWait("challenge", make([]txmgr.TxCandidate, len(rcpts))...)
+		require.NoError(t, err)
+		return tracker, l1, metrics, logs
+	}
+	newHeader := func(num uint64, extra byte) *types.Header {
+		return &types.Header{Number: new(big.Int).SetUint64(num), Extra: []byte{extra}}
+	}
+
+	t.Run("NothingTracked", func(t *testing.T) {
+		tracker, _, metrics, _ := setup(t)
+		require.NoError(t, tracker.CheckInclusions(context.Background()))
+		require.Zero(t, metrics.reorged)
+	})
+
+	t.Run("StillCanonical", func(t *testing.T) {
+		block := newHeader(10, 1)
+		tracker, l1, metrics, _ := setup(t, includedRcpt(common.Hash{0xaa}, block))
+		l1.headers[10] = block
+		l1.finalized = newHeader(5, 0)
+		require.NoError(t, tracker.CheckInclusions(context.Background()))
+		require.Zero(t, metrics.reorged)
+		require.Len(t, tracker.included, 1, "should track until finalized")
+	})
+
+	t.Run("Finalized", func(t *testing.T) {
+		block := newHeader(10, 1)
+		tracker, l1, metrics, _ := setup(t, includedRcpt(common.Hash{0xaa}, block))
+		l1.headers[10] = block
+		l1.finalized = newHeader(10, 0)
+		require.NoError(t, tracker.CheckInclusions(context.Background()))
+		require.Zero(t, metrics.reorged)
+		require.Empty(t, tracker.included)
+	})
+
+	t.Run("Reorged", func(t *testing.T) {
+		block := newHeader(10, 1)
+		tracker, l1, metrics, logs := setup(t, includedRcpt(common.Hash{0xaa}, block), includedRcpt(common.Hash{0xbb}, newHeader(11, 1)))
+		l1.headers[10] = newHeader(10, 2)
+		l1.finalized = newHeader(5, 0)
+		// Block 11 no longer exists
+		require.NoError(t, tracker.CheckInclusions(context.Background()))
+		require.Equal(t, 2, metrics.reorged)
+		require.Empty(t, tracker.included)
+		require.NotNil(t, logs.FindLog(log.LvlWarn, "Preimage challenge transaction reorged out, will re-challenge"))
+	})
+
+	t.Run("FinalizedHeadUnavailable", func(t *testing.T) {
+		tracker, _, metrics, _ := setup(t, includedRcpt(common.Hash{0xaa}, newHeader(10, 1)))
+		require.ErrorContains(t, tracker.CheckInclusions(context.Background()), "finalized L1 head")
+		require.Equal(t, 1, metrics.checkFailed)
+		require.Len(t, tracker.included, 1, "should keep tracking to retry")
+	})
+
+	t.Run("ContinueCheckingAfterFailure", func(t *testing.T) {
+		tracker, l1, metrics, _ := setup(t, includedRcpt(common.Hash{0xaa}, newHeader(10, 1)), includedRcpt(common.Hash{0xbb}, newHeader(11, 1)))
+		l1.finalized = newHeader(5, 0)
+		l1.errs = map[uint64]error{10: errors.New("boom")}
+		// Block 11 no longer exists
+		require.ErrorContains(t, tracker.CheckInclusions(context.Background()), "boom")
+		require.Equal(t, 1, metrics.checkFailed)
+		require.Equal(t, 1, metrics.reorged, "should still detect reorgs of other transactions")
+		require.Len(t, tracker.included, 1, "should keep tracking the unchecked transaction to retry")
+		require.Contains(t, tracker.included, common.Hash{0xaa})
+	})
+
+	t.Run("IgnoreFailedTransactions", func(t *testing.T) {
+		rcpt := includedRcpt(common.Hash{0xaa}, newHeader(10, 1))
+		rcpt.Status = types.ReceiptStatusFailed
+		tracker, _, _, _ := setup(t, rcpt)
+		require.Empty(t, tracker.included)
+	})
+}
+
+func includedRcpt(txHash common.Hash, block *types.Header) *types.Receipt {
+	return &types.Receipt{
+		TxHash:      txHash,
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: block.Number,
+		BlockHash:   block.Hash(),
+	}
+}
+
+type receiptSender struct {
+	rcpts []*types.Receipt
+}
+
+func (s *receiptSender) SendAndWait(_ string, _ ...txmgr.TxCandidate) ([]*types.Receipt, error) {
+	return s.rcpts, nil
+}
+
+type stubHeaderSource struct {
+	finalized *types.Header
+	headers   map[uint64]*types.Header
+	errs      map[uint64]error
+}
+
+func (s *stubHeaderSource) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if number.Int64() == int64(rpc.FinalizedBlockNumber) {
+		if s.finalized == nil {
+			return nil, errors.New("finalized head unavailable")
+		}
+		return s.finalized, nil
+	}
+	if err, ok := s.errs[number.Uint64()]; ok {
+		return nil, err
+	}
+	header, ok := s.headers[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+type stubInclusionMetrics struct {
+	reorged     int
+	checkFailed int
+}
+
+func (s *stubInclusionMetrics) RecordPreimageChallengeReorged() {
+	s.reorged++
+}
+
+func (s *stubInclusionMetrics) RecordPreimageInclusionCheckFailed() {
+	s.checkFailed++
+}
@@ -2,7 +2,6 @@ package matrix
 
 import (
 	"errors"
-	"fmt"
 	"io"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
@@ -35,56 +34,11 @@ var (
 // Challenge creates a [types.Challenge] to invalidate the provided preimage data if possible.
 // [ErrValid] is returned if the provided inputs are valid and no challenge can be created.
 func Challenge(data io.Reader, commitments []common.Hash) (types.Challenge, error) {
-	s := NewStateMatrix()
-	lastValidState := s.StateSnapshot()
-	var lastValidLeaf types.Leaf
-	var firstInvalidLeaf types.Leaf
-	for i := 0; ; i++ {
-		if i >= len(commitments) {
-			// There should have been more commitments.
-			// The contracts should prevent this so it can't be challenged, return an error
-			return types.Challenge{}, ErrIncorrectCommitmentCount
-		}
-		claimedCommitment := commitments[i]
-		_, err := s.absorbNextLeafInput(data, func() common.Hash { return claimedCommitment })
-		isEOF := errors.Is(err, io.EOF)
-		if err != nil && !isEOF {
-			return types.Challenge{}, fmt.Errorf("failed to verify inputs: %w", err)
-		}
-		validCommitment := s.StateCommitment()
-
-		if firstInvalidLeaf == (types.Leaf{}) {
-			if validCommitment != claimedCommitment {
-				lastValidLeaf = s.prestateLeaf
-				firstInvalidLeaf = s.poststateLeaf
-			} else {
-				lastValidState = s.StateSnapshot()
-			}
-		}
-		if isEOF {
-			if i < len(commitments)-1 {
-				// We got too many commitments
-				// The contracts should prevent this so it can't be challenged, return an error
-				return types.Challenge{}, ErrIncorrectCommitmentCount
-			}
-			break
-		}
-	}
-	if firstInvalidLeaf != (types.Leaf{}) {
-		var prestateProof merkle.Proof
-		if lastValidLeaf != (types.Leaf{}) {
-			prestateProof = s.merkleTree.ProofAtIndex(lastValidLeaf.Index)
-		}
-		poststateProof := s.merkleTree.ProofAtIndex(firstInvalidLeaf.Index)
-		return types.Challenge{
-			StateMatrix:    lastValidState,
-			Prestate:       lastValidLeaf,
-			PrestateProof:  prestateProof,
-			Poststate:      firstInvalidLeaf,
-			PoststateProof: poststateProof,
-		}, nil
+	s := NewStream(0, nil)
+	if err := s.Absorb(data, commitments, true); err != nil {
+		return types.Challenge{}, err
 	}
-	return types.Challenge{}, ErrValid
+	return s.Result()
 }
 
 // NewStateMatrix creates a new state matrix initialized with the initial, zero keccak block.
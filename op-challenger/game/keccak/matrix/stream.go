@@ -0,0 +1,147 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrStreamFinalized    = errors.New("stream already finalized")
+	ErrStreamNotFinalized = errors.New("stream not finalized")
+)
+
+// Snapshot is the state of the keccak sponge after absorbing a leaf.
+type Snapshot struct {
+	// Index is the index of the last leaf absorbed.
+	Index uint64
+	// StateMatrix is the state matrix after absorbing the leaf.
+	StateMatrix types.StateSnapshot
+	// StateCommitment is the commitment to StateMatrix.
+	StateCommitment common.Hash
+}
+
+// SnapshotHandler is called with each snapshot emitted by a [Stream].
+type SnapshotHandler func(snapshot Snapshot)
+
+// Stream incrementally verifies preimage data against claimed state commitments.
+// Unlike [Challenge], input data does not need to be held in memory in full. Data can be absorbed in
+// chunks as it becomes available and only the merkle tree of leaves is retained.
+type Stream struct {
+	s *StateMatrix
+
+	snapshotInterval uint64
+	onSnapshot       SnapshotHandler
+
+	lastValidState   types.StateSnapshot
+	lastValidLeaf    types.Leaf
+	firstInvalidLeaf types.Leaf
+	leafCount        uint64
+	finalized        bool
+}
+
+// NewStream creates a new Stream. If snapshotInterval is non-zero, onSnapshot is called after every
+// snapshotInterval leaves are absorbed.
+func NewStream(snapshotInterval uint64, onSnapshot SnapshotHandler) *Stream {
+	s := NewStateMatrix()
+	return &Stream{
+		s:                s,
+		snapshotInterval: snapshotInterval,
+		onSnapshot:       onSnapshot,
+		lastValidState:   s.StateSnapshot(),
+	}
+}
+
+// Absorb reads one leaf of input from in for each of the supplied claimed commitments.
+// If final is false, in must supply exactly len(commitments)*[types.BlockSize] bytes.
+// If final is true, leaves are absorbed until in reaches EOF and the final leaf is padded. No further data
+// can be absorbed after a final chunk.
+// [ErrIncorrectCommitmentCount] is returned if the number of commitments does not match the input length.
+func (s *Stream) Absorb(in io.Reader, commitments []common.Hash, final bool) error {
+	if s.finalized {
+		return ErrStreamFinalized
+	}
+	for i := 0; ; i++ {
+		if i >= len(commitments) {
+			if final {
+				// There should have been more commitments.
+				// The contracts should prevent this so it can't be challenged, return an error
+				return ErrIncorrectCommitmentCount
+			}
+			return nil
+		}
+		claimedCommitment := commitments[i]
+		_, err := s.s.absorbNextLeafInput(in, func() common.Hash { return claimedCommitment })
+		isEOF := errors.Is(err, io.EOF)
+		if err != nil && !isEOF {
+			return fmt.Errorf("failed to verify inputs: %w", err)
+		}
+		if isEOF && !final {
+			// Non-final chunks must be a whole number of blocks
+			return ErrIncorrectCommitmentCount
+		}
+		s.recordLeaf(claimedCommitment)
+		if isEOF {
+			s.finalized = true
+			if i < len(commitments)-1 {
+				// We got too many commitments
+				// The contracts should prevent this so it can't be challenged, return an error
+				return ErrIncorrectCommitmentCount
+			}
+			return nil
+		}
+	}
+}
+
+func (s *Stream) recordLeaf(claimedCommitment common.Hash) {
+	validCommitment := s.s.StateCommitment()
+	if s.firstInvalidLeaf == (types.Leaf{}) {
+		if validCommitment != claimedCommitment {
+			s.lastValidLeaf = s.s.prestateLeaf
+			s.firstInvalidLeaf = s.s.poststateLeaf
+		} else {
+			s.lastValidState = s.s.StateSnapshot()
+		}
+	}
+	s.leafCount++
+	if s.snapshotInterval > 0 && s.onSnapshot != nil && s.leafCount%s.snapshotInterval == 0 {
+		s.onSnapshot(Snapshot{
+			Index:           s.s.poststateLeaf.Index,
+			StateMatrix:     s.s.StateSnapshot(),
+			StateCommitment: validCommitment,
+		})
+	}
+}
+
+// Finalized returns true once the final chunk of data has been absorbed.
+func (s *Stream) Finalized() bool {
+	return s.finalized
+}
+
+// Result returns a [types.Challenge] to invalidate the absorbed data if possible.
+// [ErrValid] is returned if all absorbed data is valid and no challenge can be created.
+// The stream must be finalized as the merkle proofs in the challenge depend on all leaves.
+func (s *Stream) Result() (types.Challenge, error) {
+	if !s.finalized {
+		return types.Challenge{}, ErrStreamNotFinalized
+	}
+	if s.firstInvalidLeaf != (types.Leaf{}) {
+		var prestateProof merkle.Proof
+		if s.lastValidLeaf != (types.Leaf{}) {
+			prestateProof = s.s.merkleTree.ProofAtIndex(s.lastValidLeaf.Index)
+		}
+		poststateProof := s.s.merkleTree.ProofAtIndex(s.firstInvalidLeaf.Index)
+		return types.Challenge{
+			StateMatrix:    s.lastValidState,
+			Prestate:       s.lastValidLeaf,
+			PrestateProof:  prestateProof,
+			Poststate:      s.firstInvalidLeaf,
+			PoststateProof: poststateProof,
+		}, nil
+	}
+	return types.Challenge{}, ErrValid
+}
@@ -0,0 +1,103 @@
+package matrix
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStream_MatchesChallenge(t *testing.T) {
+	preimage := testutils.RandomData(rand.New(rand.NewSource(5454)), 10*types.BlockSize+37)
+	valid, err := NewStateMatrix().AbsorbUpTo(bytes.NewReader(preimage), 1000*types.BlockSize)
+	require.ErrorIs(t, err, io.EOF)
+
+	absorbInChunks := func(t *testing.T, commitments []common.Hash, leavesPerChunk int) (types.Challenge, error) {
+		s := NewStream(0, nil)
+		for start := 0; start < len(commitments); start += leavesPerChunk {
+			end := min(start+leavesPerChunk, len(commitments))
+			final := end == len(commitments)
+			dataEnd := min(end*types.BlockSize, len(preimage))
+			require.NoError(t, s.Absorb(bytes.NewReader(preimage[start*types.BlockSize:dataEnd]), commitments[start:end], final))
+		}
+		require.True(t, s.Finalized())
+		return s.Result()
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		for _, chunkSize := range []int{1, 3, 4, 100} {
+			_, err := absorbInChunks(t, valid.Commitments, chunkSize)
+			require.ErrorIs(t, err, ErrValid)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, invalidIdx := range []int{0, 5, len(valid.Commitments) - 1} {
+			commitments := make([]common.Hash, len(valid.Commitments))
+			copy(commitments, valid.Commitments)
+			commitments[invalidIdx] = common.Hash{0xaa}
+			expected, err := Challenge(bytes.NewReader(preimage), commitments)
+			require.NoError(t, err)
+			for _, chunkSize := range []int{1, 3, 4, 100} {
+				actual, err := absorbInChunks(t, commitments, chunkSize)
+				require.NoError(t, err)
+				require.Equal(t, expected, actual)
+			}
+		}
+	})
+}
+
+func TestStream_Snapshots(t *testing.T) {
+	preimage := testutils.RandomData(rand.New(rand.NewSource(5454)), 10*types.BlockSize)
+	valid, err := NewStateMatrix().AbsorbUpTo(bytes.NewReader(preimage), 1000*types.BlockSize)
+	require.ErrorIs(t, err, io.EOF)
+
+	var snapshots []Snapshot
+	s := NewStream(3, func(snapshot Snapshot) {
+		snapshots = append(snapshots, snapshot)
+	})
+	require.NoError(t, s.Absorb(bytes.NewReader(preimage), valid.Commitments, true))
+	// 11 leaves including the final padding leaf
+	require.Len(t, snapshots, 3)
+	for i, snapshot := range snapshots {
+		expectedIdx := uint64((i+1)*3 - 1)
+		require.Equal(t, expectedIdx, snapshot.Index)
+		require.Equal(t, valid.Commitments[expectedIdx], snapshot.StateCommitment)
+	}
+}
+
+func TestStream_Errors(t *testing.T) {
+	preimage := testutils.RandomData(rand.New(rand.NewSource(5454)), 2*types.BlockSize)
+	valid, err := NewStateMatrix().AbsorbUpTo(bytes.NewReader(preimage), 1000*types.BlockSize)
+	require.ErrorIs(t, err, io.EOF)
+
+	t.Run("ResultBeforeFinalized", func(t *testing.T) {
+		s := NewStream(0, nil)
+		require.NoError(t, s.Absorb(bytes.NewReader(preimage[:types.BlockSize]), valid.Commitments[:1], false))
+		_, err := s.Result()
+		require.ErrorIs(t, err, ErrStreamNotFinalized)
+	})
+
+	t.Run("AbsorbAfterFinalized", func(t *testing.T) {
+		s := NewStream(0, nil)
+		require.NoError(t, s.Absorb(bytes.NewReader(preimage), valid.Commitments, true))
+		require.ErrorIs(t, s.Absorb(bytes.NewReader(nil), nil, true), ErrStreamFinalized)
+	})
+
+	t.Run("PartialNonFinalChunk", func(t *testing.T) {
+		s := NewStream(0, nil)
+		err := s.Absorb(bytes.NewReader(preimage[:types.BlockSize-1]), valid.Commitments[:1], false)
+		require.ErrorIs(t, err, ErrIncorrectCommitmentCount)
+	})
+
+	t.Run("TooFewCommitments", func(t *testing.T) {
+		s := NewStream(0, nil)
+		err := s.Absorb(bytes.NewReader(preimage), valid.Commitments[:1], true)
+		require.ErrorIs(t, err, ErrIncorrectCommitmentCount)
+	})
+}
@@ -1,17 +1,47 @@
 package keccak
 
 import (
+	"cmp"
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// ErrSchedulerStopped is returned by CheckHealth when the scheduler has stopped running.
+var ErrSchedulerStopped = errors.New("large preimage scheduler not running")
+
 type Challenger interface {
 	Challenge(ctx context.Context, blockHash common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData) error
+	ChallengeUrgent(ctx context.Context, blockHash common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData) error
+}
+
+type Clock interface {
+	Now() time.Time
+}
+
+type SchedulerMetrics interface {
+	RecordLargePreimageMinTimeRemaining(remaining time.Duration)
+	ClearLargePreimageMinTimeRemaining()
+}
+
+type VerifiedCache interface {
+	Prune(oracle common.Address, active []keccakTypes.LargePreimageMetaData) error
+}
+
+type StatusUpdater interface {
+	UpdateActive(oracle common.Address, active []keccakTypes.LargePreimageMetaData)
+}
+
+type InclusionChecker interface {
+	CheckInclusions(ctx context.Context) error
 }
 
 type LargePreimageScheduler struct {
@@ -19,16 +49,47 @@ type LargePreimageScheduler struct {
 	ch         chan common.Hash
 	oracles    []keccakTypes.LargePreimageOracle
 	challenger Challenger
-	cancel     func()
-	wg         sync.WaitGroup
+	cache      VerifiedCache
+	status     StatusUpdater
+	inclusions InclusionChecker
+	claimants  *ClaimantFilter
+	// urgentWindow is the time remaining in the challenge period below which proposals are challenged urgently
+	urgentWindow time.Duration
+	cl           Clock
+	metrics      SchedulerMetrics
+	cancel       func()
+	wg           sync.WaitGroup
+	started      atomic.Bool
+	running      atomic.Bool
+
+	// challengePeriods caches the challenge period of each oracle as it is immutable
+	challengePeriods map[common.Address]time.Duration
 }
 
-func NewLargePreimageScheduler(logger log.Logger, oracles []keccakTypes.LargePreimageOracle, challenger Challenger) *LargePreimageScheduler {
+// NewLargePreimageScheduler creates a scheduler to verify large preimage proposals in each of the supplied oracles.
+// Oracles are deduplicated by address and challenges are sent to the oracle the proposal was made to.
+func NewLargePreimageScheduler(logger log.Logger, cl Clock, metrics SchedulerMetrics, oracles []keccakTypes.LargePreimageOracle, challenger Challenger, cache VerifiedCache, status StatusUpdater, inclusions InclusionChecker, claimants *ClaimantFilter, urgentWindow time.Duration) *LargePreimageScheduler {
+	uniqueOracles := make([]keccakTypes.LargePreimageOracle, 0, len(oracles))
+	for _, oracle := range oracles {
+		if !slices.ContainsFunc(uniqueOracles, func(o keccakTypes.LargePreimageOracle) bool {
+			return o.Addr() == oracle.Addr()
+		}) {
+			uniqueOracles = append(uniqueOracles, oracle)
+		}
+	}
 	return &LargePreimageScheduler{
-		log:        logger,
-		ch:         make(chan common.Hash, 1),
-		oracles:    oracles,
-		challenger: challenger,
+		log:              logger,
+		ch:               make(chan common.Hash, 1),
+		oracles:          uniqueOracles,
+		challenger:       challenger,
+		cache:            cache,
+		status:           status,
+		inclusions:       inclusions,
+		claimants:        claimants,
+		urgentWindow:     urgentWindow,
+		cl:               cl,
+		metrics:          metrics,
+		challengePeriods: make(map[common.Address]time.Duration),
 	}
 }
 
@@ -36,10 +97,25 @@ func (s *LargePreimageScheduler) Start(ctx context.Context) {
 	ctx, cancel := context.WithCancel(ctx)
 	s.cancel = cancel
 	s.wg.Add(1)
+	s.running.Store(true)
+	s.started.Store(true)
 	go s.run(ctx)
 }
 
+// CheckHealth returns ErrSchedulerStopped if the scheduler was started and is no longer running.
+// A scheduler that has not been started yet is considered healthy so the challenger is not reported as
+// unhealthy while it is still starting up.
+func (s *LargePreimageScheduler) CheckHealth(_ context.Context) error {
+	if s.started.Load() && !s.running.Load() {
+		return ErrSchedulerStopped
+	}
+	return nil
+}
+
 func (s *LargePreimageScheduler) Close() error {
+	if s.cancel == nil { // never started
+		return nil
+	}
 	s.cancel()
 	s.wg.Wait()
 	return nil
@@ -47,6 +123,7 @@ func (s *LargePreimageScheduler) Close() error {
 
 func (s *LargePreimageScheduler) run(ctx context.Context) {
 	defer s.wg.Done()
+	defer s.running.Store(false)
 	for {
 		select {
 		case <-ctx.Done():
@@ -69,23 +146,108 @@ func (s *LargePreimageScheduler) Schedule(blockHash common.Hash, _ uint64) error
 }
 
 func (s *LargePreimageScheduler) verifyPreimages(ctx context.Context, blockHash common.Hash) error {
+	// Check for reorged challenges first so that any affected proposals are re-challenged by this verification.
+	if err := s.inclusions.CheckInclusions(ctx); err != nil {
+		s.log.Warn("Failed to check inclusion of preimage challenges", "err", err)
+	}
 	var err error
+	var minRemaining time.Duration
+	tracked := false
 	for _, oracle := range s.oracles {
-		err = errors.Join(err, s.verifyOraclePreimages(ctx, oracle, blockHash))
+		remaining, ok, oracleErr := s.verifyOraclePreimages(ctx, oracle, blockHash)
+		err = errors.Join(err, oracleErr)
+		if ok && (!tracked || remaining < minRemaining) {
+			minRemaining = remaining
+			tracked = true
+		}
+	}
+	if tracked {
+		s.metrics.RecordLargePreimageMinTimeRemaining(minRemaining)
+	} else {
+		s.metrics.ClearLargePreimageMinTimeRemaining()
 	}
 	return err
 }
 
-func (s *LargePreimageScheduler) verifyOraclePreimages(ctx context.Context, oracle keccakTypes.LargePreimageOracle, blockHash common.Hash) error {
+// verifyOraclePreimages verifies the active preimages in oracle, prioritising those with the least time remaining
+// in their challenge period. Returns the minimum time remaining for unexpired proposals that still require
+// verification and whether any such proposals were found.
+func (s *LargePreimageScheduler) verifyOraclePreimages(ctx context.Context, oracle keccakTypes.LargePreimageOracle, blockHash common.Hash) (time.Duration, bool, error) {
+	period, err := s.challengePeriod(ctx, oracle)
+	if err != nil {
+		return 0, false, err
+	}
 	preimages, err := oracle.GetActivePreimages(ctx, blockHash)
 	if err != nil {
-		return err
+		return 0, false, err
+	}
+	preimages = s.filterClaimants(preimages)
+	s.status.UpdateActive(oracle.Addr(), preimages)
+	if err := s.cache.Prune(oracle.Addr(), preimages); err != nil {
+		s.log.Warn("Failed to prune verified preimage cache", "oracle", oracle.Addr(), "err", err)
+	}
+	now := s.cl.Now()
+	remaining := func(preimage keccakTypes.LargePreimageMetaData) time.Duration {
+		return time.Unix(int64(preimage.Timestamp), 0).Add(period).Sub(now)
 	}
 	toVerify := make([]keccakTypes.LargePreimageMetaData, 0, len(preimages))
+	seen := make(map[statusKey]bool, len(preimages))
 	for _, preimage := range preimages {
-		if preimage.ShouldVerify() {
+		key := newStatusKey(oracle.Addr(), preimage.LargePreimageIdent)
+		if preimage.ShouldVerify() && !seen[key] {
+			seen[key] = true
 			toVerify = append(toVerify, preimage)
 		}
 	}
-	return s.challenger.Challenge(ctx, blockHash, oracle, toVerify)
+	// Proposals whose challenge period expires soonest are verified first.
+	// Proposals which have already expired but not yet been squeezed come before all others as they may be squeezed
+	// at any time, but can still be challenged until they are.
+	slices.SortStableFunc(toVerify, func(a, b keccakTypes.LargePreimageMetaData) int {
+		return cmp.Compare(remaining(a), remaining(b))
+	})
+	var minRemaining time.Duration
+	tracked := false
+	for _, preimage := range toVerify {
+		if r := remaining(preimage); r > 0 {
+			minRemaining = r
+			tracked = true
+			break
+		}
+	}
+	// Proposals that are or will soon be squeezable are challenged urgently before the remaining proposals.
+	urgentCount := 0
+	for urgentCount < len(toVerify) && remaining(toVerify[urgentCount]) <= s.urgentWindow {
+		urgentCount++
+	}
+	var urgentErr error
+	if urgentCount > 0 {
+		urgentErr = s.challenger.ChallengeUrgent(ctx, blockHash, oracle, toVerify[:urgentCount])
+	}
+	err = s.challenger.Challenge(ctx, blockHash, oracle, toVerify[urgentCount:])
+	return minRemaining, tracked, errors.Join(urgentErr, err)
+}
+
+// filterClaimants returns the preimages with claimants that should be verified.
+// Proposals from other claimants are excluded entirely, including from status reporting.
+func (s *LargePreimageScheduler) filterClaimants(preimages []keccakTypes.LargePreimageMetaData) []keccakTypes.LargePreimageMetaData {
+	filtered := make([]keccakTypes.LargePreimageMetaData, 0, len(preimages))
+	for _, preimage := range preimages {
+		if s.claimants.ShouldVerify(preimage.Claimant) {
+			filtered = append(filtered, preimage)
+		}
+	}
+	return filtered
+}
+
+func (s *LargePreimageScheduler) challengePeriod(ctx context.Context, oracle keccakTypes.LargePreimageOracle) (time.Duration, error) {
+	if period, ok := s.challengePeriods[oracle.Addr()]; ok {
+		return period, nil
+	}
+	seconds, err := oracle.ChallengePeriod(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load challenge period: %w", err)
+	}
+	period := time.Duration(seconds) * time.Second
+	s.challengePeriods[oracle.Addr()] = period
+	return period, nil
 }
@@ -3,11 +3,13 @@ package keccak
 import (
 	"context"
 	"math/big"
+	"slices"
 	"sync"
 	"testing"
 	"time"
 
 	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -44,10 +46,12 @@ func TestScheduleNextCheck(t *testing.T) {
 		images: []keccakTypes.LargePreimageMetaData{preimage1, preimage2, preimage3},
 	}
 	challenger := &stubChallenger{}
-	scheduler := NewLargePreimageScheduler(logger, []keccakTypes.LargePreimageOracle{oracle}, challenger)
+	cache, err := NewVerifiedPreimageCache(logger, "")
+	require.NoError(t, err)
+	scheduler := NewLargePreimageScheduler(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), &stubSchedulerMetrics{}, []keccakTypes.LargePreimageOracle{oracle}, challenger, cache, NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0))), &stubInclusionChecker{}, NewClaimantFilter(nil, nil), 0)
 	scheduler.Start(ctx)
 	defer scheduler.Close()
-	err := scheduler.Schedule(common.Hash{0xaa}, 3)
+	err = scheduler.Schedule(common.Hash{0xaa}, 3)
 	require.NoError(t, err)
 	require.Eventually(t, func() bool {
 		return oracle.GetPreimagesCount() == 1
@@ -59,11 +63,201 @@ func TestScheduleNextCheck(t *testing.T) {
 	}, 10*time.Second, 10*time.Millisecond, "Did not verify preimage")
 }
 
+func TestSchedulerHealth(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cache, err := NewVerifiedPreimageCache(logger, "")
+	require.NoError(t, err)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	scheduler := NewLargePreimageScheduler(logger, cl, &stubSchedulerMetrics{}, nil, &stubChallenger{}, cache, NewStatusTracker(cl), &stubInclusionChecker{}, NewClaimantFilter(nil, nil), 0)
+	require.NoError(t, scheduler.CheckHealth(context.Background()), "should be healthy before starting")
+
+	scheduler.Start(context.Background())
+	require.NoError(t, scheduler.CheckHealth(context.Background()))
+
+	require.NoError(t, scheduler.Close())
+	require.ErrorIs(t, scheduler.CheckHealth(context.Background()), ErrSchedulerStopped)
+}
+
+func TestPrioritiseByChallengePeriodExpiry(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlInfo)
+	newPreimage := func(uuid int64, timestamp uint64) keccakTypes.LargePreimageMetaData {
+		return keccakTypes.LargePreimageMetaData{
+			LargePreimageIdent: keccakTypes.LargePreimageIdent{
+				Claimant: common.Address{0xab},
+				UUID:     big.NewInt(uuid),
+			},
+			Timestamp: timestamp,
+		}
+	}
+	// Challenge period is 100 seconds and the current time is 1000
+	expired := newPreimage(1, 850)
+	latest := newPreimage(2, 990)
+	soonest := newPreimage(3, 920)
+	middle := newPreimage(4, 950)
+	oracle := &stubOracle{
+		images:          []keccakTypes.LargePreimageMetaData{expired, latest, soonest, middle},
+		challengePeriod: 100,
+	}
+	challenger := &stubChallenger{}
+	metrics := &stubSchedulerMetrics{}
+	cache, err := NewVerifiedPreimageCache(logger, "")
+	require.NoError(t, err)
+	scheduler := NewLargePreimageScheduler(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), metrics, []keccakTypes.LargePreimageOracle{oracle}, challenger, cache, NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0))), &stubInclusionChecker{}, NewClaimantFilter(nil, nil), 0)
+
+	require.NoError(t, scheduler.verifyPreimages(ctx, common.Hash{0xaa}))
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{expired, soonest, middle, latest}, challenger.Checked())
+	remaining, tracked := metrics.MinTimeRemaining()
+	require.True(t, tracked)
+	require.Equal(t, 20*time.Second, remaining)
+
+	oracle.images = nil
+	require.NoError(t, scheduler.verifyPreimages(ctx, common.Hash{0xaa}))
+	_, tracked = metrics.MinTimeRemaining()
+	require.False(t, tracked, "should clear min time remaining when no proposals are tracked")
+}
+
+func TestChallengeUrgentlyNearSqueeze(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlInfo)
+	newPreimage := func(uuid int64, timestamp uint64) keccakTypes.LargePreimageMetaData {
+		return keccakTypes.LargePreimageMetaData{
+			LargePreimageIdent: keccakTypes.LargePreimageIdent{
+				Claimant: common.Address{0xab},
+				UUID:     big.NewInt(uuid),
+			},
+			Timestamp: timestamp,
+		}
+	}
+	// Challenge period is 100 seconds and the current time is 1000
+	expired := newPreimage(1, 850)
+	notUrgent := newPreimage(2, 990)
+	urgent1 := newPreimage(3, 920)
+	urgent2 := newPreimage(4, 925)
+	oracle := &stubOracle{
+		images:          []keccakTypes.LargePreimageMetaData{expired, notUrgent, urgent2, urgent1},
+		challengePeriod: 100,
+	}
+	challenger := &stubChallenger{}
+	cache, err := NewVerifiedPreimageCache(logger, "")
+	require.NoError(t, err)
+	scheduler := NewLargePreimageScheduler(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), &stubSchedulerMetrics{},
+		[]keccakTypes.LargePreimageOracle{oracle}, challenger, cache, NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0))),
+		&stubInclusionChecker{}, NewClaimantFilter(nil, nil), 30*time.Second)
+
+	require.NoError(t, scheduler.verifyPreimages(ctx, common.Hash{0xaa}))
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{expired, urgent1, urgent2}, challenger.Urgent())
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{expired, urgent1, urgent2, notUrgent}, challenger.Checked())
+}
+
+func TestMultipleOracles(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlInfo)
+	newPreimage := func(uuid int64) keccakTypes.LargePreimageMetaData {
+		return keccakTypes.LargePreimageMetaData{
+			LargePreimageIdent: keccakTypes.LargePreimageIdent{
+				Claimant: common.Address{0xab},
+				UUID:     big.NewInt(uuid),
+			},
+			Timestamp: 990,
+		}
+	}
+	preimage1 := newPreimage(1)
+	preimage2 := newPreimage(2)
+	oracleA := &stubOracle{
+		addr:            common.Address{0xaa},
+		images:          []keccakTypes.LargePreimageMetaData{preimage1, preimage1},
+		challengePeriod: 100,
+	}
+	// Same proposal ident but in a different oracle so must be verified separately
+	oracleB := &stubOracle{
+		addr:            common.Address{0xbb},
+		images:          []keccakTypes.LargePreimageMetaData{preimage1, preimage2},
+		challengePeriod: 100,
+	}
+	challenger := &stubChallenger{}
+	cache, err := NewVerifiedPreimageCache(logger, "")
+	require.NoError(t, err)
+	scheduler := NewLargePreimageScheduler(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), &stubSchedulerMetrics{},
+		[]keccakTypes.LargePreimageOracle{oracleA, oracleB, oracleA}, challenger, cache, NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0))), &stubInclusionChecker{}, NewClaimantFilter(nil, nil), 0)
+
+	require.NoError(t, scheduler.verifyPreimages(ctx, common.Hash{0xaa}))
+	require.Equal(t, 1, oracleA.GetPreimagesCount(), "should deduplicate oracles")
+	require.Equal(t, 1, oracleB.GetPreimagesCount())
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{preimage1}, challenger.CheckedFor(oracleA.addr))
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{preimage1, preimage2}, challenger.CheckedFor(oracleB.addr))
+}
+
+func TestFilterClaimants(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlInfo)
+	newPreimage := func(claimant common.Address) keccakTypes.LargePreimageMetaData {
+		return keccakTypes.LargePreimageMetaData{
+			LargePreimageIdent: keccakTypes.LargePreimageIdent{
+				Claimant: claimant,
+				UUID:     big.NewInt(1),
+			},
+			Timestamp: 990,
+		}
+	}
+	trusted := newPreimage(common.Address{0x01})
+	untrusted := newPreimage(common.Address{0x02})
+	oracle := &stubOracle{
+		images:          []keccakTypes.LargePreimageMetaData{trusted, untrusted},
+		challengePeriod: 100,
+	}
+	challenger := &stubChallenger{}
+	cache, err := NewVerifiedPreimageCache(logger, "")
+	require.NoError(t, err)
+	status := NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0)))
+	scheduler := NewLargePreimageScheduler(logger, clock.NewDeterministicClock(time.Unix(1000, 0)), &stubSchedulerMetrics{},
+		[]keccakTypes.LargePreimageOracle{oracle}, challenger, cache, status, &stubInclusionChecker{},
+		NewClaimantFilter([]common.Address{trusted.Claimant}, nil), 0)
+
+	require.NoError(t, scheduler.verifyPreimages(ctx, common.Hash{0xaa}))
+	require.Equal(t, []keccakTypes.LargePreimageMetaData{untrusted}, challenger.Checked())
+	statuses := status.Statuses()
+	require.Len(t, statuses, 1)
+	require.Equal(t, untrusted.Claimant, statuses[0].Claimant)
+	require.Len(t, oracle.images, 2, "should not modify oracle results")
+}
+
+type stubSchedulerMetrics struct {
+	m                sync.Mutex
+	minTimeRemaining time.Duration
+	tracked          bool
+}
+
+func (s *stubSchedulerMetrics) RecordLargePreimageMinTimeRemaining(remaining time.Duration) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.minTimeRemaining = remaining
+	s.tracked = true
+}
+
+func (s *stubSchedulerMetrics) ClearLargePreimageMinTimeRemaining() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.minTimeRemaining = 0
+	s.tracked = false
+}
+
+func (s *stubSchedulerMetrics) MinTimeRemaining() (time.Duration, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.minTimeRemaining, s.tracked
+}
+
 type stubOracle struct {
 	m                 sync.Mutex
 	addr              common.Address
 	getPreimagesCount int
 	images            []keccakTypes.LargePreimageMetaData
+	challengePeriod   uint64
+}
+
+func (s *stubOracle) ChallengePeriod(_ context.Context) (uint64, error) {
+	return s.challengePeriod, nil
 }
 
 func (s *stubOracle) GetInputDataBlocks(_ context.Context, _ batching.Block, _ keccakTypes.LargePreimageIdent) ([]uint64, error) {
@@ -96,17 +290,42 @@ func (s *stubOracle) ChallengeTx(_ keccakTypes.LargePreimageIdent, _ keccakTypes
 }
 
 type stubChallenger struct {
-	m       sync.Mutex
-	checked []keccakTypes.LargePreimageMetaData
+	m        sync.Mutex
+	checked  []keccakTypes.LargePreimageMetaData
+	urgent   []keccakTypes.LargePreimageMetaData
+	byOracle map[common.Address][]keccakTypes.LargePreimageMetaData
+}
+
+func (s *stubChallenger) ChallengeUrgent(ctx context.Context, blockHash common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData) error {
+	s.m.Lock()
+	s.urgent = append(s.urgent, preimages...)
+	s.m.Unlock()
+	return s.Challenge(ctx, blockHash, oracle, preimages)
+}
+
+func (s *stubChallenger) Urgent() []keccakTypes.LargePreimageMetaData {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return slices.Clone(s.urgent)
 }
 
-func (s *stubChallenger) Challenge(_ context.Context, _ common.Hash, _ Oracle, preimages []keccakTypes.LargePreimageMetaData) error {
+func (s *stubChallenger) Challenge(_ context.Context, _ common.Hash, oracle Oracle, preimages []keccakTypes.LargePreimageMetaData) error {
 	s.m.Lock()
 	defer s.m.Unlock()
 	s.checked = append(s.checked, preimages...)
+	if s.byOracle == nil {
+		s.byOracle = make(map[common.Address][]keccakTypes.LargePreimageMetaData)
+	}
+	s.byOracle[oracle.Addr()] = append(s.byOracle[oracle.Addr()], preimages...)
 	return nil
 }
 
+func (s *stubChallenger) CheckedFor(oracle common.Address) []keccakTypes.LargePreimageMetaData {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.byOracle[oracle]
+}
+
 func (s *stubChallenger) Checked() []keccakTypes.LargePreimageMetaData {
 	s.m.Lock()
 	defer s.m.Unlock()
@@ -114,3 +333,9 @@ func (s *stubChallenger) Checked() []keccakTypes.LargePreimageMetaData {
 	copy(v, s.checked)
 	return v
 }
+
+type stubInclusionChecker struct{}
+
+func (s *stubInclusionChecker) CheckInclusions(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,199 @@
+package keccak
+
+import (
+	"bytes"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type VerificationStatus string
+
+const (
+	StatusPending VerificationStatus = "pending"
+	StatusValid   VerificationStatus = "valid"
+	StatusInvalid VerificationStatus = "invalid"
+)
+
+// LeafSummary describes a leaf in a large preimage proposal.
+type LeafSummary struct {
+	Index           uint64      `json:"index"`
+	StateCommitment common.Hash `json:"stateCommitment"`
+}
+
+// ChallengeSummary describes the computed challenge for an invalid large preimage proposal.
+type ChallengeSummary struct {
+	// PrestateMatrix is the state matrix after absorbing the last valid leaf
+	PrestateMatrix keccakTypes.StateSnapshot `json:"prestateMatrix"`
+	Prestate       LeafSummary               `json:"prestate"`
+	Poststate      LeafSummary               `json:"poststate"`
+}
+
+// PreimageStatus is the verification status of a tracked large preimage proposal.
+type PreimageStatus struct {
+	Oracle          common.Address     `json:"oracle"`
+	Claimant        common.Address     `json:"claimant"`
+	UUID            *big.Int           `json:"uuid"`
+	Timestamp       uint64             `json:"timestamp"`
+	PartOffset      uint32             `json:"partOffset"`
+	ClaimedSize     uint32             `json:"claimedSize"`
+	BlocksProcessed uint32             `json:"blocksProcessed"`
+	BytesProcessed  uint32             `json:"bytesProcessed"`
+	Detected        time.Time          `json:"detected"`
+	Status          VerificationStatus `json:"status"`
+	Challenge       *ChallengeSummary  `json:"challenge,omitempty"`
+	ChallengeTxs    []common.Hash      `json:"challengeTxs,omitempty"`
+
+	metaDataHash common.Hash
+}
+
+type statusKey struct {
+	oracle   common.Address
+	claimant common.Address
+	uuid     string
+}
+
+func newStatusKey(oracle common.Address, ident keccakTypes.LargePreimageIdent) statusKey {
+	return statusKey{oracle: oracle, claimant: ident.Claimant, uuid: ident.UUID.String()}
+}
+
+// StatusTracker records the verification status of large preimage proposals requiring verification.
+type StatusTracker struct {
+	cl       Clock
+	lock     sync.Mutex
+	statuses map[statusKey]*PreimageStatus
+}
+
+func NewStatusTracker(cl Clock) *StatusTracker {
+	return &StatusTracker{
+		cl:       cl,
+		statuses: make(map[statusKey]*PreimageStatus),
+	}
+}
+
+// UpdateActive updates the set of tracked proposals for oracle to those in active requiring verification.
+// The status of proposals is preserved unless the proposal metadata has changed.
+// The time a proposal was first detected is always preserved.
+func (t *StatusTracker) UpdateActive(oracle common.Address, active []keccakTypes.LargePreimageMetaData) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	keep := make(map[statusKey]bool, len(active))
+	for _, preimage := range active {
+		if !preimage.ShouldVerify() {
+			continue
+		}
+		key := newStatusKey(oracle, preimage.LargePreimageIdent)
+		keep[key] = true
+		metaDataHash := preimage.MetaDataHash()
+		detected := t.cl.Now()
+		if existing, ok := t.statuses[key]; ok {
+			if existing.metaDataHash == metaDataHash {
+				continue
+			}
+			detected = existing.Detected
+		}
+		t.statuses[key] = &PreimageStatus{
+			Oracle:          oracle,
+			Claimant:        preimage.Claimant,
+			UUID:            preimage.UUID,
+			Timestamp:       preimage.Timestamp,
+			PartOffset:      preimage.PartOffset,
+			ClaimedSize:     preimage.ClaimedSize,
+			BlocksProcessed: preimage.BlocksProcessed,
+			BytesProcessed:  preimage.BytesProcessed,
+			Detected:        detected,
+			Status:          StatusPending,
+			metaDataHash:    metaDataHash,
+		}
+	}
+	for key := range t.statuses {
+		if key.oracle == oracle && !keep[key] {
+			delete(t.statuses, key)
+		}
+	}
+}
+
+// RecordValid records that the proposal was verified as valid.
+func (t *StatusTracker) RecordValid(oracle common.Address, ident keccakTypes.LargePreimageIdent) {
+	t.update(oracle, ident, func(status *PreimageStatus) {
+		status.Status = StatusValid
+		status.Challenge = nil
+	})
+}
+
+// RecordInvalid records that the proposal was found to be invalid and the challenge that was computed.
+func (t *StatusTracker) RecordInvalid(oracle common.Address, ident keccakTypes.LargePreimageIdent, challenge keccakTypes.Challenge) {
+	t.update(oracle, ident, func(status *PreimageStatus) {
+		status.Status = StatusInvalid
+		status.Challenge = &ChallengeSummary{
+			PrestateMatrix: challenge.StateMatrix,
+			Prestate: LeafSummary{
+				Index:           challenge.Prestate.Index,
+				StateCommitment: challenge.Prestate.StateCommitment,
+			},
+			Poststate: LeafSummary{
+				Index:           challenge.Poststate.Index,
+				StateCommitment: challenge.Poststate.StateCommitment,
+			},
+		}
+	})
+}
+
+// RecordChallengeTx records the hash of a transaction sent to challenge the proposal.
+func (t *StatusTracker) RecordChallengeTx(oracle common.Address, ident keccakTypes.LargePreimageIdent, txHash common.Hash) {
+	t.update(oracle, ident, func(status *PreimageStatus) {
+		status.ChallengeTxs = append(status.ChallengeTxs, txHash)
+	})
+}
+
+// TimeSinceDetected returns the time elapsed since the proposal was first detected.
+// Returns false if the proposal is not tracked.
+func (t *StatusTracker) TimeSinceDetected(oracle common.Address, ident keccakTypes.LargePreimageIdent) (time.Duration, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	status, ok := t.statuses[newStatusKey(oracle, ident)]
+	if !ok {
+		return 0, false
+	}
+	return t.cl.Now().Sub(status.Detected), true
+}
+
+func (t *StatusTracker) update(oracle common.Address, ident keccakTypes.LargePreimageIdent, fn func(status *PreimageStatus)) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	status, ok := t.statuses[newStatusKey(oracle, ident)]
+	if !ok {
+		return
+	}
+	fn(status)
+}
+
+// Statuses returns a copy of the status of all tracked proposals, ordered by oracle, claimant and UUID.
+func (t *StatusTracker) Statuses() []PreimageStatus {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	result := make([]PreimageStatus, 0, len(t.statuses))
+	for _, status := range t.statuses {
+		cpy := *status
+		cpy.ChallengeTxs = slices.Clone(status.ChallengeTxs)
+		if status.Challenge != nil {
+			challenge := *status.Challenge
+			cpy.Challenge = &challenge
+		}
+		result = append(result, cpy)
+	}
+	slices.SortFunc(result, func(a, b PreimageStatus) int {
+		if c := bytes.Compare(a.Oracle[:], b.Oracle[:]); c != 0 {
+			return c
+		}
+		if c := bytes.Compare(a.Claimant[:], b.Claimant[:]); c != 0 {
+			return c
+		}
+		return a.UUID.Cmp(b.UUID)
+	})
+	return result
+}
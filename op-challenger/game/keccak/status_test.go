@@ -0,0 +1,111 @@
+package keccak
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	keccakTypes "github.com/ethereum-optimism/optimism/op-challenger/game/keccak/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusTracker(t *testing.T) {
+	oracle := common.Address{0xaa}
+	newPreimage := func(uuid int64) keccakTypes.LargePreimageMetaData {
+		return keccakTypes.LargePreimageMetaData{
+			LargePreimageIdent: keccakTypes.LargePreimageIdent{
+				Claimant: common.Address{0xbb},
+				UUID:     big.NewInt(uuid),
+			},
+			Timestamp:   1234,
+			ClaimedSize: 500,
+		}
+	}
+	preimage1 := newPreimage(1)
+	preimage2 := newPreimage(2)
+	incomplete := newPreimage(3)
+	incomplete.Timestamp = 0
+
+	t.Run("TrackActivePending", func(t *testing.T) {
+		tracker := NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0)))
+		tracker.UpdateActive(oracle, []keccakTypes.LargePreimageMetaData{preimage2, incomplete, preimage1})
+		statuses := tracker.Statuses()
+		require.Len(t, statuses, 2)
+		require.Equal(t, preimage1.UUID, statuses[0].UUID)
+		require.Equal(t, preimage2.UUID, statuses[1].UUID)
+		for _, status := range statuses {
+			require.Equal(t, StatusPending, status.Status)
+			require.Equal(t, oracle, status.Oracle)
+			require.Equal(t, uint32(500), status.ClaimedSize)
+		}
+	})
+
+	t.Run("RecordResults", func(t *testing.T) {
+		tracker := NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0)))
+		tracker.UpdateActive(oracle, []keccakTypes.LargePreimageMetaData{preimage1, preimage2})
+		challenge := keccakTypes.Challenge{
+			StateMatrix: keccakTypes.StateSnapshot{0x01},
+			Prestate:    keccakTypes.Leaf{Index: 3, StateCommitment: common.Hash{0x03}},
+			Poststate:   keccakTypes.Leaf{Index: 4, StateCommitment: common.Hash{0x04}},
+		}
+		tracker.RecordValid(oracle, preimage1.LargePreimageIdent)
+		tracker.RecordInvalid(oracle, preimage2.LargePreimageIdent, challenge)
+		tracker.RecordChallengeTx(oracle, preimage2.LargePreimageIdent, common.Hash{0xcc})
+
+		statuses := tracker.Statuses()
+		require.Equal(t, StatusValid, statuses[0].Status)
+		require.Nil(t, statuses[0].Challenge)
+		require.Equal(t, StatusInvalid, statuses[1].Status)
+		require.Equal(t, &ChallengeSummary{
+			PrestateMatrix: challenge.StateMatrix,
+			Prestate:       LeafSummary{Index: 3, StateCommitment: common.Hash{0x03}},
+			Poststate:      LeafSummary{Index: 4, StateCommitment: common.Hash{0x04}},
+		}, statuses[1].Challenge)
+		require.Equal(t, []common.Hash{{0xcc}}, statuses[1].ChallengeTxs)
+
+		// Status is preserved while the proposal is unchanged
+		tracker.UpdateActive(oracle, []keccakTypes.LargePreimageMetaData{preimage1, preimage2})
+		require.Equal(t, statuses, tracker.Statuses())
+	})
+
+	t.Run("RemoveInactive", func(t *testing.T) {
+		tracker := NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0)))
+		otherOracle := common.Address{0xdd}
+		tracker.UpdateActive(oracle, []keccakTypes.LargePreimageMetaData{preimage1, preimage2})
+		tracker.UpdateActive(otherOracle, []keccakTypes.LargePreimageMetaData{preimage1})
+		countered := preimage2
+		countered.Countered = true
+		tracker.UpdateActive(oracle, []keccakTypes.LargePreimageMetaData{countered})
+		statuses := tracker.Statuses()
+		require.Len(t, statuses, 1)
+		require.Equal(t, otherOracle, statuses[0].Oracle)
+	})
+
+	t.Run("IgnoreUntracked", func(t *testing.T) {
+		tracker := NewStatusTracker(clock.NewDeterministicClock(time.Unix(1000, 0)))
+		tracker.RecordValid(oracle, preimage1.LargePreimageIdent)
+		require.Empty(t, tracker.Statuses())
+	})
+
+	t.Run("TimeSinceDetected", func(t *testing.T) {
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		tracker := NewStatusTracker(cl)
+		_, ok := tracker.TimeSinceDetected(oracle, preimage1.LargePreimageIdent)
+		require.False(t, ok)
+
+		tracker.UpdateActive(oracle, []keccakTypes.LargePreimageMetaData{preimage1})
+		cl.AdvanceTime(5 * time.Minute)
+		// Detection time is preserved when the proposal metadata changes
+		modified := preimage1
+		modified.BytesProcessed = 136
+		tracker.UpdateActive(oracle, []keccakTypes.LargePreimageMetaData{modified})
+		cl.AdvanceTime(time.Minute)
+
+		elapsed, ok := tracker.TimeSinceDetected(oracle, preimage1.LargePreimageIdent)
+		require.True(t, ok)
+		require.Equal(t, 6*time.Minute, elapsed)
+		require.Equal(t, time.Unix(1000, 0), tracker.Statuses()[0].Detected)
+	})
+}
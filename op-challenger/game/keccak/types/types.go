@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"encoding/binary"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/merkle"
@@ -71,6 +72,20 @@ func (m LargePreimageMetaData) ShouldVerify() bool {
 	return m.Timestamp > 0 && !m.Countered
 }
 
+// MetaDataHash returns a hash of the proposal metadata (timestamp, part offset, claimed size and
+// processed blocks and bytes). It does not commit to the preimage data itself, but the metadata is
+// fixed once all data is available so the hash can be used to detect if a previously verified
+// proposal has changed.
+func (m LargePreimageMetaData) MetaDataHash() common.Hash {
+	buf := make([]byte, 0, 8+4*4)
+	buf = binary.BigEndian.AppendUint64(buf, m.Timestamp)
+	buf = binary.BigEndian.AppendUint32(buf, m.PartOffset)
+	buf = binary.BigEndian.AppendUint32(buf, m.ClaimedSize)
+	buf = binary.BigEndian.AppendUint32(buf, m.BlocksProcessed)
+	buf = binary.BigEndian.AppendUint32(buf, m.BytesProcessed)
+	return crypto.Keccak256Hash(buf)
+}
+
 type StateSnapshot [25]uint64
 
 // Pack packs the state in to the solidity ABI encoding required for the state matrix
@@ -98,6 +113,7 @@ type Challenge struct {
 type LargePreimageOracle interface {
 	Addr() common.Address
 	GetActivePreimages(ctx context.Context, blockHash common.Hash) ([]LargePreimageMetaData, error)
+	ChallengePeriod(ctx context.Context) (uint64, error)
 	GetInputDataBlocks(ctx context.Context, block batching.Block, ident LargePreimageIdent) ([]uint64, error)
 	DecodeInputData(data []byte) (*big.Int, InputData, error)
 	ChallengeTx(ident LargePreimageIdent, challenge Challenge) (txmgr.TxCandidate, error)
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
@@ -14,33 +14,60 @@ import (
 )
 
 type Fetcher interface {
-	FetchInputs(ctx context.Context, blockHash common.Hash, oracle fetcher.Oracle, ident keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error)
+	FetchInputs(ctx context.Context, blockHash common.Hash, oracle fetcher.Oracle, ident keccakTypes.LargePreimageIdent, onInput func(input keccakTypes.InputData) error) error
+}
+
+type VerifierMetrics interface {
+	RecordLargePreimageFetchTime(t time.Duration)
+	RecordLargePreimageVerificationThroughput(bytesPerSecond float64)
 }
 
 type PreimageVerifier struct {
-	log     log.Logger
-	fetcher Fetcher
+	log              log.Logger
+	metrics          VerifierMetrics
+	fetcher          Fetcher
+	snapshotInterval uint64
 }
 
-func NewPreimageVerifier(logger log.Logger, fetcher Fetcher) *PreimageVerifier {
+// NewPreimageVerifier creates a new PreimageVerifier.
+// If snapshotInterval is non-zero, verification progress is logged every snapshotInterval leaves.
+func NewPreimageVerifier(logger log.Logger, metrics VerifierMetrics, fetcher Fetcher, snapshotInterval uint64) *PreimageVerifier {
 	return &PreimageVerifier{
-		log:     logger,
-		fetcher: fetcher,
+		log:              logger,
+		metrics:          metrics,
+		fetcher:          fetcher,
+		snapshotInterval: snapshotInterval,
 	}
 }
 
+// CreateChallenge verifies the large preimage and returns the challenge to submit if it is invalid.
+// Inputs are absorbed as they are fetched, so the full preimage is never held in memory.
 func (v *PreimageVerifier) CreateChallenge(ctx context.Context, blockHash common.Hash, oracle fetcher.Oracle, preimage keccakTypes.LargePreimageMetaData) (keccakTypes.Challenge, error) {
-	inputs, err := v.fetcher.FetchInputs(ctx, blockHash, oracle, preimage.LargePreimageIdent)
-	if err != nil {
+	start := time.Now()
+	var absorbTime time.Duration
+	var absorbErr error
+	totalBytes := 0
+	stream := matrix.NewStream(v.snapshotInterval, func(snapshot matrix.Snapshot) {
+		v.log.Debug("Large preimage verification progress", "oracle", oracle.Addr(), "claimant", preimage.Claimant, "uuid", preimage.UUID,
+			"leaf", snapshot.Index, "stateCommitment", snapshot.StateCommitment)
+	})
+	err := v.fetcher.FetchInputs(ctx, blockHash, oracle, preimage.LargePreimageIdent, func(input keccakTypes.InputData) error {
+		absorbStart := time.Now()
+		absorbErr = stream.Absorb(bytes.NewReader(input.Input), input.Commitments, input.Finalize)
+		absorbTime += time.Since(absorbStart)
+		totalBytes += len(input.Input)
+		return absorbErr
+	})
+	if absorbErr != nil {
+		return keccakTypes.Challenge{}, fmt.Errorf("failed to absorb leaves: %w", absorbErr)
+	} else if err != nil {
 		return keccakTypes.Challenge{}, fmt.Errorf("failed to fetch leaves: %w", err)
 	}
-	readers := make([]io.Reader, 0, len(inputs))
-	var commitments []common.Hash
-	for _, input := range inputs {
-		readers = append(readers, bytes.NewReader(input.Input))
-		commitments = append(commitments, input.Commitments...)
+	v.metrics.RecordLargePreimageFetchTime(time.Since(start) - absorbTime)
+	if absorbTime > 0 {
+		v.metrics.RecordLargePreimageVerificationThroughput(float64(totalBytes) / absorbTime.Seconds())
 	}
-	challenge, err := matrix.Challenge(io.MultiReader(readers...), commitments)
+	challenge, err := stream.Result()
 	if err != nil {
 		return keccakTypes.Challenge{}, fmt.Errorf("failed to create challenge: %w", err)
 	}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/matrix"
@@ -70,7 +71,8 @@ func TestVerify(t *testing.T) {
 			fetcher := &stubFetcher{
 				inputs: test.inputs(),
 			}
-			verifier := NewPreimageVerifier(logger, fetcher)
+			metrics := &stubVerifierMetrics{}
+			verifier := NewPreimageVerifier(logger, metrics, fetcher, 1)
 			preimage := keccakTypes.LargePreimageMetaData{}
 			challenge, err := verifier.CreateChallenge(context.Background(), common.Hash{0xff}, &stubOracle{}, preimage)
 			require.ErrorIs(t, err, test.expectedErr)
@@ -81,6 +83,10 @@ func TestVerify(t *testing.T) {
 			} else {
 				require.Equal(t, keccakTypes.Challenge{}, challenge)
 			}
+			require.Len(t, metrics.fetchTimes, 1)
+			for _, throughput := range metrics.throughputs {
+				require.Greater(t, throughput, float64(0))
+			}
 		})
 	}
 }
@@ -108,6 +114,24 @@ type stubFetcher struct {
 	inputs []keccakTypes.InputData
 }
 
-func (s *stubFetcher) FetchInputs(_ context.Context, _ common.Hash, _ fetcher.Oracle, _ keccakTypes.LargePreimageIdent) ([]keccakTypes.InputData, error) {
-	return s.inputs, nil
+func (s *stubFetcher) FetchInputs(_ context.Context, _ common.Hash, _ fetcher.Oracle, _ keccakTypes.LargePreimageIdent, onInput func(input keccakTypes.InputData) error) error {
+	for _, input := range s.inputs {
+		if err := onInput(input); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type stubVerifierMetrics struct {
+	fetchTimes  []time.Duration
+	throughputs []float64
+}
+
+func (s *stubVerifierMetrics) RecordLargePreimageFetchTime(t time.Duration) {
+	s.fetchTimes = append(s.fetchTimes, t)
+}
+
+func (s *stubVerifierMetrics) RecordLargePreimageVerificationThroughput(bytesPerSecond float64) {
+	s.throughputs = append(s.throughputs, bytesPerSecond)
 }
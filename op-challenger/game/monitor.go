@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -42,6 +43,20 @@ type claimer interface {
 	Schedule(blockNumber uint64, games []types.GameMetadata) error
 }
 
+// gameEvents identifies logs that indicate games should be progressed before the next L1 head.
+type gameEvents interface {
+	FilterQuery(games []common.Address) ethereum.FilterQuery
+	IsGameCreated(l ethTypes.Log) bool
+	IsMove(l ethTypes.Log) bool
+}
+
+// errTrackedGamesChanged ends the game events subscription, to resubscribe to the events of the new set of games.
+var errTrackedGamesChanged = errors.New("tracked games changed")
+
+type LogSubscriber interface {
+	SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- ethTypes.Log) (ethereum.Subscription, error)
+}
+
 type gameMonitor struct {
 	logger           log.Logger
 	clock            RWClock
@@ -52,9 +67,24 @@ type gameMonitor struct {
 	claimer          claimer
 	fetchBlockNumber blockNumberFetcher
 	allowedGames     []common.Address
+	allowedTypes     []uint32
+	minCreationTime  uint64
 	l1HeadsSub       ethereum.Subscription
 	l1Source         *headSource
+	events           gameEvents
+	logSource        LogSubscriber
+	eventsSub        event.Subscription
 	runState         sync.Mutex
+
+	// progressLock serializes progressing games on new L1 heads and on game events
+	progressLock      sync.Mutex
+	lastEventBlock    common.Hash
+	lastProgressBlock uint64
+
+	gamesLock    sync.Mutex
+	trackedGames map[common.Address]bool
+	// gamesChanged is signaled when the set of tracked games changes
+	gamesChanged chan struct{}
 }
 
 type MinimalSubscriber interface {
@@ -79,7 +109,11 @@ func newGameMonitor(
 	claimer claimer,
 	fetchBlockNumber blockNumberFetcher,
 	allowedGames []common.Address,
+	allowedTypes []uint32,
+	minCreationTime uint64,
 	l1Source MinimalSubscriber,
+	events gameEvents,
+	logSource LogSubscriber,
 ) *gameMonitor {
 	return &gameMonitor{
 		logger:           logger,
@@ -91,7 +125,13 @@ func newGameMonitor(
 		claimer:          claimer,
 		fetchBlockNumber: fetchBlockNumber,
 		allowedGames:     allowedGames,
+		allowedTypes:     allowedTypes,
+		minCreationTime:  minCreationTime,
 		l1Source:         &headSource{inner: l1Source},
+		events:           events,
+		logSource:        logSource,
+		trackedGames:     make(map[common.Address]bool),
+		gamesChanged:     make(chan struct{}, 1),
 	}
 }
 
@@ -107,6 +147,10 @@ func (m *gameMonitor) allowedGame(game common.Address) bool {
 	return false
 }
 
+func (m *gameMonitor) allowedGameType(gameType uint32) bool {
+	return len(m.allowedTypes) == 0 || slices.Contains(m.allowedTypes, gameType)
+}
+
 func (m *gameMonitor) minGameTimestamp() uint64 {
 	if m.gameWindow.Seconds() == 0 {
 		return 0
@@ -119,7 +163,9 @@ func (m *gameMonitor) minGameTimestamp() uint64 {
 	return 0
 }
 
+// progressGames schedules the games at the given block. The progressLock must be held.
 func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash, blockNumber uint64) error {
+	m.lastProgressBlock = blockNumber
 	games, err := m.source.FetchAllGamesAtBlock(ctx, m.minGameTimestamp(), blockHash)
 	if err != nil {
 		return fmt.Errorf("failed to load games: %w", err)
@@ -133,8 +179,17 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 			m.logger.Debug("Skipping game not on allow list", "game", game.Proxy)
 			continue
 		}
+		if !m.allowedGameType(game.GameType) {
+			m.logger.Debug("Skipping game with type not on allow list", "game", game.Proxy, "gameType", game.GameType)
+			continue
+		}
+		if game.Timestamp < m.minCreationTime {
+			m.logger.Debug("Skipping game created before minimum creation time", "game", game.Proxy, "timestamp", game.Timestamp)
+			continue
+		}
 		gamesToPlay = append(gamesToPlay, game)
 	}
+	m.setTrackedGames(gamesToPlay)
 	if err := m.scheduler.Schedule(gamesToPlay, blockNumber); errors.Is(err, scheduler.ErrBusy) {
 		m.logger.Info("Scheduler still busy with previous update")
 	} else if err != nil {
@@ -144,6 +199,8 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 }
 
 func (m *gameMonitor) onNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
+	m.progressLock.Lock()
+	defer m.progressLock.Unlock()
 	m.clock.SetTime(sig.Time)
 	if err := m.progressGames(ctx, sig.Hash, sig.Number); err != nil {
 		m.logger.Error("Failed to progress games", "err", err)
@@ -153,6 +210,113 @@ func (m *gameMonitor) onNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	}
 }
 
+func (m *gameMonitor) setTrackedGames(games []types.GameMetadata) {
+	m.gamesLock.Lock()
+	defer m.gamesLock.Unlock()
+	changed := len(games) != len(m.trackedGames)
+	trackedGames := make(map[common.Address]bool, len(games))
+	for _, game := range games {
+		trackedGames[game.Proxy] = true
+		changed = changed || !m.trackedGames[game.Proxy]
+	}
+	m.trackedGames = trackedGames
+	if changed {
+		select {
+		case m.gamesChanged <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (m *gameMonitor) isTrackedGame(game common.Address) bool {
+	m.gamesLock.Lock()
+	defer m.gamesLock.Unlock()
+	return m.trackedGames[game]
+}
+
+func (m *gameMonitor) trackedGameAddresses() []common.Address {
+	m.gamesLock.Lock()
+	defer m.gamesLock.Unlock()
+	games := make([]common.Address, 0, len(m.trackedGames))
+	for game := range m.trackedGames {
+		games = append(games, game)
+	}
+	return games
+}
+
+// shouldProgressForEvent returns true if l indicates games should be progressed immediately.
+// Games are progressed at most once per block, regardless of how many events it contains,
+// and not for events in blocks before the last block games were progressed at. The progressLock must be held.
+func (m *gameMonitor) shouldProgressForEvent(l ethTypes.Log) bool {
+	if l.Removed {
+		return false
+	}
+	if !m.events.IsGameCreated(l) && !(m.events.IsMove(l) && m.isTrackedGame(l.Address)) {
+		return false
+	}
+	if l.BlockHash == m.lastEventBlock || l.BlockNumber < m.lastProgressBlock {
+		return false
+	}
+	m.lastEventBlock = l.BlockHash
+	return true
+}
+
+func (m *gameMonitor) onGameEvent(ctx context.Context, l ethTypes.Log) {
+	m.progressLock.Lock()
+	defer m.progressLock.Unlock()
+	if !m.shouldProgressForEvent(l) {
+		return
+	}
+	m.logger.Debug("Progressing games after game event", "block", l.BlockNumber, "contract", l.Address)
+	if err := m.progressGames(ctx, l.BlockHash, l.BlockNumber); err != nil {
+		m.logger.Error("Failed to progress games after game event", "err", err)
+	}
+}
+
+func (m *gameMonitor) resubscribeEventsFunction() event.ResubscribeErrFunc {
+	return func(ctx context.Context, err error) (event.Subscription, error) {
+		if errors.Is(err, errTrackedGamesChanged) {
+			m.logger.Debug("resubscribing to game events of the new set of games")
+		} else if err != nil {
+			m.logger.Warn("resubscribing after failed game event subscription", "err", err)
+		}
+		// drain a pending change signal, the new subscription includes the current set of games
+		select {
+		case <-m.gamesChanged:
+		default:
+		}
+		logs := make(chan ethTypes.Log, 10)
+		sub, err := m.logSource.SubscribeFilterLogs(ctx, m.events.FilterQuery(m.trackedGameAddresses()), logs)
+		if err != nil {
+			return nil, err
+		}
+		return event.NewSubscription(func(quit <-chan struct{}) error {
+			eventsCtx, eventsCancel := context.WithCancel(context.Background())
+			defer sub.Unsubscribe()
+			defer eventsCancel()
+			go func() {
+				select {
+				case <-quit:
+					eventsCancel()
+				case <-eventsCtx.Done():
+				}
+			}()
+			for {
+				select {
+				case l := <-logs:
+					m.onGameEvent(eventsCtx, l)
+				case err := <-sub.Err():
+					return err
+				case <-m.gamesChanged:
+					return errTrackedGamesChanged
+				case <-eventsCtx.Done():
+					return nil
+				}
+			}
+		}), nil
+	}
+}
+
 func (m *gameMonitor) resubscribeFunction() event.ResubscribeErrFunc {
 	// The ctx is cancelled as soon as the subscription is returned,
 	// but is only used to create the subscription, and does not affect the returned subscription.
@@ -171,6 +335,9 @@ func (m *gameMonitor) StartMonitoring() {
 		return // already started
 	}
 	m.l1HeadsSub = event.ResubscribeErr(time.Second*10, m.resubscribeFunction())
+	if m.events != nil {
+		m.eventsSub = event.ResubscribeErr(time.Second*10, m.resubscribeEventsFunction())
+	}
 }
 
 func (m *gameMonitor) StopMonitoring() {
@@ -181,4 +348,8 @@ func (m *gameMonitor) StopMonitoring() {
 	}
 	m.l1HeadsSub.Unsubscribe()
 	m.l1HeadsSub = nil
+	if m.eventsSub != nil {
+		m.eventsSub.Unsubscribe()
+		m.eventsSub = nil
+	}
 }
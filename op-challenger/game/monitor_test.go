@@ -8,7 +8,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -158,6 +161,25 @@ func TestMonitorCreateAndProgressGameAgents(t *testing.T) {
 	require.Equal(t, []common.Address{addr1, addr2}, sched.Scheduled()[0])
 }
 
+func TestMonitorClaimsBondsOnNewL1Head(t *testing.T) {
+	monitor, source, _, _, _ := setupMonitorTest(t, []common.Address{})
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	source.games = []types.GameMetadata{newFDG(addr1, 9999), newFDG(addr2, 9999)}
+
+	bondClaimer := &stubBondClaimer{}
+	claimScheduler := claims.NewBondClaimScheduler(testlog.Logger(t, log.LvlDebug), metrics.NoopMetrics, bondClaimer)
+	claimScheduler.Start(context.Background())
+	defer claimScheduler.Close()
+	monitor.claimer = claimScheduler
+
+	monitor.onNewL1Head(context.Background(), eth.L1BlockRef{Hash: common.Hash{0x01}, Number: 1})
+	require.Eventually(t, func() bool {
+		return len(bondClaimer.Claimed()) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	require.Equal(t, source.games, bondClaimer.Claimed()[0])
+}
+
 func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	addr1 := common.Address{0xaa}
 	addr2 := common.Address{0xbb}
@@ -170,6 +192,182 @@ func TestMonitorOnlyScheduleSpecifiedGame(t *testing.T) {
 	require.Equal(t, []common.Address{addr2}, sched.Scheduled()[0])
 }
 
+func TestMonitorOnlyScheduleAllowedGameTypes(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	monitor, source, sched, _, _ := setupMonitorTest(t, []common.Address{})
+	monitor.allowedTypes = []uint32{1}
+	game1 := newFDG(addr1, 9999)
+	game2 := newFDG(addr2, 9999)
+	game2.GameType = 1
+	source.games = []types.GameMetadata{game1, game2}
+
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}, 0))
+
+	require.Len(t, sched.Scheduled(), 1)
+	require.Equal(t, []common.Address{addr2}, sched.Scheduled()[0])
+}
+
+func TestMonitorSkipGamesCreatedBeforeMinCreationTime(t *testing.T) {
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	addr3 := common.Address{0xcc}
+	monitor, source, sched, _, _ := setupMonitorTest(t, []common.Address{})
+	monitor.minCreationTime = 5000
+	source.games = []types.GameMetadata{newFDG(addr1, 4999), newFDG(addr2, 5000), newFDG(addr3, 9999)}
+
+	require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}, 0))
+
+	require.Len(t, sched.Scheduled(), 1)
+	require.Equal(t, []common.Address{addr2, addr3}, sched.Scheduled()[0])
+}
+
+func TestMonitorGameEvents(t *testing.T) {
+	factory := common.Address{0xfa}
+	addr1 := common.Address{0xaa}
+	addr2 := common.Address{0xbb}
+	created := func(block common.Hash) ethtypes.Log {
+		return ethtypes.Log{Address: factory, Topics: []common.Hash{gameCreatedTopic}, BlockHash: block, BlockNumber: 5}
+	}
+	move := func(game common.Address, block common.Hash) ethtypes.Log {
+		return ethtypes.Log{Address: game, Topics: []common.Hash{moveTopic}, BlockHash: block, BlockNumber: 5}
+	}
+
+	t.Run("ProgressOnGameCreated", func(t *testing.T) {
+		monitor, source, sched, _, _ := setupMonitorTest(t, []common.Address{})
+		monitor.events = &stubGameEvents{factory: factory}
+		source.games = []types.GameMetadata{newFDG(addr1, 9999)}
+		monitor.onGameEvent(context.Background(), created(common.Hash{0x01}))
+		require.Len(t, sched.Scheduled(), 1)
+
+		// Only progress once per block
+		monitor.onGameEvent(context.Background(), created(common.Hash{0x01}))
+		require.Len(t, sched.Scheduled(), 1)
+
+		monitor.onGameEvent(context.Background(), created(common.Hash{0x02}))
+		require.Len(t, sched.Scheduled(), 2)
+	})
+
+	t.Run("ProgressOnMoveInTrackedGame", func(t *testing.T) {
+		monitor, source, sched, _, _ := setupMonitorTest(t, []common.Address{addr1})
+		monitor.events = &stubGameEvents{factory: factory}
+		source.games = []types.GameMetadata{newFDG(addr1, 9999), newFDG(addr2, 9999)}
+		require.NoError(t, monitor.progressGames(context.Background(), common.Hash{0x01}, 1))
+		require.Len(t, sched.Scheduled(), 1)
+
+		monitor.onGameEvent(context.Background(), move(addr2, common.Hash{0x02}))
+		require.Len(t, sched.Scheduled(), 1, "should ignore moves in games that are not tracked")
+
+		monitor.onGameEvent(context.Background(), move(addr1, common.Hash{0x02}))
+		require.Len(t, sched.Scheduled(), 2)
+	})
+
+	t.Run("IgnoreEventsBeforeLastProgressedBlock", func(t *testing.T) {
+		monitor, source, sched, _, _ := setupMonitorTest(t, []common.Address{})
+		monitor.events = &stubGameEvents{factory: factory}
+		source.games = []types.GameMetadata{newFDG(addr1, 9999)}
+		monitor.onNewL1Head(context.Background(), eth.L1BlockRef{Hash: common.Hash{0x06}, Number: 6})
+		require.Len(t, sched.Scheduled(), 1)
+
+		monitor.onGameEvent(context.Background(), created(common.Hash{0x05}))
+		require.Len(t, sched.Scheduled(), 1, "should not progress games at an older block")
+	})
+
+	t.Run("IgnoreRemovedLogs", func(t *testing.T) {
+		monitor, _, sched, _, _ := setupMonitorTest(t, []common.Address{})
+		monitor.events = &stubGameEvents{factory: factory}
+		l := created(common.Hash{0x01})
+		l.Removed = true
+		monitor.onGameEvent(context.Background(), l)
+		require.Empty(t, sched.Scheduled())
+	})
+
+	t.Run("SubscribeToEvents", func(t *testing.T) {
+		monitor, source, sched, _, _ := setupMonitorTest(t, []common.Address{})
+		logSource := &stubLogSubscriber{subscribed: make(chan chan<- ethtypes.Log, 1)}
+		monitor.events = &stubGameEvents{factory: factory}
+		monitor.logSource = logSource
+		source.games = []types.GameMetadata{newFDG(addr1, 9999)}
+
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+		var logs chan<- ethtypes.Log
+		select {
+		case logs = <-logSource.subscribed:
+		case <-time.After(10 * time.Second):
+			t.Fatal("did not subscribe to game events")
+		}
+		logs <- created(common.Hash{0x01})
+		waitErr := wait.For(context.Background(), 5*time.Second, func() (bool, error) {
+			return len(sched.Scheduled()) > 0, nil
+		})
+		require.NoError(t, waitErr)
+		require.Equal(t, []common.Address{addr1}, sched.Scheduled()[0])
+	})
+
+	t.Run("ResubscribeWhenTrackedGamesChange", func(t *testing.T) {
+		monitor, source, _, _, _ := setupMonitorTest(t, []common.Address{})
+		logSource := &stubLogSubscriber{subscribed: make(chan chan<- ethtypes.Log, 1), queries: make(chan ethereum.FilterQuery, 1)}
+		monitor.events = &stubGameEvents{factory: factory}
+		monitor.logSource = logSource
+
+		monitor.StartMonitoring()
+		defer monitor.StopMonitoring()
+		nextQuery := func() ethereum.FilterQuery {
+			select {
+			case q := <-logSource.queries:
+				<-logSource.subscribed
+				return q
+			case <-time.After(10 * time.Second):
+				t.Fatal("did not subscribe to game events")
+				return ethereum.FilterQuery{}
+			}
+		}
+		require.Equal(t, []common.Address{factory}, nextQuery().Addresses)
+
+		source.games = []types.GameMetadata{newFDG(addr1, 9999)}
+		monitor.onNewL1Head(context.Background(), eth.L1BlockRef{Hash: common.Hash{0x06}, Number: 6})
+		require.Equal(t, []common.Address{factory, addr1}, nextQuery().Addresses)
+	})
+}
+
+var (
+	gameCreatedTopic = common.Hash{0x01}
+	moveTopic        = common.Hash{0x02}
+)
+
+type stubGameEvents struct {
+	factory common.Address
+}
+
+func (s *stubGameEvents) FilterQuery(games []common.Address) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		Addresses: append([]common.Address{s.factory}, games...),
+		Topics:    [][]common.Hash{{gameCreatedTopic, moveTopic}},
+	}
+}
+
+func (s *stubGameEvents) IsGameCreated(l ethtypes.Log) bool {
+	return l.Address == s.factory && l.Topics[0] == gameCreatedTopic
+}
+
+func (s *stubGameEvents) IsMove(l ethtypes.Log) bool {
+	return l.Topics[0] == moveTopic
+}
+
+type stubLogSubscriber struct {
+	subscribed chan chan<- ethtypes.Log
+	queries    chan ethereum.FilterQuery
+}
+
+func (s *stubLogSubscriber) SubscribeFilterLogs(_ context.Context, q ethereum.FilterQuery, ch chan<- ethtypes.Log) (ethereum.Subscription, error) {
+	if s.queries != nil {
+		s.queries <- q
+	}
+	s.subscribed <- ch
+	return &mockSubscription{errChan: make(chan error)}, nil
+}
+
 func newFDG(proxy common.Address, timestamp uint64) types.GameMetadata {
 	return types.GameMetadata{
 		Proxy:     proxy,
@@ -202,7 +400,11 @@ func setupMonitorTest(
 		mockScheduler,
 		fetchBlockNum,
 		allowedGames,
+		nil,
+		0,
 		mockHeadSource,
+		nil,
+		nil,
 	)
 	return monitor, source, sched, mockHeadSource, preimages
 }
@@ -256,6 +458,24 @@ func (m *mockScheduler) Schedule(uint64, []types.GameMetadata) error {
 	return m.scheduleErr
 }
 
+type stubBondClaimer struct {
+	sync.Mutex
+	claimed [][]types.GameMetadata
+}
+
+func (s *stubBondClaimer) ClaimBonds(_ context.Context, games []types.GameMetadata) error {
+	s.Lock()
+	defer s.Unlock()
+	s.claimed = append(s.claimed, games)
+	return nil
+}
+
+func (s *stubBondClaimer) Claimed() [][]types.GameMetadata {
+	s.Lock()
+	defer s.Unlock()
+	return s.claimed
+}
+
 type mockSubscription struct {
 	errChan chan error
 	headers chan<- *ethtypes.Header
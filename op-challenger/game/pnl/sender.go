@@ -0,0 +1,81 @@
+package pnl
+
+import (
+	"math/big"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// TxSender records the gas spent and bonds posted by transactions sent for games of a single game type.
+type TxSender struct {
+	gameTypes.TxSender
+	tracker  *Tracker
+	gameType uint32
+}
+
+func NewTxSender(sender gameTypes.TxSender, tracker *Tracker, gameType uint32) *TxSender {
+	return &TxSender{
+		TxSender: sender,
+		tracker:  tracker,
+		gameType: gameType,
+	}
+}
+
+func (s *TxSender) SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	receipts, err := s.TxSender.SendAndWait(txPurpose, txs...)
+	gas := new(big.Int)
+	bonds := new(big.Int)
+	for i, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		gas.Add(gas, GasCost(receipt))
+		if receipt.Status == ethtypes.ReceiptStatusSuccessful && i < len(txs) && txs[i].Value != nil {
+			bonds.Add(bonds, txs[i].Value)
+		}
+	}
+	if gas.Sign() > 0 {
+		s.tracker.RecordGasSpent(s.gameType, gas)
+	}
+	if bonds.Sign() > 0 {
+		s.tracker.RecordBondPosted(s.gameType, bonds)
+	}
+	return receipts, err
+}
+
+// SharedTxSender records the gas spent by transactions that may act on games of multiple game types.
+type SharedTxSender struct {
+	gameTypes.TxSender
+	tracker *Tracker
+}
+
+func NewSharedTxSender(sender gameTypes.TxSender, tracker *Tracker) *SharedTxSender {
+	return &SharedTxSender{
+		TxSender: sender,
+		tracker:  tracker,
+	}
+}
+
+func (s *SharedTxSender) SendAndWait(txPurpose string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	receipts, err := s.TxSender.SendAndWait(txPurpose, txs...)
+	gas := new(big.Int)
+	for _, receipt := range receipts {
+		if receipt != nil {
+			gas.Add(gas, GasCost(receipt))
+		}
+	}
+	if gas.Sign() > 0 {
+		s.tracker.RecordSharedGasSpent(gas)
+	}
+	return receipts, err
+}
+
+// GasCost returns the amount (in wei) paid for the gas used by the transaction with receipt.
+func GasCost(receipt *ethtypes.Receipt) *big.Int {
+	if receipt.EffectiveGasPrice == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+}
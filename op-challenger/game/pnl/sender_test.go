@@ -0,0 +1,50 @@
+package pnl
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxSender(t *testing.T) {
+	tracker, _ := newTestTracker(t, "")
+	sender := NewTxSender(&stubTxSender{}, tracker, 2)
+	_, err := sender.SendAndWait("test",
+		txmgr.TxCandidate{Value: big.NewInt(100)},
+		txmgr.TxCandidate{Value: big.NewInt(200), TxData: []byte{0xff}}, // Reverts so bond is not posted
+		txmgr.TxCandidate{})
+	require.NoError(t, err)
+	requireSummary(t, tracker.Report().GameTypes[2], 3*21000*10, 100, 0, -(3*21000*10 + 100))
+}
+
+func TestSharedTxSender(t *testing.T) {
+	tracker, _ := newTestTracker(t, "")
+	sender := NewSharedTxSender(&stubTxSender{}, tracker)
+	_, err := sender.SendAndWait("test", txmgr.TxCandidate{}, txmgr.TxCandidate{})
+	require.NoError(t, err)
+	report := tracker.Report()
+	require.Empty(t, report.GameTypes)
+	requireSummary(t, report.Total, 2*21000*10, 0, 0, -2*21000*10)
+}
+
+type stubTxSender struct{}
+
+func (s *stubTxSender) From() common.Address {
+	return common.Address{0xaa}
+}
+
+func (s *stubTxSender) SendAndWait(_ string, txs ...txmgr.TxCandidate) ([]*ethtypes.Receipt, error) {
+	receipts := make([]*ethtypes.Receipt, len(txs))
+	for i, tx := range txs {
+		status := ethtypes.ReceiptStatusSuccessful
+		if len(tx.TxData) > 0 {
+			status = ethtypes.ReceiptStatusFailed
+		}
+		receipts[i] = &ethtypes.Receipt{Status: status, GasUsed: 21000, EffectiveGasPrice: big.NewInt(10)}
+	}
+	return receipts, nil
+}
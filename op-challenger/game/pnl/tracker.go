@@ -0,0 +1,212 @@
+package pnl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const allGameTypesLabel = "all"
+
+type Metrics interface {
+	RecordProfitAndLoss(gameType string, gasSpent, bondsPosted, bondsRecovered, netProfit *big.Int)
+}
+
+// Totals is the cumulative amount (in wei) spent and recovered by the challenger.
+type Totals struct {
+	GasSpent       *big.Int `json:"gasSpent"`
+	BondsPosted    *big.Int `json:"bondsPosted"`
+	BondsRecovered *big.Int `json:"bondsRecovered"`
+}
+
+func newTotals() *Totals {
+	return &Totals{
+		GasSpent:       new(big.Int),
+		BondsPosted:    new(big.Int),
+		BondsRecovered: new(big.Int),
+	}
+}
+
+func (t *Totals) add(other *Totals) {
+	t.GasSpent.Add(t.GasSpent, other.GasSpent)
+	t.BondsPosted.Add(t.BondsPosted, other.BondsPosted)
+	t.BondsRecovered.Add(t.BondsRecovered, other.BondsRecovered)
+}
+
+func (t *Totals) summary() Summary {
+	net := new(big.Int).Sub(t.BondsRecovered, t.BondsPosted)
+	net.Sub(net, t.GasSpent)
+	return Summary{
+		Totals: Totals{
+			GasSpent:       new(big.Int).Set(t.GasSpent),
+			BondsPosted:    new(big.Int).Set(t.BondsPosted),
+			BondsRecovered: new(big.Int).Set(t.BondsRecovered),
+		},
+		NetProfit: net,
+	}
+}
+
+// Summary is the profit and loss for a set of games. NetProfit is negative when the challenger has made a loss.
+type Summary struct {
+	Totals
+	NetProfit *big.Int `json:"netProfit"`
+}
+
+// Report is the profit and loss for each game type and across all games.
+// Total includes gas spent on transactions that act on games of multiple types, such as batched claim resolutions,
+// which is not included in any individual game type.
+type Report struct {
+	GameTypes map[uint32]Summary `json:"gameTypes"`
+	Total     Summary            `json:"total"`
+}
+
+type persistedTotals struct {
+	GameTypes      map[uint32]*Totals `json:"gameTypes"`
+	SharedGasSpent *big.Int           `json:"sharedGasSpent"`
+}
+
+// Tracker records the cumulative gas and bonds spent and the bonds recovered by the challenger for each game type.
+// If a path is provided, totals are stored so they accumulate across restarts.
+type Tracker struct {
+	logger log.Logger
+	m      Metrics
+	path   string
+
+	lock           sync.Mutex
+	gameTypes      map[uint32]*Totals
+	sharedGasSpent *big.Int
+}
+
+// NewTracker creates a Tracker, loading any previously stored totals from path.
+// Totals are not stored if path is empty.
+func NewTracker(logger log.Logger, m Metrics, path string) (*Tracker, error) {
+	t := &Tracker{
+		logger:         logger,
+		m:              m,
+		path:           path,
+		gameTypes:      make(map[uint32]*Totals),
+		sharedGasSpent: new(big.Int),
+	}
+	if path == "" {
+		return t, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return t, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read profit and loss totals: %w", err)
+	}
+	var stored persistedTotals
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to parse profit and loss totals: %w", err)
+	}
+	for gameType, totals := range stored.GameTypes {
+		loaded := newTotals()
+		loaded.add(totals)
+		t.gameTypes[gameType] = loaded
+	}
+	if stored.SharedGasSpent != nil {
+		t.sharedGasSpent.Set(stored.SharedGasSpent)
+	}
+	t.recordMetrics()
+	return t, nil
+}
+
+// RecordGasSpent adds amount to the gas spent on games of gameType.
+func (t *Tracker) RecordGasSpent(gameType uint32, amount *big.Int) {
+	t.update(func() {
+		totals := t.totals(gameType)
+		totals.GasSpent.Add(totals.GasSpent, amount)
+	})
+}
+
+// RecordSharedGasSpent adds amount to the gas spent on transactions that act on games of multiple types.
+func (t *Tracker) RecordSharedGasSpent(amount *big.Int) {
+	t.update(func() {
+		t.sharedGasSpent.Add(t.sharedGasSpent, amount)
+	})
+}
+
+// RecordBondPosted adds amount to the bonds posted in games of gameType.
+func (t *Tracker) RecordBondPosted(gameType uint32, amount *big.Int) {
+	t.update(func() {
+		totals := t.totals(gameType)
+		totals.BondsPosted.Add(totals.BondsPosted, amount)
+	})
+}
+
+// RecordBondRecovered adds amount to the credit claimed from games of gameType.
+func (t *Tracker) RecordBondRecovered(gameType uint32, amount *big.Int) {
+	t.update(func() {
+		totals := t.totals(gameType)
+		totals.BondsRecovered.Add(totals.BondsRecovered, amount)
+	})
+}
+
+func (t *Tracker) Report() Report {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	report := Report{GameTypes: make(map[uint32]Summary, len(t.gameTypes))}
+	for gameType, totals := range t.gameTypes {
+		report.GameTypes[gameType] = totals.summary()
+	}
+	report.Total = t.overall().summary()
+	return report
+}
+
+// update applies fn to the totals then updates metrics and stored totals. Failing to store the totals is logged but
+// not returned as the in-memory totals remain correct.
+func (t *Tracker) update(fn func()) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	fn()
+	t.recordMetrics()
+	if err := t.store(); err != nil {
+		t.logger.Error("Failed to store profit and loss totals", "path", t.path, "err", err)
+	}
+}
+
+// totals returns the totals for gameType, creating them if required. The lock must be held when calling this method.
+func (t *Tracker) totals(gameType uint32) *Totals {
+	totals, ok := t.gameTypes[gameType]
+	if !ok {
+		totals = newTotals()
+		t.gameTypes[gameType] = totals
+	}
+	return totals
+}
+
+// overall returns the totals across all games. The lock must be held when calling this method.
+func (t *Tracker) overall() *Totals {
+	overall := newTotals()
+	for _, totals := range t.gameTypes {
+		overall.add(totals)
+	}
+	overall.GasSpent.Add(overall.GasSpent, t.sharedGasSpent)
+	return overall
+}
+
+// recordMetrics reports the current totals. The lock must be held when calling this method.
+func (t *Tracker) recordMetrics() {
+	for gameType, totals := range t.gameTypes {
+		summary := totals.summary()
+		t.m.RecordProfitAndLoss(strconv.FormatUint(uint64(gameType), 10), summary.GasSpent, summary.BondsPosted, summary.BondsRecovered, summary.NetProfit)
+	}
+	summary := t.overall().summary()
+	t.m.RecordProfitAndLoss(allGameTypesLabel, summary.GasSpent, summary.BondsPosted, summary.BondsRecovered, summary.NetProfit)
+}
+
+// store writes the current totals to path. The lock must be held when calling this method.
+func (t *Tracker) store() error {
+	if t.path == "" {
+		return nil
+	}
+	return ioutil.WriteAtomicJSON(t.path, persistedTotals{GameTypes: t.gameTypes, SharedGasSpent: t.sharedGasSpent}, 0o644)
+}
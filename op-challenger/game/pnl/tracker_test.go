@@ -0,0 +1,77 @@
+package pnl
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	t.Run("Report", func(t *testing.T) {
+		tracker, m := newTestTracker(t, "")
+		tracker.RecordGasSpent(0, big.NewInt(10))
+		tracker.RecordBondPosted(0, big.NewInt(100))
+		tracker.RecordBondRecovered(0, big.NewInt(250))
+		tracker.RecordGasSpent(2, big.NewInt(5))
+		tracker.RecordBondPosted(2, big.NewInt(100))
+		tracker.RecordSharedGasSpent(big.NewInt(3))
+
+		report := tracker.Report()
+		require.Len(t, report.GameTypes, 2)
+		requireSummary(t, report.GameTypes[0], 10, 100, 250, 140)
+		requireSummary(t, report.GameTypes[2], 5, 100, 0, -105)
+		requireSummary(t, report.Total, 18, 200, 250, 32)
+
+		require.Equal(t, int64(140), m.net["0"])
+		require.Equal(t, int64(-105), m.net["2"])
+		require.Equal(t, int64(32), m.net[allGameTypesLabel])
+	})
+
+	t.Run("ReportIsCopy", func(t *testing.T) {
+		tracker, _ := newTestTracker(t, "")
+		tracker.RecordGasSpent(0, big.NewInt(10))
+		report := tracker.Report()
+		report.GameTypes[0].GasSpent.SetInt64(50)
+		requireSummary(t, tracker.Report().GameTypes[0], 10, 0, 0, -10)
+	})
+
+	t.Run("PersistTotals", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pnl.json")
+		tracker, _ := newTestTracker(t, path)
+		tracker.RecordGasSpent(0, big.NewInt(10))
+		tracker.RecordBondRecovered(0, big.NewInt(30))
+		tracker.RecordSharedGasSpent(big.NewInt(5))
+
+		reloaded, m := newTestTracker(t, path)
+		require.Equal(t, tracker.Report(), reloaded.Report())
+		require.Equal(t, int64(15), m.net[allGameTypesLabel], "should record metrics for loaded totals")
+		reloaded.RecordBondPosted(0, big.NewInt(1))
+		requireSummary(t, reloaded.Report().GameTypes[0], 10, 1, 30, 19)
+	})
+}
+
+func requireSummary(t *testing.T, summary Summary, gas, posted, recovered, net int64) {
+	require.Equal(t, big.NewInt(gas), summary.GasSpent)
+	require.Equal(t, big.NewInt(posted), summary.BondsPosted)
+	require.Equal(t, big.NewInt(recovered), summary.BondsRecovered)
+	require.Equal(t, big.NewInt(net), summary.NetProfit)
+}
+
+func newTestTracker(t *testing.T, path string) (*Tracker, *stubMetrics) {
+	m := &stubMetrics{net: make(map[string]int64)}
+	tracker, err := NewTracker(testlog.Logger(t, log.LvlInfo), m, path)
+	require.NoError(t, err)
+	return tracker, m
+}
+
+type stubMetrics struct {
+	net map[string]int64
+}
+
+func (s *stubMetrics) RecordProfitAndLoss(gameType string, _, _, _, netProfit *big.Int) {
+	s.net[gameType] = netProfit.Int64()
+}
@@ -36,12 +36,18 @@ func (r *GameTypeRegistry) RegisterGameType(gameType uint32, creator scheduler.P
 	}
 	r.types[gameType] = creator
 	if oracle != nil {
-		// It's ok to have two game types use the same oracle contract.
-		// We add them to a map deliberately to deduplicate them.
-		r.oracles[oracle.Addr()] = oracle
+		r.RegisterOracle(oracle)
 	}
 }
 
+// RegisterOracle registers a preimage oracle contract to monitor for large preimage proposals.
+// Oracles are deduplicated by address so registering the same oracle multiple times is safe.
+func (r *GameTypeRegistry) RegisterOracle(oracle keccakTypes.LargePreimageOracle) {
+	// It's ok to have two game types use the same oracle contract.
+	// We add them to a map deliberately to deduplicate them.
+	r.oracles[oracle.Addr()] = oracle
+}
+
 func (r *GameTypeRegistry) RegisterBondContract(gameType uint32, creator claims.BondContractCreator) {
 	if _, ok := r.bondCreators[gameType]; ok {
 		panic(fmt.Errorf("duplicate bond contract registered for game type: %v", gameType))
@@ -62,6 +62,22 @@ func TestDeduplicateOracles(t *testing.T) {
 	require.Contains(t, oracles, oracleB)
 }
 
+func TestRegisterAdditionalOracles(t *testing.T) {
+	registry := NewGameTypeRegistry()
+	creator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		return nil, nil
+	}
+	oracleA := stubPreimageOracle{0xaa}
+	oracleB := stubPreimageOracle{0xbb}
+	registry.RegisterGameType(0, creator, oracleA)
+	registry.RegisterOracle(oracleA)
+	registry.RegisterOracle(oracleB)
+	oracles := registry.Oracles()
+	require.Len(t, oracles, 2)
+	require.Contains(t, oracles, oracleA)
+	require.Contains(t, oracles, oracleB)
+}
+
 func TestBondContracts(t *testing.T) {
 	t.Run("UnknownGameType", func(t *testing.T) {
 		registry := NewGameTypeRegistry()
@@ -113,6 +129,10 @@ func (s stubPreimageOracle) GetActivePreimages(_ context.Context, _ common.Hash)
 	return nil, nil
 }
 
+func (s stubPreimageOracle) ChallengePeriod(_ context.Context) (uint64, error) {
+	panic("not supported")
+}
+
 type stubBondContract struct{}
 
 func (s *stubBondContract) GetCredit(ctx context.Context, receipient common.Address) (*big.Int, error) {
@@ -12,7 +12,7 @@ import (
 	"golang.org/x/exp/slices"
 )
 
-var errUnknownGame = errors.New("unknown game")
+var ErrUnknownGame = errors.New("unknown game")
 
 type PlayerCreator func(game types.GameMetadata, dir string) (GamePlayer, error)
 
@@ -164,7 +164,7 @@ func (c *coordinator) enqueueJob(ctx context.Context, j job) error {
 func (c *coordinator) processResult(j job) error {
 	state, ok := c.states[j.addr]
 	if !ok {
-		return fmt.Errorf("game %v received unexpected result: %w", j.addr, errUnknownGame)
+		return fmt.Errorf("game %v received unexpected result: %w", j.addr, ErrUnknownGame)
 	}
 	state.inflight = false
 	state.status = j.status
@@ -174,6 +174,15 @@ func (c *coordinator) processResult(j job) error {
 	return nil
 }
 
+// player returns the player for the specified game or nil if the game is not tracked or has no player yet.
+func (c *coordinator) player(addr common.Address) GamePlayer {
+	state, ok := c.states[addr]
+	if !ok {
+		return nil
+	}
+	return state.player
+}
+
 func (c *coordinator) deleteResolvedGameFiles() {
 	var keepGames []common.Address
 	for addr, state := range c.states {
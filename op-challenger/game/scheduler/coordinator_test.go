@@ -96,7 +96,7 @@ func TestScheduleGameAgainAfterCompletion(t *testing.T) {
 func TestResultForUnknownGame(t *testing.T) {
 	c, _, _, _, _ := setupCoordinatorTest(t, 10)
 	err := c.processResult(job{addr: common.Address{0xaa}})
-	require.ErrorIs(t, err, errUnknownGame)
+	require.ErrorIs(t, err, ErrUnknownGame)
 }
 
 func TestProcessResultsWhileJobQueueFull(t *testing.T) {
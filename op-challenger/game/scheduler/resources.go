@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+type ResourceMetricer interface {
+	RecordCannonRuns(running int, queued int)
+}
+
+// ResourceManager limits the number of cannon executions that run concurrently.
+// When the limit is reached, requests are queued and granted in order of the game deadline so that games with the
+// least time remaining on their clock are served first.
+type ResourceManager struct {
+	m       ResourceMetricer
+	maxRuns int
+
+	lock    sync.Mutex
+	running int
+	queue   requestQueue
+	nextSeq uint64
+}
+
+func NewResourceManager(m ResourceMetricer, maxRuns uint) *ResourceManager {
+	return &ResourceManager{
+		m:       m,
+		maxRuns: int(maxRuns),
+	}
+}
+
+// ForGame returns a limiter that acquires resources on behalf of a game that must be completed by deadline.
+func (r *ResourceManager) ForGame(deadline time.Time) *GameResources {
+	return &GameResources{manager: r, deadline: deadline}
+}
+
+// Acquire blocks until a cannon execution may start or ctx is done.
+// The returned release function must be called once the execution completes.
+func (r *ResourceManager) Acquire(ctx context.Context, deadline time.Time) (func(), error) {
+	r.lock.Lock()
+	if r.running < r.maxRuns && len(r.queue) == 0 {
+		r.running++
+		r.recordMetrics()
+		r.lock.Unlock()
+		return r.release, nil
+	}
+	req := &resourceRequest{deadline: deadline, seq: r.nextSeq, ready: make(chan struct{})}
+	r.nextSeq++
+	heap.Push(&r.queue, req)
+	r.recordMetrics()
+	r.lock.Unlock()
+
+	select {
+	case <-req.ready:
+		return r.release, nil
+	case <-ctx.Done():
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		if req.index < 0 {
+			// Resources were granted concurrently with the context being cancelled so hand them on.
+			r.running--
+			r.grantNext()
+		} else {
+			heap.Remove(&r.queue, req.index)
+		}
+		r.recordMetrics()
+		return nil, ctx.Err()
+	}
+}
+
+func (r *ResourceManager) release() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.running--
+	r.grantNext()
+	r.recordMetrics()
+}
+
+// grantNext starts queued requests while capacity is available. Must be called with the lock held.
+func (r *ResourceManager) grantNext() {
+	for r.running < r.maxRuns && len(r.queue) > 0 {
+		req := heap.Pop(&r.queue).(*resourceRequest)
+		r.running++
+		close(req.ready)
+	}
+}
+
+func (r *ResourceManager) recordMetrics() {
+	r.m.RecordCannonRuns(r.running, len(r.queue))
+}
+
+// GameResources acquires resources from a ResourceManager with the priority of a single game.
+type GameResources struct {
+	manager  *ResourceManager
+	deadline time.Time
+}
+
+func (g *GameResources) Acquire(ctx context.Context) (func(), error) {
+	return g.manager.Acquire(ctx, g.deadline)
+}
+
+type resourceRequest struct {
+	deadline time.Time
+	seq      uint64
+	index    int
+	ready    chan struct{}
+}
+
+// requestQueue is a heap of requests ordered by deadline, then by arrival.
+type requestQueue []*resourceRequest
+
+func (q requestQueue) Len() int {
+	return len(q)
+}
+
+func (q requestQueue) Less(i, j int) bool {
+	if q[i].deadline.Equal(q[j].deadline) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].deadline.Before(q[j].deadline)
+}
+
+func (q requestQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *requestQueue) Push(x any) {
+	req := x.(*resourceRequest)
+	req.index = len(*q)
+	*q = append(*q, req)
+}
+
+func (q *requestQueue) Pop() any {
+	old := *q
+	n := len(old)
+	req := old[n-1]
+	old[n-1] = nil
+	req.index = -1
+	*q = old[:n-1]
+	return req
+}
@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceManager_AcquireWithinLimit(t *testing.T) {
+	m := &stubResourceMetrics{}
+	resources := NewResourceManager(m, 2)
+	release1, err := resources.Acquire(context.Background(), time.Unix(100, 0))
+	require.NoError(t, err)
+	release2, err := resources.Acquire(context.Background(), time.Unix(100, 0))
+	require.NoError(t, err)
+	require.Equal(t, 2, m.running)
+	require.Equal(t, 0, m.queued)
+
+	release1()
+	release2()
+	require.Equal(t, 0, m.running)
+}
+
+func TestResourceManager_QueueByDeadline(t *testing.T) {
+	m := &stubResourceMetrics{}
+	resources := NewResourceManager(m, 1)
+	release, err := resources.Acquire(context.Background(), time.Unix(100, 0))
+	require.NoError(t, err)
+
+	granted := make(chan int, 3)
+	acquire := func(id int, deadline time.Time) {
+		go func() {
+			release, err := resources.ForGame(deadline).Acquire(context.Background())
+			require.NoError(t, err)
+			granted <- id
+			release()
+		}()
+	}
+	acquire(1, time.Unix(300, 0))
+	waitForQueued(t, resources, 1)
+	acquire(2, time.Unix(200, 0))
+	waitForQueued(t, resources, 2)
+	acquire(3, time.Unix(300, 0))
+	waitForQueued(t, resources, 3)
+	require.Equal(t, 1, m.running)
+	require.Equal(t, 3, m.queued)
+
+	release()
+	require.Equal(t, 2, <-granted, "should run game with least time remaining first")
+	require.Equal(t, 1, <-granted, "should run games with the same deadline in order of request")
+	require.Equal(t, 3, <-granted)
+	waitForQueued(t, resources, 0)
+}
+
+func TestResourceManager_CancelQueuedRequest(t *testing.T) {
+	m := &stubResourceMetrics{}
+	resources := NewResourceManager(m, 1)
+	release, err := resources.Acquire(context.Background(), time.Unix(100, 0))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		_, err := resources.Acquire(ctx, time.Unix(100, 0))
+		result <- err
+	}()
+	waitForQueued(t, resources, 1)
+	cancel()
+	require.ErrorIs(t, <-result, context.Canceled)
+	require.Equal(t, 0, m.queued)
+
+	release()
+	require.Equal(t, 0, m.running)
+	release, err = resources.Acquire(context.Background(), time.Unix(100, 0))
+	require.NoError(t, err, "should not have granted resources to cancelled request")
+	release()
+}
+
+func waitForQueued(t *testing.T, resources *ResourceManager, expected int) {
+	require.Eventually(t, func() bool {
+		resources.lock.Lock()
+		defer resources.lock.Unlock()
+		return len(resources.queue) == expected
+	}, 10*time.Second, 10*time.Millisecond)
+}
+
+type stubResourceMetrics struct {
+	running int
+	queued  int
+}
+
+func (s *stubResourceMetrics) RecordCannonRuns(running int, queued int) {
+	s.running = running
+	s.queued = queued
+}
@@ -3,9 +3,11 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -27,12 +29,18 @@ type blockGames struct {
 	games       []types.GameMetadata
 }
 
+type playerRequest struct {
+	addr   common.Address
+	result chan<- GamePlayer
+}
+
 type Scheduler struct {
 	logger         log.Logger
 	coordinator    *coordinator
 	m              SchedulerMetricer
 	maxConcurrency uint
 	scheduleQueue  chan blockGames
+	playerQueue    chan playerRequest
 	jobQueue       chan job
 	resultQueue    chan job
 	wg             sync.WaitGroup
@@ -55,6 +63,7 @@ func NewScheduler(logger log.Logger, m SchedulerMetricer, disk DiskManager, maxC
 		coordinator:    newCoordinator(logger, m, jobQueue, resultQueue, createPlayer, disk),
 		maxConcurrency: maxConcurrency,
 		scheduleQueue:  scheduleQueue,
+		playerQueue:    make(chan playerRequest),
 		jobQueue:       jobQueue,
 		resultQueue:    resultQueue,
 	}
@@ -99,6 +108,26 @@ func (s *Scheduler) Schedule(games []types.GameMetadata, blockNumber uint64) err
 	}
 }
 
+// Player returns the player for the specified game. The lookup is performed on the scheduling thread so it is
+// safe to call concurrently with scheduling. Returns ErrUnknownGame if the game does not currently have a player.
+func (s *Scheduler) Player(ctx context.Context, addr common.Address) (GamePlayer, error) {
+	result := make(chan GamePlayer, 1)
+	select {
+	case s.playerQueue <- playerRequest{addr: addr, result: result}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case player := <-result:
+		if player == nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnknownGame, addr)
+		}
+		return player, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 func (s *Scheduler) loop(ctx context.Context) {
 	defer s.wg.Done()
 	for {
@@ -109,6 +138,8 @@ func (s *Scheduler) loop(ctx context.Context) {
 			if err := s.coordinator.schedule(ctx, blockGames.games, blockGames.blockNumber); err != nil {
 				s.logger.Error("Failed to schedule game updates", "err", err)
 			}
+		case req := <-s.playerQueue:
+			req.result <- s.coordinator.player(req.addr)
 		case j := <-s.resultQueue:
 			if err := s.coordinator.processResult(j); err != nil {
 				s.logger.Error("Error while processing game result", "game", j.addr, "err", err)
@@ -59,6 +59,34 @@ func TestReturnBusyWhenScheduleQueueFull(t *testing.T) {
 	require.ErrorIs(t, err, ErrBusy)
 }
 
+func TestPlayerLookup(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	ctx := context.Background()
+	player := &test.StubGamePlayer{}
+	createPlayer := func(g types.GameMetadata, dir string) (GamePlayer, error) {
+		return player, nil
+	}
+	removeExceptCalls := make(chan []common.Address)
+	disk := &trackingDiskManager{removeExceptCalls: removeExceptCalls}
+	s := NewScheduler(logger, metrics.NoopMetrics, disk, 2, createPlayer)
+	s.Start(ctx)
+	defer s.Close()
+
+	gameAddr := common.Address{0xaa}
+	_, err := s.Player(ctx, gameAddr)
+	require.ErrorIs(t, err, ErrUnknownGame)
+
+	require.NoError(t, s.Schedule(asGames(gameAddr), 0))
+	<-removeExceptCalls
+
+	actual, err := s.Player(ctx, gameAddr)
+	require.NoError(t, err)
+	require.Same(t, player, actual)
+
+	_, err = s.Player(ctx, common.Address{0xbb})
+	require.ErrorIs(t, err, ErrUnknownGame)
+}
+
 type trackingDiskManager struct {
 	removeExceptCalls chan []common.Address
 }
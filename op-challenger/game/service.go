@@ -5,20 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"os"
+	"path/filepath"
 	"sync/atomic"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak/fetcher"
 	"github.com/ethereum-optimism/optimism/op-challenger/sender"
+	"github.com/ethereum-optimism/optimism/op-service/shutdown"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-challenger/api"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/archive"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/loader"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/pnl"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
@@ -26,6 +38,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -33,18 +46,39 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
+// l1BlockTime is the expected time between L1 blocks.
+const l1BlockTime = 12 * time.Second
+
+// resolutionBatchDelay is the maximum time to wait for claims from other games to be added to a batch before
+// resolving the claims already in it.
+const resolutionBatchDelay = 2 * time.Second
+
+// profitsFile is the file in the datadir that cumulative profit and loss totals are stored in.
+const profitsFile = "pnl.json"
+
+// budgetFile is the file in the datadir that spending against the spending budget is stored in.
+const budgetFile = "budget.json"
+
 type Service struct {
 	logger  log.Logger
 	metrics metrics.Metricer
 	monitor *gameMonitor
 	sched   *scheduler.Scheduler
 
+	resources *scheduler.ResourceManager
+
 	faultGamesCloser fault.CloseFunc
 
-	preimages *keccak.LargePreimageScheduler
+	archive *archive.Archive
+
+	preimages      *keccak.LargePreimageScheduler
+	preimageStatus *keccak.StatusTracker
 
 	txMgr    *txmgr.SimpleTxManager
 	txSender *sender.TxSender
+	// txMgrs are the transaction managers shared between chains, closed by the service that owns them.
+	txMgrs     *txManagers
+	ownsTxMgrs bool
 
 	cl *clock.SimpleClock
 
@@ -52,27 +86,92 @@ type Service struct {
 
 	claimer *claims.BondClaimScheduler
 
+	chainName string
+	profits   *pnl.Tracker
+	budget    *fault.SpendBudget
+
 	factoryContract *contracts.DisputeGameFactoryContract
 	registry        *registry.GameTypeRegistry
 	rollupClient    *sources.RollupClient
 
-	l1Client   *ethclient.Client
-	pollClient client.RPC
+	l1Client    *ethclient.Client
+	l1Fallbacks []*ethclient.Client
+	pollClient  client.RPC
 
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
+	apiServer    *api.Server
+	healthChecks []healthCheck
+
+	// chains are the services playing games on additional chains
+	chains []*Service
 
 	balanceMetricer io.Closer
 
+	// started is set once the service has started playing games, which the readiness check reports.
+	started atomic.Bool
 	stopped atomic.Bool
 }
 
 // NewService creates a new Service.
+// If additional chains are configured, a child service is created to play games on each chain. Metrics for all
+// chains are served from a single registry, labelled by chain name. Cannon executions are limited across all chains.
+// Chains sending from the same account on the same L1 share a transaction manager so nonces are not reused.
+// All chains share a single spending budget, stored in the top level datadir.
 func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
+	txMgrs := newTxManagers()
+	if len(cfg.Chains) == 0 {
+		m := metrics.NewMetrics()
+		budget, err := newSpendBudget(logger, m, cfg)
+		if err != nil {
+			return nil, err
+		}
+		return newService(ctx, logger, cfg, m, scheduler.NewResourceManager(m, cfg.CannonMaxConcurrency), budget, txMgrs, true, nil)
+	}
+	registry := opmetrics.NewRegistry()
+	m := metrics.NewChainMetrics(registry, cfg.ChainName)
+	resources := scheduler.NewResourceManager(m, cfg.CannonMaxConcurrency)
+	budget, err := newSpendBudget(logger, m, cfg)
+	if err != nil {
+		return nil, err
+	}
+	var chains []*Service
+	stopChainsOnErr := func(err error) error {
+		err = errors.Join(err, stopChains(ctx, chains))
+		txMgrs.Close()
+		return err
+	}
+	for _, chainCfg := range cfg.ChainConfigs() {
+		chainCfg := chainCfg
+		if err := os.MkdirAll(chainCfg.Datadir, 0o755); err != nil {
+			return nil, stopChainsOnErr(fmt.Errorf("failed to create datadir for chain %v: %w", chainCfg.ChainName, err))
+		}
+		chainLogger := logger.New("chain", chainCfg.ChainName)
+		chain, err := newService(ctx, chainLogger, &chainCfg, metrics.NewChainMetrics(registry, chainCfg.ChainName), resources, budget, txMgrs, false, nil)
+		if err != nil {
+			return nil, stopChainsOnErr(fmt.Errorf("failed to init chain %v: %w", chainCfg.ChainName, err))
+		}
+		if cfg.MetricsConfig.Enabled {
+			chain.startBalanceMetrics()
+		}
+		chains = append(chains, chain)
+	}
+	return newService(ctx, logger.New("chain", cfg.ChainName), cfg, m, resources, budget, txMgrs, true, chains)
+}
+
+// newService creates a service playing games on a single chain.
+// Additional chains are only passed to the top level service, which also owns the shared transaction managers.
+// The budget may be nil if spending is not limited.
+func newService(ctx context.Context, logger log.Logger, cfg *config.Config, m metrics.Metricer, resources *scheduler.ResourceManager, budget *fault.SpendBudget, txMgrs *txManagers, ownsTxMgrs bool, chains []*Service) (*Service, error) {
 	s := &Service{
-		cl:      clock.NewSimpleClock(),
-		logger:  logger,
-		metrics: metrics.NewMetrics(),
+		cl:         clock.NewSimpleClock(),
+		logger:     logger,
+		metrics:    m,
+		resources:  resources,
+		budget:     budget,
+		txMgrs:     txMgrs,
+		ownsTxMgrs: ownsTxMgrs,
+		chains:     chains,
 	}
 
 	if err := s.initFromConfig(ctx, cfg); err != nil {
@@ -108,6 +207,12 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initGameLoader(); err != nil {
 		return fmt.Errorf("failed to init game loader: %w", err)
 	}
+	if err := s.initArchive(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init game archive: %w", err)
+	}
+	if err := s.initProfits(cfg); err != nil {
+		return fmt.Errorf("failed to init profit and loss tracking: %w", err)
+	}
 	if err := s.registerGameTypes(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to register game types: %w", err)
 	}
@@ -117,11 +222,18 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initScheduler(cfg); err != nil {
 		return fmt.Errorf("failed to init scheduler: %w", err)
 	}
-	if err := s.initLargePreimages(); err != nil {
+	if err := s.initLargePreimages(cfg); err != nil {
 		return fmt.Errorf("failed to init large preimage scheduler: %w", err)
 	}
 
-	s.initMonitor(cfg)
+	if err := s.initMonitor(cfg); err != nil {
+		return fmt.Errorf("failed to init game monitor: %w", err)
+	}
+	s.initHealthChecks(cfg)
+
+	if err := s.initAPIServer(&cfg.APIConfig); err != nil {
+		return fmt.Errorf("failed to init api server: %w", err)
+	}
 
 	s.metrics.RecordInfo(version.SimpleWithMeta)
 	s.metrics.RecordUp()
@@ -129,12 +241,12 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 }
 
 func (s *Service) initTxManager(ctx context.Context, cfg *config.Config) error {
-	txMgr, err := txmgr.NewSimpleTxManager("challenger", s.logger, s.metrics, cfg.TxMgrConfig)
+	txMgr, txSender, err := s.txMgrs.get(ctx, s.logger, s.metrics, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create the transaction manager: %w", err)
+		return err
 	}
 	s.txMgr = txMgr
-	s.txSender = sender.NewTxSender(ctx, s.logger, txMgr, cfg.MaxPendingTx)
+	s.txSender = txSender
 	return nil
 }
 
@@ -144,11 +256,23 @@ func (s *Service) initL1Client(ctx context.Context, cfg *config.Config) error {
 		return fmt.Errorf("failed to dial L1: %w", err)
 	}
 	s.l1Client = l1Client
+	for _, rpc := range cfg.L1EthRpcFallbacks {
+		fallback, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, rpc)
+		if err != nil {
+			return fmt.Errorf("failed to dial fallback L1: %w", err)
+		}
+		s.l1Fallbacks = append(s.l1Fallbacks, fallback)
+	}
 	return nil
 }
 
+// instrumentedL1RPC wraps the primary L1 RPC client so per-method latency and response sizes are recorded.
+func (s *Service) instrumentedL1RPC() client.RPC {
+	return client.NewInstrumentedRPC(client.NewBaseRPCClient(s.l1Client.Client()), s.metrics)
+}
+
 func (s *Service) initPollClient(ctx context.Context, cfg *config.Config) error {
-	pollClient, err := client.NewRPCWithClient(ctx, s.logger, cfg.L1EthRpc, client.NewBaseRPCClient(s.l1Client.Client()), cfg.PollInterval)
+	pollClient, err := client.NewRPCWithClient(ctx, s.logger, cfg.L1EthRpc, s.instrumentedL1RPC(), cfg.PollInterval)
 	if err != nil {
 		return fmt.Errorf("failed to create RPC client: %w", err)
 	}
@@ -157,14 +281,7 @@ func (s *Service) initPollClient(ctx context.Context, cfg *config.Config) error
 }
 
 func (s *Service) initPProf(cfg *oppprof.CLIConfig) error {
-	s.pprofService = oppprof.New(
-		cfg.ListenEnabled,
-		cfg.ListenAddr,
-		cfg.ListenPort,
-		cfg.ProfileType,
-		cfg.ProfileDir,
-		cfg.ProfileFilename,
-	)
+	s.pprofService = oppprof.NewFromCLIConfig(*cfg)
 
 	if err := s.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -188,13 +305,17 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 	}
 	s.logger.Info("started metrics server", "addr", metricsSrv.Addr())
 	s.metricsSrv = metricsSrv
-	s.balanceMetricer = s.metrics.StartBalanceMetrics(s.logger, s.l1Client, s.txSender.From())
+	s.startBalanceMetrics()
 	return nil
 }
 
+func (s *Service) startBalanceMetrics() {
+	s.balanceMetricer = s.metrics.StartBalanceMetrics(s.logger, s.l1Client, s.txSender.From())
+}
+
 func (s *Service) initFactoryContract(cfg *config.Config) error {
 	factoryContract, err := contracts.NewDisputeGameFactoryContract(cfg.GameFactoryAddress,
-		batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
+		batching.NewMultiCaller(s.instrumentedL1RPC(), batching.DefaultBatchSize))
 	if err != nil {
 		return fmt.Errorf("failed to bind the fault dispute game factory contract: %w", err)
 	}
@@ -207,8 +328,18 @@ func (s *Service) initGameLoader() error {
 	return nil
 }
 
+func (s *Service) initProfits(cfg *config.Config) error {
+	profits, err := pnl.NewTracker(s.logger, s.metrics, filepath.Join(cfg.Datadir, profitsFile))
+	if err != nil {
+		return err
+	}
+	s.chainName = cfg.ChainName
+	s.profits = profits
+	return nil
+}
+
 func (s *Service) initBondClaims() error {
-	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender)
+	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.profits)
 	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, claimer)
 	return nil
 }
@@ -225,42 +356,185 @@ func (s *Service) initRollupClient(ctx context.Context, cfg *config.Config) erro
 	return nil
 }
 
+func (s *Service) initArchive(ctx context.Context, cfg *config.Config) error {
+	if cfg.ArchiveDriver == "" {
+		return nil
+	}
+	gameArchive, err := archive.NewArchive(ctx, cfg.ArchiveDriver, cfg.ArchiveDSN)
+	if err != nil {
+		return err
+	}
+	s.archive = gameArchive
+	return nil
+}
+
 func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) error {
 	gameTypeRegistry := registry.NewGameTypeRegistry()
-	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.cl, s.logger, s.metrics, cfg, s.rollupClient, s.txSender, s.factoryContract, caller)
+	caller := batching.NewMultiCaller(s.instrumentedL1RPC(), batching.DefaultBatchSize)
+	var archiver fault.GameArchiver
+	if s.archive != nil {
+		archiver = s.archive
+	}
+	resolver, err := s.newClaimResolver(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	closer, err := fault.RegisterGameTypes(gameTypeRegistry, ctx, s.cl, s.logger, s.metrics, cfg, s.rollupClient, s.txSender, s.factoryContract, caller, archiver, s.resources, s.budget, resolver, s.profits)
 	if err != nil {
 		return err
 	}
 	s.faultGamesCloser = closer
+	for _, addr := range cfg.AdditionalPreimageOracles {
+		oracle, err := contracts.NewPreimageOracleContract(addr, caller)
+		if err != nil {
+			return fmt.Errorf("failed to create preimage oracle contract %v: %w", addr, err)
+		}
+		gameTypeRegistry.RegisterOracle(oracle)
+	}
 	s.registry = gameTypeRegistry
 	return nil
 }
 
+// newSpendBudget creates the budget limiting spending on games, or nil if no limits are configured.
+// Chains use the same signer, so a single budget is shared by all chains rather than one per chain.
+// Spending is stored in the datadir so the budget is not reset when the challenger restarts.
+func newSpendBudget(logger log.Logger, m metrics.Metricer, cfg *config.Config) (*fault.SpendBudget, error) {
+	if cfg.MaxGameSpendEth == 0 && cfg.MaxTotalSpendEth == 0 {
+		return nil, nil
+	}
+	maxPerGame, err := eth.GweiToWei(cfg.MaxGameSpendEth * params.GWei)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max game spend: %w", err)
+	}
+	maxTotal, err := eth.GweiToWei(cfg.MaxTotalSpendEth * params.GWei)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max total spend: %w", err)
+	}
+	return fault.NewSpendBudget(logger, m, maxPerGame, maxTotal, filepath.Join(cfg.Datadir, budgetFile))
+}
+
+// newClaimResolver creates the resolver used to batch claim resolutions from all games into multicall transactions,
+// or nil if batching is disabled or the Multicall3 contract is not deployed.
+func (s *Service) newClaimResolver(ctx context.Context, cfg *config.Config) (responder.ClaimResolver, error) {
+	if cfg.ResolutionBatchSize <= 1 {
+		return nil, nil
+	}
+	addr := common.HexToAddress(predeploys.MultiCall3)
+	code, err := s.l1Client.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for multicall3 contract: %w", err)
+	}
+	if len(code) == 0 {
+		s.logger.Warn("Multicall3 contract not deployed, claims will be resolved individually", "addr", addr)
+		return nil, nil
+	}
+	multicall, err := contracts.NewMulticall3Contract(addr)
+	if err != nil {
+		return nil, err
+	}
+	// Batches may resolve claims in games of different types so the gas can't be attributed to a single game type.
+	txSender := pnl.NewSharedTxSender(s.txSender, s.profits)
+	return claims.NewResolutionBatcher(s.logger, clock.SystemClock, multicall, txSender, int(cfg.ResolutionBatchSize), resolutionBatchDelay), nil
+}
+
 func (s *Service) initScheduler(cfg *config.Config) error {
 	disk := newDiskManager(cfg.Datadir)
 	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, s.registry.CreatePlayer)
 	return nil
 }
 
-func (s *Service) initLargePreimages() error {
-	fetcher := fetcher.NewPreimageFetcher(s.logger, s.l1Client)
-	verifier := keccak.NewPreimageVerifier(s.logger, fetcher)
-	challenger := keccak.NewPreimageChallenger(s.logger, s.metrics, verifier, s.txSender)
-	s.preimages = keccak.NewLargePreimageScheduler(s.logger, s.registry.Oracles(), challenger)
+func (s *Service) initLargePreimages(cfg *config.Config) error {
+	l1Sources := []fetcher.L1Source{fetcher.NewBatchingL1Source(s.l1Client, s.l1Client.Client(), cfg.LargePreimageBatchSize)}
+	for _, client := range s.l1Fallbacks {
+		l1Sources = append(l1Sources, fetcher.NewBatchingL1Source(client, client.Client(), cfg.LargePreimageBatchSize))
+	}
+	fetcher := fetcher.NewPreimageFetcher(s.logger, fetcher.NewFailoverL1Source(s.logger, l1Sources...), cfg.LargePreimageBatchSize)
+	cache, err := keccak.NewVerifiedPreimageCache(s.logger, cfg.Datadir)
+	if err != nil {
+		return fmt.Errorf("failed to load verified preimage cache: %w", err)
+	}
+	verifier := keccak.NewCachingVerifier(s.logger, keccak.NewPreimageVerifier(s.logger, s.metrics, fetcher, cfg.LargePreimageSnapshotInterval), cache)
+	s.preimageStatus = keccak.NewStatusTracker(clock.SystemClock)
+	gasLimiter := keccak.NewChallengeGasLimiter(s.logger, s.l1Client, s.txSender.From(), cfg.LargePreimageGasPadding, cfg.LargePreimageGasCap)
+	inclusions := keccak.NewChallengeInclusionTracker(s.logger, s.metrics, s.txSender, s.l1Client)
+	urgentTipCap, err := eth.GweiToWei(cfg.LargePreimageUrgentTipCapGwei)
+	if err != nil {
+		return fmt.Errorf("invalid large preimage urgent tip cap: %w", err)
+	}
+	challenger := keccak.NewPreimageChallenger(s.logger, s.metrics, verifier, inclusions, gasLimiter, s.preimageStatus, cfg.LargePreimageWorkers, cfg.LargePreimageDryRun, urgentTipCap)
+	s.preimages = keccak.NewLargePreimageScheduler(s.logger, s.cl, s.metrics, s.registry.Oracles(), challenger, cache, s.preimageStatus, inclusions,
+		keccak.NewClaimantFilter(cfg.LargePreimageClaimantAllowlist, cfg.LargePreimageClaimantBlocklist),
+		time.Duration(cfg.LargePreimageUrgentBlocks)*l1BlockTime)
 	return nil
 }
 
-func (s *Service) initMonitor(cfg *config.Config) {
-	s.monitor = newGameMonitor(s.logger, s.cl, s.loader, s.sched, s.preimages, cfg.GameWindow, s.claimer, s.l1Client.BlockNumber, cfg.GameAllowlist, s.pollClient)
+func (s *Service) initAPIServer(cfg *api.CLIConfig) error {
+	if !cfg.ServerEnabled() {
+		return nil
+	}
+	s.apiServer = api.NewServer(s.logger, *cfg)
+	health := api.NewHealthChecker(s.logger)
+	if len(s.chains) == 0 {
+		s.addHealthChecks(health, "")
+	} else {
+		s.addHealthChecks(health, s.chainName+"/")
+		for _, chain := range s.chains {
+			chain.addHealthChecks(health, chain.chainName+"/")
+		}
+	}
+	s.apiServer.HandleHealth(health)
+	if !cfg.Enabled {
+		// Only the health endpoints are served, which don't require authentication.
+		return s.apiServer.Start()
+	}
+	players := playerSources{s.sched}
+	for _, chain := range s.chains {
+		players = append(players, chain.sched)
+	}
+	s.apiServer.HandleJSON("/large-preimages", func(_ *http.Request) (any, error) {
+		statuses := s.preimageStatus.Statuses()
+		for _, chain := range s.chains {
+			statuses = append(statuses, chain.preimageStatus.Statuses()...)
+		}
+		return statuses, nil
+	})
+	s.apiServer.HandleJSON("/games/explain", explainClaimHandler(players))
+	s.apiServer.HandleJSON("/pnl", func(_ *http.Request) (any, error) {
+		reports := map[string]pnl.Report{s.chainName: s.profits.Report()}
+		for _, chain := range s.chains {
+			reports[chain.chainName] = chain.profits.Report()
+		}
+		return reports, nil
+	})
+	return s.apiServer.Start()
+}
+
+func (s *Service) initMonitor(cfg *config.Config) error {
+	var events gameEvents
+	if cfg.SubscribeGameEvents {
+		gameEvents, err := contracts.NewGameEvents(cfg.GameFactoryAddress)
+		if err != nil {
+			return err
+		}
+		events = gameEvents
+	}
+	s.monitor = newGameMonitor(s.logger, s.cl, s.loader, s.sched, s.preimages, cfg.GameWindow, s.claimer, s.l1Client.BlockNumber, cfg.GameAllowlist, cfg.GameTypeAllowlist, cfg.MinGameCreationTime, s.pollClient, events, s.l1Client)
+	return nil
 }
 
 func (s *Service) Start(ctx context.Context) error {
 	s.logger.Info("starting scheduler")
 	s.sched.Start(ctx)
 	s.preimages.Start(ctx)
+	s.claimer.Start(ctx)
 	s.logger.Info("starting monitoring")
 	s.monitor.StartMonitoring()
+	for _, chain := range s.chains {
+		if err := chain.Start(ctx); err != nil {
+			return err
+		}
+	}
+	s.started.Store(true)
 	s.logger.Info("challenger game service start completed")
 	return nil
 }
@@ -272,48 +546,82 @@ func (s *Service) Stopped() bool {
 func (s *Service) Stop(ctx context.Context) error {
 	s.logger.Info("stopping challenger game service")
 
-	var result error
-	if s.sched != nil {
-		if err := s.sched.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close scheduler: %w", err))
-		}
+	result := s.shutdownManager().Stop(ctx)
+	s.stopped.Store(true)
+	s.logger.Info("stopped challenger game service", "err", result)
+	return result
+}
+
+// shutdownManager registers the initialized subsystems of the service, after the subsystems they use.
+func (s *Service) shutdownManager() *shutdown.Manager {
+	m := shutdown.NewManager(s.logger)
+	if s.metricsSrv != nil {
+		m.Register("metrics-server", s.metricsSrv.Stop)
 	}
-	if s.monitor != nil {
-		s.monitor.StopMonitoring()
+	if s.pprofService != nil {
+		m.Register("pprof", s.pprofService.Stop)
 	}
-	if s.faultGamesCloser != nil {
-		s.faultGamesCloser()
+	if s.l1Client != nil {
+		m.Register("l1-client", shutdown.Closer(s.l1Client.Close))
 	}
-	if s.pprofService != nil {
-		if err := s.pprofService.Stop(ctx); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
-		}
+	if len(s.l1Fallbacks) != 0 {
+		m.Register("l1-fallbacks", shutdown.Closer(func() {
+			for _, client := range s.l1Fallbacks {
+				client.Close()
+			}
+		}))
+	}
+	if s.pollClient != nil {
+		m.Register("poll-client", shutdown.Closer(s.pollClient.Close))
+	}
+	if s.rollupClient != nil {
+		m.Register("rollup-client", shutdown.Closer(s.rollupClient.Close))
+	}
+	if s.ownsTxMgrs {
+		m.Register("txmgr", shutdown.Closer(s.txMgrs.Close))
+	}
+	if s.archive != nil {
+		m.Register("archive", shutdown.ErrCloser(s.archive.Close))
 	}
 	if s.balanceMetricer != nil {
-		if err := s.balanceMetricer.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close balance metricer: %w", err))
-		}
+		m.Register("balance-metrics", shutdown.ErrCloser(s.balanceMetricer.Close), shutdown.DependsOn("l1-client"))
 	}
-
-	if s.txMgr != nil {
-		s.txMgr.Close()
+	if s.faultGamesCloser != nil {
+		m.Register("fault-games", shutdown.Closer(s.faultGamesCloser),
+			shutdown.DependsOn("l1-client", "rollup-client", "txmgr", "archive"))
 	}
-
-	if s.rollupClient != nil {
-		s.rollupClient.Close()
+	if s.sched != nil {
+		m.Register("scheduler", shutdown.ErrCloser(s.sched.Close), shutdown.DependsOn("fault-games"))
 	}
-	if s.pollClient != nil {
-		s.pollClient.Close()
+	if s.preimages != nil {
+		m.Register("large-preimages", shutdown.ErrCloser(s.preimages.Close),
+			shutdown.DependsOn("l1-client", "l1-fallbacks", "txmgr"))
 	}
-	if s.l1Client != nil {
-		s.l1Client.Close()
+	if s.claimer != nil {
+		m.Register("bond-claims", shutdown.ErrCloser(s.claimer.Close), shutdown.DependsOn("l1-client", "txmgr"))
 	}
-	if s.metricsSrv != nil {
-		if err := s.metricsSrv.Stop(ctx); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close metrics server: %w", err))
+	if s.monitor != nil {
+		m.Register("game-monitor", shutdown.Closer(s.monitor.StopMonitoring),
+			shutdown.DependsOn("scheduler", "large-preimages", "bond-claims", "l1-client", "poll-client"))
+	}
+	if len(s.chains) != 0 {
+		// Chains share the metrics server of this service.
+		m.Register("chains", func(ctx context.Context) error {
+			return stopChains(ctx, s.chains)
+		}, shutdown.DependsOn("metrics-server", "txmgr"))
+	}
+	if s.apiServer != nil {
+		m.Register("api-server", s.apiServer.Stop, shutdown.DependsOn("scheduler", "large-preimages", "chains"))
+	}
+	return m
+}
+
+func stopChains(ctx context.Context, chains []*Service) error {
+	var result error
+	for _, chain := range chains {
+		if err := chain.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop chain service: %w", err))
 		}
 	}
-	s.stopped.Store(true)
-	s.logger.Info("stopped challenger game service", "err", result)
 	return result
 }
@@ -0,0 +1,72 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/sender"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type txManagerKey struct {
+	chainID string
+	from    common.Address
+}
+
+type sharedTxManager struct {
+	txMgr    *txmgr.SimpleTxManager
+	txSender *sender.TxSender
+}
+
+// txManagers shares transaction managers between the chains played by a single challenger.
+// Chains that send from the same account on the same L1 use one transaction manager, so their transactions are
+// assigned nonces in sequence rather than racing each other for the same nonce.
+type txManagers struct {
+	lock     sync.Mutex
+	managers map[txManagerKey]*sharedTxManager
+}
+
+func newTxManagers() *txManagers {
+	return &txManagers{managers: make(map[txManagerKey]*sharedTxManager)}
+}
+
+// get returns the transaction manager and sender for the account and L1 specified by cfg, creating them if they
+// are not already in use by another chain.
+// The metrics and logger of the chain that first uses the transaction manager are used.
+func (t *txManagers) get(ctx context.Context, logger log.Logger, m metrics.Metricer, cfg *config.Config) (*txmgr.SimpleTxManager, *sender.TxSender, error) {
+	txMgrCfg, err := txmgr.NewConfig(cfg.TxMgrConfig, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the transaction manager config: %w", err)
+	}
+	key := txManagerKey{chainID: txMgrCfg.ChainID.String(), from: txMgrCfg.From}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if shared, ok := t.managers[key]; ok {
+		txMgrCfg.Backend.Close()
+		logger.Info("Sharing transaction manager with other chains", "l1ChainID", key.chainID, "from", key.from)
+		return shared.txMgr, shared.txSender, nil
+	}
+	txMgr, err := txmgr.NewSimpleTxManagerFromConfig("challenger", logger, m, txMgrCfg)
+	if err != nil {
+		txMgrCfg.Backend.Close()
+		return nil, nil, fmt.Errorf("failed to create the transaction manager: %w", err)
+	}
+	txSender := sender.NewTxSender(ctx, logger, txMgr, cfg.MaxPendingTx)
+	t.managers[key] = &sharedTxManager{txMgr: txMgr, txSender: txSender}
+	return txMgr, txSender, nil
+}
+
+// Close closes all transaction managers.
+func (t *txManagers) Close() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for _, shared := range t.managers {
+		shared.txMgr.Close()
+	}
+}
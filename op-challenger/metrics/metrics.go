@@ -2,6 +2,9 @@ package metrics
 
 import (
 	"io"
+	"math"
+	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,17 +31,40 @@ type Metricer interface {
 	// Record cache metrics
 	caching.Metrics
 
+	// Record RPC client metrics
+	opmetrics.RPCClientMetricer
+
 	RecordActedL1Block(n uint64)
 
 	RecordGameStep()
 	RecordGameMove()
+	RecordActionSimulationReverted(actionType string)
+	RecordMaxPlayDepthReached()
+	RecordInvalidOwnClaim()
 	RecordCannonExecutionTime(t float64)
+	RecordAsteriscExecutionTime(t float64)
+	RecordCannonRuns(running int, queued int)
 
 	RecordPreimageChallenged()
 	RecordPreimageChallengeFailed()
+	RecordPreimageChallengeDryRun()
+	RecordPreimageChallengeGasEstimationFailed()
+	RecordPreimageChallengeReorged()
+	RecordPreimageInclusionCheckFailed()
+	RecordLargePreimageFetchTime(t time.Duration)
+	RecordLargePreimageVerificationThroughput(bytesPerSecond float64)
+	RecordLargePreimageChallengeTime(t time.Duration)
+	RecordLargePreimageMinTimeRemaining(remaining time.Duration)
+	ClearLargePreimageMinTimeRemaining()
 
 	RecordBondClaimFailed()
-	RecordBondClaimed(amount uint64)
+	RecordBondClaimed(amount *big.Int)
+	RecordBondsPending(amount *big.Int)
+
+	RecordBudgetExceeded()
+	RecordTotalSpend(amount *big.Int)
+
+	RecordProfitAndLoss(gameType string, gasSpent, bondsPosted, bondsRecovered, netProfit *big.Int)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 
@@ -55,11 +81,13 @@ type Metricer interface {
 var _ opmetrics.RegistryMetricer = (*Metrics)(nil)
 
 type Metrics struct {
-	ns       string
-	registry *prometheus.Registry
-	factory  opmetrics.Factory
+	ns         string
+	registry   *prometheus.Registry
+	registerer prometheus.Registerer
+	factory    opmetrics.Factory
 
 	txmetrics.TxMetrics
+	opmetrics.RPCMetrics
 
 	*opmetrics.CacheMetrics
 
@@ -70,16 +98,40 @@ type Metrics struct {
 
 	bondClaimFailures prometheus.Counter
 	bondsClaimed      prometheus.Counter
+	bondsPending      prometheus.Gauge
+
+	budgetExceeded prometheus.Counter
+	totalSpend     prometheus.Gauge
+
+	pnlGasSpent       prometheus.GaugeVec
+	pnlBondsPosted    prometheus.GaugeVec
+	pnlBondsRecovered prometheus.GaugeVec
+	pnlNetProfit      prometheus.GaugeVec
+
+	preimageChallenged                  prometheus.Counter
+	preimageChallengeFailed             prometheus.Counter
+	preimageChallengeDryRun             prometheus.Counter
+	preimageChallengeGasEstimateFailure prometheus.Counter
+	preimageChallengeReorged            prometheus.Counter
+	preimageInclusionCheckFailed        prometheus.Counter
 
-	preimageChallenged      prometheus.Counter
-	preimageChallengeFailed prometheus.Counter
+	largePreimageMinTimeRemaining prometheus.Gauge
+	largePreimageFetchTime        prometheus.Histogram
+	largePreimageVerifyThroughput prometheus.Histogram
+	largePreimageChallengeTime    prometheus.Histogram
 
 	highestActedL1Block prometheus.Gauge
 
 	moves prometheus.Counter
 	steps prometheus.Counter
 
-	cannonExecutionTime prometheus.Histogram
+	simulationReverts prometheus.CounterVec
+	maxPlayDepth      prometheus.Counter
+	invalidOwnClaims  prometheus.Counter
+
+	cannonExecutionTime   prometheus.Histogram
+	asteriscExecutionTime prometheus.Histogram
+	cannonRuns            prometheus.GaugeVec
 
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
@@ -93,14 +145,26 @@ var _ Metricer = (*Metrics)(nil)
 
 func NewMetrics() *Metrics {
 	registry := opmetrics.NewRegistry()
-	factory := opmetrics.With(registry)
+	return newMetrics(registry, registry)
+}
+
+// NewChainMetrics creates metrics registered with the shared registry, labelled with the chain name.
+// Used to run the challenger against multiple chains in a single process with isolated metrics.
+func NewChainMetrics(registry *prometheus.Registry, chain string) *Metrics {
+	return newMetrics(registry, prometheus.WrapRegistererWith(prometheus.Labels{"chain": chain}, registry))
+}
+
+func newMetrics(registry *prometheus.Registry, registerer prometheus.Registerer) *Metrics {
+	factory := opmetrics.WithRegisterer(registerer)
 
 	return &Metrics{
-		ns:       Namespace,
-		registry: registry,
-		factory:  factory,
+		ns:         Namespace,
+		registry:   registry,
+		registerer: registerer,
+		factory:    factory,
 
-		TxMetrics: txmetrics.MakeTxMetrics(Namespace, factory),
+		TxMetrics:  txmetrics.MakeTxMetrics(Namespace, factory),
+		RPCMetrics: opmetrics.MakeRPCMetrics(Namespace, factory),
 
 		CacheMetrics: opmetrics.NewCacheMetrics(factory, Namespace, "provider_cache", "Provider cache"),
 
@@ -133,6 +197,23 @@ func NewMetrics() *Metrics {
 			Name:      "steps",
 			Help:      "Number of game steps made by the challenge agent",
 		}),
+		simulationReverts: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "action_simulation_reverts",
+			Help:      "Number of game moves and steps not sent because their simulation reverted",
+		}, []string{
+			"action",
+		}),
+		maxPlayDepth: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "max_play_depth_reached",
+			Help:      "Number of times a game had claims requiring a response beyond the maximum play depth",
+		}),
+		invalidOwnClaims: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "invalid_own_claims",
+			Help:      "Number of claims posted by the challenger's address that disagree with its local trace",
+		}),
 		cannonExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
 			Name:      "cannon_execution_time",
@@ -141,6 +222,21 @@ func NewMetrics() *Metrics {
 				[]float64{1.0, 10.0},
 				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
 		}),
+		asteriscExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "asterisc_execution_time",
+			Help:      "Time (in seconds) to execute asterisc",
+			Buckets: append(
+				[]float64{1.0, 10.0},
+				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
+		}),
+		cannonRuns: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "cannon_runs",
+			Help:      "Number of cannon executions that are running or queued waiting for resources",
+		}, []string{
+			"status",
+		}),
 		bondClaimFailures: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "claim_failures",
@@ -149,8 +245,43 @@ func NewMetrics() *Metrics {
 		bondsClaimed: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "bonds",
-			Help:      "Number of bonds claimed by the challenge agent",
+			Help:      "Total credit (in wei) claimed by the challenge agent",
+		}),
+		bondsPending: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "bonds_pending",
+			Help:      "Total credit (in wei) owed to the challenge agent that has not yet been claimed",
 		}),
+		budgetExceeded: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "budget_exceeded",
+			Help:      "Number of times claims were not posted because the spending budget was exceeded",
+		}),
+		totalSpend: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "total_spend",
+			Help:      "Total amount (in wei) spent on bonds and gas across all games since the challenger started",
+		}),
+		pnlGasSpent: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "pnl_gas_spent",
+			Help:      "Cumulative amount (in wei) spent on gas, by game type",
+		}, []string{"game_type"}),
+		pnlBondsPosted: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "pnl_bonds_posted",
+			Help:      "Cumulative amount (in wei) posted as bonds, by game type",
+		}, []string{"game_type"}),
+		pnlBondsRecovered: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "pnl_bonds_recovered",
+			Help:      "Cumulative amount (in wei) of credit claimed from games, by game type",
+		}, []string{"game_type"}),
+		pnlNetProfit: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "pnl_net_profit",
+			Help:      "Cumulative net profit (in wei) from bonds recovered less bonds posted and gas spent, by game type",
+		}, []string{"game_type"}),
 		preimageChallenged: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "preimage_challenged",
@@ -161,6 +292,49 @@ func NewMetrics() *Metrics {
 			Name:      "preimage_challenge_failed",
 			Help:      "Number of preimage challenges that failed",
 		}),
+		preimageChallengeDryRun: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_challenge_dry_run",
+			Help:      "Number of preimage challenges that would have been sent when running in dry-run mode",
+		}),
+		preimageChallengeGasEstimateFailure: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_challenge_gas_estimation_failed",
+			Help:      "Number of preimage challenges not sent because gas estimation failed",
+		}),
+		preimageChallengeReorged: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_challenge_reorged",
+			Help:      "Number of included preimage challenges that were reorged out before finality",
+		}),
+		preimageInclusionCheckFailed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "preimage_inclusion_check_failed",
+			Help:      "Number of failed checks that included preimage challenges are still canonical",
+		}),
+		largePreimageMinTimeRemaining: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "large_preimage_min_time_remaining",
+			Help:      "Minimum time (in seconds) remaining in the challenge period of unexpired large preimage proposals, NaN if there are none",
+		}),
+		largePreimageFetchTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "large_preimage_fetch_time",
+			Help:      "Time (in seconds) to fetch the leaves of a large preimage proposal",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2.0, 12),
+		}),
+		largePreimageVerifyThroughput: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "large_preimage_verification_throughput",
+			Help:      "Rate (in bytes per second) at which large preimage data is verified",
+			Buckets:   prometheus.ExponentialBuckets(64*1024, 2.0, 12),
+		}),
+		largePreimageChallengeTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "large_preimage_challenge_time",
+			Help:      "Time (in seconds) from detecting a large preimage proposal to its challenge being included on chain",
+			Buckets:   prometheus.ExponentialBuckets(12.0, 2.0, 14),
+		}),
 		trackedGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "tracked_games",
@@ -190,7 +364,7 @@ func (m *Metrics) StartBalanceMetrics(
 	client *ethclient.Client,
 	account common.Address,
 ) io.Closer {
-	return opmetrics.LaunchBalanceMetrics(l, m.registry, m.ns, client, account)
+	return opmetrics.LaunchBalanceMetrics(l, m.registerer, m.ns, client, account)
 }
 
 // RecordInfo sets a pseudo-metric that contains versioning and
@@ -217,6 +391,18 @@ func (m *Metrics) RecordGameStep() {
 	m.steps.Add(1)
 }
 
+func (m *Metrics) RecordActionSimulationReverted(actionType string) {
+	m.simulationReverts.WithLabelValues(actionType).Add(1)
+}
+
+func (m *Metrics) RecordMaxPlayDepthReached() {
+	m.maxPlayDepth.Add(1)
+}
+
+func (m *Metrics) RecordInvalidOwnClaim() {
+	m.invalidOwnClaims.Add(1)
+}
+
 func (m *Metrics) RecordPreimageChallenged() {
 	m.preimageChallenged.Add(1)
 }
@@ -225,18 +411,92 @@ func (m *Metrics) RecordPreimageChallengeFailed() {
 	m.preimageChallengeFailed.Add(1)
 }
 
+func (m *Metrics) RecordPreimageChallengeDryRun() {
+	m.preimageChallengeDryRun.Add(1)
+}
+
+func (m *Metrics) RecordPreimageChallengeGasEstimationFailed() {
+	m.preimageChallengeGasEstimateFailure.Add(1)
+}
+
+func (m *Metrics) RecordPreimageChallengeReorged() {
+	m.preimageChallengeReorged.Add(1)
+}
+
+func (m *Metrics) RecordPreimageInclusionCheckFailed() {
+	m.preimageInclusionCheckFailed.Add(1)
+}
+
+func (m *Metrics) RecordLargePreimageMinTimeRemaining(remaining time.Duration) {
+	m.largePreimageMinTimeRemaining.Set(remaining.Seconds())
+}
+
+// ClearLargePreimageMinTimeRemaining sets the minimum time remaining to NaN when no proposals are tracked,
+// so it is not mistaken for a proposal with no time remaining.
+func (m *Metrics) ClearLargePreimageMinTimeRemaining() {
+	m.largePreimageMinTimeRemaining.Set(math.NaN())
+}
+
+func (m *Metrics) RecordLargePreimageFetchTime(t time.Duration) {
+	m.largePreimageFetchTime.Observe(t.Seconds())
+}
+
+func (m *Metrics) RecordLargePreimageVerificationThroughput(bytesPerSecond float64) {
+	m.largePreimageVerifyThroughput.Observe(bytesPerSecond)
+}
+
+func (m *Metrics) RecordLargePreimageChallengeTime(t time.Duration) {
+	m.largePreimageChallengeTime.Observe(t.Seconds())
+}
+
 func (m *Metrics) RecordBondClaimFailed() {
 	m.bondClaimFailures.Add(1)
 }
 
-func (m *Metrics) RecordBondClaimed(amount uint64) {
-	m.bondsClaimed.Add(float64(amount))
+func (m *Metrics) RecordBondClaimed(amount *big.Int) {
+	claimed, _ := new(big.Float).SetInt(amount).Float64()
+	m.bondsClaimed.Add(claimed)
+}
+
+func (m *Metrics) RecordBondsPending(amount *big.Int) {
+	pending, _ := new(big.Float).SetInt(amount).Float64()
+	m.bondsPending.Set(pending)
+}
+
+func (m *Metrics) RecordBudgetExceeded() {
+	m.budgetExceeded.Inc()
+}
+
+func (m *Metrics) RecordTotalSpend(amount *big.Int) {
+	spend, _ := new(big.Float).SetInt(amount).Float64()
+	m.totalSpend.Set(spend)
+}
+
+func (m *Metrics) RecordProfitAndLoss(gameType string, gasSpent, bondsPosted, bondsRecovered, netProfit *big.Int) {
+	m.pnlGasSpent.WithLabelValues(gameType).Set(weiFloat(gasSpent))
+	m.pnlBondsPosted.WithLabelValues(gameType).Set(weiFloat(bondsPosted))
+	m.pnlBondsRecovered.WithLabelValues(gameType).Set(weiFloat(bondsRecovered))
+	m.pnlNetProfit.WithLabelValues(gameType).Set(weiFloat(netProfit))
+}
+
+func weiFloat(amount *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(amount).Float64()
+	return f
 }
 
 func (m *Metrics) RecordCannonExecutionTime(t float64) {
 	m.cannonExecutionTime.Observe(t)
 }
 
+func (m *Metrics) RecordAsteriscExecutionTime(t float64) {
+	m.asteriscExecutionTime.Observe(t)
+}
+
+func (m *Metrics) RecordCannonRuns(running int, queued int) {
+	m.cannonRuns.WithLabelValues("running").Set(float64(running))
+	m.cannonRuns.WithLabelValues("queued").Set(float64(queued))
+}
+
 func (m *Metrics) IncActiveExecutors() {
 	m.executors.WithLabelValues("active").Inc()
 }
@@ -2,16 +2,20 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
 type NoopMetricsImpl struct {
 	txmetrics.NoopTxMetrics
+	opmetrics.NoopRPCMetrics
 }
 
 func (i *NoopMetricsImpl) StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer {
@@ -26,15 +30,38 @@ func (*NoopMetricsImpl) RecordUp()                 {}
 func (*NoopMetricsImpl) RecordGameMove() {}
 func (*NoopMetricsImpl) RecordGameStep() {}
 
+func (*NoopMetricsImpl) RecordActionSimulationReverted(_ string) {}
+func (*NoopMetricsImpl) RecordMaxPlayDepthReached()              {}
+func (*NoopMetricsImpl) RecordInvalidOwnClaim()                  {}
+
 func (*NoopMetricsImpl) RecordActedL1Block(_ uint64) {}
 
-func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
-func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
+func (*NoopMetricsImpl) RecordPreimageChallenged()                   {}
+func (*NoopMetricsImpl) RecordPreimageChallengeFailed()              {}
+func (*NoopMetricsImpl) RecordPreimageChallengeDryRun()              {}
+func (*NoopMetricsImpl) RecordPreimageChallengeGasEstimationFailed() {}
+func (*NoopMetricsImpl) RecordPreimageChallengeReorged()             {}
+func (*NoopMetricsImpl) RecordPreimageInclusionCheckFailed()         {}
+
+func (*NoopMetricsImpl) RecordLargePreimageMinTimeRemaining(_ time.Duration) {}
+func (*NoopMetricsImpl) ClearLargePreimageMinTimeRemaining()                 {}
+func (*NoopMetricsImpl) RecordLargePreimageFetchTime(_ time.Duration)        {}
+func (*NoopMetricsImpl) RecordLargePreimageVerificationThroughput(_ float64) {}
+func (*NoopMetricsImpl) RecordLargePreimageChallengeTime(_ time.Duration)    {}
+
+func (*NoopMetricsImpl) RecordBondClaimFailed()      {}
+func (*NoopMetricsImpl) RecordBondClaimed(*big.Int)  {}
+func (*NoopMetricsImpl) RecordBondsPending(*big.Int) {}
 
-func (*NoopMetricsImpl) RecordBondClaimFailed()   {}
-func (*NoopMetricsImpl) RecordBondClaimed(uint64) {}
+func (*NoopMetricsImpl) RecordBudgetExceeded()     {}
+func (*NoopMetricsImpl) RecordTotalSpend(*big.Int) {}
+
+func (*NoopMetricsImpl) RecordProfitAndLoss(_ string, _, _, _, _ *big.Int) {}
 
 func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64) {}
+func (*NoopMetricsImpl) RecordCannonRuns(_ int, _ int)       {}
+
+func (*NoopMetricsImpl) RecordAsteriscExecutionTime(t float64) {}
 
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
 
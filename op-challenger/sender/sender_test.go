@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/maps"
@@ -98,6 +99,25 @@ func (s *stubTxMgr) Send(ctx context.Context, candidate txmgr.TxCandidate) (*typ
 	return <-ch, nil
 }
 
+func (s *stubTxMgr) SendAsync(ctx context.Context, candidate txmgr.TxCandidate, ch chan txmgr.SendResponse) {
+	receiptCh := s.recordTx(candidate)
+	go func() {
+		ch <- txmgr.SendResponse{Receipt: <-receiptCh}
+	}()
+}
+
+func (s *stubTxMgr) Cancel(_ context.Context, _ uint64) (*types.Receipt, error) {
+	panic("unimplemented")
+}
+
+func (s *stubTxMgr) Abandon(_ uint64) error {
+	panic("unimplemented")
+}
+
+func (s *stubTxMgr) SubscribeTxEvents(_ chan<- txmgr.TxEvent) event.Subscription {
+	panic("unimplemented")
+}
+
 func (s *stubTxMgr) recordTx(candidate txmgr.TxCandidate) chan *types.Receipt {
 	s.m.Lock()
 	defer s.m.Unlock()
@@ -51,6 +51,19 @@ type Config struct {
 	// RPCEnableProxy is true if the sequencer RPC proxy should be enabled.
 	RPCEnableProxy bool
 
+	// TransferMaxBlockLag is the maximum number of blocks the unsafe head of a leadership transfer target
+	// may be behind the leader, for the transfer to pass the pre-flight checks.
+	TransferMaxBlockLag uint64
+
+	// ForwardEndpoints are the admin RPC endpoints of the follower op-nodes that unsafe payloads are forwarded to while leader.
+	ForwardEndpoints []string
+
+	// ForwardJWTSecret is the JWT secret used to authenticate with the ForwardEndpoints.
+	ForwardJWTSecret [32]byte
+
+	// NodeJWTSecret is the JWT secret used to authenticate with the admin API of the op-node at NodeRPC, if set.
+	NodeJWTSecret [32]byte
+
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
@@ -77,6 +90,9 @@ func (c *Config) Check() error {
 	if c.ExecutionRPC == "" {
 		return fmt.Errorf("missing geth RPC")
 	}
+	if len(c.ForwardEndpoints) > 0 && c.ForwardJWTSecret == ([32]byte{}) {
+		return fmt.Errorf("missing forward JWT secret")
+	}
 	if err := c.HealthCheck.Check(); err != nil {
 		return errors.Wrap(err, "invalid health check config")
 	}
@@ -106,6 +122,21 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 		return nil, errors.Wrap(err, "failed to load rollup config")
 	}
 
+	var forwardJWTSecret [32]byte
+	if path := ctx.String(flags.ForwardJWTSecret.Name); path != "" {
+		forwardJWTSecret, err = oprpc.ReadJWTSecret(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load forward JWT secret")
+		}
+	}
+	var nodeJWTSecret [32]byte
+	if path := ctx.String(flags.NodeJWTSecret.Name); path != "" {
+		nodeJWTSecret, err = oprpc.ReadJWTSecret(path)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load node JWT secret")
+		}
+	}
+
 	return &Config{
 		ConsensusAddr:  ctx.String(flags.ConsensusAddr.Name),
 		ConsensusPort:  ctx.Int(flags.ConsensusPort.Name),
@@ -121,12 +152,16 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 			SafeInterval:   ctx.Uint64(flags.HealthCheckSafeInterval.Name),
 			MinPeerCount:   ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
 		},
-		RollupCfg:      *rollupCfg,
-		RPCEnableProxy: ctx.Bool(flags.RPCEnableProxy.Name),
-		LogConfig:      oplog.ReadCLIConfig(ctx),
-		MetricsConfig:  opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:    oppprof.ReadCLIConfig(ctx),
-		RPC:            oprpc.ReadCLIConfig(ctx),
+		RollupCfg:           *rollupCfg,
+		RPCEnableProxy:      ctx.Bool(flags.RPCEnableProxy.Name),
+		TransferMaxBlockLag: ctx.Uint64(flags.TransferMaxBlockLag.Name),
+		ForwardEndpoints:    ctx.StringSlice(flags.ForwardEndpoints.Name),
+		ForwardJWTSecret:    forwardJWTSecret,
+		NodeJWTSecret:       nodeJWTSecret,
+		LogConfig:           oplog.ReadCLIConfig(ctx),
+		MetricsConfig:       opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:         oppprof.ReadCLIConfig(ctx),
+		RPC:                 oprpc.ReadCLIConfig(ctx),
 	}, nil
 }
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/raft"
@@ -17,6 +18,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-conductor/client"
 	"github.com/ethereum-optimism/optimism/op-conductor/consensus"
+	"github.com/ethereum-optimism/optimism/op-conductor/forwarder"
 	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
 	opp2p "github.com/ethereum-optimism/optimism/op-node/p2p"
@@ -69,6 +71,7 @@ func NewOpConductor(
 		hmon:         hmon,
 	}
 	oc.loopActionFn = oc.loopAction
+	oc.dialPeerFn = dialPeer
 
 	// explicitly set all atomic.Bool values
 	oc.leader.Store(false)    // upon start, it should not be the leader unless specified otherwise by raft bootstrap, in that case, it'll receive a leadership update from consensus.
@@ -106,6 +109,9 @@ func (c *OpConductor) init(ctx context.Context) error {
 	if err := c.initHealthMonitor(ctx); err != nil {
 		return errors.Wrap(err, "failed to initialize health monitor")
 	}
+	if err := c.initForwarder(ctx); err != nil {
+		return errors.Wrap(err, "failed to initialize unsafe payload forwarder")
+	}
 	if err := c.initRPCServer(ctx); err != nil {
 		return errors.Wrap(err, "failed to initialize rpc server")
 	}
@@ -128,12 +134,15 @@ func (c *OpConductor) initSequencerControl(ctx context.Context) error {
 		return errors.Wrap(err, "failed to create geth client")
 	}
 
-	nc, err := opclient.NewRPC(ctx, c.log, c.cfg.NodeRPC)
+	var opts []opclient.RPCOption
+	if c.cfg.NodeJWTSecret != ([32]byte{}) {
+		opts = append(opts, opclient.WithGethRPCOptions(rpc.WithHTTPAuth(node.NewJWTAuth(c.cfg.NodeJWTSecret))))
+	}
+	nc, err := opclient.NewRPC(ctx, c.log, c.cfg.NodeRPC, opts...)
 	if err != nil {
 		return errors.Wrap(err, "failed to create node rpc client")
 	}
-	node := sources.NewRollupClient(nc)
-	c.ctrl = client.NewSequencerControl(exec, node)
+	c.ctrl = client.NewSequencerControl(exec, sources.NewRollupClient(nc))
 
 	return c.updateSequencerActiveStatus()
 }
@@ -185,6 +194,25 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 	return nil
 }
 
+func (c *OpConductor) initForwarder(ctx context.Context) error {
+	if c.fwd != nil || len(c.cfg.ForwardEndpoints) == 0 {
+		return nil
+	}
+
+	auth := rpc.WithHTTPAuth(node.NewJWTAuth(c.cfg.ForwardJWTSecret))
+	targets := make(map[string]forwarder.PayloadTarget, len(c.cfg.ForwardEndpoints))
+	for _, endpoint := range c.cfg.ForwardEndpoints {
+		nc, err := opclient.NewRPC(ctx, c.log, endpoint, opclient.WithGethRPCOptions(auth))
+		if err != nil {
+			return errors.Wrapf(err, "failed to create follower node rpc client for %s", endpoint)
+		}
+		targets[endpoint] = sources.NewRollupClient(nc)
+	}
+	c.fwd = forwarder.NewUnsafePayloadForwarder(c.log, targets)
+
+	return nil
+}
+
 func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 	server := oprpc.NewServer(
 		oc.cfg.RPC.ListenAddr,
@@ -248,6 +276,7 @@ type OpConductor struct {
 	ctrl client.SequencerControl
 	cons consensus.Consensus
 	hmon health.HealthMonitor
+	fwd  forwarder.PayloadForwarder // fwd is nil if unsafe payloads are not forwarded to followers.
 
 	leader    atomic.Bool
 	seqActive atomic.Bool
@@ -258,6 +287,7 @@ type OpConductor struct {
 	healthUpdateCh <-chan error
 	leaderUpdateCh <-chan bool
 	loopActionFn   func() // loopActionFn defines the logic to be executed inside control loop.
+	dialPeerFn     func(ctx context.Context, rpcAddr string) (peer, error)
 
 	wg             sync.WaitGroup
 	pauseCh        chan struct{}
@@ -273,6 +303,23 @@ type OpConductor struct {
 	rpcServer *oprpc.Server
 }
 
+// peer is the part of the conductor API of another server used by the leadership transfer pre-flight checks.
+type peer interface {
+	ServerID(ctx context.Context) (string, error)
+	Active(ctx context.Context) (bool, error)
+	SequencerHealthy(ctx context.Context) (bool, error)
+	LatestUnsafeBlock(ctx context.Context) (eth.BlockID, error)
+	Close()
+}
+
+func dialPeer(ctx context.Context, rpcAddr string) (peer, error) {
+	c, err := rpc.DialContext(ctx, rpcAddr)
+	if err != nil {
+		return nil, err
+	}
+	return conductorrpc.NewAPIClient(c), nil
+}
+
 type state struct {
 	leader, healthy, active bool
 }
@@ -300,6 +347,12 @@ func (oc *OpConductor) Start(ctx context.Context) error {
 		return errors.Wrap(err, "failed to start health monitor")
 	}
 
+	if oc.fwd != nil {
+		if err := oc.fwd.Start(); err != nil {
+			return errors.Wrap(err, "failed to start unsafe payload forwarder")
+		}
+	}
+
 	oc.log.Info("starting JSON-RPC server")
 	if err := oc.rpcServer.Start(); err != nil {
 		return errors.Wrap(err, "failed to start JSON-RPC server")
@@ -339,6 +392,12 @@ func (oc *OpConductor) Stop(ctx context.Context) error {
 		}
 	}
 
+	if oc.fwd != nil {
+		if err := oc.fwd.Stop(); err != nil {
+			result = multierror.Append(result, errors.Wrap(err, "failed to stop unsafe payload forwarder"))
+		}
+	}
+
 	if oc.cons != nil {
 		if err := oc.cons.Shutdown(); err != nil {
 			result = multierror.Append(result, errors.Wrap(err, "failed to shutdown consensus"))
@@ -409,6 +468,11 @@ func (oc *OpConductor) LeaderWithID(_ context.Context) (string, string) {
 	return oc.cons.LeaderWithID()
 }
 
+// ServerID returns the raft server ID of this server.
+func (oc *OpConductor) ServerID(_ context.Context) string {
+	return oc.cons.ServerID()
+}
+
 // AddServerAsVoter adds a server as a voter to the cluster.
 func (oc *OpConductor) AddServerAsVoter(_ context.Context, id string, addr string) error {
 	return oc.cons.AddVoter(id, addr)
@@ -434,9 +498,98 @@ func (oc *OpConductor) TransferLeaderToServer(_ context.Context, id string, addr
 	return oc.cons.TransferLeaderTo(id, addr)
 }
 
-// CommitUnsafePayload commits a unsafe payload (latest head) to the cluster FSM.
+// TransferLeadership transfers leadership to a specific server, after checking that its conductor at rpcAddr is active,
+// its sequencer is healthy and its unsafe head is at most cfg.TransferMaxBlockLag blocks behind the one in consensus.
+// The conductor at rpcAddr must report the server ID id, so the checks are not run against another server.
+// A *conductorrpc.PreflightError is returned if any of the checks fail.
+func (oc *OpConductor) TransferLeadership(ctx context.Context, id string, addr string, rpcAddr string) error {
+	if !oc.cons.Leader() {
+		return &conductorrpc.PreflightError{Reason: conductorrpc.PreflightNotLeader, ServerID: id}
+	}
+	unsafeInCons := oc.cons.LatestUnsafePayload()
+	if unsafeInCons == nil {
+		return ErrUnableToRetrieveUnsafeHeadFromConsensus
+	}
+
+	p, err := oc.dialPeerFn(ctx, rpcAddr)
+	if err != nil {
+		return &conductorrpc.PreflightError{Reason: conductorrpc.PreflightTargetUnreachable, ServerID: id, Detail: err.Error()}
+	}
+	defer p.Close()
+	if err := oc.checkPeer(ctx, id, p, uint64(unsafeInCons.ExecutionPayload.BlockNumber)); err != nil {
+		oc.log.Warn("refusing to transfer leadership", "server", oc.cons.ServerID(), "target", id, "err", err)
+		return err
+	}
+
+	oc.log.Info("transferring leadership", "server", oc.cons.ServerID(), "target", id, "addr", addr)
+	return oc.cons.TransferLeaderTo(id, addr)
+}
+
+// checkPeer runs the leadership transfer pre-flight checks against the conductor of the target server.
+func (oc *OpConductor) checkPeer(ctx context.Context, id string, p peer, leaderUnsafe uint64) error {
+	unreachable := func(err error) error {
+		return &conductorrpc.PreflightError{Reason: conductorrpc.PreflightTargetUnreachable, ServerID: id, Detail: err.Error()}
+	}
+
+	targetID, err := p.ServerID(ctx)
+	if err != nil {
+		return unreachable(err)
+	}
+	if targetID != id {
+		return &conductorrpc.PreflightError{Reason: conductorrpc.PreflightTargetMismatch, ServerID: id, TargetID: targetID}
+	}
+
+	active, err := p.Active(ctx)
+	if err != nil {
+		return unreachable(err)
+	}
+	if !active {
+		return &conductorrpc.PreflightError{Reason: conductorrpc.PreflightTargetInactive, ServerID: id}
+	}
+
+	healthy, err := p.SequencerHealthy(ctx)
+	if err != nil {
+		return unreachable(err)
+	}
+	if !healthy {
+		return &conductorrpc.PreflightError{Reason: conductorrpc.PreflightTargetUnhealthy, ServerID: id}
+	}
+
+	targetUnsafe, err := p.LatestUnsafeBlock(ctx)
+	if err != nil {
+		return unreachable(err)
+	}
+	if targetUnsafe.Number+oc.cfg.TransferMaxBlockLag < leaderUnsafe {
+		return &conductorrpc.PreflightError{
+			Reason:       conductorrpc.PreflightTargetBehind,
+			ServerID:     id,
+			LeaderUnsafe: leaderUnsafe,
+			TargetUnsafe: targetUnsafe.Number,
+			MaxBlockLag:  oc.cfg.TransferMaxBlockLag,
+		}
+	}
+	return nil
+}
+
+// LatestUnsafeBlock returns the latest unsafe block of the sequencer.
+func (oc *OpConductor) LatestUnsafeBlock(ctx context.Context) (eth.BlockID, error) {
+	block, err := oc.ctrl.LatestUnsafeBlock(ctx)
+	if err != nil {
+		return eth.BlockID{}, err
+	}
+	return eth.ToBlockID(block), nil
+}
+
+// CommitUnsafePayload commits a unsafe payload (latest head) to the cluster FSM,
+// and forwards it to the follower op-nodes once committed.
 func (oc *OpConductor) CommitUnsafePayload(_ context.Context, payload *eth.ExecutionPayloadEnvelope) error {
-	return oc.cons.CommitUnsafePayload(payload)
+	if err := oc.cons.CommitUnsafePayload(payload); err != nil {
+		return err
+	}
+	if oc.fwd != nil {
+		oc.fwd.Forward(payload)
+	}
+	return nil
 }
 
 // SequencerHealthy returns true if sequencer is healthy.
@@ -19,6 +19,7 @@ import (
 	consensusmocks "github.com/ethereum-optimism/optimism/op-conductor/consensus/mocks"
 	"github.com/ethereum-optimism/optimism/op-conductor/health"
 	healthmocks "github.com/ethereum-optimism/optimism/op-conductor/health/mocks"
+	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
@@ -73,7 +74,8 @@ func mockConfig(t *testing.T) Config {
 			L1SystemConfigAddress:   [20]byte{3, 4},
 			ProtocolVersionsAddress: [20]byte{4, 5},
 		},
-		RPCEnableProxy: false,
+		RPCEnableProxy:      false,
+		TransferMaxBlockLag: 1,
 	}
 }
 
@@ -715,6 +717,139 @@ func (s *OpConductorTestSuite) TestFailureAndRetry3() {
 	}, 2*time.Second, 100*time.Millisecond)
 }
 
+type fakePeer struct {
+	id      string
+	active  bool
+	healthy bool
+	unsafe  eth.BlockID
+	err     error
+	closed  bool
+}
+
+func (p *fakePeer) ServerID(_ context.Context) (string, error) {
+	return p.id, p.err
+}
+
+func (p *fakePeer) Active(_ context.Context) (bool, error) {
+	return p.active, p.err
+}
+
+func (p *fakePeer) SequencerHealthy(_ context.Context) (bool, error) {
+	return p.healthy, p.err
+}
+
+func (p *fakePeer) LatestUnsafeBlock(_ context.Context) (eth.BlockID, error) {
+	return p.unsafe, p.err
+}
+
+func (p *fakePeer) Close() {
+	p.closed = true
+}
+
+func (s *OpConductorTestSuite) TestTransferLeadership() {
+	mockPayload := &eth.ExecutionPayloadEnvelope{
+		ExecutionPayload: &eth.ExecutionPayload{
+			BlockNumber: 10,
+			BlockHash:   [32]byte{1, 2, 3},
+		},
+	}
+	s.cons.EXPECT().LatestUnsafePayload().Return(mockPayload)
+
+	tests := []struct {
+		name   string
+		peer   *fakePeer
+		reason conductorrpc.PreflightReason
+	}{
+		{name: "Synced", peer: &fakePeer{id: "SequencerB", active: true, healthy: true, unsafe: eth.BlockID{Number: 10}}},
+		{name: "WithinLag", peer: &fakePeer{id: "SequencerB", active: true, healthy: true, unsafe: eth.BlockID{Number: 9}}},
+		{name: "Behind", peer: &fakePeer{id: "SequencerB", active: true, healthy: true, unsafe: eth.BlockID{Number: 8}}, reason: conductorrpc.PreflightTargetBehind},
+		{name: "Unhealthy", peer: &fakePeer{id: "SequencerB", active: true, healthy: false, unsafe: eth.BlockID{Number: 10}}, reason: conductorrpc.PreflightTargetUnhealthy},
+		{name: "Inactive", peer: &fakePeer{id: "SequencerB", active: false, healthy: true, unsafe: eth.BlockID{Number: 10}}, reason: conductorrpc.PreflightTargetInactive},
+		{name: "OtherServer", peer: &fakePeer{id: "SequencerC", active: true, healthy: true, unsafe: eth.BlockID{Number: 10}}, reason: conductorrpc.PreflightTargetMismatch},
+		{name: "Unreachable", peer: &fakePeer{err: s.err}, reason: conductorrpc.PreflightTargetUnreachable},
+	}
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.conductor.dialPeerFn = func(_ context.Context, rpcAddr string) (peer, error) {
+				s.Equal("http://sequencer-b:8545", rpcAddr)
+				return test.peer, nil
+			}
+			s.cons.EXPECT().Leader().Return(true).Once()
+			if test.reason == "" {
+				s.cons.EXPECT().TransferLeaderTo("SequencerB", "sequencer-b:50050").Return(nil).Once()
+			}
+
+			err := s.conductor.TransferLeadership(s.ctx, "SequencerB", "sequencer-b:50050", "http://sequencer-b:8545")
+			s.True(test.peer.closed)
+			if test.reason == "" {
+				s.NoError(err)
+				return
+			}
+			var preflightErr *conductorrpc.PreflightError
+			s.ErrorAs(err, &preflightErr)
+			s.Equal(test.reason, preflightErr.Reason)
+			s.Equal("SequencerB", preflightErr.ServerID)
+		})
+	}
+	s.cons.AssertNumberOfCalls(s.T(), "TransferLeaderTo", 2)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeadershipRefusedIfNotLeader() {
+	s.conductor.dialPeerFn = func(_ context.Context, _ string) (peer, error) {
+		s.FailNow("must not dial peer if not leader")
+		return nil, nil
+	}
+	s.cons.EXPECT().Leader().Return(false)
+
+	err := s.conductor.TransferLeadership(s.ctx, "SequencerB", "sequencer-b:50050", "http://sequencer-b:8545")
+	var preflightErr *conductorrpc.PreflightError
+	s.ErrorAs(err, &preflightErr)
+	s.Equal(conductorrpc.PreflightNotLeader, preflightErr.Reason)
+	s.cons.AssertNotCalled(s.T(), "TransferLeaderTo", mock.Anything, mock.Anything)
+}
+
+func (s *OpConductorTestSuite) TestTransferLeadershipDialFailure() {
+	s.conductor.dialPeerFn = func(_ context.Context, _ string) (peer, error) {
+		return nil, s.err
+	}
+	s.cons.EXPECT().Leader().Return(true)
+	s.cons.EXPECT().LatestUnsafePayload().Return(&eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 10}})
+
+	err := s.conductor.TransferLeadership(s.ctx, "SequencerB", "sequencer-b:50050", "http://sequencer-b:8545")
+	var preflightErr *conductorrpc.PreflightError
+	s.ErrorAs(err, &preflightErr)
+	s.Equal(conductorrpc.PreflightTargetUnreachable, preflightErr.Reason)
+	s.Equal(s.err.Error(), preflightErr.Detail)
+}
+
+type fakeForwarder struct {
+	forwarded []*eth.ExecutionPayloadEnvelope
+}
+
+func (f *fakeForwarder) Forward(payload *eth.ExecutionPayloadEnvelope) {
+	f.forwarded = append(f.forwarded, payload)
+}
+
+func (f *fakeForwarder) Start() error { return nil }
+
+func (f *fakeForwarder) Stop() error { return nil }
+
+func (s *OpConductorTestSuite) TestCommitUnsafePayloadForwardsToFollowers() {
+	fwd := &fakeForwarder{}
+	s.conductor.fwd = fwd
+
+	committed := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 1}}
+	s.cons.EXPECT().CommitUnsafePayload(committed).Return(nil).Once()
+	s.NoError(s.conductor.CommitUnsafePayload(s.ctx, committed))
+	s.Equal([]*eth.ExecutionPayloadEnvelope{committed}, fwd.forwarded)
+
+	// payloads that fail to commit must not be forwarded.
+	rejected := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{BlockNumber: 2}}
+	s.cons.EXPECT().CommitUnsafePayload(rejected).Return(s.err).Once()
+	s.ErrorIs(s.conductor.CommitUnsafePayload(s.ctx, rejected), s.err)
+	s.Equal([]*eth.ExecutionPayloadEnvelope{committed}, fwd.forwarded)
+}
+
 func (s *OpConductorTestSuite) TestHandleInitError() {
 	// This will cause an error in the init function, which should cause the conductor to stop successfully without issues.
 	_, err := New(s.ctx, &s.cfg, s.log, s.version)
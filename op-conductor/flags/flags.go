@@ -49,6 +49,12 @@ var (
 		Usage:   "HTTP provider URL for op-node",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "NODE_RPC"),
 	}
+	NodeJWTSecret = &cli.StringFlag{
+		Name:      "node.jwt-secret",
+		Usage:     "Path to the JWT secret file used to authenticate with the admin API of the op-node at node.rpc",
+		EnvVars:   opservice.PrefixEnvVar(EnvVarPrefix, "NODE_JWT_SECRET"),
+		TakesFile: true,
+	}
 	ExecutionRPC = &cli.StringFlag{
 		Name:    "execution.rpc",
 		Usage:   "HTTP provider URL for execution layer",
@@ -74,6 +80,23 @@ var (
 		Usage:   "Minimum number of peers required to be considered healthy",
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "HEALTHCHECK_MIN_PEER_COUNT"),
 	}
+	TransferMaxBlockLag = &cli.Uint64Flag{
+		Name:    "transfer.max-block-lag",
+		Usage:   "Maximum number of blocks the unsafe head of a leadership transfer target may be behind the leader",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "TRANSFER_MAX_BLOCK_LAG"),
+		Value:   1,
+	}
+	ForwardEndpoints = &cli.StringSliceFlag{
+		Name:    "forward.endpoints",
+		Usage:   "Admin RPC endpoints of the follower op-nodes that unsafe payloads are forwarded to while leader, authenticated with the forward.jwt-secret",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "FORWARD_ENDPOINTS"),
+	}
+	ForwardJWTSecret = &cli.StringFlag{
+		Name:      "forward.jwt-secret",
+		Usage:     "Path to the JWT secret file used to authenticate with the forward.endpoints",
+		EnvVars:   opservice.PrefixEnvVar(EnvVarPrefix, "FORWARD_JWT_SECRET"),
+		TakesFile: true,
+	}
 	Paused = &cli.BoolFlag{
 		Name:    "paused",
 		Usage:   "Whether the conductor is paused",
@@ -105,6 +128,10 @@ var optionalFlags = []cli.Flag{
 	Paused,
 	RPCEnableProxy,
 	RaftBootstrap,
+	TransferMaxBlockLag,
+	ForwardEndpoints,
+	ForwardJWTSecret,
+	NodeJWTSecret,
 }
 
 func init() {
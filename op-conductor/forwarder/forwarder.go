@@ -0,0 +1,169 @@
+package forwarder
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// postTimeout is the timeout to post a single unsafe payload to a follower.
+	postTimeout = 2 * time.Second
+	// maxQueuedPayloads is the number of payloads that are queued for a follower that is busy.
+	// The oldest payload is dropped when the queue is full.
+	maxQueuedPayloads = 64
+)
+
+// PayloadTarget is a follower op-node that unsafe payloads can be posted to.
+type PayloadTarget interface {
+	PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
+}
+
+// PayloadForwarder defines the interface for forwarding unsafe payloads from the leader to follower op-nodes.
+type PayloadForwarder interface {
+	// Forward queues the payload to be posted to all followers, it never blocks.
+	Forward(payload *eth.ExecutionPayloadEnvelope)
+	// Start starts forwarding payloads.
+	Start() error
+	// Stop stops forwarding payloads.
+	Stop() error
+}
+
+// NewUnsafePayloadForwarder creates a new unsafe payload forwarder, posting payloads to the given targets keyed by name.
+func NewUnsafePayloadForwarder(log log.Logger, targets map[string]PayloadTarget) *UnsafePayloadForwarder {
+	followers := make([]*follower, 0, len(targets))
+	for name, target := range targets {
+		followers = append(followers, &follower{
+			name:   name,
+			target: target,
+			notify: make(chan struct{}, 1),
+		})
+	}
+	return &UnsafePayloadForwarder{
+		log:       log,
+		done:      make(chan struct{}),
+		followers: followers,
+	}
+}
+
+// UnsafePayloadForwarder posts unsafe payloads to follower op-nodes, so they stay in sync with the leader
+// even when p2p gossip is slow.
+// Every follower is posted to by its own goroutine, so a slow follower does not hold back the others.
+// Payloads are queued in order for a follower that is still busy with a previous one,
+// since a follower can only insert a payload on top of its parent.
+// Only when the queue of a follower is full, its oldest payload is dropped.
+type UnsafePayloadForwarder struct {
+	log  log.Logger
+	done chan struct{}
+	wg   sync.WaitGroup
+
+	mu        sync.Mutex
+	followers []*follower
+}
+
+type follower struct {
+	name   string
+	target PayloadTarget
+	// notify is signaled when a payload is queued
+	notify chan struct{}
+
+	mu       sync.Mutex
+	payloads []*eth.ExecutionPayloadEnvelope
+}
+
+// push queues the payload, and returns the oldest payload if it had to be dropped to make room.
+func (fl *follower) push(payload *eth.ExecutionPayloadEnvelope) (dropped *eth.ExecutionPayloadEnvelope) {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	if len(fl.payloads) >= maxQueuedPayloads {
+		dropped = fl.payloads[0]
+		fl.payloads = fl.payloads[1:]
+	}
+	fl.payloads = append(fl.payloads, payload)
+	select {
+	case fl.notify <- struct{}{}:
+	default:
+	}
+	return dropped
+}
+
+// pop returns the oldest queued payload, or nil if the queue is empty.
+func (fl *follower) pop() *eth.ExecutionPayloadEnvelope {
+	fl.mu.Lock()
+	defer fl.mu.Unlock()
+	if len(fl.payloads) == 0 {
+		return nil
+	}
+	payload := fl.payloads[0]
+	fl.payloads[0] = nil
+	fl.payloads = fl.payloads[1:]
+	return payload
+}
+
+var _ PayloadForwarder = (*UnsafePayloadForwarder)(nil)
+
+// Start implements PayloadForwarder.
+func (f *UnsafePayloadForwarder) Start() error {
+	f.log.Info("starting unsafe payload forwarder", "followers", len(f.followers))
+	for _, fl := range f.followers {
+		f.wg.Add(1)
+		go f.loop(fl)
+	}
+	return nil
+}
+
+// Stop implements PayloadForwarder.
+func (f *UnsafePayloadForwarder) Stop() error {
+	f.log.Info("stopping unsafe payload forwarder")
+	close(f.done)
+	f.wg.Wait()
+
+	f.log.Info("unsafe payload forwarder stopped")
+	return nil
+}
+
+// Forward implements PayloadForwarder.
+func (f *UnsafePayloadForwarder) Forward(payload *eth.ExecutionPayloadEnvelope) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, fl := range f.followers {
+		if dropped := fl.push(payload); dropped != nil {
+			f.log.Warn("unsafe payload queue of follower is full, dropping oldest payload", "follower", fl.name, "dropped", dropped.ExecutionPayload.ID(), "payload", payload.ExecutionPayload.ID())
+		}
+	}
+}
+
+func (f *UnsafePayloadForwarder) loop(fl *follower) {
+	defer f.wg.Done()
+
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-fl.notify:
+			for payload := fl.pop(); payload != nil; payload = fl.pop() {
+				select {
+				case <-f.done:
+					return
+				default:
+				}
+				f.post(fl, payload)
+			}
+		}
+	}
+}
+
+func (f *UnsafePayloadForwarder) post(fl *follower, payload *eth.ExecutionPayloadEnvelope) {
+	ctx, cancel := context.WithTimeout(context.Background(), postTimeout)
+	defer cancel()
+	if err := fl.target.PostUnsafePayload(ctx, payload); err != nil {
+		f.log.Warn("failed to forward unsafe payload", "follower", fl.name, "payload", payload.ExecutionPayload.ID(), "err", err)
+		return
+	}
+	f.log.Debug("forwarded unsafe payload", "follower", fl.name, "payload", payload.ExecutionPayload.ID())
+}
@@ -0,0 +1,133 @@
+package forwarder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type fakeTarget struct {
+	mu       sync.Mutex
+	received []eth.BlockID
+	err      error
+
+	// posting and release are used to hold the target busy while posting a payload, if set.
+	posting chan struct{}
+	release chan struct{}
+}
+
+func (t *fakeTarget) PostUnsafePayload(_ context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+	if t.posting != nil {
+		t.posting <- struct{}{}
+		<-t.release
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.received = append(t.received, payload.ExecutionPayload.ID())
+	return t.err
+}
+
+func (t *fakeTarget) Received() []eth.BlockID {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]eth.BlockID(nil), t.received...)
+}
+
+func payload(num uint64) *eth.ExecutionPayloadEnvelope {
+	return &eth.ExecutionPayloadEnvelope{
+		ExecutionPayload: &eth.ExecutionPayload{
+			BlockNumber: eth.Uint64Quantity(num),
+			BlockHash:   [32]byte{byte(num)},
+		},
+	}
+}
+
+func TestForwardToAllFollowers(t *testing.T) {
+	a := &fakeTarget{}
+	b := &fakeTarget{err: errors.New("boom")}
+	f := NewUnsafePayloadForwarder(testlog.Logger(t, log.LvlDebug), map[string]PayloadTarget{"a": a, "b": b})
+	require.NoError(t, f.Start())
+	defer func() {
+		require.NoError(t, f.Stop())
+	}()
+
+	for i := uint64(1); i <= 3; i++ {
+		f.Forward(payload(i))
+		expected := payload(i).ExecutionPayload.ID()
+		for _, target := range []*fakeTarget{a, b} {
+			require.Eventually(t, func() bool {
+				received := target.Received()
+				return len(received) > 0 && received[len(received)-1] == expected
+			}, time.Second, 10*time.Millisecond)
+		}
+	}
+	// a failing follower must not prevent later payloads from being forwarded to it.
+	require.Len(t, b.Received(), 3)
+}
+
+func TestForwardInOrderToBusyFollower(t *testing.T) {
+	slow := &fakeTarget{posting: make(chan struct{}), release: make(chan struct{})}
+	fast := &fakeTarget{}
+	f := NewUnsafePayloadForwarder(testlog.Logger(t, log.LvlDebug), map[string]PayloadTarget{"slow": slow, "fast": fast})
+	require.NoError(t, f.Start())
+	defer func() {
+		require.NoError(t, f.Stop())
+	}()
+
+	f.Forward(payload(1))
+	<-slow.posting
+	// the slow follower is busy posting payload 1, so payloads 2 and 3 are queued.
+	f.Forward(payload(2))
+	require.Eventually(t, func() bool { return len(fast.Received()) == 2 }, time.Second, 10*time.Millisecond)
+	f.Forward(payload(3))
+	close(slow.release)
+	<-slow.posting
+	<-slow.posting
+
+	require.Eventually(t, func() bool { return len(slow.Received()) == 3 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []eth.BlockID{payload(1).ExecutionPayload.ID(), payload(2).ExecutionPayload.ID(), payload(3).ExecutionPayload.ID()}, slow.Received())
+	require.Eventually(t, func() bool { return len(fast.Received()) == 3 }, time.Second, 10*time.Millisecond)
+}
+
+func TestDropOldestWhenQueueIsFull(t *testing.T) {
+	slow := &fakeTarget{posting: make(chan struct{}), release: make(chan struct{})}
+	f := NewUnsafePayloadForwarder(testlog.Logger(t, log.LvlDebug), map[string]PayloadTarget{"slow": slow})
+	require.NoError(t, f.Start())
+	defer func() {
+		require.NoError(t, f.Stop())
+	}()
+
+	f.Forward(payload(1))
+	<-slow.posting
+	// the slow follower is busy posting payload 1, fill its queue with one payload too many.
+	for i := uint64(2); i <= maxQueuedPayloads+2; i++ {
+		f.Forward(payload(i))
+	}
+	close(slow.release)
+	go func() {
+		for range slow.posting {
+		}
+	}()
+
+	require.Eventually(t, func() bool { return len(slow.Received()) == maxQueuedPayloads+1 }, time.Second, 10*time.Millisecond)
+	received := slow.Received()
+	require.Equal(t, payload(1).ExecutionPayload.ID(), received[0])
+	// payload 2 was the oldest queued payload, and dropped.
+	require.Equal(t, payload(3).ExecutionPayload.ID(), received[1])
+	require.Equal(t, payload(maxQueuedPayloads+2).ExecutionPayload.ID(), received[len(received)-1])
+}
+
+func TestStopWithoutFollowers(t *testing.T) {
+	f := NewUnsafePayloadForwarder(testlog.Logger(t, log.LvlDebug), nil)
+	require.NoError(t, f.Start())
+	f.Forward(payload(1))
+	require.NoError(t, f.Stop())
+}
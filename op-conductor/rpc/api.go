@@ -3,6 +3,7 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/rpc"
 
@@ -12,6 +13,65 @@ import (
 
 var ErrNotLeader = errors.New("refusing to proxy request to non-leader sequencer")
 
+// PreflightErrorCode is the JSON-RPC error code of a PreflightError.
+const PreflightErrorCode = -32050
+
+// PreflightReason describes why a leadership transfer was refused.
+type PreflightReason string
+
+const (
+	// PreflightNotLeader means the server asked to transfer leadership is not the leader.
+	PreflightNotLeader PreflightReason = "not_leader"
+	// PreflightTargetMismatch means the conductor at the given RPC address reports a different server ID than the target.
+	PreflightTargetMismatch PreflightReason = "target_mismatch"
+	// PreflightTargetUnreachable means the conductor of the target server could not be queried.
+	PreflightTargetUnreachable PreflightReason = "target_unreachable"
+	// PreflightTargetInactive means the conductor of the target server is paused or stopped.
+	PreflightTargetInactive PreflightReason = "target_inactive"
+	// PreflightTargetUnhealthy means the sequencer of the target server is not healthy.
+	PreflightTargetUnhealthy PreflightReason = "target_unhealthy"
+	// PreflightTargetBehind means the unsafe head of the target server is too far behind the leader.
+	PreflightTargetBehind PreflightReason = "target_behind"
+)
+
+// PreflightError is returned when a leadership transfer is refused by the pre-flight checks.
+// It is returned over JSON-RPC with PreflightErrorCode, and the error itself as data.
+type PreflightError struct {
+	Reason   PreflightReason `json:"reason"`
+	ServerID string          `json:"serverID"`
+	// Detail is the underlying error, if any.
+	Detail string `json:"detail,omitempty"`
+	// TargetID is the server ID reported by the conductor at the RPC address, set when it does not match.
+	TargetID string `json:"targetID,omitempty"`
+	// LeaderUnsafe, TargetUnsafe and MaxBlockLag are set when the target is behind.
+	LeaderUnsafe uint64 `json:"leaderUnsafe,omitempty"`
+	TargetUnsafe uint64 `json:"targetUnsafe,omitempty"`
+	MaxBlockLag  uint64 `json:"maxBlockLag,omitempty"`
+}
+
+func (e *PreflightError) Error() string {
+	msg := fmt.Sprintf("leadership transfer to %s refused: %s", e.ServerID, e.Reason)
+	switch {
+	case e.Detail != "":
+		msg += ": " + e.Detail
+	case e.Reason == PreflightTargetMismatch:
+		msg += fmt.Sprintf(": conductor reports server ID %s", e.TargetID)
+	case e.Reason == PreflightTargetBehind:
+		msg += fmt.Sprintf(": target unsafe head %d, leader unsafe head %d, max lag %d", e.TargetUnsafe, e.LeaderUnsafe, e.MaxBlockLag)
+	}
+	return msg
+}
+
+// ErrorCode implements rpc.Error.
+func (e *PreflightError) ErrorCode() int {
+	return PreflightErrorCode
+}
+
+// ErrorData implements rpc.DataError.
+func (e *PreflightError) ErrorData() interface{} {
+	return e
+}
+
 type ServerInfo struct {
 	ID   string `json:"id"`
 	Addr string `json:"addr"`
@@ -25,12 +85,16 @@ type API interface {
 	Resume(ctx context.Context) error
 	// SequencerHealthy returns true if the sequencer is healthy.
 	SequencerHealthy(ctx context.Context) (bool, error)
+	// LatestUnsafeBlock returns the latest unsafe block of the sequencer.
+	LatestUnsafeBlock(ctx context.Context) (eth.BlockID, error)
 
 	// Consensus related APIs
 	// Leader returns true if the server is the leader.
 	Leader(ctx context.Context) (bool, error)
 	// LeaderWithID returns the current leader's server info.
 	LeaderWithID(ctx context.Context) (*ServerInfo, error)
+	// ServerID returns the raft server ID of this server.
+	ServerID(ctx context.Context) (string, error)
 	// AddServerAsVoter adds a server as a voter to the cluster.
 	AddServerAsVoter(ctx context.Context, id string, addr string) error
 	// AddServerAsNonvoter adds a server as a non-voter to the cluster. non-voter will not participate in leader election.
@@ -41,6 +105,10 @@ type API interface {
 	TransferLeader(ctx context.Context) error
 	// TransferLeaderToServer transfers leadership to a specific server.
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
+	// TransferLeadership transfers leadership to a specific server, after checking that its conductor at rpcAddr
+	// is active, its sequencer is healthy and its unsafe head is close enough to the leader's.
+	// A *PreflightError is returned if any of the checks fail.
+	TransferLeadership(ctx context.Context, id string, addr string, rpcAddr string) error
 
 	// APIs called by op-node
 	// Active returns true if op-conductor is active.
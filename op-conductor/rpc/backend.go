@@ -14,14 +14,17 @@ type conductor interface {
 	Paused() bool
 	Stopped() bool
 	SequencerHealthy(ctx context.Context) bool
+	LatestUnsafeBlock(ctx context.Context) (eth.BlockID, error)
 
 	Leader(ctx context.Context) bool
 	LeaderWithID(ctx context.Context) (string, string)
+	ServerID(ctx context.Context) string
 	AddServerAsVoter(ctx context.Context, id string, addr string) error
 	AddServerAsNonvoter(ctx context.Context, id string, addr string) error
 	RemoveServer(ctx context.Context, id string) error
 	TransferLeader(ctx context.Context) error
 	TransferLeaderToServer(ctx context.Context, id string, addr string) error
+	TransferLeadership(ctx context.Context, id string, addr string, rpcAddr string) error
 	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 }
 
@@ -102,6 +105,21 @@ func (api *APIBackend) TransferLeaderToServer(ctx context.Context, id string, ad
 	return api.con.TransferLeaderToServer(ctx, id, addr)
 }
 
+// ServerID implements API.
+func (api *APIBackend) ServerID(ctx context.Context) (string, error) {
+	return api.con.ServerID(ctx), nil
+}
+
+// TransferLeadership implements API.
+func (api *APIBackend) TransferLeadership(ctx context.Context, id string, addr string, rpcAddr string) error {
+	return api.con.TransferLeadership(ctx, id, addr, rpcAddr)
+}
+
+// LatestUnsafeBlock implements API.
+func (api *APIBackend) LatestUnsafeBlock(ctx context.Context) (eth.BlockID, error) {
+	return api.con.LatestUnsafeBlock(ctx)
+}
+
 // SequencerHealthy implements API.
 func (api *APIBackend) SequencerHealthy(ctx context.Context) (bool, error) {
 	return api.con.SequencerHealthy(ctx), nil
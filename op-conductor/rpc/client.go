@@ -2,6 +2,8 @@ package rpc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 
 	"github.com/ethereum/go-ethereum/rpc"
 
@@ -92,6 +94,42 @@ func (c *APIClient) TransferLeaderToServer(ctx context.Context, id string, addr
 	return c.c.CallContext(ctx, nil, prefixRPC("transferLeaderToServer"), id, addr)
 }
 
+// ServerID implements API.
+func (c *APIClient) ServerID(ctx context.Context) (string, error) {
+	var id string
+	err := c.c.CallContext(ctx, &id, prefixRPC("serverID"))
+	return id, err
+}
+
+// TransferLeadership implements API.
+// A refusal by the pre-flight checks is decoded back into a *PreflightError.
+func (c *APIClient) TransferLeadership(ctx context.Context, id string, addr string, rpcAddr string) error {
+	err := c.c.CallContext(ctx, nil, prefixRPC("transferLeadership"), id, addr, rpcAddr)
+	var dataErr interface {
+		rpc.Error
+		rpc.DataError
+	}
+	if errors.As(err, &dataErr) && dataErr.ErrorCode() == PreflightErrorCode {
+		data, merr := json.Marshal(dataErr.ErrorData())
+		if merr != nil {
+			return err
+		}
+		var preflightErr PreflightError
+		if uerr := json.Unmarshal(data, &preflightErr); uerr != nil {
+			return err
+		}
+		return &preflightErr
+	}
+	return err
+}
+
+// LatestUnsafeBlock implements API.
+func (c *APIClient) LatestUnsafeBlock(ctx context.Context) (eth.BlockID, error) {
+	var block eth.BlockID
+	err := c.c.CallContext(ctx, &block, prefixRPC("latestUnsafeBlock"))
+	return block, err
+}
+
 // SequencerHealthy implements API.
 func (c *APIClient) SequencerHealthy(ctx context.Context) (bool, error) {
 	var healthy bool
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
@@ -61,6 +62,22 @@ func (f fakeTxMgr) Send(_ context.Context, _ txmgr.TxCandidate) (*types.Receipt,
 	panic("unimplemented")
 }
 
+func (f fakeTxMgr) SendAsync(_ context.Context, _ txmgr.TxCandidate, _ chan txmgr.SendResponse) {
+	panic("unimplemented")
+}
+
+func (f fakeTxMgr) Cancel(_ context.Context, _ uint64) (*types.Receipt, error) {
+	panic("unimplemented")
+}
+
+func (f fakeTxMgr) Abandon(_ uint64) error {
+	panic("unimplemented")
+}
+
+func (f fakeTxMgr) SubscribeTxEvents(_ chan<- txmgr.TxEvent) event.Subscription {
+	panic("unimplemented")
+}
+
 func (f fakeTxMgr) Close() {
 }
 
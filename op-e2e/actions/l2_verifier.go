@@ -6,12 +6,14 @@ import (
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	gnode "github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
@@ -61,7 +63,7 @@ type L2API interface {
 func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc derive.L1BlobsFetcher, eng L2API, cfg *rollup.Config, syncCfg *sync.Config) *L2Verifier {
 	metrics := &testutils.TestDerivationMetrics{}
 	engine := derive.NewEngineController(eng, log, metrics, cfg, syncCfg.SyncMode)
-	pipeline := derive.NewDerivationPipeline(log, cfg, l1, blobsSrc, eng, engine, metrics, syncCfg)
+	pipeline := derive.NewDerivationPipeline(log, cfg, l1, derive.NewDataSourceFactory(log, cfg, l1, blobsSrc), eng, engine, metrics, syncCfg, safedb.Disabled)
 	pipeline.Reset()
 
 	rollupNode := &L2Verifier{
@@ -84,7 +86,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc deri
 	apis := []rpc.API{
 		{
 			Namespace:     "optimism",
-			Service:       node.NewNodeAPI(cfg, eng, backend, log, m),
+			Service:       node.NewNodeAPI(cfg, eng, backend, safedb.Disabled, log, m),
 			Public:        true,
 			Authenticated: false,
 		},
@@ -122,14 +124,34 @@ func (s *l2VerifierBackend) StartSequencer(ctx context.Context, blockHash common
 	return nil
 }
 
+func (s *l2VerifierBackend) ResetUnsafeHead(ctx context.Context, blockHash common.Hash) error {
+	return errors.New("resetting the L2Verifier unsafe head is not supported")
+}
+
 func (s *l2VerifierBackend) StopSequencer(ctx context.Context) (common.Hash, error) {
 	return common.Hash{}, errors.New("stopping the L2Verifier sequencer is not supported")
 }
 
+func (s *l2VerifierBackend) SubscribePayloadAttributes(ch chan<- *eth.PayloadAttributesEvent) event.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		<-quit
+		return nil
+	})
+}
+
+func (s *l2VerifierBackend) OverrideLeader(ctx context.Context) error {
+	return nil
+}
+
 func (s *l2VerifierBackend) SequencerActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+func (s *l2VerifierBackend) DerivationPipelineState(ctx context.Context) (*eth.DerivationPipelineState, error) {
+	state := s.verifier.derivation.State()
+	return &state, nil
+}
+
 func (s *l2VerifierBackend) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	return nil
 }
@@ -93,15 +93,7 @@ func Start(ctx context.Context, l log.Logger, cfg Config, version string) (*Hear
 		l.Info("started metrics server", "addr", metricsSrv.Addr())
 	}
 
-	pprofCfg := cfg.Pprof
-	hs.pprofService = oppprof.New(
-		pprofCfg.ListenEnabled,
-		pprofCfg.ListenAddr,
-		pprofCfg.ListenPort,
-		pprofCfg.ProfileType,
-		pprofCfg.ProfileDir,
-		pprofCfg.ProfileFilename,
-	)
+	hs.pprofService = oppprof.NewFromCLIConfig(cfg.Pprof)
 
 	if err := hs.pprofService.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start pprof service: %w", err)
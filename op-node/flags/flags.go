@@ -6,11 +6,13 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
@@ -43,6 +45,12 @@ var (
 		Destination: new(string),
 	}
 	/* Optional Flags */
+	L2StandbyEngineAddr = &cli.StringFlag{
+		Name: "l2.standby",
+		Usage: "Address of the L2 Engine JSON-RPC endpoint of a standby execution client. " +
+			"Forkchoice updates and new payloads are forwarded to it, to keep it in sync for failover. Uses the l2.jwt-secret.",
+		EnvVars: prefixEnvVars("L2_STANDBY"),
+	}
 	BeaconAddr = &cli.StringFlag{
 		Name:     "l1.beacon",
 		Usage:    "Address of L1 Beacon-node HTTP endpoint to use.",
@@ -73,6 +81,13 @@ var (
 		}(),
 		Hidden: true,
 	}
+	DepositsOnlyFlag = &cli.BoolFlag{
+		Name: "derivation.deposits-only",
+		Usage: "Incident response only: ignore all batch data, and derive deposit-only blocks for every L1 origin " +
+			"once its sequencing window expires. The chain diverges from nodes without this flag if any valid batches are submitted.",
+		EnvVars: prefixEnvVars("DERIVATION_DEPOSITS_ONLY"),
+		Value:   false,
+	}
 	RPCListenAddr = &cli.StringFlag{
 		Name:    "rpc.addr",
 		Usage:   "RPC listening address",
@@ -90,6 +105,12 @@ var (
 		Usage:   "Enable the admin API (experimental)",
 		EnvVars: prefixEnvVars("RPC_ENABLE_ADMIN"),
 	}
+	RPCAdminJWTSecret = &cli.StringFlag{
+		Name: "rpc.admin-jwt-secret",
+		Usage: "Path to JWT secret key, to require authentication for the admin API. Keys are 32 bytes, hex encoded in a file. " +
+			"Requests without a JWT are served without the admin API. Disabled if not set.",
+		EnvVars: prefixEnvVars("RPC_ADMIN_JWT_SECRET"),
+	}
 	RPCAdminPersistence = &cli.StringFlag{
 		Name:    "rpc.admin-state",
 		Usage:   "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
@@ -102,7 +123,8 @@ var (
 	}
 	L1RPCProviderKind = &cli.GenericFlag{
 		Name: "l1.rpckind",
-		Usage: "The kind of RPC provider, used to inform optimal transactions receipts fetching, and thus reduce costs. Valid options: " +
+		Usage: "The kind of RPC provider, used to inform optimal transactions receipts fetching, and thus reduce costs. " +
+			"Use auto to detect the receipt methods supported by the provider. Valid options: " +
 			openum.EnumString(sources.RPCProviderKinds),
 		EnvVars: prefixEnvVars("L1_RPC_KIND"),
 		Value: func() *sources.RPCProviderKind {
@@ -116,6 +138,12 @@ var (
 		EnvVars: prefixEnvVars("L1_RETHDB"),
 		Hidden:  true,
 	}
+	L1FallbackAddrs = &cli.StringSliceFlag{
+		Name: "l1.fallback",
+		Usage: "Comma separated addresses of L1 User JSON-RPC endpoints to fail over to, in order, when the L1 endpoint fails. " +
+			"The L1 endpoint is used again once it recovers.",
+		EnvVars: prefixEnvVars("L1_FALLBACK"),
+	}
 	L1RPCMaxConcurrency = &cli.IntFlag{
 		Name:    "l1.max-concurrency",
 		Usage:   "Maximum number of concurrent RPC requests to make to the L1 RPC provider.",
@@ -134,6 +162,18 @@ var (
 		EnvVars: prefixEnvVars("L1_RPC_MAX_BATCH_SIZE"),
 		Value:   20,
 	}
+	L1RPCCircuitBreakerThreshold = &cli.IntFlag{
+		Name:    "l1.rpc-circuit-breaker-threshold",
+		Usage:   "Number of consecutive L1 RPC failures after which requests are rejected for the circuit breaker cooldown, instead of being retried against a failing provider. Disabled if set to 0.",
+		EnvVars: prefixEnvVars("L1_RPC_CIRCUIT_BREAKER_THRESHOLD"),
+		Value:   0,
+	}
+	L1RPCCircuitBreakerCooldown = &cli.DurationFlag{
+		Name:    "l1.rpc-circuit-breaker-cooldown",
+		Usage:   "Duration to reject L1 RPC requests for once the circuit breaker trips, before probing the provider again.",
+		EnvVars: prefixEnvVars("L1_RPC_CIRCUIT_BREAKER_COOLDOWN"),
+		Value:   time.Second * 10,
+	}
 	L1HTTPPollInterval = &cli.DurationFlag{
 		Name:    "l1.http-poll-interval",
 		Usage:   "Polling interval for latest-block subscription when using an HTTP RPC provider. Ignored for other types of RPC endpoints.",
@@ -168,6 +208,13 @@ var (
 		EnvVars: prefixEnvVars("SEQUENCER_L1_CONFS"),
 		Value:   4,
 	}
+	SequencerL1DepositConfs = &cli.Uint64Flag{
+		Name: "sequencer.l1-deposit-confs",
+		Usage: "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin that contains deposits. " +
+			"L1 origins without deposits are picked at the sequencer.l1-confs distance. Disabled if not larger than sequencer.l1-confs.",
+		EnvVars: prefixEnvVars("SEQUENCER_L1_DEPOSIT_CONFS"),
+		Value:   0,
+	}
 	L1EpochPollIntervalFlag = &cli.DurationFlag{
 		Name:    "l1.epoch-poll-interval",
 		Usage:   "Poll interval for retrieving new L1 epoch updates such as safe and finalized block changes. Disabled if 0 or negative.",
@@ -223,6 +270,11 @@ var (
 		Usage:   "Opt-in option to halt on incompatible protocol version requirements of the given level (major/minor/patch/none), as signaled onchain in L1",
 		EnvVars: prefixEnvVars("ROLLUP_HALT"),
 	}
+	SafeDBPath = &cli.StringFlag{
+		Name:    "safedb.path",
+		Usage:   "File path used to persist the L1 block each L2 safe head was derived from. Disabled if not set.",
+		EnvVars: prefixEnvVars("SAFEDB_PATH"),
+	}
 	RollupLoadProtocolVersions = &cli.BoolFlag{
 		Name:    "rollup.load-protocol-versions",
 		Usage:   "Load protocol versions from the superchain L1 ProtocolVersions contract (if available), and report in logs and metrics",
@@ -263,6 +315,13 @@ var (
 		EnvVars: prefixEnvVars("L2_BACKUP_UNSAFE_SYNC_RPC_TRUST_RPC"),
 		Hidden:  true,
 	}
+	DASourceFlag = &cli.StringFlag{
+		Name: "da-source",
+		Usage: "Data availability source to derive the L2 chain from. The default \"" + derive.L1DASourceName + "\" source reads batch data from L1 calldata and blobs. " +
+			"Alternative DA sources are available when their plugin is compiled into the node.",
+		EnvVars: prefixEnvVars("DA_SOURCE"),
+		Value:   derive.L1DASourceName,
+	}
 	ConductorEnabledFlag = &cli.BoolFlag{
 		Name:    "conductor.enabled",
 		Usage:   "Enable the conductor service",
@@ -290,26 +349,33 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	L2StandbyEngineAddr,
 	BeaconAddr,
 	BeaconCheckIgnore,
 	BeaconFetchAllSidecars,
 	SyncModeFlag,
+	DepositsOnlyFlag,
 	RPCListenAddr,
 	RPCListenPort,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	L1RPCRateLimit,
 	L1RPCMaxBatchSize,
+	L1FallbackAddrs,
 	L1RPCMaxConcurrency,
+	L1RPCCircuitBreakerThreshold,
+	L1RPCCircuitBreakerCooldown,
 	L1HTTPPollInterval,
 	VerifierL1Confs,
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
 	SequencerL1Confs,
+	SequencerL1DepositConfs,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
+	RPCAdminJWTSecret,
 	RPCAdminPersistence,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
@@ -320,7 +386,9 @@ var optionalFlags = []cli.Flag{
 	HeartbeatURLFlag,
 	RollupHalt,
 	RollupLoadProtocolVersions,
+	SafeDBPath,
 	L1RethDBPath,
+	DASourceFlag,
 	ConductorEnabledFlag,
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
@@ -343,6 +411,7 @@ func init() {
 	optionalFlags = append(optionalFlags, P2PFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, opsigner.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, DeprecatedFlags...)
 	optionalFlags = append(optionalFlags, opflags.CLIFlags(EnvVarPrefix)...)
 	Flags = append(requiredFlags, optionalFlags...)
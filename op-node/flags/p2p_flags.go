@@ -14,45 +14,52 @@ func p2pEnv(envprefix, v string) []string {
 }
 
 var (
-	DisableP2PName         = "p2p.disable"
-	NoDiscoveryName        = "p2p.no-discovery"
-	ScoringName            = "p2p.scoring"
-	PeerScoringName        = "p2p.scoring.peers"
-	PeerScoreBandsName     = "p2p.score.bands"
-	BanningName            = "p2p.ban.peers"
-	BanningThresholdName   = "p2p.ban.threshold"
-	BanningDurationName    = "p2p.ban.duration"
-	TopicScoringName       = "p2p.scoring.topics"
-	P2PPrivPathName        = "p2p.priv.path"
-	P2PPrivRawName         = "p2p.priv.raw"
-	ListenIPName           = "p2p.listen.ip"
-	ListenTCPPortName      = "p2p.listen.tcp"
-	ListenUDPPortName      = "p2p.listen.udp"
-	AdvertiseIPName        = "p2p.advertise.ip"
-	AdvertiseTCPPortName   = "p2p.advertise.tcp"
-	AdvertiseUDPPortName   = "p2p.advertise.udp"
-	BootnodesName          = "p2p.bootnodes"
-	StaticPeersName        = "p2p.static"
-	NetRestrictName        = "p2p.netrestrict"
-	HostMuxName            = "p2p.mux"
-	HostSecurityName       = "p2p.security"
-	PeersLoName            = "p2p.peers.lo"
-	PeersHiName            = "p2p.peers.hi"
-	PeersGraceName         = "p2p.peers.grace"
-	NATName                = "p2p.nat"
-	UserAgentName          = "p2p.useragent"
-	TimeoutNegotiationName = "p2p.timeout.negotiation"
-	TimeoutAcceptName      = "p2p.timeout.accept"
-	TimeoutDialName        = "p2p.timeout.dial"
-	PeerstorePathName      = "p2p.peerstore.path"
-	DiscoveryPathName      = "p2p.discovery.path"
-	SequencerP2PKeyName    = "p2p.sequencer.key"
-	GossipMeshDName        = "p2p.gossip.mesh.d"
-	GossipMeshDloName      = "p2p.gossip.mesh.lo"
-	GossipMeshDhiName      = "p2p.gossip.mesh.dhi"
-	GossipMeshDlazyName    = "p2p.gossip.mesh.dlazy"
-	GossipFloodPublishName = "p2p.gossip.mesh.floodpublish"
-	SyncReqRespName        = "p2p.sync.req-resp"
+	DisableP2PName                  = "p2p.disable"
+	NoDiscoveryName                 = "p2p.no-discovery"
+	ScoringName                     = "p2p.scoring"
+	PeerScoringName                 = "p2p.scoring.peers"
+	PeerScoreBandsName              = "p2p.score.bands"
+	BanningName                     = "p2p.ban.peers"
+	BanningThresholdName            = "p2p.ban.threshold"
+	BanningDurationName             = "p2p.ban.duration"
+	TopicScoringName                = "p2p.scoring.topics"
+	P2PPrivPathName                 = "p2p.priv.path"
+	P2PPrivRawName                  = "p2p.priv.raw"
+	ListenIPName                    = "p2p.listen.ip"
+	ListenTCPPortName               = "p2p.listen.tcp"
+	ListenUDPPortName               = "p2p.listen.udp"
+	AdvertiseIPName                 = "p2p.advertise.ip"
+	AdvertiseTCPPortName            = "p2p.advertise.tcp"
+	AdvertiseUDPPortName            = "p2p.advertise.udp"
+	BootnodesName                   = "p2p.bootnodes"
+	StaticPeersName                 = "p2p.static"
+	StaticPeersOnlyName             = "p2p.static-only"
+	NetRestrictName                 = "p2p.netrestrict"
+	HostMuxName                     = "p2p.mux"
+	HostSecurityName                = "p2p.security"
+	PeersLoName                     = "p2p.peers.lo"
+	PeersHiName                     = "p2p.peers.hi"
+	PeersGraceName                  = "p2p.peers.grace"
+	NATName                         = "p2p.nat"
+	UserAgentName                   = "p2p.useragent"
+	TimeoutNegotiationName          = "p2p.timeout.negotiation"
+	TimeoutAcceptName               = "p2p.timeout.accept"
+	TimeoutDialName                 = "p2p.timeout.dial"
+	PeerstorePathName               = "p2p.peerstore.path"
+	DiscoveryPathName               = "p2p.discovery.path"
+	SequencerP2PKeyName             = "p2p.sequencer.key"
+	GossipMeshDName                 = "p2p.gossip.mesh.d"
+	GossipMeshDloName               = "p2p.gossip.mesh.lo"
+	GossipMeshDhiName               = "p2p.gossip.mesh.dhi"
+	GossipMeshDlazyName             = "p2p.gossip.mesh.dlazy"
+	GossipFloodPublishName          = "p2p.gossip.mesh.floodpublish"
+	SyncReqRespName                 = "p2p.sync.req-resp"
+	SyncServerGlobalRateLimitName   = "p2p.sync.server.global-rate-limit"
+	SyncServerGlobalBurstName       = "p2p.sync.server.global-burst"
+	SyncServerPeerRateLimitName     = "p2p.sync.server.peer-rate-limit"
+	SyncServerPeerBurstName         = "p2p.sync.server.peer-burst"
+	SyncServerMaxConcurrentName     = "p2p.sync.server.max-concurrent"
+	SyncServerMaxPeerConcurrentName = "p2p.sync.server.max-peer-concurrent"
 )
 
 func deprecatedP2PFlags(envPrefix string) []cli.Flag {
@@ -201,6 +208,12 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Value:    "",
 			EnvVars:  p2pEnv(envPrefix, "STATIC"),
 		},
+		&cli.BoolFlag{
+			Name:     StaticPeersOnlyName,
+			Usage:    "Disable discovery, and only connect to and accept connections from the static peers. For devnets and private networks.",
+			Required: false,
+			EnvVars:  p2pEnv(envPrefix, "STATIC_ONLY"),
+		},
 		&cli.StringFlag{
 			Name:     NetRestrictName,
 			Usage:    "Comma-separated list of CIDR masks. P2P will only try to connect on these networks",
@@ -302,7 +315,7 @@ func P2PFlags(envPrefix string) []cli.Flag {
 		},
 		&cli.StringFlag{
 			Name:     SequencerP2PKeyName,
-			Usage:    "Hex-encoded private key for signing off on p2p application messages as sequencer.",
+			Usage:    "Hex-encoded private key for signing off on p2p application messages as sequencer. Cannot be used together with a remote signer.",
 			Required: false,
 			Value:    "",
 			EnvVars:  p2pEnv(envPrefix, "SEQUENCER_KEY"),
@@ -353,5 +366,53 @@ func P2PFlags(envPrefix string) []cli.Flag {
 			Required: false,
 			EnvVars:  p2pEnv(envPrefix, "SYNC_REQ_RESP"),
 		},
+		&cli.Float64Flag{
+			Name:     SyncServerGlobalRateLimitName,
+			Usage:    "Maximum number of P2P sync requests per second served to all peers combined.",
+			Required: false,
+			Hidden:   true,
+			Value:    float64(p2p.DefaultReqRespServerConfig().GlobalRateLimit),
+			EnvVars:  p2pEnv(envPrefix, "SYNC_SERVER_GLOBAL_RATE_LIMIT"),
+		},
+		&cli.UintFlag{
+			Name:     SyncServerGlobalBurstName,
+			Usage:    "Maximum burst of P2P sync requests served to all peers combined.",
+			Required: false,
+			Hidden:   true,
+			Value:    uint(p2p.DefaultReqRespServerConfig().GlobalBurst),
+			EnvVars:  p2pEnv(envPrefix, "SYNC_SERVER_GLOBAL_BURST"),
+		},
+		&cli.Float64Flag{
+			Name:     SyncServerPeerRateLimitName,
+			Usage:    "Maximum number of P2P sync requests per second served to a single peer.",
+			Required: false,
+			Hidden:   true,
+			Value:    float64(p2p.DefaultReqRespServerConfig().PeerRateLimit),
+			EnvVars:  p2pEnv(envPrefix, "SYNC_SERVER_PEER_RATE_LIMIT"),
+		},
+		&cli.UintFlag{
+			Name:     SyncServerPeerBurstName,
+			Usage:    "Maximum burst of P2P sync requests served to a single peer.",
+			Required: false,
+			Hidden:   true,
+			Value:    uint(p2p.DefaultReqRespServerConfig().PeerBurst),
+			EnvVars:  p2pEnv(envPrefix, "SYNC_SERVER_PEER_BURST"),
+		},
+		&cli.UintFlag{
+			Name:     SyncServerMaxConcurrentName,
+			Usage:    "Maximum number of P2P sync requests served at the same time. Further requests wait, and are served by priority.",
+			Required: false,
+			Hidden:   true,
+			Value:    uint(p2p.DefaultReqRespServerConfig().MaxConcurrentRequests),
+			EnvVars:  p2pEnv(envPrefix, "SYNC_SERVER_MAX_CONCURRENT"),
+		},
+		&cli.UintFlag{
+			Name:     SyncServerMaxPeerConcurrentName,
+			Usage:    "Maximum number of P2P sync requests of a single peer served at the same time.",
+			Required: false,
+			Hidden:   true,
+			Value:    uint(p2p.DefaultReqRespServerConfig().MaxPeerConcurrentRequests),
+			EnvVars:  p2pEnv(envPrefix, "SYNC_SERVER_MAX_PEER_CONCURRENT"),
+		},
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -27,7 +28,7 @@ import (
 const (
 	Namespace = "op_node"
 
-	BatchMethod = "<batch>"
+	BatchMethod = metrics.BatchMethod
 )
 
 type Metricer interface {
@@ -35,7 +36,9 @@ type Metricer interface {
 	RecordUp()
 	RecordRPCServerRequest(method string) func()
 	RecordRPCClientRequest(method string) func(err error)
+	RecordRPCClientBatchRequest(b []rpc.BatchElem) func(err error)
 	RecordRPCClientResponse(method string, err error)
+	RecordRPCClientResponseSize(method string, size int)
 	SetDerivationIdle(status bool)
 	RecordPipelineReset()
 	RecordSequencingError()
@@ -49,6 +52,11 @@ type Metricer interface {
 	RecordDerivedBatches(batchType string)
 	CountSequencedTxs(count int)
 	RecordL1ReorgDepth(d uint64)
+	RecordL2ReorgDepth(head string, d uint64)
+	RecordDeepL1Reorg(l1Depth uint64)
+	RecordDerivationStageStep(stage string, result string, d time.Duration)
+	RecordDerivationQueueDepth(stage string, depth int)
+	RecordDerivationStageStalled(stage string, stalled bool)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
 	RecordGossipEvent(evType int32)
@@ -73,6 +81,8 @@ type Metricer interface {
 	RecordIPUnban()
 	RecordDial(allow bool)
 	RecordAccept(allow bool)
+	SetStaticPeers(connected int, disconnected int)
+	RecordStaticPeerDial(success bool)
 	ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion)
 }
 
@@ -86,6 +96,8 @@ type Metrics struct {
 	L1SourceCache *metrics.CacheMetrics
 	L2SourceCache *metrics.CacheMetrics
 
+	L1Fallback *metrics.FallbackClientMetrics
+
 	DerivationIdle prometheus.Gauge
 
 	PipelineResets   *metrics.Event
@@ -120,6 +132,15 @@ type Metrics struct {
 
 	L1ReorgDepth prometheus.Histogram
 
+	L2ReorgDepth     *prometheus.HistogramVec
+	DeepL1Reorgs     *metrics.Event
+	DeepL1ReorgDepth prometheus.Histogram
+
+	DerivationStageStepDuration *prometheus.HistogramVec
+	DerivationStageSteps        *prometheus.CounterVec
+	DerivationQueueDepth        *prometheus.GaugeVec
+	DerivationStageStalled      *prometheus.GaugeVec
+
 	TransactionsSequencedTotal prometheus.Counter
 
 	// Channel Bank Metrics
@@ -137,6 +158,8 @@ type Metrics struct {
 	Dials             *prometheus.CounterVec
 	Accepts           *prometheus.CounterVec
 	PeerScores        *prometheus.HistogramVec
+	StaticPeers       *prometheus.GaugeVec
+	StaticPeerDials   *prometheus.CounterVec
 
 	ChannelInputBytes prometheus.Counter
 
@@ -183,6 +206,8 @@ func NewMetrics(procName string) *Metrics {
 		L1SourceCache: metrics.NewCacheMetrics(factory, ns, "l1_source_cache", "L1 Source cache"),
 		L2SourceCache: metrics.NewCacheMetrics(factory, ns, "l2_source_cache", "L2 Source cache"),
 
+		L1Fallback: metrics.NewFallbackClientMetrics(factory, ns, "l1_rpc", "L1"),
+
 		DerivationIdle: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "derivation_idle",
@@ -219,6 +244,45 @@ func NewMetrics(procName string) *Metrics {
 			Buckets:   []float64{0.5, 1.5, 2.5, 3.5, 4.5, 5.5, 6.5, 7.5, 8.5, 9.5, 10.5, 20.5, 50.5, 100.5},
 			Help:      "Histogram of L1 Reorg Depths",
 		}),
+		L2ReorgDepth: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "l2_reorg_depth",
+			Buckets:   []float64{0.5, 1.5, 2.5, 5.5, 10.5, 20.5, 50.5, 100.5, 200.5, 500.5, 1000.5, 5000.5},
+			Help:      "Histogram of the number of L2 blocks rewound by L1 reorgs, by the L2 head that was rewound",
+		}, []string{"head"}),
+		DeepL1Reorgs: metrics.NewEvent(factory, ns, "", "deep_l1_reorgs", "L1 reorgs deeper than the unsafe window, that rewound the safe head"),
+		DeepL1ReorgDepth: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "deep_l1_reorg_depth",
+			Buckets:   []float64{0.5, 1.5, 2.5, 3.5, 4.5, 5.5, 6.5, 7.5, 8.5, 9.5, 10.5, 20.5, 50.5, 100.5},
+			Help:      "Histogram of the number of L1 origins rewound by L1 reorgs deeper than the unsafe window",
+		}),
+
+		DerivationStageStepDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "derivation",
+			Name:      "stage_step_seconds",
+			Buckets:   []float64{.0001, .0005, .001, .005, .01, .05, .1, .5, 1, 5, 10},
+			Help:      "Duration of pulling from a derivation pipeline stage, including the stages it pulls from",
+		}, []string{"stage"}),
+		DerivationStageSteps: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "derivation",
+			Name:      "stage_steps_total",
+			Help:      "Count of derivation pipeline stage steps, by stage and result",
+		}, []string{"stage", "result"}),
+		DerivationQueueDepth: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "derivation",
+			Name:      "queue_depth",
+			Help:      "Number of items buffered by a derivation pipeline stage",
+		}, []string{"stage"}),
+		DerivationStageStalled: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "derivation",
+			Name:      "stage_stalled",
+			Help:      "1 if a derivation pipeline stage has not produced output for a sequencing window of L1 blocks, 0 otherwise",
+		}, []string{"stage"}),
 
 		TransactionsSequencedTotal: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
@@ -284,6 +348,18 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "accepts",
 			Help:      "Count of incoming dial attempts to accept, with label to filter to allowed attempts",
 		}, []string{"allow"}),
+		StaticPeers: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "static_peers",
+			Help:      "Number of configured static peers, by connection state",
+		}, []string{"state"}),
+		StaticPeerDials: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "static_peer_dials",
+			Help:      "Count of (re)dial attempts to static peers, with label to filter to successful attempts",
+		}, []string{"success"}),
 
 		headChannelOpenedEvent: metrics.NewEvent(factory, ns, "", "head_channel", "New channel at the front of the channel bank"),
 		channelTimedOutEvent:   metrics.NewEvent(factory, ns, "", "channel_timeout", "Channel has timed out"),
@@ -466,6 +542,32 @@ func (m *Metrics) RecordL1ReorgDepth(d uint64) {
 	m.L1ReorgDepth.Observe(float64(d))
 }
 
+func (m *Metrics) RecordL2ReorgDepth(head string, d uint64) {
+	m.L2ReorgDepth.WithLabelValues(head).Observe(float64(d))
+}
+
+func (m *Metrics) RecordDeepL1Reorg(l1Depth uint64) {
+	m.DeepL1Reorgs.Record()
+	m.DeepL1ReorgDepth.Observe(float64(l1Depth))
+}
+
+func (m *Metrics) RecordDerivationStageStep(stage string, result string, d time.Duration) {
+	m.DerivationStageStepDuration.WithLabelValues(stage).Observe(d.Seconds())
+	m.DerivationStageSteps.WithLabelValues(stage, result).Inc()
+}
+
+func (m *Metrics) RecordDerivationQueueDepth(stage string, depth int) {
+	m.DerivationQueueDepth.WithLabelValues(stage).Set(float64(depth))
+}
+
+func (m *Metrics) RecordDerivationStageStalled(stage string, stalled bool) {
+	if stalled {
+		m.DerivationStageStalled.WithLabelValues(stage).Set(1)
+	} else {
+		m.DerivationStageStalled.WithLabelValues(stage).Set(0)
+	}
+}
+
 func (m *Metrics) RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID) {
 	m.SequencerInconsistentL1Origin.Record()
 	m.RecordRef("l1_origin", "inconsistent_from", from.Number, 0, from.Hash)
@@ -605,6 +707,19 @@ func (m *Metrics) RecordAccept(allow bool) {
 		m.Accepts.WithLabelValues("false").Inc()
 	}
 }
+
+func (m *Metrics) SetStaticPeers(connected int, disconnected int) {
+	m.StaticPeers.WithLabelValues("connected").Set(float64(connected))
+	m.StaticPeers.WithLabelValues("disconnected").Set(float64(disconnected))
+}
+
+func (m *Metrics) RecordStaticPeerDial(success bool) {
+	if success {
+		m.StaticPeerDials.WithLabelValues("true").Inc()
+	} else {
+		m.StaticPeerDials.WithLabelValues("false").Inc()
+	}
+}
 func (m *Metrics) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 	m.ProtocolVersionDelta.WithLabelValues("local_recommended").Set(float64(local.Compare(recommended)))
 	m.ProtocolVersionDelta.WithLabelValues("local_required").Set(float64(local.Compare(required)))
@@ -664,6 +779,21 @@ func (n *noopMetricer) CountSequencedTxs(count int) {
 func (n *noopMetricer) RecordL1ReorgDepth(d uint64) {
 }
 
+func (n *noopMetricer) RecordL2ReorgDepth(head string, d uint64) {
+}
+
+func (n *noopMetricer) RecordDeepL1Reorg(l1Depth uint64) {
+}
+
+func (n *noopMetricer) RecordDerivationStageStep(stage string, result string, d time.Duration) {
+}
+
+func (n *noopMetricer) RecordDerivationQueueDepth(stage string, depth int) {
+}
+
+func (n *noopMetricer) RecordDerivationStageStalled(stage string, stalled bool) {
+}
+
 func (n *noopMetricer) RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID) {
 }
 
@@ -733,5 +863,11 @@ func (n *noopMetricer) RecordDial(allow bool) {
 
 func (n *noopMetricer) RecordAccept(allow bool) {
 }
+
+func (n *noopMetricer) SetStaticPeers(connected int, disconnected int) {
+}
+
+func (n *noopMetricer) RecordStaticPeerDial(success bool) {
+}
 func (n *noopMetricer) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 }
@@ -2,12 +2,16 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -23,14 +27,22 @@ type l2EthClient interface {
 	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
 }
 
+type SafeDBReader interface {
+	SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error)
+}
+
 type driverClient interface {
 	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 	BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error)
 	ResetDerivationPipeline(context.Context) error
+	ResetUnsafeHead(ctx context.Context, blockHash common.Hash) error
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
+	DerivationPipelineState(context.Context) (*eth.DerivationPipelineState, error)
+	OverrideLeader(context.Context) error
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
+	SubscribePayloadAttributes(ch chan<- *eth.PayloadAttributesEvent) event.Subscription
 }
 
 type adminAPI struct {
@@ -51,6 +63,14 @@ func (n *adminAPI) ResetDerivationPipeline(ctx context.Context) error {
 	return n.dr.ResetDerivationPipeline(ctx)
 }
 
+// ResetUnsafeHead rewinds the unsafe head to the given L2 block and re-runs derivation from there,
+// to recover from sequencer faults without restarting the node or wiping its data.
+func (n *adminAPI) ResetUnsafeHead(ctx context.Context, blockHash common.Hash) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_resetUnsafeHead")
+	defer recordDur()
+	return n.dr.ResetUnsafeHead(ctx, blockHash)
+}
+
 func (n *adminAPI) StartSequencer(ctx context.Context, blockHash common.Hash) error {
 	recordDur := n.M.RecordRPCServerRequest("admin_startSequencer")
 	defer recordDur()
@@ -69,6 +89,22 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+// DerivationPipelineState dumps the internals of every stage of the derivation pipeline,
+// to debug stalled or slow derivation.
+func (n *adminAPI) DerivationPipelineState(ctx context.Context) (*eth.DerivationPipelineState, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_derivationPipelineState")
+	defer recordDur()
+	return n.dr.DerivationPipelineState(ctx)
+}
+
+// OverrideLeader forces the sequencer to act as leader, bypassing the sequencer conductor.
+// It should only be used in emergencies, when the conductor cannot elect a leader.
+func (n *adminAPI) OverrideLeader(ctx context.Context) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_overrideLeader")
+	defer recordDur()
+	return n.dr.OverrideLeader(ctx)
+}
+
 // PostUnsafePayload is a special API that allow posting an unsafe payload to the L2 derivation pipeline.
 // It should only be used by op-conductor for sequencer failover scenarios.
 // TODO(ethereum-optimism/optimism#9064): op-conductor Dencun changes.
@@ -89,15 +125,17 @@ type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
 	dr     driverClient
+	safeDB SafeDBReader
 	log    log.Logger
 	m      metrics.RPCMetricer
 }
 
-func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, m metrics.RPCMetricer) *nodeAPI {
+func NewNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, log log.Logger, m metrics.RPCMetricer) *nodeAPI {
 	return &nodeAPI{
 		config: config,
 		client: l2Client,
 		dr:     dr,
+		safeDB: safeDB,
 		log:    log,
 		m:      m,
 	}
@@ -126,6 +164,23 @@ func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) (*et
 	}, nil
 }
 
+// SafeHeadAtL1Block returns the L2 safe head that was derived from the L1 chain up to and including the given L1 block,
+// without having to replay the derivation. Requires the safe head database to be enabled.
+func (n *nodeAPI) SafeHeadAtL1Block(ctx context.Context, number hexutil.Uint64) (*eth.SafeHeadResponse, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_safeHeadAtL1Block")
+	defer recordDur()
+	l1Block, safeHead, err := n.safeDB.SafeHeadAtL1(ctx, uint64(number))
+	if errors.Is(err, safedb.ErrNotFound) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get safe head at l1 block %v: %w", number, err)
+	}
+	return &eth.SafeHeadResponse{
+		L1Block:  l1Block,
+		SafeHead: safeHead,
+	}, nil
+}
+
 func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_syncStatus")
 	defer recordDur()
@@ -143,3 +198,33 @@ func (n *nodeAPI) Version(ctx context.Context) (string, error) {
 	defer recordDur()
 	return version.Version + "-" + version.Meta, nil
 }
+
+// PayloadAttributes streams the payload attributes of the blocks this node starts sequencing,
+// so external block builders can prepare blocks ahead of time.
+// It is only available over websocket, with optimism_subscribe("payloadAttributes").
+func (n *nodeAPI) PayloadAttributes(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	events := make(chan *eth.PayloadAttributesEvent, 16)
+	eventsSub := n.dr.SubscribePayloadAttributes(events)
+	go func() {
+		defer eventsSub.Unsubscribe()
+		for {
+			select {
+			case ev := <-events:
+				if err := notifier.Notify(rpcSub.ID, ev); err != nil {
+					n.log.Debug("Failed to notify payload attributes subscriber", "err", err)
+				}
+			case <-rpcSub.Err():
+				return
+			case err := <-eventsSub.Err():
+				n.log.Warn("Payload attributes subscription ended", "err", err)
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
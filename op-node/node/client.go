@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 
 	"github.com/ethereum/go-ethereum/log"
@@ -25,7 +26,8 @@ type L1EndpointSetup interface {
 	// Setup a RPC client to a L1 node to pull rollup input-data from.
 	// The results of the RPC client may be trusted for faster processing, or strictly validated.
 	// The kind of the RPC may be non-basic, to optimize RPC usage.
-	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (cl client.RPC, rpcCfg *sources.L1ClientConfig, err error)
+	// The metrics record which endpoint is in use, if the L1 node has fallback endpoints.
+	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.FallbackClientMetricer) (cl client.RPC, rpcCfg *sources.L1ClientConfig, err error)
 	Check() error
 }
 
@@ -45,6 +47,11 @@ type L2EndpointConfig struct {
 	// JWT secrets for L2 Engine API authentication during HTTP or initial Websocket communication.
 	// Any value for an IPC connection.
 	L2EngineJWTSecret [32]byte
+
+	// L2StandbyEngineAddr is the optional address of the Engine JSON-RPC endpoint of a standby L2 execution client.
+	// The forkchoice updates and new payloads accepted by the primary engine are forwarded to it,
+	// to keep it in sync for failover. It authenticates with the same JWT secret as the primary engine.
+	L2StandbyEngineAddr string
 }
 
 var _ L2EndpointSetup = (*L2EndpointConfig)(nil)
@@ -71,6 +78,17 @@ func (cfg *L2EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 		return nil, nil, err
 	}
 
+	if cfg.L2StandbyEngineAddr != "" {
+		// The standby is not required to be available, HTTP connections are established lazily.
+		standby, err := rpc.DialOptions(ctx, cfg.L2StandbyEngineAddr, auth)
+		if err != nil {
+			l2Node.Close()
+			return nil, nil, fmt.Errorf("failed to dial standby L2 engine: %w", err)
+		}
+		log.Info("Forwarding engine calls to standby L2 engine", "addr", cfg.L2StandbyEngineAddr)
+		l2Node = newStandbyEngineRPC(log.New("engine", "standby"), l2Node, client.NewBaseRPCClient(standby))
+	}
+
 	return l2Node, sources.EngineClientDefaultConfig(rollupCfg), nil
 }
 
@@ -95,6 +113,10 @@ func (p *PreparedL2Endpoints) Setup(ctx context.Context, log log.Logger, rollupC
 type L1EndpointConfig struct {
 	L1NodeAddr string // Address of L1 User JSON-RPC endpoint to use (eth namespace required)
 
+	// L1FallbackAddrs are the addresses of L1 User JSON-RPC endpoints to fail over to, in order,
+	// when the L1NodeAddr endpoint fails.
+	L1FallbackAddrs []string
+
 	// L1TrustRPC: if we trust the L1 RPC we do not have to validate L1 response contents like headers
 	// against block hashes, or cached transaction sender addresses.
 	// Thus we can sync faster at the risk of the source RPC being wrong.
@@ -118,6 +140,13 @@ type L1EndpointConfig struct {
 	// It is recommended to use websockets or IPC for efficient following of the changing block.
 	// Setting this to 0 disables polling.
 	HttpPollInterval time.Duration
+
+	// CircuitBreakerThreshold specifies the number of consecutive L1 request failures after which
+	// requests are rejected for CircuitBreakerCooldown. 0 disables the circuit breaker.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown specifies how long requests are rejected for once the circuit breaker trips.
+	CircuitBreakerCooldown time.Duration
 }
 
 var _ L1EndpointSetup = (*L1EndpointConfig)(nil)
@@ -132,10 +161,16 @@ func (cfg *L1EndpointConfig) Check() error {
 	if cfg.MaxConcurrency < 1 {
 		return fmt.Errorf("max concurrent requests cannot be less than 1, was %d", cfg.MaxConcurrency)
 	}
+	if cfg.CircuitBreakerThreshold < 0 {
+		return fmt.Errorf("circuit breaker threshold cannot be negative")
+	}
+	if cfg.CircuitBreakerThreshold > 0 && cfg.CircuitBreakerCooldown <= 0 {
+		return fmt.Errorf("circuit breaker cooldown must be positive, was %v", cfg.CircuitBreakerCooldown)
+	}
 	return nil
 }
 
-func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.L1ClientConfig, error) {
+func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, m opmetrics.FallbackClientMetricer) (client.RPC, *sources.L1ClientConfig, error) {
 	opts := []client.RPCOption{
 		client.WithHttpPollInterval(cfg.HttpPollInterval),
 		client.WithDialBackoff(10),
@@ -143,10 +178,24 @@ func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	if cfg.RateLimit != 0 {
 		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.BatchSize))
 	}
+	if cfg.CircuitBreakerThreshold != 0 {
+		opts = append(opts, client.WithCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown))
+	}
 
-	l1Node, err := client.NewRPC(ctx, log, cfg.L1NodeAddr, opts...)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to dial L1 address (%s): %w", cfg.L1NodeAddr, err)
+	var l1Node client.RPC
+	if len(cfg.L1FallbackAddrs) > 0 {
+		urls := append([]string{cfg.L1NodeAddr}, cfg.L1FallbackAddrs...)
+		fallback, err := client.NewFallbackRPC(log, urls, m, client.DefaultFallbackProbeInterval, client.DefaultFallbackAttemptTimeout, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create L1 fallback client: %w", err)
+		}
+		l1Node = fallback
+	} else {
+		var err error
+		l1Node, err = client.NewRPC(ctx, log, cfg.L1NodeAddr, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dial L1 address (%s): %w", cfg.L1NodeAddr, err)
+		}
 	}
 	rpcCfg := sources.L1ClientDefaultConfig(rollupCfg, cfg.L1TrustRPC, cfg.L1RPCKind)
 	rpcCfg.MaxRequestsPerBatch = cfg.BatchSize
@@ -163,7 +212,7 @@ type PreparedL1Endpoint struct {
 
 var _ L1EndpointSetup = (*PreparedL1Endpoint)(nil)
 
-func (p *PreparedL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (client.RPC, *sources.L1ClientConfig, error) {
+func (p *PreparedL1Endpoint) Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config, _ opmetrics.FallbackClientMetricer) (client.RPC, *sources.L1ClientConfig, error) {
 	return p.Client, sources.L1ClientDefaultConfig(rollupCfg, p.TrustRPC, p.RPCProviderKind), nil
 }
 
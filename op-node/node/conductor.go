@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
@@ -21,6 +22,9 @@ type ConductorClient struct {
 	metrics   *metrics.Metrics
 	log       log.Logger
 	apiClient *conductorRpc.APIClient
+
+	// overrideLeader is set when the conductor is bypassed by an emergency override.
+	overrideLeader atomic.Bool
 }
 
 var _ conductor.SequencerConductor = &ConductorClient{}
@@ -45,6 +49,9 @@ func (c *ConductorClient) initialize() error {
 
 // Leader returns true if this node is the leader sequencer.
 func (c *ConductorClient) Leader(ctx context.Context) (bool, error) {
+	if c.overrideLeader.Load() {
+		return true, nil
+	}
 	if err := c.initialize(); err != nil {
 		return false, err
 	}
@@ -62,6 +69,9 @@ func (c *ConductorClient) Leader(ctx context.Context) (bool, error) {
 
 // CommitUnsafePayload commits an unsafe payload to the conductor log.
 func (c *ConductorClient) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
+	if c.overrideLeader.Load() {
+		return nil
+	}
 	if err := c.initialize(); err != nil {
 		return err
 	}
@@ -78,6 +88,16 @@ func (c *ConductorClient) CommitUnsafePayload(ctx context.Context, payload *eth.
 	return err
 }
 
+// OverrideLeader bypasses the conductor: this node is treated as the leader,
+// and unsafe payloads are no longer committed to the conductor log.
+// The override lasts until the node is restarted.
+func (c *ConductorClient) OverrideLeader(ctx context.Context) error {
+	if !c.overrideLeader.Swap(true) {
+		c.log.Warn("Overriding conductor leadership, the conductor is bypassed until restart")
+	}
+	return nil
+}
+
 func (c *ConductorClient) Close() {
 	if c.apiClient == nil {
 		return
@@ -0,0 +1,28 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestConductorClient_OverrideLeader(t *testing.T) {
+	// The conductor RPC is unreachable, so calls only succeed if they are bypassed by the override.
+	cfg := &Config{ConductorRpc: "http://127.0.0.1:0", ConductorRpcTimeout: time.Second}
+	c := NewConductorClient(cfg, testlog.Logger(t, log.LvlInfo), metrics.NewMetrics(""))
+	defer c.Close()
+	ctx := context.Background()
+
+	require.NoError(t, c.OverrideLeader(ctx))
+	isLeader, err := c.Leader(ctx)
+	require.NoError(t, err)
+	require.True(t, isLeader)
+	require.NoError(t, c.CommitUnsafePayload(ctx, &eth.ExecutionPayloadEnvelope{}))
+}
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -55,6 +57,10 @@ type Config struct {
 
 	Sync sync.Config
 
+	// DASource is the name of the data availability source to derive from, see derive.RegisterDASource.
+	// The default L1 calldata and blobs source is used if empty.
+	DASource string
+
 	// To halt when detecting the node does not support a signaled protocol version
 	// change of the given severity (major/minor/patch). Disabled if empty.
 	RollupHalt string
@@ -65,6 +71,10 @@ type Config struct {
 	// [OPTIONAL] The reth DB path to read receipts from
 	RethDBPath string
 
+	// [OPTIONAL] The path of the database of the L1 block each L2 safe head was derived from.
+	// Disabled if empty.
+	SafeDBPath string
+
 	// Conductor is used to determine this node is the leader sequencer.
 	ConductorEnabled    bool
 	ConductorRpc        string
@@ -75,6 +85,8 @@ type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// AdminJWTSecret, if set, is the secret that requests must be authenticated with to access the admin API.
+	AdminJWTSecret *[32]byte
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -156,6 +168,12 @@ func (cfg *Config) Check() error {
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
+	if cfg.DASource != "" && !slices.Contains(derive.DASourceNames(), cfg.DASource) {
+		return fmt.Errorf("unknown DA source %q, expected one of %v", cfg.DASource, derive.DASourceNames())
+	}
+	if cfg.Sync.DepositsOnly && cfg.Driver.SequencerEnabled {
+		return fmt.Errorf("sequencer must be disabled when only deriving deposits, its blocks would be reorged out")
+	}
 	if cfg.ConductorEnabled {
 		if state, _ := cfg.ConfigPersistence.SequencerState(); state != StateUnset {
 			return fmt.Errorf("config persistence must be disabled when conductor is enabled")
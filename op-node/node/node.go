@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync/atomic"
 	"time"
 
@@ -18,8 +19,10 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/heartbeat"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-node/version"
@@ -27,11 +30,18 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/shutdown"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
 
 var ErrAlreadyClosed = errors.New("node is already closed")
 
+type closableSafeDB interface {
+	derive.SafeHeadListener
+	SafeDBReader
+	io.Closer
+}
+
 type OpNode struct {
 	log        log.Logger
 	appVersion string
@@ -50,6 +60,8 @@ type OpNode struct {
 	tracer    Tracer                // tracer to get events for testing/debugging
 	runCfg    *RuntimeConfig        // runtime configurables
 
+	safeDB closableSafeDB
+
 	rollupHalt string // when to halt the rollup, disabled if empty
 
 	pprofService *oppprof.Service
@@ -154,7 +166,7 @@ func (n *OpNode) initTracer(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
-	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup)
+	l1Node, rpcCfg, err := cfg.L1.Setup(ctx, n.log, &cfg.Rollup, n.metrics.L1Fallback)
 	if err != nil {
 		return fmt.Errorf("failed to get L1 RPC client: %w", err)
 	}
@@ -373,13 +385,28 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 	if cfg.ConductorEnabled {
 		sequencerConductor = NewConductorClient(cfg, n.log, n.metrics)
 	}
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.beacon, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync, sequencerConductor)
+	// Fetch batch data through the metered L1 fetcher, like the rest of derivation.
+	dataSrc, err := derive.NewDASource(cfg.DASource, n.log, &cfg.Rollup, driver.NewMeteredL1Fetcher(n.l1Source, n.metrics), n.beacon)
+	if err != nil {
+		return err
+	}
+	if cfg.SafeDBPath != "" {
+		n.log.Info("Safe head database enabled", "path", cfg.SafeDBPath)
+		safeDB, err := safedb.NewSafeDB(n.log, cfg.SafeDBPath)
+		if err != nil {
+			return fmt.Errorf("failed to create safe head database at %v: %w", cfg.SafeDBPath, err)
+		}
+		n.safeDB = safeDB
+	} else {
+		n.safeDB = safedb.Disabled
+	}
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, dataSrc, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync, sequencerConductor, n.safeDB)
 
 	return nil
 }
 
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
-	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
+	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.safeDB, n.log, n.appVersion, n.metrics)
 	if err != nil {
 		return err
 	}
@@ -440,14 +467,7 @@ func (n *OpNode) initHeartbeat(cfg *Config) {
 }
 
 func (n *OpNode) initPProf(cfg *Config) error {
-	n.pprofService = oppprof.New(
-		cfg.Pprof.ListenEnabled,
-		cfg.Pprof.ListenAddr,
-		cfg.Pprof.ListenPort,
-		cfg.Pprof.ProfileType,
-		cfg.Pprof.ProfileDir,
-		cfg.Pprof.ProfileFilename,
-	)
+	n.pprofService = oppprof.NewFromCLIConfig(cfg.Pprof)
 
 	if err := n.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -602,59 +622,8 @@ func (n *OpNode) Stop(ctx context.Context) error {
 
 	var result *multierror.Error
 
-	if n.server != nil {
-		if err := n.server.Stop(ctx); err != nil {
-			result = multierror.Append(result, fmt.Errorf("failed to close RPC server: %w", err))
-		}
-	}
-	if n.p2pNode != nil {
-		if err := n.p2pNode.Close(); err != nil {
-			result = multierror.Append(result, fmt.Errorf("failed to close p2p node: %w", err))
-		}
-	}
-	if n.p2pSigner != nil {
-		if err := n.p2pSigner.Close(); err != nil {
-			result = multierror.Append(result, fmt.Errorf("failed to close p2p signer: %w", err))
-		}
-	}
-
-	if n.resourcesClose != nil {
-		n.resourcesClose()
-	}
-
-	// stop L1 heads feed
-	if n.l1HeadsSub != nil {
-		n.l1HeadsSub.Unsubscribe()
-	}
-	// stop polling for L1 safe-head changes
-	if n.l1SafeSub != nil {
-		n.l1SafeSub.Unsubscribe()
-	}
-	// stop polling for L1 finalized-head changes
-	if n.l1FinalizedSub != nil {
-		n.l1FinalizedSub.Unsubscribe()
-	}
-
-	// close L2 driver
-	if n.l2Driver != nil {
-		if err := n.l2Driver.Close(); err != nil {
-			result = multierror.Append(result, fmt.Errorf("failed to close L2 engine driver cleanly: %w", err))
-		}
-	}
-
-	// Wait for the runtime config loader to be done using the data sources before closing them
-	if n.runtimeConfigReloaderDone != nil {
-		<-n.runtimeConfigReloaderDone
-	}
-
-	// close L2 engine RPC client
-	if n.l2Source != nil {
-		n.l2Source.Close()
-	}
-
-	// close L1 data source
-	if n.l1Source != nil {
-		n.l1Source.Close()
+	if err := n.shutdownManager().Stop(ctx); err != nil {
+		result = multierror.Append(result, err)
 	}
 
 	if result == nil { // mark as closed if we successfully fully closed
@@ -687,6 +656,60 @@ func (n *OpNode) Stop(ctx context.Context) error {
 	return result.ErrorOrNil()
 }
 
+// shutdownManager registers the initialized subsystems of the node, after the subsystems they use.
+// Metrics and pprof are not registered, as they are only closed after idling on a halt.
+func (n *OpNode) shutdownManager() *shutdown.Manager {
+	m := shutdown.NewManager(n.log)
+	if n.l1Source != nil {
+		m.Register("l1-source", shutdown.Closer(n.l1Source.Close))
+	}
+	if n.l2Source != nil {
+		m.Register("l2-source", shutdown.Closer(n.l2Source.Close))
+	}
+	if n.p2pSigner != nil {
+		m.Register("p2p-signer", shutdown.ErrCloser(n.p2pSigner.Close))
+	}
+	if n.resourcesClose != nil {
+		m.Register("resources", func(ctx context.Context) error {
+			n.resourcesClose()
+			// Wait for the runtime config loader to be done using the data sources before closing them
+			if n.runtimeConfigReloaderDone != nil {
+				<-n.runtimeConfigReloaderDone
+			}
+			return nil
+		}, shutdown.DependsOn("l1-source", "l2-source"))
+	}
+	if n.safeDB != nil {
+		m.Register("safe-db", shutdown.ErrCloser(n.safeDB.Close))
+	}
+	if n.l2Driver != nil {
+		m.Register("l2-driver", shutdown.ErrCloser(n.l2Driver.Close), shutdown.DependsOn("l1-source", "l2-source", "p2p-signer", "safe-db"))
+	}
+	// L1 subscriptions feed new L1 blocks to the driver.
+	m.Register("l1-subscriptions", shutdown.Closer(func() {
+		// stop L1 heads feed
+		if n.l1HeadsSub != nil {
+			n.l1HeadsSub.Unsubscribe()
+		}
+		// stop polling for L1 safe-head changes
+		if n.l1SafeSub != nil {
+			n.l1SafeSub.Unsubscribe()
+		}
+		// stop polling for L1 finalized-head changes
+		if n.l1FinalizedSub != nil {
+			n.l1FinalizedSub.Unsubscribe()
+		}
+	}), shutdown.DependsOn("l2-driver", "l1-source"))
+	if n.p2pNode != nil {
+		// The p2p node runs on the node resources, and passes gossiped payloads to the driver.
+		m.Register("p2p", shutdown.ErrCloser(n.p2pNode.Close), shutdown.DependsOn("resources", "l2-driver", "l2-source", "p2p-signer"))
+	}
+	if n.server != nil {
+		m.Register("rpc-server", n.server.Stop, shutdown.DependsOn("l2-driver", "p2p", "l1-source", "l2-source", "safe-db"))
+	}
+	return m
+}
+
 func (n *OpNode) Stopped() bool {
 	return n.closed.Load()
 }
@@ -0,0 +1,29 @@
+package safedb
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type DisabledDB struct{}
+
+// Disabled is used when the safe head database is not configured: updates are ignored, and queries fail.
+var Disabled = &DisabledDB{}
+
+func (d *DisabledDB) SafeHeadUpdated(_ eth.L2BlockRef, _ eth.BlockID) error {
+	return nil
+}
+
+func (d *DisabledDB) SafeHeadReset(_ eth.L2BlockRef) error {
+	return nil
+}
+
+func (d *DisabledDB) SafeHeadAtL1(_ context.Context, _ uint64) (l1 eth.BlockID, safeHead eth.BlockID, err error) {
+	err = ErrNotEnabled
+	return
+}
+
+func (d *DisabledDB) Close() error {
+	return nil
+}
@@ -0,0 +1,174 @@
+// Package safedb records, for every L1 block, the L2 safe head that was derived from the L1 chain up to that block.
+package safedb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrNotEnabled = errors.New("safe head database not enabled")
+)
+
+const (
+	// keyPrefixSafeByL1BlockNum is the prefix of the keys of the safe head entries, followed by the L1 block number.
+	keyPrefixSafeByL1BlockNum byte = 0
+)
+
+type SafeDB struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+}
+
+func safeByL1BlockNumKey(l1BlockNum uint64) []byte {
+	key := make([]byte, 9)
+	key[0] = keyPrefixSafeByL1BlockNum
+	binary.BigEndian.PutUint64(key[1:], l1BlockNum)
+	return key
+}
+
+func safeByL1BlockNumValue(l1 eth.BlockID, l2 eth.BlockID) []byte {
+	val := make([]byte, 0, 72)
+	val = append(val, l1.Hash.Bytes()...)
+	val = append(val, l2.Hash.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, l2.Number)
+	return val
+}
+
+func decodeSafeByL1BlockNum(key []byte, val []byte) (l1 eth.BlockID, l2 eth.BlockID, err error) {
+	if len(key) != 9 || len(val) != 72 || key[0] != keyPrefixSafeByL1BlockNum {
+		err = fmt.Errorf("invalid safe head entry, key: %x, value: %x", key, val)
+		return
+	}
+	l1.Number = binary.BigEndian.Uint64(key[1:])
+	copy(l1.Hash[:], val[:32])
+	copy(l2.Hash[:], val[32:64])
+	l2.Number = binary.BigEndian.Uint64(val[64:])
+	return
+}
+
+// pebbleLogger adapts the logger to the pebble logger, to not write the database logs to stderr.
+type pebbleLogger struct {
+	log log.Logger
+}
+
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	l.log.Debug(fmt.Sprintf(format, args...))
+}
+
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	l.log.Crit(fmt.Sprintf(format, args...))
+}
+
+func NewSafeDB(logger log.Logger, path string) (*SafeDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{
+		Logger: &pebbleLogger{log: logger.New("db", "safedb")},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open safe head database at %s: %w", path, err)
+	}
+	return &SafeDB{
+		log:       logger,
+		db:        db,
+		writeOpts: pebble.Sync,
+	}, nil
+}
+
+// SafeHeadUpdated records that the given L2 block is the safe head after processing all the data up to the given L1 block.
+func (d *SafeDB) SafeHeadUpdated(safeHead eth.L2BlockRef, l1Head eth.BlockID) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.log.Debug("Record safe head", "l2", safeHead.ID(), "l1", l1Head)
+	if err := d.db.Set(safeByL1BlockNumKey(l1Head.Number), safeByL1BlockNumValue(l1Head, safeHead.ID()), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record safe head update: %w", err)
+	}
+	return nil
+}
+
+// SafeHeadReset removes the entries for the safe head and any later L2 blocks,
+// as derivation is restarting from the given safe head, and the L1 blocks they were recorded at may have been reorged out.
+func (d *SafeDB) SafeHeadReset(safeHead eth.L2BlockRef) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	iter, err := d.db.NewIter(&pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(safeHead.L1Origin.Number),
+		UpperBound: []byte{keyPrefixSafeByL1BlockNum + 1},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create safe head iterator: %w", err)
+	}
+	defer iter.Close()
+	// The safe head is derived from the L1 chain at or after its L1 origin, so earlier entries are never affected.
+	for valid := iter.First(); valid; valid = iter.Next() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return fmt.Errorf("failed to read safe head entry: %w", err)
+		}
+		l1Block, l2Block, err := decodeSafeByL1BlockNum(iter.Key(), val)
+		if err != nil {
+			return err
+		}
+		if l2Block.Number >= safeHead.Number {
+			d.log.Info("Truncating safe head entries after reset", "l1", l1Block, "l2", l2Block, "reset_to", safeHead)
+			// The key is only valid until the iterator moves, so copy it.
+			if err := d.db.DeleteRange(slices.Clone(iter.Key()), []byte{keyPrefixSafeByL1BlockNum + 1}, d.writeOpts); err != nil {
+				return fmt.Errorf("failed to truncate safe head entries: %w", err)
+			}
+			return nil
+		}
+	}
+	return iter.Error()
+}
+
+// SafeHeadAtL1 returns the L2 safe head that was derived from the L1 chain up to and including the given L1 block number,
+// along with the L1 block the safe head was recorded at. That L1 block may be older than the requested block number,
+// if the safe head did not change after it.
+func (d *SafeDB) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64) (l1Block eth.BlockID, safeHead eth.BlockID, err error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	// Iterate over all entries up to and including the requested L1 block.
+	upperBound := []byte{keyPrefixSafeByL1BlockNum + 1}
+	if l1BlockNum < math.MaxUint64 {
+		upperBound = safeByL1BlockNumKey(l1BlockNum + 1)
+	}
+	iter, err := d.db.NewIterWithContext(ctx, &pebble.IterOptions{
+		LowerBound: safeByL1BlockNumKey(0),
+		UpperBound: upperBound,
+	})
+	if err != nil {
+		return
+	}
+	defer iter.Close()
+	if valid := iter.Last(); !valid {
+		err = ErrNotFound
+		return
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return
+	}
+	l1Block, safeHead, err = decodeSafeByL1BlockNum(iter.Key(), val)
+	return
+}
+
+func (d *SafeDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	return d.db.Close()
+}
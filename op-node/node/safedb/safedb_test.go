@@ -0,0 +1,107 @@
+package safedb
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func l1ID(n uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0x01, byte(n)}, Number: n}
+}
+
+func l2Ref(n uint64, l1Origin uint64) eth.L2BlockRef {
+	return eth.L2BlockRef{Hash: common.Hash{0x02, byte(n)}, Number: n, L1Origin: l1ID(l1Origin)}
+}
+
+func TestStoreSafeHeads(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, _, err = db.SafeHeadAtL1(ctx, 100)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(10, 3), l1ID(5)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(20, 6), l1ID(8)))
+
+	verify := func(db *SafeDB) {
+		_, _, err := db.SafeHeadAtL1(ctx, 4)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		l1, safeHead, err := db.SafeHeadAtL1(ctx, 5)
+		require.NoError(t, err)
+		require.Equal(t, l1ID(5), l1)
+		require.Equal(t, l2Ref(10, 3).ID(), safeHead)
+
+		// no update at this L1 block, so the safe head recorded at an earlier L1 block applies
+		l1, safeHead, err = db.SafeHeadAtL1(ctx, 7)
+		require.NoError(t, err)
+		require.Equal(t, l1ID(5), l1)
+		require.Equal(t, l2Ref(10, 3).ID(), safeHead)
+
+		l1, safeHead, err = db.SafeHeadAtL1(ctx, math.MaxUint64)
+		require.NoError(t, err)
+		require.Equal(t, l1ID(8), l1)
+		require.Equal(t, l2Ref(20, 6).ID(), safeHead)
+	}
+	verify(db)
+
+	// the entries persist across restarts
+	require.NoError(t, db.Close())
+	db, err = NewSafeDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	verify(db)
+}
+
+func TestSafeHeadReset(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewSafeDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(10, 3), l1ID(5)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(20, 6), l1ID(8)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(30, 9), l1ID(11)))
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(40, 12), l1ID(14)))
+
+	// reset to a safe head between the recorded ones: entries of later safe heads are removed
+	require.NoError(t, db.SafeHeadReset(l2Ref(25, 7)))
+
+	l1, safeHead, err := db.SafeHeadAtL1(ctx, 20)
+	require.NoError(t, err)
+	require.Equal(t, l1ID(8), l1)
+	require.Equal(t, l2Ref(20, 6).ID(), safeHead)
+
+	// reset to a recorded safe head: its entry may be at a reorged L1 block, so it is removed too
+	require.NoError(t, db.SafeHeadReset(l2Ref(20, 6)))
+	l1, safeHead, err = db.SafeHeadAtL1(ctx, 20)
+	require.NoError(t, err)
+	require.Equal(t, l1ID(5), l1)
+	require.Equal(t, l2Ref(10, 3).ID(), safeHead)
+
+	// derivation continues after the reset
+	require.NoError(t, db.SafeHeadUpdated(l2Ref(21, 7), l1ID(9)))
+	l1, safeHead, err = db.SafeHeadAtL1(ctx, 20)
+	require.NoError(t, err)
+	require.Equal(t, l1ID(9), l1)
+	require.Equal(t, l2Ref(21, 7).ID(), safeHead)
+}
+
+func TestDisabled(t *testing.T) {
+	require.NoError(t, Disabled.SafeHeadUpdated(l2Ref(10, 3), l1ID(5)))
+	require.NoError(t, Disabled.SafeHeadReset(l2Ref(10, 3)))
+	_, _, err := Disabled.SafeHeadAtL1(context.Background(), 5)
+	require.ErrorIs(t, err, ErrNotEnabled)
+}
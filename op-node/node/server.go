@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum/go-ethereum/log"
@@ -21,15 +22,16 @@ import (
 type rpcServer struct {
 	endpoint   string
 	apis       []rpc.API
+	jwtSecret  *[32]byte
 	httpServer *ophttp.HTTPServer
 	appVersion string
 	log        log.Logger
 	sources.L2Client
 }
 
-func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
-	api := NewNodeAPI(rollupCfg, l2Client, dr, log.New("rpc", "node"), m)
-	// TODO: extend RPC config with options for WS, IPC and HTTP RPC connections
+func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, safeDB SafeDBReader, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
+	api := NewNodeAPI(rollupCfg, l2Client, dr, safeDB, log.New("rpc", "node"), m)
+	// TODO: extend RPC config with options for IPC RPC connections
 	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
 	r := &rpcServer{
 		endpoint: endpoint,
@@ -38,6 +40,7 @@ func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Conf
 			Service:       api,
 			Authenticated: false,
 		}},
+		jwtSecret:  rpcCfg.AdminJWTSecret,
 		appVersion: appVersion,
 		log:        log,
 	}
@@ -49,7 +52,7 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 		Namespace:     "admin",
 		Version:       "",
 		Service:       api,
-		Authenticated: false,
+		Authenticated: s.jwtSecret != nil,
 	})
 }
 
@@ -63,19 +66,28 @@ func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 }
 
 func (s *rpcServer) Start() error {
-	srv := rpc.NewServer()
-	if err := node.RegisterApis(s.apis, nil, srv); err != nil {
+	var unauthenticated []rpc.API
+	for _, api := range s.apis {
+		if !api.Authenticated {
+			unauthenticated = append(unauthenticated, api)
+		}
+	}
+	handler, err := rpcHandler(unauthenticated, nil)
+	if err != nil {
 		return err
 	}
-
-	// The CORS and VHosts arguments below must be set in order for
-	// other services to connect to the opnode. VHosts in particular
-	// defaults to localhost, which will prevent containers from
-	// calling into the opnode without an "invalid host" error.
-	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	// Authenticated APIs are served on the same endpoint, to requests that carry a JWT.
+	// These requests are served by a separate RPC server that rejects them if the JWT is invalid.
+	if s.jwtSecret != nil {
+		authHandler, err := rpcHandler(s.apis, s.jwtSecret[:])
+		if err != nil {
+			return err
+		}
+		handler = withAuthenticated(handler, authHandler)
+	}
 
 	mux := http.NewServeMux()
-	mux.Handle("/", nodeHandler)
+	mux.Handle("/", handler)
 	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
 
 	hs, err := ophttp.StartHTTPServer(s.endpoint, mux)
@@ -94,6 +106,46 @@ func (r *rpcServer) Addr() net.Addr {
 	return r.httpServer.Addr()
 }
 
+// rpcHandler serves the APIs over HTTP and websockets, authenticating requests with the JWT secret, if not nil.
+func rpcHandler(apis []rpc.API, jwtSecret []byte) (http.Handler, error) {
+	srv := rpc.NewServer()
+	if err := node.RegisterApis(apis, nil, srv); err != nil {
+		return nil, err
+	}
+
+	// The CORS and VHosts arguments below must be set in order for
+	// other services to connect to the opnode. VHosts in particular
+	// defaults to localhost, which will prevent containers from
+	// calling into the opnode without an "invalid host" error.
+	httpHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, jwtSecret)
+	// Websocket connections are served on the same endpoint, for subscriptions.
+	wsHandler := node.NewWSHandlerStack(srv.WebsocketHandler([]string{"*"}), jwtSecret)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			wsHandler.ServeHTTP(w, r)
+			return
+		}
+		httpHandler.ServeHTTP(w, r)
+	}), nil
+}
+
+// withAuthenticated routes requests with an Authorization header to the authenticated handler.
+func withAuthenticated(handler, authHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			authHandler.ServeHTTP(w, r)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
 func healthzHandler(appVersion string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(appVersion))
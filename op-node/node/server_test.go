@@ -5,19 +5,25 @@ import (
 	"encoding/json"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	gethnode "github.com/ethereum/go-ethereum/node"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/version"
 	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -100,7 +106,7 @@ func TestOutputAtBlock(t *testing.T) {
 	status := randomSyncStatus(rand.New(rand.NewSource(123)))
 	drClient.ExpectBlockRefWithStatus(0xdcdc89, ref, status, nil)
 
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
 	require.NoError(t, err)
 	require.NoError(t, server.Start())
 	defer func() {
@@ -134,7 +140,7 @@ func TestVersion(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer func() {
@@ -150,6 +156,60 @@ func TestVersion(t *testing.T) {
 	assert.Equal(t, version.Version+"-"+version.Meta, out)
 }
 
+func TestAuthenticatedAdminAPI(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	drClient.Mock.On("SequencerActive").Return(true)
+	secret := [32]byte{1, 2, 3}
+	rpcCfg := &RPCConfig{
+		ListenAddr:     "localhost",
+		ListenPort:     0,
+		EnableAdmin:    true,
+		AdminJWTSecret: &secret,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableAdminAPI(NewAdminAPI(drClient, metrics.NoopMetrics, log))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+	endpoint := "http://" + server.Addr().String()
+
+	dial := func(t *testing.T, opts ...gethrpc.ClientOption) *gethrpc.Client {
+		client, err := gethrpc.DialOptions(context.Background(), endpoint, opts...)
+		require.NoError(t, err)
+		t.Cleanup(client.Close)
+		return client
+	}
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		client := dial(t)
+		var active bool
+		err := client.CallContext(context.Background(), &active, "admin_sequencerActive")
+		require.ErrorContains(t, err, "the method admin_sequencerActive does not exist")
+		var out string
+		require.NoError(t, client.CallContext(context.Background(), &out, "optimism_version"), "other APIs are served without JWT")
+	})
+
+	t.Run("InvalidJWT", func(t *testing.T) {
+		client := dial(t, gethrpc.WithHTTPAuth(gethnode.NewJWTAuth([32]byte{4, 5, 6})))
+		var active bool
+		err := client.CallContext(context.Background(), &active, "admin_sequencerActive")
+		require.ErrorContains(t, err, "401")
+	})
+
+	t.Run("Authenticated", func(t *testing.T) {
+		client := dial(t, gethrpc.WithHTTPAuth(gethnode.NewJWTAuth(secret)))
+		var active bool
+		require.NoError(t, client.CallContext(context.Background(), &active, "admin_sequencerActive"))
+		require.True(t, active)
+		var out string
+		require.NoError(t, client.CallContext(context.Background(), &out, "optimism_version"), "other APIs are served with JWT")
+	})
+}
+
 func randomSyncStatus(rng *rand.Rand) *eth.SyncStatus {
 	return &eth.SyncStatus{
 		CurrentL1:          testutils.RandomBlockRef(rng),
@@ -179,7 +239,7 @@ func TestSyncStatus(t *testing.T) {
 	rollupCfg := &rollup.Config{
 		// ignore other rollup config info in this test
 	}
-	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
 	assert.NoError(t, err)
 	assert.NoError(t, server.Start())
 	defer func() {
@@ -195,8 +255,103 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestSafeHeadAtL1Block(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	db, err := safedb.NewSafeDB(log, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	l1Block := eth.BlockID{Hash: common.Hash{0x01}, Number: 10}
+	safeHead := eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 20}
+	require.NoError(t, db.SafeHeadUpdated(safeHead, l1Block))
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, l2Client, drClient, db, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client)
+
+	out, err := rollupClient.SafeHeadAtL1Block(context.Background(), 15)
+	require.NoError(t, err)
+	require.Equal(t, &eth.SafeHeadResponse{L1Block: l1Block, SafeHead: safeHead.ID()}, out)
+
+	_, err = rollupClient.SafeHeadAtL1Block(context.Background(), 9)
+	require.ErrorContains(t, err, safedb.ErrNotFound.Error())
+}
+
+func TestPayloadAttributesSubscription(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	var feed event.Feed
+	drClient.On("SubscribePayloadAttributes", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		drClient.sub = feed.Subscribe(args[0].(chan<- *eth.PayloadAttributesEvent))
+	})
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, l2Client, drClient, safedb.Disabled, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := gethrpc.DialContext(context.Background(), "ws://"+server.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	events := make(chan *eth.PayloadAttributesEvent, 1)
+	sub, err := client.Subscribe(context.Background(), "optimism", events, "payloadAttributes")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	gasLimit := eth.Uint64Quantity(30_000_000)
+	ev := &eth.PayloadAttributesEvent{
+		ParentBlock: eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 1},
+		L1Origin:    eth.BlockID{Hash: common.Hash{0x02}, Number: 2},
+		Attributes: &eth.PayloadAttributes{
+			Timestamp:    100,
+			Transactions: []eth.Data{{0x7e, 0x01}},
+			NoTxPool:     true,
+			GasLimit:     &gasLimit,
+		},
+	}
+	require.Eventually(t, func() bool {
+		return feed.Send(ev) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	select {
+	case got := <-events:
+		require.Equal(t, ev, got)
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for payload attributes")
+	}
+
+	// The HTTP endpoint does not support subscriptions.
+	httpClient, err := gethrpc.DialContext(context.Background(), "http://"+server.Addr().String())
+	require.NoError(t, err)
+	defer httpClient.Close()
+	_, err = httpClient.Subscribe(context.Background(), "optimism", events, "payloadAttributes")
+	require.ErrorIs(t, err, gethrpc.ErrNotificationsUnsupported)
+}
+
 type mockDriverClient struct {
 	mock.Mock
+	sub event.Subscription
 }
 
 func (c *mockDriverClient) ExpectBlockRefWithStatus(num uint64, ref eth.L2BlockRef, status *eth.SyncStatus, err error) {
@@ -216,6 +371,10 @@ func (c *mockDriverClient) ResetDerivationPipeline(ctx context.Context) error {
 	return c.Mock.MethodCalled("ResetDerivationPipeline").Get(0).(error)
 }
 
+func (c *mockDriverClient) ResetUnsafeHead(ctx context.Context, blockHash common.Hash) error {
+	return c.Mock.MethodCalled("ResetUnsafeHead", blockHash).Get(0).(error)
+}
+
 func (c *mockDriverClient) StartSequencer(ctx context.Context, blockHash common.Hash) error {
 	return c.Mock.MethodCalled("StartSequencer").Get(0).(error)
 }
@@ -228,6 +387,19 @@ func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
 
+func (c *mockDriverClient) DerivationPipelineState(ctx context.Context) (*eth.DerivationPipelineState, error) {
+	return c.Mock.MethodCalled("DerivationPipelineState").Get(0).(*eth.DerivationPipelineState), nil
+}
+
+func (c *mockDriverClient) OverrideLeader(ctx context.Context) error {
+	return c.Mock.MethodCalled("OverrideLeader").Get(0).(error)
+}
+
+func (c *mockDriverClient) SubscribePayloadAttributes(ch chan<- *eth.PayloadAttributesEvent) event.Subscription {
+	c.Mock.MethodCalled("SubscribePayloadAttributes", ch)
+	return c.sub
+}
+
 func (c *mockDriverClient) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
 	return c.Mock.MethodCalled("OnUnsafeL2Payload").Get(0).(error)
 }
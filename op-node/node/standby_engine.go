@@ -0,0 +1,138 @@
+package node
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// standbyEngineQueueSize is the number of engine calls buffered for the standby engine.
+	// If the standby falls further behind, calls are dropped, and the standby has to sync the gap itself.
+	standbyEngineQueueSize = 64
+	standbyEngineTimeout   = 10 * time.Second
+)
+
+type standbyEngineCall struct {
+	method string
+	args   []any
+}
+
+// standbyEngineRPC wraps the RPC client of the primary L2 engine, and forwards the forkchoice updates
+// and new payloads that the primary engine accepted (VALID or ACCEPTED) to a standby engine,
+// to keep the standby execution client in sync, so failing over to it does not require a long resync.
+//
+// The calls to the standby are fire-and-forget: they are made in order in the background,
+// and their results are ignored, so the standby can never slow down or fail the primary engine.
+// Payload attributes are stripped from forkchoice updates, so the standby does not build blocks.
+type standbyEngineRPC struct {
+	client.RPC
+	standby client.RPC
+	log     log.Logger
+
+	calls  chan standbyEngineCall
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newStandbyEngineRPC(log log.Logger, primary client.RPC, standby client.RPC) *standbyEngineRPC {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &standbyEngineRPC{
+		RPC:     primary,
+		standby: standby,
+		log:     log,
+		calls:   make(chan standbyEngineCall, standbyEngineQueueSize),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	s.wg.Add(1)
+	go s.forwardLoop()
+	return s
+}
+
+func (s *standbyEngineRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	err := s.RPC.CallContext(ctx, result, method, args...)
+	if err != nil {
+		return err
+	}
+	if !acceptedByPrimary(result) {
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(method, "engine_forkchoiceUpdated"):
+		if len(args) > 1 {
+			args = []any{args[0], nil}
+		}
+	case strings.HasPrefix(method, "engine_newPayload"):
+	default:
+		return nil
+	}
+	select {
+	case s.calls <- standbyEngineCall{method: method, args: args}:
+	default:
+		s.log.Warn("Standby engine is falling behind, dropping engine call", "method", method)
+	}
+	return nil
+}
+
+// acceptedByPrimary returns true if the engine call result reports a VALID or ACCEPTED payload status.
+// Calls that the primary engine rejected or could not process yet (INVALID, SYNCING) are not forwarded,
+// so the standby engine never follows a chain that the primary engine did not accept.
+func acceptedByPrimary(result any) bool {
+	var status eth.ExecutePayloadStatus
+	switch r := result.(type) {
+	case *eth.PayloadStatusV1:
+		if r == nil {
+			return false
+		}
+		status = r.Status
+	case *eth.ForkchoiceUpdatedResult:
+		if r == nil {
+			return false
+		}
+		status = r.PayloadStatus.Status
+	default:
+		return false
+	}
+	return status == eth.ExecutionValid || status == eth.ExecutionAccepted
+}
+
+func (s *standbyEngineRPC) forwardLoop() {
+	defer s.wg.Done()
+	healthy := true
+	for {
+		select {
+		case call := <-s.calls:
+			ctx, cancel := context.WithTimeout(s.ctx, standbyEngineTimeout)
+			var result any
+			err := s.standby.CallContext(ctx, &result, call.method, call.args...)
+			cancel()
+			if err != nil {
+				if healthy {
+					s.log.Warn("Failed to forward engine call to standby engine", "method", call.method, "err", err)
+				} else {
+					s.log.Debug("Failed to forward engine call to standby engine", "method", call.method, "err", err)
+				}
+			} else if !healthy {
+				s.log.Info("Standby engine is accepting engine calls again")
+			}
+			healthy = err == nil
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *standbyEngineRPC) Close() {
+	s.cancel()
+	s.wg.Wait()
+	s.standby.Close()
+	s.RPC.Close()
+}
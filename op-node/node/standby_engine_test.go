@@ -0,0 +1,85 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type recordingRPC struct {
+	client.RPC
+	err    error
+	status eth.ExecutePayloadStatus
+	calls  chan standbyEngineCall
+}
+
+func (r *recordingRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	r.calls <- standbyEngineCall{method: method, args: args}
+	switch res := result.(type) {
+	case *eth.PayloadStatusV1:
+		res.Status = r.status
+	case *eth.ForkchoiceUpdatedResult:
+		res.PayloadStatus.Status = r.status
+	}
+	return r.err
+}
+
+func (r *recordingRPC) Close() {}
+
+func TestStandbyEngineRPC(t *testing.T) {
+	primary := &recordingRPC{calls: make(chan standbyEngineCall, 10), status: eth.ExecutionValid}
+	standby := &recordingRPC{calls: make(chan standbyEngineCall, 10), err: errors.New("standby offline")}
+	s := newStandbyEngineRPC(testlog.Logger(t, log.LvlInfo), primary, standby)
+	defer s.Close()
+	ctx := context.Background()
+
+	fc := &eth.ForkchoiceState{HeadBlockHash: [32]byte{1}}
+	attrs := &eth.PayloadAttributes{}
+	require.NoError(t, s.CallContext(ctx, new(eth.PayloadStatusV1), "engine_newPayloadV3", "payload"))
+	require.NoError(t, s.CallContext(ctx, new(eth.ForkchoiceUpdatedResult), "engine_forkchoiceUpdatedV3", fc, attrs))
+	require.NoError(t, s.CallContext(ctx, new(eth.PayloadStatusV1), "eth_getBlockByNumber", "latest", false))
+
+	for _, method := range []string{"engine_newPayloadV3", "engine_forkchoiceUpdatedV3", "eth_getBlockByNumber"} {
+		call := <-primary.calls
+		require.Equal(t, method, call.method)
+	}
+	call := <-standby.calls
+	require.Equal(t, "engine_newPayloadV3", call.method)
+	require.Equal(t, []any{"payload"}, call.args)
+	call = <-standby.calls
+	require.Equal(t, "engine_forkchoiceUpdatedV3", call.method)
+	require.Equal(t, []any{fc, nil}, call.args, "payload attributes must not be forwarded")
+	select {
+	case call := <-standby.calls:
+		t.Fatalf("unexpected call to standby engine: %s", call.method)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Calls the primary engine did not accept are not forwarded
+	for _, status := range []eth.ExecutePayloadStatus{eth.ExecutionInvalid, eth.ExecutionSyncing} {
+		primary.status = status
+		require.NoError(t, s.CallContext(ctx, new(eth.PayloadStatusV1), "engine_newPayloadV3", "payload"))
+		require.NoError(t, s.CallContext(ctx, new(eth.ForkchoiceUpdatedResult), "engine_forkchoiceUpdatedV3", fc, nil))
+		<-primary.calls
+		<-primary.calls
+	}
+
+	// Calls failed by the primary engine are not forwarded
+	primary.status = eth.ExecutionValid
+	primary.err = errors.New("invalid payload")
+	require.ErrorIs(t, s.CallContext(ctx, new(eth.PayloadStatusV1), "engine_newPayloadV3", "payload"), primary.err)
+	<-primary.calls
+	select {
+	case call := <-standby.calls:
+		t.Fatalf("unexpected call to standby engine: %s", call.method)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
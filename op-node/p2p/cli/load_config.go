@@ -16,6 +16,7 @@ import (
 	leveldb "github.com/ipfs/go-ds-leveldb"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/multiformats/go-multiaddr"
+	"golang.org/x/time/rate"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
@@ -65,6 +66,14 @@ func NewConfig(ctx *cli.Context, rollupCfg *rollup.Config) (*p2p.Config, error)
 	}
 
 	conf.EnableReqRespSync = ctx.Bool(flags.SyncReqRespName)
+	conf.SyncServer = p2p.ReqRespServerConfig{
+		GlobalRateLimit:           rate.Limit(ctx.Float64(flags.SyncServerGlobalRateLimitName)),
+		GlobalBurst:               int(ctx.Uint(flags.SyncServerGlobalBurstName)),
+		PeerRateLimit:             rate.Limit(ctx.Float64(flags.SyncServerPeerRateLimitName)),
+		PeerBurst:                 int(ctx.Uint(flags.SyncServerPeerBurstName)),
+		MaxConcurrentRequests:     int(ctx.Uint(flags.SyncServerMaxConcurrentName)),
+		MaxPeerConcurrentRequests: int(ctx.Uint(flags.SyncServerMaxPeerConcurrentName)),
+	}
 
 	return conf, nil
 }
@@ -218,6 +227,7 @@ func loadLibp2pOpts(conf *p2p.Config, ctx *cli.Context) error {
 		}
 		conf.StaticPeers = append(conf.StaticPeers, a)
 	}
+	conf.StaticPeersOnly = ctx.Bool(flags.StaticPeersOnlyName)
 
 	for _, v := range strings.Split(ctx.String(flags.HostMuxName), ",") {
 		v = strings.ToLower(strings.TrimSpace(v))
@@ -1,22 +1,26 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 )
 
-// TODO: implement remote signer setup (config to authenticated endpoint)
-// and remote signer itself (e.g. a open http client to make signing requests)
-
 // LoadSignerSetup loads a configuration for a Signer to be set up later
-func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
+func LoadSignerSetup(ctx *cli.Context, logger log.Logger) (p2p.SignerSetup, error) {
 	key := ctx.String(flags.SequencerP2PKeyName)
+	signerCfg := opsigner.ReadCLIConfig(ctx)
+	if key != "" && signerCfg.Enabled() {
+		return nil, errors.New("cannot specify both a p2p sequencer key and a remote signer")
+	}
 	if key != "" {
 		// Mnemonics are bad because they leak *all* keys when they leak.
 		// Unencrypted keys from file are bad because they are easy to leak (and we are not checking file permissions).
@@ -28,7 +32,12 @@ func LoadSignerSetup(ctx *cli.Context) (p2p.SignerSetup, error) {
 		return &p2p.PreparedSigner{Signer: p2p.NewLocalSigner(priv)}, nil
 	}
 
-	// TODO: create remote signer
+	if signerCfg.Enabled() {
+		if err := signerCfg.Check(); err != nil {
+			return nil, fmt.Errorf("invalid remote signer config: %w", err)
+		}
+		return &p2p.RemoteSignerSetup{Log: logger, Config: signerCfg}, nil
+	}
 
 	return nil, nil
 }
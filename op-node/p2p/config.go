@@ -34,6 +34,7 @@ var DefaultBootnodes = []*enode.Node{
 type HostMetrics interface {
 	gating.UnbanMetrics
 	gating.ConnectionGaterMetrics
+	StaticPeerMetrics
 }
 
 // SetupP2P provides a host and discovery service for usage in the rollup node.
@@ -50,6 +51,7 @@ type SetupP2P interface {
 	BanDuration() time.Duration
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
+	SyncServerConfig() ReqRespServerConfig
 }
 
 // ScoringParams defines the various types of peer scoring parameters.
@@ -88,6 +90,8 @@ type Config struct {
 	NetRestrict      *netutil.Netlist
 
 	StaticPeers []core.Multiaddr
+	// StaticPeersOnly disables discovery, and only allows connections with the static peers.
+	StaticPeersOnly bool
 
 	HostMux             []libp2p.Option
 	HostSecurity        []libp2p.Option
@@ -118,6 +122,8 @@ type Config struct {
 	Store ds.Batching
 
 	EnableReqRespSync bool
+	// SyncServer configures the limits of serving req-resp sync requests to peers.
+	SyncServer ReqRespServerConfig
 }
 
 func DefaultConnManager(conf *Config) (connmgr.ConnManager, error) {
@@ -160,6 +166,10 @@ func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
 
+func (conf *Config) SyncServerConfig() ReqRespServerConfig {
+	return conf.SyncServer
+}
+
 const maxMeshParam = 1000
 
 func (conf *Config) Check() error {
@@ -169,7 +179,10 @@ func (conf *Config) Check() error {
 	if conf.Store == nil {
 		return errors.New("p2p requires a persistent or in-memory peerstore, but found none")
 	}
-	if !conf.NoDiscovery {
+	if conf.StaticPeersOnly && len(conf.StaticPeers) == 0 {
+		return errors.New("static peers only mode requires static peers")
+	}
+	if !conf.NoDiscovery && !conf.StaticPeersOnly {
 		if conf.DiscoveryDB == nil {
 			return errors.New("discovery requires a persistent or in-memory discv5 db, but found none")
 		}
@@ -189,5 +202,8 @@ func (conf *Config) Check() error {
 	if conf.MeshDLazy <= 0 || conf.MeshDLazy > maxMeshParam {
 		return fmt.Errorf("mesh Dlazy param must not be 0 or exceed %d, but got %d", maxMeshParam, conf.MeshDLazy)
 	}
+	if err := conf.SyncServer.Check(); err != nil {
+		return fmt.Errorf("invalid sync server config: %w", err)
+	}
 	return nil
 }
@@ -52,7 +52,7 @@ const (
 )
 
 func (conf *Config) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16) (*enode.LocalNode, *discover.UDPv5, error) {
-	if conf.NoDiscovery {
+	if conf.NoDiscovery || conf.StaticPeersOnly {
 		return nil, nil, nil
 	}
 	priv := (*decredSecp.PrivateKey)(conf.Priv).ToECDSA()
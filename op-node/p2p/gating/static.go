@@ -0,0 +1,40 @@
+package gating
+
+import (
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// StaticPeersConnectionGater only allows connections with a fixed set of peers,
+// for private networks where all peers are known up front.
+type StaticPeersConnectionGater struct {
+	BlockingConnectionGater
+	allowed map[peer.ID]struct{}
+}
+
+func AddStaticPeersOnly(gater BlockingConnectionGater, peers []peer.ID) *StaticPeersConnectionGater {
+	allowed := make(map[peer.ID]struct{}, len(peers))
+	for _, p := range peers {
+		allowed[p] = struct{}{}
+	}
+	return &StaticPeersConnectionGater{BlockingConnectionGater: gater, allowed: allowed}
+}
+
+func (g *StaticPeersConnectionGater) isStatic(p peer.ID) bool {
+	_, ok := g.allowed[p]
+	return ok
+}
+
+func (g *StaticPeersConnectionGater) InterceptPeerDial(p peer.ID) (allow bool) {
+	return g.isStatic(p) && g.BlockingConnectionGater.InterceptPeerDial(p)
+}
+
+func (g *StaticPeersConnectionGater) InterceptAddrDial(id peer.ID, ma multiaddr.Multiaddr) (allow bool) {
+	return g.isStatic(id) && g.BlockingConnectionGater.InterceptAddrDial(id, ma)
+}
+
+// InterceptSecured rejects inbound connections from non-static peers, once the peer ID is authenticated.
+func (g *StaticPeersConnectionGater) InterceptSecured(dir network.Direction, id peer.ID, mas network.ConnMultiaddrs) (allow bool) {
+	return g.isStatic(id) && g.BlockingConnectionGater.InterceptSecured(dir, id, mas)
+}
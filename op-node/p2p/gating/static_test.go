@@ -0,0 +1,42 @@
+package gating
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/gating/mocks"
+)
+
+func TestStaticPeersConnectionGater(t *testing.T) {
+	alice := peer.ID("alice")
+	mallory := peer.ID("mallory")
+	addr := multiaddr.StringCast("/ip4/127.0.0.1/tcp/9222")
+
+	t.Run("static peer", func(t *testing.T) {
+		mockGater := mocks.NewBlockingConnectionGater(t)
+		gater := AddStaticPeersOnly(mockGater, []peer.ID{alice})
+		mockGater.EXPECT().InterceptPeerDial(alice).Return(true)
+		mockGater.EXPECT().InterceptAddrDial(alice, addr).Return(true)
+		mockGater.EXPECT().InterceptSecured(network.DirInbound, alice, nil).Return(true)
+		require.True(t, gater.InterceptPeerDial(alice))
+		require.True(t, gater.InterceptAddrDial(alice, addr))
+		require.True(t, gater.InterceptSecured(network.DirInbound, alice, nil))
+	})
+	t.Run("blocked static peer", func(t *testing.T) {
+		mockGater := mocks.NewBlockingConnectionGater(t)
+		gater := AddStaticPeersOnly(mockGater, []peer.ID{alice})
+		mockGater.EXPECT().InterceptPeerDial(alice).Return(false)
+		require.False(t, gater.InterceptPeerDial(alice))
+	})
+	t.Run("non-static peer", func(t *testing.T) {
+		mockGater := mocks.NewBlockingConnectionGater(t)
+		gater := AddStaticPeersOnly(mockGater, []peer.ID{alice})
+		require.False(t, gater.InterceptPeerDial(mallory))
+		require.False(t, gater.InterceptAddrDial(mallory, addr))
+		require.False(t, gater.InterceptSecured(network.DirInbound, mallory, nil))
+	})
+}
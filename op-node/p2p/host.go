@@ -36,8 +36,15 @@ import (
 
 const (
 	staticPeerTag = "static"
+	// apiProtectedTag tags the peers protected through the admin API.
+	apiProtectedTag = "api-protected"
 )
 
+type StaticPeerMetrics interface {
+	SetStaticPeers(connected int, disconnected int)
+	RecordStaticPeerDial(success bool)
+}
+
 type ExtraHostFeatures interface {
 	host.Host
 	ConnectionGater() gating.BlockingConnectionGater
@@ -49,6 +56,7 @@ type extraHost struct {
 	gater   gating.BlockingConnectionGater
 	connMgr connmgr.ConnManager
 	log     log.Logger
+	metrics StaticPeerMetrics
 
 	staticPeers []*peer.AddrInfo
 
@@ -87,10 +95,9 @@ func (e *extraHost) initStaticPeers() {
 
 func (e *extraHost) dialStaticPeer(ctx context.Context, addr *peer.AddrInfo) error {
 	e.log.Info("dialing static peer", "peer", addr.ID, "addrs", addr.Addrs)
-	if _, err := e.Network().DialPeer(ctx, addr.ID); err != nil {
-		return err
-	}
-	return nil
+	_, err := e.Network().DialPeer(ctx, addr.ID)
+	e.metrics.RecordStaticPeerDial(err == nil)
+	return err
 }
 
 func (e *extraHost) monitorStaticPeers() {
@@ -104,11 +111,13 @@ func (e *extraHost) monitorStaticPeers() {
 			var wg sync.WaitGroup
 
 			e.log.Debug("polling static peers", "peers", len(e.staticPeers))
+			connected := 0
 			for _, addr := range e.staticPeers {
 				connectedness := e.Network().Connectedness(addr.ID)
 				e.log.Trace("static peer connectedness", "peer", addr.ID, "connectedness", connectedness)
 
 				if connectedness == network.Connected {
+					connected++
 					continue
 				}
 
@@ -121,6 +130,7 @@ func (e *extraHost) monitorStaticPeers() {
 					wg.Done()
 				}(addr)
 			}
+			e.metrics.SetStaticPeers(connected, len(e.staticPeers)-connected)
 
 			wg.Wait()
 			cancel()
@@ -168,18 +178,41 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		return nil, fmt.Errorf("failed to set up peerstore with pub key: %w", err)
 	}
 
+	staticPeers := make([]*peer.AddrInfo, 0, len(conf.StaticPeers))
+	for _, peerAddr := range conf.StaticPeers {
+		addr, err := peer.AddrInfoFromP2pAddr(peerAddr)
+		if err != nil {
+			return nil, fmt.Errorf("bad peer address: %w", err)
+		}
+		if addr.ID == pid {
+			log.Info("Static-peer list contains address of local peer, ignoring the address.", "peer_id", addr.ID, "addrs", addr.Addrs)
+			continue
+		}
+		staticPeers = append(staticPeers, addr)
+	}
+
 	var connGtr gating.BlockingConnectionGater
 	connGtr, err = gating.NewBlockingConnectionGater(conf.Store)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open connection gater: %w", err)
 	}
 	connGtr = gating.AddBanExpiry(connGtr, ps, log, clock.SystemClock, metrics)
+	if conf.StaticPeersOnly {
+		staticIDs := make([]peer.ID, 0, len(staticPeers))
+		for _, addr := range staticPeers {
+			staticIDs = append(staticIDs, addr.ID)
+		}
+		connGtr = gating.AddStaticPeersOnly(connGtr, staticIDs)
+	}
 	connGtr = gating.AddMetering(connGtr, metrics)
 
 	connMngr, err := DefaultConnManager(conf)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open connection manager: %w", err)
 	}
+	if err := restoreProtectedPeers(ps, connMngr); err != nil {
+		return nil, fmt.Errorf("failed to restore protected peers: %w", err)
+	}
 
 	listenAddr, err := addrFromIPAndPort(conf.ListenIP, conf.ListenTCPPort)
 	if err != nil {
@@ -229,23 +262,11 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		return nil, err
 	}
 
-	staticPeers := make([]*peer.AddrInfo, 0, len(conf.StaticPeers))
-	for _, peerAddr := range conf.StaticPeers {
-		addr, err := peer.AddrInfoFromP2pAddr(peerAddr)
-		if err != nil {
-			return nil, fmt.Errorf("bad peer address: %w", err)
-		}
-		if addr.ID == h.ID() {
-			log.Info("Static-peer list contains address of local peer, ignoring the address.", "peer_id", addr.ID, "addrs", addr.Addrs)
-			continue
-		}
-		staticPeers = append(staticPeers, addr)
-	}
-
 	out := &extraHost{
 		Host:        h,
 		connMgr:     connMngr,
 		log:         log,
+		metrics:     metrics,
 		staticPeers: staticPeers,
 		quitC:       make(chan struct{}),
 	}
@@ -258,6 +279,22 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 	return out, nil
 }
 
+// restoreProtectedPeers protects the peers that were protected through the admin API before the node restarted.
+func restoreProtectedPeers(ps store.ExtendedPeerstore, connMngr connmgr.ConnManager) error {
+	protected, err := ps.ProtectedPeers()
+	if err != nil {
+		return err
+	}
+	for _, id := range protected {
+		connMngr.Protect(id, apiProtectedTag)
+		// Refresh the record, so it does not expire while the peer remains protected.
+		if err := ps.SetPeerProtected(id, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Creates a multi-addr to bind to. Does not contain a PeerID component (required for usage by external peers)
 func addrFromIPAndPort(ip net.IP, port uint16) (ma.Multiaddr, error) {
 	ipScheme := "ip4"
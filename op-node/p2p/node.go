@@ -124,7 +124,7 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 				n.syncCl.AddPeer(peerID)
 			}
 			if l2Chain != nil { // Only enable serving side of req-resp sync if we have a data-source, to make minimal P2P testing easy
-				n.syncSrv = NewReqRespServer(rollupCfg, l2Chain, metrics)
+				n.syncSrv = NewReqRespServer(rollupCfg, setup.SyncServerConfig(), l2Chain, metrics)
 				// register the sync protocol with libp2p host
 				payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 				n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
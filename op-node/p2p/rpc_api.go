@@ -31,6 +31,8 @@ type PeerInfo struct {
 	GossipBlocks bool `json:"gossipBlocks"` // if the peer is in our gossip topic
 
 	PeerScores store.PeerScores `json:"scores"`
+	// BanExpiry is when the ban of the peer from peer scoring expires, if it is banned.
+	BanExpiry *time.Time `json:"banExpiry,omitempty"`
 }
 
 type PeerDump struct {
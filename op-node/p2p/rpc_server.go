@@ -115,6 +115,9 @@ func dumpPeer(id peer.ID, nw network.Network, pstore peerstore.Peerstore, connMg
 		if dat, err := eps.GetPeerScores(id); err == nil {
 			info.PeerScores = dat
 		}
+		if expiry, err := eps.GetPeerBanExpiration(id); err == nil && expiry.After(time.Now()) {
+			info.BanExpiry = &expiry
+		}
 		if md, err := eps.GetPeerMetadata(id); err == nil {
 			info.ENR = md.ENR
 			info.ChainID = md.OPStackID
@@ -254,14 +257,20 @@ func (s *APIBackend) BlockPeer(_ context.Context, p peer.ID) error {
 	}
 }
 
+// UnblockPeer removes a peer from the set of blocked peers.
+// It also lifts any ban of the peer from peer scoring.
 func (s *APIBackend) UnblockPeer(_ context.Context, p peer.ID) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_unblockPeer")
 	defer recordDur()
 	if gater := s.node.ConnectionGater(); gater == nil {
 		return ErrNoConnectionGater
-	} else {
-		return gater.UnblockPeer(p)
+	} else if err := gater.UnblockPeer(p); err != nil {
+		return err
 	}
+	if eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore); ok {
+		return eps.SetPeerBanExpiration(p, time.Time{})
+	}
+	return nil
 }
 
 func (s *APIBackend) ListBlockedPeers(_ context.Context) ([]peer.ID, error) {
@@ -286,14 +295,20 @@ func (s *APIBackend) BlockAddr(_ context.Context, ip net.IP) error {
 	}
 }
 
+// UnblockAddr removes an IP address from the set of blocked addresses.
+// It also lifts any ban of the IP address from peer scoring.
 func (s *APIBackend) UnblockAddr(_ context.Context, ip net.IP) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_unblockAddr")
 	defer recordDur()
 	if gater := s.node.ConnectionGater(); gater == nil {
 		return ErrNoConnectionGater
-	} else {
-		return gater.UnblockAddr(ip)
+	} else if err := gater.UnblockAddr(ip); err != nil {
+		return err
 	}
+	if eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore); ok {
+		return eps.SetIPBanExpiration(ip, time.Time{})
+	}
+	return nil
 }
 
 func (s *APIBackend) ListBlockedAddrs(_ context.Context) ([]net.IP, error) {
@@ -338,15 +353,20 @@ func (s *APIBackend) ListBlockedSubnets(_ context.Context) ([]*net.IPNet, error)
 	}
 }
 
+// ProtectPeer protects a peer from being disconnected by the connection manager.
+// The protection is persisted in the peerstore, and restored when the node restarts.
 func (s *APIBackend) ProtectPeer(_ context.Context, p peer.ID) error {
 	recordDur := s.m.RecordRPCServerRequest("opp2p_protectPeer")
 	defer recordDur()
 	if manager := s.node.ConnectionManager(); manager == nil {
 		return ErrNoConnectionManager
 	} else {
-		manager.Protect(p, "api-protected")
-		return nil
+		manager.Protect(p, apiProtectedTag)
+	}
+	if eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore); ok {
+		return eps.SetPeerProtected(p, true)
 	}
+	return nil
 }
 
 func (s *APIBackend) UnprotectPeer(_ context.Context, p peer.ID) error {
@@ -355,9 +375,12 @@ func (s *APIBackend) UnprotectPeer(_ context.Context, p peer.ID) error {
 	if manager := s.node.ConnectionManager(); manager == nil {
 		return ErrNoConnectionManager
 	} else {
-		manager.Unprotect(p, "api-protected")
-		return nil
+		manager.Unprotect(p, apiProtectedTag)
+	}
+	if eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore); ok {
+		return eps.SetPeerProtected(p, false)
 	}
+	return nil
 }
 
 // ConnectPeer connects to a given peer address, and wait for protocol negotiation & identification of the peer
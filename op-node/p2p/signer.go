@@ -4,13 +4,17 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"fmt"
 	"io"
 	"math/big"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
 )
 
 var SigningDomainBlocksV1 = [32]byte{}
@@ -21,6 +25,10 @@ type Signer interface {
 }
 
 func SigningHash(domain [32]byte, chainID *big.Int, payloadBytes []byte) (common.Hash, error) {
+	return signingHashFromPayloadHash(domain, chainID, crypto.Keccak256Hash(payloadBytes))
+}
+
+func signingHashFromPayloadHash(domain [32]byte, chainID *big.Int, payloadHash common.Hash) (common.Hash, error) {
 	var msgInput [32 + 32 + 32]byte
 	// domain: first 32 bytes
 	copy(msgInput[:32], domain[:])
@@ -30,7 +38,7 @@ func SigningHash(domain [32]byte, chainID *big.Int, payloadBytes []byte) (common
 	}
 	chainID.FillBytes(msgInput[32:64])
 	// payload_hash: third 32 bytes, hash of encoded payload
-	copy(msgInput[64:], crypto.Keccak256(payloadBytes))
+	copy(msgInput[64:], payloadHash[:])
 
 	return crypto.Keccak256Hash(msgInput[:]), nil
 }
@@ -69,6 +77,128 @@ func (s *LocalSigner) Close() error {
 	return nil
 }
 
+type blockPayloadSigner interface {
+	SignBlockPayload(ctx context.Context, args *opsigner.BlockPayloadArgs) ([65]byte, error)
+}
+
+// RemoteSigner signs payloads with a remote signer service, so the unsafe block signer key
+// does not have to be held by the node. The remote signer computes the signing hash from
+// the domain, chain ID and payload hash, and the signature is checked against the signer address.
+// The signer endpoints are tried in order, and endpoints that are unreachable are reconnected to
+// when the next payload is signed: an unavailable signer only fails the publication of blocks.
+type RemoteSigner struct {
+	log       log.Logger
+	sender    common.Address
+	endpoints []string
+	newClient func(endpoint string) (blockPayloadSigner, error)
+
+	mu      sync.Mutex
+	clients []blockPayloadSigner
+	closed  bool
+}
+
+func NewRemoteSigner(logger log.Logger, config opsigner.CLIConfig) (*RemoteSigner, error) {
+	if err := config.Check(); err != nil {
+		return nil, fmt.Errorf("invalid remote signer config: %w", err)
+	}
+	if config.Type != "" && config.Type != opsigner.TypeRemote {
+		return nil, fmt.Errorf("signer type %q does not support signing block payloads", config.Type)
+	}
+	if !common.IsHexAddress(config.Address) {
+		return nil, fmt.Errorf("invalid remote signer address: %q", config.Address)
+	}
+	endpoints := append([]string{config.Endpoint}, config.FallbackEndpoints...)
+	s := &RemoteSigner{
+		log:       logger,
+		sender:    common.HexToAddress(config.Address),
+		endpoints: endpoints,
+		newClient: func(endpoint string) (blockPayloadSigner, error) {
+			return opsigner.NewSignerClient(logger, endpoint, config.TLSConfig)
+		},
+		clients: make([]blockPayloadSigner, len(endpoints)),
+	}
+	for i := range endpoints {
+		if _, err := s.client(i); err != nil {
+			logger.Warn("Remote signer is not available, will retry when signing", "endpoint", endpoints[i], "err", err)
+		}
+	}
+	return s, nil
+}
+
+// client returns the client for the i-th endpoint, connecting to it if it is not connected yet.
+func (s *RemoteSigner) client(i int) (blockPayloadSigner, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, errors.New("signer is closed")
+	}
+	if s.clients[i] == nil {
+		client, err := s.newClient(s.endpoints[i])
+		if err != nil {
+			return nil, err
+		}
+		s.clients[i] = client
+	}
+	return s.clients[i], nil
+}
+
+func (s *RemoteSigner) Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error) {
+	payloadHash := crypto.Keccak256Hash(encodedMsg)
+	signingHash, err := signingHashFromPayloadHash(domain, chainID, payloadHash)
+	if err != nil {
+		return nil, err
+	}
+	args := opsigner.NewBlockPayloadArgs(domain, chainID, payloadHash, &s.sender)
+	var errs []error
+	for i, endpoint := range s.endpoints {
+		signature, err := s.sign(ctx, i, args, signingHash)
+		if err != nil {
+			s.log.Warn("Failed to sign block payload with remote signer", "endpoint", endpoint, "err", err)
+			errs = append(errs, fmt.Errorf("signer %v: %w", i, err))
+			continue
+		}
+		return signature, nil
+	}
+	return nil, fmt.Errorf("failed to sign block payload: %w", errors.Join(errs...))
+}
+
+func (s *RemoteSigner) sign(ctx context.Context, i int, args *opsigner.BlockPayloadArgs, signingHash common.Hash) (*[65]byte, error) {
+	client, err := s.client(i)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := client.SignBlockPayload(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.SigToPub(signingHash[:], signature[:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if addr := crypto.PubkeyToAddress(*pub); addr != s.sender {
+		return nil, fmt.Errorf("signature is from %s, expected %s", addr, s.sender)
+	}
+	return &signature, nil
+}
+
+func (s *RemoteSigner) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.clients = nil
+	return nil
+}
+
+// RemoteSignerSetup creates a RemoteSigner for the configured signer service.
+type RemoteSignerSetup struct {
+	Log    log.Logger
+	Config opsigner.CLIConfig
+}
+
+func (r *RemoteSignerSetup) SetupSigner(ctx context.Context) (Signer, error) {
+	return NewRemoteSigner(r.Log, r.Config)
+}
+
 type PreparedSigner struct {
 	Signer
 }
@@ -1,11 +1,19 @@
 package p2p
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"testing"
 
-	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestSigningHash_DifferentDomain(t *testing.T) {
@@ -65,3 +73,88 @@ func TestSigningHash_LimitChainID(t *testing.T) {
 	_, err := SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, []byte("arbitraryData"))
 	require.ErrorContains(t, err, "chain_id is too large")
 }
+
+type keySigner struct {
+	priv  *ecdsa.PrivateKey
+	calls int
+}
+
+func (k *keySigner) SignBlockPayload(ctx context.Context, args *opsigner.BlockPayloadArgs) ([65]byte, error) {
+	k.calls++
+	h, err := signingHashFromPayloadHash(args.Domain, args.ChainID.ToInt(), args.PayloadHash)
+	if err != nil {
+		return [65]byte{}, err
+	}
+	sig, err := crypto.Sign(h[:], k.priv)
+	if err != nil {
+		return [65]byte{}, err
+	}
+	return [65]byte(sig), nil
+}
+
+func newTestRemoteSigner(t *testing.T, signerKey *ecdsa.PrivateKey, clients map[string]blockPayloadSigner, endpoints ...string) *RemoteSigner {
+	return &RemoteSigner{
+		log:       testlog.Logger(t, log.LvlInfo),
+		sender:    crypto.PubkeyToAddress(signerKey.PublicKey),
+		endpoints: endpoints,
+		newClient: func(endpoint string) (blockPayloadSigner, error) {
+			if c, ok := clients[endpoint]; ok {
+				return c, nil
+			}
+			return nil, errors.New("connection refused")
+		},
+		clients: make([]blockPayloadSigner, len(endpoints)),
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	chainID := big.NewInt(100)
+	msg := []byte("arbitraryData")
+	expected, err := NewLocalSigner(priv).Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+	require.NoError(t, err)
+
+	t.Run("Sign", func(t *testing.T) {
+		s := newTestRemoteSigner(t, priv, map[string]blockPayloadSigner{"primary": &keySigner{priv: priv}}, "primary")
+		sig, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	})
+
+	t.Run("FallbackWhenUnreachable", func(t *testing.T) {
+		fallback := &keySigner{priv: priv}
+		s := newTestRemoteSigner(t, priv, map[string]blockPayloadSigner{"fallback": fallback}, "primary", "fallback")
+		sig, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+		require.Equal(t, 1, fallback.calls)
+	})
+
+	t.Run("ReconnectWhenAvailable", func(t *testing.T) {
+		clients := map[string]blockPayloadSigner{}
+		s := newTestRemoteSigner(t, priv, clients, "primary")
+		_, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+		require.ErrorContains(t, err, "connection refused")
+
+		clients["primary"] = &keySigner{priv: priv}
+		sig, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+		require.NoError(t, err)
+		require.Equal(t, expected, sig)
+	})
+
+	t.Run("RejectWrongSigner", func(t *testing.T) {
+		other, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		s := newTestRemoteSigner(t, priv, map[string]blockPayloadSigner{"primary": &keySigner{priv: other}}, "primary")
+		_, err = s.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+		require.ErrorContains(t, err, "signature is from")
+	})
+
+	t.Run("Closed", func(t *testing.T) {
+		s := newTestRemoteSigner(t, priv, map[string]blockPayloadSigner{"primary": &keySigner{priv: priv}}, "primary")
+		require.NoError(t, s.Close())
+		_, err := s.Sign(context.Background(), SigningDomainBlocksV1, chainID, msg)
+		require.ErrorContains(t, err, "signer is closed")
+	})
+}
@@ -18,6 +18,7 @@ type extendedStore struct {
 	*scoreBook
 	*peerBanBook
 	*ipBanBook
+	*protectedPeerBook
 	*metadataBook
 }
 
@@ -41,6 +42,11 @@ func NewExtendedPeerstore(ctx context.Context, logger log.Logger, clock clock.Cl
 		return nil, fmt.Errorf("create IP ban book: %w", err)
 	}
 	ib.startGC()
+	ppb, err := newProtectedPeerBook(ctx, logger, clock, store)
+	if err != nil {
+		return nil, fmt.Errorf("create protected peer book: %w", err)
+	}
+	ppb.startGC()
 	md, err := newMetadataBook(ctx, logger, clock, store)
 	if err != nil {
 		return nil, fmt.Errorf("create metadata book: %w", err)
@@ -52,6 +58,7 @@ func NewExtendedPeerstore(ctx context.Context, logger log.Logger, clock clock.Cl
 		scoreBook:         sb,
 		peerBanBook:       pb,
 		ipBanBook:         ib,
+		protectedPeerBook: ppb,
 		metadataBook:      md,
 	}, nil
 }
@@ -60,6 +67,7 @@ func (s *extendedStore) Close() error {
 	s.scoreBook.Close()
 	s.peerBanBook.Close()
 	s.ipBanBook.Close()
+	s.protectedPeerBook.Close()
 	s.metadataBook.Close()
 	return s.Peerstore.Close()
 }
@@ -112,6 +112,13 @@ type PeerBanStore interface {
 	GetPeerBanExpiration(id peer.ID) (time.Time, error)
 }
 
+type ProtectedPeerStore interface {
+	// SetPeerProtected persists whether the peer is protected from being disconnected by the connection manager.
+	SetPeerProtected(id peer.ID, protected bool) error
+	// ProtectedPeers lists the persisted protected peers.
+	ProtectedPeers() ([]peer.ID, error)
+}
+
 type IPBanStore interface {
 	// SetIPBanExpiration create the IP ban with expiration time.
 	// If expiry == time.Time{} then the ban is deleted.
@@ -134,5 +141,6 @@ type ExtendedPeerstore interface {
 	peerstore.CertifiedAddrBook
 	PeerBanStore
 	IPBanStore
+	ProtectedPeerStore
 	MetadataStore
 }
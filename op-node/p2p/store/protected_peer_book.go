@@ -0,0 +1,92 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	protectedPeerCacheSize = 100
+	// Protected peers are refreshed whenever they are restored on startup,
+	// so they only expire if the node has not been restarted for this long.
+	protectedPeerRecordExpiration = time.Hour * 24 * 365
+)
+
+var protectedPeersBase = ds.NewKey("/peers/protected")
+
+type protectedPeerRecord struct {
+	PeerID     peer.ID `json:"peerID"`
+	LastUpdate int64   `json:"lastUpdate"` // unix timestamp in seconds
+}
+
+func (s *protectedPeerRecord) SetLastUpdated(t time.Time) {
+	s.LastUpdate = t.Unix()
+}
+
+func (s *protectedPeerRecord) LastUpdated() time.Time {
+	return time.Unix(s.LastUpdate, 0)
+}
+
+func (s *protectedPeerRecord) MarshalBinary() (data []byte, err error) {
+	return json.Marshal(s)
+}
+
+func (s *protectedPeerRecord) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, s)
+}
+
+type protectedPeerUpdate peer.ID
+
+func (p protectedPeerUpdate) Apply(rec *protectedPeerRecord) {
+	rec.PeerID = peer.ID(p)
+}
+
+type protectedPeerBook struct {
+	book *recordsBook[peer.ID, *protectedPeerRecord]
+}
+
+func newProtectedPeerRecord() *protectedPeerRecord {
+	return new(protectedPeerRecord)
+}
+
+func newProtectedPeerBook(ctx context.Context, logger log.Logger, clock clock.Clock, store ds.Batching) (*protectedPeerBook, error) {
+	book, err := newRecordsBook[peer.ID, *protectedPeerRecord](ctx, logger, clock, store, protectedPeerCacheSize, protectedPeerRecordExpiration, protectedPeersBase, newProtectedPeerRecord, peerIDKey)
+	if err != nil {
+		return nil, err
+	}
+	return &protectedPeerBook{book: book}, nil
+}
+
+func (d *protectedPeerBook) startGC() {
+	d.book.startGC()
+}
+
+func (d *protectedPeerBook) SetPeerProtected(id peer.ID, protected bool) error {
+	if !protected {
+		return d.book.deleteRecord(id)
+	}
+	_, err := d.book.SetRecord(id, protectedPeerUpdate(id))
+	return err
+}
+
+func (d *protectedPeerBook) ProtectedPeers() ([]peer.ID, error) {
+	records, err := d.book.records()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]peer.ID, 0, len(records))
+	for _, rec := range records {
+		ids = append(ids, rec.PeerID)
+	}
+	return ids, nil
+}
+
+func (d *protectedPeerBook) Close() {
+	d.book.Close()
+}
@@ -0,0 +1,70 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProtectedPeers(t *testing.T) {
+	store := sync.MutexWrap(ds.NewMapDatastore())
+	c := clock.NewDeterministicClock(time.UnixMilli(100))
+	book := createProtectedPeerBook(t, store, c)
+	defer book.Close()
+	a, b, cc, d := test.RandPeerIDFatal(t), test.RandPeerIDFatal(t), test.RandPeerIDFatal(t), test.RandPeerIDFatal(t)
+
+	peers, err := book.ProtectedPeers()
+	require.NoError(t, err)
+	require.Empty(t, peers)
+
+	require.NoError(t, book.SetPeerProtected(a, true))
+	require.NoError(t, book.SetPeerProtected(b, true))
+	require.NoError(t, book.SetPeerProtected(cc, true))
+	require.NoError(t, book.SetPeerProtected(b, false))
+	// Unprotecting a peer that is not protected is a no-op
+	require.NoError(t, book.SetPeerProtected(d, false))
+
+	peers, err = book.ProtectedPeers()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []peer.ID{a, cc}, peers)
+
+	// Protected peers persist across restarts
+	restarted := createProtectedPeerBook(t, store, c)
+	defer restarted.Close()
+	peers, err = restarted.ProtectedPeers()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []peer.ID{a, cc}, peers)
+}
+
+func TestProtectedPeersExpire(t *testing.T) {
+	store := sync.MutexWrap(ds.NewMapDatastore())
+	c := clock.NewDeterministicClock(time.UnixMilli(100))
+	book := createProtectedPeerBook(t, store, c)
+	defer book.Close()
+	a, b := test.RandPeerIDFatal(t), test.RandPeerIDFatal(t)
+
+	require.NoError(t, book.SetPeerProtected(a, true))
+	c.AdvanceTime(protectedPeerRecordExpiration / 2)
+	require.NoError(t, book.SetPeerProtected(b, true))
+	c.AdvanceTime(protectedPeerRecordExpiration/2 + time.Second)
+
+	peers, err := book.ProtectedPeers()
+	require.NoError(t, err)
+	require.Equal(t, []peer.ID{b}, peers)
+}
+
+func createProtectedPeerBook(t *testing.T, store ds.Batching, c clock.Clock) *protectedPeerBook {
+	logger := testlog.Logger(t, log.LvlInfo)
+	book, err := newProtectedPeerBook(context.Background(), logger, c, store)
+	require.NoError(t, err)
+	return book
+}
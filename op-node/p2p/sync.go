@@ -54,11 +54,21 @@ const (
 	peerServerBlocksRateLimit rate.Limit = 4
 	// Allow a peer to request 30s of blocks at once
 	peerServerBlocksBurst = 15
+	// Do not serve more than 16 requests at the same time
+	maxServerConcurrentRequests = 16
+	// Do not serve more than 2 requests of the same peer at the same time
+	maxServerPeerConcurrentRequests = 2
 	// If the client hits a request error, it counts as a lot of rate-limit tokens for syncing from that peer:
 	// we rather sync from other servers. We'll try again later,
 	// and eventually kick the peer based on degraded scoring if it's really not serving us well.
 	// TODO(CLI-4009): Use a backoff rather than this mechanism.
 	clientErrRateCost = peerServerBlocksBurst
+	// If a request takes longer than this, it is considered slow: the same block is requested once more,
+	// from any other available peer, so a single slow peer does not stall the sync of a range.
+	clientSlowRequestThreshold = time.Second * 3
+	// If the client receives a slow response, it counts as some extra rate-limit tokens for syncing from that peer,
+	// so the faster peers take on more of the requests.
+	clientSlowRateCost = peerServerBlocksBurst / 3
 )
 
 func PayloadByNumberProtocolID(l2ChainID *big.Int) protocol.ID {
@@ -100,6 +110,38 @@ type peerRequest struct {
 	complete *atomic.Bool
 }
 
+// inFlightRequest tracks the requests for a single block number that have been scheduled for the peers.
+type inFlightRequest struct {
+	// complete flags of the original request and, if the original request was slow, of its hedge request.
+	requests []*atomic.Bool
+	start    time.Time
+}
+
+func (r *inFlightRequest) complete() bool {
+	for _, c := range r.requests {
+		if !c.Load() {
+			return false
+		}
+	}
+	return true
+}
+
+// shouldHedge returns true if the request is slow, and has not been repeated yet.
+func (r *inFlightRequest) shouldHedge(now time.Time) bool {
+	return len(r.requests) == 1 && !r.requests[0].Load() && now.Sub(r.start) > clientSlowRequestThreshold
+}
+
+// syncProgress tracks how far the blocks of a range request target have been promoted, so a repeated range
+// request for the same target resumes below the promoted blocks instead of fetching them again.
+type syncProgress struct {
+	// target is the hash of the end of the range request.
+	target common.Hash
+	// next is the hash of the next block expected to be promoted, the parent of the lowest promoted block.
+	next common.Hash
+	// lowest is the number of the lowest block promoted on the chain of the target, or the target number if none.
+	lowest uint64
+}
+
 type inFlightCheck struct {
 	num uint64
 
@@ -133,7 +175,13 @@ type SyncPeerScorer interface {
 //   - The high part of the range has a known block-hash, and is marked as trusted.
 //   - Once there are no more peers available for buffering requests, we stop the range request processing.
 //   - Every request buffered for a peer is tracked as in-flight, by block number.
-//   - In-flight requests are not repeated
+//   - In-flight requests are not repeated, unless they are slow: then the request is hedged once,
+//     to be served by the next available peer, so one slow peer cannot stall the sync of the range.
+//   - Blocks that were already promoted for the same range target are not requested again,
+//     so a repeated range request resumes below the blocks that were synced.
+//   - Blocks are requested by number, one per request, from a queue shared by all peers. Ranges are not split
+//     into contiguous shards per peer: the payload_by_number protocol serves one block per request, so sharding
+//     would not reduce the number of requests, and the shared queue already spreads the range over the peers.
 //   - Requests for data that's already in the quarantine are not repeated
 //   - Data already in the quarantine that is trusted is attempted to be promoted.
 //
@@ -141,6 +189,7 @@ type SyncPeerScorer interface {
 //   - They fetch the requested block by number, parse and validate it, and then send it back to the main loop
 //   - If peers fail to fetch or process it, or fail to send it back to the main loop within timeout,
 //     then the doRequest returns an error. It then marks the in-flight request as completed.
+//   - Peers back off after errors and slow responses, so the faster peers serve more of the requests.
 //
 // - Main loop receives results synchronously with the range requests
 //   - The result is removed from in-flight tracker
@@ -208,8 +257,11 @@ type SyncClient struct {
 	// This map is cleared upon evictions of items from the quarantine LRU
 	quarantineByNum map[uint64]common.Hash
 
-	// inFlight requests are not repeated
-	inFlight map[uint64]*atomic.Bool
+	// inFlight requests are not repeated, unless they are slow
+	inFlight map[uint64]*inFlightRequest
+
+	// progress of the latest range request target
+	progress syncProgress
 
 	requests       chan rangeRequest
 	peerRequests   chan peerRequest
@@ -246,7 +298,7 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rc
 		payloadByNumber: PayloadByNumberProtocolID(cfg.L2ChainID),
 		peers:           make(map[peer.ID]context.CancelFunc),
 		quarantineByNum: make(map[uint64]common.Hash),
-		inFlight:        make(map[uint64]*atomic.Bool),
+		inFlight:        make(map[uint64]*inFlightRequest),
 		requests:        make(chan rangeRequest), // blocking
 		peerRequests:    make(chan peerRequest, 128),
 		results:         make(chan syncResult, 128),
@@ -346,11 +398,11 @@ func (s *SyncClient) mainLoop() {
 			cancel()
 		case check := <-s.inFlightChecks:
 			s.log.Info("Checking in flight", "num", check.num)
-			complete, ok := s.inFlight[check.num]
+			req, ok := s.inFlight[check.num]
 			if !ok {
 				check.result <- false
 			} else {
-				check.result <- !complete.Load()
+				check.result <- !req.complete()
 			}
 		case <-s.resCtx.Done():
 			s.log.Info("stopped P2P req-resp L2 block sync client")
@@ -385,14 +437,22 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 
 	// clean up the completed in-flight requests
 	for k, v := range s.inFlight {
-		if v.Load() {
+		if v.complete() {
 			delete(s.inFlight, k)
 		}
 	}
+	now := time.Now()
+
+	// Resume below the blocks that were already promoted for this target.
+	if s.progress.target != req.end.Hash {
+		s.progress = syncProgress{target: req.end.Hash, next: req.end.ParentHash, lowest: req.end.Number}
+	} else if s.progress.lowest < req.end.Number {
+		log.Debug("Resuming P2P sync range below promoted blocks", "lowest", s.progress.lowest)
+	}
 
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
 	for i := uint64(0); ; i++ {
-		num := req.end.Number - 1 - i
+		num := s.progress.lowest - 1 - i
 		if num <= req.start {
 			return
 		}
@@ -406,17 +466,26 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 			continue
 		}
 
-		if _, ok := s.inFlight[num]; ok {
+		inFlight, ok := s.inFlight[num]
+		if ok && !inFlight.shouldHedge(now) {
 			log.Debug("request still in-flight, not rescheduling sync request", "num", num)
 			continue // request still in flight
 		}
 		pr := peerRequest{num: num, complete: new(atomic.Bool)}
 
-		log.Debug("Scheduling P2P block request", "num", num)
+		if ok {
+			log.Debug("Hedging slow P2P block request", "num", num, "age", now.Sub(inFlight.start))
+		} else {
+			log.Debug("Scheduling P2P block request", "num", num)
+		}
 		// schedule number
 		select {
 		case s.peerRequests <- pr:
-			s.inFlight[num] = pr.complete
+			if ok {
+				inFlight.requests = append(inFlight.requests, pr.complete)
+			} else {
+				s.inFlight[num] = &inFlightRequest{requests: []*atomic.Bool{pr.complete}, start: now}
+			}
 		case <-ctx.Done():
 			log.Info("did not schedule full P2P sync range", "current", num, "err", ctx.Err())
 			return
@@ -471,6 +540,12 @@ func (s *SyncClient) promote(ctx context.Context, res syncResult) {
 	// Mark parent block as trusted, so that we can promote it once we receive it / find it
 	s.trusted.Add(res.payload.ExecutionPayload.ParentHash, struct{}{})
 
+	// Track the progress towards the start of the range, if the block is the next one on the chain of the target.
+	if res.payload.ExecutionPayload.BlockHash == s.progress.next {
+		s.progress.lowest = uint64(res.payload.ExecutionPayload.BlockNumber)
+		s.progress.next = res.payload.ExecutionPayload.ParentHash
+	}
+
 	// Try to promote the parent block too, if any: previous unverifiable data may now be canonical
 	s.tryPromote(res.payload.ExecutionPayload.ParentHash)
 
@@ -547,6 +622,12 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 				s.appScorer.onValidResponse(id)
 			}
 			took := time.Since(start)
+			if err == nil && took > clientSlowRequestThreshold {
+				log.Debug("slow p2p sync response", "num", pr.num, "took", took)
+				if err := rl.WaitN(ctx, clientSlowRateCost); err != nil {
+					return
+				}
+			}
 
 			resultCode := byte(0)
 			if err != nil {
@@ -687,6 +768,69 @@ type peerStat struct {
 	Requests *rate.Limiter
 }
 
+// ReqRespServerConfig configures the limits of the P2P sync server.
+// Zero values are replaced with the defaults.
+type ReqRespServerConfig struct {
+	// GlobalRateLimit is the number of requests per second served to all peers combined.
+	GlobalRateLimit rate.Limit
+	GlobalBurst     int
+	// PeerRateLimit is the number of requests per second served to a single peer.
+	PeerRateLimit rate.Limit
+	PeerBurst     int
+	// MaxConcurrentRequests is the number of requests served at the same time.
+	// Further requests wait, and are admitted by priority once capacity is available.
+	MaxConcurrentRequests int
+	// MaxPeerConcurrentRequests is the number of requests of a single peer served at the same time.
+	MaxPeerConcurrentRequests int
+}
+
+func DefaultReqRespServerConfig() ReqRespServerConfig {
+	return ReqRespServerConfig{
+		GlobalRateLimit:           globalServerBlocksRateLimit,
+		GlobalBurst:               globalServerBlocksBurst,
+		PeerRateLimit:             peerServerBlocksRateLimit,
+		PeerBurst:                 peerServerBlocksBurst,
+		MaxConcurrentRequests:     maxServerConcurrentRequests,
+		MaxPeerConcurrentRequests: maxServerPeerConcurrentRequests,
+	}
+}
+
+func (c ReqRespServerConfig) withDefaults() ReqRespServerConfig {
+	def := DefaultReqRespServerConfig()
+	if c.GlobalRateLimit == 0 {
+		c.GlobalRateLimit = def.GlobalRateLimit
+	}
+	if c.GlobalBurst == 0 {
+		c.GlobalBurst = def.GlobalBurst
+	}
+	if c.PeerRateLimit == 0 {
+		c.PeerRateLimit = def.PeerRateLimit
+	}
+	if c.PeerBurst == 0 {
+		c.PeerBurst = def.PeerBurst
+	}
+	if c.MaxConcurrentRequests == 0 {
+		c.MaxConcurrentRequests = def.MaxConcurrentRequests
+	}
+	if c.MaxPeerConcurrentRequests == 0 {
+		c.MaxPeerConcurrentRequests = def.MaxPeerConcurrentRequests
+	}
+	return c
+}
+
+func (c ReqRespServerConfig) Check() error {
+	if c.GlobalRateLimit < 0 || c.PeerRateLimit < 0 {
+		return errors.New("sync server rate limits must not be negative")
+	}
+	if c.GlobalBurst < 0 || c.PeerBurst < 0 {
+		return errors.New("sync server bursts must not be negative")
+	}
+	if c.MaxConcurrentRequests < 0 || c.MaxPeerConcurrentRequests < 0 {
+		return errors.New("sync server concurrent request limits must not be negative")
+	}
+	return nil
+}
+
 type L2Chain interface {
 	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayloadEnvelope, error)
 }
@@ -702,25 +846,33 @@ type ReqRespServer struct {
 
 	metrics ReqRespServerMetrics
 
+	limits ReqRespServerConfig
+
 	peerRateLimits *simplelru.LRU[peer.ID, *peerStat]
 	peerStatsLock  sync.Mutex
 
 	globalRequestsRL *rate.Limiter
+
+	scheduler *syncRequestScheduler
 }
 
-func NewReqRespServer(cfg *rollup.Config, l2 L2Chain, metrics ReqRespServerMetrics) *ReqRespServer {
+func NewReqRespServer(cfg *rollup.Config, limits ReqRespServerConfig, l2 L2Chain, metrics ReqRespServerMetrics) *ReqRespServer {
+	limits = limits.withDefaults()
+
 	// We should never allow over 1000 different peers to churn through quickly,
 	// so it's fine to prune rate-limit details past this.
 
 	peerRateLimits, _ := simplelru.NewLRU[peer.ID, *peerStat](1000, nil)
-	globalRequestsRL := rate.NewLimiter(globalServerBlocksRateLimit, globalServerBlocksBurst)
+	globalRequestsRL := rate.NewLimiter(limits.GlobalRateLimit, limits.GlobalBurst)
 
 	return &ReqRespServer{
 		cfg:              cfg,
 		l2:               l2,
 		metrics:          metrics,
+		limits:           limits,
 		peerRateLimits:   peerRateLimits,
 		globalRequestsRL: globalRequestsRL,
+		scheduler:        newSyncRequestScheduler(limits.MaxConcurrentRequests, limits.MaxPeerConcurrentRequests),
 	}
 }
 
@@ -774,21 +926,23 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	ps, _ := srv.peerRateLimits.Get(peerId)
 	if ps == nil {
 		ps = &peerStat{
-			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
+			Requests: rate.NewLimiter(srv.limits.PeerRateLimit, srv.limits.PeerBurst),
 		}
 		srv.peerRateLimits.Add(peerId, ps)
 		ps.Requests.Reserve() // count the hit, but make it delay the next request rather than immediately waiting
+		srv.peerStatsLock.Unlock()
 	} else {
+		// Release the lock before waiting, so a throttled peer does not block serving other peers.
+		srv.peerStatsLock.Unlock()
 		// Only wait if it's an existing peer, otherwise the instant rate-limit Wait call always errors.
 
 		// If the requester thinks we're taking too long, then it's their problem and they can disconnect.
 		// We'll disconnect ourselves only when failing to read/write,
 		// if the work is invalid (range validation), or when individual sub tasks timeout.
 		if err := ps.Requests.Wait(ctx); err != nil {
-			return 0, fmt.Errorf("timed out waiting for global sync rate limit: %w", err)
+			return 0, fmt.Errorf("timed out waiting for peer sync rate limit: %w", err)
 		}
 	}
-	srv.peerStatsLock.Unlock()
 
 	// Set read deadline, if available
 	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
@@ -814,6 +968,13 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 		return req, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", req, max, invalidRequestErr)
 	}
 
+	// Wait for capacity to serve the request, prioritized against the requests of other peers.
+	release, err := srv.scheduler.Acquire(ctx, peerId, req)
+	if err != nil {
+		return req, fmt.Errorf("timed out waiting for sync request capacity: %w", err)
+	}
+	defer release()
+
 	envelope, err := srv.l2.PayloadByNumber(ctx, req)
 	if err != nil {
 		if errors.Is(err, ethereum.NotFound) {
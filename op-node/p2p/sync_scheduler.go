@@ -0,0 +1,113 @@
+package p2p
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// syncRequest is a request of a peer, waiting to be served by the sync server.
+type syncRequest struct {
+	peer  peer.ID
+	block uint64
+	seq   uint64
+	// ready is closed when the request is admitted to be served
+	ready chan struct{}
+}
+
+// syncRequestScheduler bounds the number of sync requests that are served concurrently, in total and per peer.
+//
+// When capacity is available, the waiting request with the highest priority is admitted:
+// requests of peers with the fewest requests being served go first, so a single peer cannot starve the others,
+// then requests for the highest block number, as blocks near the tip are the most useful to sync,
+// and finally the oldest request.
+type syncRequestScheduler struct {
+	maxActive     int
+	maxPeerActive int
+
+	mu         sync.Mutex
+	active     int
+	peerActive map[peer.ID]int
+	waiting    []*syncRequest
+	nextSeq    uint64
+}
+
+func newSyncRequestScheduler(maxActive int, maxPeerActive int) *syncRequestScheduler {
+	return &syncRequestScheduler{
+		maxActive:     maxActive,
+		maxPeerActive: maxPeerActive,
+		peerActive:    make(map[peer.ID]int),
+	}
+}
+
+// Acquire waits until the request of the peer for the given block can be served.
+// The returned release function must be called once the request has been served.
+func (s *syncRequestScheduler) Acquire(ctx context.Context, id peer.ID, block uint64) (release func(), err error) {
+	s.mu.Lock()
+	req := &syncRequest{peer: id, block: block, seq: s.nextSeq, ready: make(chan struct{})}
+	s.nextSeq++
+	s.waiting = append(s.waiting, req)
+	s.dispatch()
+	s.mu.Unlock()
+
+	release = func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.active--
+		if s.peerActive[id]--; s.peerActive[id] == 0 {
+			delete(s.peerActive, id)
+		}
+		s.dispatch()
+	}
+	select {
+	case <-req.ready:
+		return release, nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		if i := slices.Index(s.waiting, req); i >= 0 {
+			s.waiting = slices.Delete(s.waiting, i, i+1)
+			s.mu.Unlock()
+			return nil, ctx.Err()
+		}
+		s.mu.Unlock()
+		// The request was admitted concurrently with the context being done, give up the capacity again.
+		release()
+		return nil, ctx.Err()
+	}
+}
+
+// dispatch admits waiting requests, in order of priority, while there is capacity.
+// The caller must hold the lock.
+func (s *syncRequestScheduler) dispatch() {
+	for s.active < s.maxActive {
+		best := -1
+		for i, req := range s.waiting {
+			if s.peerActive[req.peer] >= s.maxPeerActive {
+				continue
+			}
+			if best < 0 || s.higherPriority(req, s.waiting[best]) {
+				best = i
+			}
+		}
+		if best < 0 {
+			return
+		}
+		req := s.waiting[best]
+		s.waiting = slices.Delete(s.waiting, best, best+1)
+		s.active++
+		s.peerActive[req.peer]++
+		close(req.ready)
+	}
+}
+
+func (s *syncRequestScheduler) higherPriority(a, b *syncRequest) bool {
+	if aActive, bActive := s.peerActive[a.peer], s.peerActive[b.peer]; aActive != bActive {
+		return aActive < bActive
+	}
+	if a.block != b.block {
+		return a.block > b.block
+	}
+	return a.seq < b.seq
+}
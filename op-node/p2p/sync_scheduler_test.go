@@ -0,0 +1,99 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+// acquireAsync acquires capacity in the background, and sends the release function once admitted.
+func acquireAsync(s *syncRequestScheduler, id peer.ID, block uint64) chan func() {
+	admitted := make(chan func(), 1)
+	go func() {
+		release, err := s.Acquire(context.Background(), id, block)
+		if err == nil {
+			admitted <- release
+		}
+	}()
+	return admitted
+}
+
+// waitQueued waits until n requests are waiting for capacity.
+func waitQueued(t *testing.T, s *syncRequestScheduler, n int) {
+	require.Eventually(t, func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.waiting) == n
+	}, time.Second, time.Millisecond)
+}
+
+func TestSyncRequestScheduler_Limits(t *testing.T) {
+	s := newSyncRequestScheduler(2, 1)
+	releaseA, err := s.Acquire(context.Background(), "a", 1)
+	require.NoError(t, err)
+
+	// Peer a is at its limit, while there is still capacity for other peers
+	secondA := acquireAsync(s, "a", 2)
+	waitQueued(t, s, 1)
+	releaseB, err := s.Acquire(context.Background(), "b", 1)
+	require.NoError(t, err)
+
+	// All capacity is in use
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = s.Acquire(ctx, "c", 1)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	waitQueued(t, s, 1)
+
+	releaseB()
+	require.Empty(t, secondA, "peer a should still be at its limit")
+	releaseA()
+	(<-secondA)()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	require.Zero(t, s.active)
+	require.Empty(t, s.peerActive)
+}
+
+func TestSyncRequestScheduler_Priority(t *testing.T) {
+	s := newSyncRequestScheduler(1, 2)
+	release, err := s.Acquire(context.Background(), "a", 1)
+	require.NoError(t, err)
+
+	old := acquireAsync(s, "b", 10)
+	waitQueued(t, s, 1)
+	tip := acquireAsync(s, "b", 20)
+	waitQueued(t, s, 2)
+
+	// Requests for newer blocks are served first
+	release()
+	releaseTip := <-tip
+	require.Empty(t, old)
+
+	// Requests of peers with fewer requests being served go first
+	s2 := newSyncRequestScheduler(3, 3)
+	var releases []func()
+	for i := uint64(0); i < 3; i++ {
+		r, err := s2.Acquire(context.Background(), "greedy", i)
+		require.NoError(t, err)
+		releases = append(releases, r)
+	}
+	greedy := acquireAsync(s2, "greedy", 100)
+	waitQueued(t, s2, 1)
+	fresh := acquireAsync(s2, "fresh", 1)
+	waitQueued(t, s2, 2)
+	releases[0]()
+	releaseFresh := <-fresh
+	require.Empty(t, greedy)
+	releaseFresh()
+	(<-greedy)()
+	releases[1]()
+	releases[2]()
+
+	releaseTip()
+	(<-old)()
+}
@@ -156,7 +156,7 @@ func TestSinglePeerSync(t *testing.T) {
 	defer cancel()
 
 	// Setup host A as the server
-	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+	srv := NewReqRespServer(cfg, DefaultReqRespServerConfig(), servePayload, metrics.NoopMetrics)
 	payloadByNumber := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest)
 	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
 
@@ -217,7 +217,7 @@ func TestMultiPeerSync(t *testing.T) {
 		})
 
 		// Setup as server
-		srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
+		srv := NewReqRespServer(cfg, DefaultReqRespServerConfig(), servePayload, metrics.NoopMetrics)
 		payloadByNumber := MakeStreamHandler(ctx, log.New("serve", "payloads_by_number"), srv.HandleSyncRequest)
 		h.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
 
@@ -379,3 +379,99 @@ func TestNetworkNotifyAddPeerAndRemovePeer(t *testing.T) {
 	_, peerBExist3 := syncCl.peers[hostB.ID()]
 	require.True(t, !peerBExist3, "peerB should not exist in syncClient")
 }
+
+func TestSyncClientHedgesSlowRequests(t *testing.T) {
+	log := testlog.Logger(t, log.LvlDebug)
+	cfg, _ := setupSyncTestData(25)
+	cl := NewSyncClient(log, cfg, nil, nil, metrics.NoopMetrics, &NoopApplicationScorer{})
+	ctx := context.Background()
+
+	drain := func() (out []uint64) {
+		for {
+			select {
+			case pr := <-cl.peerRequests:
+				out = append(out, pr.num)
+			default:
+				return out
+			}
+		}
+	}
+
+	req := rangeRequest{start: 10, end: eth.L2BlockRef{Hash: common.Hash{0x0f}, Number: 15}}
+	cl.onRangeRequest(ctx, req)
+	require.Equal(t, []uint64{14, 13, 12, 11}, drain())
+
+	// the requests are still in-flight, and not slow yet, so they are not repeated
+	cl.onRangeRequest(ctx, req)
+	require.Empty(t, drain())
+
+	// block 12 becomes slow: it is hedged, once
+	cl.inFlight[12].start = time.Now().Add(-2 * clientSlowRequestThreshold)
+	cl.onRangeRequest(ctx, req)
+	require.Equal(t, []uint64{12}, drain())
+	cl.onRangeRequest(ctx, req)
+	require.Empty(t, drain())
+
+	// the request stays in-flight until both the original and the hedge request complete
+	cl.inFlight[12].requests[0].Store(true)
+	cl.onRangeRequest(ctx, req)
+	require.Empty(t, drain())
+	require.Contains(t, cl.inFlight, uint64(12))
+	cl.inFlight[12].requests[1].Store(true)
+	cl.onRangeRequest(ctx, req)
+	require.Equal(t, []uint64{12}, drain(), "completed without result, so it is rescheduled")
+}
+
+func TestSyncClientResumesBelowPromotedBlocks(t *testing.T) {
+	log := testlog.Logger(t, log.LvlDebug)
+	cfg, _ := setupSyncTestData(25)
+	var received []uint64
+	rcv := func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayloadEnvelope) error {
+		received = append(received, uint64(payload.ExecutionPayload.BlockNumber))
+		return nil
+	}
+	cl := NewSyncClient(log, cfg, nil, rcv, metrics.NoopMetrics, &NoopApplicationScorer{})
+	ctx := context.Background()
+
+	drain := func() (out []uint64) {
+		for {
+			select {
+			case pr := <-cl.peerRequests:
+				out = append(out, pr.num)
+			default:
+				return out
+			}
+		}
+	}
+	hash := func(num uint64) common.Hash { return common.Hash{byte(num)} }
+	result := func(num uint64) syncResult {
+		return syncResult{payload: &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{
+			BlockNumber: eth.Uint64Quantity(num),
+			BlockHash:   hash(num),
+			ParentHash:  hash(num - 1),
+		}}}
+	}
+
+	req := rangeRequest{start: 10, end: eth.L2BlockRef{Hash: hash(15), ParentHash: hash(14), Number: 15}}
+	cl.onRangeRequest(ctx, req)
+	require.Equal(t, []uint64{14, 13, 12, 11}, drain())
+
+	// blocks 14 and 13 are synced and promoted, the other requests complete without a result
+	cl.onResult(ctx, result(14))
+	cl.onResult(ctx, result(13))
+	require.Equal(t, []uint64{14, 13}, received)
+	for _, inFlight := range cl.inFlight {
+		inFlight.requests[0].Store(true)
+	}
+
+	cl.onRangeRequest(ctx, req)
+	require.Equal(t, []uint64{12, 11}, drain(), "should not request promoted blocks again")
+
+	// a new target starts from its own end
+	newReq := rangeRequest{start: 10, end: eth.L2BlockRef{Hash: hash(16), ParentHash: hash(15), Number: 16}}
+	for _, inFlight := range cl.inFlight {
+		inFlight.requests[0].Store(true)
+	}
+	cl.onRangeRequest(ctx, newReq)
+	require.Equal(t, []uint64{15, 14, 13, 12, 11}, drain())
+}
@@ -11,6 +11,9 @@ import (
 type SequencerConductor interface {
 	Leader(ctx context.Context) (bool, error)
 	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
+	// OverrideLeader forces this node to act as the leader sequencer, bypassing the conductor.
+	// It is meant for emergencies where the conductor is unavailable or misbehaving.
+	OverrideLeader(ctx context.Context) error
 	Close()
 }
 
@@ -27,5 +30,10 @@ func (c *NoOpConductor) CommitUnsafePayload(ctx context.Context, payload *eth.Ex
 	return nil
 }
 
+// OverrideLeader is a no-op, as NoOpConductor always assumes this node is the leader.
+func (c *NoOpConductor) OverrideLeader(ctx context.Context) error {
+	return nil
+}
+
 // Close closes the conductor client.
 func (c *NoOpConductor) Close() {}
@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -60,15 +61,18 @@ type BatchQueue struct {
 	nextSpan []*SingularBatch
 
 	l2 SafeBlockFetcher
+
+	syncCfg *sync.Config
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
-func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher) *BatchQueue {
+func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher, syncCfg *sync.Config) *BatchQueue {
 	return &BatchQueue{
-		log:    log,
-		config: cfg,
-		prev:   prev,
-		l2:     l2,
+		log:     log,
+		config:  cfg,
+		prev:    prev,
+		l2:      l2,
+		syncCfg: syncCfg,
 	}
 }
 
@@ -76,6 +80,10 @@ func (bq *BatchQueue) Origin() eth.L1BlockRef {
 	return bq.prev.Origin()
 }
 
+func (bq *BatchQueue) queueDepth() int {
+	return len(bq.batches) + len(bq.nextSpan)
+}
+
 // popNextBatch pops the next batch from the current queued up span-batch nextSpan.
 // The queue must be non-empty, or the function will panic.
 func (bq *BatchQueue) popNextBatch(parent eth.L2BlockRef) *SingularBatch {
@@ -228,6 +236,10 @@ func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, parent eth.L2Bl
 	if len(bq.l1Blocks) == 0 {
 		panic(fmt.Errorf("cannot add batch with timestamp %d, no origin was prepared", batch.GetTimestamp()))
 	}
+	if bq.syncCfg.DepositsOnly {
+		batch.LogContext(bq.log).Warn("Ignoring batch, only deriving deposits")
+		return
+	}
 	data := BatchWithL1InclusionBlock{
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
@@ -302,7 +314,7 @@ batchLoop:
 	}
 
 	// If the current epoch is too old compared to the L1 block we are at,
-	// i.e. if the sequence window expired, we create empty batches for the current epoch
+	// i.e. if the sequence window expired, we create empty batches for the current epoch.
 	expiryEpoch := epoch.Number + bq.config.SeqWindowSize
 	forceEmptyBatches := (expiryEpoch == bq.origin.Number && outOfData) || expiryEpoch < bq.origin.Number
 	firstOfEpoch := epoch.Number == parent.L1Origin.Number+1
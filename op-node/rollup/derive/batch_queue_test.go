@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
@@ -193,7 +194,7 @@ func BatchQueueNewOrigin(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, &sync.Config{})
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	require.Equal(t, []eth.L1BlockRef{l1[0]}, bq.l1Blocks)
 
@@ -282,7 +283,7 @@ func BatchQueueEager(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, &sync.Config{})
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -360,7 +361,7 @@ func BatchQueueInvalidInternalAdvance(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, &sync.Config{})
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	// Load continuous batches for epoch 0
@@ -475,7 +476,7 @@ func BatchQueueMissing(t *testing.T, batchType int) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, &sync.Config{})
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -602,7 +603,7 @@ func BatchQueueAdvancedEpoch(t *testing.T, batchType int) {
 		origin:  l1[inputOriginNumber],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, &sync.Config{})
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -693,7 +694,7 @@ func BatchQueueShuffle(t *testing.T, batchType int) {
 		origin:  l1[inputOriginNumber],
 	}
 
-	bq := NewBatchQueue(log, cfg, input, nil)
+	bq := NewBatchQueue(log, cfg, input, nil, &sync.Config{})
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -808,7 +809,7 @@ func TestBatchQueueOverlappingSpanBatch(t *testing.T) {
 		}
 	}
 
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, &sync.Config{})
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -914,7 +915,7 @@ func TestBatchQueueComplex(t *testing.T) {
 		}
 	}
 
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, &sync.Config{})
 	_ = bq.Reset(context.Background(), l1[1], eth.SystemConfig{})
 
 	for i := 0; i < len(expectedOutputBatches); i++ {
@@ -984,7 +985,7 @@ func TestBatchQueueResetSpan(t *testing.T) {
 		origin:  l1[2],
 	}
 	l2Client := testutils.MockL2Client{}
-	bq := NewBatchQueue(log, cfg, input, &l2Client)
+	bq := NewBatchQueue(log, cfg, input, &l2Client, &sync.Config{})
 	bq.l1Blocks = l1 // Set enough l1 blocks to derive span batch
 
 	// This NextBatch() will derive the span batch, return the first singular batch and save rest of batches in span.
@@ -1014,3 +1015,73 @@ func TestBatchQueueResetSpan(t *testing.T) {
 	require.ErrorIs(t, err, io.EOF)
 	require.Equal(t, len(bq.nextSpan), 0)
 }
+
+// TestBatchQueueDepositsOnly tests that valid batches are ignored when only deriving deposits,
+// and that empty batches are only generated once the sequencing window expires.
+func TestBatchQueueDepositsOnly(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	l1 := L1Chain([]uint64{10, 15, 20, 25})
+	chainId := big.NewInt(1234)
+	safeHead := eth.L2BlockRef{
+		Hash:           mockHash(10, 2),
+		Number:         0,
+		ParentHash:     common.Hash{},
+		Time:           10,
+		L1Origin:       l1[0].ID(),
+		SequenceNumber: 0,
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		SeqWindowSize:     2,
+		L2ChainID:         chainId,
+	}
+
+	// a valid batch, which would be accepted outside of deposits-only mode
+	input := &fakeBatchQueueInput{
+		batches: []Batch{b(chainId, 12, l1[0])},
+		errors:  []error{nil},
+		origin:  l1[0],
+	}
+	bq := NewBatchQueue(log, cfg, input, nil, &sync.Config{DepositsOnly: true})
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+
+	// The batch is ignored.
+	_, _, err := bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, NotEnoughData)
+	require.Empty(t, bq.batches)
+	_, _, err = bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, io.EOF)
+
+	// The next L1 origin is known, but the sequencing window has not expired yet, so no empty batch is generated.
+	input.origin = l1[1]
+	_, _, err = bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, []eth.L1BlockRef{l1[0], l1[1]}, bq.l1Blocks)
+
+	// Once the sequencing window expired, empty batches are generated up to the time of the next L1 origin.
+	input.origin = l1[cfg.SeqWindowSize]
+	for _, ts := range []uint64{12, 14} {
+		batch, isLastInSpan, err := bq.NextBatch(context.Background(), safeHead)
+		require.NoError(t, err)
+		require.True(t, isLastInSpan)
+		require.Equal(t, &SingularBatch{
+			ParentHash: safeHead.Hash,
+			EpochNum:   rollup.Epoch(l1[0].Number),
+			EpochHash:  l1[0].Hash,
+			Timestamp:  ts,
+		}, batch)
+		safeHead.Number += 1
+		safeHead.Time = ts
+		safeHead.Hash = mockHash(ts, 2)
+	}
+	// All the empty batches of the epoch were generated, the next epoch has to wait for its own sequencing window.
+	_, _, err = bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, []eth.L1BlockRef{l1[1], l1[2]}, bq.l1Blocks)
+	_, _, err = bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, io.EOF)
+}
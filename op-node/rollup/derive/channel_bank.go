@@ -60,6 +60,10 @@ func (cb *ChannelBank) Origin() eth.L1BlockRef {
 	return cb.prev.Origin()
 }
 
+func (cb *ChannelBank) queueDepth() int {
+	return len(cb.channelQueue)
+}
+
 func (cb *ChannelBank) prune() {
 	// check total size
 	totalSize := uint64(0)
@@ -0,0 +1,147 @@
+package derive
+
+import (
+	"bufio"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionAlgo is the algorithm that channel data is compressed with.
+type CompressionAlgo string
+
+const (
+	Zlib CompressionAlgo = "zlib"
+	// Zstd compressed channel data is only valid once the ZstdChannel upgrade is active.
+	Zstd CompressionAlgo = "zstd"
+)
+
+var CompressionAlgos = []CompressionAlgo{
+	Zlib,
+	Zstd,
+}
+
+func (algo CompressionAlgo) String() string {
+	return string(algo)
+}
+
+func ValidCompressionAlgo(value CompressionAlgo) bool {
+	for _, k := range CompressionAlgos {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Zlib compressed channel data has no version byte, it starts with the zlib header.
+const (
+	// ChannelVersionBrotli is the version byte that Fjord assigns to brotli compressed channel data.
+	// Brotli is not supported, the version byte is reserved so it is never reused for other algorithms.
+	ChannelVersionBrotli byte = 0x01
+	// ChannelVersionZstd is the version byte that prefixes zstd compressed channel data.
+	ChannelVersionZstd byte = 0x02
+)
+
+// zstdWindowSize bounds the memory used to compress and decompress zstd channel data.
+// It is larger than channels are expected to be.
+const zstdWindowSize = 8 << 20
+
+// CompressionWriter is the writer of a compression algorithm, as implemented by zlib.Writer.
+type CompressionWriter interface {
+	io.WriteCloser
+	Flush() error
+	// Reset discards the writer's state and makes it write to w, as if newly created.
+	Reset(w io.Writer)
+}
+
+// NewCompressionWriter returns a writer that compresses channel data with the given algorithm, at its
+// best compression level, and writes it to w. This includes the version byte of the algorithm, if any.
+func NewCompressionWriter(algo CompressionAlgo, w io.Writer) (CompressionWriter, error) {
+	switch algo {
+	case Zlib, "":
+		zw, err := zlib.NewWriterLevel(w, zlib.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		return zw, nil
+	case Zstd:
+		enc, err := zstd.NewWriter(nil,
+			zstd.WithEncoderLevel(zstd.SpeedBestCompression),
+			zstd.WithEncoderConcurrency(1),
+			zstd.WithWindowSize(zstdWindowSize))
+		if err != nil {
+			return nil, err
+		}
+		zw := &zstdWriter{enc: enc}
+		zw.Reset(w)
+		return zw, nil
+	default:
+		return nil, fmt.Errorf("unknown compression algorithm: %q", algo)
+	}
+}
+
+// zstdWriter prefixes the zstd stream with the ChannelVersionZstd byte.
+type zstdWriter struct {
+	enc *zstd.Encoder
+	// err is the error of writing the version byte, if any.
+	err error
+}
+
+func (w *zstdWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.enc.Write(p)
+}
+
+func (w *zstdWriter) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	return w.enc.Flush()
+}
+
+func (w *zstdWriter) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	return w.enc.Close()
+}
+
+func (w *zstdWriter) Reset(dst io.Writer) {
+	_, w.err = dst.Write([]byte{ChannelVersionZstd})
+	w.enc.Reset(dst)
+}
+
+// newDecompressionReader returns a reader of the decompressed channel data.
+// The compression algorithm is detected from the first byte of the data.
+// Zstd compressed data is only accepted if isZstdChannel is true.
+func newDecompressionReader(r io.Reader, isZstdChannel bool) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	version, err := br.Peek(1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read channel version: %w", err)
+	}
+	switch version[0] {
+	case ChannelVersionZstd:
+	case ChannelVersionBrotli:
+		return nil, errors.New("brotli compressed channel data is not supported")
+	default:
+		return zlib.NewReader(br)
+	}
+	if !isZstdChannel {
+		return nil, errors.New("zstd compressed channel data is not supported before the ZstdChannel upgrade")
+	}
+	_, _ = br.Discard(1)
+	zr, err := zstd.NewReader(br,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxWindow(zstdWindowSize))
+	if err != nil {
+		return nil, err
+	}
+	return zr, nil
+}
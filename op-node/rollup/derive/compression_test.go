@@ -0,0 +1,67 @@
+package derive
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestCompressionRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5a1d))
+	chainID := big.NewInt(rng.Int63n(1000))
+	batch := NewBatchData(RandomSingularBatch(rng, 20, chainID))
+
+	for _, algo := range CompressionAlgos {
+		t.Run(algo.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			w, err := NewCompressionWriter(algo, &buf)
+			require.NoError(t, err)
+			require.NoError(t, rlp.Encode(w, batch))
+			require.NoError(t, w.Close())
+
+			readBatch, err := BatchReader(bytes.NewReader(buf.Bytes()), true)
+			require.NoError(t, err)
+			decoded, err := readBatch()
+			require.NoError(t, err)
+			require.Equal(t, batch.GetBatchType(), decoded.GetBatchType())
+			require.Equal(t, batch.inner, decoded.inner)
+
+			// A reset writer produces the same output again, including the version byte.
+			var resetBuf bytes.Buffer
+			w.Reset(&resetBuf)
+			require.NoError(t, rlp.Encode(w, batch))
+			require.NoError(t, w.Close())
+			require.Equal(t, buf.Bytes(), resetBuf.Bytes())
+		})
+	}
+}
+
+func TestCompressionZstdRequiresUpgrade(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewCompressionWriter(Zstd, &buf)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("channel data"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	require.Equal(t, ChannelVersionZstd, buf.Bytes()[0])
+
+	_, err = BatchReader(bytes.NewReader(buf.Bytes()), false)
+	require.ErrorContains(t, err, "before the ZstdChannel upgrade")
+}
+
+func TestCompressionBrotliNotSupported(t *testing.T) {
+	_, err := BatchReader(bytes.NewReader([]byte{ChannelVersionBrotli, 0x1b}), true)
+	require.ErrorContains(t, err, "brotli")
+}
+
+func TestCompressionUnknownAlgo(t *testing.T) {
+	_, err := NewCompressionWriter("brotli", new(bytes.Buffer))
+	require.ErrorContains(t, err, "unknown compression algorithm")
+	require.False(t, ValidCompressionAlgo("brotli"))
+	require.True(t, ValidCompressionAlgo(Zstd))
+}
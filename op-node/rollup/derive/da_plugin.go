@@ -0,0 +1,68 @@
+package derive
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// L1DASourceName is the name of the default DA source, which reads batch data from L1 calldata and blobs.
+const L1DASourceName = "l1"
+
+// DASourcePlugin creates an alternative DataAvailabilitySource to derive from.
+// The l1 source reads the data that the batcher submitted to L1. Alternative DA sources typically
+// read commitments from it, and resolve them to the batch data stored on another DA layer.
+type DASourcePlugin func(log log.Logger, cfg *rollup.Config, l1 DataAvailabilitySource) (DataAvailabilitySource, error)
+
+var (
+	daPluginsLock sync.RWMutex
+	daPlugins     = make(map[string]DASourcePlugin)
+)
+
+// RegisterDASource registers a DA source plugin, so it can be selected by name in the node configuration.
+// Plugins are compiled into the node by importing their package, which registers them in an init function.
+// It panics if a DA source with the same name is already registered.
+func RegisterDASource(name string, plugin DASourcePlugin) {
+	daPluginsLock.Lock()
+	defer daPluginsLock.Unlock()
+	if _, ok := daPlugins[name]; ok || name == L1DASourceName {
+		panic(fmt.Errorf("DA source %q already registered", name))
+	}
+	daPlugins[name] = plugin
+}
+
+// DASourceNames returns the sorted names of all DA sources that can be selected.
+func DASourceNames() []string {
+	daPluginsLock.RLock()
+	defer daPluginsLock.RUnlock()
+	names := []string{L1DASourceName}
+	for name := range daPlugins {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// NewDASource creates the DA source with the given name, reading L1 data with the given fetchers.
+// An empty name selects the default L1 source.
+func NewDASource(name string, log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher) (DataAvailabilitySource, error) {
+	l1 := NewDataSourceFactory(log, cfg, fetcher, blobsFetcher)
+	if name == "" || name == L1DASourceName {
+		return l1, nil
+	}
+	daPluginsLock.RLock()
+	plugin, ok := daPlugins[name]
+	daPluginsLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown DA source %q, expected one of %v", name, DASourceNames())
+	}
+	src, err := plugin(log.New("da_source", name), cfg, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create DA source %q: %w", name, err)
+	}
+	return src, nil
+}
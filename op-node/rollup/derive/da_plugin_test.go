@@ -0,0 +1,71 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+// wrappingDASource is an alternative DA source, that reads commitments from the L1 source.
+type wrappingDASource struct {
+	l1 DataAvailabilitySource
+}
+
+func (s *wrappingDASource) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error) {
+	return s.l1.OpenData(ctx, ref, batcherAddr)
+}
+
+func TestNewDASource(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	cfg := &rollup.Config{}
+	l1 := &testutils.MockL1Source{}
+
+	t.Run("Default", func(t *testing.T) {
+		for _, name := range []string{"", L1DASourceName} {
+			src, err := NewDASource(name, logger, cfg, l1, nil)
+			require.NoError(t, err)
+			require.IsType(t, &DataSourceFactory{}, src)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := NewDASource("unknown", logger, cfg, l1, nil)
+		require.ErrorContains(t, err, "unknown DA source")
+	})
+
+	t.Run("Plugin", func(t *testing.T) {
+		RegisterDASource("test-commitments", func(log log.Logger, cfg *rollup.Config, l1 DataAvailabilitySource) (DataAvailabilitySource, error) {
+			return &wrappingDASource{l1: l1}, nil
+		})
+		require.Contains(t, DASourceNames(), "test-commitments")
+		require.Contains(t, DASourceNames(), L1DASourceName)
+		src, err := NewDASource("test-commitments", logger, cfg, l1, nil)
+		require.NoError(t, err)
+		require.IsType(t, &DataSourceFactory{}, src.(*wrappingDASource).l1, "plugin should wrap the L1 source")
+
+		require.Panics(t, func() {
+			RegisterDASource("test-commitments", nil)
+		})
+		require.Panics(t, func() {
+			RegisterDASource(L1DASourceName, nil)
+		})
+	})
+
+	t.Run("PluginError", func(t *testing.T) {
+		pluginErr := errors.New("no DA server configured")
+		RegisterDASource("test-failing", func(log log.Logger, cfg *rollup.Config, l1 DataAvailabilitySource) (DataAvailabilitySource, error) {
+			return nil, pluginErr
+		})
+		_, err := NewDASource("test-failing", logger, cfg, l1, nil)
+		require.ErrorIs(t, err, pluginErr)
+	})
+}
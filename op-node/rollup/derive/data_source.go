@@ -16,6 +16,11 @@ type DataIter interface {
 	Next(ctx context.Context) (eth.Data, error)
 }
 
+// DataAvailabilitySource provides the batch data that the batcher submitted in an L1 block.
+type DataAvailabilitySource interface {
+	OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error)
+}
+
 type L1TransactionFetcher interface {
 	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
 }
@@ -27,32 +32,75 @@ type L1BlobsFetcher interface {
 
 // DataSourceFactory reads raw transactions from a given block & then filters for
 // batch submitter transactions.
+// It reads calldata before the Ecotone upgrade, and blobs as well after it.
 // This is not a stage in the pipeline, but a wrapper for another stage in the pipeline
 type DataSourceFactory struct {
-	log          log.Logger
-	dsCfg        DataSourceConfig
-	fetcher      L1TransactionFetcher
-	blobsFetcher L1BlobsFetcher
-	ecotoneTime  *uint64
+	calldata    *CalldataDASource
+	blobs       *BlobDASource
+	ecotoneTime *uint64
 }
 
+var _ DataAvailabilitySource = (*DataSourceFactory)(nil)
+
 func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher) *DataSourceFactory {
 	config := DataSourceConfig{
 		l1Signer:          cfg.L1Signer(),
 		batchInboxAddress: cfg.BatchInboxAddress,
 	}
-	return &DataSourceFactory{log: log, dsCfg: config, fetcher: fetcher, blobsFetcher: blobsFetcher, ecotoneTime: cfg.EcotoneTime}
+	factory := &DataSourceFactory{
+		calldata:    NewCalldataDASource(log, config, fetcher),
+		ecotoneTime: cfg.EcotoneTime,
+	}
+	if blobsFetcher != nil {
+		factory.blobs = NewBlobDASource(log, config, fetcher, blobsFetcher)
+	}
+	return factory
 }
 
 // OpenData returns the appropriate data source for the L1 block `ref`.
 func (ds *DataSourceFactory) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error) {
 	if ds.ecotoneTime != nil && ref.Time >= *ds.ecotoneTime {
-		if ds.blobsFetcher == nil {
+		if ds.blobs == nil {
 			return nil, fmt.Errorf("ecotone upgrade active but beacon endpoint not configured")
 		}
-		return NewBlobDataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ds.blobsFetcher, ref, batcherAddr), nil
+		return ds.blobs.OpenData(ctx, ref, batcherAddr)
 	}
-	return NewCalldataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ref, batcherAddr), nil
+	return ds.calldata.OpenData(ctx, ref, batcherAddr)
+}
+
+// CalldataDASource reads batch data from the calldata of batcher transactions.
+type CalldataDASource struct {
+	log     log.Logger
+	dsCfg   DataSourceConfig
+	fetcher L1TransactionFetcher
+}
+
+var _ DataAvailabilitySource = (*CalldataDASource)(nil)
+
+func NewCalldataDASource(log log.Logger, dsCfg DataSourceConfig, fetcher L1TransactionFetcher) *CalldataDASource {
+	return &CalldataDASource{log: log, dsCfg: dsCfg, fetcher: fetcher}
+}
+
+func (s *CalldataDASource) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error) {
+	return NewCalldataSource(ctx, s.log, s.dsCfg, s.fetcher, ref, batcherAddr), nil
+}
+
+// BlobDASource reads batch data from the blobs, and the calldata, of batcher transactions.
+type BlobDASource struct {
+	log          log.Logger
+	dsCfg        DataSourceConfig
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
+}
+
+var _ DataAvailabilitySource = (*BlobDASource)(nil)
+
+func NewBlobDASource(log log.Logger, dsCfg DataSourceConfig, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher) *BlobDASource {
+	return &BlobDASource{log: log, dsCfg: dsCfg, fetcher: fetcher, blobsFetcher: blobsFetcher}
+}
+
+func (s *BlobDASource) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error) {
+	return NewBlobDataSource(ctx, s.log, s.dsCfg, s.fetcher, s.blobsFetcher, ref, batcherAddr), nil
 }
 
 // DataSourceConfig regroups the mandatory rollup.Config fields needed for DataFromEVMTransactions.
@@ -0,0 +1,85 @@
+package derive
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func elSyncPayload(num uint64) (*eth.ExecutionPayloadEnvelope, eth.L2BlockRef) {
+	payload := &eth.ExecutionPayload{
+		ParentHash:  common.Hash{byte(num - 1)},
+		BlockHash:   common.Hash{byte(num)},
+		BlockNumber: eth.Uint64Quantity(num),
+	}
+	ref := eth.L2BlockRef{Hash: payload.BlockHash, Number: num, ParentHash: payload.ParentHash}
+	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}, ref
+}
+
+func TestEngineController_ELSync(t *testing.T) {
+	setup := func(t *testing.T) (*EngineController, *testutils.MockEngine) {
+		eng := &testutils.MockEngine{}
+		ec := NewEngineController(eng, testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics, &rollup.Config{}, sync.ELSync)
+		require.True(t, ec.IsEngineSyncing(), "should start in EL sync")
+		return ec, eng
+	}
+
+	t.Run("SkipWhenFinalizedBlockExists", func(t *testing.T) {
+		ec, eng := setup(t)
+		eng.ExpectL2BlockRefByLabel(eth.Finalized, eth.L2BlockRef{Number: 5}, nil)
+		envelope, ref := elSyncPayload(10)
+		require.NoError(t, ec.InsertUnsafePayload(context.Background(), envelope, ref))
+		require.False(t, ec.IsEngineSyncing(), "should switch to derivation immediately")
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("SwitchToDerivationWhenSynced", func(t *testing.T) {
+		ec, eng := setup(t)
+		eng.ExpectL2BlockRefByLabel(eth.Finalized, eth.L2BlockRef{}, ethereum.NotFound)
+
+		// The engine accepts payloads while it is still syncing towards them.
+		envelope, ref := elSyncPayload(10)
+		eng.ExpectNewPayload(envelope.ExecutionPayload, nil, &eth.PayloadStatusV1{Status: eth.ExecutionSyncing}, nil)
+		eng.ExpectForkchoiceUpdate(&eth.ForkchoiceState{HeadBlockHash: ref.Hash},
+			nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionSyncing}}, nil)
+		require.NoError(t, ec.InsertUnsafePayload(context.Background(), envelope, ref))
+		require.True(t, ec.IsEngineSyncing())
+		require.Equal(t, ref, ec.UnsafeL2Head())
+		require.Equal(t, eth.L2BlockRef{}, ec.Finalized())
+
+		// Once the engine has synced, the synced block is marked as finalized so derivation can continue from it.
+		envelope, ref = elSyncPayload(11)
+		eng.ExpectNewPayload(envelope.ExecutionPayload, nil, &eth.PayloadStatusV1{Status: eth.ExecutionValid}, nil)
+		eng.ExpectForkchoiceUpdate(&eth.ForkchoiceState{HeadBlockHash: ref.Hash, SafeBlockHash: ref.Hash, FinalizedBlockHash: ref.Hash},
+			nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil)
+		require.NoError(t, ec.InsertUnsafePayload(context.Background(), envelope, ref))
+		require.False(t, ec.IsEngineSyncing(), "should switch to derivation once synced")
+		require.Equal(t, ref, ec.UnsafeL2Head())
+		require.Equal(t, ref, ec.SafeL2Head())
+		require.Equal(t, ref, ec.Finalized())
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("RejectInvalidPayload", func(t *testing.T) {
+		ec, eng := setup(t)
+		eng.ExpectL2BlockRefByLabel(eth.Finalized, eth.L2BlockRef{}, ethereum.NotFound)
+		envelope, ref := elSyncPayload(10)
+		eng.ExpectNewPayload(envelope.ExecutionPayload, nil, &eth.PayloadStatusV1{Status: eth.ExecutionInvalid}, nil)
+		err := ec.InsertUnsafePayload(context.Background(), envelope, ref)
+		require.ErrorIs(t, err, ErrTemporary)
+		require.True(t, ec.IsEngineSyncing())
+		require.Equal(t, eth.L2BlockRef{}, ec.UnsafeL2Head())
+		eng.AssertExpectations(t)
+	})
+}
@@ -92,6 +92,27 @@ type LocalEngineControl interface {
 	SetPendingSafeL2Head(eth.L2BlockRef)
 }
 
+// SafeHeadListener is notified of every change of the safe head made by derivation,
+// to track which L1 block every safe head was derived from.
+type SafeHeadListener interface {
+	// SafeHeadUpdated indicates that the safe head was updated by processing batch data.
+	// All the data to derive the new safe head was available in the L1 chain up to and including l1Block.
+	SafeHeadUpdated(newSafeHead eth.L2BlockRef, l1Block eth.BlockID) error
+	// SafeHeadReset indicates that the derivation pipeline was reset back to the given safe head.
+	SafeHeadReset(resetSafeHead eth.L2BlockRef) error
+}
+
+// NoOpSafeHeadListener ignores all safe head changes, for when the safe head history is not needed.
+type NoOpSafeHeadListener struct{}
+
+func (n *NoOpSafeHeadListener) SafeHeadUpdated(_ eth.L2BlockRef, _ eth.BlockID) error {
+	return nil
+}
+
+func (n *NoOpSafeHeadListener) SafeHeadReset(_ eth.L2BlockRef) error {
+	return nil
+}
+
 // Max memory used for buffering unsafe payloads
 const maxUnsafePayloadsMemory = 500 * 1024 * 1024
 
@@ -153,10 +174,12 @@ type EngineQueue struct {
 	l1Fetcher L1Fetcher
 
 	syncCfg *sync.Config
+
+	safeHeadNotifs SafeHeadListener
 }
 
 // NewEngineQueue creates a new EngineQueue, which should be Reset(origin) before use.
-func NewEngineQueue(log log.Logger, cfg *rollup.Config, l2Source L2Source, engine LocalEngineControl, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config) *EngineQueue {
+func NewEngineQueue(log log.Logger, cfg *rollup.Config, l2Source L2Source, engine LocalEngineControl, metrics Metrics, prev NextAttributesProvider, l1Fetcher L1Fetcher, syncCfg *sync.Config, safeHeadNotifs SafeHeadListener) *EngineQueue {
 	return &EngineQueue{
 		log:            log,
 		cfg:            cfg,
@@ -168,6 +191,7 @@ func NewEngineQueue(log log.Logger, cfg *rollup.Config, l2Source L2Source, engin
 		prev:           prev,
 		l1Fetcher:      l1Fetcher,
 		syncCfg:        syncCfg,
+		safeHeadNotifs: safeHeadNotifs,
 	}
 }
 
@@ -176,6 +200,10 @@ func (eq *EngineQueue) Origin() eth.L1BlockRef {
 	return eq.origin
 }
 
+func (eq *EngineQueue) queueDepth() int {
+	return eq.unsafePayloads.Len()
+}
+
 func (eq *EngineQueue) SystemConfig() eth.SystemConfig {
 	return eq.sysCfg
 }
@@ -403,6 +431,14 @@ func (eq *EngineQueue) postProcessSafeL2() {
 	}
 }
 
+// notifySafeHeadUpdated records the L1 block the new safe head was derived from with the safe head listener.
+func (eq *EngineQueue) notifySafeHeadUpdated() error {
+	if err := eq.safeHeadNotifs.SafeHeadUpdated(eq.ec.SafeL2Head(), eq.origin.ID()); err != nil {
+		return NewCriticalError(fmt.Errorf("failed to notify safe head listener: %w", err))
+	}
+	return nil
+}
+
 func (eq *EngineQueue) logSyncProgress(reason string) {
 	eq.log.Info("Sync progress",
 		"reason", reason,
@@ -521,6 +557,9 @@ func (eq *EngineQueue) consolidateNextSafeAttributes(ctx context.Context) error
 	if eq.safeAttributes.isLastInSpan {
 		eq.ec.SetSafeHead(ref)
 		eq.postProcessSafeL2()
+		if err := eq.notifySafeHeadUpdated(); err != nil {
+			return err
+		}
 	}
 	// unsafe head stays the same, we did not reorg the chain.
 	eq.safeAttributes = nil
@@ -582,6 +621,9 @@ func (eq *EngineQueue) forceNextSafeAttributes(ctx context.Context) error {
 	eq.logSyncProgress("processed safe block derived from L1")
 	if lastInSpan {
 		eq.postProcessSafeL2()
+		if err := eq.notifySafeHeadUpdated(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -611,6 +653,7 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 		return NewTemporaryError(fmt.Errorf("failed to find the L2 Heads to start from: %w", err))
 	}
 	finalized, safe, unsafe := result.Finalized, result.Safe, result.Unsafe
+	eq.recordReorg(eq.ec.UnsafeL2Head(), eq.ec.SafeL2Head(), unsafe)
 	l1Origin, err := eq.l1Fetcher.L1BlockRefByHash(ctx, safe.L1Origin.Hash)
 	if err != nil {
 		return NewTemporaryError(fmt.Errorf("failed to fetch the new L1 progress: origin: %v; err: %w", safe.L1Origin, err))
@@ -645,6 +688,15 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 		return NewTemporaryError(fmt.Errorf("failed to fetch L1 config of L2 block %s: %w", pipelineL2.ID(), err))
 	}
 	eq.log.Debug("Reset engine queue", "safeHead", safe, "unsafe", unsafe, "safe_timestamp", safe.Time, "unsafe_timestamp", unsafe.Time, "l1Origin", l1Origin)
+	if err := eq.safeHeadNotifs.SafeHeadReset(safe); err != nil {
+		return NewCriticalError(fmt.Errorf("failed to notify safe head listener of reset: %w", err))
+	}
+	if safe.ID() == eq.cfg.Genesis.L2 {
+		// The genesis block is safe by definition, as of the L1 genesis block.
+		if err := eq.safeHeadNotifs.SafeHeadUpdated(safe, eq.cfg.Genesis.L1); err != nil {
+			return NewCriticalError(fmt.Errorf("failed to notify safe head listener of genesis: %w", err))
+		}
+	}
 	eq.ec.SetUnsafeHead(unsafe)
 	eq.ec.SetSafeHead(safe)
 	eq.ec.SetPendingSafeL2Head(safe)
@@ -660,6 +712,32 @@ func (eq *EngineQueue) Reset(ctx context.Context, _ eth.L1BlockRef, _ eth.System
 	return io.EOF
 }
 
+// recordReorg records the L2 blocks rewound by a reset, if the new unsafe head is behind the previous unsafe head.
+// A reset only rewinds the unsafe head past L2 blocks with non-canonical L1 origins,
+// and if the previous safe head is rewound too, the L1 reorg was deeper than the unsafe window.
+func (eq *EngineQueue) recordReorg(prevUnsafe, prevSafe, unsafe eth.L2BlockRef) {
+	if prevUnsafe == (eth.L2BlockRef{}) || unsafe.Number >= prevUnsafe.Number {
+		// Either the first reset after startup, or the unsafe chain was not reorged.
+		return
+	}
+	eq.metrics.RecordL2ReorgDepth("unsafe", prevUnsafe.Number-unsafe.Number)
+	if unsafe.Number >= prevSafe.Number {
+		eq.log.Warn("L1 reorg rewound unsafe L2 blocks", "unsafe", unsafe, "prev_unsafe", prevUnsafe,
+			"reorged_from", unsafe.Number+1, "reorged_to", prevUnsafe.Number)
+		return
+	}
+	var l1Depth uint64
+	if prevSafe.L1Origin.Number > unsafe.L1Origin.Number {
+		l1Depth = prevSafe.L1Origin.Number - unsafe.L1Origin.Number
+	}
+	eq.metrics.RecordL2ReorgDepth("safe", prevSafe.Number-unsafe.Number)
+	eq.metrics.RecordDeepL1Reorg(l1Depth)
+	eq.log.Warn("Deep L1 reorg rewound safe L2 blocks, walking back to the last canonical L1 origin",
+		"l1_depth", l1Depth, "canonical_l1_origin", unsafe.L1Origin, "prev_safe_l1_origin", prevSafe.L1Origin,
+		"unsafe", unsafe, "prev_unsafe", prevUnsafe, "prev_safe", prevSafe,
+		"reorged_from", unsafe.Number+1, "reorged_to", prevUnsafe.Number, "reorged_safe_to", prevSafe.Number)
+}
+
 // UnsafeL2SyncTarget retrieves the first queued-up L2 unsafe payload, or a zeroed reference if there is none.
 func (eq *EngineQueue) UnsafeL2SyncTarget() eth.L2BlockRef {
 	if first := eq.unsafePayloads.Peek(); first != nil {
@@ -251,7 +251,7 @@ func TestEngineQueue_Finalize(t *testing.T) {
 	prev := &fakeAttributesQueue{}
 
 	ec := NewEngineController(eng, logger, metrics, &rollup.Config{}, sync.CLSync)
-	eq := NewEngineQueue(logger, cfg, eng, ec, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, ec, metrics, prev, l1F, &sync.Config{}, &NoOpSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, ec.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -487,7 +487,7 @@ func TestEngineQueue_ResetWhenUnsafeOriginNotCanonical(t *testing.T) {
 	prev := &fakeAttributesQueue{origin: refE}
 
 	ec := NewEngineController(eng, logger, metrics, &rollup.Config{}, sync.CLSync)
-	eq := NewEngineQueue(logger, cfg, eng, ec, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, ec, metrics, prev, l1F, &sync.Config{}, &NoOpSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	require.Equal(t, refB1, ec.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -817,7 +817,7 @@ func TestVerifyNewL1Origin(t *testing.T) {
 
 			prev := &fakeAttributesQueue{origin: refE}
 			ec := NewEngineController(eng, logger, metrics, &rollup.Config{}, sync.CLSync)
-			eq := NewEngineQueue(logger, cfg, eng, ec, metrics, prev, l1F, &sync.Config{})
+			eq := NewEngineQueue(logger, cfg, eng, ec, metrics, prev, l1F, &sync.Config{}, &NoOpSafeHeadListener{})
 			require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 			require.Equal(t, refB1, ec.SafeL2Head(), "L2 reset should go back to sequence window ago: blocks with origin E and D are not safe until we reconcile, C is extra, and B1 is the end we look for")
@@ -914,7 +914,7 @@ func TestBlockBuildingRace(t *testing.T) {
 
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs, islastInSpan: true}
 	ec := NewEngineController(eng, logger, metrics, &rollup.Config{}, sync.CLSync)
-	eq := NewEngineQueue(logger, cfg, eng, ec, metrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, ec, metrics, prev, l1F, &sync.Config{}, &NoOpSafeHeadListener{})
 	require.ErrorIs(t, eq.Reset(context.Background(), eth.L1BlockRef{}, eth.SystemConfig{}), io.EOF)
 
 	id := eth.PayloadID{0xff}
@@ -1086,7 +1086,7 @@ func TestResetLoop(t *testing.T) {
 	prev := &fakeAttributesQueue{origin: refA, attrs: attrs, islastInSpan: true}
 
 	ec := NewEngineController(eng, logger, metrics.NoopMetrics, &rollup.Config{}, sync.CLSync)
-	eq := NewEngineQueue(logger, cfg, eng, ec, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, ec, metrics.NoopMetrics, prev, l1F, &sync.Config{}, &NoOpSafeHeadListener{})
 	eq.ec.SetUnsafeHead(refA2)
 	eq.ec.SetSafeHead(refA1)
 	eq.ec.SetFinalizedHead(refA0)
@@ -1192,7 +1192,7 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 	prev := &fakeAttributesQueue{origin: refA}
 
 	ec := NewEngineController(eng, logger, metrics.NoopMetrics, &rollup.Config{}, sync.CLSync)
-	eq := NewEngineQueue(logger, cfg, eng, ec, metrics.NoopMetrics, prev, l1F, &sync.Config{})
+	eq := NewEngineQueue(logger, cfg, eng, ec, metrics.NoopMetrics, prev, l1F, &sync.Config{}, &NoOpSafeHeadListener{})
 	eq.ec.SetUnsafeHead(refA2)
 	eq.ec.SetSafeHead(refA0)
 	eq.ec.SetFinalizedHead(refA0)
@@ -1217,3 +1217,53 @@ func TestEngineQueue_StepPopOlderUnsafe(t *testing.T) {
 	l1F.AssertExpectations(t)
 	eng.AssertExpectations(t)
 }
+
+func TestEngineQueue_RecordReorg(t *testing.T) {
+	l2Ref := func(num uint64, origin uint64) eth.L2BlockRef {
+		return eth.L2BlockRef{Hash: common.Hash{byte(num)}, Number: num, L1Origin: eth.BlockID{Number: origin}}
+	}
+	prevSafe := l2Ref(20, 10)
+	prevUnsafe := l2Ref(30, 15)
+
+	type recorded struct {
+		l2Depths map[string]uint64
+		l1Depth  *uint64
+	}
+	setup := func(t *testing.T) (*EngineQueue, *recorded) {
+		rec := &recorded{l2Depths: make(map[string]uint64)}
+		m := &testutils.TestDerivationMetrics{
+			FnRecordL2ReorgDepth: func(head string, d uint64) { rec.l2Depths[head] = d },
+			FnRecordDeepL1Reorg:  func(l1Depth uint64) { rec.l1Depth = &l1Depth },
+		}
+		return &EngineQueue{log: testlog.Logger(t, log.LvlInfo), metrics: m}, rec
+	}
+
+	t.Run("NoReorg", func(t *testing.T) {
+		eq, rec := setup(t)
+		eq.recordReorg(prevUnsafe, prevSafe, prevUnsafe)
+		require.Empty(t, rec.l2Depths)
+		require.Nil(t, rec.l1Depth)
+	})
+
+	t.Run("Startup", func(t *testing.T) {
+		eq, rec := setup(t)
+		eq.recordReorg(eth.L2BlockRef{}, eth.L2BlockRef{}, prevUnsafe)
+		require.Empty(t, rec.l2Depths)
+		require.Nil(t, rec.l1Depth)
+	})
+
+	t.Run("UnsafeReorg", func(t *testing.T) {
+		eq, rec := setup(t)
+		eq.recordReorg(prevUnsafe, prevSafe, l2Ref(25, 13))
+		require.Equal(t, map[string]uint64{"unsafe": 5}, rec.l2Depths)
+		require.Nil(t, rec.l1Depth)
+	})
+
+	t.Run("DeepReorg", func(t *testing.T) {
+		eq, rec := setup(t)
+		eq.recordReorg(prevUnsafe, prevSafe, l2Ref(12, 6))
+		require.Equal(t, map[string]uint64{"unsafe": 18, "safe": 8}, rec.l2Depths)
+		require.NotNil(t, rec.l1Depth)
+		require.Equal(t, uint64(4), *rec.l1Depth)
+	})
+}
@@ -33,6 +33,10 @@ func (fq *FrameQueue) Origin() eth.L1BlockRef {
 	return fq.prev.Origin()
 }
 
+func (fq *FrameQueue) queueDepth() int {
+	return len(fq.frames)
+}
+
 func (fq *FrameQueue) NextFrame(ctx context.Context) (Frame, error) {
 	// Find more frames if we need to
 	if len(fq.frames) == 0 {
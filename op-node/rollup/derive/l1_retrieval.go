@@ -5,16 +5,11 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
-type DataAvailabilitySource interface {
-	OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) (DataIter, error)
-}
-
 type NextBlockProvider interface {
 	NextL1Block(context.Context) (eth.L1BlockRef, error)
 	Origin() eth.L1BlockRef
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -22,6 +23,11 @@ type Metrics interface {
 	RecordChannelTimedOut()
 	RecordFrame()
 	RecordDerivedBatches(batchType string)
+	RecordL2ReorgDepth(head string, d uint64)
+	RecordDeepL1Reorg(l1Depth uint64)
+	RecordDerivationStageStep(stage string, result string, d time.Duration)
+	RecordDerivationQueueDepth(stage string, depth int)
+	RecordDerivationStageStalled(stage string, stalled bool)
 }
 
 type L1Fetcher interface {
@@ -63,26 +69,42 @@ type DerivationPipeline struct {
 	traversal *L1Traversal
 	eng       EngineQueueStage
 
+	// Tracers of the stages, ordered from L1 traversal to the engine queue
+	tracers         []*stageTracer
+	traversalTracer *stageTracer
+	engTracer       *stageTracer
+
 	metrics Metrics
 }
 
 // NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
 
-func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L1Fetcher, l1Blobs L1BlobsFetcher, l2Source L2Source, engine LocalEngineControl, metrics Metrics, syncCfg *sync.Config) *DerivationPipeline {
+// The dataSrc provides the batch data of each L1 block, see NewDataSourceFactory for the default L1 source.
+func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L1Fetcher, dataSrc DataAvailabilitySource, l2Source L2Source, engine LocalEngineControl, metrics Metrics, syncCfg *sync.Config, safeHeadListener SafeHeadListener) *DerivationPipeline {
+
+	// Every stage is traced where the next stage pulls from it.
+	var tracers []*stageTracer
+	trace := func(name string, stage tracedStage) *stageTracer {
+		t := newStageTracer(log, name, stage, rollupCfg.SeqWindowSize, metrics)
+		tracers = append(tracers, t)
+		return t
+	}
 
 	// Pull stages
 	l1Traversal := NewL1Traversal(log, rollupCfg, l1Fetcher)
-	dataSrc := NewDataSourceFactory(log, rollupCfg, l1Fetcher, l1Blobs) // auxiliary stage for L1Retrieval
+	traversalTracer := trace("l1_traversal", l1Traversal)
 	l1Src := NewL1Retrieval(log, dataSrc, l1Traversal)
-	frameQueue := NewFrameQueue(log, l1Src)
-	bank := NewChannelBank(log, rollupCfg, frameQueue, l1Fetcher, metrics)
-	chInReader := NewChannelInReader(rollupCfg, log, bank, metrics)
-	batchQueue := NewBatchQueue(log, rollupCfg, chInReader, l2Source)
+	frameQueue := NewFrameQueue(log, &tracedDataProvider{l1Src, trace("l1_retrieval", l1Src)})
+	bank := NewChannelBank(log, rollupCfg, &tracedFrameProvider{frameQueue, trace("frame_queue", frameQueue)}, l1Fetcher, metrics)
+	chInReader := NewChannelInReader(rollupCfg, log, &tracedDataProvider{bank, trace("channel_bank", bank)}, metrics)
+	batchQueue := NewBatchQueue(log, rollupCfg, &tracedBatchProvider{chInReader, trace("channel_in_reader", chInReader)}, l2Source, syncCfg)
 	attrBuilder := NewFetchingAttributesBuilder(rollupCfg, l1Fetcher, l2Source)
-	attributesQueue := NewAttributesQueue(log, rollupCfg, attrBuilder, batchQueue)
+	attributesQueue := NewAttributesQueue(log, rollupCfg, attrBuilder, &tracedSingularBatchProvider{batchQueue, trace("batch_queue", batchQueue)})
 
 	// Step stages
-	eng := NewEngineQueue(log, rollupCfg, l2Source, engine, metrics, attributesQueue, l1Fetcher, syncCfg)
+	eng := NewEngineQueue(log, rollupCfg, l2Source, engine, metrics,
+		&tracedAttributesProvider{attributesQueue, trace("attributes_queue", attributesQueue)}, l1Fetcher, syncCfg, safeHeadListener)
+	engTracer := trace("engine_queue", eng)
 
 	// Reset from engine queue then up from L1 Traversal. The stages do not talk to each other during
 	// the reset, but after the engine queue, this is the order in which the stages could talk to each other.
@@ -98,6 +120,10 @@ func NewDerivationPipeline(log log.Logger, rollupCfg *rollup.Config, l1Fetcher L
 		eng:       eng,
 		metrics:   metrics,
 		traversal: l1Traversal,
+
+		tracers:         tracers,
+		traversalTracer: traversalTracer,
+		engTracer:       engTracer,
 	}
 }
 
@@ -109,6 +135,22 @@ func (dp *DerivationPipeline) EngineReady() bool {
 
 func (dp *DerivationPipeline) Reset() {
 	dp.resetting = 0
+	for _, t := range dp.tracers {
+		t.reset()
+	}
+}
+
+// State returns a snapshot of the internals of every stage of the pipeline, for debugging.
+func (dp *DerivationPipeline) State() eth.DerivationPipelineState {
+	stages := make([]eth.DerivationStageState, 0, len(dp.tracers))
+	for _, t := range dp.tracers {
+		stages = append(stages, t.State())
+	}
+	return eth.DerivationPipelineState{
+		Origin:    dp.Origin(),
+		Resetting: dp.resetting < len(dp.stages),
+		Stages:    stages,
+	}
 }
 
 // Origin is the L1 block of the inner-most stage of the derivation pipeline,
@@ -161,9 +203,15 @@ func (dp *DerivationPipeline) Step(ctx context.Context) error {
 	}
 
 	// Now step the engine queue. It will pull earlier data as needed.
-	if err := dp.eng.Step(ctx); err == io.EOF {
+	start := time.Now()
+	err := dp.eng.Step(ctx)
+	dp.engTracer.record(start, err)
+	if err == io.EOF {
 		// If every stage has returned io.EOF, try to advance the L1 Origin
-		return dp.traversal.AdvanceL1Block(ctx)
+		start := time.Now()
+		err := dp.traversal.AdvanceL1Block(ctx)
+		dp.traversalTracer.record(start, err)
+		return err
 	} else if errors.Is(err, EngineELSyncing) {
 		return err
 	} else if err != nil {
@@ -0,0 +1,176 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Results of a stage step, as recorded in the stage metrics.
+const (
+	stepResultOK            = "ok"
+	stepResultEOF           = "eof"
+	stepResultNotEnoughData = "not_enough_data"
+	stepResultError         = "error"
+)
+
+type tracedStage interface {
+	Origin() eth.L1BlockRef
+}
+
+// queuedStage is implemented by stages that buffer data, to report their queue depth.
+type queuedStage interface {
+	queueDepth() int
+}
+
+// stageTracer records the step durations, queue depth and progress of a single pipeline stage.
+// A stage is considered stalled if its origin advanced by more than stallDistance L1 blocks
+// without the stage producing any output.
+type stageTracer struct {
+	log           log.Logger
+	metrics       Metrics
+	stage         tracedStage
+	stallDistance uint64
+
+	state eth.DerivationStageState
+	// progressOrigin is the origin of the last output, or of the first step after a reset.
+	progressOrigin eth.L1BlockRef
+}
+
+func newStageTracer(log log.Logger, name string, stage tracedStage, stallDistance uint64, m Metrics) *stageTracer {
+	return &stageTracer{
+		log:           log,
+		metrics:       m,
+		stage:         stage,
+		stallDistance: stallDistance,
+		state:         eth.DerivationStageState{Stage: name},
+	}
+}
+
+func (t *stageTracer) record(start time.Time, err error) {
+	d := time.Since(start)
+	origin := t.stage.Origin()
+	t.state.Steps++
+	t.state.LastStepDuration = uint64(d)
+	t.state.TotalStepDuration += uint64(d)
+
+	var result string
+	switch {
+	case err == nil:
+		result = stepResultOK
+		t.state.Outputs++
+		t.state.LastOutputTime = uint64(time.Now().Unix())
+		t.state.LastOutputOrigin = origin
+		t.progressOrigin = origin
+	case err == io.EOF:
+		result = stepResultEOF
+	case errors.Is(err, NotEnoughData):
+		result = stepResultNotEnoughData
+	default:
+		result = stepResultError
+		t.state.Errors++
+	}
+	t.metrics.RecordDerivationStageStep(t.state.Stage, result, d)
+	if q, ok := t.stage.(queuedStage); ok {
+		t.metrics.RecordDerivationQueueDepth(t.state.Stage, q.queueDepth())
+	}
+
+	if t.progressOrigin == (eth.L1BlockRef{}) {
+		t.progressOrigin = origin
+	}
+	t.setStalled(origin.Number > t.progressOrigin.Number+t.stallDistance, origin)
+}
+
+func (t *stageTracer) setStalled(stalled bool, origin eth.L1BlockRef) {
+	if t.state.Stalled == stalled {
+		return
+	}
+	t.state.Stalled = stalled
+	t.metrics.RecordDerivationStageStalled(t.state.Stage, stalled)
+	if stalled {
+		t.log.Warn("Derivation stage has not produced output for a sequencing window", "stage", t.state.Stage,
+			"origin", origin, "last_output_origin", t.state.LastOutputOrigin)
+	} else {
+		t.log.Info("Derivation stage is making progress again", "stage", t.state.Stage, "origin", origin)
+	}
+}
+
+// reset forgets the progress of the stage, as the origin of the stage may move back on a pipeline reset.
+func (t *stageTracer) reset() {
+	t.progressOrigin = eth.L1BlockRef{}
+	t.setStalled(false, t.stage.Origin())
+}
+
+func (t *stageTracer) State() eth.DerivationStageState {
+	state := t.state
+	state.Origin = t.stage.Origin()
+	if q, ok := t.stage.(queuedStage); ok {
+		state.QueueDepth = q.queueDepth()
+	}
+	return state
+}
+
+type tracedDataProvider struct {
+	NextDataProvider
+	t *stageTracer
+}
+
+func (p *tracedDataProvider) NextData(ctx context.Context) ([]byte, error) {
+	start := time.Now()
+	data, err := p.NextDataProvider.NextData(ctx)
+	p.t.record(start, err)
+	return data, err
+}
+
+type tracedFrameProvider struct {
+	NextFrameProvider
+	t *stageTracer
+}
+
+func (p *tracedFrameProvider) NextFrame(ctx context.Context) (Frame, error) {
+	start := time.Now()
+	frame, err := p.NextFrameProvider.NextFrame(ctx)
+	p.t.record(start, err)
+	return frame, err
+}
+
+type tracedBatchProvider struct {
+	NextBatchProvider
+	t *stageTracer
+}
+
+func (p *tracedBatchProvider) NextBatch(ctx context.Context) (Batch, error) {
+	start := time.Now()
+	batch, err := p.NextBatchProvider.NextBatch(ctx)
+	p.t.record(start, err)
+	return batch, err
+}
+
+type tracedSingularBatchProvider struct {
+	SingularBatchProvider
+	t *stageTracer
+}
+
+func (p *tracedSingularBatchProvider) NextBatch(ctx context.Context, parent eth.L2BlockRef) (*SingularBatch, bool, error) {
+	start := time.Now()
+	batch, isLastInSpan, err := p.SingularBatchProvider.NextBatch(ctx, parent)
+	p.t.record(start, err)
+	return batch, isLastInSpan, err
+}
+
+type tracedAttributesProvider struct {
+	NextAttributesProvider
+	t *stageTracer
+}
+
+func (p *tracedAttributesProvider) NextAttributes(ctx context.Context, parent eth.L2BlockRef) (*AttributesWithParent, error) {
+	start := time.Now()
+	attrs, err := p.NextAttributesProvider.NextAttributes(ctx, parent)
+	p.t.record(start, err)
+	return attrs, err
+}
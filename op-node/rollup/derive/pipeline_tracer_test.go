@@ -0,0 +1,78 @@
+package derive
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type fakeTracedStage struct {
+	origin eth.L1BlockRef
+	depth  int
+}
+
+func (s *fakeTracedStage) Origin() eth.L1BlockRef {
+	return s.origin
+}
+
+func (s *fakeTracedStage) queueDepth() int {
+	return s.depth
+}
+
+func TestStageTracer(t *testing.T) {
+	var stalled []bool
+	m := &testutils.TestDerivationMetrics{
+		FnRecordDerivationStageStalled: func(stage string, s bool) {
+			require.Equal(t, "frame_queue", stage)
+			stalled = append(stalled, s)
+		},
+	}
+	stage := &fakeTracedStage{origin: eth.L1BlockRef{Number: 100}, depth: 3}
+	tr := newStageTracer(testlog.Logger(t, log.LvlInfo), "frame_queue", stage, 10, m)
+
+	tr.record(time.Now(), nil)
+	stage.origin.Number = 105
+	tr.record(time.Now(), io.EOF)
+	tr.record(time.Now(), errors.New("boom"))
+	tr.record(time.Now(), NotEnoughData)
+
+	state := tr.State()
+	require.Equal(t, "frame_queue", state.Stage)
+	require.Equal(t, uint64(105), state.Origin.Number)
+	require.Equal(t, 3, state.QueueDepth)
+	require.Equal(t, uint64(4), state.Steps)
+	require.Equal(t, uint64(1), state.Outputs)
+	require.Equal(t, uint64(1), state.Errors)
+	require.Equal(t, uint64(100), state.LastOutputOrigin.Number)
+	require.NotZero(t, state.LastOutputTime)
+	require.False(t, state.Stalled)
+
+	// No output for more than a sequencing window of L1 blocks
+	stage.origin.Number = 111
+	tr.record(time.Now(), io.EOF)
+	require.True(t, tr.State().Stalled)
+
+	// Output recovers the stage
+	tr.record(time.Now(), nil)
+	require.False(t, tr.State().Stalled)
+
+	// A reset clears the stall, and restarts tracking from the new origin
+	stage.origin.Number = 200
+	tr.record(time.Now(), io.EOF)
+	require.True(t, tr.State().Stalled)
+	tr.reset()
+	require.False(t, tr.State().Stalled)
+	stage.origin.Number = 150
+	tr.record(time.Now(), io.EOF)
+	require.False(t, tr.State().Stalled)
+
+	require.Equal(t, []bool{true, false, true, false}, stalled)
+}
@@ -0,0 +1,93 @@
+package derive
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// FuzzSpanBatchBitsRoundTrip tests that bitlists of any length are encoded and decoded without loss,
+// and are encoded to the minimal number of bytes.
+func FuzzSpanBatchBitsRoundTrip(f *testing.F) {
+	f.Add(uint16(0), []byte{})
+	f.Add(uint16(1), []byte{0x01})
+	f.Add(uint16(9), []byte{0x01, 0xff})
+	f.Add(uint16(64), []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})
+	f.Fuzz(func(t *testing.T, bitLength uint16, data []byte) {
+		// Truncate the bits to fit in the bitlist
+		bits := new(big.Int).SetBytes(data)
+		mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(bitLength)), big.NewInt(1))
+		bits.And(bits, mask)
+
+		var buf bytes.Buffer
+		require.NoError(t, encodeSpanBatchBits(&buf, uint64(bitLength), bits))
+		require.Equal(t, (int(bitLength)+7)/8, buf.Len(), "bitlist should be encoded in the minimal number of bytes")
+
+		r := bytes.NewReader(buf.Bytes())
+		dec, err := decodeSpanBatchBits(r, uint64(bitLength))
+		require.NoError(t, err)
+		require.Zero(t, r.Len(), "should read the full bitlist")
+		require.Zero(t, bits.Cmp(dec), "round trip bitlist encoding did not match original bits")
+	})
+}
+
+// FuzzDecodeSpanBatchBits tests that decoding arbitrary data as a bitlist does not panic,
+// and that successfully decoded bitlists encode back to the same data.
+func FuzzDecodeSpanBatchBits(f *testing.F) {
+	f.Add(uint16(3), []byte{0x08})
+	f.Add(uint16(16), []byte{0x80, 0x00, 0x01})
+	f.Fuzz(func(t *testing.T, bitLength uint16, data []byte) {
+		r := bytes.NewReader(data)
+		bits, err := decodeSpanBatchBits(r, uint64(bitLength))
+		if err != nil {
+			return
+		}
+		require.LessOrEqual(t, bits.BitLen(), int(bitLength))
+		var buf bytes.Buffer
+		require.NoError(t, encodeSpanBatchBits(&buf, uint64(bitLength), bits))
+		require.True(t, bytes.Equal(data[:len(data)-r.Len()], buf.Bytes()), "bitlist should re-encode to the decoded data")
+	})
+}
+
+// FuzzSpanBatchRoundTrip tests that randomly generated span batches are encoded and decoded without loss.
+// Unlike FuzzBatchRoundTrip, the span batches are generated to be structurally valid, including their transactions.
+func FuzzSpanBatchRoundTrip(f *testing.F) {
+	f.Add(int64(0x77556694), uint16(901))
+	f.Fuzz(func(t *testing.T, seed int64, chainID uint16) {
+		rng := rand.New(rand.NewSource(seed))
+		rawSpanBatch := RandomRawSpanBatch(rng, big.NewInt(int64(chainID)))
+
+		var buf bytes.Buffer
+		require.NoError(t, rawSpanBatch.encode(&buf))
+
+		var dec RawSpanBatch
+		require.NoError(t, dec.decode(bytes.NewReader(buf.Bytes())))
+		require.NoError(t, dec.txs.recoverV(big.NewInt(int64(chainID))))
+		require.Equal(t, rawSpanBatch, &dec, "round trip span batch encoding did not match original values")
+	})
+}
+
+// FuzzDecodeRawSpanBatch tests that decoding arbitrary data as a span batch does not panic,
+// and that successfully decoded span batches are encoded and decoded again without loss.
+func FuzzDecodeRawSpanBatch(f *testing.F) {
+	rng := rand.New(rand.NewSource(0x5eed))
+	for i := 0; i < 4; i++ {
+		var buf bytes.Buffer
+		require.NoError(f, RandomRawSpanBatch(rng, big.NewInt(10)).encode(&buf))
+		f.Add(buf.Bytes())
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var sb RawSpanBatch
+		if err := sb.decode(bytes.NewReader(data)); err != nil {
+			return
+		}
+		var buf bytes.Buffer
+		require.NoError(t, sb.encode(&buf))
+		var dec RawSpanBatch
+		require.NoError(t, dec.decode(bytes.NewReader(buf.Bytes())))
+		require.Equal(t, &sb, &dec, "decoded span batch did not survive a round trip")
+	})
+}
@@ -2,8 +2,11 @@ package driver
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -43,3 +46,58 @@ func (c *confDepth) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1B
 }
 
 var _ derive.L1Fetcher = (*confDepth)(nil)
+
+// depositConfDepth wraps the L1 fetcher used for L1 origin selection, and hides L1 blocks that contain deposits
+// until they have depth confirmations. L1 blocks without deposits are not hidden by it,
+// so origin selection does not have to trail as far behind the L1 head as the deposits do.
+//
+// At 0 depth the l1 head is completely ignored.
+type depositConfDepth struct {
+	derive.L1Fetcher
+	l1Head              func() eth.L1BlockRef
+	depth               uint64
+	depositContractAddr common.Address
+}
+
+func NewDepositConfDepth(depth uint64, l1Head func() eth.L1BlockRef, fetcher derive.L1Fetcher, depositContractAddr common.Address) *depositConfDepth {
+	return &depositConfDepth{L1Fetcher: fetcher, l1Head: l1Head, depth: depth, depositContractAddr: depositContractAddr}
+}
+
+// L1BlockRefByNumber mocks blocks within the deposit confirmation depth of the L1 head to be "not found",
+// if they contain deposits. Checking for deposits requires the receipts of the block,
+// which are needed anyway to build the first L2 block of the epoch once the block is adopted as L1 origin.
+func (c *depositConfDepth) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	ref, err := c.L1Fetcher.L1BlockRefByNumber(ctx, num)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	// Don't apply the conf depth is l1Head is empty (as it is during the startup case before the l1State is initialized).
+	l1Head := c.l1Head()
+	if l1Head == (eth.L1BlockRef{}) || num == 0 || c.depth == 0 || num+c.depth <= l1Head.Number {
+		return ref, nil
+	}
+	_, receipts, err := c.L1Fetcher.FetchReceipts(ctx, ref.Hash)
+	if err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to check L1 block %s for deposits: %w", ref, err)
+	}
+	if hasDeposits(receipts, c.depositContractAddr) {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func hasDeposits(receipts types.Receipts, depositContractAddr common.Address) bool {
+	for _, rec := range receipts {
+		if rec.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		for _, log := range rec.Logs {
+			if log.Address == depositContractAddr && len(log.Topics) > 0 && log.Topics[0] == derive.DepositEventABIHash {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var _ derive.L1Fetcher = (*depositConfDepth)(nil)
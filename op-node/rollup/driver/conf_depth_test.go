@@ -8,7 +8,9 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
@@ -66,3 +68,51 @@ func TestConfDepth(t *testing.T) {
 		t.Run(tc.name, tc.Run)
 	}
 }
+
+func TestDepositConfDepth(t *testing.T) {
+	depositContractAddr := common.Address{0xdd}
+	depositReceipts := types.Receipts{{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   []*types.Log{{Address: depositContractAddr, Topics: []common.Hash{derive.DepositEventABIHash}}},
+	}}
+	failedDepositReceipts := types.Receipts{{
+		Status: types.ReceiptStatusFailed,
+		Logs:   []*types.Log{{Address: depositContractAddr, Topics: []common.Hash{derive.DepositEventABIHash}}},
+	}}
+	otherReceipts := types.Receipts{{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   []*types.Log{{Address: common.Address{0xee}, Topics: []common.Hash{derive.DepositEventABIHash}}},
+	}}
+	l1Head := eth.L1BlockRef{Number: 100, Hash: exHash}
+
+	testCases := []struct {
+		name     string
+		req      uint64
+		receipts types.Receipts
+		pass     bool
+	}{
+		{name: "deposits within depth", req: 95, receipts: depositReceipts, pass: false},
+		{name: "failed deposits within depth", req: 95, receipts: failedDepositReceipts, pass: true},
+		{name: "no deposits within depth", req: 95, receipts: otherReceipts, pass: true},
+		{name: "deposits past depth", req: 90, pass: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			l1Fetcher := &testutils.MockL1Source{}
+			ref := eth.L1BlockRef{Number: tc.req, Hash: common.Hash{byte(tc.req)}}
+			l1Fetcher.ExpectL1BlockRefByNumber(tc.req, ref, nil)
+			if tc.receipts != nil {
+				l1Fetcher.ExpectFetchReceipts(ref.Hash, nil, tc.receipts, nil)
+			}
+			cd := NewDepositConfDepth(10, func() eth.L1BlockRef { return l1Head }, l1Fetcher, depositContractAddr)
+			out, err := cd.L1BlockRefByNumber(context.Background(), tc.req)
+			l1Fetcher.AssertExpectations(t)
+			if tc.pass {
+				require.NoError(t, err)
+				require.Equal(t, ref, out)
+			} else {
+				require.Equal(t, ethereum.NotFound, err)
+			}
+		})
+	}
+}
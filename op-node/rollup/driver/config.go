@@ -11,6 +11,13 @@ type Config struct {
 	// and thus fail to produce a block with anything more than deposits.
 	SequencerConfDepth uint64 `json:"sequencer_conf_depth"`
 
+	// SequencerDepositConfDepth is the distance to keep from the L1 head when adopting a L1 origin that contains deposits,
+	// so deposits are only included once they are unlikely to be reorged out of L1.
+	// L1 origins without deposits are still adopted at SequencerConfDepth.
+	// Disabled if not larger than SequencerConfDepth. Like SequencerConfDepth, if this distance is too large
+	// the sequencer may not adopt a L1 origin within the allowed time (rollup.Config.MaxSequencerDrift).
+	SequencerDepositConfDepth uint64 `json:"sequencer_deposit_conf_depth"`
+
 	// SequencerEnabled is true when the driver should sequence new blocks.
 	SequencerEnabled bool `json:"sequencer_enabled"`
 
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -15,6 +16,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opevent "github.com/ethereum-optimism/optimism/op-service/event"
 )
 
 type Downloader interface {
@@ -26,6 +28,10 @@ type L1OriginSelectorIface interface {
 	FindL1Origin(ctx context.Context, l2Head eth.L2BlockRef) (eth.L1BlockRef, error)
 }
 
+// ErrSlowAttributesSubscriber is the error of a payload attributes subscription that was dropped,
+// because the subscriber did not keep up with the payload attributes of the sequenced blocks.
+var ErrSlowAttributesSubscriber = errors.New("payload attributes subscriber is too slow")
+
 type SequencerMetrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
@@ -47,6 +53,9 @@ type Sequencer struct {
 	timeNow func() time.Time
 
 	nextAction time.Time
+
+	// attrs notifies subscribers of the payload attributes of each block that starts building.
+	attrs opevent.NonBlockingFeed[*eth.PayloadAttributesEvent]
 }
 
 func NewSequencer(log log.Logger, rollupCfg *rollup.Config, engine derive.EngineControl, attributesBuilder derive.AttributesBuilder, l1OriginSelector L1OriginSelectorIface, metrics SequencerMetrics) *Sequencer {
@@ -109,9 +118,29 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to start building on top of L2 chain %s, error (%d): %w", l2Head, errTyp, err)
 	}
+	d.publishPayloadAttributes(&eth.PayloadAttributesEvent{
+		ParentBlock: l2Head,
+		L1Origin:    l1Origin.ID(),
+		Attributes:  attrs,
+	})
 	return nil
 }
 
+// publishPayloadAttributes sends the event to all subscribers without blocking.
+// Subscribers with a full channel are dropped, so they can't delay sequencing.
+func (d *Sequencer) publishPayloadAttributes(ev *eth.PayloadAttributesEvent) {
+	if dropped := d.attrs.Send(ev); dropped > 0 {
+		d.log.Warn("Dropped slow payload attributes subscribers", "parent", ev.ParentBlock, "count", dropped)
+	}
+}
+
+// SubscribePayloadAttributes subscribes to the payload attributes of the blocks the sequencer starts building.
+// Events are sent without blocking sequencing, so ch should be buffered and drained promptly:
+// if ch is full when an event is sent, the subscription fails with ErrSlowAttributesSubscriber.
+func (d *Sequencer) SubscribePayloadAttributes(ch chan<- *eth.PayloadAttributesEvent) event.Subscription {
+	return d.attrs.Subscribe(ch, ErrSlowAttributesSubscriber)
+}
+
 // CompleteBuildingBlock takes the current block that is being built, and asks the engine to complete the building, seal the block, and persist it as canonical.
 // Warning: the safe and finalized L2 blocks as viewed during the initiation of the block building are reused for completion of the block building.
 // The Execution engine should not change the safe and finalized blocks between start and completion of block building.
@@ -378,3 +378,30 @@ func TestSequencerChaosMonkey(t *testing.T) {
 	require.Greater(t, engControl.avgBuildingTime(), time.Second, "With 2 second block time and 1 second error backoff and healthy-on-average errors, building time should at least be a second")
 	require.Greater(t, engControl.avgTxsPerBlock(), 3.0, "We expect at least 1 system tx per block, but with a mocked 0-10 txs we expect an higher avg")
 }
+
+func TestSequencerPayloadAttributesSubscription(t *testing.T) {
+	seq := NewSequencer(testlog.Logger(t, log.LvlCrit), &rollup.Config{}, nil, nil, nil, metrics.NoopMetrics)
+	ev := &eth.PayloadAttributesEvent{ParentBlock: eth.L2BlockRef{Number: 1}}
+
+	fast := make(chan *eth.PayloadAttributesEvent, 2)
+	fastSub := seq.SubscribePayloadAttributes(fast)
+	defer fastSub.Unsubscribe()
+	slow := make(chan *eth.PayloadAttributesEvent, 1)
+	slowSub := seq.SubscribePayloadAttributes(slow)
+	defer slowSub.Unsubscribe()
+
+	seq.publishPayloadAttributes(ev)
+	require.Equal(t, ev, <-fast)
+	seq.publishPayloadAttributes(ev)
+	require.Equal(t, ev, <-fast)
+
+	// The slow subscriber is dropped when its channel is full, without blocking the sequencer.
+	require.ErrorIs(t, <-slowSub.Err(), ErrSlowAttributesSubscriber)
+	require.Len(t, slow, 1)
+	seq.publishPayloadAttributes(ev)
+	require.Equal(t, ev, <-fast)
+
+	fastSub.Unsubscribe()
+	seq.publishPayloadAttributes(ev)
+	require.Empty(t, fast)
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -51,6 +52,11 @@ type Driver struct {
 	// It tells the caller that the reset occurred by closing the passed in channel.
 	forceReset chan chan struct{}
 
+	// Upon receiving a hash in this channel, the unsafe head is reset to the given L2 block,
+	// and the derivation pipeline is reset to re-derive the chain from there.
+	// It tells the caller that the reset occurred by closing the passed in channel (or returning an error).
+	resetUnsafeHead chan hashAndErrorChannel
+
 	// Upon receiving a hash in this channel, the sequencer is started at the given hash.
 	// It tells the caller that the sequencer started by closing the passed in channel (or returning an error).
 	startSequencer chan hashAndErrorChannel
@@ -414,6 +420,14 @@ func (s *Driver) eventLoop() {
 			s.derivation.Reset()
 			s.metrics.RecordPipelineReset()
 			close(respCh)
+		case resp := <-s.resetUnsafeHead:
+			if err := s.resetUnsafeHeadTo(s.driverCtx, resp.hash); err != nil {
+				resp.err <- err
+				continue
+			}
+			s.metrics.RecordPipelineReset()
+			close(resp.err)
+			reqStep()
 		case resp := <-s.startSequencer:
 			unsafeHead := s.engineController.UnsafeL2Head().Hash
 			if !s.driverConfig.SequencerStopped {
@@ -471,6 +485,27 @@ func (s *Driver) ResetDerivationPipeline(ctx context.Context) error {
 	}
 }
 
+// ResetUnsafeHead rewinds the unsafe head to the given L2 block, and resets the derivation pipeline,
+// to recover from sequencer faults without restarting the node.
+// The safe head is rewound too if it is ahead of the given block. The finalized head cannot be rewound.
+func (s *Driver) ResetUnsafeHead(ctx context.Context, blockHash common.Hash) error {
+	h := hashAndErrorChannel{
+		hash: blockHash,
+		err:  make(chan error, 1),
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case s.resetUnsafeHead <- h:
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-h.err:
+			return e
+		}
+	}
+}
+
 func (s *Driver) StartSequencer(ctx context.Context, blockHash common.Hash) error {
 	if !s.driverConfig.SequencerEnabled {
 		return errors.New("sequencer is not enabled")
@@ -515,6 +550,20 @@ func (s *Driver) StopSequencer(ctx context.Context) (common.Hash, error) {
 	}
 }
 
+// OverrideLeader forces the sequencer conductor to treat this node as the leader.
+// It is meant for emergencies, when the conductor cannot elect a leader.
+func (s *Driver) OverrideLeader(ctx context.Context) error {
+	if !s.driverConfig.SequencerEnabled {
+		return errors.New("sequencer is not enabled")
+	}
+	return s.sequencerConductor.OverrideLeader(ctx)
+}
+
+// SubscribePayloadAttributes subscribes to the payload attributes of the blocks this node starts sequencing.
+func (s *Driver) SubscribePayloadAttributes(ch chan<- *eth.PayloadAttributesEvent) event.Subscription {
+	return s.sequencer.SubscribePayloadAttributes(ch)
+}
+
 func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	if !s.driverConfig.SequencerEnabled {
 		return false, nil
@@ -579,6 +628,20 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// DerivationPipelineState blocks the driver event loop and captures the internals of the derivation pipeline.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) DerivationPipelineState(ctx context.Context) (*eth.DerivationPipelineState, error) {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		resp := s.derivation.State()
+		<-wait
+		return &resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
@@ -599,6 +662,42 @@ func (s *Driver) snapshot(event string) {
 		"l2FinalizedHead", deferJSONString{s.engineController.Finalized()})
 }
 
+// resetUnsafeHeadTo forces the engine to the given L2 block, and resets the derivation pipeline,
+// which then re-derives the chain from the L1 origin of the new safe head.
+func (s *Driver) resetUnsafeHeadTo(ctx context.Context, blockHash common.Hash) error {
+	if s.engineController.IsEngineSyncing() {
+		return errors.New("cannot reset unsafe head while the engine is syncing")
+	}
+	ref, err := s.l2.L2BlockRefByHash(ctx, blockHash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 block %s: %w", blockHash, err)
+	}
+	finalized := s.engineController.Finalized()
+	if ref.Number < finalized.Number || (ref.Number == finalized.Number && ref.Hash != finalized.Hash) {
+		return fmt.Errorf("cannot reset unsafe head to %s, it conflicts with the finalized head %s", ref, finalized)
+	}
+
+	prevUnsafe, prevSafe, prevPendingSafe := s.engineController.UnsafeL2Head(), s.engineController.SafeL2Head(), s.engineController.PendingSafeL2Head()
+	s.sequencer.CancelBuildingBlock(ctx)
+	s.engineController.SetUnsafeHead(ref)
+	if prevSafe.Number > ref.Number {
+		s.engineController.SetSafeHead(ref)
+	}
+	if prevPendingSafe.Number > ref.Number {
+		s.engineController.SetPendingSafeL2Head(ref)
+	}
+	if err := s.engineController.TryUpdateEngine(ctx); err != nil {
+		// Restore the previous forkchoice state, the next engine update will retry it.
+		s.engineController.SetUnsafeHead(prevUnsafe)
+		s.engineController.SetSafeHead(prevSafe)
+		s.engineController.SetPendingSafeL2Head(prevPendingSafe)
+		return fmt.Errorf("failed to reset engine to unsafe head %s: %w", ref, err)
+	}
+	s.log.Warn("Unsafe head is manually reset", "unsafe", ref, "prev_unsafe", prevUnsafe, "safe", s.engineController.SafeL2Head(), "prev_safe", prevSafe)
+	s.derivation.Reset()
+	return nil
+}
+
 type hashAndError struct {
 	hash common.Hash
 	err  error
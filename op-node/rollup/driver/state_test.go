@@ -0,0 +1,98 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+type resetCountingPipeline struct {
+	DerivationPipeline
+	resets int
+}
+
+func (p *resetCountingPipeline) Reset() {
+	p.resets++
+}
+
+func TestDriver_ResetUnsafeHead(t *testing.T) {
+	finalized := eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 1}
+	target := eth.L2BlockRef{Hash: common.Hash{0x05}, Number: 5}
+	safe := eth.L2BlockRef{Hash: common.Hash{0x08}, Number: 8}
+	unsafe := eth.L2BlockRef{Hash: common.Hash{0x0a}, Number: 10}
+
+	setup := func(t *testing.T) (*Driver, *testutils.MockEngine, *resetCountingPipeline) {
+		logger := testlog.Logger(t, log.LvlInfo)
+		cfg := &rollup.Config{}
+		eng := &testutils.MockEngine{}
+		ec := derive.NewEngineController(eng, logger, metrics.NoopMetrics, cfg, sync.CLSync)
+		ec.SetFinalizedHead(finalized)
+		ec.SetSafeHead(safe)
+		ec.SetPendingSafeL2Head(safe)
+		ec.SetUnsafeHead(unsafe)
+		pipeline := &resetCountingPipeline{}
+		return &Driver{
+			log:              logger,
+			l2:               eng,
+			engineController: ec,
+			derivation:       pipeline,
+			sequencer:        NewSequencer(logger, cfg, ec, nil, nil, metrics.NoopMetrics),
+		}, eng, pipeline
+	}
+
+	t.Run("RewindSafeHead", func(t *testing.T) {
+		d, eng, pipeline := setup(t)
+		eng.ExpectL2BlockRefByHash(target.Hash, target, nil)
+		eng.ExpectForkchoiceUpdate(&eth.ForkchoiceState{
+			HeadBlockHash:      target.Hash,
+			SafeBlockHash:      target.Hash,
+			FinalizedBlockHash: finalized.Hash,
+		}, nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil)
+
+		require.NoError(t, d.resetUnsafeHeadTo(context.Background(), target.Hash))
+		require.Equal(t, target, d.engineController.UnsafeL2Head())
+		require.Equal(t, target, d.engineController.SafeL2Head())
+		require.Equal(t, target, d.engineController.PendingSafeL2Head())
+		require.Equal(t, 1, pipeline.resets)
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("RejectBeforeFinalized", func(t *testing.T) {
+		d, eng, pipeline := setup(t)
+		eng.ExpectL2BlockRefByHash(common.Hash{0xaa}, eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 1}, nil)
+
+		require.ErrorContains(t, d.resetUnsafeHeadTo(context.Background(), common.Hash{0xaa}), "finalized head")
+		require.Equal(t, unsafe, d.engineController.UnsafeL2Head())
+		require.Zero(t, pipeline.resets)
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("RestoreOnEngineError", func(t *testing.T) {
+		d, eng, pipeline := setup(t)
+		eng.ExpectL2BlockRefByHash(target.Hash, target, nil)
+		eng.ExpectForkchoiceUpdate(&eth.ForkchoiceState{
+			HeadBlockHash:      target.Hash,
+			SafeBlockHash:      target.Hash,
+			FinalizedBlockHash: finalized.Hash,
+		}, nil, nil, errors.New("engine offline"))
+
+		require.ErrorContains(t, d.resetUnsafeHeadTo(context.Background(), target.Hash), "engine offline")
+		require.Equal(t, unsafe, d.engineController.UnsafeL2Head())
+		require.Equal(t, safe, d.engineController.SafeL2Head())
+		require.Equal(t, safe, d.engineController.PendingSafeL2Head())
+		require.Zero(t, pipeline.resets)
+		eng.AssertExpectations(t)
+	})
+}
@@ -70,4 +70,8 @@ type Config struct {
 	// Note: We probably need to detect the condition that snap sync has not complete when we do a restart prior to running sync-start if we are doing
 	// snap sync with a genesis finalization data.
 	SkipSyncStartCheck bool `json:"skip_sync_start_check"`
+	// DepositsOnly ignores all batch data, so deposit-only blocks are derived for every L1 origin once its sequencing
+	// window expires, as if no batches were submitted. This diverges from the canonical chain if there are valid batches,
+	// and is only meant for incident response, to make the deposits safe while batch submission is broken.
+	DepositsOnly bool `json:"deposits_only"`
 }
@@ -91,6 +91,11 @@ type Config struct {
 	// Active if FjordTime != nil && L2 block timestamp >= *FjordTime, inactive otherwise.
 	FjordTime *uint64 `json:"fjord_time,omitempty"`
 
+	// ZstdChannelTime sets the activation time of zstd compressed channel data, activated like a hardfork.
+	// Channels are checked against the timestamp of the L1 block that includes them, like Fjord channels.
+	// Active if ZstdChannelTime != nil && timestamp >= *ZstdChannelTime, inactive otherwise.
+	ZstdChannelTime *uint64 `json:"zstd_channel_time,omitempty"`
+
 	// InteropTime sets the activation time for an experimental feature-set, activated like a hardfork.
 	// Active if InteropTime != nil && L2 block timestamp >= *InteropTime, inactive otherwise.
 	InteropTime *uint64 `json:"interop_time,omitempty"`
@@ -348,6 +353,11 @@ func (c *Config) IsFjord(timestamp uint64) bool {
 	return c.FjordTime != nil && timestamp >= *c.FjordTime
 }
 
+// IsZstdChannel returns true if zstd compressed channel data is accepted at or past the given timestamp.
+func (c *Config) IsZstdChannel(timestamp uint64) bool {
+	return c.ZstdChannelTime != nil && timestamp >= *c.ZstdChannelTime
+}
+
 // IsInterop returns true if the Interop hardfork is active at or past the given timestamp.
 func (c *Config) IsInterop(timestamp uint64) bool {
 	return c.InteropTime != nil && timestamp >= *c.InteropTime
@@ -384,6 +394,7 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  - Delta: %s\n", fmtForkTimeOrUnset(c.DeltaTime))
 	banner += fmt.Sprintf("  - Ecotone: %s\n", fmtForkTimeOrUnset(c.EcotoneTime))
 	banner += fmt.Sprintf("  - Fjord: %s\n", fmtForkTimeOrUnset(c.FjordTime))
+	banner += fmt.Sprintf("  - ZstdChannel: %s\n", fmtForkTimeOrUnset(c.ZstdChannelTime))
 	banner += fmt.Sprintf("  - Interop: %s\n", fmtForkTimeOrUnset(c.InteropTime))
 	// Report the protocol version
 	banner += fmt.Sprintf("Node supports up to OP-Stack Protocol Version: %s\n", OPStackSupport)
@@ -414,6 +425,7 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 		"delta_time", fmtForkTimeOrUnset(c.DeltaTime),
 		"ecotone_time", fmtForkTimeOrUnset(c.EcotoneTime),
 		"fjord_time", fmtForkTimeOrUnset(c.FjordTime),
+		"zstd_channel_time", fmtForkTimeOrUnset(c.ZstdChannelTime),
 		"interop_time", fmtForkTimeOrUnset(c.InteropTime),
 	)
 }
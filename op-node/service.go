@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 )
 
 // NewConfig creates a Config from the provided flags or environment variables.
@@ -46,7 +47,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	driverConfig := NewDriverConfig(ctx)
 
-	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
+	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load p2p signer: %w", err)
 	}
@@ -68,6 +69,11 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to create the sync config: %w", err)
 	}
 
+	rpcConfig, err := NewRPCConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rpc config: %w", err)
+	}
+
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
 		haltOption = ""
@@ -79,11 +85,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
 		Beacon: NewBeaconEndpointConfig(ctx),
-		RPC: node.RPCConfig{
-			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
-			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
-			EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
-		},
+		RPC:    *rpcConfig,
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.Bool(flags.MetricsEnabledFlag.Name),
 			ListenAddr: ctx.String(flags.MetricsAddrFlag.Name),
@@ -103,6 +105,8 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		Sync:              *syncConfig,
 		RollupHalt:        haltOption,
 		RethDBPath:        ctx.String(flags.L1RethDBPath.Name),
+		SafeDBPath:        ctx.String(flags.SafeDBPath.Name),
+		DASource:          ctx.String(flags.DASourceFlag.Name),
 
 		ConductorEnabled:    ctx.Bool(flags.ConductorEnabledFlag.Name),
 		ConductorRpc:        ctx.String(flags.ConductorRpcFlag.Name),
@@ -135,12 +139,16 @@ func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
 func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	return &node.L1EndpointConfig{
 		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
+		L1FallbackAddrs:  ctx.StringSlice(flags.L1FallbackAddrs.Name),
 		L1TrustRPC:       ctx.Bool(flags.L1TrustRPC.Name),
 		L1RPCKind:        sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
 		RateLimit:        ctx.Float64(flags.L1RPCRateLimit.Name),
 		BatchSize:        ctx.Int(flags.L1RPCMaxBatchSize.Name),
 		HttpPollInterval: ctx.Duration(flags.L1HTTPPollInterval.Name),
 		MaxConcurrency:   ctx.Int(flags.L1RPCMaxConcurrency.Name),
+
+		CircuitBreakerThreshold: ctx.Int(flags.L1RPCCircuitBreakerThreshold.Name),
+		CircuitBreakerCooldown:  ctx.Duration(flags.L1RPCCircuitBreakerCooldown.Name),
 	}
 }
 
@@ -169,11 +177,30 @@ func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConf
 	}
 
 	return &node.L2EndpointConfig{
-		L2EngineAddr:      l2Addr,
-		L2EngineJWTSecret: secret,
+		L2EngineAddr:        l2Addr,
+		L2EngineJWTSecret:   secret,
+		L2StandbyEngineAddr: ctx.String(flags.L2StandbyEngineAddr.Name),
 	}, nil
 }
 
+func NewRPCConfig(ctx *cli.Context) (*node.RPCConfig, error) {
+	cfg := &node.RPCConfig{
+		ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
+		ListenPort:  ctx.Int(flags.RPCListenPort.Name),
+		EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
+	}
+	fileName := strings.TrimSpace(ctx.String(flags.RPCAdminJWTSecret.Name))
+	if fileName == "" {
+		return cfg, nil
+	}
+	secret, err := oprpc.ReadJWTSecret(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load admin jwt secret: %w", err)
+	}
+	cfg.AdminJWTSecret = &secret
+	return cfg, nil
+}
+
 func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 	stateFile := ctx.String(flags.RPCAdminPersistence.Name)
 	if stateFile == "" {
@@ -184,11 +211,12 @@ func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 
 func NewDriverConfig(ctx *cli.Context) *driver.Config {
 	return &driver.Config{
-		VerifierConfDepth:   ctx.Uint64(flags.VerifierL1Confs.Name),
-		SequencerConfDepth:  ctx.Uint64(flags.SequencerL1Confs.Name),
-		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
-		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
-		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		VerifierConfDepth:         ctx.Uint64(flags.VerifierL1Confs.Name),
+		SequencerConfDepth:        ctx.Uint64(flags.SequencerL1Confs.Name),
+		SequencerDepositConfDepth: ctx.Uint64(flags.SequencerL1DepositConfs.Name),
+		SequencerEnabled:          ctx.Bool(flags.SequencerEnabledFlag.Name),
+		SequencerStopped:          ctx.Bool(flags.SequencerStoppedFlag.Name),
+		SequencerMaxSafeLag:       ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
 	}
 }
 
@@ -271,6 +299,10 @@ func NewSyncConfig(ctx *cli.Context, log log.Logger) (*sync.Config, error) {
 	cfg := &sync.Config{
 		SyncMode:           mode,
 		SkipSyncStartCheck: ctx.Bool(flags.SkipSyncStartCheck.Name),
+		DepositsOnly:       ctx.Bool(flags.DepositsOnlyFlag.Name),
+	}
+	if cfg.DepositsOnly {
+		log.Warn("Only deriving deposits, all batch data is ignored. The chain will diverge if any valid batches are submitted.")
 	}
 	if ctx.Bool(flags.L2EngineSyncEnabled.Name) {
 		cfg.SyncMode = sync.ELSync
@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 )
 
@@ -39,3 +41,10 @@ func RollupConfigByChainID(chainID uint64) (*rollup.Config, error) {
 func ChainConfigByChainID(chainID uint64) (*params.ChainConfig, error) {
 	return params.LoadOPStackChainConfig(chainID)
 }
+
+// ChainConfigHash commits to the rollup config and the L2 chain config of a chain.
+// The hash is the keccak256 hash of the concatenated keccak256 hashes of the encoded configs, as they are provided
+// to the client program. The configs are not encoded again, so the hash commits to the exact input bytes.
+func ChainConfigHash(rollupJSON []byte, l2ChainConfigJSON []byte) common.Hash {
+	return crypto.Keccak256Hash(crypto.Keccak256(rollupJSON), crypto.Keccak256(l2ChainConfigJSON))
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"math"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -27,6 +28,11 @@ const (
 // CustomChainIDIndicator is used to detect when the program should load custom chain configuration
 const CustomChainIDIndicator = uint64(math.MaxUint64)
 
+// customChainConfigHash is the chain config hash of the custom chain that the client program is built for, if any.
+// It is set at build time, with CUSTOM_CHAIN_CONFIG_HASH in the Makefile, and is thus part of the absolute prestate
+// that fault dispute games commit to. Custom chain configs are provided by the host, so they are unchecked without it.
+var customChainConfigHash string
+
 type BootInfo struct {
 	L1Head             common.Hash
 	L2OutputRoot       common.Hash
@@ -60,13 +66,21 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 	var l2ChainConfig *params.ChainConfig
 	var rollupConfig *rollup.Config
 	if l2ChainID == CustomChainIDIndicator {
+		l2ChainConfigJSON := br.r.Get(L2ChainConfigLocalIndex)
+		rollupConfigJSON := br.r.Get(RollupConfigLocalIndex)
+		if customChainConfigHash != "" {
+			expectedHash := common.HexToHash(customChainConfigHash)
+			if hash := chainconfig.ChainConfigHash(rollupConfigJSON, l2ChainConfigJSON); hash != expectedHash {
+				panic(fmt.Sprintf("chain config hash mismatch: expected %v but got %v", expectedHash, hash))
+			}
+		}
 		l2ChainConfig = new(params.ChainConfig)
-		err := json.Unmarshal(br.r.Get(L2ChainConfigLocalIndex), &l2ChainConfig)
+		err := json.Unmarshal(l2ChainConfigJSON, &l2ChainConfig)
 		if err != nil {
 			panic("failed to bootstrap l2ChainConfig")
 		}
 		rollupConfig = new(rollup.Config)
-		err = json.Unmarshal(br.r.Get(RollupConfigLocalIndex), rollupConfig)
+		err = json.Unmarshal(rollupConfigJSON, rollupConfig)
 		if err != nil {
 			panic("failed to bootstrap rollup config")
 		}
@@ -23,7 +23,7 @@ func TestBootstrapClient(t *testing.T) {
 		L2ChainConfig:      chainconfig.OPGoerliChainConfig,
 		RollupConfig:       chaincfg.Goerli,
 	}
-	mockOracle := &mockBoostrapOracle{bootInfo, false}
+	mockOracle := &mockBoostrapOracle{b: bootInfo}
 	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
 	require.EqualValues(t, bootInfo, readBootInfo)
 }
@@ -38,11 +38,44 @@ func TestBootstrapClient_CustomChain(t *testing.T) {
 		L2ChainConfig:      chainconfig.OPGoerliChainConfig,
 		RollupConfig:       chaincfg.Goerli,
 	}
-	mockOracle := &mockBoostrapOracle{bootInfo, true}
+	mockOracle := &mockBoostrapOracle{b: bootInfo, custom: true}
 	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
 	require.EqualValues(t, bootInfo, readBootInfo)
 }
 
+func TestBootstrapClient_CustomChainHash(t *testing.T) {
+	bootInfo := &BootInfo{
+		L1Head:             common.HexToHash("0x1111"),
+		L2OutputRoot:       common.HexToHash("0x2222"),
+		L2Claim:            common.HexToHash("0x3333"),
+		L2ClaimBlockNumber: 1,
+		L2ChainID:          CustomChainIDIndicator,
+		L2ChainConfig:      chainconfig.OPGoerliChainConfig,
+		RollupConfig:       chaincfg.Goerli,
+	}
+	setCustomChainConfigHash(t, mustChainConfigHash(t, bootInfo))
+	mockOracle := &mockBoostrapOracle{b: bootInfo, custom: true}
+	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
+	require.EqualValues(t, bootInfo, readBootInfo)
+}
+
+func TestBootstrapClient_CustomChainHashMismatchPanics(t *testing.T) {
+	bootInfo := &BootInfo{
+		L1Head:             common.HexToHash("0x1111"),
+		L2OutputRoot:       common.HexToHash("0x2222"),
+		L2Claim:            common.HexToHash("0x3333"),
+		L2ClaimBlockNumber: 1,
+		L2ChainID:          CustomChainIDIndicator,
+		L2ChainConfig:      chainconfig.OPGoerliChainConfig,
+		RollupConfig:       chaincfg.Goerli,
+	}
+	otherHash := mustChainConfigHash(t, &BootInfo{RollupConfig: chaincfg.Sepolia, L2ChainConfig: chainconfig.OPSepoliaChainConfig})
+	setCustomChainConfigHash(t, otherHash)
+	mockOracle := &mockBoostrapOracle{b: bootInfo, custom: true}
+	client := NewBootstrapClient(mockOracle)
+	require.PanicsWithValue(t, fmt.Sprintf("chain config hash mismatch: expected %v but got %v", otherHash, mustChainConfigHash(t, bootInfo)), func() { client.BootInfo() })
+}
+
 func TestBootstrapClient_UnknownChainPanics(t *testing.T) {
 	bootInfo := &BootInfo{
 		L1Head:             common.HexToHash("0x1111"),
@@ -51,7 +84,7 @@ func TestBootstrapClient_UnknownChainPanics(t *testing.T) {
 		L2ClaimBlockNumber: 1,
 		L2ChainID:          uint64(0xdead),
 	}
-	mockOracle := &mockBoostrapOracle{bootInfo, false}
+	mockOracle := &mockBoostrapOracle{b: bootInfo}
 	client := NewBootstrapClient(mockOracle)
 	require.Panics(t, func() { client.BootInfo() })
 }
@@ -61,6 +94,21 @@ type mockBoostrapOracle struct {
 	custom bool
 }
 
+// setCustomChainConfigHash sets the chain config hash that is set at build time for the duration of the test.
+func setCustomChainConfigHash(t *testing.T, hash common.Hash) {
+	prev := customChainConfigHash
+	customChainConfigHash = hash.Hex()
+	t.Cleanup(func() { customChainConfigHash = prev })
+}
+
+func mustChainConfigHash(t *testing.T, b *BootInfo) common.Hash {
+	rollupJSON, err := json.Marshal(b.RollupConfig)
+	require.NoError(t, err)
+	l2ChainConfigJSON, err := json.Marshal(b.L2ChainConfig)
+	require.NoError(t, err)
+	return chainconfig.ChainConfigHash(rollupJSON, l2ChainConfigJSON)
+}
+
 func (o *mockBoostrapOracle) Get(key preimage.Key) []byte {
 	switch key.PreimageKey() {
 	case L1HeadLocalIndex.PreimageKey():
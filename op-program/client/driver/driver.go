@@ -39,7 +39,7 @@ type Driver struct {
 
 func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher, l1BlobsSource derive.L1BlobsFetcher, l2Source L2Source, targetBlockNum uint64) *Driver {
 	engine := derive.NewEngineController(l2Source, logger, metrics.NoopMetrics, cfg, sync.CLSync)
-	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l1BlobsSource, l2Source, engine, metrics.NoopMetrics, &sync.Config{})
+	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, derive.NewDataSourceFactory(logger, cfg, l1Source, l1BlobsSource), l2Source, engine, metrics.NoopMetrics, &sync.Config{}, &derive.NoOpSafeHeadListener{})
 	pipeline.Reset()
 	return &Driver{
 		logger:         logger,
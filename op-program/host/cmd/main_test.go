@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"strconv"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -112,6 +114,29 @@ func TestDataDir(t *testing.T) {
 	require.Equal(t, expected, cfg.DataDir)
 }
 
+func TestDataFormat(t *testing.T) {
+	t.Run("DefaultFile", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, types.DataFormatFile, cfg.DataFormat)
+	})
+	for _, format := range types.SupportedDataFormats {
+		format := format
+		t.Run(fmt.Sprintf("Valid-%v", format), func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs("--data.format", string(format)))
+			require.Equal(t, format, cfg.DataFormat)
+		})
+	}
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid data format", addRequiredArgs("--data.format", "foo"))
+	})
+}
+
+func TestDataRemote(t *testing.T) {
+	expected := "https://example.com/preimages"
+	cfg := configForArgs(t, addRequiredArgs("--data.remote", expected))
+	require.Equal(t, expected, cfg.DataRemote)
+}
+
 func TestL2(t *testing.T) {
 	expected := "https://example.com:8545"
 	cfg := configForArgs(t, addRequiredArgs("--l2", expected))
@@ -300,6 +325,55 @@ func TestServerMode(t *testing.T) {
 	})
 }
 
+func TestPrefetchWorkers(t *testing.T) {
+	t.Run("DefaultZero", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Zero(t, cfg.PrefetchWorkers)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--prefetch.workers", "8"))
+		require.Equal(t, 8, cfg.PrefetchWorkers)
+	})
+}
+
+func TestJournal(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.JournalPath)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--journal", "/tmp/journal.json"))
+		require.Equal(t, "/tmp/journal.json", cfg.JournalPath)
+	})
+}
+
+func TestMemKVMaxEntries(t *testing.T) {
+	t.Run("DefaultZero", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Zero(t, cfg.MemKVMaxEntries)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--memkv.max-entries", "5000", "--l1", "http://localhost:8545", "--l2", "http://localhost:9545"))
+		require.Equal(t, 5000, cfg.MemKVMaxEntries)
+	})
+}
+
+func TestPreimageRecording(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.PreimageRecordPath)
+		require.Empty(t, cfg.PreimageReplayPath)
+	})
+	t.Run("Record", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--preimages.record", "/tmp/preimages.bin"))
+		require.Equal(t, "/tmp/preimages.bin", cfg.PreimageRecordPath)
+	})
+	t.Run("Replay", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--preimages.replay", "/tmp/preimages.bin"))
+		require.Equal(t, "/tmp/preimages.bin", cfg.PreimageReplayPath)
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
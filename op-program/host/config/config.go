@@ -5,12 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 
 	opnode "github.com/ethereum-optimism/optimism/op-node"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -20,16 +23,22 @@ import (
 )
 
 var (
-	ErrMissingRollupConfig = errors.New("missing rollup config")
-	ErrMissingL2Genesis    = errors.New("missing l2 genesis")
-	ErrInvalidL1Head       = errors.New("invalid l1 head")
-	ErrInvalidL2Head       = errors.New("invalid l2 head")
-	ErrInvalidL2OutputRoot = errors.New("invalid l2 output root")
-	ErrL1AndL2Inconsistent = errors.New("l1 and l2 options must be specified together or both omitted")
-	ErrInvalidL2Claim      = errors.New("invalid l2 claim")
-	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
-	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
-	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrMissingRollupConfig    = errors.New("missing rollup config")
+	ErrMissingL2Genesis       = errors.New("missing l2 genesis")
+	ErrInvalidL1Head          = errors.New("invalid l1 head")
+	ErrInvalidL2Head          = errors.New("invalid l2 head")
+	ErrInvalidL2OutputRoot    = errors.New("invalid l2 output root")
+	ErrL1AndL2Inconsistent    = errors.New("l1 and l2 options must be specified together or both omitted")
+	ErrInvalidL2Claim         = errors.New("invalid l2 claim")
+	ErrInvalidL2ClaimBlock    = errors.New("invalid l2 claim block number")
+	ErrDataDirRequired        = errors.New("datadir, remote data or pre-image replay file must be specified when in non-fetching mode")
+	ErrNoExecInServerMode     = errors.New("exec command must not be set when in server mode")
+	ErrNoJournalInServerMode  = errors.New("journal must not be set when in server mode")
+	ErrInvalidMemKVSize       = errors.New("in-memory storage max entries must not be negative")
+	ErrEvictionNotFetching    = errors.New("in-memory storage eviction requires fetching mode")
+	ErrInvalidDataFormat      = errors.New("invalid data format")
+	ErrInvalidPrefetchWorkers = errors.New("prefetch workers must not be negative")
+	ErrPrefetchNotFetching    = errors.New("speculative prefetching requires fetching mode")
 )
 
 type Config struct {
@@ -37,6 +46,10 @@ type Config struct {
 	// DataDir is the directory to read/write pre-image data from/to.
 	// If not set, an in-memory key-value store is used and fetching data must be enabled
 	DataDir string
+	// DataFormat specifies the format to use for the data directory
+	DataFormat types.DataFormat
+	// DataRemote is the base URL of a HTTP server to read pre-images from, when not available in local storage
+	DataRemote string
 
 	// L1Head is the block has of the L1 chain head block
 	L1Head      common.Hash
@@ -68,6 +81,25 @@ type Config struct {
 
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
+
+	// PrefetchWorkers is the number of workers to speculatively prefetch pre-images with, ahead of the client program.
+	// If zero, pre-images are only fetched when requested by the client program.
+	PrefetchWorkers int
+
+	// JournalPath is the file to write the journal of the program inputs and result to.
+	// If unset, no journal is written.
+	JournalPath string
+
+	// MemKVMaxEntries is the maximum number of pre-images kept by the in-memory storage, used when DataDir is not set.
+	// The least recently used pre-images are evicted when exceeded, and fetched again with the hint they were fetched for.
+	// If zero, all pre-images are kept.
+	MemKVMaxEntries int
+
+	// PreimageRecordPath is the file to record all pre-images served to the client program to, to replay it offline.
+	PreimageRecordPath string
+
+	// PreimageReplayPath is a pre-image recording to load into the pre-image storage before serving the client program.
+	PreimageReplayPath string
 }
 
 func (c *Config) Check() error {
@@ -95,12 +127,30 @@ func (c *Config) Check() error {
 	if (c.L1URL != "") != (c.L2URL != "") {
 		return ErrL1AndL2Inconsistent
 	}
-	if !c.FetchingEnabled() && c.DataDir == "" {
+	if !c.FetchingEnabled() && c.DataDir == "" && c.DataRemote == "" && c.PreimageReplayPath == "" {
 		return ErrDataDirRequired
 	}
+	if !slices.Contains(types.SupportedDataFormats, c.DataFormat) {
+		return fmt.Errorf("%w: %v", ErrInvalidDataFormat, c.DataFormat)
+	}
+	if c.MemKVMaxEntries < 0 {
+		return ErrInvalidMemKVSize
+	}
+	if c.MemKVMaxEntries > 0 && !c.FetchingEnabled() {
+		return ErrEvictionNotFetching
+	}
+	if c.PrefetchWorkers < 0 {
+		return ErrInvalidPrefetchWorkers
+	}
+	if c.PrefetchWorkers > 0 && !c.FetchingEnabled() {
+		return ErrPrefetchNotFetching
+	}
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if c.ServerMode && c.JournalPath != "" {
+		return ErrNoJournalInServerMode
+	}
 	return nil
 }
 
@@ -131,6 +181,7 @@ func NewConfig(
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
 		L1RPCKind:           sources.RPCKindStandard,
 		IsCustomChainConfig: isCustomConfig,
+		DataFormat:          types.DataFormatFile,
 	}
 }
 
@@ -184,9 +235,15 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid genesis: %w", err)
 	}
+	dataFormat := types.DataFormat(ctx.String(flags.DataFormat.Name))
+	if !slices.Contains(types.SupportedDataFormats, dataFormat) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDataFormat, dataFormat)
+	}
 	return &Config{
 		Rollup:              rollupCfg,
 		DataDir:             ctx.String(flags.DataDir.Name),
+		DataFormat:          dataFormat,
+		DataRemote:          ctx.String(flags.DataRemote.Name),
 		L2URL:               ctx.String(flags.L2NodeAddr.Name),
 		L2ChainConfig:       l2ChainConfig,
 		L2Head:              l2Head,
@@ -201,9 +258,37 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		ExecCmd:             ctx.String(flags.Exec.Name),
 		ServerMode:          ctx.Bool(flags.Server.Name),
 		IsCustomChainConfig: isCustomConfig,
+		PrefetchWorkers:     ctx.Int(flags.PrefetchWorkers.Name),
+		JournalPath:         ctx.String(flags.Journal.Name),
+		MemKVMaxEntries:     ctx.Int(flags.MemKVMaxEntries.Name),
+		PreimageRecordPath:  ctx.String(flags.PreimageRecord.Name),
+		PreimageReplayPath:  ctx.String(flags.PreimageReplay.Name),
 	}, nil
 }
 
+// ChainConfigInputs returns the encoded rollup config and L2 chain config, exactly as they are provided to the
+// client program as bootstrap data. The served configs and the chain config hash are both derived from these bytes.
+func (c *Config) ChainConfigInputs() (rollupJSON []byte, l2ChainConfigJSON []byte, err error) {
+	rollupJSON, err = json.Marshal(c.Rollup)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode rollup config: %w", err)
+	}
+	l2ChainConfigJSON, err = json.Marshal(c.L2ChainConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode l2 chain config: %w", err)
+	}
+	return rollupJSON, l2ChainConfigJSON, nil
+}
+
+// ChainConfigHash returns the hash of the chain config inputs, see chainconfig.ChainConfigHash.
+func (c *Config) ChainConfigHash() (common.Hash, error) {
+	rollupJSON, l2ChainConfigJSON, err := c.ChainConfigInputs()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return chainconfig.ChainConfigHash(rollupJSON, l2ChainConfigJSON), nil
+}
+
 func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -152,6 +152,50 @@ func TestRequireDataDirInNonFetchingMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrDataDirRequired)
 }
 
+func TestAllowReplayInNonFetchingMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.DataDir = ""
+	cfg.L1URL = ""
+	cfg.L2URL = ""
+	cfg.PreimageReplayPath = "/tmp/preimages.bin"
+	require.NoError(t, cfg.Check())
+}
+
+func TestAllowRemoteDataInNonFetchingMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.DataDir = ""
+	cfg.L1URL = ""
+	cfg.L2URL = ""
+	cfg.DataRemote = "https://example.com/preimages"
+	require.NoError(t, cfg.Check())
+}
+
+func TestInvalidDataFormat(t *testing.T) {
+	cfg := validConfig()
+	cfg.DataFormat = "foo"
+	require.ErrorIs(t, cfg.Check(), ErrInvalidDataFormat)
+}
+
+func TestMemKVMaxEntries(t *testing.T) {
+	t.Run("Negative", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.MemKVMaxEntries = -1
+		require.ErrorIs(t, cfg.Check(), ErrInvalidMemKVSize)
+	})
+	t.Run("RequireFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.MemKVMaxEntries = 1000
+		require.ErrorIs(t, cfg.Check(), ErrEvictionNotFetching)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1URL = "https://example.com:1234"
+		cfg.L2URL = "https://example.com:5678"
+		cfg.MemKVMaxEntries = 1000
+		require.NoError(t, cfg.Check())
+	})
+}
+
 func TestRejectExecAndServerMode(t *testing.T) {
 	cfg := validConfig()
 	cfg.ServerMode = true
@@ -160,6 +204,34 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestPrefetchWorkers(t *testing.T) {
+	t.Run("Negative", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.PrefetchWorkers = -1
+		require.ErrorIs(t, cfg.Check(), ErrInvalidPrefetchWorkers)
+	})
+	t.Run("RequireFetching", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.PrefetchWorkers = 4
+		require.ErrorIs(t, cfg.Check(), ErrPrefetchNotFetching)
+	})
+	t.Run("Valid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.L1URL = "http://localhost:8545"
+		cfg.L2URL = "http://localhost:9545"
+		cfg.PrefetchWorkers = 4
+		require.NoError(t, cfg.Check())
+	})
+}
+
+func TestRejectJournalAndServerMode(t *testing.T) {
+	cfg := validConfig()
+	cfg.ServerMode = true
+	cfg.JournalPath = "/tmp/journal.json"
+	err := cfg.Check()
+	require.ErrorIs(t, err, ErrNoJournalInServerMode)
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
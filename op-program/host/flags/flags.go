@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	service "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -22,7 +23,7 @@ func prefixEnvVars(name string) []string {
 var (
 	RollupConfig = &cli.StringFlag{
 		Name:    "rollup.config",
-		Usage:   "Rollup chain parameters",
+		Usage:   "Rollup chain parameters, for custom chains. Requires --l2.genesis",
 		EnvVars: prefixEnvVars("ROLLUP_CONFIG"),
 	}
 	Network = &cli.StringFlag{
@@ -35,6 +36,17 @@ var (
 		Usage:   "Directory to use for preimage data storage. Default uses in-memory storage",
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
+	DataFormat = &cli.StringFlag{
+		Name:    "data.format",
+		Usage:   fmt.Sprintf("Format to use for preimage data storage. Available formats: %s", openum.EnumString(types.SupportedDataFormats)),
+		EnvVars: prefixEnvVars("DATA_FORMAT"),
+		Value:   string(types.DataFormatFile),
+	}
+	DataRemote = &cli.StringFlag{
+		Name:    "data.remote",
+		Usage:   "Base URL of a HTTP server to read preimages from when not available in local storage, e.g. a static file server of another host's datadir in file format. Only keccak256 and sha256 preimages are read from it, after verifying them against their key",
+		EnvVars: prefixEnvVars("DATA_REMOTE"),
+	}
 	L2NodeAddr = &cli.StringFlag{
 		Name:    "l2",
 		Usage:   "Address of L2 JSON-RPC endpoint to use (eth and debug namespace required)",
@@ -67,7 +79,7 @@ var (
 	}
 	L2GenesisPath = &cli.StringFlag{
 		Name:    "l2.genesis",
-		Usage:   "Path to the op-geth genesis file",
+		Usage:   "Path to the op-geth genesis file, for custom chains. Requires --rollup.config",
 		EnvVars: prefixEnvVars("L2_GENESIS"),
 	}
 	L1NodeAddr = &cli.StringFlag{
@@ -106,6 +118,31 @@ var (
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	PrefetchWorkers = &cli.IntFlag{
+		Name:    "prefetch.workers",
+		Usage:   "Number of workers to speculatively prefetch the L1 and L2 data the client program is likely to request, concurrently with the program. Requires fetching mode. Default disables speculative prefetching.",
+		EnvVars: prefixEnvVars("PREFETCH_WORKERS"),
+	}
+	Journal = &cli.StringFlag{
+		Name:    "journal",
+		Usage:   "Write a journal of the inputs and result of the program to this file, in a canonical encoding to compare runs.",
+		EnvVars: prefixEnvVars("JOURNAL"),
+	}
+	MemKVMaxEntries = &cli.IntFlag{
+		Name:    "memkv.max-entries",
+		Usage:   "Maximum number of pre-images to keep in in-memory storage, used when no datadir is set. The least recently used pre-images are evicted, and fetched again with the hint they were fetched for when requested again. Requires fetching mode. Default keeps all pre-images.",
+		EnvVars: prefixEnvVars("MEMKV_MAX_ENTRIES"),
+	}
+	PreimageRecord = &cli.StringFlag{
+		Name:    "preimages.record",
+		Usage:   "Record all pre-images served to the client program to this file, to replay the program offline with --preimages.replay.",
+		EnvVars: prefixEnvVars("PREIMAGES_RECORD"),
+	}
+	PreimageReplay = &cli.StringFlag{
+		Name:    "preimages.replay",
+		Usage:   "Load the pre-images recorded with --preimages.record from this file, before serving the client program.",
+		EnvVars: prefixEnvVars("PREIMAGES_REPLAY"),
+	}
 )
 
 // Flags contains the list of configuration options available to the binary.
@@ -123,6 +160,8 @@ var programFlags = []cli.Flag{
 	RollupConfig,
 	Network,
 	DataDir,
+	DataFormat,
+	DataRemote,
 	L2NodeAddr,
 	L2GenesisPath,
 	L1NodeAddr,
@@ -131,6 +170,11 @@ var programFlags = []cli.Flag{
 	L1RPCProviderKind,
 	Exec,
 	Server,
+	PrefetchWorkers,
+	Journal,
+	MemKVMaxEntries,
+	PreimageRecord,
+	PreimageReplay,
 }
 
 func init() {
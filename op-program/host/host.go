@@ -12,10 +12,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum-optimism/optimism/op-program/host/journal"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	oppio "github.com/ethereum-optimism/optimism/op-program/io"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -35,6 +38,13 @@ func Main(logger log.Logger, cfg *config.Config) error {
 	}
 	opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, logger)
 	cfg.Rollup.LogDescription(logger, chaincfg.L2ChainIDToNetworkDisplayName)
+	if cfg.IsCustomChainConfig {
+		hash, err := cfg.ChainConfigHash()
+		if err != nil {
+			return fmt.Errorf("invalid chain config: %w", err)
+		}
+		logger.Info("Using custom chain config", "chain_config_hash", hash)
+	}
 
 	ctx := context.Background()
 	if cfg.ServerMode {
@@ -43,19 +53,58 @@ func Main(logger log.Logger, cfg *config.Config) error {
 		return PreimageServer(ctx, logger, cfg, preimageChan, hinterChan)
 	}
 
-	if err := FaultProofProgram(ctx, logger, cfg); err != nil {
+	err := FaultProofProgram(ctx, logger, cfg)
+	if cfg.JournalPath != "" {
+		if _, ok := journal.ExitCode(err); !ok {
+			logger.Warn("Not writing journal as the client program did not complete", "err", err)
+		} else if journalErr := writeJournal(logger, cfg, err); journalErr != nil {
+			return errors.Join(err, journalErr)
+		}
+	}
+	if err != nil {
 		return err
 	}
 	log.Info("Claim successfully verified")
 	return nil
 }
 
-// FaultProofProgram is the programmatic entry-point for the fault proof program
-func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config) error {
+func writeJournal(logger log.Logger, cfg *config.Config, result error) error {
+	j, err := journal.New(cfg, result)
+	if err != nil {
+		return fmt.Errorf("failed to create journal: %w", err)
+	}
+	if err := journal.Write(cfg.JournalPath, j); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	logger.Info("Wrote journal", "path", cfg.JournalPath, "exit_code", j.ExitCode)
+	return nil
+}
+
+// ProgramOpt configures optional behaviour of FaultProofProgram and PreimageServer.
+type ProgramOpt func(*programOpts)
+
+type programOpts struct {
+	kv kvstore.KV
+}
+
+// WithKV uses the given pre-image storage instead of creating one from the config.
+// This allows sharing pre-images between multiple runs of the program within the same process.
+func WithKV(kv kvstore.KV) ProgramOpt {
+	return func(o *programOpts) {
+		o.kv = kv
+	}
+}
+
+// FaultProofProgram is the programmatic entry-point for the fault proof program.
+// If no exec command is configured, the client program runs in-process and communicates with the
+// pre-image server over in-memory channels.
+// If the client program fails after the pre-image server failed, the returned error is marked with
+// journal.ErrHostFailure, as the client program can't complete without the pre-image server.
+func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Config, opts ...ProgramOpt) (result error) {
 	var (
 		serverErr chan error
-		pClientRW oppio.FileChannel
-		hClientRW oppio.FileChannel
+		pClientRW io.ReadWriteCloser
+		hClientRW io.ReadWriteCloser
 	)
 	defer func() {
 		if pClientRW != nil {
@@ -68,37 +117,54 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 			err := <-serverErr
 			if err != nil {
 				logger.Error("preimage server failed", "err", err)
+				if result != nil {
+					result = errors.Join(result, fmt.Errorf("%w: preimage server failed: %w", journal.ErrHostFailure, err))
+				}
 			}
 			logger.Debug("Preimage server stopped")
 		}
 	}()
-	// Setup client I/O for preimage oracle interaction
-	pClientRW, pHostRW, err := oppio.CreateBidirectionalChannel()
-	if err != nil {
-		return fmt.Errorf("failed to create preimage pipe: %w", err)
-	}
+	var (
+		pHostRW   io.ReadWriteCloser
+		hHostRW   io.ReadWriteCloser
+		pClientFC oppio.FileChannel
+		hClientFC oppio.FileChannel
+	)
+	if cfg.ExecCmd != "" {
+		// Setup client I/O for preimage oracle interaction
+		var err error
+		pClientFC, pHostRW, err = oppio.CreateBidirectionalChannel()
+		if err != nil {
+			return fmt.Errorf("failed to create preimage pipe: %w", err)
+		}
+		pClientRW = pClientFC
 
-	// Setup client I/O for hint comms
-	hClientRW, hHostRW, err := oppio.CreateBidirectionalChannel()
-	if err != nil {
-		return fmt.Errorf("failed to create hints pipe: %w", err)
+		// Setup client I/O for hint comms
+		hClientFC, hHostRW, err = oppio.CreateBidirectionalChannel()
+		if err != nil {
+			return fmt.Errorf("failed to create hints pipe: %w", err)
+		}
+		hClientRW = hClientFC
+	} else {
+		pClientRW, pHostRW = oppio.CreateMemoryChannel()
+		hClientRW, hHostRW = oppio.CreateMemoryChannel()
 	}
 
 	// Use a channel to receive the server result so we can wait for it to complete before returning
 	serverErr = make(chan error)
 	go func() {
 		defer close(serverErr)
-		serverErr <- PreimageServer(ctx, logger, cfg, pHostRW, hHostRW)
+		serverErr <- PreimageServer(ctx, logger, cfg, pHostRW, hHostRW, opts...)
 	}()
 
 	var cmd *exec.Cmd
 	if cfg.ExecCmd != "" {
 		cmd = exec.CommandContext(ctx, cfg.ExecCmd)
 		cmd.ExtraFiles = make([]*os.File, cl.MaxFd-3) // not including stdin, stdout and stderr
-		cmd.ExtraFiles[cl.HClientRFd-3] = hClientRW.Reader()
-		cmd.ExtraFiles[cl.HClientWFd-3] = hClientRW.Writer()
-		cmd.ExtraFiles[cl.PClientRFd-3] = pClientRW.Reader()
-		cmd.ExtraFiles[cl.PClientWFd-3] = pClientRW.Writer()
+		cmd.ExtraFiles[cl.HClientRFd-3] = hClientFC.Reader()
+		cmd.ExtraFiles[cl.HClientWFd-3] = hClientFC.Writer()
+		cmd.ExtraFiles[cl.PClientRFd-3] = pClientFC.Reader()
+		cmd.ExtraFiles[cl.PClientWFd-3] = pClientFC.Writer()
 		cmd.Stdout = os.Stdout // for debugging
 		cmd.Stderr = os.Stderr // for debugging
 
@@ -112,7 +178,11 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 		logger.Debug("Client program completed successfully")
 		return nil
 	} else {
-		return cl.RunProgram(logger, pClientRW, hClientRW)
+		err := cl.RunProgram(logger, pClientRW, hClientRW)
+		if err != nil && !errors.Is(err, cldr.ErrClaimNotValid) {
+			return fmt.Errorf("%w: %w", journal.ErrClientFailure, err)
+		}
+		return err
 	}
 }
 
@@ -120,9 +190,15 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 // This method will block until both the hinter and preimage handlers complete.
 // If either returns an error both handlers are stopped.
 // The supplied preimageChannel and hintChannel will be closed before this function returns.
-func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) error {
+func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel io.ReadWriteCloser, hintChannel io.ReadWriteCloser, opts ...ProgramOpt) error {
+	var o programOpts
+	for _, opt := range opts {
+		opt(&o)
+	}
 	var serverDone chan error
 	var hinterDone chan error
+	// cleanups are run after the handlers complete, when no more pre-images are served
+	var cleanups []func()
 	defer func() {
 		preimageChannel.Close()
 		hintChannel.Close()
@@ -134,18 +210,42 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 			// Wait for hinter to complete
 			<-hinterDone
 		}
+		// Run in reverse order, as later cleanups may depend on earlier ones
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
 	}()
 	logger.Info("Starting preimage server")
-	var kv kvstore.KV
-	if cfg.DataDir == "" {
-		logger.Info("Using in-memory storage")
-		kv = kvstore.NewMemKV()
+	kv := o.kv
+	if kv != nil {
+		logger.Info("Using shared storage")
 	} else {
-		logger.Info("Creating disk storage", "datadir", cfg.DataDir)
-		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
-			return fmt.Errorf("creating datadir: %w", err)
+		var err error
+		kv, err = createKV(logger, cfg)
+		if err != nil {
+			return err
 		}
-		kv = kvstore.NewDiskKV(cfg.DataDir)
+		if closer, ok := kv.(io.Closer); ok {
+			cleanups = append(cleanups, func() {
+				if err := closer.Close(); err != nil {
+					logger.Error("Failed to close storage", "err", err)
+				}
+			})
+		}
+	}
+	// Pre-images evicted from in-memory storage have to be fetched again with the hint they were fetched for.
+	_, evicting := kv.(*kvstore.LRUKV)
+	if cfg.DataRemote != "" {
+		logger.Info("Using remote storage", "url", cfg.DataRemote)
+		kv = kvstore.NewFallbackKV(logger, kv, kvstore.NewHTTPKV(cfg.DataRemote), cfg.FetchingEnabled())
+	}
+
+	if cfg.PreimageReplayPath != "" {
+		count, err := loadPreimages(cfg.PreimageReplayPath, kv)
+		if err != nil {
+			return fmt.Errorf("failed to load pre-image recording: %w", err)
+		}
+		logger.Info("Loaded pre-image recording", "path", cfg.PreimageReplayPath, "count", count)
 	}
 
 	var (
@@ -157,8 +257,14 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		if err != nil {
 			return fmt.Errorf("failed to create prefetcher: %w", err)
 		}
+		if evicting {
+			prefetch.RefetchEvicted()
+		}
 		getPreimage = func(key common.Hash) ([]byte, error) { return prefetch.GetPreimage(ctx, key) }
 		hinter = prefetch.Hint
+		if cfg.PrefetchWorkers > 0 {
+			cleanups = append(cleanups, speculate(ctx, logger, prefetch, cfg))
+		}
 	} else {
 		logger.Info("Using offline mode. All required pre-images must be pre-populated.")
 		getPreimage = kv.Get
@@ -168,6 +274,25 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		}
 	}
 
+	if cfg.PreimageRecordPath != "" {
+		f, err := os.Create(cfg.PreimageRecordPath)
+		if err != nil {
+			return fmt.Errorf("failed to create pre-image recording: %w", err)
+		}
+		recorder := kvstore.NewPreimageRecorder(f)
+		cleanups = append(cleanups, func() {
+			if err := recorder.Flush(); err != nil {
+				logger.Error("Failed to flush pre-image recording", "err", err)
+			}
+			if err := f.Close(); err != nil {
+				logger.Error("Failed to close pre-image recording", "err", err)
+			}
+		})
+		logger.Info("Recording pre-images", "path", cfg.PreimageRecordPath)
+		// Local pre-images are derived from the config, so only the global pre-images are recorded.
+		getPreimage = recorder.Record(getPreimage)
+	}
+
 	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
 	preimageGetter := preimage.WithVerification(splitter.Get)
@@ -182,6 +307,71 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 	}
 }
 
+// speculate starts speculative prefetching in the background, and returns a function to stop it.
+func speculate(ctx context.Context, logger log.Logger, prefetch *prefetcher.Prefetcher, cfg *config.Config) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := prefetch.Speculate(ctx, prefetcher.SpeculativeConfig{
+			Rollup:       cfg.Rollup,
+			L1Head:       cfg.L1Head,
+			L2Head:       cfg.L2Head,
+			L2OutputRoot: cfg.L2OutputRoot,
+			Workers:      cfg.PrefetchWorkers,
+		})
+		if err != nil {
+			logger.Debug("Speculative prefetching stopped", "err", err)
+			return
+		}
+		logger.Info("Speculative prefetching completed")
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func createKV(logger log.Logger, cfg *config.Config) (kvstore.KV, error) {
+	if cfg.DataDir == "" {
+		if cfg.MemKVMaxEntries > 0 {
+			logger.Info("Using in-memory storage with eviction", "max_entries", cfg.MemKVMaxEntries)
+			kv, err := kvstore.NewLRUKV(cfg.MemKVMaxEntries)
+			if err != nil {
+				return nil, fmt.Errorf("creating in-memory storage: %w", err)
+			}
+			return kv, nil
+		}
+		logger.Info("Using in-memory storage")
+		return kvstore.NewMemKV(), nil
+	}
+	logger.Info("Creating disk storage", "datadir", cfg.DataDir, "format", cfg.DataFormat)
+	if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating datadir: %w", err)
+	}
+	switch cfg.DataFormat {
+	case types.DataFormatFile:
+		return kvstore.NewDiskKV(cfg.DataDir), nil
+	case types.DataFormatPebble:
+		kv, err := kvstore.NewPebbleKV(cfg.DataDir)
+		if err != nil {
+			return nil, fmt.Errorf("creating pebble storage: %w", err)
+		}
+		return kv, nil
+	default:
+		return nil, fmt.Errorf("invalid data format: %s", cfg.DataFormat)
+	}
+}
+
+func loadPreimages(path string, kv kvstore.KV) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return kvstore.LoadPreimages(f, kv)
+}
+
 func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *config.Config) (*prefetcher.Prefetcher, error) {
 	logger.Info("Connecting to L1 node", "l1", cfg.L1URL)
 	l1RPC, err := client.NewRPC(ctx, logger, cfg.L1URL, client.WithDialBackoff(10))
@@ -218,7 +408,7 @@ func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHa
 		defer close(chErr)
 		for {
 			if err := hintReader.NextHint(hinter); err != nil {
-				if err == io.EOF || errors.Is(err, fs.ErrClosed) {
+				if err == io.EOF || errors.Is(err, fs.ErrClosed) || errors.Is(err, io.ErrClosedPipe) {
 					logger.Debug("closing pre-image hint handler")
 					return
 				}
@@ -238,7 +428,7 @@ func launchOracleServer(logger log.Logger, pHostRW io.ReadWriteCloser, getter pr
 		defer close(chErr)
 		for {
 			if err := server.NextPreimageRequest(getter); err != nil {
-				if err == io.EOF || errors.Is(err, fs.ErrClosed) {
+				if err == io.EOF || errors.Is(err, fs.ErrClosed) || errors.Is(err, io.ErrClosedPipe) {
 					logger.Debug("closing pre-image server")
 					return
 				}
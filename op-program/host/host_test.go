@@ -3,6 +3,9 @@ package host
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -13,9 +16,11 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum-optimism/optimism/op-program/io"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -55,6 +60,72 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestRecordAndReplayPreimages(t *testing.T) {
+	dir := t.TempDir()
+	recording := filepath.Join(dir, "preimages.bin")
+	data := []byte("hello world")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(data))
+
+	l1Head := common.Hash{0x11}
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, l1Head, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.PreimageRecordPath = recording
+
+	// Serve the pre-image from a shared store, and record it
+	kv := kvstore.NewMemKV()
+	require.NoError(t, kv.Put(key.PreimageKey(), data))
+	runInMemoryServer(t, cfg, func(pClient *preimage.OracleClient) {
+		require.Equal(t, l1Head.Bytes(), pClient.Get(client.L1HeadLocalIndex))
+		require.Equal(t, data, pClient.Get(key))
+	}, WithKV(kv))
+
+	// Replay the recording without the shared store
+	cfg.PreimageRecordPath = ""
+	cfg.PreimageReplayPath = recording
+	runInMemoryServer(t, cfg, func(pClient *preimage.OracleClient) {
+		require.Equal(t, data, pClient.Get(key))
+	})
+}
+
+func TestRemoteDataWithPebbleStorage(t *testing.T) {
+	data := []byte("hello world")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(data))
+	remoteDir := t.TempDir()
+	require.NoError(t, kvstore.NewDiskKV(remoteDir).Put(key.PreimageKey(), data))
+	srv := httptest.NewServer(http.FileServer(http.Dir(remoteDir)))
+	defer srv.Close()
+
+	dataDir := t.TempDir()
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = dataDir
+	cfg.DataFormat = types.DataFormatPebble
+	cfg.DataRemote = srv.URL
+	runInMemoryServer(t, cfg, func(pClient *preimage.OracleClient) {
+		require.Equal(t, data, pClient.Get(key))
+	})
+
+	// The storage must be closed by the server, and contain the pre-image from the remote
+	kv, err := kvstore.NewPebbleKV(dataDir)
+	require.NoError(t, err)
+	defer kv.Close()
+	v, err := kv.Get(key.PreimageKey())
+	require.NoError(t, err)
+	require.Equal(t, data, v)
+}
+
+func runInMemoryServer(t *testing.T, cfg *config.Config, fn func(pClient *preimage.OracleClient), opts ...ProgramOpt) {
+	preimageServer, preimageClient := io.CreateMemoryChannel()
+	hintServer, hintClient := io.CreateMemoryChannel()
+	logger := testlog.Logger(t, log.LvlTrace)
+	result := make(chan error)
+	go func() {
+		result <- PreimageServer(context.Background(), logger, cfg, preimageServer, hintServer, opts...)
+	}()
+	fn(preimage.NewOracleClient(preimageClient))
+	require.NoError(t, preimageClient.Close())
+	require.NoError(t, hintClient.Close())
+	require.NoError(t, waitFor(result))
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
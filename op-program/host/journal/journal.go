@@ -0,0 +1,167 @@
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+
+	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Version is the version of the journal format.
+const Version = 1
+
+// Exit codes of the client program, see client.Main.
+const (
+	ExitCodeValid   = 0
+	ExitCodeInvalid = 1
+	ExitCodeError   = 2
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported journal version")
+	// ErrHostFailure marks errors of the host, such as failing to fetch pre-images. They don't depend on the program
+	// inputs, so no journal is written for them.
+	ErrHostFailure = errors.New("host failure")
+	// ErrClientFailure marks errors returned by the client program when it runs in-process. They are journaled with
+	// ExitCodeError, the exit code of the client program for the same error when it runs in a separate process.
+	ErrClientFailure = errors.New("client failure")
+	// ErrNoClientResult is returned by New when the client program did not complete with a result to journal.
+	ErrNoClientResult = errors.New("client program did not complete")
+)
+
+// Journal is the result of a run of the fault proof program, together with the inputs that determine it.
+// Two runs with equal inputs must produce an identical journal,
+// so the journals of different runs can be compared, and the journal hash can be attested to.
+type Journal struct {
+	Version uint8 `json:"version"`
+	// L1Head is the hash of the L1 head block that derivation stops at
+	L1Head common.Hash `json:"l1Head"`
+	// L2OutputRoot is the agreed L2 output root that derivation starts from
+	L2OutputRoot common.Hash `json:"l2OutputRoot"`
+	// L2Claim is the claimed L2 output root
+	L2Claim common.Hash `json:"l2Claim"`
+	// L2ClaimBlockNumber is the L2 block number of the claim
+	L2ClaimBlockNumber uint64 `json:"l2ClaimBlockNumber"`
+	// ChainConfigHash commits to the rollup and L2 chain config inputs, see config.Config.ChainConfigHash
+	ChainConfigHash common.Hash `json:"chainConfigHash"`
+	// ExitCode is the exit code of the client program
+	ExitCode int `json:"exitCode"`
+}
+
+// New creates the journal of a run of the program with the given config, that completed with the given result.
+// It returns ErrNoClientResult if the result is not an exit of the client program, see ExitCode.
+func New(cfg *config.Config, result error) (*Journal, error) {
+	exitCode, ok := ExitCode(result)
+	if !ok {
+		return nil, fmt.Errorf("%w: %w", ErrNoClientResult, result)
+	}
+	chainConfigHash, err := cfg.ChainConfigHash()
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{
+		Version:            Version,
+		L1Head:             cfg.L1Head,
+		L2OutputRoot:       cfg.L2OutputRoot,
+		L2Claim:            cfg.L2Claim,
+		L2ClaimBlockNumber: cfg.L2ClaimBlockNumber,
+		ChainConfigHash:    chainConfigHash,
+		ExitCode:           exitCode,
+	}, nil
+}
+
+// ExitCode returns the exit code of the client program that completed with the given result.
+// The exit code of a client program that ran in a separate process is returned as-is, and errors of a client program
+// that ran in-process are mapped to the same exit codes.
+// It returns false if the result is not an exit of the client program: errors marked with ErrHostFailure, and
+// other errors such as failing to start the client program, may be transient so they are not journaled.
+func ExitCode(result error) (int, bool) {
+	var exitErr *exec.ExitError
+	switch {
+	case result == nil:
+		return ExitCodeValid, true
+	case errors.Is(result, ErrHostFailure):
+		return 0, false
+	case errors.Is(result, cldr.ErrClaimNotValid):
+		return ExitCodeInvalid, true
+	case errors.Is(result, ErrClientFailure):
+		return ExitCodeError, true
+	case errors.As(result, &exitErr):
+		return exitErr.ExitCode(), true
+	default:
+		return 0, false
+	}
+}
+
+// Encode returns the canonical encoding of the journal: compact JSON with fields in declaration order,
+// lower-case hex hashes, and a trailing newline.
+func (j *Journal) Encode() ([]byte, error) {
+	dat, err := json.Marshal(j)
+	if err != nil {
+		return nil, err
+	}
+	return append(dat, '\n'), nil
+}
+
+// Hash returns the keccak256 hash of the canonical encoding of the journal.
+func (j *Journal) Hash() (common.Hash, error) {
+	dat, err := j.Encode()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(dat), nil
+}
+
+// Decode parses a journal from its canonical encoding.
+// Unknown fields and unsupported versions are rejected.
+func Decode(dat []byte) (*Journal, error) {
+	dec := json.NewDecoder(bytes.NewReader(dat))
+	dec.DisallowUnknownFields()
+	var j Journal
+	if err := dec.Decode(&j); err != nil {
+		return nil, fmt.Errorf("failed to decode journal: %w", err)
+	}
+	if j.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, j.Version)
+	}
+	return &j, nil
+}
+
+// Write atomically writes the canonical encoding of the journal to the file at path.
+func Write(path string, j *Journal) error {
+	dat, err := j.Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode journal: %w", err)
+	}
+	w, err := ioutil.NewAtomicWriterCompressed(path, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create journal file: %w", err)
+	}
+	if _, err := w.Write(dat); err != nil {
+		_ = w.Abort()
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return w.Close()
+}
+
+// Read reads the journal from the file at path.
+func Read(path string) (*Journal, error) {
+	f, err := ioutil.OpenDecompressed(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer f.Close()
+	dat, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return Decode(dat)
+}
@@ -0,0 +1,123 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *config.Config {
+	return config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+}
+
+func TestNew(t *testing.T) {
+	cfg := testConfig()
+	j, err := New(cfg, nil)
+	require.NoError(t, err)
+	rollupJSON, l2ChainConfigJSON, err := cfg.ChainConfigInputs()
+	require.NoError(t, err)
+	expectedHash := chainconfig.ChainConfigHash(rollupJSON, l2ChainConfigJSON)
+	require.Equal(t, &Journal{
+		Version:            Version,
+		L1Head:             cfg.L1Head,
+		L2OutputRoot:       cfg.L2OutputRoot,
+		L2Claim:            cfg.L2Claim,
+		L2ClaimBlockNumber: cfg.L2ClaimBlockNumber,
+		ChainConfigHash:    expectedHash,
+		ExitCode:           ExitCodeValid,
+	}, j)
+
+	t.Run("DifferentChain", func(t *testing.T) {
+		other := config.NewConfig(chaincfg.Sepolia, chainconfig.OPSepoliaChainConfig, cfg.L1Head, cfg.L2Head, cfg.L2OutputRoot, cfg.L2Claim, cfg.L2ClaimBlockNumber)
+		otherJournal, err := New(other, nil)
+		require.NoError(t, err)
+		require.NotEqual(t, j.ChainConfigHash, otherJournal.ChainConfigHash)
+	})
+}
+
+func TestExitCode(t *testing.T) {
+	requireExitCode := func(t *testing.T, expected int, result error) {
+		code, ok := ExitCode(result)
+		require.True(t, ok)
+		require.Equal(t, expected, code)
+	}
+	requireExitCode(t, ExitCodeValid, nil)
+	requireExitCode(t, ExitCodeInvalid, fmt.Errorf("wrapped: %w", cldr.ErrClaimNotValid))
+
+	err := exec.Command("sh", "-c", "exit 2").Run()
+	require.Error(t, err)
+	exitErr := fmt.Errorf("failed to wait for child program: %w", err)
+	requireExitCode(t, ExitCodeError, exitErr)
+	requireExitCode(t, ExitCodeError, fmt.Errorf("%w: boom", ErrClientFailure))
+
+	t.Run("HostFailures", func(t *testing.T) {
+		_, ok := ExitCode(errors.New("program cmd failed to start"))
+		require.False(t, ok)
+		_, ok = ExitCode(errors.Join(exitErr, fmt.Errorf("%w: preimage server failed", ErrHostFailure)))
+		require.False(t, ok, "client exit caused by the pre-image server failing")
+		_, ok = ExitCode(errors.Join(fmt.Errorf("%w: boom", ErrClientFailure), fmt.Errorf("%w: preimage server failed", ErrHostFailure)))
+		require.False(t, ok, "in-process client failure caused by the pre-image server failing")
+
+		_, err := New(testConfig(), errors.New("boom"))
+		require.ErrorIs(t, err, ErrNoClientResult)
+	})
+}
+
+func TestEncoding(t *testing.T) {
+	j := &Journal{
+		Version:            Version,
+		L1Head:             common.Hash{0xaa},
+		L2OutputRoot:       common.Hash{0xbb},
+		L2Claim:            common.Hash{0xcc},
+		L2ClaimBlockNumber: 1234,
+		ChainConfigHash:    common.Hash{0xdd},
+		ExitCode:           ExitCodeInvalid,
+	}
+	dat, err := j.Encode()
+	require.NoError(t, err)
+	require.Equal(t, `{"version":1,`+
+		`"l1Head":"0xaa00000000000000000000000000000000000000000000000000000000000000",`+
+		`"l2OutputRoot":"0xbb00000000000000000000000000000000000000000000000000000000000000",`+
+		`"l2Claim":"0xcc00000000000000000000000000000000000000000000000000000000000000",`+
+		`"l2ClaimBlockNumber":1234,`+
+		`"chainConfigHash":"0xdd00000000000000000000000000000000000000000000000000000000000000",`+
+		`"exitCode":1}`+"\n", string(dat))
+
+	decoded, err := Decode(dat)
+	require.NoError(t, err)
+	require.Equal(t, j, decoded)
+
+	t.Run("UnknownField", func(t *testing.T) {
+		_, err := Decode([]byte(`{"version":1,"foo":2}`))
+		require.ErrorContains(t, err, "unknown field")
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		_, err := Decode([]byte(`{"version":2}`))
+		require.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+}
+
+func TestWriteAndRead(t *testing.T) {
+	j, err := New(testConfig(), cldr.ErrClaimNotValid)
+	require.NoError(t, err)
+	for _, name := range []string{"journal.json", "journal.json.gz"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, Write(path, j))
+			read, err := Read(path)
+			require.NoError(t, err)
+			require.Equal(t, j, read)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package kvstore
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrUnverifiedPreimage is returned when a pre-image read from the secondary store doesn't match its key.
+var ErrUnverifiedPreimage = errors.New("pre-image does not match key")
+
+// FallbackKV reads pre-images from the primary KV store, and falls back to the secondary KV store
+// for pre-images that are not found in the primary store.
+// Pre-images read from the secondary store are stored in the primary store, and all writes go to the primary store,
+// so the secondary store may be read-only, like HTTPKV.
+// If the pre-images can also be fetched from elsewhere, errors reading from the secondary store are logged and
+// reported as ErrNotFound, so an unavailable secondary store, such as a remote server, doesn't stop the program.
+// The secondary store is not trusted: pre-images read from it are verified against their key before they are stored,
+// and pre-images that don't match, or of which the key type can't be verified, are reported as ErrNotFound.
+type FallbackKV struct {
+	logger    log.Logger
+	primary   KV
+	secondary KV
+	canFetch  bool
+}
+
+var _ KV = (*FallbackKV)(nil)
+
+// NewFallbackKV creates a FallbackKV. canFetch indicates that pre-images not found in either store can be
+// fetched from elsewhere, in which case errors from the secondary store are reported as ErrNotFound.
+func NewFallbackKV(logger log.Logger, primary KV, secondary KV, canFetch bool) *FallbackKV {
+	return &FallbackKV{logger: logger, primary: primary, secondary: secondary, canFetch: canFetch}
+}
+
+func (f *FallbackKV) Put(k common.Hash, v []byte) error {
+	return f.primary.Put(k, v)
+}
+
+func (f *FallbackKV) Get(k common.Hash) ([]byte, error) {
+	v, err := f.primary.Get(k)
+	if !errors.Is(err, ErrNotFound) {
+		return v, err
+	}
+	v, err = f.secondary.Get(k)
+	if err != nil {
+		if f.canFetch && !errors.Is(err, ErrNotFound) {
+			f.logger.Warn("Failed to read pre-image from fallback store, fetching it instead", "key", k, "err", err)
+			return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+		}
+		return nil, err
+	}
+	if err := verifyPreimage(k, v); err != nil {
+		f.logger.Warn("Discarding pre-image from fallback store", "key", k, "err", err)
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	if err := f.primary.Put(k, v); err != nil {
+		return nil, fmt.Errorf("failed to store pre-image %s: %w", k, err)
+	}
+	return v, nil
+}
+
+// verifyPreimage checks that the pre-image hashes to its key.
+// Only keccak256 and sha256 keys can be verified from the pre-image alone.
+func verifyPreimage(k common.Hash, v []byte) error {
+	var hash [32]byte
+	switch preimage.KeyType(k[0]) {
+	case preimage.Keccak256KeyType:
+		hash = preimage.Keccak256(v)
+	case preimage.Sha256KeyType:
+		hash = sha256.Sum256(v)
+	default:
+		return fmt.Errorf("%w: can't verify key type %d", ErrUnverifiedPreimage, k[0])
+	}
+	// The first byte of the key is replaced by the key type.
+	if !slices.Equal(hash[1:], k[1:]) {
+		return fmt.Errorf("%w: key %s, hash %x", ErrUnverifiedPreimage, k, hash)
+	}
+	return nil
+}
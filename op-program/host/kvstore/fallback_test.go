@@ -0,0 +1,111 @@
+package kvstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackKV(t *testing.T) {
+	t.Run("KV", func(t *testing.T) {
+		kvTest(t, NewFallbackKV(testlog.Logger(t, log.LvlInfo), NewMemKV(), NewMemKV(), false))
+	})
+
+	t.Run("Fallback", func(t *testing.T) {
+		primary := NewMemKV()
+		secondary := NewMemKV()
+		require.NoError(t, primary.Put(common.Hash{0xaa}, []byte("primary")))
+		require.NoError(t, secondary.Put(common.Hash{0xaa}, []byte("secondary")))
+		keccakKey := preimage.Keccak256Key(crypto.Keccak256Hash([]byte("keccak"))).PreimageKey()
+		require.NoError(t, secondary.Put(keccakKey, []byte("keccak")))
+		sha256Key := preimage.Sha256Key(sha256.Sum256([]byte("sha256"))).PreimageKey()
+		require.NoError(t, secondary.Put(sha256Key, []byte("sha256")))
+		kv := NewFallbackKV(testlog.Logger(t, log.LvlInfo), primary, secondary, false)
+
+		dat, err := kv.Get(common.Hash{0xaa})
+		require.NoError(t, err)
+		require.Equal(t, "primary", string(dat), "primary store takes precedence")
+
+		dat, err = kv.Get(keccakKey)
+		require.NoError(t, err)
+		require.Equal(t, "keccak", string(dat))
+		dat, err = primary.Get(keccakKey)
+		require.NoError(t, err, "pre-image from secondary store must be stored in primary store")
+		require.Equal(t, "keccak", string(dat))
+
+		dat, err = kv.Get(sha256Key)
+		require.NoError(t, err)
+		require.Equal(t, "sha256", string(dat))
+
+		_, err = kv.Get(common.Hash{0xcc})
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("ReadOnlySecondary", func(t *testing.T) {
+		kv := NewFallbackKV(testlog.Logger(t, log.LvlInfo), NewMemKV(), NewHTTPKV("http://localhost:0"), false)
+		require.NoError(t, kv.Put(common.Hash{0xaa}, []byte("hello")), "writes go to the primary store")
+	})
+
+	t.Run("WrongPreimage", func(t *testing.T) {
+		primary := NewMemKV()
+		keccakKey := preimage.Keccak256Key(crypto.Keccak256Hash([]byte("keccak"))).PreimageKey()
+		sha256Key := preimage.Sha256Key(sha256.Sum256([]byte("sha256"))).PreimageKey()
+		blobKey := preimage.BlobKey(crypto.Keccak256Hash([]byte("blob"))).PreimageKey()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(hex.EncodeToString([]byte("wrong"))))
+		}))
+		t.Cleanup(server.Close)
+		kv := NewFallbackKV(testlog.Logger(t, log.LvlCrit), primary, NewHTTPKV(server.URL), true)
+
+		for _, key := range []common.Hash{keccakKey, sha256Key, blobKey} {
+			_, err := kv.Get(key)
+			require.ErrorIs(t, err, ErrNotFound, "pre-image should be fetched instead")
+			require.ErrorIs(t, err, ErrUnverifiedPreimage)
+			_, err = primary.Get(key)
+			require.ErrorIs(t, err, ErrNotFound, "wrong pre-image must not be stored")
+		}
+	})
+
+	t.Run("PrimaryError", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		secondary := NewMemKV()
+		require.NoError(t, secondary.Put(common.Hash{0xaa}, []byte("secondary")))
+		kv := NewFallbackKV(testlog.Logger(t, log.LvlInfo), &errorKV{err: expectedErr}, secondary, true)
+		_, err := kv.Get(common.Hash{0xaa})
+		require.ErrorIs(t, err, expectedErr, "errors other than not found are not hidden by the fallback")
+	})
+
+	t.Run("SecondaryError", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		kv := NewFallbackKV(testlog.Logger(t, log.LvlCrit), NewMemKV(), &errorKV{err: expectedErr}, false)
+		_, err := kv.Get(common.Hash{0xaa})
+		require.ErrorIs(t, err, expectedErr)
+		require.NotErrorIs(t, err, ErrNotFound, "errors are returned if the pre-image can't be fetched instead")
+
+		kv = NewFallbackKV(testlog.Logger(t, log.LvlCrit), NewMemKV(), &errorKV{err: expectedErr}, true)
+		_, err = kv.Get(common.Hash{0xaa})
+		require.ErrorIs(t, err, ErrNotFound, "pre-image should be fetched instead")
+	})
+}
+
+type errorKV struct {
+	err error
+}
+
+func (e *errorKV) Put(k common.Hash, v []byte) error {
+	return e.err
+}
+
+func (e *errorKV) Get(k common.Hash) ([]byte, error) {
+	return nil, e.err
+}
@@ -0,0 +1,72 @@
+package kvstore
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrReadOnly is returned when writing to a read-only KV store.
+var ErrReadOnly = errors.New("read-only kv store")
+
+// maxHTTPResponseSize bounds the size of a hex-encoded pre-image read from a HTTP server.
+const maxHTTPResponseSize = 2*maxRecordedPreimageSize + 2
+
+const defaultHTTPTimeout = 30 * time.Second
+
+// HTTPKV is a read-only KV store that retrieves pre-images from a HTTP server.
+// A pre-image is requested with GET <base url>/<key>.txt, and the response body is the hex-encoded pre-image.
+// This matches the layout of a DiskKV directory, so hosts can share the pre-images of a DiskKV data directory
+// by serving it with any static file server.
+// HTTPKV is safe for concurrent use.
+type HTTPKV struct {
+	baseURL string
+	client  *http.Client
+}
+
+var _ KV = (*HTTPKV)(nil)
+
+// NewHTTPKV creates a HTTPKV that gets pre-images from the server at the given base URL.
+func NewHTTPKV(baseURL string) *HTTPKV {
+	return &HTTPKV{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: defaultHTTPTimeout},
+	}
+}
+
+func (h *HTTPKV) Put(k common.Hash, v []byte) error {
+	return fmt.Errorf("cannot put pre-image %s: %w", k, ErrReadOnly)
+}
+
+func (h *HTTPKV) Get(k common.Hash) ([]byte, error) {
+	resp, err := h.client.Get(h.baseURL + "/" + k.String() + ".txt")
+	if err != nil {
+		return nil, fmt.Errorf("failed to request pre-image %s: %w", k, err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, fmt.Errorf("failed to request pre-image %s: unexpected status %s", k, resp.Status)
+	}
+	dat, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pre-image %s: %w", k, err)
+	}
+	if len(dat) > maxHTTPResponseSize {
+		return nil, fmt.Errorf("pre-image %s exceeds the maximum size", k)
+	}
+	v, err := hex.DecodeString(strings.TrimSpace(string(dat)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode pre-image %s: %w", k, err)
+	}
+	return v, nil
+}
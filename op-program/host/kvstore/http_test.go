@@ -0,0 +1,55 @@
+package kvstore
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPKV(t *testing.T) {
+	dir := t.TempDir()
+	disk := NewDiskKV(dir)
+	require.NoError(t, disk.Put(common.Hash{0xaa}, []byte("hello world")))
+	require.NoError(t, disk.Put(common.Hash{0xbb}, []byte{}))
+
+	srv := httptest.NewServer(http.FileServer(http.Dir(dir)))
+	defer srv.Close()
+	kv := NewHTTPKV(srv.URL + "/")
+
+	dat, err := kv.Get(common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(dat))
+
+	dat, err = kv.Get(common.Hash{0xbb})
+	require.NoError(t, err)
+	require.Empty(t, dat)
+
+	_, err = kv.Get(common.Hash{0xcc})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.ErrorIs(t, kv.Put(common.Hash{0xcc}, []byte{1}), ErrReadOnly)
+}
+
+func TestHTTPKVErrors(t *testing.T) {
+	t.Run("server error", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer srv.Close()
+		_, err := NewHTTPKV(srv.URL).Get(common.Hash{0xaa})
+		require.ErrorContains(t, err, "unexpected status")
+		require.NotErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("invalid encoding", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("not hex"))
+		}))
+		defer srv.Close()
+		_, err := NewHTTPKV(srv.URL).Get(common.Hash{0xaa})
+		require.ErrorContains(t, err, "failed to decode")
+	})
+}
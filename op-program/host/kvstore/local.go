@@ -2,7 +2,6 @@ package kvstore
 
 import (
 	"encoding/binary"
-	"encoding/json"
 
 	"github.com/ethereum-optimism/optimism/op-program/client"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
@@ -48,9 +47,11 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 		}
 		return binary.BigEndian.AppendUint64(nil, chainID), nil
 	case l2ChainConfigKey:
-		return json.Marshal(s.config.L2ChainConfig)
+		_, l2ChainConfigJSON, err := s.config.ChainConfigInputs()
+		return l2ChainConfigJSON, err
 	case rollupKey:
-		return json.Marshal(s.config.Rollup)
+		rollupJSON, _, err := s.config.ChainConfigInputs()
+		return rollupJSON, err
 	default:
 		return nil, ErrNotFound
 	}
@@ -0,0 +1,39 @@
+package kvstore
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// LRUKV implements the KV store interface in memory, and evicts the least recently used pre-images
+// once more than the maximum number of pre-images are stored.
+// Evicted pre-images are reported as not found, so this should only be used when pre-images can be fetched again.
+// LRUKV is safe for concurrent use.
+type LRUKV struct {
+	cache *lru.Cache[common.Hash, []byte]
+}
+
+var _ KV = (*LRUKV)(nil)
+
+func NewLRUKV(maxEntries int) (*LRUKV, error) {
+	cache, err := lru.New[common.Hash, []byte](maxEntries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+	return &LRUKV{cache: cache}, nil
+}
+
+func (m *LRUKV) Put(k common.Hash, v []byte) error {
+	m.cache.Add(k, v)
+	return nil
+}
+
+func (m *LRUKV) Get(k common.Hash) ([]byte, error) {
+	v, ok := m.cache.Get(k)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
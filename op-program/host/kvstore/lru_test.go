@@ -0,0 +1,36 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUKV(t *testing.T) {
+	kv, err := NewLRUKV(100)
+	require.NoError(t, err)
+	kvTest(t, kv)
+}
+
+func TestLRUKVEviction(t *testing.T) {
+	_, err := NewLRUKV(0)
+	require.Error(t, err)
+
+	kv, err := NewLRUKV(2)
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{1}))
+	require.NoError(t, kv.Put(common.Hash{0xbb}, []byte{2}))
+	_, err = kv.Get(common.Hash{0xaa}) // use 0xaa, so 0xbb is the least recently used
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(common.Hash{0xcc}, []byte{3}))
+
+	_, err = kv.Get(common.Hash{0xbb})
+	require.ErrorIs(t, err, ErrNotFound, "least recently used pre-image must be evicted")
+	v, err := kv.Get(common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, v)
+	v, err = kv.Get(common.Hash{0xcc})
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, v)
+}
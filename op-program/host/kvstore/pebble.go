@@ -0,0 +1,73 @@
+package kvstore
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PebbleKV is a disk-backed key-value store, with PebbleDB as the underlying DBMS.
+// Unlike DiskKV, it performs well with millions of pre-images.
+// PebbleKV is safe for concurrent use with a single PebbleKV instance.
+type PebbleKV struct {
+	sync.RWMutex
+	db *pebble.DB
+}
+
+var _ KV = (*PebbleKV)(nil)
+
+// NewPebbleKV creates a PebbleKV that puts/gets pre-images in the pebble database at the given directory path.
+// The database is created if it does not exist yet.
+func NewPebbleKV(path string) (*PebbleKV, error) {
+	db, err := pebble.Open(path, &pebble.Options{
+		Levels: []pebble.LevelOptions{
+			{Compression: pebble.SnappyCompression},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pebble database at %s: %w", path, err)
+	}
+	return &PebbleKV{db: db}, nil
+}
+
+func (d *PebbleKV) Put(k common.Hash, v []byte) error {
+	d.Lock()
+	defer d.Unlock()
+	// Pre-images can be fetched again, so the write does not need to be synced to disk
+	if err := d.db.Set(k.Bytes(), v, pebble.NoSync); err != nil {
+		return fmt.Errorf("failed to write pre-image %s: %w", k, err)
+	}
+	return nil
+}
+
+func (d *PebbleKV) Get(k common.Hash) ([]byte, error) {
+	d.RLock()
+	defer d.RUnlock()
+	dat, closer, err := d.db.Get(k.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read pre-image %s: %w", k, err)
+	}
+	// The returned slice is only valid until the closer is called
+	ret := make([]byte, len(dat))
+	copy(ret, dat)
+	if err := closer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to release pre-image %s: %w", k, err)
+	}
+	return ret, nil
+}
+
+// Close flushes all pending writes and closes the database.
+func (d *PebbleKV) Close() error {
+	d.Lock()
+	defer d.Unlock()
+	if err := d.db.Flush(); err != nil {
+		return fmt.Errorf("failed to flush pebble database: %w", err)
+	}
+	return d.db.Close()
+}
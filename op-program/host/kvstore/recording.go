@@ -0,0 +1,103 @@
+package kvstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxRecordedPreimageSize bounds the size of a pre-image read from a recording, to not allocate arbitrary amounts of memory.
+const maxRecordedPreimageSize = 1 << 30
+
+// PreimageRecorder records the pre-images that are served to the client program,
+// so the program can be replayed later without access to the original pre-image sources.
+// Every pre-image is encoded as the 32-byte key, the 8-byte big-endian length of the pre-image, and the pre-image.
+// Each key is recorded only once.
+// PreimageRecorder is safe for concurrent use.
+type PreimageRecorder struct {
+	mu   sync.Mutex
+	w    *bufio.Writer
+	seen map[common.Hash]struct{}
+}
+
+func NewPreimageRecorder(w io.Writer) *PreimageRecorder {
+	return &PreimageRecorder{
+		w:    bufio.NewWriter(w),
+		seen: make(map[common.Hash]struct{}),
+	}
+}
+
+// Record wraps the source, to record all pre-images that are successfully retrieved from it.
+func (r *PreimageRecorder) Record(source PreimageSource) PreimageSource {
+	return func(key common.Hash) ([]byte, error) {
+		v, err := source(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.add(key, v); err != nil {
+			return nil, fmt.Errorf("failed to record pre-image %s: %w", key, err)
+		}
+		return v, nil
+	}
+}
+
+func (r *PreimageRecorder) add(key common.Hash, v []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.seen[key]; ok {
+		return nil
+	}
+	if _, err := r.w.Write(key[:]); err != nil {
+		return err
+	}
+	if err := binary.Write(r.w, binary.BigEndian, uint64(len(v))); err != nil {
+		return err
+	}
+	if _, err := r.w.Write(v); err != nil {
+		return err
+	}
+	r.seen[key] = struct{}{}
+	return nil
+}
+
+// Flush writes any buffered pre-images to the underlying writer.
+func (r *PreimageRecorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.w.Flush()
+}
+
+// LoadPreimages reads the pre-images of a recording made by PreimageRecorder into the KV store,
+// and returns the number of pre-images that were loaded.
+func LoadPreimages(r io.Reader, kv KV) (int, error) {
+	br := bufio.NewReader(r)
+	count := 0
+	for {
+		var key common.Hash
+		if _, err := io.ReadFull(br, key[:]); errors.Is(err, io.EOF) {
+			return count, nil
+		} else if err != nil {
+			return count, fmt.Errorf("failed to read key of pre-image %d: %w", count, err)
+		}
+		var size uint64
+		if err := binary.Read(br, binary.BigEndian, &size); err != nil {
+			return count, fmt.Errorf("failed to read length of pre-image %s: %w", key, err)
+		}
+		if size > maxRecordedPreimageSize {
+			return count, fmt.Errorf("pre-image %s of %d bytes exceeds the maximum size", key, size)
+		}
+		v := make([]byte, size)
+		if _, err := io.ReadFull(br, v); err != nil {
+			return count, fmt.Errorf("failed to read pre-image %s: %w", key, err)
+		}
+		if err := kv.Put(key, v); err != nil {
+			return count, fmt.Errorf("failed to store pre-image %s: %w", key, err)
+		}
+		count++
+	}
+}
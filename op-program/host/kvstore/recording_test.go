@@ -0,0 +1,51 @@
+package kvstore
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreimageRecording(t *testing.T) {
+	source := NewMemKV()
+	require.NoError(t, source.Put(common.Hash{0xaa}, []byte("hello")))
+	require.NoError(t, source.Put(common.Hash{0xbb}, []byte{}))
+	require.NoError(t, source.Put(common.Hash{0xcc}, []byte("not requested")))
+
+	var buf bytes.Buffer
+	recorder := NewPreimageRecorder(&buf)
+	get := recorder.Record(source.Get)
+	for _, key := range []common.Hash{{0xaa}, {0xbb}, {0xaa}} {
+		expected, err := source.Get(key)
+		require.NoError(t, err)
+		v, err := get(key)
+		require.NoError(t, err)
+		require.Equal(t, expected, v)
+	}
+	_, err := get(common.Hash{0xdd})
+	require.ErrorIs(t, err, ErrNotFound, "errors of the source are passed through")
+	require.NoError(t, recorder.Flush())
+	require.Equal(t, 2*(32+8)+len("hello"), buf.Len(), "pre-images are recorded once")
+
+	replay := NewMemKV()
+	count, err := LoadPreimages(bytes.NewReader(buf.Bytes()), replay)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	v, err := replay.Get(common.Hash{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, []byte("hello"), v)
+	v, err = replay.Get(common.Hash{0xbb})
+	require.NoError(t, err)
+	require.Empty(t, v)
+	_, err = replay.Get(common.Hash{0xcc})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	t.Run("truncated", func(t *testing.T) {
+		_, err := LoadPreimages(bytes.NewReader(buf.Bytes()[:buf.Len()-1]), NewMemKV())
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrNotFound))
+	})
+}
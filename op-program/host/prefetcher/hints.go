@@ -0,0 +1,51 @@
+package prefetcher
+
+import (
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// keyHints maps pre-image keys to the hint they were fetched for. It is safe for concurrent use,
+// as pre-images are fetched concurrently by speculative prefetching.
+type keyHints struct {
+	lock  sync.RWMutex
+	hints map[common.Hash]string
+}
+
+func (h *keyHints) get(key common.Hash) (string, bool) {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	hint, ok := h.hints[key]
+	return hint, ok
+}
+
+func (h *keyHints) set(key common.Hash, hint string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.hints[key] = hint
+}
+
+// hintedKV stores the pre-images fetched for a single hint. It remembers the hint of every stored pre-image,
+// if keyHints is set, and captures the pre-image of the wanted key, if any, as it may be evicted right after.
+type hintedKV struct {
+	kvstore.KV
+	hint     string
+	keyHints *keyHints
+
+	want   *common.Hash
+	wanted []byte
+	found  bool
+}
+
+func (kv *hintedKV) Put(k common.Hash, v []byte) error {
+	if kv.keyHints != nil {
+		kv.keyHints.set(k, kv.hint)
+	}
+	if kv.want != nil && k == *kv.want {
+		kv.wanted = v
+		kv.found = true
+	}
+	return kv.KV.Put(k, v)
+}
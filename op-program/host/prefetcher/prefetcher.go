@@ -23,6 +23,7 @@ import (
 
 type L1Source interface {
 	InfoByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, error)
+	InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error)
 	InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error)
 	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
 }
@@ -46,6 +47,8 @@ type Prefetcher struct {
 	l2Fetcher     L2Source
 	lastHint      string
 	kvStore       kvstore.KV
+	// keyHints is the hint that each pre-image was fetched for, if the KV store may evict pre-images.
+	keyHints *keyHints
 }
 
 func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
@@ -64,26 +67,71 @@ func (p *Prefetcher) Hint(hint string) error {
 	return nil
 }
 
+// RefetchEvicted makes the prefetcher remember the hint that each pre-image was fetched for,
+// so that pre-images evicted from the KV store are fetched again with the same hint when they are requested.
+// It must be enabled if the KV store may evict pre-images, before any pre-images are fetched.
+func (p *Prefetcher) RefetchEvicted() {
+	p.keyHints = &keyHints{hints: make(map[common.Hash]string)}
+}
+
 func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte, error) {
 	p.logger.Trace("Pre-image requested", "key", key)
 	pre, err := p.kvStore.Get(key)
 	// Use a loop to keep retrying the prefetch as long as the key is not found
 	// This handles the case where the prefetch downloads a preimage, but it is then deleted unexpectedly
 	// before we get to read it.
-	for errors.Is(err, kvstore.ErrNotFound) && p.lastHint != "" {
-		hint := p.lastHint
-		if err := p.prefetch(ctx, hint); err != nil {
-			return nil, fmt.Errorf("prefetch failed: %w", err)
+	for errors.Is(err, kvstore.ErrNotFound) {
+		hint := p.hintFor(key)
+		if hint == "" {
+			break
 		}
-		pre, err = p.kvStore.Get(key)
-		if err != nil {
-			p.logger.Error("Fetched pre-images for last hint but did not find required key", "hint", hint, "key", key)
+		pre, err = p.fetchKey(ctx, hint, key)
+		if errors.Is(err, kvstore.ErrNotFound) {
+			p.logger.Error("Fetched pre-images for hint but did not find required key", "hint", hint, "key", key)
+		} else if err != nil {
+			return nil, fmt.Errorf("prefetch failed: %w", err)
 		}
 	}
 	return pre, err
 }
 
+// hintFor returns the hint that the pre-image was fetched for before, if it was evicted, or the last hint otherwise.
+func (p *Prefetcher) hintFor(key common.Hash) string {
+	if p.keyHints != nil {
+		if hint, ok := p.keyHints.get(key); ok {
+			return hint
+		}
+	}
+	return p.lastHint
+}
+
+// prefetch fetches the pre-images for the hint, and stores them in the KV store.
 func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
+	return p.fetchHint(ctx, hint, p.hintedKV(hint))
+}
+
+// fetchKey fetches the pre-images for the hint, and returns the pre-image of the key if it is one of them.
+// If the KV store may evict pre-images, the pre-image is captured while it is stored,
+// as it may be evicted again before it can be read back.
+func (p *Prefetcher) fetchKey(ctx context.Context, hint string, key common.Hash) ([]byte, error) {
+	kv := p.hintedKV(hint)
+	if p.keyHints != nil {
+		kv.want = &key
+	}
+	if err := p.fetchHint(ctx, hint, kv); err != nil {
+		return nil, err
+	}
+	if kv.found {
+		return kv.wanted, nil
+	}
+	return p.kvStore.Get(key)
+}
+
+func (p *Prefetcher) hintedKV(hint string) *hintedKV {
+	return &hintedKV{KV: p.kvStore, hint: hint, keyHints: p.keyHints}
+}
+
+func (p *Prefetcher) fetchHint(ctx context.Context, hint string, kv kvstore.KV) error {
 	hintType, hintBytes, err := parseHint(hint)
 	if err != nil {
 		return err
@@ -103,7 +151,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("marshall header: %w", err)
 		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
+		return kv.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
 	case l1.HintL1Transactions:
 		if len(hintBytes) != 32 {
 			return fmt.Errorf("invalid L1 transactions hint: %x", hint)
@@ -113,7 +161,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L1 block %s txs: %w", hash, err)
 		}
-		return p.storeTransactions(txs)
+		return storeTransactions(kv, txs)
 	case l1.HintL1Receipts:
 		if len(hintBytes) != 32 {
 			return fmt.Errorf("invalid L1 receipts hint: %x", hint)
@@ -123,7 +171,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L1 block %s receipts: %w", hash, err)
 		}
-		return p.storeReceipts(receipts)
+		return storeReceipts(kv, receipts)
 	case l1.HintL1Blob:
 		if len(hintBytes) != 48 {
 			return fmt.Errorf("invalid blob hint: %x", hint)
@@ -146,7 +194,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		sidecar := sidecars[0]
 
 		// Put the preimage for the versioned hash into the kv store
-		if err = p.kvStore.Put(preimage.Sha256Key(blobVersionHash).PreimageKey(), sidecar.KZGCommitment[:]); err != nil {
+		if err = kv.Put(preimage.Sha256Key(blobVersionHash).PreimageKey(), sidecar.KZGCommitment[:]); err != nil {
 			return err
 		}
 
@@ -157,7 +205,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		for i := 0; i < params.BlobTxFieldElementsPerBlob; i++ {
 			binary.BigEndian.PutUint64(blobKey[72:], uint64(i))
 			blobKeyHash := crypto.Keccak256Hash(blobKey)
-			if err = p.kvStore.Put(preimage.BlobKey(blobKeyHash).PreimageKey(), sidecar.Blob[i<<5:(i+1)<<5]); err != nil {
+			if err = kv.Put(preimage.BlobKey(blobKeyHash).PreimageKey(), sidecar.Blob[i<<5:(i+1)<<5]); err != nil {
 				return err
 			}
 		}
@@ -171,15 +219,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L2 block %s: %w", hash, err)
 		}
-		data, err := header.HeaderRLP()
-		if err != nil {
-			return fmt.Errorf("failed to encode header to RLP: %w", err)
-		}
-		err = p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
-		if err != nil {
-			return err
-		}
-		return p.storeTransactions(txs)
+		return storeL2Block(kv, hash, header, txs)
 	case l2.HintL2StateNode:
 		if len(hintBytes) != 32 {
 			return fmt.Errorf("invalid L2 state node hint: %x", hint)
@@ -189,7 +229,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L2 state node %s: %w", hash, err)
 		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), node)
+		return kv.Put(preimage.Keccak256Key(hash).PreimageKey(), node)
 	case l2.HintL2Code:
 		if len(hintBytes) != 32 {
 			return fmt.Errorf("invalid L2 code hint: %x", hint)
@@ -199,7 +239,7 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L2 contract code %s: %w", hash, err)
 		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), code)
+		return kv.Put(preimage.Keccak256Key(hash).PreimageKey(), code)
 	case l2.HintL2Output:
 		if len(hintBytes) != 32 {
 			return fmt.Errorf("invalid L2 output hint: %x", hint)
@@ -209,32 +249,44 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 		if err != nil {
 			return fmt.Errorf("failed to fetch L2 output root %s: %w", hash, err)
 		}
-		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), output.Marshal())
+		return kv.Put(preimage.Keccak256Key(hash).PreimageKey(), output.Marshal())
 	}
 	return fmt.Errorf("unknown hint type: %v", hintType)
 }
 
-func (p *Prefetcher) storeReceipts(receipts types.Receipts) error {
+func storeL2Block(kv kvstore.KV, hash common.Hash, header eth.BlockInfo, txs types.Transactions) error {
+	data, err := header.HeaderRLP()
+	if err != nil {
+		return fmt.Errorf("failed to encode header to RLP: %w", err)
+	}
+	err = kv.Put(preimage.Keccak256Key(hash).PreimageKey(), data)
+	if err != nil {
+		return err
+	}
+	return storeTransactions(kv, txs)
+}
+
+func storeReceipts(kv kvstore.KV, receipts types.Receipts) error {
 	opaqueReceipts, err := eth.EncodeReceipts(receipts)
 	if err != nil {
 		return err
 	}
-	return p.storeTrieNodes(opaqueReceipts)
+	return storeTrieNodes(kv, opaqueReceipts)
 }
 
-func (p *Prefetcher) storeTransactions(txs types.Transactions) error {
+func storeTransactions(kv kvstore.KV, txs types.Transactions) error {
 	opaqueTxs, err := eth.EncodeTransactions(txs)
 	if err != nil {
 		return err
 	}
-	return p.storeTrieNodes(opaqueTxs)
+	return storeTrieNodes(kv, opaqueTxs)
 }
 
-func (p *Prefetcher) storeTrieNodes(values []hexutil.Bytes) error {
+func storeTrieNodes(kv kvstore.KV, values []hexutil.Bytes) error {
 	_, nodes := mpt.WriteTrie(values)
 	for _, node := range nodes {
 		key := preimage.Keccak256Key(crypto.Keccak256Hash(node)).PreimageKey()
-		if err := p.kvStore.Put(key, node); err != nil {
+		if err := kv.Put(key, node); err != nil {
 			return fmt.Errorf("failed to store node: %w", err)
 		}
 	}
@@ -399,6 +399,40 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	require.EqualValues(t, node, result)
 }
 
+func TestRefetchEvictedWithHintItWasFetchedFor(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	nodeA := testutils.RandomData(rng, 30)
+	hashA := crypto.Keccak256Hash(nodeA)
+	nodeB := testutils.RandomData(rng, 30)
+	hashB := crypto.Keccak256Hash(nodeB)
+
+	_, l1Source, l1BlobSource, l2Cl, _ := createPrefetcher(t)
+	kv, err := kvstore.NewLRUKV(1)
+	require.NoError(t, err)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, l1BlobSource, l2Cl, kv)
+	prefetcher.RefetchEvicted()
+
+	l2Cl.ExpectNodeByHash(hashA, nodeA, nil)
+	l2Cl.ExpectNodeByHash(hashB, nodeB, nil)
+	// A is evicted by B, and fetched again with its own hint, rather than the last hint for B.
+	l2Cl.ExpectNodeByHash(hashA, nodeA, nil)
+	defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+	require.NoError(t, prefetcher.Hint(l2.StateNodeHint(hashA).Hint()))
+	pre, err := prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(hashA).PreimageKey())
+	require.NoError(t, err)
+	require.EqualValues(t, nodeA, pre)
+
+	require.NoError(t, prefetcher.Hint(l2.StateNodeHint(hashB).Hint()))
+	pre, err = prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(hashB).PreimageKey())
+	require.NoError(t, err)
+	require.EqualValues(t, nodeB, pre)
+
+	pre, err = prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(hashA).PreimageKey())
+	require.NoError(t, err)
+	require.EqualValues(t, nodeA, pre)
+}
+
 type unreliableKvStore struct {
 	kvstore.KV
 	putsToIgnore int
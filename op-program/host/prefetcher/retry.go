@@ -37,6 +37,16 @@ func (s *RetryingL1Source) InfoByHash(ctx context.Context, blockHash common.Hash
 	})
 }
 
+func (s *RetryingL1Source) InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
+	return retry.Do(ctx, maxAttempts, s.strategy, func() (eth.BlockInfo, error) {
+		res, err := s.source.InfoByNumber(ctx, number)
+		if err != nil {
+			s.logger.Warn("Failed to retrieve info", "number", number, "err", err)
+		}
+		return res, err
+	})
+}
+
 func (s *RetryingL1Source) InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error) {
 	return retry.Do2(ctx, maxAttempts, s.strategy, func() (eth.BlockInfo, types.Transactions, error) {
 		i, t, err := s.source.InfoAndTxsByHash(ctx, blockHash)
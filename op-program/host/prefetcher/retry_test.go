@@ -55,6 +55,28 @@ func TestRetryingL1Source(t *testing.T) {
 		require.Equal(t, info, result)
 	})
 
+	t.Run("InfoByNumber Success", func(t *testing.T) {
+		source, mock := createL1Source(t)
+		defer mock.AssertExpectations(t)
+		mock.ExpectInfoByNumber(info.NumberU64(), info, nil)
+
+		result, err := source.InfoByNumber(ctx, info.NumberU64())
+		require.NoError(t, err)
+		require.Equal(t, info, result)
+	})
+
+	t.Run("InfoByNumber Error", func(t *testing.T) {
+		source, mock := createL1Source(t)
+		defer mock.AssertExpectations(t)
+		expectedErr := errors.New("boom")
+		mock.ExpectInfoByNumber(info.NumberU64(), wrongInfo, expectedErr)
+		mock.ExpectInfoByNumber(info.NumberU64(), info, nil)
+
+		result, err := source.InfoByNumber(ctx, info.NumberU64())
+		require.NoError(t, err)
+		require.Equal(t, info, result)
+	})
+
 	t.Run("InfoAndTxsByHash Success", func(t *testing.T) {
 		source, mock := createL1Source(t)
 		defer mock.AssertExpectations(t)
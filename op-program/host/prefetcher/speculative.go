@@ -0,0 +1,188 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SpeculativeConfig describes the data that the client program is likely to request.
+type SpeculativeConfig struct {
+	Rollup *rollup.Config
+	// L1Head is the L1 block that derivation stops at
+	L1Head common.Hash
+	// L2Head is the agreed L2 block that derivation starts from
+	L2Head common.Hash
+	// L2OutputRoot is the agreed output root of L2Head
+	L2OutputRoot common.Hash
+	// Workers is the number of pre-image fetches to run concurrently
+	Workers int
+}
+
+// Speculate warms the KV store with the pre-images that the client program is likely to request,
+// using a pool of workers to fetch them concurrently, so the program is not bound by the latency of every request.
+//
+// The agreed L2 output and L2 head block are fetched, and the header, transactions and receipts of the L1 blocks
+// that derivation reads are fetched in order: from the L1 origin of the L2 head minus the channel timeout,
+// where derivation restarts from, up to the L1 origin plus the sequencing window, or the L1 head if it is lower.
+// The L1 blocks are fetched by number, so the L1 head must be canonical on the L1 source.
+// L2 blocks after the L2 head are not fetched, as these are derived by the client program.
+// Pre-images that are already in the KV store are not fetched again, except for the L1 headers,
+// which are fetched by number to find the hashes of the L1 blocks.
+//
+// Speculate blocks until all pre-images are fetched, or the context is done.
+// Failures to fetch pre-images are not fatal, as the pre-images are fetched again when requested.
+func (p *Prefetcher) Speculate(ctx context.Context, cfg SpeculativeConfig) error {
+	if cfg.Workers <= 0 {
+		return fmt.Errorf("invalid number of workers: %d", cfg.Workers)
+	}
+	jobs := make(chan func(), cfg.Workers)
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				job()
+			}
+		}()
+	}
+	err := p.speculate(ctx, cfg, jobs)
+	close(jobs)
+	wg.Wait()
+	if err != nil && !errors.Is(err, ctx.Err()) {
+		p.logger.Warn("Stopped speculative prefetching", "err", err)
+	}
+	return ctx.Err()
+}
+
+func (p *Prefetcher) speculate(ctx context.Context, cfg SpeculativeConfig, jobs chan<- func()) error {
+	send := func(job func()) error {
+		select {
+		case jobs <- job:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if !p.has(preimage.Keccak256Key(cfg.L2OutputRoot).PreimageKey()) {
+		if err := send(func() { p.speculativePrefetch(ctx, l2.L2OutputHint(cfg.L2OutputRoot).Hint()) }); err != nil {
+			return err
+		}
+	}
+
+	info, txs, err := p.l2Fetcher.InfoAndTxsByHash(ctx, cfg.L2Head)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L2 head %s: %w", cfg.L2Head, err)
+	}
+	if err := storeL2Block(p.hintedKV(l2.BlockHeaderHint(cfg.L2Head).Hint()), cfg.L2Head, info, txs); err != nil {
+		return err
+	}
+	if len(txs) == 0 || txs[0].Type() != types.DepositTxType {
+		return fmt.Errorf("L2 head %s does not start with the L1 info deposit", cfg.L2Head)
+	}
+	l1Origin, err := derive.L1BlockInfoFromBytes(cfg.Rollup, info.Time(), txs[0].Data())
+	if err != nil {
+		return fmt.Errorf("failed to parse L1 info deposit of L2 head %s: %w", cfg.L2Head, err)
+	}
+
+	// Find the range of L1 blocks first, to fetch them in the order that derivation reads them.
+	var start uint64
+	if l1Origin.Number > cfg.Rollup.ChannelTimeout {
+		start = l1Origin.Number - cfg.Rollup.ChannelTimeout
+	}
+	l1Head, err := p.l1Header(ctx, cfg.L1Head)
+	if err != nil {
+		return err
+	}
+	end := min(l1Origin.Number+cfg.Rollup.SeqWindowSize, l1Head.Number.Uint64())
+	canonical, err := p.l1Fetcher.InfoByNumber(ctx, l1Head.Number.Uint64())
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 block %d: %w", l1Head.Number, err)
+	}
+	if canonical.Hash() != cfg.L1Head {
+		return fmt.Errorf("L1 head %s is not canonical on the L1 source, found %s", cfg.L1Head, canonical.Hash())
+	}
+	p.logger.Info("Speculatively prefetching L1 data", "l1_head", cfg.L1Head, "l1_origin", l1Origin.Number, "start", start, "end", end)
+
+	for number := start; number <= end; number++ {
+		number := number
+		if err := send(func() { p.speculateL1Block(ctx, number) }); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// speculateL1Block fetches the header, transactions and receipts of the L1 block with the given number.
+func (p *Prefetcher) speculateL1Block(ctx context.Context, number uint64) {
+	info, err := p.l1Fetcher.InfoByNumber(ctx, number)
+	if err != nil {
+		p.logger.Debug("Speculative prefetch of L1 header failed", "number", number, "err", err)
+		return
+	}
+	hash := info.Hash()
+	data, err := info.HeaderRLP()
+	if err != nil {
+		p.logger.Debug("Failed to encode L1 header", "number", number, "err", err)
+		return
+	}
+	var header types.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		p.logger.Debug("Invalid L1 header", "number", number, "err", err)
+		return
+	}
+	if key := preimage.Keccak256Key(hash).PreimageKey(); !p.has(key) {
+		if err := p.hintedKV(l1.BlockHeaderHint(hash).Hint()).Put(key, data); err != nil {
+			p.logger.Debug("Failed to store L1 header", "number", number, "err", err)
+			return
+		}
+	}
+	if !p.has(preimage.Keccak256Key(header.TxHash).PreimageKey()) {
+		p.speculativePrefetch(ctx, l1.TransactionsHint(hash).Hint())
+	}
+	if !p.has(preimage.Keccak256Key(header.ReceiptHash).PreimageKey()) {
+		p.speculativePrefetch(ctx, l1.ReceiptsHint(hash).Hint())
+	}
+}
+
+func (p *Prefetcher) speculativePrefetch(ctx context.Context, hint string) {
+	if err := p.prefetch(ctx, hint); err != nil {
+		p.logger.Debug("Speculative prefetch failed", "hint", hint, "err", err)
+	}
+}
+
+// l1Header returns the L1 header with the given hash from the KV store, or fetches and stores it if not available.
+func (p *Prefetcher) l1Header(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	key := preimage.Keccak256Key(hash).PreimageKey()
+	data, err := p.kvStore.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		data, err = p.fetchKey(ctx, l1.BlockHeaderHint(hash).Hint(), key)
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	var header types.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid L1 block %s header: %w", hash, err)
+	}
+	return &header, nil
+}
+
+func (p *Prefetcher) has(key common.Hash) bool {
+	_, err := p.kvStore.Get(key)
+	return err == nil
+}
@@ -0,0 +1,224 @@
+package prefetcher
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestSpeculate(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	rollupCfg := &rollup.Config{ChannelTimeout: 5, SeqWindowSize: 4}
+
+	// L1 chain of blocks 0 to 19, with the L1 origin of the L2 head at block 10
+	l1Source := &fakeL1Source{blocks: make(map[common.Hash]*types.Block), receipts: make(map[common.Hash]types.Receipts), calls: make(map[string]int)}
+	var l1Blocks []*types.Block
+	parent := common.Hash{}
+	for i := 0; i < 20; i++ {
+		block, rcpts := testutils.RandomBlock(rng, 2)
+		header := block.Header()
+		header.Number = big.NewInt(int64(i))
+		header.ParentHash = parent
+		block = block.WithSeal(header)
+		l1Source.blocks[block.Hash()] = block
+		l1Source.byNumber = append(l1Source.byNumber, block)
+		l1Source.receipts[block.Hash()] = rcpts
+		l1Blocks = append(l1Blocks, block)
+		parent = block.Hash()
+	}
+	l1Head := l1Blocks[19]
+	l1Origin := l1Blocks[10]
+
+	l2Time := uint64(1000)
+	deposit, err := derive.L1InfoDeposit(rollupCfg, eth.SystemConfig{}, 0, eth.HeaderBlockInfo(l1Origin.Header()), l2Time)
+	require.NoError(t, err)
+	l2Block, _ := testutils.RandomBlockPrependTxs(rng, 2, types.NewTx(deposit))
+	l2Header := l2Block.Header()
+	l2Header.Time = l2Time
+	l2Block = l2Block.WithSeal(l2Header)
+	output := &eth.OutputV0{BlockHash: l2Block.Hash()}
+	l2Source := &fakeL2Source{block: l2Block, output: output}
+
+	kv := kvstore.NewMemKV()
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), l1Source, nil, l2Source, kv)
+	cfg := SpeculativeConfig{
+		Rollup:       rollupCfg,
+		L1Head:       l1Head.Hash(),
+		L2Head:       l2Block.Hash(),
+		L2OutputRoot: common.Hash(eth.OutputRoot(output)),
+		Workers:      4,
+	}
+	require.NoError(t, prefetcher.Speculate(context.Background(), cfg))
+
+	// The client must be able to read all the data from the L1 origin minus the channel timeout,
+	// up to the L1 origin plus the sequencing window
+	oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	for i := 5; i <= 14; i++ {
+		block := l1Blocks[i]
+		header, txs := oracle.TransactionsByBlockHash(block.Hash())
+		require.Equal(t, block.Hash(), header.Hash())
+		assertTransactionsEqual(t, block.Transactions(), txs)
+		_, rcpts := oracle.ReceiptsByBlockHash(block.Hash())
+		expectedRcpts, err := eth.EncodeReceipts(l1Source.receipts[block.Hash()])
+		require.NoError(t, err)
+		actualRcpts, err := eth.EncodeReceipts(rcpts)
+		require.NoError(t, err)
+		require.Equal(t, expectedRcpts, actualRcpts)
+	}
+	for i := 0; i < 5; i++ {
+		_, err := kv.Get(preimage.Keccak256Key(l1Blocks[i].Hash()).PreimageKey())
+		require.ErrorIs(t, err, kvstore.ErrNotFound, "must not fetch L1 blocks before the start block")
+	}
+	for i := 15; i < 19; i++ {
+		_, err := kv.Get(preimage.Keccak256Key(l1Blocks[i].Hash()).PreimageKey())
+		require.ErrorIs(t, err, kvstore.ErrNotFound, "must not fetch L1 blocks after the end block")
+	}
+	require.Equal(t, 1, l1Source.callCount("InfoByHash"), "only the L1 head is fetched by hash")
+	require.Equal(t, 11, l1Source.callCount("InfoByNumber"), "L1 blocks and the L1 head are fetched by number")
+	require.Equal(t, 10, l1Source.callCount("InfoAndTxsByHash"))
+	require.Equal(t, 10, l1Source.callCount("FetchReceipts"))
+	require.ElementsMatch(t, []uint64{19, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, l1Source.fetchedNumbers)
+
+	l2Oracle := l2.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	require.Equal(t, output, l2Oracle.OutputByRoot(cfg.L2OutputRoot))
+	require.Equal(t, l2Block.Hash(), l2Oracle.BlockByHash(l2Block.Hash()).Hash())
+
+	t.Run("SkipKnown", func(t *testing.T) {
+		require.NoError(t, prefetcher.Speculate(context.Background(), cfg))
+		require.Equal(t, 1, l1Source.callCount("InfoByHash"), "must not fetch known headers")
+		require.Equal(t, 10, l1Source.callCount("InfoAndTxsByHash"), "must not fetch known transactions")
+		require.Equal(t, 10, l1Source.callCount("FetchReceipts"), "must not fetch known receipts")
+		require.Equal(t, 1, l2Source.outputCalls, "must not fetch known output")
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlDebug), l1Source, nil, l2Source, kvstore.NewMemKV())
+		require.ErrorIs(t, prefetcher.Speculate(ctx, cfg), context.Canceled)
+	})
+
+	t.Run("InOrder", func(t *testing.T) {
+		cfg := cfg
+		cfg.Workers = 1
+		l1Source.mu.Lock()
+		l1Source.fetchedNumbers = nil
+		l1Source.mu.Unlock()
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlCrit), l1Source, nil, l2Source, kvstore.NewMemKV())
+		require.NoError(t, prefetcher.Speculate(context.Background(), cfg))
+		require.Equal(t, []uint64{19, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, l1Source.fetchedNumbers,
+			"L1 blocks are fetched in the order that derivation reads them")
+	})
+
+	t.Run("NonCanonicalL1Head", func(t *testing.T) {
+		l1Source.mu.Lock()
+		l1Source.byNumber = l1Source.byNumber[:19]
+		l1Source.byNumber = append(l1Source.byNumber, l1Blocks[18])
+		l1Source.mu.Unlock()
+		calls := l1Source.callCount("InfoAndTxsByHash")
+		prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlCrit), l1Source, nil, l2Source, kvstore.NewMemKV())
+		require.NoError(t, prefetcher.Speculate(context.Background(), cfg))
+		require.Equal(t, calls, l1Source.callCount("InfoAndTxsByHash"), "must not fetch L1 blocks by number")
+	})
+
+	t.Run("InvalidWorkers", func(t *testing.T) {
+		cfg := cfg
+		cfg.Workers = 0
+		require.ErrorContains(t, prefetcher.Speculate(context.Background(), cfg), "invalid number of workers")
+	})
+}
+
+type fakeL1Source struct {
+	blocks   map[common.Hash]*types.Block
+	byNumber []*types.Block
+	receipts map[common.Hash]types.Receipts
+
+	mu             sync.Mutex
+	calls          map[string]int
+	fetchedNumbers []uint64
+}
+
+func (s *fakeL1Source) call(method string, hash common.Hash) *types.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[method]++
+	block, ok := s.blocks[hash]
+	if !ok {
+		panic("unknown L1 block " + hash.String())
+	}
+	return block
+}
+
+func (s *fakeL1Source) callCount(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+func (s *fakeL1Source) InfoByHash(_ context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	return eth.HeaderBlockInfo(s.call("InfoByHash", hash).Header()), nil
+}
+
+func (s *fakeL1Source) InfoByNumber(_ context.Context, number uint64) (eth.BlockInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls["InfoByNumber"]++
+	s.fetchedNumbers = append(s.fetchedNumbers, number)
+	return eth.HeaderBlockInfo(s.byNumber[number].Header()), nil
+}
+
+func (s *fakeL1Source) InfoAndTxsByHash(_ context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	block := s.call("InfoAndTxsByHash", hash)
+	return eth.HeaderBlockInfo(block.Header()), block.Transactions(), nil
+}
+
+func (s *fakeL1Source) FetchReceipts(_ context.Context, hash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	block := s.call("FetchReceipts", hash)
+	return eth.HeaderBlockInfo(block.Header()), s.receipts[hash], nil
+}
+
+type fakeL2Source struct {
+	block       *types.Block
+	output      eth.Output
+	outputCalls int
+}
+
+func (s *fakeL2Source) InfoAndTxsByHash(_ context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	if hash != s.block.Hash() {
+		panic("unknown L2 block " + hash.String())
+	}
+	return eth.HeaderBlockInfo(s.block.Header()), s.block.Transactions(), nil
+}
+
+func (s *fakeL2Source) OutputByRoot(_ context.Context, root common.Hash) (eth.Output, error) {
+	s.outputCalls++
+	if root != common.Hash(eth.OutputRoot(s.output)) {
+		panic("unknown L2 output " + root.String())
+	}
+	return s.output, nil
+}
+
+func (s *fakeL2Source) NodeByHash(_ context.Context, _ common.Hash) ([]byte, error) {
+	panic("unexpected call")
+}
+
+func (s *fakeL2Source) CodeByHash(_ context.Context, _ common.Hash) ([]byte, error) {
+	panic("unexpected call")
+}
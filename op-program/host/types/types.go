@@ -0,0 +1,13 @@
+package types
+
+// DataFormat is the storage format of the pre-images in the data directory.
+type DataFormat string
+
+const (
+	// DataFormatFile stores every pre-image as a file in the data directory.
+	DataFormatFile DataFormat = "file"
+	// DataFormatPebble stores the pre-images in a pebble database in the data directory.
+	DataFormatPebble DataFormat = "pebble"
+)
+
+var SupportedDataFormats = []DataFormat{DataFormatFile, DataFormatPebble}
@@ -57,3 +57,29 @@ func CreateBidirectionalChannel() (FileChannel, FileChannel, error) {
 	}
 	return NewReadWritePair(ar, aw), NewReadWritePair(br, bw), nil
 }
+
+type memChannel struct {
+	r *io.PipeReader
+	w *io.PipeWriter
+}
+
+func (c *memChannel) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+func (c *memChannel) Write(p []byte) (int, error) {
+	return c.w.Write(p)
+}
+
+func (c *memChannel) Close() error {
+	return errors.Join(c.r.Close(), c.w.Close())
+}
+
+// CreateMemoryChannel creates a pair of in-memory channels that are connected to each other.
+// Unlike CreateBidirectionalChannel, no OS resources are used, but the channels can only be used within the same process.
+// Writes block until the data is read by the other side.
+func CreateMemoryChannel() (io.ReadWriteCloser, io.ReadWriteCloser) {
+	ar, bw := io.Pipe()
+	br, aw := io.Pipe()
+	return &memChannel{r: ar, w: aw}, &memChannel{r: br, w: bw}
+}
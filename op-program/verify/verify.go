@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/host"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -122,6 +123,7 @@ func Run(l1RpcUrl string, l1RpcKind string, l2RpcUrl string, l2OracleAddr common
 	offlineCfg := config.Config{
 		Rollup:             rollupCfg,
 		DataDir:            dataDir,
+		DataFormat:         types.DataFormatFile,
 		L2ChainConfig:      chainCfg,
 		L2Head:             l2Head,
 		L2OutputRoot:       agreedOutput.OutputRoot,
@@ -52,22 +52,46 @@ var (
 	}
 	DisputeGameFactoryAddressFlag = &cli.StringFlag{
 		Name:    "dgf-address",
-		Usage:   "Address of the DisputeGameFactory contract",
+		Usage:   "Address of the DisputeGameFactory contract. If set instead of the L2OutputOracle address, outputs are proposed by creating dispute games.",
 		EnvVars: prefixEnvVars("DGF_ADDRESS"),
-		Hidden:  true,
 	}
 	ProposalIntervalFlag = &cli.DurationFlag{
 		Name:    "proposal-interval",
 		Usage:   "Interval between submitting L2 output proposals when the DGFAddress is set",
 		EnvVars: prefixEnvVars("PROPOSAL_INTERVAL"),
-		Hidden:  true,
 	}
 	DisputeGameTypeFlag = &cli.UintFlag{
 		Name:    "dg-type",
 		Usage:   "Dispute game type to create via the configured DisputeGameFactory",
 		Value:   0,
 		EnvVars: prefixEnvVars("DG_TYPE"),
-		Hidden:  true,
+	}
+	ProposalMaxIntervalFlag = &cli.DurationFlag{
+		Name: "proposal-max-interval",
+		Usage: "Maximum interval between L2 output proposals when the DGFAddress is set. If set, proposals at the " +
+			"proposal-interval are deferred while the proposal targets are not met, up to this interval.",
+		EnvVars: prefixEnvVars("PROPOSAL_MAX_INTERVAL"),
+	}
+	ProposalMinL2BlocksFlag = &cli.Uint64Flag{
+		Name:    "proposal-min-l2-blocks",
+		Usage:   "Defer proposals while fewer L2 blocks were produced since the last proposal. Requires proposal-max-interval.",
+		EnvVars: prefixEnvVars("PROPOSAL_MIN_L2_BLOCKS"),
+	}
+	ProposalMaxGasPriceFlag = &cli.Float64Flag{
+		Name:    "proposal-max-gas-price",
+		Usage:   "Defer proposals while the L1 base fee, in GWei, exceeds this target. Requires proposal-max-interval.",
+		EnvVars: prefixEnvVars("PROPOSAL_MAX_GAS_PRICE"),
+	}
+	ProposalMaxBondFlag = &cli.Float64Flag{
+		Name:    "proposal-max-bond",
+		Usage:   "Defer proposals while the dispute game bond, in GWei, exceeds this target. Requires proposal-max-interval.",
+		EnvVars: prefixEnvVars("PROPOSAL_MAX_BOND"),
+	}
+	VerifierRollupRpcFlag = &cli.StringFlag{
+		Name: "verifier-rollup-rpc",
+		Usage: "HTTP provider URL of a second, independently synced rollup node. If set, output roots are only " +
+			"proposed if they match the output root of this node at the same L2 block.",
+		EnvVars: prefixEnvVars("VERIFIER_ROLLUP_RPC"),
 	}
 	ActiveSequencerCheckDurationFlag = &cli.DurationFlag{
 		Name:    "active-sequencer-check-duration",
@@ -92,7 +116,12 @@ var optionalFlags = []cli.Flag{
 	DisputeGameFactoryAddressFlag,
 	ProposalIntervalFlag,
 	DisputeGameTypeFlag,
+	ProposalMaxIntervalFlag,
+	ProposalMinL2BlocksFlag,
+	ProposalMaxGasPriceFlag,
+	ProposalMaxBondFlag,
 	ActiveSequencerCheckDurationFlag,
+	VerifierRollupRpcFlag,
 }
 
 func init() {
@@ -34,6 +34,9 @@ type Metricer interface {
 	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+	RecordOutputVerification(l2ref eth.L2BlockRef, match bool)
+	RecordProposalsPending(count int)
+	RecordProposalReorged(l2ref eth.L2BlockRef)
 }
 
 type Metrics struct {
@@ -47,6 +50,14 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	outputRootMismatch         prometheus.Gauge
+	outputRootMismatchesTotal  prometheus.Counter
+	outputRootMismatchL2Number prometheus.Gauge
+
+	proposalsPending      prometheus.Gauge
+	proposalsReorgedTotal prometheus.Counter
+	proposalReorgL2Number prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -81,6 +92,36 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		outputRootMismatch: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "output_root_mismatch",
+			Help:      "1 if the last output root to propose did not match the output root of the verifier rollup node, 0 otherwise",
+		}),
+		outputRootMismatchesTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_root_mismatches_total",
+			Help:      "Number of output roots that were not proposed, because they did not match the verifier rollup node",
+		}),
+		outputRootMismatchL2Number: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "output_root_mismatch_l2_block_number",
+			Help:      "L2 block number of the last output root that did not match the verifier rollup node",
+		}),
+		proposalsPending: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposals_pending_finalization",
+			Help:      "Number of proposals of which the L1 inclusion block is not finalized yet, including reorged proposals to propose again",
+		}),
+		proposalsReorgedTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proposals_reorged_total",
+			Help:      "Number of proposals that were reorged out of L1 before finalization",
+		}),
+		proposalReorgL2Number: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposal_reorg_l2_block_number",
+			Help:      "L2 block number of the last proposal that was reorged out of L1",
+		}),
 	}
 }
 
@@ -113,6 +154,29 @@ func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordOutputVerification records whether the output root at the given L2 block
+// matched the output root of the verifier rollup node.
+func (m *Metrics) RecordOutputVerification(l2ref eth.L2BlockRef, match bool) {
+	if match {
+		m.outputRootMismatch.Set(0)
+		return
+	}
+	m.outputRootMismatch.Set(1)
+	m.outputRootMismatchesTotal.Inc()
+	m.outputRootMismatchL2Number.Set(float64(l2ref.Number))
+}
+
+// RecordProposalsPending records the number of proposals pending L1 finalization.
+func (m *Metrics) RecordProposalsPending(count int) {
+	m.proposalsPending.Set(float64(count))
+}
+
+// RecordProposalReorged records that the proposal of the L2 block was reorged out of L1.
+func (m *Metrics) RecordProposalReorged(l2ref eth.L2BlockRef) {
+	m.proposalsReorgedTotal.Inc()
+	m.proposalReorgL2Number.Set(float64(l2ref.Number))
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -23,7 +23,10 @@ var NoopMetrics Metricer = new(noopMetrics)
 func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
-func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
+func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef)           {}
+func (*noopMetrics) RecordOutputVerification(l2ref eth.L2BlockRef, _ bool) {}
+func (*noopMetrics) RecordProposalsPending(count int)                      {}
+func (*noopMetrics) RecordProposalReorged(l2ref eth.L2BlockRef)            {}
 
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
@@ -5,8 +5,11 @@ import (
 	"math/big"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
@@ -14,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
@@ -117,3 +121,25 @@ func TestManualABIPacking(t *testing.T) {
 
 	require.Equal(t, txData, dgfTx.Data())
 }
+
+func TestNewDGFSubmitterRequiresGameImpl(t *testing.T) {
+	_, opts, backend, _, err := setupDisputeGameFactory()
+	require.NoError(t, err)
+	addr, _, _, err := bindings.DeployDisputeGameFactory(opts, backend)
+	require.NoError(t, err)
+	backend.Commit()
+
+	_, err = NewL2OutputSubmitter(DriverSetup{
+		Log:      testlog.Logger(t, log.LvlCrit),
+		Metr:     metrics.NoopMetrics,
+		Cfg:      ProposerConfig{NetworkTimeout: time.Second, DisputeGameFactoryAddr: &addr, DisputeGameType: 1},
+		L1Client: backend,
+	})
+	require.ErrorContains(t, err, "dispute game type 1 has no implementation")
+}
+
+func TestCheckBondFunding(t *testing.T) {
+	require.NoError(t, checkBondFunding(big.NewInt(100), big.NewInt(100)))
+	require.NoError(t, checkBondFunding(big.NewInt(100), big.NewInt(0)))
+	require.ErrorIs(t, checkBondFunding(big.NewInt(99), big.NewInt(100)), ErrInsufficientBondFunds)
+}
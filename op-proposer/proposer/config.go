@@ -2,11 +2,13 @@ package proposer
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -58,6 +60,23 @@ type CLIConfig struct {
 
 	// ActiveSequencerCheckDuration is the duration between checks to determine the active sequencer endpoint.
 	ActiveSequencerCheckDuration time.Duration
+
+	// ProposalMaxInterval is the maximum time the proposal policy may defer proposals for.
+	// The proposal policy is disabled if zero.
+	ProposalMaxInterval time.Duration
+
+	// ProposalMinL2Blocks is the minimum number of L2 blocks since the last proposal to propose a new output.
+	ProposalMinL2Blocks uint64
+
+	// ProposalMaxGasPriceGwei is the L1 base fee above which proposals are deferred. Disabled if zero.
+	ProposalMaxGasPriceGwei float64
+
+	// ProposalMaxBondGwei is the dispute game bond above which proposals are deferred. Disabled if zero.
+	ProposalMaxBondGwei float64
+
+	// VerifierRollupRpc is the HTTP provider URL of a second rollup node, to cross-check output roots with
+	// before proposing them. Output roots are not cross-checked if empty.
+	VerifierRollupRpc string
 }
 
 func (c *CLIConfig) Check() error {
@@ -83,10 +102,46 @@ func (c *CLIConfig) Check() error {
 	if c.ProposalInterval != 0 && c.DGFAddress == "" {
 		return errors.New("the `ProposalInterval` was provided but the `DisputeGameFactory` address was not set")
 	}
+	if c.ProposalMaxInterval != 0 {
+		if c.DGFAddress == "" {
+			return errors.New("the `ProposalMaxInterval` was provided but the `DisputeGameFactory` address was not set")
+		}
+		if c.ProposalMaxInterval < c.ProposalInterval {
+			return fmt.Errorf("the `ProposalMaxInterval` (%v) must not be less than the `ProposalInterval` (%v)", c.ProposalMaxInterval, c.ProposalInterval)
+		}
+	} else if c.ProposalMinL2Blocks != 0 || c.ProposalMaxGasPriceGwei != 0 || c.ProposalMaxBondGwei != 0 {
+		return errors.New("proposal policy targets were provided but the `ProposalMaxInterval` was not set")
+	}
+	if _, err := c.ProposalPolicy(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// ProposalPolicy returns the ProposalPolicy of the configured proposal targets.
+func (c *CLIConfig) ProposalPolicy() (ProposalPolicy, error) {
+	policy := ProposalPolicy{
+		MaxInterval: c.ProposalMaxInterval,
+		MinL2Blocks: c.ProposalMinL2Blocks,
+	}
+	if c.ProposalMaxGasPriceGwei != 0 {
+		maxGasPrice, err := eth.GweiToWei(c.ProposalMaxGasPriceGwei)
+		if err != nil {
+			return ProposalPolicy{}, fmt.Errorf("invalid proposal max gas price: %w", err)
+		}
+		policy.MaxGasPrice = maxGasPrice
+	}
+	if c.ProposalMaxBondGwei != 0 {
+		maxBond, err := eth.GweiToWei(c.ProposalMaxBondGwei)
+		if err != nil {
+			return ProposalPolicy{}, fmt.Errorf("invalid proposal max bond: %w", err)
+		}
+		policy.MaxBond = maxBond
+	}
+	return policy, nil
+}
+
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) *CLIConfig {
 	return &CLIConfig{
@@ -106,5 +161,10 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		ProposalInterval:             ctx.Duration(flags.ProposalIntervalFlag.Name),
 		DisputeGameType:              uint32(ctx.Uint(flags.DisputeGameTypeFlag.Name)),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
+		ProposalMaxInterval:          ctx.Duration(flags.ProposalMaxIntervalFlag.Name),
+		ProposalMinL2Blocks:          ctx.Uint64(flags.ProposalMinL2BlocksFlag.Name),
+		ProposalMaxGasPriceGwei:      ctx.Float64(flags.ProposalMaxGasPriceFlag.Name),
+		ProposalMaxBondGwei:          ctx.Float64(flags.ProposalMaxBondFlag.Name),
+		VerifierRollupRpc:            ctx.String(flags.VerifierRollupRpcFlag.Name),
 	}
 }
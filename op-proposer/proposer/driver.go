@@ -27,6 +27,9 @@ import (
 var (
 	supportedL2OutputVersion = eth.Bytes32{}
 	ErrProposerNotRunning    = errors.New("proposer is not running")
+	ErrInsufficientBondFunds = errors.New("insufficient funds for dispute game bond")
+	ErrGameExists            = errors.New("dispute game already exists")
+	ErrOutputRootMismatch    = errors.New("output root does not match verifier rollup node")
 )
 
 type L1Client interface {
@@ -38,6 +41,10 @@ type L1Client interface {
 	// CallContract executes an Ethereum contract call with the specified data as the
 	// input.
 	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+
+	// BalanceAt returns the balance of the given account. This is needed to check
+	// that the proposer can fund the bonds of the dispute games it creates.
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 }
 
 type RollupClient interface {
@@ -54,6 +61,10 @@ type DriverSetup struct {
 
 	// RollupProvider's RollupClient() is used to retrieve output roots from
 	RollupProvider dial.RollupProvider
+
+	// VerifierRollupProvider's RollupClient() is used to cross-check output roots before proposing them.
+	// It should be an independently synced rollup node. Output roots are not cross-checked if nil.
+	VerifierRollupProvider dial.RollupProvider
 }
 
 // L2OutputSubmitter is responsible for proposing outputs
@@ -74,6 +85,13 @@ type L2OutputSubmitter struct {
 
 	dgfContract *bindings.DisputeGameFactoryCaller
 	dgfABI      *abi.ABI
+
+	// lastProposalTime and lastProposalBlock track the last proposal to evaluate the ProposalPolicy.
+	lastProposalTime  time.Time
+	lastProposalBlock uint64
+
+	// pendingProposals are the proposals of which the L1 inclusion block is not finalized yet.
+	pendingProposals []pendingProposal
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
@@ -144,7 +162,17 @@ func newDGFSubmitter(ctx context.Context, cancel context.CancelFunc, setup Drive
 		cancel()
 		return nil, err
 	}
-	log.Info("Connected to DisputeGameFactory", "address", setup.Cfg.DisputeGameFactoryAddr, "version", version)
+	impl, err := dgfCaller.GameImpls(&bind.CallOpts{Context: cCtx}, setup.Cfg.DisputeGameType)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to fetch implementation of dispute game type %d: %w", setup.Cfg.DisputeGameType, err)
+	}
+	if impl == (common.Address{}) {
+		cancel()
+		return nil, fmt.Errorf("dispute game type %d has no implementation in the DisputeGameFactory", setup.Cfg.DisputeGameType)
+	}
+	log.Info("Connected to DisputeGameFactory", "address", setup.Cfg.DisputeGameFactoryAddr, "version", version,
+		"game_type", setup.Cfg.DisputeGameType, "game_impl", impl)
 
 	parsed, err := bindings.DisputeGameFactoryMetaData.GetAbi()
 	if err != nil {
@@ -299,9 +327,40 @@ func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*e
 			"allow_non_finalized", l.Cfg.AllowNonFinalized)
 		return nil, false, nil
 	}
+	if err := l.verifyOutput(ctx, output); err != nil {
+		return nil, false, err
+	}
 	return output, true, nil
 }
 
+// verifyOutput cross-checks the output with the output of the verifier rollup node at the same L2 block,
+// to not propose output roots of a corrupted node. If the verifier's output can't be fetched, the output
+// is not verified and an error is returned, so the proposal is retried later.
+func (l *L2OutputSubmitter) verifyOutput(ctx context.Context, output *eth.OutputResponse) error {
+	if l.VerifierRollupProvider == nil {
+		return nil
+	}
+	rollupClient, err := l.VerifierRollupProvider.RollupClient(ctx)
+	if err != nil {
+		l.Log.Error("proposer unable to get verifier rollup client", "err", err)
+		return err
+	}
+	verifierOutput, err := rollupClient.OutputAtBlock(ctx, output.BlockRef.Number)
+	if err != nil {
+		l.Log.Error("failed to fetch output at block from verifier", "block", output.BlockRef.Number, "err", err)
+		return err
+	}
+	if verifierOutput.OutputRoot != output.OutputRoot || verifierOutput.BlockRef.Hash != output.BlockRef.Hash {
+		l.Log.Error("Output root does not match verifier rollup node, refusing to propose",
+			"l2block", output.BlockRef, "output_root", output.OutputRoot,
+			"verifier_l2block", verifierOutput.BlockRef, "verifier_output_root", verifierOutput.OutputRoot)
+		l.Metr.RecordOutputVerification(output.BlockRef, false)
+		return ErrOutputRootMismatch
+	}
+	l.Metr.RecordOutputVerification(output.BlockRef, true)
+	return nil
+}
+
 // ProposeL2OutputTxData creates the transaction data for the ProposeL2Output function
 func (l *L2OutputSubmitter) ProposeL2OutputTxData(output *eth.OutputResponse) ([]byte, error) {
 	return proposeL2OutputTxData(l.l2ooABI, output)
@@ -329,9 +388,50 @@ func (l *L2OutputSubmitter) ProposeL2OutputDGFTxData(output *eth.OutputResponse)
 	return data, bond, err
 }
 
+// checkDisputeGame checks that the dispute game of the output does not exist yet, and that
+// the proposer's balance covers the bond of the game. It returns the bond.
+func (l *L2OutputSubmitter) checkDisputeGame(ctx context.Context, output *eth.OutputResponse) (*big.Int, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	callOpts := &bind.CallOpts{Context: cCtx}
+	game, err := l.dgfContract.Games(callOpts, l.Cfg.DisputeGameType, output.OutputRoot, disputeGameExtraData(output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch dispute game: %w", err)
+	}
+	if game.Proxy != (common.Address{}) {
+		return nil, fmt.Errorf("%w at %s", ErrGameExists, game.Proxy)
+	}
+	bond, err := l.dgfContract.InitBonds(callOpts, l.Cfg.DisputeGameType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch dispute game bond: %w", err)
+	}
+	balance, err := l.L1Client.BalanceAt(cCtx, l.Txmgr.From(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proposer balance: %w", err)
+	}
+	if err := checkBondFunding(balance, bond); err != nil {
+		return nil, err
+	}
+	return bond, nil
+}
+
+// checkBondFunding returns ErrInsufficientBondFunds if the balance doesn't cover the bond.
+// The balance must also cover the gas of the proposal, which isn't known yet, so this is a lower bound.
+func checkBondFunding(balance, bond *big.Int) error {
+	if balance.Cmp(bond) < 0 {
+		return fmt.Errorf("%w: balance %v, bond %v", ErrInsufficientBondFunds, balance, bond)
+	}
+	return nil
+}
+
 // proposeL2OutputDGFTxData creates the transaction data for the DisputeGameFactory's `create` function
 func proposeL2OutputDGFTxData(abi *abi.ABI, gameType uint32, output *eth.OutputResponse) ([]byte, error) {
-	return abi.Pack("create", gameType, output.OutputRoot, math.U256Bytes(new(big.Int).SetUint64(output.BlockRef.Number)))
+	return abi.Pack("create", gameType, output.OutputRoot, disputeGameExtraData(output))
+}
+
+// disputeGameExtraData returns the extra data of the output's dispute game, which is the L2 block number.
+func disputeGameExtraData(output *eth.OutputResponse) []byte {
+	return math.U256Bytes(new(big.Int).SetUint64(output.BlockRef.Number))
 }
 
 // We wait until l1head advances beyond blocknum. This is used to make sure proposal tx won't
@@ -363,17 +463,22 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 }
 
 // sendTransaction creates & sends transactions through the underlying transaction manager.
-func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) error {
+// It returns the receipt of the transaction, which may have reverted.
+func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) (*types.Receipt, error) {
 	err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	var receipt *types.Receipt
 	if l.Cfg.DisputeGameFactoryAddr != nil {
-		data, bond, err := l.ProposeL2OutputDGFTxData(output)
+		bond, err := l.checkDisputeGame(ctx, output)
+		if err != nil {
+			return nil, err
+		}
+		data, err := proposeL2OutputDGFTxData(l.dgfABI, l.Cfg.DisputeGameType, output)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
 			TxData:   data,
@@ -382,12 +487,12 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 			Value:    bond,
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		data, err := l.ProposeL2OutputTxData(output)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
 			TxData:   data,
@@ -395,7 +500,7 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 			GasLimit: 0,
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
@@ -407,7 +512,7 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 			"l1blocknum", output.Status.CurrentL1.Number,
 			"l1blockhash", output.Status.CurrentL1.Hash)
 	}
-	return nil
+	return receipt, nil
 }
 
 // loop is responsible for creating & submitting the next outputs
@@ -428,6 +533,8 @@ func (l *L2OutputSubmitter) loopL2OO(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
+			l.checkPendingProposals(ctx)
+
 			output, shouldPropose, err := l.FetchNextOutputInfo(ctx)
 			if err != nil || !shouldPropose {
 				break
@@ -446,6 +553,8 @@ func (l *L2OutputSubmitter) loopDGF(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
+			l.checkPendingProposals(ctx)
+
 			blockNumber, err := l.FetchCurrentBlockNumber(ctx)
 			if err != nil {
 				break
@@ -456,6 +565,14 @@ func (l *L2OutputSubmitter) loopDGF(ctx context.Context) {
 				break
 			}
 
+			if err := l.checkProposalPolicy(ctx, output); errors.Is(err, ErrProposalDeferred) {
+				l.Log.Info("Deferring proposal", "reason", err, "l2block", output.BlockRef)
+				break
+			} else if err != nil {
+				l.Log.Error("Failed to check proposal policy", "err", err, "l2block", output.BlockRef)
+				break
+			}
+
 			l.proposeOutput(ctx, output)
 		case <-l.done:
 			return
@@ -463,17 +580,71 @@ func (l *L2OutputSubmitter) loopDGF(ctx context.Context) {
 	}
 }
 
-func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.OutputResponse) {
+// checkProposalPolicy checks the output against the configured ProposalPolicy.
+// The first output after startup is always proposed.
+func (l *L2OutputSubmitter) checkProposalPolicy(ctx context.Context, output *eth.OutputResponse) error {
+	policy := l.Cfg.ProposalPolicy
+	if !policy.Enabled() || l.lastProposalTime.IsZero() {
+		return nil
+	}
+	conds := ProposalConditions{
+		SinceLastProposal: time.Since(l.lastProposalTime),
+	}
+	if output.BlockRef.Number > l.lastProposalBlock {
+		conds.L2Blocks = output.BlockRef.Number - l.lastProposalBlock
+	}
+	if conds.SinceLastProposal >= policy.MaxInterval {
+		return nil
+	}
+
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	if policy.MaxGasPrice != nil {
+		head, err := l.L1Client.HeaderByNumber(cCtx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L1 head: %w", err)
+		}
+		conds.GasPrice = head.BaseFee
+		if conds.GasPrice == nil {
+			conds.GasPrice = new(big.Int)
+		}
+	}
+	if policy.MaxBond != nil {
+		bond, err := l.dgfContract.InitBonds(&bind.CallOpts{Context: cCtx}, l.Cfg.DisputeGameType)
+		if err != nil {
+			return fmt.Errorf("failed to fetch dispute game bond: %w", err)
+		}
+		conds.Bond = bond
+	}
+	return policy.Check(conds)
+}
+
+// proposeOutput proposes the output and tracks the proposal until its L1 block is finalized.
+// It returns false if the proposal transaction could not be sent or reverted.
+func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.OutputResponse) bool {
 	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
-	if err := l.sendTransaction(cCtx, output); err != nil {
+	receipt, err := l.sendTransaction(cCtx, output)
+	if errors.Is(err, ErrGameExists) {
+		l.Log.Info("Dispute game of output already exists, not proposing", "err", err, "l2block", output.BlockRef)
+		return true
+	} else if err != nil {
 		l.Log.Error("Failed to send proposal transaction",
 			"err", err,
 			"l1blocknum", output.Status.CurrentL1.Number,
 			"l1blockhash", output.Status.CurrentL1.Hash,
 			"l1head", output.Status.HeadL1.Number)
-		return
+		return false
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return false
+	}
+	l.trackProposal(output, receipt)
+	l.lastProposalTime = time.Now()
+	if output.BlockRef.Number > l.lastProposalBlock { // re-proposals of reorged outputs may be older
+		l.lastProposalBlock = output.BlockRef.Number
 	}
 	l.Metr.RecordL2BlocksProposed(output.BlockRef)
+	return true
 }
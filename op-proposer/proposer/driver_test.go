@@ -0,0 +1,214 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+type mockRollupProvider struct {
+	client *testutils.MockRollupClient
+}
+
+func (p *mockRollupProvider) RollupClient(context.Context) (dial.RollupClientInterface, error) {
+	return p.client, nil
+}
+
+func (p *mockRollupProvider) Close() {}
+
+func setupVerifiedSubmitter(t *testing.T) (*L2OutputSubmitter, *testutils.MockRollupClient, *testutils.MockRollupClient) {
+	rollupClient, verifierClient := new(testutils.MockRollupClient), new(testutils.MockRollupClient)
+	t.Cleanup(func() {
+		rollupClient.AssertExpectations(t)
+		verifierClient.AssertExpectations(t)
+	})
+	l := &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:                    testlog.Logger(t, log.LvlCrit),
+			Metr:                   metrics.NoopMetrics,
+			Cfg:                    ProposerConfig{NetworkTimeout: time.Second},
+			RollupProvider:         &mockRollupProvider{client: rollupClient},
+			VerifierRollupProvider: &mockRollupProvider{client: verifierClient},
+		},
+	}
+	return l, rollupClient, verifierClient
+}
+
+func finalizedOutput(rng *rand.Rand) *eth.OutputResponse {
+	output := testutils.RandomOutputResponse(rng)
+	output.Version = supportedL2OutputVersion
+	output.Status.FinalizedL2 = output.BlockRef
+	return output
+}
+
+func TestFetchOutput_Verifier(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+
+	t.Run("Match", func(t *testing.T) {
+		l, rollupClient, verifierClient := setupVerifiedSubmitter(t)
+		output := finalizedOutput(rng)
+		verifierOutput := *output
+		rollupClient.ExpectOutputAtBlock(output.BlockRef.Number, output, nil)
+		verifierClient.ExpectOutputAtBlock(output.BlockRef.Number, &verifierOutput, nil)
+
+		fetched, shouldPropose, err := l.fetchOutput(context.Background(), new(big.Int).SetUint64(output.BlockRef.Number))
+		require.NoError(t, err)
+		require.True(t, shouldPropose)
+		require.Equal(t, output, fetched)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		l, rollupClient, verifierClient := setupVerifiedSubmitter(t)
+		output := finalizedOutput(rng)
+		verifierOutput := *output
+		verifierOutput.OutputRoot = eth.Bytes32(testutils.RandomHash(rng))
+		rollupClient.ExpectOutputAtBlock(output.BlockRef.Number, output, nil)
+		verifierClient.ExpectOutputAtBlock(output.BlockRef.Number, &verifierOutput, nil)
+
+		_, shouldPropose, err := l.fetchOutput(context.Background(), new(big.Int).SetUint64(output.BlockRef.Number))
+		require.ErrorIs(t, err, ErrOutputRootMismatch)
+		require.False(t, shouldPropose)
+	})
+
+	t.Run("VerifierError", func(t *testing.T) {
+		l, rollupClient, verifierClient := setupVerifiedSubmitter(t)
+		output := finalizedOutput(rng)
+		rollupClient.ExpectOutputAtBlock(output.BlockRef.Number, output, nil)
+		verifierClient.ExpectOutputAtBlock(output.BlockRef.Number, (*eth.OutputResponse)(nil), errors.New("not found"))
+
+		_, shouldPropose, err := l.fetchOutput(context.Background(), new(big.Int).SetUint64(output.BlockRef.Number))
+		require.ErrorContains(t, err, "not found")
+		require.False(t, shouldPropose)
+	})
+}
+
+// fakeL1Client serves the headers of a canonical L1 chain.
+type fakeL1Client struct {
+	L1Client
+	headers   map[uint64]*types.Header
+	finalized uint64
+}
+
+func (c *fakeL1Client) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	if number.Int64() == int64(rpc.FinalizedBlockNumber) {
+		return c.headers[c.finalized], nil
+	}
+	header, ok := c.headers[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func TestCheckPendingProposals(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l1 := &fakeL1Client{headers: make(map[uint64]*types.Header), finalized: 10}
+	for i := uint64(0); i <= 20; i++ {
+		l1.headers[i] = &types.Header{Number: new(big.Int).SetUint64(i), Extra: []byte{byte(i)}}
+	}
+	l, rollupClient, _ := setupVerifiedSubmitter(t)
+	l.L1Client = l1
+	l.VerifierRollupProvider = nil
+
+	receipt := func(num uint64, hash common.Hash) *types.Receipt {
+		return &types.Receipt{BlockNumber: new(big.Int).SetUint64(num), BlockHash: hash}
+	}
+	finalizedProposal := finalizedOutput(rng)
+	pendingProposal := finalizedOutput(rng)
+	reorgedProposal := finalizedOutput(rng)
+	l.trackProposal(finalizedProposal, receipt(10, l1.headers[10].Hash()))
+	l.trackProposal(pendingProposal, receipt(15, l1.headers[15].Hash()))
+	l.trackProposal(reorgedProposal, receipt(12, testutils.RandomHash(rng)))
+
+	// The reorged output is not ready to be proposed again yet, so it's retried later.
+	notReady := *reorgedProposal
+	notReady.Status = &eth.SyncStatus{}
+	rollupClient.ExpectOutputAtBlock(reorgedProposal.BlockRef.Number, &notReady, nil)
+
+	l.checkPendingProposals(context.Background())
+	require.Len(t, l.pendingProposals, 2)
+	require.Equal(t, pendingProposal, l.pendingProposals[0].output)
+	require.Equal(t, reorgedProposal, l.pendingProposals[1].output)
+	require.Zero(t, l.pendingProposals[1].l1Block)
+
+	// Once the pending proposal is finalized, it's no longer tracked.
+	l1.finalized = 15
+	rollupClient.ExpectOutputAtBlock(reorgedProposal.BlockRef.Number, &notReady, nil)
+	l.checkPendingProposals(context.Background())
+	require.Len(t, l.pendingProposals, 1)
+	require.Equal(t, reorgedProposal, l.pendingProposals[0].output)
+}
+
+// proposedMetrics records the L2 blocks that are recorded as proposed.
+type proposedMetrics struct {
+	metrics.Metricer
+	proposed []eth.L2BlockRef
+}
+
+func (m *proposedMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
+	m.proposed = append(m.proposed, l2ref)
+}
+
+func TestProposeOutput(t *testing.T) {
+	setup := func(t *testing.T, status uint64) (*L2OutputSubmitter, *proposedMetrics) {
+		l, _, _ := setupVerifiedSubmitter(t)
+		l2ooABI, err := bindings.L2OutputOracleMetaData.GetAbi()
+		require.NoError(t, err)
+		l.l2ooABI = l2ooABI
+		l.Cfg.PollInterval = time.Second
+		m := &proposedMetrics{Metricer: metrics.NoopMetrics}
+		l.Metr = m
+		txMgr := new(mocks.TxManager)
+		t.Cleanup(func() { txMgr.AssertExpectations(t) })
+		txMgr.On("BlockNumber", mock.Anything).Return(uint64(100), nil)
+		txMgr.On("Send", mock.Anything, mock.Anything).Return(&types.Receipt{
+			Status:      status,
+			BlockNumber: big.NewInt(99),
+			BlockHash:   common.Hash{0xaa},
+		}, nil)
+		l.Txmgr = txMgr
+		return l, m
+	}
+
+	t.Run("Successful", func(t *testing.T) {
+		l, m := setup(t, types.ReceiptStatusSuccessful)
+		output := finalizedOutput(rand.New(rand.NewSource(1234)))
+		output.Status.HeadL1.Number = 1
+		require.True(t, l.proposeOutput(context.Background(), output))
+		require.Len(t, l.pendingProposals, 1)
+		require.Equal(t, output, l.pendingProposals[0].output)
+		require.NotZero(t, l.lastProposalTime)
+		require.Equal(t, output.BlockRef.Number, l.lastProposalBlock)
+		require.Equal(t, []eth.L2BlockRef{output.BlockRef}, m.proposed)
+	})
+
+	t.Run("Reverted", func(t *testing.T) {
+		l, m := setup(t, types.ReceiptStatusFailed)
+		output := finalizedOutput(rand.New(rand.NewSource(1234)))
+		output.Status.HeadL1.Number = 1
+		require.False(t, l.proposeOutput(context.Background(), output))
+		require.Empty(t, l.pendingProposals, "should not track a reverted proposal")
+		require.Zero(t, l.lastProposalTime, "should not delay the next proposal")
+		require.Zero(t, l.lastProposalBlock, "should not delay the next proposal")
+		require.Empty(t, m.proposed, "should not record a reverted proposal as proposed")
+	})
+}
@@ -0,0 +1,59 @@
+package proposer
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+var ErrProposalDeferred = errors.New("proposal deferred")
+
+// ProposalPolicy adjusts the cadence of proposals to the DisputeGameFactory. It is evaluated at every
+// ProposalInterval and defers the proposal while the dispute game bond or the L1 gas price exceed
+// their targets, or while too few L2 blocks were produced since the last proposal.
+// Proposals are never deferred for longer than MaxInterval.
+type ProposalPolicy struct {
+	// MaxInterval is the maximum time between proposals. Once exceeded, the next output is proposed
+	// regardless of the other targets. The policy is disabled if zero.
+	MaxInterval time.Duration
+	// MinL2Blocks is the minimum number of L2 blocks since the last proposal. Disabled if zero.
+	MinL2Blocks uint64
+	// MaxGasPrice is the maximum L1 base fee, in wei. Disabled if nil.
+	MaxGasPrice *big.Int
+	// MaxBond is the maximum bond of the dispute game, in wei. Disabled if nil.
+	MaxBond *big.Int
+}
+
+func (p ProposalPolicy) Enabled() bool {
+	return p.MaxInterval != 0
+}
+
+// ProposalConditions are the conditions a proposal is checked against by the ProposalPolicy.
+type ProposalConditions struct {
+	// SinceLastProposal is the time since the last proposal.
+	SinceLastProposal time.Duration
+	// L2Blocks is the number of L2 blocks since the last proposal.
+	L2Blocks uint64
+	// GasPrice is the current L1 base fee, in wei. Only required if the policy has a MaxGasPrice.
+	GasPrice *big.Int
+	// Bond is the bond of the dispute game, in wei. Only required if the policy has a MaxBond.
+	Bond *big.Int
+}
+
+// Check returns an error wrapping ErrProposalDeferred if the proposal should be deferred under the given conditions.
+func (p ProposalPolicy) Check(c ProposalConditions) error {
+	if !p.Enabled() || c.SinceLastProposal >= p.MaxInterval {
+		return nil
+	}
+	if c.L2Blocks < p.MinL2Blocks {
+		return fmt.Errorf("%w: %d L2 blocks since last proposal, below target %d", ErrProposalDeferred, c.L2Blocks, p.MinL2Blocks)
+	}
+	if p.MaxGasPrice != nil && c.GasPrice.Cmp(p.MaxGasPrice) > 0 {
+		return fmt.Errorf("%w: L1 gas price %v exceeds target %v", ErrProposalDeferred, c.GasPrice, p.MaxGasPrice)
+	}
+	if p.MaxBond != nil && c.Bond.Cmp(p.MaxBond) > 0 {
+		return fmt.Errorf("%w: bond %v exceeds target %v", ErrProposalDeferred, c.Bond, p.MaxBond)
+	}
+	return nil
+}
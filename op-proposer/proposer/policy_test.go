@@ -0,0 +1,95 @@
+package proposer
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProposalPolicy(t *testing.T) {
+	policy := ProposalPolicy{
+		MaxInterval: time.Hour,
+		MinL2Blocks: 100,
+		MaxGasPrice: big.NewInt(50),
+		MaxBond:     big.NewInt(1000),
+	}
+	met := ProposalConditions{
+		SinceLastProposal: time.Minute,
+		L2Blocks:          100,
+		GasPrice:          big.NewInt(50),
+		Bond:              big.NewInt(1000),
+	}
+
+	tests := []struct {
+		name     string
+		policy   ProposalPolicy
+		modify   func(c *ProposalConditions)
+		deferred bool
+	}{
+		{name: "TargetsMet", policy: policy},
+		{name: "FewL2Blocks", policy: policy, modify: func(c *ProposalConditions) { c.L2Blocks = 99 }, deferred: true},
+		{name: "HighGasPrice", policy: policy, modify: func(c *ProposalConditions) { c.GasPrice = big.NewInt(51) }, deferred: true},
+		{name: "HighBond", policy: policy, modify: func(c *ProposalConditions) { c.Bond = big.NewInt(1001) }, deferred: true},
+		{
+			name:   "MaxIntervalExceeded",
+			policy: policy,
+			modify: func(c *ProposalConditions) {
+				c.SinceLastProposal = time.Hour
+				c.L2Blocks = 0
+				c.GasPrice = big.NewInt(1e9)
+				c.Bond = big.NewInt(1e9)
+			},
+		},
+		{
+			name:   "Disabled",
+			policy: ProposalPolicy{MinL2Blocks: 100},
+			modify: func(c *ProposalConditions) { c.L2Blocks = 0 },
+		},
+		{
+			name:   "UnsetTargets",
+			policy: ProposalPolicy{MaxInterval: time.Hour},
+			modify: func(c *ProposalConditions) {
+				c.L2Blocks = 0
+				c.GasPrice = nil
+				c.Bond = nil
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			conds := met
+			if test.modify != nil {
+				test.modify(&conds)
+			}
+			err := test.policy.Check(conds)
+			if test.deferred {
+				require.ErrorIs(t, err, ErrProposalDeferred)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCLIConfigProposalPolicy(t *testing.T) {
+	cfg := &CLIConfig{
+		ProposalMaxInterval:     time.Hour,
+		ProposalMinL2Blocks:     100,
+		ProposalMaxGasPriceGwei: 1.5,
+		ProposalMaxBondGwei:     80_000_000,
+	}
+	policy, err := cfg.ProposalPolicy()
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, policy.MaxInterval)
+	require.Equal(t, uint64(100), policy.MinL2Blocks)
+	require.Equal(t, big.NewInt(1_500_000_000), policy.MaxGasPrice)
+	require.Equal(t, new(big.Int).Mul(big.NewInt(80_000_000), big.NewInt(1e9)), policy.MaxBond)
+
+	policy, err = (&CLIConfig{ProposalMaxInterval: time.Hour}).ProposalPolicy()
+	require.NoError(t, err)
+	require.Nil(t, policy.MaxGasPrice)
+	require.Nil(t, policy.MaxBond)
+}
@@ -0,0 +1,108 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// pendingProposal is a proposal of which the L1 inclusion block is not finalized yet.
+// A zero l1Block means that the proposal was reorged out and has to be proposed again.
+type pendingProposal struct {
+	output  *eth.OutputResponse
+	l1Block eth.BlockID
+}
+
+// trackProposal tracks the proposal included in the receipt's L1 block until that block is finalized.
+func (l *L2OutputSubmitter) trackProposal(output *eth.OutputResponse, receipt *types.Receipt) {
+	l.pendingProposals = append(l.pendingProposals, pendingProposal{
+		output:  output,
+		l1Block: eth.BlockID{Hash: receipt.BlockHash, Number: receipt.BlockNumber.Uint64()},
+	})
+	l.Metr.RecordProposalsPending(len(l.pendingProposals))
+}
+
+// checkPendingProposals checks the pending proposals against the canonical L1 chain.
+// Proposals that were reorged out are proposed again, and proposals included
+// in finalized L1 blocks are no longer tracked.
+func (l *L2OutputSubmitter) checkPendingProposals(ctx context.Context) {
+	if len(l.pendingProposals) == 0 {
+		return
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	finalized, err := l.L1Client.HeaderByNumber(cCtx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		l.Log.Error("Failed to fetch finalized L1 block to check pending proposals", "err", err)
+		return
+	}
+
+	var pending, reorged []pendingProposal
+	for _, p := range l.pendingProposals {
+		if p.l1Block == (eth.BlockID{}) {
+			reorged = append(reorged, p)
+			continue
+		}
+		header, err := l.L1Client.HeaderByNumber(cCtx, new(big.Int).SetUint64(p.l1Block.Number))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			l.Log.Error("Failed to fetch L1 block of pending proposal", "err", err, "l1block", p.l1Block)
+			pending = append(pending, p)
+			continue
+		}
+		if header == nil || header.Hash() != p.l1Block.Hash {
+			l.Log.Warn("Proposal was reorged out of L1", "l2block", p.output.BlockRef, "l1block", p.l1Block)
+			l.Metr.RecordProposalReorged(p.output.BlockRef)
+			reorged = append(reorged, pendingProposal{output: p.output})
+		} else if p.l1Block.Number <= finalized.Number.Uint64() {
+			l.Log.Info("Proposal finalized", "l2block", p.output.BlockRef, "l1block", p.l1Block)
+		} else {
+			pending = append(pending, p)
+		}
+	}
+	l.pendingProposals = pending
+
+	for _, p := range reorged {
+		if !l.reproposeOutput(ctx, p.output) {
+			l.pendingProposals = append(l.pendingProposals, p)
+		}
+	}
+	l.Metr.RecordProposalsPending(len(l.pendingProposals))
+}
+
+// reproposeOutput proposes the output at the L2 block of a reorged proposal again.
+// It returns false if the re-proposal has to be retried later.
+func (l *L2OutputSubmitter) reproposeOutput(ctx context.Context, prev *eth.OutputResponse) bool {
+	block := new(big.Int).SetUint64(prev.BlockRef.Number)
+	if l.l2ooContract != nil {
+		// The L2OutputOracle only accepts the next output, which may have been proposed again already.
+		cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+		next, err := l.l2ooContract.NextBlockNumber(&bind.CallOpts{From: l.Txmgr.From(), Context: cCtx})
+		cancel()
+		if err != nil {
+			l.Log.Error("proposer unable to get next block number", "err", err)
+			return false
+		}
+		if next.Cmp(block) > 0 {
+			return true
+		} else if next.Cmp(block) < 0 {
+			return false
+		}
+	}
+	output, shouldPropose, err := l.fetchOutput(ctx, block)
+	if err != nil || !shouldPropose {
+		return false
+	}
+	if output.OutputRoot != prev.OutputRoot {
+		l.Log.Warn("Output root changed since reorged proposal", "l2block", output.BlockRef,
+			"prev_output_root", prev.OutputRoot, "output_root", output.OutputRoot)
+	}
+	l.Log.Info("Proposing reorged output again", "l2block", output.BlockRef)
+	return l.proposeOutput(ctx, output)
+}
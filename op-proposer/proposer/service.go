@@ -18,6 +18,7 @@ import (
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/shutdown"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -33,6 +34,8 @@ type ProposerConfig struct {
 
 	// How frequently to post L2 outputs when the DisputeGameFactory is configured
 	ProposalInterval time.Duration
+	// ProposalPolicy may defer the proposals of the ProposalInterval, up to its MaxInterval
+	ProposalPolicy ProposalPolicy
 
 	L2OutputOracleAddr     *common.Address
 	DisputeGameFactoryAddr *common.Address
@@ -54,6 +57,8 @@ type ProposerService struct {
 	TxManager      txmgr.TxManager
 	L1Client       *ethclient.Client
 	RollupProvider dial.RollupProvider
+	// VerifierRollupProvider provides the rollup node to cross-check output roots with. It may be nil.
+	VerifierRollupProvider dial.RollupProvider
 
 	driver *L2OutputSubmitter
 
@@ -90,7 +95,9 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	ps.AllowNonFinalized = cfg.AllowNonFinalized
 
 	ps.initL2ooAddress(cfg)
-	ps.initDGF(cfg)
+	if err := ps.initDGF(cfg); err != nil {
+		return err
+	}
 
 	if err := ps.initRPCClients(ctx, cfg); err != nil {
 		return err
@@ -135,6 +142,15 @@ func (ps *ProposerService) initRPCClients(ctx context.Context, cfg *CLIConfig) e
 		return fmt.Errorf("failed to build L2 endpoint provider: %w", err)
 	}
 	ps.RollupProvider = rollupProvider
+
+	if cfg.VerifierRollupRpc != "" {
+		verifierProvider, err := dial.NewStaticL2RollupProvider(ctx, ps.Log, cfg.VerifierRollupRpc)
+		if err != nil {
+			return fmt.Errorf("failed to build verifier rollup provider: %w", err)
+		}
+		ps.VerifierRollupProvider = verifierProvider
+		ps.Log.Info("Cross-checking output roots with verifier rollup node")
+	}
 	return nil
 }
 
@@ -164,14 +180,7 @@ func (ps *ProposerService) initTxManager(cfg *CLIConfig) error {
 }
 
 func (ps *ProposerService) initPProf(cfg *CLIConfig) error {
-	ps.pprofService = oppprof.New(
-		cfg.PprofConfig.ListenEnabled,
-		cfg.PprofConfig.ListenAddr,
-		cfg.PprofConfig.ListenPort,
-		cfg.PprofConfig.ProfileType,
-		cfg.PprofConfig.ProfileDir,
-		cfg.PprofConfig.ProfileFilename,
-	)
+	ps.pprofService = oppprof.NewFromCLIConfig(cfg.PprofConfig)
 
 	if err := ps.pprofService.Start(); err != nil {
 		return fmt.Errorf("failed to start pprof service: %w", err)
@@ -208,15 +217,22 @@ func (ps *ProposerService) initL2ooAddress(cfg *CLIConfig) {
 	ps.L2OutputOracleAddr = &l2ooAddress
 }
 
-func (ps *ProposerService) initDGF(cfg *CLIConfig) {
+func (ps *ProposerService) initDGF(cfg *CLIConfig) error {
 	dgfAddress, err := opservice.ParseAddress(cfg.DGFAddress)
 	if err != nil {
 		// Return no error & set no DGF related configuration fields.
-		return
+		return nil
 	}
 	ps.DisputeGameFactoryAddr = &dgfAddress
 	ps.ProposalInterval = cfg.ProposalInterval
 	ps.DisputeGameType = cfg.DisputeGameType
+
+	policy, err := cfg.ProposalPolicy()
+	if err != nil {
+		return err
+	}
+	ps.ProposalPolicy = policy
+	return nil
 }
 
 func (ps *ProposerService) initDriver() error {
@@ -227,6 +243,8 @@ func (ps *ProposerService) initDriver() error {
 		Txmgr:          ps.TxManager,
 		L1Client:       ps.L1Client,
 		RollupProvider: ps.RollupProvider,
+
+		VerifierRollupProvider: ps.VerifierRollupProvider,
 	})
 	if err != nil {
 		return err
@@ -282,54 +300,49 @@ func (ps *ProposerService) Stop(ctx context.Context) error {
 	}
 	ps.Log.Info("Stopping Proposer")
 
-	var result error
-	if ps.driver != nil {
-		if err := ps.driver.StopL2OutputSubmittingIfRunning(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to stop L2Output submitting: %w", err))
-		}
-	}
+	result := ps.shutdownManager().Stop(ctx)
 
-	if ps.rpcServer != nil {
-		// TODO(7685): the op-service RPC server is not built on top of op-service httputil Server, and has poor shutdown
-		if err := ps.rpcServer.Stop(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to stop RPC server: %w", err))
-		}
-	}
-	if ps.pprofService != nil {
-		if err := ps.pprofService.Stop(ctx); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to stop PProf server: %w", err))
-		}
-	}
-	if ps.balanceMetricer != nil {
-		if err := ps.balanceMetricer.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close balance metricer: %w", err))
-		}
+	if result == nil {
+		ps.stopped.Store(true)
+		ps.Log.Info("L2Output Submitter stopped")
 	}
 
-	if ps.TxManager != nil {
-		ps.TxManager.Close()
-	}
+	return result
+}
 
+// shutdownManager registers the initialized subsystems of the proposer, after the subsystems they use.
+func (ps *ProposerService) shutdownManager() *shutdown.Manager {
+	m := shutdown.NewManager(ps.Log)
 	if ps.metricsSrv != nil {
-		if err := ps.metricsSrv.Stop(ctx); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to stop metrics server: %w", err))
-		}
+		m.Register("metrics-server", ps.metricsSrv.Stop)
+	}
+	if ps.pprofService != nil {
+		m.Register("pprof", ps.pprofService.Stop)
 	}
-
 	if ps.L1Client != nil {
-		ps.L1Client.Close()
+		m.Register("l1-client", shutdown.Closer(ps.L1Client.Close))
 	}
-
 	if ps.RollupProvider != nil {
-		ps.RollupProvider.Close()
+		m.Register("rollup-provider", shutdown.Closer(ps.RollupProvider.Close))
 	}
-
-	if result == nil {
-		ps.stopped.Store(true)
-		ps.Log.Info("L2Output Submitter stopped")
+	if ps.VerifierRollupProvider != nil {
+		m.Register("verifier-rollup-provider", shutdown.Closer(ps.VerifierRollupProvider.Close))
 	}
-
-	return result
+	if ps.TxManager != nil {
+		m.Register("txmgr", shutdown.Closer(ps.TxManager.Close), shutdown.DependsOn("metrics-server"))
+	}
+	if ps.balanceMetricer != nil {
+		m.Register("balance-metrics", shutdown.ErrCloser(ps.balanceMetricer.Close), shutdown.DependsOn("l1-client", "txmgr"))
+	}
+	if ps.driver != nil {
+		m.Register("output-submitter", shutdown.ErrCloser(ps.driver.StopL2OutputSubmittingIfRunning),
+			shutdown.DependsOn("txmgr", "l1-client", "rollup-provider", "verifier-rollup-provider", "metrics-server"))
+	}
+	if ps.rpcServer != nil {
+		// TODO(7685): the op-service RPC server is not built on top of op-service httputil Server, and has poor shutdown
+		m.Register("rpc-server", shutdown.ErrCloser(ps.rpcServer.Stop), shutdown.DependsOn("output-submitter"))
+	}
+	return m
 }
 
 var _ cliapp.Lifecycle = (*ProposerService)(nil)
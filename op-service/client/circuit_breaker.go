@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// ErrCircuitOpen is returned by a CircuitBreakerClient while requests are being rejected
+// because the underlying RPC has failed repeatedly.
+var ErrCircuitOpen = errors.New("rpc circuit breaker is open")
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreakerClient is a wrapper around a pure RPC that stops sending requests after
+// a number of consecutive failures, to avoid hammering a provider that is down.
+//
+// Once tripped, requests fail fast with ErrCircuitOpen until the cooldown elapses. A single
+// probe request is then let through: if it succeeds the circuit closes again,
+// otherwise it re-opens for another cooldown period.
+//
+// Only transport-level failures count towards tripping the circuit. JSON-RPC error responses
+// prove the provider is reachable, and requests aborted by the caller's context are ignored.
+type CircuitBreakerClient struct {
+	c         RPC
+	clock     clock.Clock
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+// NewCircuitBreakerClient creates a circuit breaker that trips after threshold consecutive failures,
+// and probes the RPC again after cooldown.
+func NewCircuitBreakerClient(c RPC, threshold int, cooldown time.Duration) *CircuitBreakerClient {
+	return newCircuitBreakerClient(c, threshold, cooldown, clock.SystemClock)
+}
+
+func newCircuitBreakerClient(c RPC, threshold int, cooldown time.Duration, cl clock.Clock) *CircuitBreakerClient {
+	return &CircuitBreakerClient{
+		c:         c,
+		clock:     cl,
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+func (b *CircuitBreakerClient) Close() {
+	b.c.Close()
+}
+
+func (b *CircuitBreakerClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if err := b.acquire(); err != nil {
+		return err
+	}
+	err := b.c.CallContext(ctx, result, method, args...)
+	b.release(ctx, err)
+	return err
+}
+
+// BatchCallContext only considers the error of the batch as a whole.
+// Errors of individual batch elements are JSON-RPC errors and do not trip the circuit.
+func (b *CircuitBreakerClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	if err := b.acquire(); err != nil {
+		return err
+	}
+	err := b.c.BatchCallContext(ctx, batch)
+	b.release(ctx, err)
+	return err
+}
+
+func (b *CircuitBreakerClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	if err := b.acquire(); err != nil {
+		return nil, err
+	}
+	sub, err := b.c.EthSubscribe(ctx, channel, args...)
+	b.release(ctx, err)
+	return sub, err
+}
+
+// acquire returns ErrCircuitOpen if the request may not be sent.
+// When the cooldown has elapsed the caller becomes the half-open probe.
+func (b *CircuitBreakerClient) acquire() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case circuitOpen:
+		if b.clock.Since(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		return nil
+	case circuitHalfOpen:
+		// A probe is already in flight.
+		return ErrCircuitOpen
+	default:
+		return nil
+	}
+}
+
+// release records the result of a request that was let through by acquire.
+func (b *CircuitBreakerClient) release(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up, which says nothing about the health of the RPC.
+		// Allow another probe if this was one.
+		if b.state == circuitHalfOpen {
+			b.state = circuitOpen
+		}
+		return
+	}
+	if !isCircuitFailure(err) {
+		b.state = circuitClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = b.clock.Now()
+	}
+}
+
+// isCircuitFailure returns true if err indicates the RPC could not serve the request.
+func isCircuitFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
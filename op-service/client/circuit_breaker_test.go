@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+type jsonRPCError struct{}
+
+func (jsonRPCError) Error() string  { return "execution reverted" }
+func (jsonRPCError) ErrorCode() int { return 3 }
+
+// flakyRPC fails every request with err, and counts the requests that reached it.
+type flakyRPC struct {
+	err   error
+	calls int
+}
+
+func (f *flakyRPC) Close() {}
+
+func (f *flakyRPC) CallContext(_ context.Context, _ any, _ string, _ ...any) error {
+	f.calls++
+	return f.err
+}
+
+func (f *flakyRPC) BatchCallContext(_ context.Context, _ []rpc.BatchElem) error {
+	f.calls++
+	return f.err
+}
+
+func (f *flakyRPC) EthSubscribe(_ context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	f.calls++
+	return nil, f.err
+}
+
+func TestCircuitBreakerClient(t *testing.T) {
+	const threshold = 3
+	const cooldown = 10 * time.Second
+	errTransport := errors.New("connection refused")
+
+	setup := func() (*CircuitBreakerClient, *flakyRPC, *clock.DeterministicClock) {
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		stub := &flakyRPC{}
+		return newCircuitBreakerClient(stub, threshold, cooldown, cl), stub, cl
+	}
+	call := func(b *CircuitBreakerClient) error {
+		return b.CallContext(context.Background(), nil, "eth_chainId")
+	}
+	trip := func(t *testing.T, b *CircuitBreakerClient, stub *flakyRPC) {
+		stub.err = errTransport
+		for i := 0; i < threshold; i++ {
+			require.ErrorIs(t, call(b), errTransport)
+		}
+		require.ErrorIs(t, call(b), ErrCircuitOpen)
+	}
+
+	t.Run("TripsAfterConsecutiveFailures", func(t *testing.T) {
+		b, stub, _ := setup()
+		trip(t, b, stub)
+		require.Equal(t, threshold, stub.calls)
+		require.ErrorIs(t, b.BatchCallContext(context.Background(), nil), ErrCircuitOpen)
+		_, err := b.EthSubscribe(context.Background(), nil)
+		require.ErrorIs(t, err, ErrCircuitOpen)
+		require.Equal(t, threshold, stub.calls)
+	})
+
+	t.Run("SuccessResetsFailureCount", func(t *testing.T) {
+		b, stub, _ := setup()
+		stub.err = errTransport
+		for i := 0; i < threshold-1; i++ {
+			require.ErrorIs(t, call(b), errTransport)
+		}
+		stub.err = nil
+		require.NoError(t, call(b))
+		stub.err = errTransport
+		for i := 0; i < threshold-1; i++ {
+			require.ErrorIs(t, call(b), errTransport)
+		}
+		require.Equal(t, 2*(threshold-1)+1, stub.calls)
+	})
+
+	t.Run("IgnoreJSONRPCErrors", func(t *testing.T) {
+		b, stub, _ := setup()
+		stub.err = jsonRPCError{}
+		for i := 0; i < threshold*2; i++ {
+			require.ErrorIs(t, call(b), stub.err)
+		}
+		require.Equal(t, threshold*2, stub.calls)
+	})
+
+	t.Run("IgnoreCallerCancellation", func(t *testing.T) {
+		b, stub, _ := setup()
+		stub.err = context.Canceled
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		for i := 0; i < threshold*2; i++ {
+			require.ErrorIs(t, b.CallContext(ctx, nil, "eth_chainId"), context.Canceled)
+		}
+		require.Equal(t, threshold*2, stub.calls)
+	})
+
+	t.Run("HalfOpenProbeSuccessCloses", func(t *testing.T) {
+		b, stub, cl := setup()
+		trip(t, b, stub)
+		cl.AdvanceTime(cooldown)
+		stub.err = nil
+		require.NoError(t, call(b))
+		require.NoError(t, call(b))
+		require.Equal(t, threshold+2, stub.calls)
+	})
+
+	t.Run("HalfOpenProbeFailureReopens", func(t *testing.T) {
+		b, stub, cl := setup()
+		trip(t, b, stub)
+		cl.AdvanceTime(cooldown)
+		require.ErrorIs(t, call(b), errTransport)
+		require.ErrorIs(t, call(b), ErrCircuitOpen)
+		require.Equal(t, threshold+1, stub.calls)
+
+		cl.AdvanceTime(cooldown)
+		stub.err = nil
+		require.NoError(t, call(b))
+	})
+
+	t.Run("SingleProbeWhileHalfOpen", func(t *testing.T) {
+		b, stub, cl := setup()
+		trip(t, b, stub)
+		cl.AdvanceTime(cooldown)
+		require.NoError(t, b.acquire())
+		require.ErrorIs(t, call(b), ErrCircuitOpen)
+		b.release(context.Background(), nil)
+		stub.err = nil
+		require.NoError(t, call(b))
+	})
+}
+
+func TestWithCircuitBreakerRejectsInvalidThreshold(t *testing.T) {
+	var cfg rpcConfig
+	require.Error(t, WithCircuitBreaker(0, time.Second)(&cfg))
+	require.NoError(t, WithCircuitBreaker(1, time.Second)(&cfg))
+	require.Equal(t, 1, cfg.breakerThreshold)
+	require.Equal(t, time.Second, cfg.breakerCooldown)
+}
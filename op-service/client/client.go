@@ -4,7 +4,7 @@ import (
 	"context"
 	"math/big"
 
-	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -53,13 +53,13 @@ type Client interface {
 // Prometheus metrics for each call.
 type InstrumentedClient struct {
 	c *ethclient.Client
-	m *metrics.Metrics
+	m metrics.RPCClientMetricer
 }
 
 // NewInstrumentedClient creates a new instrumented client. It takes
 // a concrete *rpc.Client to prevent people from passing in an already
 // instrumented client.
-func NewInstrumentedClient(c *rpc.Client, m *metrics.Metrics) *InstrumentedClient {
+func NewInstrumentedClient(c *rpc.Client, m metrics.RPCClientMetricer) *InstrumentedClient {
 	return &InstrumentedClient{
 		c: ethclient.NewClient(c),
 		m: m,
@@ -263,14 +263,14 @@ func (ic *InstrumentedClient) SendTransaction(ctx context.Context, tx *types.Tra
 	})
 }
 
-func instrument1(m *metrics.Metrics, name string, cb func() error) error {
+func instrument1(m metrics.RPCClientMetricer, name string, cb func() error) error {
 	record := m.RecordRPCClientRequest(name)
 	err := cb()
 	record(err)
 	return err
 }
 
-func instrument2[O any](m *metrics.Metrics, name string, cb func() (O, error)) (O, error) {
+func instrument2[O any](m metrics.RPCClientMetricer, name string, cb func() (O, error)) (O, error) {
 	record := m.RecordRPCClientRequest(name)
 	res, err := cb()
 	record(err)
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// DefaultFallbackProbeInterval is the default interval at which a FallbackClient
+// checks if the primary endpoint has recovered.
+const DefaultFallbackProbeInterval = 30 * time.Second
+
+// DefaultFallbackAttemptTimeout is the default timeout of a request to a single endpoint of a FallbackClient,
+// after which the request is retried against the next endpoint.
+const DefaultFallbackAttemptTimeout = 10 * time.Second
+
+const fallbackProbeTimeout = 10 * time.Second
+
+// FallbackClient is an RPC client backed by an ordered list of endpoints, the first being the primary.
+//
+// Requests are sent to the active endpoint. If it fails to serve a request, e.g. because of a
+// connection error or because it did not respond within the attempt timeout, the next endpoint becomes active
+// and the request is retried against it, until every endpoint has been tried once or the caller's context is done.
+// JSON-RPC error responses are returned as-is, as they indicate the endpoint is working.
+//
+// While a fallback endpoint is active, the primary is periodically probed and becomes active again once it recovers.
+// Existing subscriptions are not moved between endpoints.
+type FallbackClient struct {
+	log            log.Logger
+	clients        []RPC
+	m              metrics.FallbackClientMetricer
+	attemptTimeout time.Duration
+
+	active atomic.Int64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewFallbackRPC creates a FallbackClient over the urls, the first being the primary endpoint.
+// Each endpoint is dialed with the given options when it is first used, and again on later use if dialing failed,
+// so endpoints that are down do not prevent the client from being created.
+func NewFallbackRPC(lgr log.Logger, urls []string, m metrics.FallbackClientMetricer, probeInterval time.Duration, attemptTimeout time.Duration, opts ...RPCOption) (*FallbackClient, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no RPC endpoints specified")
+	}
+	clients := make([]RPC, 0, len(urls))
+	for i, url := range urls {
+		clients = append(clients, &lazyRPC{log: lgr.New("endpoint", i), addr: url, opts: opts})
+	}
+	return NewFallbackClient(lgr, clients, m, probeInterval, attemptTimeout), nil
+}
+
+// NewFallbackClient creates a FallbackClient over the given clients, the first being the primary.
+// The primary is probed for recovery every probeInterval while a fallback endpoint is active.
+// Requests to a single endpoint time out after attemptTimeout, 0 to only time out with the caller's context.
+func NewFallbackClient(lgr log.Logger, clients []RPC, m metrics.FallbackClientMetricer, probeInterval time.Duration, attemptTimeout time.Duration) *FallbackClient {
+	return newFallbackClient(lgr, clients, m, probeInterval, attemptTimeout, clock.SystemClock)
+}
+
+func newFallbackClient(lgr log.Logger, clients []RPC, m metrics.FallbackClientMetricer, probeInterval time.Duration, attemptTimeout time.Duration, cl clock.Clock) *FallbackClient {
+	f := &FallbackClient{
+		log:            lgr,
+		clients:        clients,
+		m:              m,
+		attemptTimeout: attemptTimeout,
+		done:           make(chan struct{}),
+	}
+	if len(clients) > 1 {
+		f.wg.Add(1)
+		go f.probePrimary(cl.NewTicker(probeInterval))
+	}
+	return f
+}
+
+// ActiveEndpoint returns the index of the endpoint requests are currently sent to.
+func (f *FallbackClient) ActiveEndpoint() int {
+	return int(f.active.Load())
+}
+
+func (f *FallbackClient) Close() {
+	close(f.done)
+	f.wg.Wait()
+	for _, c := range f.clients {
+		c.Close()
+	}
+}
+
+func (f *FallbackClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return f.try(ctx, func(ctx context.Context, c RPC) error {
+		return c.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (f *FallbackClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return f.try(ctx, func(ctx context.Context, c RPC) error {
+		return c.BatchCallContext(ctx, b)
+	})
+}
+
+func (f *FallbackClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := f.try(ctx, func(ctx context.Context, c RPC) error {
+		var err error
+		sub, err = c.EthSubscribe(ctx, channel, args...)
+		return err
+	})
+	return sub, err
+}
+
+// try runs fn against the active endpoint, failing over to the next endpoint
+// until fn succeeds, every endpoint has been tried, or ctx is done.
+// Every attempt runs with its own context, that times out after the attempt timeout.
+func (f *FallbackClient) try(ctx context.Context, fn func(ctx context.Context, c RPC) error) error {
+	idx := f.active.Load()
+	var err error
+	for range f.clients {
+		err = f.attempt(ctx, f.clients[idx], fn)
+		if err == nil || ctx.Err() != nil || !isCircuitFailure(err) {
+			return err
+		}
+		f.log.Warn("RPC endpoint failed, failing over", "endpoint", idx, "err", err)
+		idx = f.switchFrom(idx, (idx+1)%int64(len(f.clients)))
+	}
+	return err
+}
+
+func (f *FallbackClient) attempt(ctx context.Context, c RPC, fn func(ctx context.Context, c RPC) error) error {
+	if f.attemptTimeout == 0 {
+		return fn(ctx, c)
+	}
+	ctx, cancel := context.WithTimeout(ctx, f.attemptTimeout)
+	defer cancel()
+	return fn(ctx, c)
+}
+
+// switchFrom makes the to endpoint active, if the from endpoint is still active.
+// It returns the endpoint that is active after the switch.
+func (f *FallbackClient) switchFrom(from int64, to int64) int64 {
+	if !f.active.CompareAndSwap(from, to) {
+		// Another request already switched endpoint.
+		return f.active.Load()
+	}
+	f.log.Info("Switched RPC endpoint", "from", from, "to", to)
+	f.m.RecordActiveEndpoint(int(to))
+	return to
+}
+
+func (f *FallbackClient) probePrimary(ticker clock.Ticker) {
+	defer f.wg.Done()
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-ticker.Ch():
+			active := f.active.Load()
+			if active == 0 {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), fallbackProbeTimeout)
+			var chainID hexutil.Big
+			err := f.clients[0].CallContext(ctx, &chainID, "eth_chainId")
+			cancel()
+			if err != nil {
+				f.log.Debug("Primary RPC endpoint has not recovered", "err", err)
+				continue
+			}
+			f.switchFrom(active, 0)
+		}
+	}
+}
+
+// lazyRPC dials the endpoint when it is first used, and again on later use if dialing failed.
+type lazyRPC struct {
+	log  log.Logger
+	addr string
+	opts []RPCOption
+
+	mu     sync.Mutex
+	rpc    RPC
+	closed bool
+}
+
+var _ RPC = (*lazyRPC)(nil)
+
+func (l *lazyRPC) client(ctx context.Context) (RPC, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return nil, errors.New("client is closed")
+	}
+	if l.rpc == nil {
+		c, err := NewRPC(ctx, l.log, l.addr, l.opts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial RPC endpoint: %w", err)
+		}
+		l.rpc = c
+	}
+	return l.rpc, nil
+}
+
+func (l *lazyRPC) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closed = true
+	if l.rpc != nil {
+		l.rpc.Close()
+	}
+}
+
+func (l *lazyRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	c, err := l.client(ctx)
+	if err != nil {
+		return err
+	}
+	return c.CallContext(ctx, result, method, args...)
+}
+
+func (l *lazyRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	c, err := l.client(ctx)
+	if err != nil {
+		return err
+	}
+	return c.BatchCallContext(ctx, b)
+}
+
+func (l *lazyRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	c, err := l.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.EthSubscribe(ctx, channel, args...)
+}
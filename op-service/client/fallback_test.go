@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// endpointRPC is a stub RPC endpoint that can be switched between healthy and failing.
+type endpointRPC struct {
+	mu     sync.Mutex
+	err    error
+	calls  int
+	closed bool
+	// hang makes calls block until their context is done
+	hang bool
+}
+
+func (e *endpointRPC) setErr(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.err = err
+}
+
+func (e *endpointRPC) callCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls
+}
+
+func (e *endpointRPC) setHang(hang bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.hang = hang
+}
+
+func (e *endpointRPC) call(ctx context.Context) error {
+	e.mu.Lock()
+	e.calls++
+	hang, err := e.hang, e.err
+	e.mu.Unlock()
+	if hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return err
+}
+
+func (e *endpointRPC) Close() {
+	e.closed = true
+}
+
+func (e *endpointRPC) CallContext(ctx context.Context, _ any, _ string, _ ...any) error {
+	return e.call(ctx)
+}
+
+func (e *endpointRPC) BatchCallContext(ctx context.Context, _ []rpc.BatchElem) error {
+	return e.call(ctx)
+}
+
+func (e *endpointRPC) EthSubscribe(ctx context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, e.call(ctx)
+}
+
+type recordingFallbackMetrics struct {
+	mu       sync.Mutex
+	switches []int
+}
+
+func (m *recordingFallbackMetrics) RecordActiveEndpoint(index int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.switches = append(m.switches, index)
+}
+
+func (m *recordingFallbackMetrics) recorded() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]int(nil), m.switches...)
+}
+
+func TestFallbackClient(t *testing.T) {
+	const probeInterval = 30 * time.Second
+	const attemptTimeout = 100 * time.Millisecond
+	errTransport := errors.New("connection refused")
+
+	setup := func(t *testing.T) (*FallbackClient, []*endpointRPC, *recordingFallbackMetrics, *clock.DeterministicClock) {
+		endpoints := []*endpointRPC{{}, {}, {}}
+		clients := make([]RPC, len(endpoints))
+		for i, e := range endpoints {
+			clients[i] = e
+		}
+		m := &recordingFallbackMetrics{}
+		cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+		f := newFallbackClient(testlog.Logger(t, log.LvlInfo), clients, m, probeInterval, attemptTimeout, cl)
+		t.Cleanup(f.Close)
+		return f, endpoints, m, cl
+	}
+	call := func(f *FallbackClient) error {
+		return f.CallContext(context.Background(), nil, "eth_chainId")
+	}
+
+	t.Run("UsePrimary", func(t *testing.T) {
+		f, endpoints, m, _ := setup(t)
+		require.NoError(t, call(f))
+		require.NoError(t, f.BatchCallContext(context.Background(), nil))
+		require.Equal(t, 2, endpoints[0].callCount())
+		require.Zero(t, endpoints[1].callCount())
+		require.Empty(t, m.recorded())
+	})
+
+	t.Run("FailOverOnError", func(t *testing.T) {
+		f, endpoints, m, _ := setup(t)
+		endpoints[0].setErr(errTransport)
+		require.NoError(t, call(f))
+		require.Equal(t, 1, f.ActiveEndpoint())
+		require.Equal(t, []int{1}, m.recorded())
+
+		// Subsequent requests go straight to the fallback.
+		require.NoError(t, call(f))
+		require.Equal(t, 1, endpoints[0].callCount())
+		require.Equal(t, 2, endpoints[1].callCount())
+	})
+
+	t.Run("FailOverOnTimeout", func(t *testing.T) {
+		f, endpoints, _, _ := setup(t)
+		endpoints[0].setErr(context.DeadlineExceeded)
+		require.NoError(t, call(f))
+		require.Equal(t, 1, f.ActiveEndpoint())
+	})
+
+	t.Run("FailOverOnAttemptTimeout", func(t *testing.T) {
+		f, endpoints, _, _ := setup(t)
+		endpoints[0].setHang(true)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		require.NoError(t, f.CallContext(ctx, nil, "eth_chainId"))
+		require.Equal(t, 1, f.ActiveEndpoint())
+		require.Equal(t, 1, endpoints[1].callCount())
+	})
+
+	t.Run("DoNotFailOverOnJSONRPCError", func(t *testing.T) {
+		f, endpoints, m, _ := setup(t)
+		endpoints[0].setErr(jsonRPCError{})
+		require.ErrorIs(t, call(f), jsonRPCError{})
+		require.Equal(t, 0, f.ActiveEndpoint())
+		require.Empty(t, m.recorded())
+	})
+
+	t.Run("DoNotFailOverOnCallerCancellation", func(t *testing.T) {
+		f, endpoints, _, _ := setup(t)
+		endpoints[0].setErr(context.Canceled)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		require.ErrorIs(t, f.CallContext(ctx, nil, "eth_chainId"), context.Canceled)
+		require.Equal(t, 0, f.ActiveEndpoint())
+	})
+
+	t.Run("AllEndpointsFail", func(t *testing.T) {
+		f, endpoints, _, _ := setup(t)
+		for _, e := range endpoints {
+			e.setErr(errTransport)
+		}
+		require.ErrorIs(t, call(f), errTransport)
+		for _, e := range endpoints {
+			require.Equal(t, 1, e.callCount())
+		}
+		// Wraps back around to the primary.
+		require.Equal(t, 0, f.ActiveEndpoint())
+	})
+
+	t.Run("RecoverPrimary", func(t *testing.T) {
+		f, endpoints, m, cl := setup(t)
+		endpoints[0].setErr(errTransport)
+		require.NoError(t, call(f))
+		require.Equal(t, 1, f.ActiveEndpoint())
+
+		// Primary is still down.
+		cl.AdvanceTime(probeInterval)
+		require.Eventually(t, func() bool { return endpoints[0].callCount() == 2 }, 10*time.Second, 10*time.Millisecond)
+		require.Equal(t, 1, f.ActiveEndpoint())
+
+		endpoints[0].setErr(nil)
+		cl.AdvanceTime(probeInterval)
+		require.Eventually(t, func() bool { return f.ActiveEndpoint() == 0 }, 10*time.Second, 10*time.Millisecond)
+		require.Equal(t, []int{1, 0}, m.recorded())
+	})
+
+	t.Run("DialLazily", func(t *testing.T) {
+		srv := rpc.NewServer()
+		t.Cleanup(srv.Stop)
+		require.NoError(t, srv.RegisterName("eth", new(chainIDAPI)))
+		httpSrv := httptest.NewServer(srv)
+		t.Cleanup(httpSrv.Close)
+
+		// The primary is unavailable, which must not prevent creating the client.
+		f, err := NewFallbackRPC(testlog.Logger(t, log.LvlInfo), []string{"http://127.0.0.1:1", httpSrv.URL},
+			&recordingFallbackMetrics{}, probeInterval, attemptTimeout)
+		require.NoError(t, err)
+		t.Cleanup(f.Close)
+		var chainID hexutil.Big
+		require.NoError(t, f.CallContext(context.Background(), &chainID, "eth_chainId"))
+		require.Equal(t, uint64(10), chainID.ToInt().Uint64())
+		require.Equal(t, 1, f.ActiveEndpoint())
+	})
+
+	t.Run("CloseAllEndpoints", func(t *testing.T) {
+		endpoints := []*endpointRPC{{}, {}}
+		f := newFallbackClient(testlog.Logger(t, log.LvlInfo), []RPC{endpoints[0], endpoints[1]},
+			&recordingFallbackMetrics{}, probeInterval, attemptTimeout, clock.NewDeterministicClock(time.Unix(1000, 0)))
+		f.Close()
+		require.True(t, endpoints[0].closed)
+		require.True(t, endpoints[1].closed)
+	})
+}
+
+type chainIDAPI struct{}
+
+func (chainIDAPI) ChainId() hexutil.Big {
+	return hexutil.Big(*big.NewInt(10))
+}
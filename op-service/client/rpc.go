@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/url"
@@ -11,10 +12,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/time/rate"
 
-	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -33,6 +33,8 @@ type rpcConfig struct {
 	backoffAttempts  int
 	limit            float64
 	burst            int
+	breakerThreshold int
+	breakerCooldown  time.Duration
 }
 
 type RPCOption func(cfg *rpcConfig) error
@@ -72,6 +74,20 @@ func WithRateLimit(rateLimit float64, burst int) RPCOption {
 	}
 }
 
+// WithCircuitBreaker configures the RPC to reject requests for the cooldown duration
+// after the given number of consecutive failures.
+// See NewCircuitBreakerClient for more details.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) RPCOption {
+	return func(cfg *rpcConfig) error {
+		if threshold < 1 {
+			return fmt.Errorf("circuit breaker threshold must be at least 1, got %d", threshold)
+		}
+		cfg.breakerThreshold = threshold
+		cfg.breakerCooldown = cooldown
+		return nil
+	}
+}
+
 // NewRPC returns the correct client.RPC instance for a given RPC url.
 func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption) (RPC, error) {
 	var cfg rpcConfig
@@ -96,6 +112,12 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 		wrapped = NewRateLimitingClient(wrapped, rate.Limit(cfg.limit), cfg.burst)
 	}
 
+	// The circuit breaker wraps the rate-limiter, so requests fail fast while it is open
+	// instead of waiting for rate-limit tokens first.
+	if cfg.breakerThreshold != 0 {
+		wrapped = NewCircuitBreakerClient(wrapped, cfg.breakerThreshold, cfg.breakerCooldown)
+	}
+
 	return NewRPCWithClient(ctx, lgr, addr, wrapped, cfg.httpPollInterval)
 }
 
@@ -179,15 +201,15 @@ func (b *BaseRPCClient) EthSubscribe(ctx context.Context, channel any, args ...a
 	return b.c.EthSubscribe(ctx, channel, args...)
 }
 
-// InstrumentedRPCClient is an RPC client that tracks
-// Prometheus metrics for each call.
+// InstrumentedRPCClient is an RPC client that tracks Prometheus metrics for each call:
+// per-method request counts, latencies, error codes and response sizes.
 type InstrumentedRPCClient struct {
 	c RPC
-	m *metrics.Metrics
+	m metrics.RPCClientMetricer
 }
 
 // NewInstrumentedRPC creates a new instrumented RPC client.
-func NewInstrumentedRPC(c RPC, m *metrics.Metrics) *InstrumentedRPCClient {
+func NewInstrumentedRPC(c RPC, m metrics.RPCClientMetricer) *InstrumentedRPCClient {
 	return &InstrumentedRPCClient{
 		c: c,
 		m: m,
@@ -198,42 +220,51 @@ func (ic *InstrumentedRPCClient) Close() {
 	ic.c.Close()
 }
 
+// CallContext calls the underlying client with a raw result, so the response size can be
+// recorded, before decoding it into result.
 func (ic *InstrumentedRPCClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
 	return instrument1(ic.m, method, func() error {
-		return ic.c.CallContext(ctx, result, method, args...)
+		var raw json.RawMessage
+		if err := ic.c.CallContext(ctx, &raw, method, args...); err != nil {
+			return err
+		}
+		ic.m.RecordRPCClientResponseSize(method, len(raw))
+		return decodeResult(raw, result)
 	})
 }
 
 func (ic *InstrumentedRPCClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
-	return instrumentBatch(ic.m, func() error {
-		return ic.c.BatchCallContext(ctx, b)
-	}, b)
-}
-
-func (ic *InstrumentedRPCClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
-	return ic.c.EthSubscribe(ctx, channel, args...)
+	raws := make([]json.RawMessage, len(b))
+	rawBatch := make([]rpc.BatchElem, len(b))
+	for i, elem := range b {
+		rawBatch[i] = rpc.BatchElem{Method: elem.Method, Args: elem.Args, Result: &raws[i]}
+	}
+	record := ic.m.RecordRPCClientBatchRequest(b)
+	err := ic.c.BatchCallContext(ctx, rawBatch)
+	if err == nil {
+		size := 0
+		for i := range b {
+			size += len(raws[i])
+			b[i].Error = rawBatch[i].Error
+			if b[i].Error == nil {
+				b[i].Error = decodeResult(raws[i], b[i].Result)
+			}
+		}
+		ic.m.RecordRPCClientResponseSize(metrics.BatchMethod, size)
+	}
+	record(err)
+	return err
 }
 
-// instrumentBatch handles metrics for batch calls. Request metrics are
-// increased for each batch element. Request durations are tracked for
-// the batch as a whole using a special <batch> method. Errors are tracked
-// for each individual batch response, unless the overall request fails in
-// which case the <batch> method is used.
-func instrumentBatch(m *metrics.Metrics, cb func() error, b []rpc.BatchElem) error {
-	m.RPCClientRequestsTotal.WithLabelValues(metrics.BatchMethod).Inc()
-	for _, elem := range b {
-		m.RPCClientRequestsTotal.WithLabelValues(elem.Method).Inc()
+// decodeResult decodes raw into result in the same way as the geth RPC client:
+// a nil result discards the response and a null response leaves result unmodified.
+func decodeResult(raw json.RawMessage, result any) error {
+	if result == nil || len(raw) == 0 {
+		return nil
 	}
-	timer := prometheus.NewTimer(m.RPCClientRequestDurationSeconds.WithLabelValues(metrics.BatchMethod))
-	defer timer.ObserveDuration()
+	return json.Unmarshal(raw, result)
+}
 
-	// Track response times for batch requests separately.
-	if err := cb(); err != nil {
-		m.RecordRPCClientResponse(metrics.BatchMethod, err)
-		return err
-	}
-	for _, elem := range b {
-		m.RecordRPCClientResponse(elem.Method, elem.Error)
-	}
-	return nil
+func (ic *InstrumentedRPCClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return ic.c.EthSubscribe(ctx, channel, args...)
 }
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// rawRPC responds to each method with a fixed raw JSON response.
+type rawRPC struct {
+	responses map[string]string
+	err       error
+}
+
+func (r *rawRPC) Close() {}
+
+func (r *rawRPC) CallContext(_ context.Context, result any, method string, _ ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	return json.Unmarshal([]byte(r.responses[method]), result)
+}
+
+func (r *rawRPC) BatchCallContext(_ context.Context, b []rpc.BatchElem) error {
+	if r.err != nil {
+		return r.err
+	}
+	for i := range b {
+		resp, ok := r.responses[b[i].Method]
+		if !ok {
+			b[i].Error = errors.New("method not found")
+			continue
+		}
+		b[i].Error = json.Unmarshal([]byte(resp), b[i].Result)
+	}
+	return nil
+}
+
+func (r *rawRPC) EthSubscribe(_ context.Context, _ any, _ ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+type recordingRPCMetrics struct {
+	metrics.NoopRPCMetrics
+	sizes map[string]int
+}
+
+func (m *recordingRPCMetrics) RecordRPCClientResponseSize(method string, size int) {
+	m.sizes[method] += size
+}
+
+func TestInstrumentedRPCClient(t *testing.T) {
+	newClient := func(err error) (*InstrumentedRPCClient, *recordingRPCMetrics) {
+		m := &recordingRPCMetrics{sizes: make(map[string]int)}
+		c := NewInstrumentedRPC(&rawRPC{
+			responses: map[string]string{
+				"eth_chainId":     `"0x2a"`,
+				"eth_blockNumber": `"0x100"`,
+			},
+			err: err,
+		}, m)
+		return c, m
+	}
+
+	t.Run("CallDecodesResult", func(t *testing.T) {
+		c, m := newClient(nil)
+		var result string
+		require.NoError(t, c.CallContext(context.Background(), &result, "eth_chainId"))
+		require.Equal(t, "0x2a", result)
+		require.Equal(t, len(`"0x2a"`), m.sizes["eth_chainId"])
+	})
+
+	t.Run("CallWithNilResult", func(t *testing.T) {
+		c, m := newClient(nil)
+		require.NoError(t, c.CallContext(context.Background(), nil, "eth_chainId"))
+		require.Equal(t, len(`"0x2a"`), m.sizes["eth_chainId"])
+	})
+
+	t.Run("CallError", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		c, m := newClient(expectedErr)
+		var result string
+		require.ErrorIs(t, c.CallContext(context.Background(), &result, "eth_chainId"), expectedErr)
+		require.Empty(t, m.sizes)
+	})
+
+	t.Run("BatchDecodesResults", func(t *testing.T) {
+		c, m := newClient(nil)
+		var chainID, blockNum, unknown string
+		batch := []rpc.BatchElem{
+			{Method: "eth_chainId", Result: &chainID},
+			{Method: "eth_blockNumber", Result: &blockNum},
+			{Method: "eth_unknown", Result: &unknown},
+		}
+		require.NoError(t, c.BatchCallContext(context.Background(), batch))
+		require.NoError(t, batch[0].Error)
+		require.NoError(t, batch[1].Error)
+		require.Error(t, batch[2].Error)
+		require.Equal(t, "0x2a", chainID)
+		require.Equal(t, "0x100", blockNum)
+		require.Empty(t, unknown)
+		require.Equal(t, len(`"0x2a"`)+len(`"0x100"`), m.sizes[metrics.BatchMethod])
+	})
+
+	t.Run("BatchError", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		c, m := newClient(expectedErr)
+		var chainID string
+		batch := []rpc.BatchElem{{Method: "eth_chainId", Result: &chainID}}
+		require.ErrorIs(t, c.BatchCallContext(context.Background(), batch), expectedErr)
+		require.Empty(t, m.sizes)
+	})
+}
@@ -43,24 +43,24 @@ type SignerFn func(context.Context, common.Address, *types.Transaction) (*types.
 type SignerFactory func(chainID *big.Int) SignerFn
 
 // SignerFactoryFromConfig considers three ways that signers are created & then creates single factory from those config options.
-// It can either take a remote signer (via opsigner.CLIConfig) or it can be provided either a mnemonic + derivation path or a private key.
+// It can either take a remote signer backend (via opsigner.CLIConfig) or it can be provided either a mnemonic + derivation path or a private key.
 // It prefers the remote signer, then the mnemonic or private key (only one of which can be provided).
 func SignerFactoryFromConfig(l log.Logger, privateKey, mnemonic, hdPath string, signerConfig opsigner.CLIConfig) (SignerFactory, common.Address, error) {
 	var signer SignerFactory
 	var fromAddress common.Address
 	if signerConfig.Enabled() {
-		signerClient, err := opsigner.NewSignerClientFromConfig(l, signerConfig)
+		backend, address, err := opsigner.NewBackendFromConfig(l, signerConfig)
 		if err != nil {
 			l.Error("Unable to create Signer Client", "error", err)
 			return nil, common.Address{}, fmt.Errorf("failed to create the signer client: %w", err)
 		}
-		fromAddress = common.HexToAddress(signerConfig.Address)
+		fromAddress = address
 		signer = func(chainID *big.Int) SignerFn {
 			return func(ctx context.Context, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
 				if !bytes.Equal(address[:], fromAddress[:]) {
-					return nil, fmt.Errorf("attempting to sign for %s, expected %s: ", address, signerConfig.Address)
+					return nil, fmt.Errorf("attempting to sign for %s, expected %s: ", address, fromAddress)
 				}
-				return signerClient.SignTransaction(ctx, chainID, address, tx)
+				return backend.SignTransaction(ctx, chainID, address, tx)
 			}
 		}
 	} else {
@@ -16,6 +16,13 @@ type OutputResponse struct {
 	Status                *SyncStatus `json:"syncStatus"`
 }
 
+// SafeHeadResponse is the L2 safe head that was derived from the L1 chain up to the requested L1 block.
+type SafeHeadResponse struct {
+	// L1Block is the L1 block the safe head was recorded at, at or before the requested L1 block.
+	L1Block  BlockID `json:"l1Block"`
+	SafeHead BlockID `json:"safeHead"`
+}
+
 var (
 	ErrInvalidOutput        = errors.New("invalid output")
 	ErrInvalidOutputVersion = errors.New("invalid output version")
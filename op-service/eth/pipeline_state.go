@@ -0,0 +1,36 @@
+package eth
+
+// DerivationStageState is a snapshot of a single stage of the derivation pipeline.
+type DerivationStageState struct {
+	// Stage is the name of the stage, e.g. "channel_bank".
+	Stage string `json:"stage"`
+	// Origin is the L1 block the stage is currently reading from.
+	Origin L1BlockRef `json:"origin"`
+	// QueueDepth is the number of items buffered by the stage. Zero for stages without a buffer.
+	QueueDepth int `json:"queue_depth"`
+	// Steps counts how often the stage was stepped, and Outputs how often that produced output.
+	Steps   uint64 `json:"steps"`
+	Outputs uint64 `json:"outputs"`
+	// Errors counts the steps that failed with an error other than io.EOF.
+	Errors uint64 `json:"errors"`
+	// LastStepDuration and TotalStepDuration are in nanoseconds,
+	// and include the time spent in the stages this stage pulls from.
+	LastStepDuration  uint64 `json:"last_step_duration"`
+	TotalStepDuration uint64 `json:"total_step_duration"`
+	// LastOutputTime is the unix timestamp of the last output of the stage, zero if there was none yet.
+	LastOutputTime uint64 `json:"last_output_time"`
+	// LastOutputOrigin is the origin of the stage when it last produced output.
+	LastOutputOrigin L1BlockRef `json:"last_output_origin"`
+	// Stalled is true if the origin of the stage advanced by more than a sequencing window since its last output.
+	Stalled bool `json:"stalled"`
+}
+
+// DerivationPipelineState is a snapshot of the internals of the derivation pipeline.
+type DerivationPipelineState struct {
+	// Origin is the L1 block of the inner-most stage of the pipeline.
+	Origin L1BlockRef `json:"origin"`
+	// Resetting is true while the stages of the pipeline are being reset.
+	Resetting bool `json:"resetting"`
+	// Stages are ordered from the outer-most (L1 traversal) to the inner-most (engine queue) stage.
+	Stages []DerivationStageState `json:"stages"`
+}
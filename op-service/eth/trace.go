@@ -0,0 +1,143 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	CallTracer     = "callTracer"
+	PrestateTracer = "prestateTracer"
+)
+
+// CallTracerConfig configures the callTracer of debug_trace* methods.
+type CallTracerConfig struct {
+	// OnlyTopCall skips tracing of the calls made by the top-level call.
+	OnlyTopCall bool `json:"onlyTopCall,omitempty"`
+	// WithLog includes the logs emitted by each call.
+	WithLog bool `json:"withLog,omitempty"`
+}
+
+// PrestateTracerConfig configures the prestateTracer of debug_trace* methods.
+type PrestateTracerConfig struct {
+	// DiffMode returns the state before and after execution, instead of only the state accessed by execution.
+	DiffMode bool `json:"diffMode,omitempty"`
+}
+
+// TraceConfig is the tracing options argument of the debug_trace* methods.
+type TraceConfig struct {
+	Tracer       string `json:"tracer"`
+	TracerConfig any    `json:"tracerConfig,omitempty"`
+	// Timeout overrides the default tracing timeout of the node, e.g. "10s".
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// CallFrame is a call made during execution, as returned by the callTracer.
+type CallFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []CallFrame     `json:"calls,omitempty"`
+	// Logs are only included if CallTracerConfig.WithLog is set.
+	Logs []CallLog `json:"logs,omitempty"`
+}
+
+// CallLog is a log emitted by a call, as returned by the callTracer.
+type CallLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// PrestateAccount is the state of an account, as returned by the prestateTracer.
+// Fields are omitted by the tracer if they are empty, or unchanged in diff mode.
+type PrestateAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// PrestateAccounts is the state of the accounts accessed during execution, as returned by the prestateTracer.
+type PrestateAccounts map[common.Address]PrestateAccount
+
+// PrestateDiff is the state modified by execution, as returned by the prestateTracer in diff mode.
+type PrestateDiff struct {
+	Pre  PrestateAccounts `json:"pre"`
+	Post PrestateAccounts `json:"post"`
+}
+
+// TxTraceResult is the trace of a single transaction, as returned by debug_traceBlockByHash.
+type TxTraceResult[T any] struct {
+	TxHash common.Hash `json:"txHash"`
+	Result T           `json:"result"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// TraceFilterArgs is the filter argument of trace_filter.
+type TraceFilterArgs struct {
+	FromBlock   *hexutil.Uint64  `json:"fromBlock,omitempty"`
+	ToBlock     *hexutil.Uint64  `json:"toBlock,omitempty"`
+	FromAddress []common.Address `json:"fromAddress,omitempty"`
+	ToAddress   []common.Address `json:"toAddress,omitempty"`
+	// After skips the first traces matching the filter, to page through results with Count.
+	After *uint64 `json:"after,omitempty"`
+	Count *uint64 `json:"count,omitempty"`
+}
+
+// LocalizedTrace is an action taken during execution, located within its transaction and block,
+// as returned by the trace_ methods of parity-style tracing APIs.
+type LocalizedTrace struct {
+	// Type is one of "call", "create", "suicide" or "reward", and determines the fields set in Action and Result.
+	Type   string       `json:"type"`
+	Action TraceAction  `json:"action"`
+	Result *TraceResult `json:"result,omitempty"`
+	Error  string       `json:"error,omitempty"`
+
+	// TraceAddress is the path of call indices from the top-level call to this action.
+	TraceAddress []uint64 `json:"traceAddress"`
+	Subtraces    uint64   `json:"subtraces"`
+
+	BlockHash   common.Hash `json:"blockHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+	// TransactionHash and TransactionPosition are nil for block rewards.
+	TransactionHash     *common.Hash `json:"transactionHash,omitempty"`
+	TransactionPosition *uint64      `json:"transactionPosition,omitempty"`
+}
+
+// TraceAction is the action of a LocalizedTrace.
+type TraceAction struct {
+	// Call and create fields
+	CallType string          `json:"callType,omitempty"`
+	From     *common.Address `json:"from,omitempty"`
+	To       *common.Address `json:"to,omitempty"`
+	Gas      *hexutil.Uint64 `json:"gas,omitempty"`
+	Value    *hexutil.Big    `json:"value,omitempty"`
+	Input    hexutil.Bytes   `json:"input,omitempty"`
+	Init     hexutil.Bytes   `json:"init,omitempty"`
+
+	// Self-destruct fields
+	Address       *common.Address `json:"address,omitempty"`
+	RefundAddress *common.Address `json:"refundAddress,omitempty"`
+	Balance       *hexutil.Big    `json:"balance,omitempty"`
+
+	// Reward fields
+	Author     *common.Address `json:"author,omitempty"`
+	RewardType string          `json:"rewardType,omitempty"`
+}
+
+// TraceResult is the result of a successful call or create action of a LocalizedTrace.
+type TraceResult struct {
+	GasUsed hexutil.Uint64 `json:"gasUsed"`
+	Output  hexutil.Bytes  `json:"output,omitempty"`
+	// Address and Code are only set for create actions.
+	Address *common.Address `json:"address,omitempty"`
+	Code    hexutil.Bytes   `json:"code,omitempty"`
+}
@@ -302,6 +302,16 @@ type PayloadAttributes struct {
 	ParentBeaconBlockRoot *common.Hash `json:"parentBeaconBlockRoot,omitempty"`
 }
 
+// PayloadAttributesEvent is emitted when the sequencer starts building a new L2 block.
+type PayloadAttributesEvent struct {
+	// ParentBlock is the L2 block that the new block is built on top of.
+	ParentBlock L2BlockRef `json:"parentBlock"`
+	// L1Origin is the L1 origin of the new block.
+	L1Origin BlockID `json:"l1Origin"`
+	// Attributes the new block is built with, including the deposit transactions forced into it.
+	Attributes *PayloadAttributes `json:"attributes"`
+}
+
 type ExecutePayloadStatus string
 
 const (
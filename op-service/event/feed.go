@@ -0,0 +1,62 @@
+package event
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// NonBlockingFeed sends values to its subscribers without ever blocking the sender.
+// A subscriber whose channel is full when a value is sent is dropped, so a slow subscriber can't delay the sender.
+// The zero value is ready to use.
+type NonBlockingFeed[T any] struct {
+	lock sync.Mutex
+	subs map[*feedSubscriber[T]]struct{}
+}
+
+type feedSubscriber[T any] struct {
+	ch chan<- T
+	// dropped is closed when the subscriber is dropped for not keeping up.
+	dropped chan struct{}
+}
+
+// Send sends v to all subscribers without blocking, and returns the number of subscribers that were dropped
+// because their channel was full.
+func (f *NonBlockingFeed[T]) Send(v T) (dropped int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for sub := range f.subs {
+		select {
+		case sub.ch <- v:
+		default:
+			delete(f.subs, sub)
+			close(sub.dropped)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// Subscribe sends all values that are sent on the feed to ch, until the subscription is unsubscribed.
+// ch should be buffered and drained promptly: if ch is full when a value is sent, the subscriber is dropped
+// and the subscription fails with errSlow.
+func (f *NonBlockingFeed[T]) Subscribe(ch chan<- T, errSlow error) event.Subscription {
+	sub := &feedSubscriber[T]{ch: ch, dropped: make(chan struct{})}
+	f.lock.Lock()
+	if f.subs == nil {
+		f.subs = make(map[*feedSubscriber[T]]struct{})
+	}
+	f.subs[sub] = struct{}{}
+	f.lock.Unlock()
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		select {
+		case <-quit:
+			f.lock.Lock()
+			delete(f.subs, sub)
+			f.lock.Unlock()
+			return nil
+		case <-sub.dropped:
+			return errSlow
+		}
+	})
+}
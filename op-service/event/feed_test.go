@@ -0,0 +1,30 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonBlockingFeed(t *testing.T) {
+	errSlow := errors.New("slow")
+	var feed NonBlockingFeed[int]
+	require.Zero(t, feed.Send(0), "should send without subscribers")
+
+	fast := make(chan int, 2)
+	slow := make(chan int, 1)
+	fastSub := feed.Subscribe(fast, errSlow)
+	slowSub := feed.Subscribe(slow, errSlow)
+
+	require.Zero(t, feed.Send(1))
+	require.Equal(t, 1, feed.Send(2), "should drop the subscriber with a full channel")
+	require.ErrorIs(t, <-slowSub.Err(), errSlow)
+	require.Equal(t, 1, <-fast)
+	require.Equal(t, 2, <-fast)
+	require.Equal(t, 1, <-slow)
+
+	fastSub.Unsubscribe()
+	require.Zero(t, feed.Send(3), "should not send to unsubscribed subscribers")
+	require.Empty(t, fast)
+}
@@ -1,22 +1,25 @@
 package ioutil
 
 import (
+	"encoding/json"
 	"io"
 	"os"
 	"path/filepath"
 )
 
-type atomicWriter struct {
+// AtomicWriter is an io.WriteCloser that performs an atomic write.
+// The contents are initially written to a temporary file and only renamed into place when the writer is closed.
+// If writing fails, Abort must be called instead of Close, so the partially written contents are discarded.
+type AtomicWriter struct {
 	dest string
 	temp string
 	out  io.WriteCloser
 }
 
-// NewAtomicWriterCompressed creates a io.WriteCloser that performs an atomic write.
-// The contents are initially written to a temporary file and only renamed into place when the writer is closed.
+// NewAtomicWriterCompressed creates an AtomicWriter.
 // NOTE: It's vital to check if an error is returned from Close() as it may indicate the file could not be renamed
-// If path ends in .gz the contents written will be gzipped.
-func NewAtomicWriterCompressed(path string, perm os.FileMode) (io.WriteCloser, error) {
+// If path ends in .gz or .zst the contents written will be gzip or zstd compressed respectively.
+func NewAtomicWriterCompressed(path string, perm os.FileMode) (*AtomicWriter, error) {
 	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path))
 	if err != nil {
 		return nil, err
@@ -25,18 +28,30 @@ func NewAtomicWriterCompressed(path string, perm os.FileMode) (io.WriteCloser, e
 		_ = f.Close()
 		return nil, err
 	}
-	return &atomicWriter{
+	out, err := CompressByFileType(path, &syncCloser{f})
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &AtomicWriter{
 		dest: path,
 		temp: f.Name(),
-		out:  CompressByFileType(path, f),
+		out:  out,
 	}, nil
 }
 
-func (a *atomicWriter) Write(p []byte) (n int, err error) {
+func (a *AtomicWriter) Write(p []byte) (n int, err error) {
 	return a.out.Write(p)
 }
 
-func (a *atomicWriter) Close() error {
+// Abort discards the contents written so far and removes the temporary file. The destination file is left unchanged.
+func (a *AtomicWriter) Abort() error {
+	_ = a.out.Close()
+	return os.Remove(a.temp)
+}
+
+// Close renames the written contents into place.
+func (a *AtomicWriter) Close() error {
 	// Attempt to clean up the temp file even if it can't be renamed into place.
 	defer os.Remove(a.temp)
 	if err := a.out.Close(); err != nil {
@@ -44,3 +59,30 @@ func (a *atomicWriter) Close() error {
 	}
 	return os.Rename(a.temp, a.dest)
 }
+
+// WriteAtomicJSON writes obj as JSON to path via an AtomicWriter.
+// If encoding fails the partially written file is discarded and the existing contents of path are left unchanged.
+func WriteAtomicJSON(path string, obj any, perm os.FileMode) error {
+	out, err := NewAtomicWriterCompressed(path, perm)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(out).Encode(obj); err != nil {
+		_ = out.Abort()
+		return err
+	}
+	return out.Close()
+}
+
+// syncCloser flushes the file to disk before closing it, so a rename after Close can't expose an empty file.
+type syncCloser struct {
+	*os.File
+}
+
+func (s *syncCloser) Close() error {
+	if err := s.File.Sync(); err != nil {
+		_ = s.File.Close()
+		return err
+	}
+	return s.File.Close()
+}
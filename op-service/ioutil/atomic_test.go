@@ -84,3 +84,40 @@ func TestAtomicWriter_ApplyGzip(t *testing.T) {
 		})
 	}
 }
+
+func TestAtomicWriter_Abort(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.txt")
+	require.NoError(t, os.WriteFile(target, []byte("previous"), 0o644))
+	f, err := NewAtomicWriterCompressed(target, 0o644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte("partial"))
+	require.NoError(t, err)
+
+	require.NoError(t, f.Abort())
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.Equal(t, "previous", string(data), "should keep the previous file")
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1, "should not leave temporary files behind")
+}
+
+func TestWriteAtomicJSON(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "target.json")
+	require.NoError(t, WriteAtomicJSON(target, map[string]int{"a": 1}, 0o644))
+	data, err := os.ReadFile(target)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a":1}`, string(data))
+
+	t.Run("KeepPreviousOnEncodeError", func(t *testing.T) {
+		require.Error(t, WriteAtomicJSON(target, map[string]any{"a": make(chan int)}, 0o644))
+		data, err := os.ReadFile(target)
+		require.NoError(t, err)
+		require.JSONEq(t, `{"a":1}`, string(data))
+		files, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, files, 1, "should not leave temporary files behind")
+	})
+}
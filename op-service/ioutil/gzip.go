@@ -7,33 +7,50 @@ import (
 	"io"
 	"os"
 	"strings"
+
+	"github.com/klauspost/compress/zstd"
 )
 
-// OpenDecompressed opens a reader for the specified file and automatically gzip decompresses the content
-// if the filename ends with .gz
+// OpenDecompressed opens a reader for the specified file and automatically decompresses the content
+// if the filename ends with .gz (gzip) or .zst (zstd)
 func OpenDecompressed(path string) (io.ReadCloser, error) {
-	var r io.ReadCloser
-	r, err := os.Open(path)
+	f, err := os.Open(path)
 	if err != nil {
 		return nil, err
 	}
-	if IsGzip(path) {
-		r, err = gzip.NewReader(r)
+	switch {
+	case IsGzip(path):
+		r, err := gzip.NewReader(f)
 		if err != nil {
+			_ = f.Close()
 			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
 		}
+		return &compressedReader{r: r, closeFn: r.Close, f: f}, nil
+	case IsZstd(path):
+		r, err := zstd.NewReader(f)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+		return &compressedReader{r: r, closeFn: func() error { r.Close(); return nil }, f: f}, nil
+	default:
+		return f, nil
 	}
-	return r, nil
 }
 
-// OpenCompressed opens a file for writing and automatically compresses the content if the filename ends with .gz
+// OpenCompressed opens a file for writing and automatically compresses the content
+// if the filename ends with .gz (gzip) or .zst (zstd)
 func OpenCompressed(file string, flag int, perm os.FileMode) (io.WriteCloser, error) {
-	var out io.WriteCloser
 	out, err := os.OpenFile(file, flag, perm)
 	if err != nil {
 		return nil, err
 	}
-	return CompressByFileType(file, out), nil
+	w, err := CompressByFileType(file, out)
+	if err != nil {
+		_ = out.Close()
+		return nil, err
+	}
+	return w, nil
 }
 
 // WriteCompressedJson writes the object to the specified file as a compressed json object
@@ -56,9 +73,65 @@ func IsGzip(path string) bool {
 	return strings.HasSuffix(path, ".gz")
 }
 
-func CompressByFileType(file string, out io.WriteCloser) io.WriteCloser {
-	if IsGzip(file) {
-		return gzip.NewWriter(out)
+// IsZstd determines if a path points to a zstd compressed file.
+// Returns true when the file has a .zst extension.
+func IsZstd(path string) bool {
+	return strings.HasSuffix(path, ".zst")
+}
+
+// IsCompressed determines if a path points to a file compressed in any of the supported formats.
+func IsCompressed(path string) bool {
+	return IsGzip(path) || IsZstd(path)
+}
+
+// CompressByFileType wraps out with a compressing writer if the file has a .gz or .zst extension.
+// Closing the returned writer flushes the compressed stream and closes out.
+func CompressByFileType(file string, out io.WriteCloser) (io.WriteCloser, error) {
+	switch {
+	case IsGzip(file):
+		return &compressedWriter{w: gzip.NewWriter(out), out: out}, nil
+	case IsZstd(file):
+		w, err := zstd.NewWriter(out)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd writer: %w", err)
+		}
+		return &compressedWriter{w: w, out: out}, nil
+	default:
+		return out, nil
+	}
+}
+
+type compressedWriter struct {
+	w   io.WriteCloser
+	out io.WriteCloser
+}
+
+func (c *compressedWriter) Write(p []byte) (int, error) {
+	return c.w.Write(p)
+}
+
+func (c *compressedWriter) Close() error {
+	if err := c.w.Close(); err != nil {
+		_ = c.out.Close()
+		return err
+	}
+	return c.out.Close()
+}
+
+type compressedReader struct {
+	r       io.Reader
+	closeFn func() error
+	f       io.Closer
+}
+
+func (c *compressedReader) Read(p []byte) (int, error) {
+	return c.r.Read(p)
+}
+
+func (c *compressedReader) Close() error {
+	if err := c.closeFn(); err != nil {
+		_ = c.f.Close()
+		return err
 	}
-	return out
+	return c.f.Close()
 }
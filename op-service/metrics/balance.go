@@ -28,7 +28,7 @@ func weiToEther(wei *big.Int) float64 {
 // LaunchBalanceMetrics starts a periodic query of the balance of the supplied account and records it
 // to the "balance" metric of the namespace. The balance of the account is recorded in Ether (not Wei).
 // Cancel the supplied context to shut down the go routine
-func LaunchBalanceMetrics(log log.Logger, r *prometheus.Registry, ns string, client *ethclient.Client, account common.Address) *clock.LoopFn {
+func LaunchBalanceMetrics(log log.Logger, r prometheus.Registerer, ns string, client *ethclient.Client, account common.Address) *clock.LoopFn {
 	balanceGuage := promauto.With(r).NewGauge(prometheus.GaugeOpts{
 		Namespace: ns,
 		Name:      "balance",
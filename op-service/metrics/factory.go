@@ -30,8 +30,14 @@ type documentor struct {
 }
 
 func With(registry *prometheus.Registry) Factory {
+	return WithRegisterer(registry)
+}
+
+// WithRegisterer creates a Factory which registers metrics with the given registerer.
+// This allows metrics to be registered with a wrapped registry, e.g. to add constant labels.
+func WithRegisterer(registerer prometheus.Registerer) Factory {
 	return &documentor{
-		factory: promauto.With(registry),
+		factory: promauto.With(registerer),
 	}
 }
 
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// FallbackClientMetricer records which endpoint of a multi-endpoint RPC client is in use.
+type FallbackClientMetricer interface {
+	RecordActiveEndpoint(index int)
+}
+
+// FallbackClientMetrics implements FallbackClientMetricer,
+// implementing reusable metrics for different fallback clients.
+type FallbackClientMetrics struct {
+	ActiveEndpoint   prometheus.Gauge
+	EndpointSwitches prometheus.Counter
+}
+
+// RecordActiveEndpoint meters the index of the endpoint now in use, where 0 is the primary endpoint,
+// and counts the switch to it.
+func (m *FallbackClientMetrics) RecordActiveEndpoint(index int) {
+	m.ActiveEndpoint.Set(float64(index))
+	m.EndpointSwitches.Inc()
+}
+
+func NewFallbackClientMetrics(factory Factory, ns string, name string, displayName string) *FallbackClientMetrics {
+	return &FallbackClientMetrics{
+		ActiveEndpoint: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      name + "_active_endpoint",
+			Help:      "Index of the " + displayName + " RPC endpoint in use, 0 being the primary",
+		}),
+		EndpointSwitches: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      name + "_endpoint_switches_total",
+			Help:      "Number of times the " + displayName + " RPC client switched endpoint",
+		}),
+	}
+}
+
+type NoopFallbackClientMetrics struct{}
+
+func (*NoopFallbackClientMetrics) RecordActiveEndpoint(index int) {}
+
+var _ FallbackClientMetricer = (*NoopFallbackClientMetrics)(nil)
@@ -12,12 +12,22 @@ import (
 const (
 	RPCServerSubsystem = "rpc_server"
 	RPCClientSubsystem = "rpc_client"
+
+	// BatchMethod is the method label used for batch requests as a whole.
+	BatchMethod = "<batch>"
 )
 
 type RPCMetricer interface {
+	RPCClientMetricer
 	RecordRPCServerRequest(method string) func()
+}
+
+// RPCClientMetricer records metrics for requests made by JSON-RPC clients.
+type RPCClientMetricer interface {
 	RecordRPCClientRequest(method string) func(err error)
+	RecordRPCClientBatchRequest(b []rpc.BatchElem) func(err error)
 	RecordRPCClientResponse(method string, err error)
+	RecordRPCClientResponseSize(method string, size int)
 }
 
 // RPCMetrics tracks all the RPC metrics for the op-service RPC.
@@ -27,6 +37,7 @@ type RPCMetrics struct {
 	RPCClientRequestsTotal          *prometheus.CounterVec
 	RPCClientRequestDurationSeconds *prometheus.HistogramVec
 	RPCClientResponsesTotal         *prometheus.CounterVec
+	RPCClientResponseSizeBytes      *prometheus.HistogramVec
 }
 
 // MakeRPCMetrics creates a new RPCMetrics instance with the given process name, and
@@ -76,6 +87,15 @@ func MakeRPCMetrics(ns string, factory Factory) RPCMetrics {
 			"method",
 			"error",
 		}),
+		RPCClientResponseSizeBytes: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "response_size_bytes",
+			Buckets:   prometheus.ExponentialBuckets(64, 4, 10),
+			Help:      "Histogram of RPC client response sizes",
+		}, []string{
+			"method",
+		}),
 	}
 }
 
@@ -102,6 +122,34 @@ func (m *RPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	}
 }
 
+// RecordRPCClientBatchRequest is a helper method to record an RPC client batch
+// request. Request metrics are increased for each batch element. Request durations
+// are tracked for the batch as a whole using the BatchMethod method. Errors are tracked
+// for each individual batch response, unless the overall request fails in which case
+// the BatchMethod method is used.
+func (m *RPCMetrics) RecordRPCClientBatchRequest(b []rpc.BatchElem) func(err error) {
+	m.RPCClientRequestsTotal.WithLabelValues(BatchMethod).Inc()
+	for _, elem := range b {
+		m.RPCClientRequestsTotal.WithLabelValues(elem.Method).Inc()
+	}
+	timer := prometheus.NewTimer(m.RPCClientRequestDurationSeconds.WithLabelValues(BatchMethod))
+	return func(err error) {
+		timer.ObserveDuration()
+		if err != nil {
+			m.RecordRPCClientResponse(BatchMethod, err)
+			return
+		}
+		for _, elem := range b {
+			m.RecordRPCClientResponse(elem.Method, elem.Error)
+		}
+	}
+}
+
+// RecordRPCClientResponseSize records the size in bytes of the result of an RPC response.
+func (m *RPCMetrics) RecordRPCClientResponseSize(method string, size int) {
+	m.RPCClientResponseSizeBytes.WithLabelValues(method).Observe(float64(size))
+}
+
 // RecordRPCClientResponse records an RPC response. It will
 // convert the passed-in error into something metrics friendly.
 // Nil errors get converted into <nil>, RPC errors are converted
@@ -135,7 +183,14 @@ func (n *NoopRPCMetrics) RecordRPCServerRequest(method string) func() {
 func (n *NoopRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	return func(err error) {}
 }
+func (n *NoopRPCMetrics) RecordRPCClientBatchRequest(b []rpc.BatchElem) func(err error) {
+	return func(err error) {}
+}
+
 func (n *NoopRPCMetrics) RecordRPCClientResponse(method string, err error) {
 }
 
+func (n *NoopRPCMetrics) RecordRPCClientResponseSize(method string, size int) {
+}
+
 var _ RPCMetricer = (*NoopRPCMetrics)(nil)
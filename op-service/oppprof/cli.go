@@ -18,6 +18,9 @@ const (
 	PortFlagName        = "pprof.port"
 	ProfileTypeFlagName = "pprof.type"
 	ProfilePathFlagName = "pprof.path"
+	DumpDirFlagName     = "pprof.dump-dir"
+	DumpTokenFlagName   = "pprof.dump-token"
+	HeapDumpFlagName    = "pprof.heap-dump-threshold"
 	defaultListenAddr   = "0.0.0.0"
 	defaultListenPort   = 6060
 )
@@ -94,6 +97,21 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			}(),
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "PPROF_TYPE"),
 		},
+		&cli.StringFlag{
+			Name:    DumpDirFlagName,
+			Usage:   "Directory to capture diagnostic profiles to, on request to " + CapturePath + " or when the heap dump threshold is reached",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "PPROF_DUMP_DIR"),
+		},
+		&cli.StringFlag{
+			Name:    DumpTokenFlagName,
+			Usage:   "Bearer token required to request profile captures from the pprof server. Captures can not be requested if empty",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "PPROF_DUMP_TOKEN"),
+		},
+		&cli.Uint64Flag{
+			Name:    HeapDumpFlagName,
+			Usage:   "Heap size in MiB at which heap and goroutine profiles are captured to the dump dir. 0 to disable",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "PPROF_HEAP_DUMP_THRESHOLD"),
+		},
 	}
 }
 
@@ -105,9 +123,21 @@ type CLIConfig struct {
 	ProfileType     profileType
 	ProfileDir      string
 	ProfileFilename string
+
+	DumpDir   string
+	DumpToken string
+	// HeapDumpThreshold is the heap size in MiB at which profiles are captured to DumpDir. 0 disables it.
+	HeapDumpThreshold uint64
 }
 
 func (m CLIConfig) Check() error {
+	if m.HeapDumpThreshold != 0 && m.DumpDir == "" {
+		return errors.New("pprof dump dir is required for heap dumps")
+	}
+	if m.DumpToken != "" && m.DumpDir == "" {
+		return errors.New("pprof dump dir is required for profile captures")
+	}
+
 	if !m.ListenEnabled {
 		return nil
 	}
@@ -122,11 +152,14 @@ func (m CLIConfig) Check() error {
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	profilePathFlag := ctx.Generic(ProfilePathFlagName).(*flags.PathFlag)
 	return CLIConfig{
-		ListenEnabled:   ctx.Bool(EnabledFlagName),
-		ListenAddr:      ctx.String(ListenAddrFlagName),
-		ListenPort:      ctx.Int(PortFlagName),
-		ProfileType:     profileType(strings.ToLower(ctx.String(ProfileTypeFlagName))),
-		ProfileDir:      profilePathFlag.Dir(),
-		ProfileFilename: profilePathFlag.Filename(),
+		ListenEnabled:     ctx.Bool(EnabledFlagName),
+		ListenAddr:        ctx.String(ListenAddrFlagName),
+		ListenPort:        ctx.Int(PortFlagName),
+		ProfileType:       profileType(strings.ToLower(ctx.String(ProfileTypeFlagName))),
+		ProfileDir:        profilePathFlag.Dir(),
+		ProfileFilename:   profilePathFlag.Filename(),
+		DumpDir:           ctx.String(DumpDirFlagName),
+		DumpToken:         ctx.String(DumpTokenFlagName),
+		HeapDumpThreshold: ctx.Uint64(HeapDumpFlagName),
 	}
 }
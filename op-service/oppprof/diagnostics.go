@@ -0,0 +1,187 @@
+package oppprof
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/metrics"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	// CapturePath is the path of the pprof server endpoint that captures diagnostic profiles to the dump directory.
+	CapturePath = "/debug/diagnostics/capture"
+
+	heapCheckInterval = 15 * time.Second
+	heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+)
+
+// defaultCaptureProfiles are captured when no profile is specified.
+// Note that the block profile is empty unless block profiling is enabled with the block profile type.
+var defaultCaptureProfiles = []string{"heap", "goroutine", "block"}
+
+// diagnostics captures snapshot profiles to a directory, on request or when the heap grows over a threshold.
+type diagnostics struct {
+	dir           string
+	token         string
+	heapThreshold uint64
+
+	clock     clock.Clock
+	heapInUse func() uint64
+
+	// mu serializes captures, so concurrent captures do not write to the same files.
+	mu sync.Mutex
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newDiagnostics(dir string, token string, heapThreshold uint64, cl clock.Clock) *diagnostics {
+	return &diagnostics{
+		dir:           dir,
+		token:         token,
+		heapThreshold: heapThreshold,
+		clock:         cl,
+		heapInUse:     readHeapInUse,
+		done:          make(chan struct{}),
+	}
+}
+
+func (d *diagnostics) start() {
+	if d.heapThreshold == 0 {
+		return
+	}
+	d.wg.Add(1)
+	go d.monitorHeap(d.clock.NewTicker(heapCheckInterval))
+}
+
+func (d *diagnostics) stop() {
+	close(d.done)
+	d.wg.Wait()
+}
+
+// capture writes a snapshot of each of the named profiles to the dump directory,
+// and returns the paths of the written files.
+func (d *diagnostics) capture(reason string, profiles ...string) ([]string, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create dump dir: %w", err)
+	}
+	timestamp := d.clock.Now().UTC().Format("20060102T150405.000Z")
+	paths := make([]string, 0, len(profiles))
+	for _, name := range profiles {
+		profile := pprof.Lookup(name)
+		if profile == nil {
+			return paths, fmt.Errorf("unknown profile %q", name)
+		}
+		if name == "heap" {
+			// Include all allocations up to now, not just those up to the last GC.
+			runtime.GC()
+		}
+		path := filepath.Join(d.dir, fmt.Sprintf("%s-%s.prof", name, timestamp))
+		if err := writeProfile(profile, path); err != nil {
+			return paths, fmt.Errorf("failed to write %s profile: %w", name, err)
+		}
+		paths = append(paths, path)
+	}
+	log.Info("Captured diagnostic profiles", "reason", reason, "files", paths)
+	return paths, nil
+}
+
+func writeProfile(profile *pprof.Profile, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := profile.WriteTo(f, 0); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type captureResponse struct {
+	Files []string `json:"files"`
+}
+
+// ServeHTTP captures the profiles named by the profile query parameters, or the default profiles if there are none.
+// Requests must be POSTs that carry the dump token as a bearer token.
+func (d *diagnostics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+d.token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	profiles := r.URL.Query()["profile"]
+	if len(profiles) == 0 {
+		profiles = defaultCaptureProfiles
+	}
+	for _, name := range profiles {
+		// The CPU profile is collected over time, so it can't be captured as a snapshot.
+		if name == "cpu" || !validProfileType(profileType(name)) {
+			http.Error(w, fmt.Sprintf("unsupported profile %q", name), http.StatusBadRequest)
+			return
+		}
+	}
+	paths, err := d.capture("request", profiles...)
+	if err != nil {
+		log.Error("Failed to capture diagnostic profiles", "err", err)
+		http.Error(w, "failed to capture profiles", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(captureResponse{Files: paths})
+}
+
+// monitorHeap captures heap and goroutine profiles when the heap in use reaches the threshold.
+// Only one capture is made each time the threshold is crossed, so a persistently large heap does not fill the disk.
+func (d *diagnostics) monitorHeap(ticker clock.Ticker) {
+	defer d.wg.Done()
+	defer ticker.Stop()
+	armed := true
+	for {
+		select {
+		case <-d.done:
+			return
+		case <-ticker.Ch():
+			inUse := d.heapInUse()
+			if inUse < d.heapThreshold {
+				armed = true
+				continue
+			}
+			if !armed {
+				continue
+			}
+			armed = false
+			log.Warn("Heap in use exceeds threshold, capturing profiles", "heap", inUse, "threshold", d.heapThreshold)
+			if _, err := d.capture("heap-threshold", "heap", "goroutine"); err != nil {
+				log.Error("Failed to capture diagnostic profiles", "err", err)
+			}
+		}
+	}
+}
+
+// readHeapInUse returns the bytes of heap memory occupied by objects, without stopping the world.
+func readHeapInUse() uint64 {
+	sample := []metrics.Sample{{Name: heapObjectsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
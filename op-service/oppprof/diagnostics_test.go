@@ -0,0 +1,86 @@
+package oppprof
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+func TestDiagnostics_CaptureRequest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "dumps")
+	d := newDiagnostics(dir, "secret", 0, clock.NewDeterministicClock(time.Unix(1000, 0)))
+
+	request := func(method string, target string, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+		rec := httptest.NewRecorder()
+		d.ServeHTTP(rec, req)
+		return rec
+	}
+
+	require.Equal(t, http.StatusMethodNotAllowed, request(http.MethodGet, CapturePath, "Bearer secret").Code)
+	require.Equal(t, http.StatusUnauthorized, request(http.MethodPost, CapturePath, "").Code)
+	require.Equal(t, http.StatusUnauthorized, request(http.MethodPost, CapturePath, "Bearer wrong").Code)
+	require.Equal(t, http.StatusBadRequest, request(http.MethodPost, CapturePath+"?profile=cpu", "Bearer secret").Code)
+	require.Equal(t, http.StatusBadRequest, request(http.MethodPost, CapturePath+"?profile=unknown", "Bearer secret").Code)
+	require.NoDirExists(t, dir, "should not capture rejected requests")
+
+	rec := request(http.MethodPost, CapturePath, "Bearer secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp captureResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Files, len(defaultCaptureProfiles))
+	for _, file := range resp.Files {
+		require.FileExists(t, file)
+		require.Equal(t, dir, filepath.Dir(file))
+	}
+	require.Equal(t, filepath.Join(dir, "heap-19700101T001640.000Z.prof"), resp.Files[0])
+
+	rec = request(http.MethodPost, CapturePath+"?profile=mutex&profile=allocs", "Bearer secret")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Files, 2)
+}
+
+func TestDiagnostics_HeapThreshold(t *testing.T) {
+	dir := t.TempDir()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	d := newDiagnostics(dir, "", 100, cl)
+	heap := make(chan uint64)
+	d.heapInUse = func() uint64 {
+		return <-heap
+	}
+	d.start()
+	defer d.stop()
+
+	// Each check blocks reading the heap size, so the previous check has completed once the next one reads it.
+	check := func(size uint64) {
+		cl.AdvanceTime(heapCheckInterval)
+		heap <- size
+	}
+	countDumps := func() int {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		return len(entries)
+	}
+
+	check(99)
+	check(100)
+	check(150)
+	require.Equal(t, 2, countDumps(), "should capture heap and goroutine profiles once when threshold is reached")
+	check(50)
+	require.Equal(t, 2, countDumps(), "should not capture again while over the threshold")
+	check(120)
+	check(10)
+	require.Equal(t, 4, countDumps(), "should capture again after dropping below the threshold")
+}
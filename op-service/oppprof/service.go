@@ -12,6 +12,7 @@ import (
 	"runtime/pprof"
 	"strconv"
 
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -25,8 +26,13 @@ type Service struct {
 	profileDir      string
 	profileFilename string
 
-	cpuFile    io.Closer
-	httpServer *httputil.HTTPServer
+	dumpDir           string
+	dumpToken         string
+	heapDumpThreshold uint64
+
+	cpuFile     io.Closer
+	httpServer  *httputil.HTTPServer
+	diagnostics *diagnostics
 }
 
 func New(listenEnabled bool, listenAddr string, listenPort int, profType profileType, profileDir, profileFilename string) *Service {
@@ -40,6 +46,15 @@ func New(listenEnabled bool, listenAddr string, listenPort int, profType profile
 	}
 }
 
+// NewFromCLIConfig creates a Service from the CLI config, including its diagnostic profile captures.
+func NewFromCLIConfig(cfg CLIConfig) *Service {
+	s := New(cfg.ListenEnabled, cfg.ListenAddr, cfg.ListenPort, cfg.ProfileType, cfg.ProfileDir, cfg.ProfileFilename)
+	s.dumpDir = cfg.DumpDir
+	s.dumpToken = cfg.DumpToken
+	s.heapDumpThreshold = cfg.HeapDumpThreshold
+	return s
+}
+
 func (s *Service) Start() error {
 	switch s.profileType {
 	case "cpu":
@@ -51,6 +66,10 @@ func (s *Service) Start() error {
 	case "mutex":
 		runtime.SetMutexProfileFraction(1)
 	}
+	if s.dumpDir != "" {
+		s.diagnostics = newDiagnostics(s.dumpDir, s.dumpToken, s.heapDumpThreshold*1024*1024, clock.SystemClock)
+		s.diagnostics.start()
+	}
 	if s.listenEnabled {
 		if err := s.startServer(); err != nil {
 			return err
@@ -63,6 +82,9 @@ func (s *Service) Start() error {
 }
 
 func (s *Service) Stop(ctx context.Context) error {
+	if s.diagnostics != nil {
+		s.diagnostics.stop()
+	}
 	switch s.profileType {
 	case "cpu":
 		pprof.StopCPUProfile()
@@ -106,6 +128,9 @@ func (s *Service) startServer() error {
 	mux.Handle("/debug/pprof/profile", http.HandlerFunc(httpPprof.Profile))
 	mux.Handle("/debug/pprof/symbol", http.HandlerFunc(httpPprof.Symbol))
 	mux.Handle("/debug/pprof/trace", http.HandlerFunc(httpPprof.Trace))
+	if s.diagnostics != nil && s.dumpToken != "" {
+		mux.Handle(CapturePath, s.diagnostics)
+	}
 
 	addr := net.JoinHostPort(s.listenAddr, strconv.Itoa(s.listenPort))
 
@@ -0,0 +1,24 @@
+package rpc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ReadJWTSecret reads a hex encoded 32 byte JWT secret from the file at path.
+func ReadJWTSecret(path string) ([32]byte, error) {
+	var secret [32]byte
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return secret, fmt.Errorf("failed to read jwt secret: %w", err)
+	}
+	jwtSecret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(jwtSecret) != 32 {
+		return secret, fmt.Errorf("invalid jwt secret in path %s, not 32 hex-formatted bytes", path)
+	}
+	copy(secret[:], jwtSecret)
+	return secret, nil
+}
@@ -0,0 +1,27 @@
+package rpc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJWTSecret(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "jwt.txt")
+
+	_, err := ReadJWTSecret(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, os.WriteFile(path, []byte("0xab00cd"+strings.Repeat("00", 29)+"\n"), 0o600))
+	secret, err := ReadJWTSecret(path)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{0xab, 0x00, 0xcd}, secret)
+
+	require.NoError(t, os.WriteFile(path, []byte("0x1234"), 0o600))
+	_, err = ReadJWTSecret(path)
+	require.ErrorContains(t, err, "not 32 hex-formatted bytes")
+}
@@ -0,0 +1,173 @@
+// Package shutdown provides ordered, timeout-bounded graceful shutdown of service subsystems.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrAlreadyStopped = errors.New("already stopped")
+	ErrStopTimeout    = errors.New("timed out stopping subsystem")
+)
+
+// DefaultStopTimeout bounds the time a subsystem may take to stop, if it was registered without WithTimeout.
+// It ensures that a single hung subsystem can't hang the shutdown of the whole service.
+const DefaultStopTimeout = 30 * time.Second
+
+// StopFn stops a subsystem. The ctx may be cancelled to force an accelerated shutdown.
+type StopFn func(ctx context.Context) error
+
+// Closer adapts a Close function that does not return an error to a StopFn.
+func Closer(close func()) StopFn {
+	return func(_ context.Context) error {
+		close()
+		return nil
+	}
+}
+
+// ErrCloser adapts a Close function that returns an error to a StopFn.
+func ErrCloser(close func() error) StopFn {
+	return func(_ context.Context) error {
+		return close()
+	}
+}
+
+type Option func(s *subsystem)
+
+// DependsOn declares that the subsystem uses the named subsystems,
+// so it must be stopped before any of them are stopped.
+// Dependencies that have not been registered, e.g. because they were not initialized, are ignored.
+func DependsOn(names ...string) Option {
+	return func(s *subsystem) {
+		s.dependsOn = append(s.dependsOn, names...)
+	}
+}
+
+// WithTimeout bounds the time the subsystem may take to stop, instead of DefaultStopTimeout.
+// If it has not stopped by then, its ctx is cancelled and it is abandoned,
+// so the subsystems it depends on can still be stopped.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *subsystem) {
+		s.timeout = timeout
+	}
+}
+
+type subsystem struct {
+	name      string
+	stop      StopFn
+	dependsOn []string
+	timeout   time.Duration
+
+	// dependents are the subsystems that must be stopped before this subsystem.
+	dependents []*subsystem
+	done       chan struct{}
+	err        error
+}
+
+// Manager stops the subsystems of a service in dependency order:
+// a subsystem is only stopped after every subsystem that depends on it has stopped.
+// Subsystems that do not depend on each other are stopped concurrently.
+type Manager struct {
+	log log.Logger
+
+	mu         sync.Mutex
+	subsystems []*subsystem
+	byName     map[string]*subsystem
+	stopped    bool
+
+	defaultTimeout time.Duration
+}
+
+func NewManager(log log.Logger) *Manager {
+	return &Manager{
+		log:            log,
+		byName:         make(map[string]*subsystem),
+		defaultTimeout: DefaultStopTimeout,
+	}
+}
+
+// Register adds a subsystem to stop. Subsystems must be registered after the subsystems they depend on.
+func (m *Manager) Register(name string, stop StopFn, opts ...Option) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byName[name]; ok {
+		panic(fmt.Errorf("subsystem %q registered twice", name))
+	}
+	s := &subsystem{name: name, stop: stop, timeout: m.defaultTimeout, done: make(chan struct{})}
+	for _, opt := range opts {
+		opt(s)
+	}
+	for _, dep := range s.dependsOn {
+		if d, ok := m.byName[dep]; ok {
+			d.dependents = append(d.dependents, s)
+		}
+	}
+	m.subsystems = append(m.subsystems, s)
+	m.byName[name] = s
+}
+
+// Stop stops all registered subsystems and returns the joined errors of the subsystems that failed to stop.
+// Every subsystem is stopped, even if others fail to. Subsystems that don't stop within their timeout are
+// abandoned and reported with ErrStopTimeout, so Stop returns even if a subsystem hangs.
+// A Manager can only be stopped once.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return ErrAlreadyStopped
+	}
+	m.stopped = true
+	subsystems := m.subsystems
+	m.mu.Unlock()
+
+	for _, s := range subsystems {
+		go m.stopSubsystem(ctx, s)
+	}
+	// Collect errors in reverse registration order, the order a sequential shutdown would stop them in.
+	var result error
+	var abandoned []string
+	for i := len(subsystems) - 1; i >= 0; i-- {
+		s := subsystems[i]
+		<-s.done
+		if s.err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop %s: %w", s.name, s.err))
+		}
+		if errors.Is(s.err, ErrStopTimeout) {
+			abandoned = append(abandoned, s.name)
+		}
+	}
+	if len(abandoned) > 0 {
+		m.log.Error("Stopped with subsystems still running", "subsystems", abandoned)
+	}
+	return result
+}
+
+func (m *Manager) stopSubsystem(ctx context.Context, s *subsystem) {
+	defer close(s.done)
+	for _, d := range s.dependents {
+		<-d.done
+	}
+	m.log.Debug("Stopping subsystem", "subsystem", s.name)
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	result := make(chan error, 1)
+	go func() {
+		result <- s.stop(ctx)
+	}()
+	// Abandon the subsystem once the timeout passes, even if ctx was already cancelled,
+	// to give it a chance to stop quickly first.
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+	select {
+	case s.err = <-result:
+	case <-timer.C:
+		m.log.Error("Subsystem did not stop in time, abandoning it", "subsystem", s.name, "timeout", s.timeout)
+		s.err = ErrStopTimeout
+	}
+}
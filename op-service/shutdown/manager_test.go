@@ -0,0 +1,146 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stopRecorder struct {
+	mu    sync.Mutex
+	order []string
+}
+
+func (r *stopRecorder) stop(name string, err error) StopFn {
+	return func(_ context.Context) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.order = append(r.order, name)
+		return err
+	}
+}
+
+func (r *stopRecorder) indexOf(t *testing.T, name string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, n := range r.order {
+		if n == name {
+			return i
+		}
+	}
+	t.Fatalf("subsystem %s was not stopped", name)
+	return -1
+}
+
+func TestManager_DependencyOrder(t *testing.T) {
+	m := NewManager(testlog.Logger(t, log.LvlInfo))
+	r := &stopRecorder{}
+	m.Register("metrics", r.stop("metrics", nil))
+	m.Register("l1", r.stop("l1", nil))
+	m.Register("txmgr", r.stop("txmgr", nil), DependsOn("l1", "metrics"))
+	m.Register("driver", r.stop("driver", nil), DependsOn("txmgr", "l1", "metrics"))
+	m.Register("rpc", r.stop("rpc", nil), DependsOn("driver"))
+	m.Register("pprof", r.stop("pprof", nil))
+
+	require.NoError(t, m.Stop(context.Background()))
+	require.Len(t, r.order, 6)
+	require.Less(t, r.indexOf(t, "rpc"), r.indexOf(t, "driver"))
+	require.Less(t, r.indexOf(t, "driver"), r.indexOf(t, "txmgr"))
+	require.Less(t, r.indexOf(t, "txmgr"), r.indexOf(t, "l1"))
+	require.Less(t, r.indexOf(t, "txmgr"), r.indexOf(t, "metrics"))
+	r.indexOf(t, "pprof")
+}
+
+func TestManager_IgnoreUnregisteredDependencies(t *testing.T) {
+	m := NewManager(testlog.Logger(t, log.LvlInfo))
+	r := &stopRecorder{}
+	m.Register("driver", r.stop("driver", nil), DependsOn("disabled"))
+	require.NoError(t, m.Stop(context.Background()))
+	require.Equal(t, []string{"driver"}, r.order)
+}
+
+func TestManager_StopAllDespiteErrors(t *testing.T) {
+	m := NewManager(testlog.Logger(t, log.LvlInfo))
+	r := &stopRecorder{}
+	errA := errors.New("a failed")
+	errB := errors.New("b failed")
+	m.Register("a", r.stop("a", errA))
+	m.Register("b", r.stop("b", errB), DependsOn("a"))
+	m.Register("c", r.stop("c", nil), DependsOn("b"))
+
+	err := m.Stop(context.Background())
+	require.ErrorIs(t, err, errA)
+	require.ErrorIs(t, err, errB)
+	require.Equal(t, []string{"c", "b", "a"}, r.order)
+}
+
+func TestManager_Timeout(t *testing.T) {
+	m := NewManager(testlog.Logger(t, log.LvlCrit))
+	r := &stopRecorder{}
+	release := make(chan struct{})
+	defer close(release)
+	m.Register("dep", r.stop("dep", nil))
+	m.Register("stuck", func(ctx context.Context) error {
+		<-release
+		return nil
+	}, DependsOn("dep"), WithTimeout(10*time.Millisecond))
+
+	err := m.Stop(context.Background())
+	require.ErrorIs(t, err, ErrStopTimeout)
+	// The dependency is still stopped once the stuck subsystem is abandoned.
+	require.Equal(t, []string{"dep"}, r.order)
+}
+
+func TestManager_DefaultTimeout(t *testing.T) {
+	m := NewManager(testlog.Logger(t, log.LvlCrit))
+	m.defaultTimeout = 10 * time.Millisecond
+	release := make(chan struct{})
+	defer close(release)
+	m.Register("stuck", Closer(func() {
+		<-release
+	}))
+	m.Register("other", Closer(func() {}))
+
+	// A hung subsystem without an explicit timeout doesn't hang the shutdown.
+	err := m.Stop(context.Background())
+	require.ErrorIs(t, err, ErrStopTimeout)
+	require.ErrorContains(t, err, "stuck")
+	require.NotContains(t, err.Error(), "other")
+}
+
+func TestManager_TimeoutCancelsContext(t *testing.T) {
+	m := NewManager(testlog.Logger(t, log.LvlInfo))
+	m.Register("graceful", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}, WithTimeout(time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// A forced shutdown still lets the subsystem stop, without waiting for the timeout.
+	require.NoError(t, m.Stop(ctx))
+}
+
+func TestManager_StopOnce(t *testing.T) {
+	m := NewManager(testlog.Logger(t, log.LvlInfo))
+	r := &stopRecorder{}
+	m.Register("a", r.stop("a", nil))
+	require.NoError(t, m.Stop(context.Background()))
+	require.ErrorIs(t, m.Stop(context.Background()), ErrAlreadyStopped)
+	require.Equal(t, []string{"a"}, r.order)
+}
+
+func TestManager_RegisterTwice(t *testing.T) {
+	m := NewManager(testlog.Logger(t, log.LvlInfo))
+	m.Register("a", Closer(func() {}))
+	require.Panics(t, func() {
+		m.Register("a", Closer(func() {}))
+	})
+}
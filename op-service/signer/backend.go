@@ -0,0 +1,103 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Backend signs transactions on behalf of a single address.
+// Keys held in an HSM are supported via signing services that expose a remote or web3signer compatible API.
+type Backend interface {
+	SignTransaction(ctx context.Context, chainId *big.Int, from common.Address, tx *types.Transaction) (*types.Transaction, error)
+	// CheckHealth returns an error if the backend is not currently able to sign.
+	CheckHealth(ctx context.Context) error
+}
+
+// FailoverBackend signs with the first backend that succeeds, trying each backend in order.
+type FailoverBackend struct {
+	logger   log.Logger
+	backends []Backend
+}
+
+var _ Backend = (*FailoverBackend)(nil)
+
+func NewFailoverBackend(logger log.Logger, backends ...Backend) *FailoverBackend {
+	return &FailoverBackend{logger: logger, backends: backends}
+}
+
+func (f *FailoverBackend) SignTransaction(ctx context.Context, chainId *big.Int, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	var errs []error
+	for i, backend := range f.backends {
+		signed, err := backend.SignTransaction(ctx, chainId, from, tx)
+		if err == nil {
+			return signed, nil
+		}
+		f.logger.Warn("Signer failed to sign transaction", "signer", i, "tx", tx.Hash(), "err", err)
+		errs = append(errs, fmt.Errorf("signer %v: %w", i, err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+// CheckHealth returns nil if any of the backends is healthy.
+func (f *FailoverBackend) CheckHealth(ctx context.Context) error {
+	var errs []error
+	for i, backend := range f.backends {
+		err := backend.CheckHealth(ctx)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("signer %v: %w", i, err))
+	}
+	return errors.Join(errs...)
+}
+
+// NewBackendFromConfig creates the signing backend described by config and returns it along with the address it signs for.
+// Remote and web3signer backends fail over to each of the fallback endpoints in turn.
+func NewBackendFromConfig(logger log.Logger, config CLIConfig) (Backend, common.Address, error) {
+	switch config.signerType() {
+	case TypeKMS:
+		backend, err := NewKMSBackendFromConfig(logger, config)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		return backend, backend.Address(), nil
+	case TypeRemote, TypeWeb3Signer:
+		newClient := NewSignerClient
+		if config.signerType() == TypeWeb3Signer {
+			newClient = NewWeb3SignerClient
+		}
+		endpoints := append([]string{config.Endpoint}, config.FallbackEndpoints...)
+		backends := make([]Backend, 0, len(endpoints))
+		for _, endpoint := range endpoints {
+			client, err := newClient(logger, endpoint, config.TLSConfig)
+			if err != nil {
+				// Unavailable endpoints are tolerated when failover is configured so one outage doesn't prevent startup.
+				if len(endpoints) > 1 {
+					logger.Warn("Failed to connect to signer", "endpoint", endpoint, "err", err)
+					continue
+				}
+				return nil, common.Address{}, err
+			}
+			backends = append(backends, client)
+		}
+		if len(backends) == 0 {
+			return nil, common.Address{}, errors.New("failed to connect to any signer endpoint")
+		}
+		address := common.HexToAddress(config.Address)
+		if len(backends) == 1 {
+			return backends[0], address, nil
+		}
+		return NewFailoverBackend(logger, backends...), address, nil
+	default:
+		return nil, common.Address{}, fmt.Errorf("unknown signer type: %q", config.Type)
+	}
+}
@@ -0,0 +1,72 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type stubBackend struct {
+	signErr   error
+	healthErr error
+	signed    int
+}
+
+func (s *stubBackend) SignTransaction(_ context.Context, _ *big.Int, _ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	if s.signErr != nil {
+		return nil, s.signErr
+	}
+	s.signed++
+	return tx, nil
+}
+
+func (s *stubBackend) CheckHealth(_ context.Context) error {
+	return s.healthErr
+}
+
+func TestFailoverBackend(t *testing.T) {
+	tx := types.NewTx(&types.DynamicFeeTx{Nonce: 1})
+	chainID := big.NewInt(10)
+	from := common.Address{0xaa}
+
+	t.Run("UsesFirstBackend", func(t *testing.T) {
+		first, second := &stubBackend{}, &stubBackend{}
+		backend := NewFailoverBackend(testlog.Logger(t, log.LvlInfo), first, second)
+		_, err := backend.SignTransaction(context.Background(), chainID, from, tx)
+		require.NoError(t, err)
+		require.Equal(t, 1, first.signed)
+		require.Equal(t, 0, second.signed)
+	})
+
+	t.Run("FailsOverOnError", func(t *testing.T) {
+		first, second := &stubBackend{signErr: errors.New("boom")}, &stubBackend{}
+		backend := NewFailoverBackend(testlog.Logger(t, log.LvlInfo), first, second)
+		_, err := backend.SignTransaction(context.Background(), chainID, from, tx)
+		require.NoError(t, err)
+		require.Equal(t, 1, second.signed)
+	})
+
+	t.Run("AllFail", func(t *testing.T) {
+		err1, err2 := errors.New("boom1"), errors.New("boom2")
+		backend := NewFailoverBackend(testlog.Logger(t, log.LvlInfo), &stubBackend{signErr: err1}, &stubBackend{signErr: err2})
+		_, err := backend.SignTransaction(context.Background(), chainID, from, tx)
+		require.ErrorIs(t, err, err1)
+		require.ErrorIs(t, err, err2)
+	})
+
+	t.Run("HealthyIfAnyHealthy", func(t *testing.T) {
+		unhealthy := errors.New("unhealthy")
+		backend := NewFailoverBackend(testlog.Logger(t, log.LvlInfo), &stubBackend{healthErr: unhealthy}, &stubBackend{})
+		require.NoError(t, backend.CheckHealth(context.Background()))
+
+		backend = NewFailoverBackend(testlog.Logger(t, log.LvlInfo), &stubBackend{healthErr: unhealthy}, &stubBackend{healthErr: unhealthy})
+		require.ErrorIs(t, backend.CheckHealth(context.Background()), unhealthy)
+	})
+}
@@ -0,0 +1,42 @@
+package signer
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlockPayloadArgs represents the arguments to sign a gossiped L2 block payload.
+// The signer computes the signing hash from the domain, chain ID and payload hash,
+// so a signature for one chain or domain can not be replayed on another.
+type BlockPayloadArgs struct {
+	Domain        common.Hash     `json:"domain"`
+	ChainID       *hexutil.Big    `json:"chainId"`
+	PayloadHash   common.Hash     `json:"payloadHash"`
+	SenderAddress *common.Address `json:"senderAddress"`
+}
+
+// NewBlockPayloadArgs creates a BlockPayloadArgs struct for the keccak256 hash of an encoded payload.
+func NewBlockPayloadArgs(domain [32]byte, chainID *big.Int, payloadHash common.Hash, sender *common.Address) *BlockPayloadArgs {
+	return &BlockPayloadArgs{
+		Domain:        domain,
+		ChainID:       (*hexutil.Big)(chainID),
+		PayloadHash:   payloadHash,
+		SenderAddress: sender,
+	}
+}
+
+func (args *BlockPayloadArgs) Check() error {
+	if args.ChainID == nil {
+		return errors.New("chain ID not specified")
+	}
+	if args.ChainID.ToInt().BitLen() > 256 {
+		return errors.New("chain ID is too large")
+	}
+	if args.PayloadHash == (common.Hash{}) {
+		return errors.New("payload hash not specified")
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package signer
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/urfave/cli/v2"
 
@@ -10,36 +11,93 @@ import (
 )
 
 const (
-	EndpointFlagName = "signer.endpoint"
-	AddressFlagName  = "signer.address"
+	TypeFlagName              = "signer.type"
+	EndpointFlagName          = "signer.endpoint"
+	FallbackEndpointsFlagName = "signer.fallback-endpoints"
+	AddressFlagName           = "signer.address"
+	KMSKeyIDFlagName          = "signer.kms.key-id"
+	KMSRegionFlagName         = "signer.kms.region"
 )
 
+// Type identifies the signing service backing a remote signer.
+type Type string
+
+const (
+	// TypeRemote is an op-signer compatible remote signer.
+	TypeRemote Type = "remote"
+	// TypeWeb3Signer is a web3signer compatible remote signer.
+	TypeWeb3Signer Type = "web3signer"
+	// TypeKMS signs with an AWS KMS secp256k1 key.
+	TypeKMS Type = "kms"
+)
+
+var Types = []Type{TypeRemote, TypeWeb3Signer, TypeKMS}
+
+func (t Type) String() string {
+	return string(t)
+}
+
+func ValidType(value Type) bool {
+	for _, t := range Types {
+		if t == value {
+			return true
+		}
+	}
+	return false
+}
+
 func CLIFlags(envPrefix string) []cli.Flag {
 	envPrefix += "_SIGNER"
 	flags := []cli.Flag{
+		&cli.StringFlag{
+			Name:    TypeFlagName,
+			Usage:   fmt.Sprintf("Type of signer to use. Valid options: %v", Types),
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "TYPE"),
+			Value:   TypeRemote.String(),
+		},
 		&cli.StringFlag{
 			Name:    EndpointFlagName,
-			Usage:   "Signer endpoint the client will connect to",
+			Usage:   "Signer endpoint the client will connect to. For kms signers, overrides the regional KMS endpoint",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "ENDPOINT"),
 		},
+		&cli.StringSliceFlag{
+			Name:    FallbackEndpointsFlagName,
+			Usage:   "Additional signer endpoints of the same type, used in order when the primary signer fails",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "FALLBACK_ENDPOINTS"),
+		},
 		&cli.StringFlag{
 			Name:    AddressFlagName,
 			Usage:   "Address the signer is signing transactions for",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "ADDRESS"),
 		},
+		&cli.StringFlag{
+			Name:    KMSKeyIDFlagName,
+			Usage:   "ID or ARN of the AWS KMS key to sign with. Only used by kms signers",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_KEY_ID"),
+		},
+		&cli.StringFlag{
+			Name:    KMSRegionFlagName,
+			Usage:   "AWS region of the KMS key. Only used by kms signers",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "KMS_REGION"),
+		},
 	}
 	flags = append(flags, optls.CLIFlagsWithFlagPrefix(envPrefix, "signer")...)
 	return flags
 }
 
 type CLIConfig struct {
-	Endpoint  string
-	Address   string
-	TLSConfig optls.CLIConfig
+	Type              Type
+	Endpoint          string
+	FallbackEndpoints []string
+	Address           string
+	KMSKeyID          string
+	KMSRegion         string
+	TLSConfig         optls.CLIConfig
 }
 
 func NewCLIConfig() CLIConfig {
 	return CLIConfig{
+		Type:      TypeRemote,
 		TLSConfig: optls.NewCLIConfig(),
 	}
 }
@@ -48,13 +106,42 @@ func (c CLIConfig) Check() error {
 	if err := c.TLSConfig.Check(); err != nil {
 		return err
 	}
+	if !ValidType(c.signerType()) {
+		return fmt.Errorf("unknown signer type: %q", c.Type)
+	}
+	if c.signerType() == TypeKMS {
+		if (c.KMSKeyID == "") != (c.KMSRegion == "") {
+			return errors.New("signer kms key id and region must both be set or not set")
+		}
+		if c.KMSKeyID == "" && (c.Endpoint != "" || c.Address != "") {
+			return errors.New("signer kms key id must be set when using a kms signer")
+		}
+		if len(c.FallbackEndpoints) > 0 {
+			return errors.New("signer fallback endpoints are not supported by kms signers")
+		}
+		return nil
+	}
 	if !((c.Endpoint == "" && c.Address == "") || (c.Endpoint != "" && c.Address != "")) {
 		return errors.New("signer endpoint and address must both be set or not set")
 	}
+	if c.Endpoint == "" && len(c.FallbackEndpoints) > 0 {
+		return errors.New("signer fallback endpoints require a primary signer endpoint")
+	}
 	return nil
 }
 
+// signerType returns the configured signer type, defaulting to TypeRemote when unset.
+func (c CLIConfig) signerType() Type {
+	if c.Type == "" {
+		return TypeRemote
+	}
+	return c.Type
+}
+
 func (c CLIConfig) Enabled() bool {
+	if c.signerType() == TypeKMS {
+		return c.KMSKeyID != ""
+	}
 	if c.Endpoint != "" && c.Address != "" {
 		return true
 	}
@@ -63,9 +150,13 @@ func (c CLIConfig) Enabled() bool {
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	cfg := CLIConfig{
-		Endpoint:  ctx.String(EndpointFlagName),
-		Address:   ctx.String(AddressFlagName),
-		TLSConfig: optls.ReadCLIConfigWithPrefix(ctx, "signer"),
+		Type:              Type(ctx.String(TypeFlagName)),
+		Endpoint:          ctx.String(EndpointFlagName),
+		FallbackEndpoints: ctx.StringSlice(FallbackEndpointsFlagName),
+		Address:           ctx.String(AddressFlagName),
+		KMSKeyID:          ctx.String(KMSKeyIDFlagName),
+		KMSRegion:         ctx.String(KMSRegionFlagName),
+		TLSConfig:         optls.ReadCLIConfigWithPrefix(ctx, "signer"),
 	}
 	return cfg
 }
@@ -18,6 +18,23 @@ func TestDefaultConfigIsValid(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestReadSignerConfig(t *testing.T) {
+	cfg := configForArgs("--signer.type", "web3signer",
+		"--signer.endpoint", "http://a", "--signer.fallback-endpoints", "http://b,http://c", "--signer.address", "0x1234")
+	require.Equal(t, TypeWeb3Signer, cfg.Type)
+	require.Equal(t, "http://a", cfg.Endpoint)
+	require.Equal(t, []string{"http://b", "http://c"}, cfg.FallbackEndpoints)
+	require.NoError(t, cfg.Check())
+	require.True(t, cfg.Enabled())
+}
+
+func TestKMSConfig(t *testing.T) {
+	cfg := configForArgs("--signer.type", "kms", "--signer.kms.key-id", "key", "--signer.kms.region", "us-east-1")
+	require.Equal(t, TypeKMS, cfg.Type)
+	require.NoError(t, cfg.Check())
+	require.True(t, cfg.Enabled())
+}
+
 func TestInvalidConfig(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -38,6 +55,46 @@ func TestInvalidConfig(t *testing.T) {
 				config.Endpoint = "http://localhost"
 			},
 		},
+		{
+			name:     "UnknownType",
+			expected: "unknown signer type",
+			configChange: func(config *CLIConfig) {
+				config.Type = "foo"
+			},
+		},
+		{
+			name:     "FallbackWithoutEndpoint",
+			expected: "signer fallback endpoints require a primary signer endpoint",
+			configChange: func(config *CLIConfig) {
+				config.FallbackEndpoints = []string{"http://localhost"}
+			},
+		},
+		{
+			name:     "KMSMissingRegion",
+			expected: "signer kms key id and region must both be set or not set",
+			configChange: func(config *CLIConfig) {
+				config.Type = TypeKMS
+				config.KMSKeyID = "key"
+			},
+		},
+		{
+			name:     "KMSMissingKeyID",
+			expected: "signer kms key id must be set when using a kms signer",
+			configChange: func(config *CLIConfig) {
+				config.Type = TypeKMS
+				config.Address = "0x1234"
+			},
+		},
+		{
+			name:     "KMSWithFallback",
+			expected: "signer fallback endpoints are not supported by kms signers",
+			configChange: func(config *CLIConfig) {
+				config.Type = TypeKMS
+				config.KMSKeyID = "key"
+				config.KMSRegion = "us-east-1"
+				config.FallbackEndpoints = []string{"http://localhost"}
+			},
+		},
 		{
 			name:     "InvalidTLSConfig",
 			expected: "all tls flags must be set if at least one is set",
@@ -65,6 +122,6 @@ func configForArgs(args ...string) CLIConfig {
 		config = ReadCLIConfig(ctx)
 		return nil
 	}
-	_ = app.Run(args)
+	_ = app.Run(append([]string{"test"}, args...))
 	return config
 }
@@ -5,9 +5,12 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"io"
 	"math/big"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	optls "github.com/ethereum-optimism/optimism/op-service/tls"
@@ -20,12 +23,71 @@ import (
 )
 
 type SignerClient struct {
-	client *rpc.Client
-	status string
-	logger log.Logger
+	client      *rpc.Client
+	status      string
+	logger      log.Logger
+	checkHealth func(ctx context.Context) (string, error)
 }
 
+var _ Backend = (*SignerClient)(nil)
+
 func NewSignerClient(logger log.Logger, endpoint string, tlsConfig optls.CLIConfig) (*SignerClient, error) {
+	httpClient, err := newHTTPClient(logger, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	rpcClient, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+
+	signer := &SignerClient{logger: logger, client: rpcClient}
+	signer.checkHealth = signer.pingVersion
+	if err := signer.init(); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// NewWeb3SignerClient creates a client for a web3signer compatible signing service.
+// Transactions are signed with eth_signTransaction and health is checked with the upcheck endpoint.
+func NewWeb3SignerClient(logger log.Logger, endpoint string, tlsConfig optls.CLIConfig) (*SignerClient, error) {
+	httpClient, err := newHTTPClient(logger, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	rpcClient, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, err
+	}
+	upcheckURL, err := url.JoinPath(endpoint, "upcheck")
+	if err != nil {
+		return nil, fmt.Errorf("invalid web3signer endpoint: %w", err)
+	}
+
+	signer := &SignerClient{logger: logger, client: rpcClient}
+	signer.checkHealth = func(ctx context.Context) (string, error) {
+		return upcheck(ctx, httpClient, upcheckURL)
+	}
+	if err := signer.init(); err != nil {
+		return nil, err
+	}
+	return signer, nil
+}
+
+// init checks the signer is reachable and records its status.
+func (s *SignerClient) init() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+	version, err := s.checkHealth(ctx)
+	if err != nil {
+		return err
+	}
+	s.status = fmt.Sprintf("ok [version=%v]", version)
+	return nil
+}
+
+func newHTTPClient(logger log.Logger, tlsConfig optls.CLIConfig) (*http.Client, error) {
 	var httpClient *http.Client
 	if tlsConfig.TLSCaCert != "" {
 		logger.Info("tlsConfig specified, loading tls config")
@@ -62,36 +124,48 @@ func NewSignerClient(logger log.Logger, endpoint string, tlsConfig optls.CLIConf
 		logger.Info("no tlsConfig specified, using default http client")
 		httpClient = http.DefaultClient
 	}
-
-	rpcClient, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPClient(httpClient))
-	if err != nil {
-		return nil, err
-	}
-
-	signer := &SignerClient{logger: logger, client: rpcClient}
-	// Check if reachable
-	version, err := signer.pingVersion()
-	if err != nil {
-		return nil, err
-	}
-	signer.status = fmt.Sprintf("ok [version=%v]", version)
-	return signer, nil
+	return httpClient, nil
 }
 
 func NewSignerClientFromConfig(logger log.Logger, config CLIConfig) (*SignerClient, error) {
 	return NewSignerClient(logger, config.Endpoint, config.TLSConfig)
 }
 
-func (s *SignerClient) pingVersion() (string, error) {
+func (s *SignerClient) pingVersion(ctx context.Context) (string, error) {
 	var v string
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
-	defer cancel()
 	if err := s.client.CallContext(ctx, &v, "health_status"); err != nil {
 		return "", err
 	}
 	return v, nil
 }
 
+// upcheck queries the web3signer upcheck endpoint, which responds with OK when the signer is available.
+func upcheck(ctx context.Context, client *http.Client, upcheckURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, upcheckURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("upcheck failed with status %v: %s", resp.StatusCode, body)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+// CheckHealth returns an error if the signer is not available.
+func (s *SignerClient) CheckHealth(ctx context.Context) error {
+	_, err := s.checkHealth(ctx)
+	return err
+}
+
 func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
 	sidecar := tx.BlobTxSidecar()
 	args := NewTransactionArgsFromTransaction(chainId, &from, tx.WithoutBlobTxSidecar())
@@ -113,3 +187,18 @@ func (s *SignerClient) SignTransaction(ctx context.Context, chainId *big.Int, fr
 
 	return &signed, nil
 }
+
+// SignBlockPayload requests a signature for a gossiped L2 block payload with opsigner_signBlockPayload.
+func (s *SignerClient) SignBlockPayload(ctx context.Context, args *BlockPayloadArgs) ([65]byte, error) {
+	if err := args.Check(); err != nil {
+		return [65]byte{}, fmt.Errorf("invalid block payload args: %w", err)
+	}
+	var result hexutil.Bytes
+	if err := s.client.CallContext(ctx, &result, "opsigner_signBlockPayload", args); err != nil {
+		return [65]byte{}, fmt.Errorf("opsigner_signBlockPayload failed: %w", err)
+	}
+	if len(result) != 65 {
+		return [65]byte{}, fmt.Errorf("invalid signature length %d, expected 65", len(result))
+	}
+	return [65]byte(result), nil
+}
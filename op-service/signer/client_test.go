@@ -0,0 +1,84 @@
+package signer
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeb3SignerUpcheck(t *testing.T) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/upcheck" {
+			http.NotFound(w, r)
+			return
+		}
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("OK"))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewWeb3SignerClient(testlog.Logger(t, log.LvlInfo), server.URL, optls.CLIConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "ok [version=OK]", client.status)
+	require.NoError(t, client.CheckHealth(context.Background()))
+
+	healthy = false
+	require.ErrorContains(t, client.CheckHealth(context.Background()), "upcheck failed with status 503")
+}
+
+type healthAPI struct{}
+
+func (healthAPI) Status() string {
+	return "v1.0.0"
+}
+
+type blockPayloadAPI struct {
+	args *BlockPayloadArgs
+	sig  hexutil.Bytes
+}
+
+func (b *blockPayloadAPI) SignBlockPayload(args BlockPayloadArgs) (hexutil.Bytes, error) {
+	b.args = &args
+	return b.sig, nil
+}
+
+func TestSignBlockPayload(t *testing.T) {
+	api := &blockPayloadAPI{sig: make(hexutil.Bytes, 65)}
+	api.sig[0] = 0xaa
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("health", healthAPI{}))
+	require.NoError(t, srv.RegisterName("opsigner", api))
+	server := httptest.NewServer(srv)
+	t.Cleanup(server.Close)
+
+	client, err := NewSignerClient(testlog.Logger(t, log.LvlInfo), server.URL, optls.CLIConfig{})
+	require.NoError(t, err)
+
+	sender := common.Address{0x01}
+	args := NewBlockPayloadArgs([32]byte{0x02}, big.NewInt(100), common.Hash{0x03}, &sender)
+	sig, err := client.SignBlockPayload(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, [65]byte(api.sig), sig)
+	require.Equal(t, args, api.args)
+
+	api.sig = api.sig[:64]
+	_, err = client.SignBlockPayload(context.Background(), args)
+	require.ErrorContains(t, err, "invalid signature length 64")
+
+	_, err = client.SignBlockPayload(context.Background(), NewBlockPayloadArgs([32]byte{}, nil, common.Hash{0x03}, &sender))
+	require.ErrorContains(t, err, "chain ID not specified")
+}
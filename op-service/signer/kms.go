@@ -0,0 +1,168 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// KMSClient is the subset of the AWS KMS API used by KMSBackend.
+type KMSClient interface {
+	GetPublicKey(ctx context.Context, params *kms.GetPublicKeyInput, optFns ...func(*kms.Options)) (*kms.GetPublicKeyOutput, error)
+	Sign(ctx context.Context, params *kms.SignInput, optFns ...func(*kms.Options)) (*kms.SignOutput, error)
+}
+
+// KMSBackend signs transactions with an asymmetric ECC_SECG_P256K1 key held in AWS KMS.
+type KMSBackend struct {
+	logger  log.Logger
+	client  KMSClient
+	keyID   string
+	pubKey  *ecdsa.PublicKey
+	address common.Address
+}
+
+var _ Backend = (*KMSBackend)(nil)
+
+// NewKMSBackendFromConfig creates a KMSBackend for the configured key.
+// Credentials are resolved by the default AWS credential chain: environment variables, shared config and
+// credentials files, web identity tokens, and container or EC2 instance roles.
+// If an address is configured, it must match the address of the KMS key.
+func NewKMSBackendFromConfig(logger log.Logger, config CLIConfig) (*KMSBackend, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(config.KMSRegion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	// KMS endpoints are authenticated with request signatures and publicly trusted certificates, so the signer TLS config is not used.
+	client := kms.NewFromConfig(awsCfg, func(o *kms.Options) {
+		if config.Endpoint != "" {
+			o.BaseEndpoint = aws.String(config.Endpoint)
+		}
+	})
+	backend, err := NewKMSBackend(ctx, logger, client, config.KMSKeyID)
+	if err != nil {
+		return nil, err
+	}
+	if config.Address != "" && common.HexToAddress(config.Address) != backend.Address() {
+		return nil, fmt.Errorf("kms key %v has address %v, expected %v", config.KMSKeyID, backend.Address(), config.Address)
+	}
+	return backend, nil
+}
+
+// NewKMSBackend creates a KMSBackend, fetching the public key of keyID to determine the signing address.
+func NewKMSBackend(ctx context.Context, logger log.Logger, client KMSClient, keyID string) (*KMSBackend, error) {
+	k := &KMSBackend{
+		logger: logger,
+		client: client,
+		keyID:  keyID,
+	}
+	pubKey, err := k.fetchPublicKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch kms public key: %w", err)
+	}
+	k.pubKey = pubKey
+	k.address = crypto.PubkeyToAddress(*pubKey)
+	return k, nil
+}
+
+// Address returns the address of the KMS key.
+func (k *KMSBackend) Address() common.Address {
+	return k.address
+}
+
+func (k *KMSBackend) SignTransaction(ctx context.Context, chainId *big.Int, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+	if from != k.address {
+		return nil, fmt.Errorf("kms key signs for %v, not %v", k.address, from)
+	}
+	signer := types.LatestSignerForChainID(chainId)
+	sig, err := k.sign(ctx, signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSignature(signer, sig)
+}
+
+// CheckHealth returns an error if the KMS key is not available.
+func (k *KMSBackend) CheckHealth(ctx context.Context) error {
+	_, err := k.fetchPublicKey(ctx)
+	return err
+}
+
+// subjectPublicKeyInfo is the DER encoded public key returned by KMS.
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// ecdsaSignature is the DER encoded signature returned by KMS.
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+func (k *KMSBackend) fetchPublicKey(ctx context.Context) (*ecdsa.PublicKey, error) {
+	resp, err := k.client.GetPublicKey(ctx, &kms.GetPublicKeyInput{KeyId: aws.String(k.keyID)})
+	if err != nil {
+		return nil, err
+	}
+	if resp.KeySpec != "" && resp.KeySpec != kmstypes.KeySpecEccSecgP256k1 {
+		return nil, fmt.Errorf("unsupported kms key spec: %v", resp.KeySpec)
+	}
+	var info subjectPublicKeyInfo
+	if _, err := asn1.Unmarshal(resp.PublicKey, &info); err != nil {
+		return nil, fmt.Errorf("invalid kms public key: %w", err)
+	}
+	return crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+}
+
+// sign signs the digest with the KMS key, returning the signature in the [R || S || V] format used by go-ethereum.
+func (k *KMSBackend) sign(ctx context.Context, digest []byte) ([]byte, error) {
+	resp, err := k.client.Sign(ctx, &kms.SignInput{
+		KeyId:            aws.String(k.keyID),
+		Message:          digest,
+		MessageType:      kmstypes.MessageTypeDigest,
+		SigningAlgorithm: kmstypes.SigningAlgorithmSpecEcdsaSha256,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var der ecdsaSignature
+	if _, err := asn1.Unmarshal(resp.Signature, &der); err != nil {
+		return nil, fmt.Errorf("invalid kms signature: %w", err)
+	}
+	// KMS does not produce canonical signatures so S must be normalised to the lower half of the curve order.
+	s := der.S
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(crypto.S256().Params().N, s)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	der.R.FillBytes(sig[0:32])
+	s.FillBytes(sig[32:64])
+	// KMS does not return the recovery id so find the one that recovers our public key.
+	expected := crypto.FromECDSAPub(k.pubKey)
+	for v := byte(0); v < 2; v++ {
+		sig[64] = v
+		recovered, err := crypto.Ecrecover(digest, sig)
+		if err == nil && bytes.Equal(recovered, expected) {
+			return sig, nil
+		}
+	}
+	return nil, errors.New("kms signature does not match kms public key")
+}
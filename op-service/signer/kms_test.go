@@ -0,0 +1,191 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidSecp256k1      = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+type kmsPublicKeyRequest struct {
+	KeyId string
+}
+
+type kmsPublicKeyResponse struct {
+	PublicKey []byte
+	KeySpec   string
+}
+
+type kmsSignRequest struct {
+	KeyId            string
+	Message          []byte
+	MessageType      string
+	SigningAlgorithm string
+}
+
+type kmsSignResponse struct {
+	Signature []byte
+}
+
+// fakeKMS implements the subset of the AWS KMS JSON API used by KMSBackend.
+type fakeKMS struct {
+	t   *testing.T
+	key *ecdsa.PrivateKey
+	// highS causes signatures to be returned with a non-canonical S value.
+	highS bool
+	// sessionToken is the expected session token of the credentials requests are signed with.
+	sessionToken string
+}
+
+func (f *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	require.Equal(f.t, "application/x-amz-json-1.1", r.Header.Get("Content-Type"))
+	require.True(f.t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+	require.Contains(f.t, r.Header.Get("Authorization"), "/us-east-1/kms/aws4_request")
+	require.Equal(f.t, f.sessionToken, r.Header.Get("X-Amz-Security-Token"))
+
+	var resp any
+	switch r.Header.Get("X-Amz-Target") {
+	case "TrentService.GetPublicKey":
+		var req kmsPublicKeyRequest
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&req))
+		if req.KeyId != "key" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"NotFoundException","message":"key not found"}`))
+			return
+		}
+		params, err := asn1.Marshal(oidSecp256k1)
+		require.NoError(f.t, err)
+		pub := crypto.FromECDSAPub(&f.key.PublicKey)
+		spki, err := asn1.Marshal(subjectPublicKeyInfo{
+			Algorithm: pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: params}},
+			PublicKey: asn1.BitString{Bytes: pub, BitLength: len(pub) * 8},
+		})
+		require.NoError(f.t, err)
+		resp = kmsPublicKeyResponse{PublicKey: spki, KeySpec: "ECC_SECG_P256K1"}
+	case "TrentService.Sign":
+		var req kmsSignRequest
+		require.NoError(f.t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(f.t, "DIGEST", req.MessageType)
+		require.Equal(f.t, "ECDSA_SHA_256", req.SigningAlgorithm)
+		sig, err := crypto.Sign(req.Message, f.key)
+		require.NoError(f.t, err)
+		s := new(big.Int).SetBytes(sig[32:64])
+		if f.highS {
+			s = new(big.Int).Sub(crypto.S256().Params().N, s)
+		}
+		der, err := asn1.Marshal(ecdsaSignature{R: new(big.Int).SetBytes(sig[0:32]), S: s})
+		require.NoError(f.t, err)
+		resp = kmsSignResponse{Signature: der}
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	require.NoError(f.t, json.NewEncoder(w).Encode(resp))
+}
+
+func setupKMS(t *testing.T, highS bool) (*ecdsa.PrivateKey, CLIConfig) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	server := httptest.NewServer(&fakeKMS{t: t, key: key, highS: highS, sessionToken: "session"})
+	t.Cleanup(server.Close)
+	// Isolate the default credential chain from any config on the host.
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_PROFILE", "")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "session")
+	cfg := NewCLIConfig()
+	cfg.Type = TypeKMS
+	cfg.Endpoint = server.URL
+	cfg.KMSKeyID = "key"
+	cfg.KMSRegion = "us-east-1"
+	return key, cfg
+}
+
+func TestKMSBackend(t *testing.T) {
+	for _, highS := range []bool{false, true} {
+		highS := highS
+		name := "LowS"
+		if highS {
+			name = "HighS"
+		}
+		t.Run(name, func(t *testing.T) {
+			key, cfg := setupKMS(t, highS)
+			backend, address, err := NewBackendFromConfig(testlog.Logger(t, log.LvlInfo), cfg)
+			require.NoError(t, err)
+			expectedAddr := crypto.PubkeyToAddress(key.PublicKey)
+			require.Equal(t, expectedAddr, address)
+			require.NoError(t, backend.CheckHealth(context.Background()))
+
+			chainID := big.NewInt(10)
+			tx := types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 3, Gas: 21000, To: &common.Address{0x01}})
+			signed, err := backend.SignTransaction(context.Background(), chainID, expectedAddr, tx)
+			require.NoError(t, err)
+			sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+			require.NoError(t, err)
+			require.Equal(t, expectedAddr, sender)
+
+			_, err = backend.SignTransaction(context.Background(), chainID, common.Address{0xbb}, tx)
+			require.ErrorContains(t, err, "kms key signs for")
+		})
+	}
+}
+
+func TestKMSBackendAddressMismatch(t *testing.T) {
+	_, cfg := setupKMS(t, false)
+	cfg.Address = common.Address{0xbb}.Hex()
+	_, err := NewKMSBackendFromConfig(testlog.Logger(t, log.LvlInfo), cfg)
+	require.ErrorContains(t, err, "expected "+cfg.Address)
+}
+
+func TestKMSBackendKeyNotFound(t *testing.T) {
+	_, cfg := setupKMS(t, false)
+	cfg.KMSKeyID = "unknown"
+	_, err := NewKMSBackendFromConfig(testlog.Logger(t, log.LvlInfo), cfg)
+	require.ErrorContains(t, err, "NotFoundException: key not found")
+}
+
+func TestKMSBackendSharedCredentials(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	server := httptest.NewServer(&fakeKMS{t: t, key: key})
+	t.Cleanup(server.Close)
+	credentials := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(credentials, []byte("[signer]\naws_access_key_id = AKID\naws_secret_access_key = secret\n"), 0o600))
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", credentials)
+	t.Setenv("AWS_PROFILE", "signer")
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	cfg := NewCLIConfig()
+	cfg.Type = TypeKMS
+	cfg.Endpoint = server.URL
+	cfg.KMSKeyID = "key"
+	cfg.KMSRegion = "us-east-1"
+	backend, err := NewKMSBackendFromConfig(testlog.Logger(t, log.LvlInfo), cfg)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), backend.Address())
+}
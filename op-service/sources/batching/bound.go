@@ -11,6 +11,8 @@ import (
 var (
 	ErrUnknownMethod = errors.New("unknown method")
 	ErrInvalidCall   = errors.New("invalid call")
+	ErrUnknownError  = errors.New("unknown error")
+	ErrInvalidError  = errors.New("invalid error")
 )
 
 type BoundContract struct {
@@ -44,3 +46,21 @@ func (b *BoundContract) DecodeCall(data []byte) (string, *CallResult, error) {
 	}
 	return method.Name, &CallResult{args}, nil
 }
+
+// DecodeError decodes revert data from one of the custom errors defined in the contract ABI.
+func (b *BoundContract) DecodeError(data []byte) (string, *CallResult, error) {
+	if len(data) < 4 {
+		return "", nil, ErrUnknownError
+	}
+	var selector [4]byte
+	copy(selector[:], data[:4])
+	abiErr, err := b.abi.ErrorByID(selector)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrUnknownError, err.Error())
+	}
+	args, err := abiErr.Inputs.Unpack(data[4:])
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", ErrInvalidError, err.Error())
+	}
+	return abiErr.Name, &CallResult{args}, nil
+}
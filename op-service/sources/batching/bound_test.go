@@ -51,3 +51,34 @@ func TestDecodeCall(t *testing.T) {
 		require.Zero(t, amount.Cmp(args.GetBigInt(1)))
 	})
 }
+
+func TestDecodeError(t *testing.T) {
+	testAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	contract := NewBoundContract(testAbi, common.Address{0xaa})
+
+	t.Run("TooShort", func(t *testing.T) {
+		_, _, err := contract.DecodeError([]byte{1, 2, 3})
+		require.ErrorIs(t, err, ErrUnknownError)
+	})
+
+	t.Run("UnknownErrorId", func(t *testing.T) {
+		_, _, err := contract.DecodeError([]byte{1, 2, 3, 4})
+		require.ErrorIs(t, err, ErrUnknownError)
+	})
+
+	selector := testAbi.Errors["UnexpectedRootClaim"].ID.Bytes()[:4]
+	t.Run("MissingArgs", func(t *testing.T) {
+		_, _, err := contract.DecodeError(selector)
+		require.ErrorIs(t, err, ErrInvalidError)
+	})
+
+	t.Run("ValidError", func(t *testing.T) {
+		rootClaim := common.Hash{0xbb}
+		data := append(common.CopyBytes(selector), rootClaim[:]...)
+		name, args, err := contract.DecodeError(data)
+		require.NoError(t, err)
+		require.Equal(t, "UnexpectedRootClaim", name)
+		require.Equal(t, rootClaim, args.GetHash(0))
+	})
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -83,6 +85,23 @@ func (m *MultiCaller) Call(ctx context.Context, block Block, calls ...*ContractC
 	return callResults, nil
 }
 
+// CallTx executes tx from the specified sender via eth_call at block and returns the raw return data.
+// Errors, including reverts, are returned unchanged so that callers can inspect any revert data.
+func (m *MultiCaller) CallTx(ctx context.Context, block Block, from common.Address, tx txmgr.TxCandidate) (hexutil.Bytes, error) {
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To,
+		Gas:   tx.GasLimit,
+		Value: tx.Value,
+		Data:  tx.TxData,
+	}
+	var out hexutil.Bytes
+	if err := m.rpc.CallContext(ctx, &out, "eth_call", toCallArg(msg), block.value); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Block represents the block ref value in RPC calls.
 // It can be either a label (e.g. latest), a block number or block hash.
 type Block struct {
@@ -24,6 +24,7 @@ type expectedCall struct {
 	args       []interface{}
 	packedArgs []byte
 	outputs    []interface{}
+	err        error
 }
 
 func (e *expectedCall) String() string {
@@ -77,6 +78,13 @@ func (l *AbiBasedRpc) SetResponse(to common.Address, method string, block batchi
 	})
 }
 
+// SetError sets the error returned by calls to method with the expected arguments, such as a revert.
+func (l *AbiBasedRpc) SetError(to common.Address, method string, block batching.Block, expected []interface{}, err error) {
+	l.SetResponse(to, method, block, expected, nil)
+	calls := l.expectedCalls[method]
+	calls[len(calls)-1].err = err
+}
+
 func (l *AbiBasedRpc) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
 	var errs []error
 	for _, elem := range b {
@@ -104,6 +112,9 @@ func (l *AbiBasedRpc) CallContext(_ context.Context, out interface{}, method str
 	require.True(l.t, ok)
 
 	call, abiMethod := l.findExpectedCall(*to, data, actualBlockRef)
+	if call.err != nil {
+		return call.err
+	}
 
 	output, err := abiMethod.Outputs.Pack(call.outputs...)
 	require.NoErrorf(l.t, err, "Invalid outputs for method %v: %v", abiMethod.Name, call.outputs)
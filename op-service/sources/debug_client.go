@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -48,3 +49,63 @@ func (o *DebugClient) dbGet(ctx context.Context, key []byte) ([]byte, error) {
 	}
 	return node, nil
 }
+
+// TraceTransactionCalls traces the calls made by the transaction with the callTracer.
+func (o *DebugClient) TraceTransactionCalls(ctx context.Context, txHash common.Hash, cfg eth.CallTracerConfig) (*eth.CallFrame, error) {
+	var frame eth.CallFrame
+	if err := o.traceTransaction(ctx, &frame, txHash, eth.TraceConfig{Tracer: eth.CallTracer, TracerConfig: cfg}); err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
+
+// TraceTransactionPrestate traces the state of the accounts accessed by the transaction, before it executed.
+func (o *DebugClient) TraceTransactionPrestate(ctx context.Context, txHash common.Hash) (eth.PrestateAccounts, error) {
+	var accounts eth.PrestateAccounts
+	if err := o.traceTransaction(ctx, &accounts, txHash, eth.TraceConfig{Tracer: eth.PrestateTracer}); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// TraceTransactionStateDiff traces the state modified by the transaction, before and after it executed.
+func (o *DebugClient) TraceTransactionStateDiff(ctx context.Context, txHash common.Hash) (*eth.PrestateDiff, error) {
+	var diff eth.PrestateDiff
+	cfg := eth.TraceConfig{Tracer: eth.PrestateTracer, TracerConfig: eth.PrestateTracerConfig{DiffMode: true}}
+	if err := o.traceTransaction(ctx, &diff, txHash, cfg); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// TraceBlockCalls traces the calls made by each transaction in the block with the callTracer.
+func (o *DebugClient) TraceBlockCalls(ctx context.Context, blockHash common.Hash, cfg eth.CallTracerConfig) ([]eth.TxTraceResult[*eth.CallFrame], error) {
+	var results []eth.TxTraceResult[*eth.CallFrame]
+	if err := o.traceBlock(ctx, &results, blockHash, eth.TraceConfig{Tracer: eth.CallTracer, TracerConfig: cfg}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// TraceBlockPrestate traces the state of the accounts accessed by each transaction in the block, before it executed.
+func (o *DebugClient) TraceBlockPrestate(ctx context.Context, blockHash common.Hash) ([]eth.TxTraceResult[eth.PrestateAccounts], error) {
+	var results []eth.TxTraceResult[eth.PrestateAccounts]
+	if err := o.traceBlock(ctx, &results, blockHash, eth.TraceConfig{Tracer: eth.PrestateTracer}); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (o *DebugClient) traceTransaction(ctx context.Context, result any, txHash common.Hash, cfg eth.TraceConfig) error {
+	if err := o.callContext(ctx, result, "debug_traceTransaction", txHash, cfg); err != nil {
+		return fmt.Errorf("failed to trace transaction %s with %s: %w", txHash, cfg.Tracer, err)
+	}
+	return nil
+}
+
+func (o *DebugClient) traceBlock(ctx context.Context, result any, blockHash common.Hash, cfg eth.TraceConfig) error {
+	if err := o.callContext(ctx, result, "debug_traceBlockByHash", blockHash, cfg); err != nil {
+		return fmt.Errorf("failed to trace block %s with %s: %w", blockHash, cfg.Tracer, err)
+	}
+	return nil
+}
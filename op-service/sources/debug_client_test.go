@@ -0,0 +1,217 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// cannedRPC responds to a single expected method with a fixed JSON response,
+// and records the JSON encoding of the request params.
+type cannedRPC struct {
+	t        *testing.T
+	method   string
+	response string
+	params   string
+}
+
+func (c *cannedRPC) CallContext(_ context.Context, result any, method string, args ...any) error {
+	require.Equal(c.t, c.method, method)
+	params, err := json.Marshal(args)
+	require.NoError(c.t, err)
+	c.params = string(params)
+	return json.Unmarshal([]byte(c.response), result)
+}
+
+const callTrace = `{
+	"type": "CALL",
+	"from": "0x1111111111111111111111111111111111111111",
+	"to": "0x2222222222222222222222222222222222222222",
+	"value": "0x10",
+	"gas": "0x5208",
+	"gasUsed": "0x5000",
+	"input": "0xabcd",
+	"output": "0x",
+	"calls": [{
+		"type": "DELEGATECALL",
+		"from": "0x2222222222222222222222222222222222222222",
+		"to": "0x3333333333333333333333333333333333333333",
+		"gas": "0x100",
+		"gasUsed": "0x50",
+		"input": "0x",
+		"error": "execution reverted",
+		"revertReason": "nope"
+	}],
+	"logs": [{
+		"address": "0x2222222222222222222222222222222222222222",
+		"topics": ["0x000000000000000000000000000000000000000000000000000000000000abcd"],
+		"data": "0x01"
+	}]
+}`
+
+func TestDebugClient_TraceTransactionCalls(t *testing.T) {
+	rpc := &cannedRPC{t: t, method: "debug_traceTransaction", response: callTrace}
+	client := NewDebugClient(rpc.CallContext)
+	txHash := common.Hash{0xaa}
+	frame, err := client.TraceTransactionCalls(context.Background(), txHash, eth.CallTracerConfig{WithLog: true})
+	require.NoError(t, err)
+	require.JSONEq(t, `["`+txHash.Hex()+`",{"tracer":"callTracer","tracerConfig":{"withLog":true}}]`, rpc.params)
+
+	require.Equal(t, "CALL", frame.Type)
+	require.Equal(t, common.HexToAddress("0x2222222222222222222222222222222222222222"), *frame.To)
+	require.Equal(t, uint64(0x10), frame.Value.ToInt().Uint64())
+	require.Equal(t, hexutil.Uint64(0x5000), frame.GasUsed)
+	require.Equal(t, hexutil.Bytes{0xab, 0xcd}, frame.Input)
+	require.Len(t, frame.Calls, 1)
+	require.Equal(t, "DELEGATECALL", frame.Calls[0].Type)
+	require.Equal(t, "execution reverted", frame.Calls[0].Error)
+	require.Equal(t, "nope", frame.Calls[0].RevertReason)
+	require.Len(t, frame.Logs, 1)
+	require.Equal(t, hexutil.Bytes{0x01}, frame.Logs[0].Data)
+}
+
+func TestDebugClient_TraceTransactionPrestate(t *testing.T) {
+	rpc := &cannedRPC{t: t, method: "debug_traceTransaction", response: `{
+		"0x1111111111111111111111111111111111111111": {"balance": "0x100", "nonce": 3},
+		"0x2222222222222222222222222222222222222222": {
+			"balance": "0x0",
+			"code": "0x6000",
+			"storage": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"}
+		}
+	}`}
+	client := NewDebugClient(rpc.CallContext)
+	accounts, err := client.TraceTransactionPrestate(context.Background(), common.Hash{0xaa})
+	require.NoError(t, err)
+	require.JSONEq(t, `["`+common.Hash{0xaa}.Hex()+`",{"tracer":"prestateTracer"}]`, rpc.params)
+
+	eoa := accounts[common.HexToAddress("0x1111111111111111111111111111111111111111")]
+	require.Equal(t, uint64(0x100), eoa.Balance.ToInt().Uint64())
+	require.Equal(t, uint64(3), eoa.Nonce)
+	contract := accounts[common.HexToAddress("0x2222222222222222222222222222222222222222")]
+	require.Equal(t, hexutil.Bytes{0x60, 0x00}, contract.Code)
+	require.Equal(t, common.Hash{31: 0x02}, contract.Storage[common.Hash{31: 0x01}])
+}
+
+func TestDebugClient_TraceTransactionStateDiff(t *testing.T) {
+	rpc := &cannedRPC{t: t, method: "debug_traceTransaction", response: `{
+		"pre": {"0x1111111111111111111111111111111111111111": {"balance": "0x100", "nonce": 3}},
+		"post": {"0x1111111111111111111111111111111111111111": {"balance": "0x50", "nonce": 4}}
+	}`}
+	client := NewDebugClient(rpc.CallContext)
+	diff, err := client.TraceTransactionStateDiff(context.Background(), common.Hash{0xaa})
+	require.NoError(t, err)
+	require.JSONEq(t, `["`+common.Hash{0xaa}.Hex()+`",{"tracer":"prestateTracer","tracerConfig":{"diffMode":true}}]`, rpc.params)
+	addr := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	require.Equal(t, uint64(3), diff.Pre[addr].Nonce)
+	require.Equal(t, uint64(4), diff.Post[addr].Nonce)
+}
+
+func TestDebugClient_TraceBlock(t *testing.T) {
+	blockHash := common.Hash{0xbb}
+	t.Run("Calls", func(t *testing.T) {
+		rpc := &cannedRPC{t: t, method: "debug_traceBlockByHash", response: `[
+			{"txHash": "0x00000000000000000000000000000000000000000000000000000000000000a1", "result": ` + callTrace + `},
+			{"txHash": "0x00000000000000000000000000000000000000000000000000000000000000a2", "error": "execution timeout"}
+		]`}
+		client := NewDebugClient(rpc.CallContext)
+		results, err := client.TraceBlockCalls(context.Background(), blockHash, eth.CallTracerConfig{OnlyTopCall: true})
+		require.NoError(t, err)
+		require.JSONEq(t, `["`+blockHash.Hex()+`",{"tracer":"callTracer","tracerConfig":{"onlyTopCall":true}}]`, rpc.params)
+		require.Len(t, results, 2)
+		require.Equal(t, common.Hash{31: 0xa1}, results[0].TxHash)
+		require.Equal(t, "CALL", results[0].Result.Type)
+		require.Nil(t, results[1].Result)
+		require.Equal(t, "execution timeout", results[1].Error)
+	})
+
+	t.Run("Prestate", func(t *testing.T) {
+		rpc := &cannedRPC{t: t, method: "debug_traceBlockByHash", response: `[
+			{"txHash": "0x00000000000000000000000000000000000000000000000000000000000000a1", "result": {"0x1111111111111111111111111111111111111111": {"balance": "0x100"}}}
+		]`}
+		client := NewDebugClient(rpc.CallContext)
+		results, err := client.TraceBlockPrestate(context.Background(), blockHash)
+		require.NoError(t, err)
+		require.JSONEq(t, `["`+blockHash.Hex()+`",{"tracer":"prestateTracer"}]`, rpc.params)
+		require.Len(t, results, 1)
+		require.Contains(t, results[0].Result, common.HexToAddress("0x1111111111111111111111111111111111111111"))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		client := NewDebugClient(func(ctx context.Context, result any, method string, args ...any) error {
+			return expectedErr
+		})
+		_, err := client.TraceBlockCalls(context.Background(), blockHash, eth.CallTracerConfig{})
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
+
+func TestTraceClient_Filter(t *testing.T) {
+	rpc := &cannedRPC{t: t, method: "trace_filter", response: `[
+		{
+			"action": {
+				"callType": "call",
+				"from": "0x1111111111111111111111111111111111111111",
+				"to": "0x2222222222222222222222222222222222222222",
+				"gas": "0x5208",
+				"input": "0x",
+				"value": "0x10"
+			},
+			"blockHash": "0x00000000000000000000000000000000000000000000000000000000000000bb",
+			"blockNumber": 100,
+			"result": {"gasUsed": "0x0", "output": "0x"},
+			"subtraces": 0,
+			"traceAddress": [],
+			"transactionHash": "0x00000000000000000000000000000000000000000000000000000000000000a1",
+			"transactionPosition": 2,
+			"type": "call"
+		},
+		{
+			"action": {
+				"author": "0x3333333333333333333333333333333333333333",
+				"rewardType": "block",
+				"value": "0x1bc16d674ec80000"
+			},
+			"blockHash": "0x00000000000000000000000000000000000000000000000000000000000000bb",
+			"blockNumber": 100,
+			"subtraces": 0,
+			"traceAddress": [],
+			"type": "reward"
+		}
+	]`}
+	client := NewTraceClient(rpc.CallContext)
+	from := hexutil.Uint64(100)
+	count := uint64(10)
+	traces, err := client.Filter(context.Background(), eth.TraceFilterArgs{
+		FromBlock: &from,
+		ToBlock:   &from,
+		ToAddress: []common.Address{common.HexToAddress("0x2222222222222222222222222222222222222222")},
+		Count:     &count,
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"fromBlock":"0x64","toBlock":"0x64","toAddress":["0x2222222222222222222222222222222222222222"],"count":10}]`, rpc.params)
+
+	require.Len(t, traces, 2)
+	call := traces[0]
+	require.Equal(t, "call", call.Type)
+	require.Equal(t, "call", call.Action.CallType)
+	require.Equal(t, hexutil.Uint64(0x5208), *call.Action.Gas)
+	require.Equal(t, uint64(100), call.BlockNumber)
+	require.Equal(t, common.Hash{31: 0xa1}, *call.TransactionHash)
+	require.Equal(t, uint64(2), *call.TransactionPosition)
+	require.NotNil(t, call.Result)
+
+	reward := traces[1]
+	require.Equal(t, "reward", reward.Type)
+	require.Equal(t, "block", reward.Action.RewardType)
+	require.Equal(t, common.HexToAddress("0x3333333333333333333333333333333333333333"), *reward.Action.Author)
+	require.Nil(t, reward.TransactionHash)
+	require.Nil(t, reward.Result)
+}
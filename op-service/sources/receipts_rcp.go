@@ -2,7 +2,9 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -50,6 +52,13 @@ type RPCReceiptsFetcher struct {
 
 	// methodResetDuration defines how long we take till we reset lastMethodsReset
 	methodResetDuration time.Duration
+
+	// detectLock guards the receipt methods detected for the RPCKindAuto provider kind.
+	detectLock sync.Mutex
+	// detected is true once the supported receipt methods have been probed.
+	detected bool
+	// detectedMethods are the receipt methods that the RPC supports, if detected.
+	detectedMethods ReceiptsFetchingMethod
 }
 
 type RPCReceiptsConfig struct {
@@ -71,6 +80,9 @@ func NewRPCReceiptsFetcher(client rpcClient, log log.Logger, config RPCReceiptsC
 }
 
 func (f *RPCReceiptsFetcher) FetchReceipts(ctx context.Context, block eth.BlockID, txHashes []common.Hash) (result types.Receipts, err error) {
+	if f.provKind == RPCKindAuto {
+		f.detectReceiptsMethods(ctx)
+	}
 	m := f.PickReceiptsMethod(len(txHashes))
 	switch m {
 	case EthGetTransactionReceiptBatch:
@@ -114,15 +126,78 @@ type receiptsWrapper struct {
 
 func (f *RPCReceiptsFetcher) PickReceiptsMethod(txCount int) ReceiptsFetchingMethod {
 	txc := uint64(txCount)
+	kind, preferred := f.preferredReceiptsMethods()
 	if now := time.Now(); now.Sub(f.lastMethodsReset) > f.methodResetDuration {
-		m := AvailableReceiptsFetchingMethods(f.provKind)
-		if f.availableReceiptMethods != m {
+		if f.availableReceiptMethods != preferred {
 			f.log.Warn("resetting back RPC preferences, please review RPC provider kind setting", "kind", f.provKind.String())
 		}
-		f.availableReceiptMethods = m
+		f.availableReceiptMethods = preferred
 		f.lastMethodsReset = now
 	}
-	return PickBestReceiptsFetchingMethod(f.provKind, f.availableReceiptMethods, txc)
+	return PickBestReceiptsFetchingMethod(kind, f.availableReceiptMethods, txc)
+}
+
+// preferredReceiptsMethods returns the provider kind to optimize receipt fetching costs for,
+// and the receipt methods to use when no methods have failed.
+// For the RPCKindAuto provider kind these are based on the detected methods, if any.
+func (f *RPCReceiptsFetcher) preferredReceiptsMethods() (RPCProviderKind, ReceiptsFetchingMethod) {
+	if f.provKind != RPCKindAuto {
+		return f.provKind, AvailableReceiptsFetchingMethods(f.provKind)
+	}
+	f.detectLock.Lock()
+	defer f.detectLock.Unlock()
+	if !f.detected {
+		return RPCKindAny, AvailableReceiptsFetchingMethods(RPCKindAny)
+	}
+	// Only Alchemy serves alchemy_getTransactionReceipts, and it prices its methods differently.
+	if f.detectedMethods&AlchemyGetTransactionReceipts != 0 {
+		return RPCKindAlchemy, f.detectedMethods
+	}
+	return RPCKindAny, f.detectedMethods
+}
+
+// detectReceiptsMethods probes which receipt fetching methods the RPC supports, with a single batch request.
+// Methods are called with an unknown block hash, so supported methods return an empty result or error cheaply,
+// while unsupported methods return a method-not-found or similar error.
+// If the probe fails, detection is retried on the next call, and all methods are considered until then.
+func (f *RPCReceiptsFetcher) detectReceiptsMethods(ctx context.Context) {
+	f.detectLock.Lock()
+	defer f.detectLock.Unlock()
+	if f.detected {
+		return
+	}
+	var unknown common.Hash
+	probes := []struct {
+		method ReceiptsFetchingMethod
+		elem   rpc.BatchElem
+	}{
+		{AlchemyGetTransactionReceipts, rpc.BatchElem{Method: "alchemy_getTransactionReceipts", Args: []any{blockHashParameter{BlockHash: unknown}}}},
+		{DebugGetRawReceipts, rpc.BatchElem{Method: "debug_getRawReceipts", Args: []any{unknown}}},
+		{ParityGetBlockReceipts, rpc.BatchElem{Method: "parity_getBlockReceipts", Args: []any{unknown}}},
+		{EthGetBlockReceipts, rpc.BatchElem{Method: "eth_getBlockReceipts", Args: []any{unknown}}},
+		{ErigonGetBlockReceiptsByBlockHash, rpc.BatchElem{Method: "erigon_getBlockReceiptsByBlockHash", Args: []any{unknown}}},
+	}
+	batch := make([]rpc.BatchElem, len(probes))
+	for i, p := range probes {
+		batch[i] = p.elem
+		batch[i].Result = new(json.RawMessage)
+	}
+	if err := f.client.BatchCallContext(ctx, batch); err != nil {
+		f.log.Warn("failed to detect supported receipt fetching methods, trying all methods", "err", err)
+		return
+	}
+	// Fetching receipts one by one is part of the standard RPC API, and always available.
+	supported := EthGetTransactionReceiptBatch
+	for i, p := range probes {
+		if err := batch[i].Error; err != nil && unusableMethod(err) {
+			continue
+		}
+		supported |= p.method
+	}
+	f.log.Info("detected supported receipt fetching methods", "methods", supported)
+	f.detected = true
+	f.detectedMethods = supported
+	f.availableReceiptMethods = supported
 }
 
 func (f *RPCReceiptsFetcher) OnReceiptsMethodErr(m ReceiptsFetchingMethod, err error) {
@@ -164,6 +239,7 @@ const (
 	RPCKindBasic      RPCProviderKind = "basic"    // try only the standard most basic receipt fetching
 	RPCKindAny        RPCProviderKind = "any"      // try any method available
 	RPCKindStandard   RPCProviderKind = "standard" // try standard methods, including newer optimized standard RPC methods
+	RPCKindAuto       RPCProviderKind = "auto"     // detect the supported methods on first use, and pick the cheapest
 )
 
 var RPCProviderKinds = []RPCProviderKind{
@@ -177,6 +253,7 @@ var RPCProviderKinds = []RPCProviderKind{
 	RPCKindBasic,
 	RPCKindAny,
 	RPCKindStandard,
+	RPCKindAuto,
 }
 
 func (kind RPCProviderKind) String() string {
@@ -336,8 +413,9 @@ func AvailableReceiptsFetchingMethods(kind RPCProviderKind) ReceiptsFetchingMeth
 		return ErigonGetBlockReceiptsByBlockHash | EthGetTransactionReceiptBatch
 	case RPCKindBasic:
 		return EthGetTransactionReceiptBatch
-	case RPCKindAny:
-		// if it's any kind of RPC provider, then try all methods
+	case RPCKindAny, RPCKindAuto:
+		// if it's any kind of RPC provider, then try all methods.
+		// Auto-detection narrows these down to the methods the RPC supports.
 		return AlchemyGetTransactionReceipts | EthGetBlockReceipts |
 			DebugGetRawReceipts | ErigonGetBlockReceiptsByBlockHash |
 			ParityGetBlockReceipts | EthGetTransactionReceiptBatch
@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -339,6 +340,95 @@ func TestEthClient_FetchReceipts(t *testing.T) {
 	}
 }
 
+// receiptMethodsRPC serves the receipt methods probed by auto-detection,
+// responding to unsupported methods with a method-not-found error.
+type receiptMethodsRPC struct {
+	supported map[string]error
+	batchErr  error
+}
+
+func (r *receiptMethodsRPC) CallContext(_ context.Context, _ any, _ string, _ ...any) error {
+	return nil
+}
+
+func (r *receiptMethodsRPC) BatchCallContext(_ context.Context, b []rpc.BatchElem) error {
+	if r.batchErr != nil {
+		return r.batchErr
+	}
+	for i := range b {
+		if err, ok := r.supported[b[i].Method]; ok {
+			b[i].Error = err
+		} else {
+			b[i].Error = &methodNotFoundError{method: b[i].Method}
+		}
+	}
+	return nil
+}
+
+func TestRPCReceiptsFetcher_AutoDetect(t *testing.T) {
+	newFetcher := func(t *testing.T, rpcClient rpcClient) *RPCReceiptsFetcher {
+		return NewRPCReceiptsFetcher(rpcClient, testlog.Logger(t, log.LvlError), RPCReceiptsConfig{
+			MaxBatchSize:        20,
+			ProviderKind:        RPCKindAuto,
+			MethodResetDuration: time.Minute,
+		})
+	}
+
+	t.Run("standard", func(t *testing.T) {
+		f := newFetcher(t, &receiptMethodsRPC{supported: map[string]error{"eth_getBlockReceipts": nil}})
+		f.detectReceiptsMethods(context.Background())
+		require.Equal(t, EthGetBlockReceipts, f.PickReceiptsMethod(10))
+	})
+
+	t.Run("debug geth", func(t *testing.T) {
+		f := newFetcher(t, &receiptMethodsRPC{supported: map[string]error{
+			"eth_getBlockReceipts": nil,
+			// an unknown block is not an indication the method is unavailable
+			"debug_getRawReceipts": errors.New("header not found"),
+		}})
+		f.detectReceiptsMethods(context.Background())
+		require.Equal(t, DebugGetRawReceipts, f.PickReceiptsMethod(10))
+	})
+
+	t.Run("alchemy cost saving", func(t *testing.T) {
+		f := newFetcher(t, &receiptMethodsRPC{supported: map[string]error{
+			"alchemy_getTransactionReceipts": nil,
+			"eth_getBlockReceipts":           nil,
+		}})
+		f.detectReceiptsMethods(context.Background())
+		require.Equal(t, EthGetTransactionReceiptBatch, f.PickReceiptsMethod(5))
+		require.Equal(t, AlchemyGetTransactionReceipts, f.PickReceiptsMethod(30))
+	})
+
+	t.Run("basic", func(t *testing.T) {
+		f := newFetcher(t, &receiptMethodsRPC{})
+		f.detectReceiptsMethods(context.Background())
+		require.Equal(t, EthGetTransactionReceiptBatch, f.PickReceiptsMethod(10))
+	})
+
+	t.Run("retry failed detection", func(t *testing.T) {
+		rpcClient := &receiptMethodsRPC{
+			supported: map[string]error{"erigon_getBlockReceiptsByBlockHash": nil},
+			batchErr:  errors.New("connection refused"),
+		}
+		f := newFetcher(t, rpcClient)
+		f.detectReceiptsMethods(context.Background())
+		// tries all methods until detection succeeds
+		require.Equal(t, AlchemyGetTransactionReceipts, f.PickReceiptsMethod(10))
+
+		rpcClient.batchErr = nil
+		f.detectReceiptsMethods(context.Background())
+		require.Equal(t, ErigonGetBlockReceiptsByBlockHash, f.PickReceiptsMethod(10))
+	})
+
+	t.Run("detect on first fetch", func(t *testing.T) {
+		f := newFetcher(t, &receiptMethodsRPC{supported: map[string]error{"parity_getBlockReceipts": nil}})
+		_, err := f.FetchReceipts(context.Background(), eth.BlockID{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, ParityGetBlockReceipts, f.PickReceiptsMethod(10))
+	})
+}
+
 func TestVerifyReceipts(t *testing.T) {
 	validData := func() (eth.BlockID, common.Hash, []common.Hash, []*types.Receipt) {
 		block := eth.BlockID{
@@ -26,6 +26,12 @@ func (r *RollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth
 	return output, err
 }
 
+func (r *RollupClient) SafeHeadAtL1Block(ctx context.Context, blockNum uint64) (*eth.SafeHeadResponse, error) {
+	var output *eth.SafeHeadResponse
+	err := r.rpc.CallContext(ctx, &output, "optimism_safeHeadAtL1Block", hexutil.Uint64(blockNum))
+	return output, err
+}
+
 func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	var output *eth.SyncStatus
 	err := r.rpc.CallContext(ctx, &output, "optimism_syncStatus")
@@ -44,6 +50,10 @@ func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	return output, err
 }
 
+func (r *RollupClient) ResetUnsafeHead(ctx context.Context, blockHash common.Hash) error {
+	return r.rpc.CallContext(ctx, nil, "admin_resetUnsafeHead", blockHash)
+}
+
 func (r *RollupClient) StartSequencer(ctx context.Context, unsafeHead common.Hash) error {
 	return r.rpc.CallContext(ctx, nil, "admin_startSequencer", unsafeHead)
 }
@@ -60,6 +70,16 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	return result, err
 }
 
+func (r *RollupClient) DerivationPipelineState(ctx context.Context) (*eth.DerivationPipelineState, error) {
+	var result *eth.DerivationPipelineState
+	err := r.rpc.CallContext(ctx, &result, "admin_derivationPipelineState")
+	return result, err
+}
+
+func (r *RollupClient) OverrideLeader(ctx context.Context) error {
+	return r.rpc.CallContext(ctx, nil, "admin_overrideLeader")
+}
+
 func (r *RollupClient) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
 	return r.rpc.CallContext(ctx, nil, "admin_postUnsafePayload", payload)
 }
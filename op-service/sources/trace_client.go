@@ -0,0 +1,27 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+)
+
+// TraceClient provides access to the trace namespace of parity-style tracing APIs, served by e.g. Erigon and Nethermind.
+type TraceClient struct {
+	callContext batching.CallContextFn
+}
+
+func NewTraceClient(callContext batching.CallContextFn) *TraceClient {
+	return &TraceClient{callContext}
+}
+
+// Filter returns the traces matching the filter.
+func (o *TraceClient) Filter(ctx context.Context, args eth.TraceFilterArgs) ([]eth.LocalizedTrace, error) {
+	var traces []eth.LocalizedTrace
+	if err := o.callContext(ctx, &traces, "trace_filter", args); err != nil {
+		return nil, fmt.Errorf("failed to filter traces: %w", err)
+	}
+	return traces, nil
+}
@@ -1,6 +1,9 @@
 package testlog
 
 import (
+	"reflect"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -9,6 +12,8 @@ import (
 type CapturingHandler struct {
 	Delegate log.Handler
 	Logs     []*log.Record
+
+	subscribers []func(r *log.Record)
 }
 
 func Capture(l log.Logger) *CapturingHandler {
@@ -21,36 +26,157 @@ func Capture(l log.Logger) *CapturingHandler {
 
 func (c *CapturingHandler) Log(r *log.Record) error {
 	c.Logs = append(c.Logs, r)
+	for _, fn := range c.subscribers {
+		fn(r)
+	}
 	if c.Delegate != nil {
 		return c.Delegate.Log(r)
 	}
 	return nil
 }
 
+// Stream calls fn with each record captured from now on, as it is logged.
+// fn is called synchronously by the logging goroutine, so must not block.
+func (c *CapturingHandler) Stream(fn func(r *log.Record)) {
+	c.subscribers = append(c.subscribers, fn)
+}
+
 func (c *CapturingHandler) Clear() {
 	c.Logs = nil
 }
 
+// FindLog returns the first captured record with the given level and exact message, or nil if there is none.
 func (c *CapturingHandler) FindLog(lvl log.Lvl, msg string) *HelperRecord {
+	return c.FindMatch(Match().Level(lvl).Message(msg))
+}
+
+// FindMatch returns the first captured record matched by m, or nil if there is none.
+func (c *CapturingHandler) FindMatch(m *Matcher) *HelperRecord {
 	for _, record := range c.Logs {
-		if record.Lvl == lvl && record.Msg == msg {
+		if m.Matches(record) {
 			return &HelperRecord{record}
 		}
 	}
 	return nil
 }
 
+// FindMatches returns all captured records matched by m, in the order they were logged.
+func (c *CapturingHandler) FindMatches(m *Matcher) []*HelperRecord {
+	var records []*HelperRecord
+	for _, record := range c.Logs {
+		if m.Matches(record) {
+			records = append(records, &HelperRecord{record})
+		}
+	}
+	return records
+}
+
+// FindInOrder returns a record for each of the matchers, such that each record was logged after the
+// record of the previous matcher. Other records may be logged in between.
+// Nil is returned if the records were not logged in that order.
+func (c *CapturingHandler) FindInOrder(matchers ...*Matcher) []*HelperRecord {
+	records := make([]*HelperRecord, 0, len(matchers))
+	next := 0
+	for _, record := range c.Logs {
+		if next == len(matchers) {
+			break
+		}
+		if matchers[next].Matches(record) {
+			records = append(records, &HelperRecord{record})
+			next++
+		}
+	}
+	if next < len(matchers) {
+		return nil
+	}
+	return records
+}
+
+// Matcher matches log records that meet all of its conditions.
+// Conditions are added by chaining, e.g. Match().Level(log.LvlError).MessageContains("failed").
+type Matcher struct {
+	conditions []func(r *log.Record) bool
+}
+
+// Match creates a Matcher that matches any record, until conditions are added.
+func Match() *Matcher {
+	return &Matcher{}
+}
+
+func (m *Matcher) where(cond func(r *log.Record) bool) *Matcher {
+	m.conditions = append(m.conditions, cond)
+	return m
+}
+
+// Level matches records logged at exactly lvl.
+func (m *Matcher) Level(lvl log.Lvl) *Matcher {
+	return m.where(func(r *log.Record) bool {
+		return r.Lvl == lvl
+	})
+}
+
+// Message matches records with exactly the message msg.
+func (m *Matcher) Message(msg string) *Matcher {
+	return m.where(func(r *log.Record) bool {
+		return r.Msg == msg
+	})
+}
+
+// MessageContains matches records with a message containing substr.
+func (m *Matcher) MessageContains(substr string) *Matcher {
+	return m.where(func(r *log.Record) bool {
+		return strings.Contains(r.Msg, substr)
+	})
+}
+
+// Attr matches records with an attribute key that is deeply equal to value.
+func (m *Matcher) Attr(key string, value any) *Matcher {
+	return m.AttrMatches(key, func(v any) bool {
+		return reflect.DeepEqual(v, value)
+	})
+}
+
+// HasAttr matches records with an attribute key, of any value.
+func (m *Matcher) HasAttr(key string) *Matcher {
+	return m.AttrMatches(key, func(any) bool {
+		return true
+	})
+}
+
+// AttrMatches matches records with an attribute key whose value satisfies pred.
+func (m *Matcher) AttrMatches(key string, pred func(v any) bool) *Matcher {
+	return m.where(func(r *log.Record) bool {
+		v, ok := contextValue(r.Ctx, key)
+		return ok && pred(v)
+	})
+}
+
+// Matches returns true if r meets all conditions of the matcher.
+func (m *Matcher) Matches(r *log.Record) bool {
+	for _, cond := range m.conditions {
+		if !cond(r) {
+			return false
+		}
+	}
+	return true
+}
+
 type HelperRecord struct {
 	*log.Record
 }
 
 func (h *HelperRecord) GetContextValue(name string) any {
-	for i := 0; i < len(h.Ctx); i += 2 {
-		if h.Ctx[i] == name {
-			return h.Ctx[i+1]
+	v, _ := contextValue(h.Ctx, name)
+	return v
+}
+
+func contextValue(ctx []any, name string) (any, bool) {
+	for i := 0; i+1 < len(ctx); i += 2 {
+		if ctx[i] == name {
+			return ctx[i+1], true
 		}
 	}
-	return nil
+	return nil, false
 }
 
 var _ log.Handler = (*CapturingHandler)(nil)
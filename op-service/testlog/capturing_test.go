@@ -0,0 +1,70 @@
+package testlog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapturingHandler_Match(t *testing.T) {
+	logger := Logger(t, log.LvlInfo)
+	logs := Capture(logger)
+	errBoom := errors.New("boom")
+	logger.Info("Starting", "attempt", 1)
+	logger.Error("Failed to fetch block", "err", errBoom, "number", uint64(5))
+	logger.Info("Starting", "attempt", 2)
+	logger.Warn("Retrying block fetch", "number", uint64(5))
+
+	require.NotNil(t, logs.FindLog(log.LvlError, "Failed to fetch block"))
+	require.Nil(t, logs.FindLog(log.LvlInfo, "Failed to fetch block"))
+
+	rec := logs.FindMatch(Match().MessageContains("fetch").Attr("number", uint64(5)))
+	require.NotNil(t, rec)
+	require.Equal(t, errBoom, rec.GetContextValue("err"))
+
+	require.Nil(t, logs.FindMatch(Match().MessageContains("fetch").Attr("number", 5)), "attribute types must match")
+	require.Nil(t, logs.FindMatch(Match().HasAttr("missing")))
+	require.NotNil(t, logs.FindMatch(Match().AttrMatches("err", func(v any) bool {
+		err, ok := v.(error)
+		return ok && errors.Is(err, errBoom)
+	})))
+
+	starts := logs.FindMatches(Match().Level(log.LvlInfo).Message("Starting"))
+	require.Len(t, starts, 2)
+	require.Equal(t, 1, starts[0].GetContextValue("attempt"))
+	require.Equal(t, 2, starts[1].GetContextValue("attempt"))
+	require.Len(t, logs.FindMatches(Match()), 4)
+}
+
+func TestCapturingHandler_FindInOrder(t *testing.T) {
+	logger := Logger(t, log.LvlInfo)
+	logs := Capture(logger)
+	logger.Info("a")
+	logger.Info("b")
+	logger.Info("c")
+
+	records := logs.FindInOrder(Match().Message("a"), Match().Message("c"))
+	require.Len(t, records, 2)
+	require.Equal(t, "a", records[0].Msg)
+	require.Equal(t, "c", records[1].Msg)
+
+	require.Nil(t, logs.FindInOrder(Match().Message("c"), Match().Message("a")))
+	require.Nil(t, logs.FindInOrder(Match().Message("a"), Match().Message("d")))
+}
+
+func TestCapturingHandler_Stream(t *testing.T) {
+	logger := Logger(t, log.LvlInfo)
+	logs := Capture(logger)
+	logger.Info("before")
+
+	var streamed []string
+	logs.Stream(func(r *log.Record) {
+		streamed = append(streamed, r.Msg)
+	})
+	logger.Info("first")
+	logger.Warn("second")
+	require.Equal(t, []string{"first", "second"}, streamed)
+	require.Len(t, logs.Logs, 3)
+}
@@ -1,17 +1,23 @@
 package testutils
 
 import (
+	"time"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 // TestDerivationMetrics implements the metrics used in the derivation pipeline as no-op operations.
 // Optionally a test may hook into the metrics
 type TestDerivationMetrics struct {
-	FnRecordL1ReorgDepth      func(d uint64)
-	FnRecordL1Ref             func(name string, ref eth.L1BlockRef)
-	FnRecordL2Ref             func(name string, ref eth.L2BlockRef)
-	FnRecordUnsafePayloads    func(length uint64, memSize uint64, next eth.BlockID)
-	FnRecordChannelInputBytes func(inputCompressedBytes int)
+	FnRecordL1ReorgDepth           func(d uint64)
+	FnRecordL1Ref                  func(name string, ref eth.L1BlockRef)
+	FnRecordL2Ref                  func(name string, ref eth.L2BlockRef)
+	FnRecordUnsafePayloads         func(length uint64, memSize uint64, next eth.BlockID)
+	FnRecordChannelInputBytes      func(inputCompressedBytes int)
+	FnRecordL2ReorgDepth           func(head string, d uint64)
+	FnRecordDeepL1Reorg            func(l1Depth uint64)
+	FnRecordDerivationStageStalled func(stage string, stalled bool)
 }
 
 func (t *TestDerivationMetrics) RecordL1ReorgDepth(d uint64) {
@@ -56,6 +62,30 @@ func (t *TestDerivationMetrics) RecordFrame() {
 func (n *TestDerivationMetrics) RecordDerivedBatches(batchType string) {
 }
 
+func (t *TestDerivationMetrics) RecordL2ReorgDepth(head string, d uint64) {
+	if t.FnRecordL2ReorgDepth != nil {
+		t.FnRecordL2ReorgDepth(head, d)
+	}
+}
+
+func (t *TestDerivationMetrics) RecordDeepL1Reorg(l1Depth uint64) {
+	if t.FnRecordDeepL1Reorg != nil {
+		t.FnRecordDeepL1Reorg(l1Depth)
+	}
+}
+
+func (t *TestDerivationMetrics) RecordDerivationStageStep(stage string, result string, d time.Duration) {
+}
+
+func (t *TestDerivationMetrics) RecordDerivationQueueDepth(stage string, depth int) {
+}
+
+func (t *TestDerivationMetrics) RecordDerivationStageStalled(stage string, stalled bool) {
+	if t.FnRecordDerivationStageStalled != nil {
+		t.FnRecordDerivationStageStalled(stage, stalled)
+	}
+}
+
 type TestRPCMetrics struct{}
 
 func (n *TestRPCMetrics) RecordRPCServerRequest(method string) func() {
@@ -66,4 +96,10 @@ func (n *TestRPCMetrics) RecordRPCClientRequest(method string) func(err error) {
 	return func(err error) {}
 }
 
+func (n *TestRPCMetrics) RecordRPCClientBatchRequest(b []rpc.BatchElem) func(err error) {
+	return func(err error) {}
+}
+
 func (n *TestRPCMetrics) RecordRPCClientResponse(method string, err error) {}
+
+func (n *TestRPCMetrics) RecordRPCClientResponseSize(method string, size int) {}
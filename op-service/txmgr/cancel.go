@@ -0,0 +1,171 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrAbandoned  = errors.New("transaction abandoned")
+	ErrNotPending = errors.New("no pending transaction with nonce")
+)
+
+// inflightTx is a transaction currently being sent by sendTx.
+type inflightTx struct {
+	tx      *types.Transaction
+	abandon context.CancelCauseFunc
+}
+
+// trackInflight records tx as being sent, so it can be abandoned or cancelled by nonce.
+func (m *SimpleTxManager) trackInflight(tx *types.Transaction, abandon context.CancelCauseFunc) *inflightTx {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if m.inflight == nil {
+		m.inflight = make(map[uint64]*inflightTx)
+	}
+	entry := &inflightTx{tx: tx, abandon: abandon}
+	m.inflight[tx.Nonce()] = entry
+	return entry
+}
+
+// updateInflight records tx as the latest version of the in-flight transaction after a fee bump.
+func (m *SimpleTxManager) updateInflight(entry *inflightTx, tx *types.Transaction) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	entry.tx = tx
+}
+
+// untrackInflight removes entry, unless its nonce has since been reused by another send.
+func (m *SimpleTxManager) untrackInflight(entry *inflightTx) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	if m.inflight[entry.tx.Nonce()] == entry {
+		delete(m.inflight, entry.tx.Nonce())
+	}
+}
+
+// Abandon stops sending and monitoring the transaction with the given nonce. The send returns ErrAbandoned.
+// The transaction is not replaced, so it may still be included on L1; use Cancel to replace it instead.
+// Returns ErrNotPending if no transaction with the nonce is being sent.
+func (m *SimpleTxManager) Abandon(nonce uint64) error {
+	_, err := m.abandon(nonce)
+	return err
+}
+
+// abandon stops the send of the transaction with the given nonce and returns its latest version.
+func (m *SimpleTxManager) abandon(nonce uint64) (*types.Transaction, error) {
+	m.inflightLock.Lock()
+	defer m.inflightLock.Unlock()
+	entry, ok := m.inflight[nonce]
+	if !ok {
+		return nil, fmt.Errorf("%w %v", ErrNotPending, nonce)
+	}
+	m.txLogger(entry.tx, false).Warn("Abandoning transaction")
+	entry.abandon(ErrAbandoned)
+	delete(m.inflight, nonce)
+	return entry.tx, nil
+}
+
+// Cancel replaces the transaction with the given nonce with a zero value transfer to the sender's own address,
+// abandoning the send of the original transaction. The replacement's fees are bumped from the original
+// transaction's, or use the current suggested fees if the nonce is not being sent by this transaction manager.
+// It blocks until the replacement confirms and returns its receipt. Note that the original transaction may still
+// be included instead, in which case the replacement fails with a nonce too low error.
+func (m *SimpleTxManager) Cancel(ctx context.Context, nonce uint64) (*types.Receipt, error) {
+	if m.closed.Load() {
+		return nil, ErrClosed
+	}
+	if next := m.nextNonce(); next != nil && nonce >= *next {
+		return nil, fmt.Errorf("cannot cancel unused nonce %v", nonce)
+	}
+	m.metr.RecordPendingTx(m.pending.Add(1))
+	defer func() {
+		m.metr.RecordPendingTx(m.pending.Add(-1))
+	}()
+	orig, err := m.abandon(nonce)
+	if err != nil && !errors.Is(err, ErrNotPending) {
+		return nil, err
+	}
+	ctx, cancel := m.sendContext(ctx)
+	defer cancel()
+	tx, err := m.craftCancelTx(ctx, nonce, orig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cancellation tx: %w", err)
+	}
+	m.txLogger(tx, true).Info("Cancelling transaction")
+	return m.sendTx(ctx, tx, sendLimits{})
+}
+
+// nextNonce returns the nonce the next crafted transaction will use, or nil if it is not yet known.
+func (m *SimpleTxManager) nextNonce() *uint64 {
+	m.nonceLock.RLock()
+	defer m.nonceLock.RUnlock()
+	if m.nonce == nil {
+		return nil
+	}
+	next := *m.nonce + 1
+	return &next
+}
+
+// craftCancelTx creates a signed self-transfer with the given nonce. If orig is non-nil, the fees are bumped
+// enough to replace it. Blob transactions can only be replaced by blob transactions, so a blob transaction
+// with a single empty blob is used to cancel them.
+func (m *SimpleTxManager) craftCancelTx(ctx context.Context, nonce uint64, orig *types.Transaction) (*types.Transaction, error) {
+	gasTipCap, baseFee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
+	if err != nil {
+		m.metr.RPCError()
+		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+	}
+	isBlobTx := orig != nil && orig.Type() == types.BlobTxType
+	gasFeeCap := calcGasFeeCap(baseFee, gasTipCap)
+	if orig != nil {
+		gasTipCap, gasFeeCap = updateFees(orig.GasTipCap(), orig.GasFeeCap(), gasTipCap, baseFee, isBlobTx, m.l)
+	}
+
+	var txMessage types.TxData
+	if isBlobTx {
+		if blobBaseFee == nil {
+			return nil, errors.New("expected non-nil blobBaseFee")
+		}
+		sidecar, blobHashes, err := MakeSidecar([]*eth.Blob{{}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to make sidecar: %w", err)
+		}
+		blobFeeCap := calcBlobFeeCap(blobBaseFee)
+		if bumped := calcThresholdValue(orig.BlobGasFeeCap(), true); bumped.Cmp(blobFeeCap) > 0 {
+			blobFeeCap = bumped
+		}
+		message := &types.BlobTx{
+			Nonce:      nonce,
+			To:         m.cfg.From,
+			Gas:        params.TxGas,
+			BlobHashes: blobHashes,
+			Sidecar:    sidecar,
+		}
+		if err := finishBlobTx(message, m.chainID, gasTipCap, gasFeeCap, blobFeeCap, new(big.Int)); err != nil {
+			return nil, fmt.Errorf("failed to create blob transaction: %w", err)
+		}
+		txMessage = message
+	} else {
+		to := m.cfg.From
+		txMessage = &types.DynamicFeeTx{
+			ChainID:   m.chainID,
+			Nonce:     nonce,
+			To:        &to,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Value:     new(big.Int),
+			Gas:       params.TxGas,
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	return m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
+}
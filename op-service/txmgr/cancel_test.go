@@ -0,0 +1,117 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+// sendPending starts sending a candidate that is never mined and waits for it to be published.
+func sendPending(t *testing.T, h *testHarness, candidate TxCandidate) (chan error, *types.Transaction) {
+	published := make(chan *types.Transaction, 100)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		if tx.To() != nil && *tx.To() == h.cfg.From && len(tx.Data()) == 0 {
+			// Only mine the cancellation
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		}
+		published <- tx
+		return nil
+	})
+	result := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(context.Background(), candidate)
+		result <- err
+	}()
+	select {
+	case tx := <-published:
+		return result, tx
+	case <-time.After(10 * time.Second):
+		t.Fatal("tx not published")
+		return nil, nil
+	}
+}
+
+func TestTxMgr_Abandon(t *testing.T) {
+	h := newTestHarness(t)
+	result, tx := sendPending(t, h, h.createTxCandidate())
+
+	require.NoError(t, h.mgr.Abandon(tx.Nonce()))
+	select {
+	case err := <-result:
+		require.ErrorIs(t, err, ErrAbandoned)
+	case <-time.After(10 * time.Second):
+		t.Fatal("send not abandoned")
+	}
+
+	require.ErrorIs(t, h.mgr.Abandon(tx.Nonce()), ErrNotPending)
+}
+
+func TestTxMgr_Cancel(t *testing.T) {
+	h := newTestHarness(t)
+	result, orig := sendPending(t, h, h.createTxCandidate())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Cancel(ctx, orig.Nonce())
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.ErrorIs(t, <-result, ErrAbandoned)
+	// The mock backend records the fee cap of the mined tx in GasUsed
+	require.GreaterOrEqual(t, receipt.GasUsed, calcThresholdValue(orig.GasFeeCap(), false).Uint64())
+}
+
+func TestTxMgr_CancelUnusedNonce(t *testing.T) {
+	h := newTestHarness(t)
+	_, orig := sendPending(t, h, h.createTxCandidate())
+
+	_, err := h.mgr.Cancel(context.Background(), orig.Nonce()+1)
+	require.ErrorContains(t, err, "cannot cancel unused nonce")
+}
+
+func TestTxMgr_CraftCancelTx(t *testing.T) {
+	t.Run("UnknownTx", func(t *testing.T) {
+		h := newTestHarness(t)
+		tx, err := h.mgr.craftCancelTx(context.Background(), 7, nil)
+		require.NoError(t, err)
+		require.Equal(t, uint64(types.DynamicFeeTxType), uint64(tx.Type()))
+		require.Equal(t, uint64(7), tx.Nonce())
+		require.Equal(t, h.cfg.From, *tx.To())
+		require.Equal(t, params.TxGas, tx.Gas())
+		require.Zero(t, tx.Value().Sign())
+		require.Empty(t, tx.Data())
+	})
+
+	t.Run("BumpsOriginal", func(t *testing.T) {
+		h := newTestHarness(t)
+		orig, err := h.mgr.craftTx(context.Background(), h.createTxCandidate())
+		require.NoError(t, err)
+		h.gasPricer.mu.Lock()
+		h.gasPricer.epoch = 0 // suggest lower fees than the original tx
+		h.gasPricer.mu.Unlock()
+
+		tx, err := h.mgr.craftCancelTx(context.Background(), orig.Nonce(), orig)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, tx.GasTipCap().Cmp(calcThresholdValue(orig.GasTipCap(), false)), 0)
+		require.GreaterOrEqual(t, tx.GasFeeCap().Cmp(calcThresholdValue(orig.GasFeeCap(), false)), 0)
+	})
+
+	t.Run("BlobTx", func(t *testing.T) {
+		h := newTestHarness(t)
+		orig, err := h.mgr.craftTx(context.Background(), h.createBlobTxCandidate())
+		require.NoError(t, err)
+
+		tx, err := h.mgr.craftCancelTx(context.Background(), orig.Nonce(), orig)
+		require.NoError(t, err)
+		require.Equal(t, uint64(types.BlobTxType), uint64(tx.Type()))
+		require.Len(t, tx.BlobHashes(), 1)
+		require.Equal(t, h.cfg.From, *tx.To())
+		require.GreaterOrEqual(t, tx.BlobGasFeeCap().Cmp(new(big.Int).Mul(orig.BlobGasFeeCap(), big.NewInt(2))), 0)
+		require.GreaterOrEqual(t, tx.GasFeeCap().Cmp(calcThresholdValue(orig.GasFeeCap(), true)), 0)
+	})
+}
@@ -36,6 +36,7 @@ const (
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	JournalDirFlagName                = "txmgr.journal-dir"
 )
 
 var (
@@ -178,6 +179,11 @@ func CLIFlagsWithDefaults(envPrefix string, defaults DefaultFlagValues) []cli.Fl
 			Value:   defaults.ReceiptQueryInterval,
 			EnvVars: prefixEnvVars("TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		&cli.PathFlag{
+			Name:    JournalDirFlagName,
+			Usage:   "Directory to journal pending transactions in, so they can be resumed after a restart. Transactions are stored in <dir>/<chainID>/<from>/. Disabled if empty.",
+			EnvVars: prefixEnvVars("TXMGR_JOURNAL_DIR"),
+		},
 	}, opsigner.CLIFlags(envPrefix)...)
 }
 
@@ -200,6 +206,7 @@ type CLIConfig struct {
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	JournalDir                string
 }
 
 func NewCLIConfig(l1RPCURL string, defaults DefaultFlagValues) CLIConfig {
@@ -273,6 +280,7 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		NetworkTimeout:            ctx.Duration(NetworkTimeoutFlagName),
 		TxSendTimeout:             ctx.Duration(TxSendTimeoutFlagName),
 		TxNotInMempoolTimeout:     ctx.Duration(TxNotInMempoolTimeoutFlagName),
+		JournalDir:                ctx.Path(JournalDirFlagName),
 	}
 }
 
@@ -339,6 +347,7 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
 		Signer:                    signerFactory(chainID),
 		From:                      from,
+		JournalDir:                cfg.JournalDir,
 	}, nil
 }
 
@@ -397,6 +406,11 @@ type Config struct {
 	// Signer is used to sign transactions when the gas price is increased.
 	Signer opcrypto.SignerFn
 	From   common.Address
+
+	// JournalDir is the directory pending transactions are journaled in (optional).
+	// Transactions are stored in a subdirectory for the chain ID and sender, so the directory may be shared.
+	// Journaled transactions are resumed when the transaction manager is created.
+	JournalDir string
 }
 
 func (m Config) Check() error {
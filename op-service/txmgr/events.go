@@ -0,0 +1,49 @@
+package txmgr
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrSlowTxEventSubscriber is the error of a transaction event subscription that was dropped,
+// because the subscriber did not keep up with the events.
+var ErrSlowTxEventSubscriber = errors.New("transaction event subscriber is too slow")
+
+// TxEventType identifies a stage in the lifecycle of a transaction sent by the [TxManager].
+type TxEventType string
+
+const (
+	// TxCreated is emitted when a transaction has been crafted and signed, and been assigned a nonce.
+	TxCreated TxEventType = "created"
+	// TxPublished is emitted each time a transaction is accepted by the backend.
+	TxPublished TxEventType = "published"
+	// TxReplaced is emitted when a transaction is replaced by a version with bumped fees.
+	TxReplaced TxEventType = "replaced"
+	// TxConfirmed is emitted when a transaction has reached the required number of confirmations.
+	TxConfirmed TxEventType = "confirmed"
+	// TxFailed is emitted when sending a transaction fails.
+	TxFailed TxEventType = "failed"
+)
+
+// TxEvent describes a change in the state of a transaction sent by the [TxManager].
+type TxEvent struct {
+	Type TxEventType
+	// Tx is the latest version of the transaction. It is nil for TxFailed events if the transaction
+	// could not be created.
+	Tx *types.Transaction
+	// Replaced is the transaction that Tx replaced. Only set for TxReplaced events.
+	Replaced *types.Transaction
+	// Receipt of the confirmed transaction. Only set for TxConfirmed events.
+	Receipt *types.Receipt
+	// Err is the reason sending failed. Only set for TxFailed events.
+	Err error
+}
+
+// emit sends the event to all subscribers without blocking.
+// Subscribers with a full channel are dropped, so they can't delay sending transactions.
+func (m *SimpleTxManager) emit(ev TxEvent) {
+	if dropped := m.events.Send(ev); dropped > 0 {
+		m.l.Warn("Dropped slow transaction event subscribers", "event", ev.Type, "count", dropped)
+	}
+}
@@ -0,0 +1,116 @@
+package txmgr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func collectTxEvents(ch chan TxEvent) []TxEvent {
+	var events []TxEvent
+	for {
+		select {
+		case ev := <-ch:
+			events = append(events, ev)
+		default:
+			return events
+		}
+	}
+}
+
+func TestTxMgr_TxEventsForBumpedTx(t *testing.T) {
+	h := newTestHarness(t)
+	events := make(chan TxEvent, 100)
+	sub := h.mgr.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		if h.gasPricer.shouldMine(tx.GasFeeCap()) {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+
+	got := collectTxEvents(events)
+	require.GreaterOrEqual(t, len(got), 5)
+	require.Equal(t, TxCreated, got[0].Type)
+	created := got[0].Tx
+	require.Equal(t, TxPublished, got[1].Type)
+	require.Equal(t, created.Hash(), got[1].Tx.Hash())
+
+	// Each replacement is followed by publishing the replacement tx
+	latest := created
+	for i := 2; i < len(got)-1; i += 2 {
+		require.Equal(t, TxReplaced, got[i].Type)
+		require.Equal(t, latest.Hash(), got[i].Replaced.Hash())
+		require.Equal(t, created.Nonce(), got[i].Tx.Nonce())
+		require.Equal(t, TxPublished, got[i+1].Type)
+		require.Equal(t, got[i].Tx.Hash(), got[i+1].Tx.Hash())
+		latest = got[i].Tx
+	}
+
+	last := got[len(got)-1]
+	require.Equal(t, TxConfirmed, last.Type)
+	require.Equal(t, receipt, last.Receipt)
+	require.Equal(t, latest.Hash(), last.Tx.Hash())
+}
+
+func TestTxMgr_TxEventsForFailedTx(t *testing.T) {
+	h := newTestHarness(t)
+	events := make(chan TxEvent, 100)
+	sub := h.mgr.SubscribeTxEvents(events)
+	defer sub.Unsubscribe()
+
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := h.mgr.Send(ctx, h.createTxCandidate())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	got := collectTxEvents(events)
+	require.Len(t, got, 3)
+	require.Equal(t, TxCreated, got[0].Type)
+	require.Equal(t, TxPublished, got[1].Type)
+	require.Equal(t, TxFailed, got[2].Type)
+	require.Equal(t, got[0].Tx.Hash(), got[2].Tx.Hash())
+	require.ErrorIs(t, got[2].Err, context.DeadlineExceeded)
+}
+
+func TestTxMgr_DropSlowTxEventSubscriber(t *testing.T) {
+	h := newTestHarness(t)
+	ev := TxEvent{Type: TxCreated}
+
+	fast := make(chan TxEvent, 2)
+	fastSub := h.mgr.SubscribeTxEvents(fast)
+	defer fastSub.Unsubscribe()
+	slow := make(chan TxEvent, 1)
+	slowSub := h.mgr.SubscribeTxEvents(slow)
+	defer slowSub.Unsubscribe()
+
+	h.mgr.emit(ev)
+	require.Equal(t, ev, <-fast)
+	h.mgr.emit(ev)
+	require.Equal(t, ev, <-fast)
+
+	// The slow subscriber is dropped when its channel is full, without blocking the transaction manager.
+	require.ErrorIs(t, <-slowSub.Err(), ErrSlowTxEventSubscriber)
+	require.Len(t, slow, 1)
+	h.mgr.emit(ev)
+	require.Equal(t, ev, <-fast)
+
+	fastSub.Unsubscribe()
+	h.mgr.emit(ev)
+	require.Empty(t, fast)
+}
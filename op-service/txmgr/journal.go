@@ -0,0 +1,224 @@
+package txmgr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+const journalFileExt = ".json"
+
+// invalidJournalFileExt is appended to the file name of journal entries that can't be read,
+// so they are kept for inspection, but not loaded again.
+const invalidJournalFileExt = ".invalid"
+
+// journalEntry is the on-disk record of a pending transaction.
+// Tx is the latest signed version of the transaction, including any blob sidecar.
+type journalEntry struct {
+	Nonce         uint64        `json:"nonce"`
+	TxHash        common.Hash   `json:"txHash"`
+	Tx            hexutil.Bytes `json:"tx"`
+	MaxGasFeeCap  *hexutil.Big  `json:"maxGasFeeCap,omitempty"`
+	MaxGasTipCap  *hexutil.Big  `json:"maxGasTipCap,omitempty"`
+	MaxBlobFeeCap *hexutil.Big  `json:"maxBlobFeeCap,omitempty"`
+	MaxFeeBumps   uint64        `json:"maxFeeBumps,omitempty"`
+	UrgentAt      int64         `json:"urgentAt,omitempty"`
+}
+
+func (e journalEntry) transaction() (*types.Transaction, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(e.Tx); err != nil {
+		return nil, err
+	}
+	if tx.Hash() != e.TxHash {
+		return nil, fmt.Errorf("journaled tx hash %v does not match recorded hash %v", tx.Hash(), e.TxHash)
+	}
+	return &tx, nil
+}
+
+func (e journalEntry) limits() sendLimits {
+	var limits sendLimits
+	if e.MaxGasFeeCap != nil {
+		limits.maxGasFeeCap = e.MaxGasFeeCap.ToInt()
+	}
+	if e.MaxGasTipCap != nil {
+		limits.maxGasTipCap = e.MaxGasTipCap.ToInt()
+	}
+	if e.MaxBlobFeeCap != nil {
+		limits.maxBlobFeeCap = e.MaxBlobFeeCap.ToInt()
+	}
+	limits.maxFeeBumps = e.MaxFeeBumps
+	if e.UrgentAt != 0 {
+		limits.urgentAt = time.Unix(e.UrgentAt, 0)
+	}
+	return limits
+}
+
+// journal persists pending transactions so they can be resumed after a restart.
+// Each transaction is stored in its own file, named by nonce, and replaced whenever its fees are bumped.
+// A journal must only hold transactions for a single sender on a single chain, see journalDir.
+// All methods are safe to call on a nil journal, which disables journaling.
+type journal struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[uint64]journalEntry
+}
+
+// journalDir returns the directory within baseDir that transactions from the sender on chainID are journaled in.
+// Nonces are only unique per sender and chain, so transaction managers sharing a base dir must not share a journal.
+func journalDir(baseDir string, chainID *big.Int, from common.Address) string {
+	return filepath.Join(baseDir, chainID.String(), from.Hex())
+}
+
+// openJournal opens the journal in dir, creating the directory if required, and loads any existing entries.
+// Entries that can't be read, e.g. because they were written partially, are logged and quarantined,
+// so that they don't stop the transaction manager from starting.
+func openJournal(l log.Logger, dir string) (*journal, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create journal dir: %w", err)
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal dir: %w", err)
+	}
+	j := &journal{dir: dir, entries: make(map[uint64]journalEntry)}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, journalFileExt) {
+			continue
+		}
+		if _, err := strconv.ParseUint(strings.TrimSuffix(name, journalFileExt), 10, 64); err != nil {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		entry, err := readJournalEntry(path)
+		if err != nil {
+			l.Error("Quarantining unreadable journal entry, its transaction is not resumed", "file", path, "err", err)
+			if err := os.Rename(path, path+invalidJournalFileExt); err != nil {
+				l.Error("Failed to quarantine journal entry", "file", path, "err", err)
+			}
+			continue
+		}
+		j.entries[entry.Nonce] = entry
+	}
+	return j, nil
+}
+
+func readJournalEntry(path string) (journalEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return journalEntry{}, err
+	}
+	var entry journalEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return journalEntry{}, err
+	}
+	return entry, nil
+}
+
+// pending returns the journaled transactions in nonce order.
+func (j *journal) pending() []journalEntry {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := make([]journalEntry, 0, len(j.entries))
+	for _, entry := range j.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, k int) bool {
+		return entries[i].Nonce < entries[k].Nonce
+	})
+	return entries
+}
+
+// put records tx as the latest version of the transaction with its nonce, replacing any previous entry.
+func (j *journal) put(tx *types.Transaction, limits sendLimits) error {
+	if j == nil {
+		return nil
+	}
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode tx: %w", err)
+	}
+	entry := journalEntry{
+		Nonce:         tx.Nonce(),
+		TxHash:        tx.Hash(),
+		Tx:            data,
+		MaxGasFeeCap:  optionalBig(limits.maxGasFeeCap),
+		MaxGasTipCap:  optionalBig(limits.maxGasTipCap),
+		MaxBlobFeeCap: optionalBig(limits.maxBlobFeeCap),
+		MaxFeeBumps:   limits.maxFeeBumps,
+	}
+	if !limits.urgentAt.IsZero() {
+		entry.UrgentAt = limits.urgentAt.Unix()
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if err := j.write(entry); err != nil {
+		return err
+	}
+	j.entries[entry.Nonce] = entry
+	return nil
+}
+
+// remove deletes the entry for nonce, unless it has since been replaced by a transaction other than txHash.
+func (j *journal) remove(nonce uint64, txHash common.Hash) error {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entry, ok := j.entries[nonce]
+	if !ok || entry.TxHash != txHash {
+		return nil
+	}
+	if err := os.Remove(j.path(entry.Nonce)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove journal entry: %w", err)
+	}
+	delete(j.entries, entry.Nonce)
+	return nil
+}
+
+func (j *journal) write(entry journalEntry) error {
+	out, err := ioutil.NewAtomicWriterCompressed(j.path(entry.Nonce), 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create journal entry: %w", err)
+	}
+	if err := json.NewEncoder(out).Encode(entry); err != nil {
+		_ = out.Abort()
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write journal entry: %w", err)
+	}
+	return nil
+}
+
+func (j *journal) path(nonce uint64) string {
+	return filepath.Join(j.dir, strconv.FormatUint(nonce, 10)+journalFileExt)
+}
+
+func optionalBig(v *big.Int) *hexutil.Big {
+	if v == nil {
+		return nil
+	}
+	return (*hexutil.Big)(v)
+}
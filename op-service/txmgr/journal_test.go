@@ -0,0 +1,210 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func journalTestTx(nonce uint64, feeCap int64) *types.Transaction {
+	to := common.Address{0x42}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     nonce,
+		To:        &to,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(feeCap),
+		Gas:       21000,
+	})
+}
+
+func TestJournal_PutAndReopen(t *testing.T) {
+	dir := t.TempDir()
+	j, err := openJournal(testlog.Logger(t, log.LvlInfo), dir)
+	require.NoError(t, err)
+	require.Empty(t, j.pending())
+
+	limits := sendLimits{
+		maxGasFeeCap:  big.NewInt(100),
+		maxGasTipCap:  big.NewInt(10),
+		maxBlobFeeCap: big.NewInt(50),
+		maxFeeBumps:   3,
+		urgentAt:      time.Unix(1000, 0),
+	}
+	tx2 := journalTestTx(2, 10)
+	tx1 := journalTestTx(1, 10)
+	require.NoError(t, j.put(tx2, sendLimits{}))
+	require.NoError(t, j.put(tx1, limits))
+
+	reopened, err := openJournal(testlog.Logger(t, log.LvlInfo), dir)
+	require.NoError(t, err)
+	entries := reopened.pending()
+	require.Len(t, entries, 2)
+	require.Equal(t, uint64(1), entries[0].Nonce)
+	require.Equal(t, uint64(2), entries[1].Nonce)
+
+	tx, err := entries[0].transaction()
+	require.NoError(t, err)
+	require.Equal(t, tx1.Hash(), tx.Hash())
+	require.Equal(t, limits, entries[0].limits())
+	require.Equal(t, sendLimits{}, entries[1].limits())
+}
+
+func TestJournal_QuarantineInvalidEntries(t *testing.T) {
+	dir := t.TempDir()
+	j, err := openJournal(testlog.Logger(t, log.LvlCrit), dir)
+	require.NoError(t, err)
+	require.NoError(t, j.put(journalTestTx(1, 10), sendLimits{}))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2.json"), []byte(`{"nonce":2,"tx`), 0o644))
+
+	reopened, err := openJournal(testlog.Logger(t, log.LvlCrit), dir)
+	require.NoError(t, err, "unreadable entries must not stop the journal from opening")
+	entries := reopened.pending()
+	require.Len(t, entries, 1)
+	require.Equal(t, uint64(1), entries[0].Nonce)
+	_, err = os.Stat(filepath.Join(dir, "2.json.invalid"))
+	require.NoError(t, err, "unreadable entry should be kept for inspection")
+	_, err = os.Stat(filepath.Join(dir, "2.json"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestJournal_DirPerChainAndSender(t *testing.T) {
+	base := t.TempDir()
+	fromA := common.Address{0xaa}
+	fromB := common.Address{0xbb}
+	require.Equal(t, filepath.Join(base, "1", fromA.Hex()), journalDir(base, big.NewInt(1), fromA))
+
+	a, err := openJournal(testlog.Logger(t, log.LvlInfo), journalDir(base, big.NewInt(1), fromA))
+	require.NoError(t, err)
+	require.NoError(t, a.put(journalTestTx(1, 10), sendLimits{}))
+
+	for _, dir := range []string{journalDir(base, big.NewInt(1), fromB), journalDir(base, big.NewInt(2), fromA)} {
+		other, err := openJournal(testlog.Logger(t, log.LvlInfo), dir)
+		require.NoError(t, err)
+		require.Empty(t, other.pending(), "should not share entries with other senders or chains")
+	}
+}
+
+func TestJournal_PutReplacesBumpedTx(t *testing.T) {
+	j, err := openJournal(testlog.Logger(t, log.LvlInfo), t.TempDir())
+	require.NoError(t, err)
+	orig := journalTestTx(1, 10)
+	bumped := journalTestTx(1, 20)
+	require.NoError(t, j.put(orig, sendLimits{}))
+	require.NoError(t, j.put(bumped, sendLimits{}))
+
+	entries := j.pending()
+	require.Len(t, entries, 1)
+	require.Equal(t, bumped.Hash(), entries[0].TxHash)
+
+	// Removing a stale version of the tx leaves the latest entry in place
+	require.NoError(t, j.remove(orig.Nonce(), orig.Hash()))
+	require.Len(t, j.pending(), 1)
+
+	require.NoError(t, j.remove(bumped.Nonce(), bumped.Hash()))
+	require.Empty(t, j.pending())
+	files, err := os.ReadDir(j.dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestJournal_IgnoresUnrelatedFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hello"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "foo.json"), []byte("{}"), 0o644))
+	j, err := openJournal(testlog.Logger(t, log.LvlInfo), dir)
+	require.NoError(t, err)
+	require.Empty(t, j.pending())
+}
+
+func TestJournal_NilIsDisabled(t *testing.T) {
+	var j *journal
+	require.NoError(t, j.put(journalTestTx(1, 10), sendLimits{}))
+	require.NoError(t, j.remove(1, common.Hash{}))
+	require.Empty(t, j.pending())
+}
+
+func TestTxMgr_JournalsPendingTx(t *testing.T) {
+	h := newTestHarness(t)
+	j, err := openJournal(testlog.Logger(t, log.LvlInfo), t.TempDir())
+	require.NoError(t, err)
+	h.mgr.journal = j
+
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		// The tx is journaled before it is published
+		entries := j.pending()
+		require.Len(t, entries, 1)
+		require.Equal(t, tx.Hash(), entries[0].TxHash)
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		return nil
+	})
+
+	receipt, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Empty(t, j.pending())
+}
+
+func TestTxMgr_JournalKeepsTxWhenInterrupted(t *testing.T) {
+	h := newTestHarness(t)
+	j, err := openJournal(testlog.Logger(t, log.LvlInfo), t.TempDir())
+	require.NoError(t, err)
+	h.mgr.journal = j
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, err = h.mgr.Send(ctx, h.createTxCandidate())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, j.pending(), 1)
+}
+
+func TestTxMgr_ResumesJournaledTxs(t *testing.T) {
+	h := newTestHarness(t)
+	j, err := openJournal(testlog.Logger(t, log.LvlInfo), t.TempDir())
+	require.NoError(t, err)
+
+	pendingTx := journalTestTx(5, 1000)
+	minedTx := journalTestTx(4, 1000)
+	require.NoError(t, j.put(minedTx, sendLimits{}))
+	require.NoError(t, j.put(pendingTx, sendLimits{}))
+	minedHash := minedTx.Hash()
+	h.backend.mine(&minedHash, minedTx.GasFeeCap(), nil)
+
+	published := make(chan common.Hash, 10)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		published <- tx.Hash()
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		return nil
+	})
+
+	h.mgr.journal = j
+	h.mgr.resumeJournaled()
+
+	// Only the tx that had not been mined is resubmitted
+	require.Equal(t, pendingTx.Hash(), <-published)
+	require.Eventually(t, func() bool {
+		return len(j.pending()) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// New txs use nonces after the journaled txs
+	receipt, err := h.mgr.Send(context.Background(), h.createTxCandidate())
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Equal(t, receipt.TxHash, <-published)
+	require.Equal(t, uint64(6), *h.mgr.nonce)
+}
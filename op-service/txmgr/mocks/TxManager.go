@@ -7,6 +7,8 @@ import (
 
 	common "github.com/ethereum/go-ethereum/common"
 
+	event "github.com/ethereum/go-ethereum/event"
+
 	mock "github.com/stretchr/testify/mock"
 
 	txmgr "github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -90,6 +92,67 @@ func (_m *TxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ty
 	return r0, r1
 }
 
+// SendAsync provides a mock function with given fields: ctx, candidate, ch
+func (_m *TxManager) SendAsync(ctx context.Context, candidate txmgr.TxCandidate, ch chan txmgr.SendResponse) {
+	_m.Called(ctx, candidate, ch)
+}
+
+// Abandon provides a mock function with given fields: nonce
+func (_m *TxManager) Abandon(nonce uint64) error {
+	ret := _m.Called(nonce)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64) error); ok {
+		r0 = rf(nonce)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Cancel provides a mock function with given fields: ctx, nonce
+func (_m *TxManager) Cancel(ctx context.Context, nonce uint64) (*types.Receipt, error) {
+	ret := _m.Called(ctx, nonce)
+
+	var r0 *types.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.Receipt, error)); ok {
+		return rf(ctx, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.Receipt); ok {
+		r0 = rf(ctx, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubscribeTxEvents provides a mock function with given fields: ch
+func (_m *TxManager) SubscribeTxEvents(ch chan<- txmgr.TxEvent) event.Subscription {
+	ret := _m.Called(ch)
+
+	var r0 event.Subscription
+	if rf, ok := ret.Get(0).(func(chan<- txmgr.TxEvent) event.Subscription); ok {
+		r0 = rf(ch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(event.Subscription)
+		}
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewTxManager interface {
 	mock.TestingT
 	Cleanup(func())
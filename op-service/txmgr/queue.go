@@ -54,14 +54,17 @@ func (q *Queue[T]) Wait() {
 // Send will wait until the number of pending txs is below the max pending,
 // and then send the next tx.
 //
-// The actual tx sending is non-blocking, with the receipt returned on the
+// The tx is signed before Send returns, so nonces are assigned in the order Send is called.
+// Waiting for confirmation is non-blocking, with the receipt returned on the
 // provided receipt channel. If the channel is unbuffered, the goroutine is
 // blocked from completing until the channel is read from.
 func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) {
 	group, ctx := q.groupContext()
+	signed := make(chan struct{})
 	group.Go(func() error {
-		return q.sendTx(ctx, id, candidate, receiptCh)
+		return q.sendTx(ctx, id, candidate, receiptCh, signed)
 	})
+	<-signed
 }
 
 // TrySend sends the next tx, but only if the number of pending txs is below the
@@ -75,19 +78,29 @@ func (q *Queue[T]) Send(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]
 // blocked from completing until the channel is read from.
 func (q *Queue[T]) TrySend(id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) bool {
 	group, ctx := q.groupContext()
-	return group.TryGo(func() error {
-		return q.sendTx(ctx, id, candidate, receiptCh)
+	signed := make(chan struct{})
+	queued := group.TryGo(func() error {
+		return q.sendTx(ctx, id, candidate, receiptCh, signed)
 	})
+	if queued {
+		<-signed
+	}
+	return queued
 }
 
-func (q *Queue[T]) sendTx(ctx context.Context, id T, candidate TxCandidate, receiptCh chan TxReceipt[T]) error {
-	receipt, err := q.txMgr.Send(ctx, candidate)
+// sendTx sends the tx asynchronously, closing signed once the tx has been assigned a nonce,
+// then waits for the result and forwards it to receiptCh.
+func (q *Queue[T]) sendTx(ctx context.Context, id T, candidate TxCandidate, receiptCh chan TxReceipt[T], signed chan struct{}) error {
+	responseCh := make(chan SendResponse, 1)
+	q.txMgr.SendAsync(ctx, candidate, responseCh)
+	close(signed)
+	response := <-responseCh
 	receiptCh <- TxReceipt[T]{
 		ID:      id,
-		Receipt: receipt,
-		Err:     err,
+		Receipt: response.Receipt,
+		Err:     response.Err,
 	}
-	return err
+	return response.Err
 }
 
 // groupContext returns a Group and a Context to use when sending a tx.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -241,3 +242,39 @@ func TestQueue_Send(t *testing.T) {
 		})
 	}
 }
+
+func TestQueue_SendAssignsNoncesInCallOrder(t *testing.T) {
+	conf := configWithNumConfs(1)
+	backend := newMockBackendWithNonce(newGasPricer(3))
+	mgr := &SimpleTxManager{
+		chainID: conf.ChainID,
+		name:    "TEST",
+		cfg:     conf,
+		backend: backend,
+		l:       testlog.Logger(t, log.LvlCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+	nonces := make(map[int]uint64)
+	var noncesLock sync.Mutex
+	backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		noncesLock.Lock()
+		defer noncesLock.Unlock()
+		nonces[int(tx.Data()[0])] = tx.Nonce()
+		txHash := tx.Hash()
+		backend.mine(&txHash, tx.GasFeeCap(), nil)
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
+	defer cancel()
+	queue := NewQueue[int](ctx, mgr, 0)
+	receiptCh := make(chan TxReceipt[int], 10)
+	for i := 0; i < 10; i++ {
+		queue.Send(i, TxCandidate{TxData: []byte{byte(i)}, To: &common.Address{}}, receiptCh)
+	}
+	queue.Wait()
+	for i := 0; i < 10; i++ {
+		require.NoError(t, (<-receiptCh).Err)
+		require.Equal(t, uint64(i), nonces[i], "tx %v sent with wrong nonce", i)
+	}
+}
@@ -17,11 +17,13 @@ import (
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/holiman/uint256"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opevent "github.com/ethereum-optimism/optimism/op-service/event"
 	"github.com/ethereum-optimism/optimism/op-service/retry"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -61,6 +63,26 @@ type TxManager interface {
 	// NOTE: Send can be called concurrently, the nonce will be managed internally.
 	Send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error)
 
+	// SendAsync is used to create & send a transaction asynchronously. The transaction is crafted and signed
+	// before SendAsync returns, so nonces are assigned in the order SendAsync is called. The result is sent
+	// to ch once the transaction is confirmed or fails, so ch must be buffered.
+	//
+	// NOTE: SendAsync can be called concurrently, the nonce will be managed internally.
+	SendAsync(ctx context.Context, candidate TxCandidate, ch chan SendResponse)
+
+	// Cancel replaces the pending transaction with the given nonce with a self-transfer at bumped fees, and
+	// abandons any send of the original transaction. It blocks until the replacement is confirmed.
+	Cancel(ctx context.Context, nonce uint64) (*types.Receipt, error)
+
+	// Abandon stops sending and monitoring the transaction with the given nonce, without replacing it.
+	// The corresponding Send returns ErrAbandoned.
+	Abandon(nonce uint64) error
+
+	// SubscribeTxEvents subscribes to lifecycle events for all transactions sent by the transaction manager.
+	// Events are sent without blocking, so ch should be buffered and drained promptly:
+	// if ch is full when an event is sent, the subscription fails with ErrSlowTxEventSubscriber.
+	SubscribeTxEvents(ch chan<- TxEvent) event.Subscription
+
 	// From returns the sending address associated with the instance of the transaction manager.
 	// It is static for a single instance of a TxManager.
 	From() common.Address
@@ -121,6 +143,14 @@ type SimpleTxManager struct {
 
 	pending atomic.Int64
 
+	journal *journal
+
+	inflight     map[uint64]*inflightTx
+	inflightLock sync.Mutex
+
+	// events notifies subscribers of the lifecycle events of all transactions.
+	events opevent.NonBlockingFeed[TxEvent]
+
 	closed atomic.Bool
 }
 
@@ -138,20 +168,89 @@ func NewSimpleTxManagerFromConfig(name string, l log.Logger, m metrics.TxMetrice
 	if err := conf.Check(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
-	return &SimpleTxManager{
+	mgr := &SimpleTxManager{
 		chainID: conf.ChainID,
 		name:    name,
 		cfg:     conf,
 		backend: conf.Backend,
 		l:       l.New("service", name),
 		metr:    m,
-	}, nil
+	}
+	if conf.JournalDir != "" {
+		j, err := openJournal(mgr.l, journalDir(conf.JournalDir, conf.ChainID, conf.From))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open tx journal: %w", err)
+		}
+		mgr.journal = j
+		mgr.resumeJournaled()
+	}
+	return mgr, nil
+}
+
+// resumeJournaled resumes sending the transactions recorded in the journal by a previous instance.
+// Nonces continue after the last journaled transaction so that its nonce is not reused while it is pending.
+func (m *SimpleTxManager) resumeJournaled() {
+	entries := m.journal.pending()
+	if len(entries) == 0 {
+		return
+	}
+	lastNonce := entries[len(entries)-1].Nonce
+	m.nonceLock.Lock()
+	m.nonce = &lastNonce
+	m.nonceLock.Unlock()
+
+	for _, entry := range entries {
+		tx, err := entry.transaction()
+		if err != nil {
+			m.l.Error("Discarding invalid journaled transaction", "nonce", entry.Nonce, "tx", entry.TxHash, "err", err)
+			if err := m.journal.remove(entry.Nonce, entry.TxHash); err != nil {
+				m.l.Error("Failed to remove transaction from journal", "nonce", entry.Nonce, "err", err)
+			}
+			continue
+		}
+		m.txLogger(tx, true).Info("Resuming journaled transaction")
+		m.metr.RecordPendingTx(m.pending.Add(1))
+		go func(limits sendLimits) {
+			defer func() {
+				m.metr.RecordPendingTx(m.pending.Add(-1))
+			}()
+			ctx, cancel := m.sendContext(context.Background())
+			defer cancel()
+			if receipt, err := m.backend.TransactionReceipt(ctx, tx.Hash()); err == nil && receipt != nil {
+				m.txLogger(tx, false).Info("Journaled transaction already mined", "block", eth.ReceiptBlockID(receipt))
+				m.removeJournaled(tx)
+				return
+			}
+			if _, err := m.sendTx(ctx, tx, limits); err != nil {
+				m.txLogger(tx, false).Warn("Failed to resume journaled transaction", "err", err)
+				m.resetNonce()
+			}
+		}(entry.limits())
+	}
+}
+
+// journalTx records tx in the journal, if enabled. Failures are logged rather than returned
+// so that journaling problems do not prevent transactions being sent.
+func (m *SimpleTxManager) journalTx(tx *types.Transaction, limits sendLimits) {
+	if err := m.journal.put(tx, limits); err != nil {
+		m.txLogger(tx, false).Error("Failed to journal transaction", "err", err)
+	}
+}
+
+func (m *SimpleTxManager) removeJournaled(tx *types.Transaction) {
+	if err := m.journal.remove(tx.Nonce(), tx.Hash()); err != nil {
+		m.txLogger(tx, false).Error("Failed to remove transaction from journal", "err", err)
+	}
 }
 
 func (m *SimpleTxManager) From() common.Address {
 	return m.cfg.From
 }
 
+func (m *SimpleTxManager) SubscribeTxEvents(ch chan<- TxEvent) event.Subscription {
+	return m.events.Subscribe(ch, ErrSlowTxEventSubscriber)
+}
+
 func (m *SimpleTxManager) BlockNumber(ctx context.Context) (uint64, error) {
 	return m.backend.BlockNumber(ctx)
 }
@@ -163,6 +262,21 @@ func (m *SimpleTxManager) Close() {
 	m.closed.Store(true)
 }
 
+// CheckSigner verifies that the signer is available by signing, but not sending, an empty transaction.
+// The nonce is not consumed.
+func (m *SimpleTxManager) CheckSigner(ctx context.Context) error {
+	if m.closed.Load() {
+		return ErrClosed
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	_, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(&types.DynamicFeeTx{ChainID: m.chainID}))
+	if err != nil {
+		return fmt.Errorf("failed to sign tx: %w", err)
+	}
+	return nil
+}
+
 func (m *SimpleTxManager) txLogger(tx *types.Transaction, logGas bool) log.Logger {
 	fields := []any{"tx", tx.Hash(), "nonce", tx.Nonce()}
 	if logGas {
@@ -189,6 +303,42 @@ type TxCandidate struct {
 	GasLimit uint64
 	// Value is the value to be used in the constructed tx.
 	Value *big.Int
+	// MinGasTipCap is the minimum gas tip cap to use for the tx (optional).
+	// It takes precedence over the suggested tip cap and the configured minimum tip cap when higher.
+	MinGasTipCap *big.Int
+	// MaxGasTipCap is the maximum gas tip cap to use for the tx (optional).
+	// The initial tip cap is clamped to it, taking precedence over MinGasTipCap, and fee bumps that
+	// would exceed it are skipped. As replacements must increase the tip cap, this also limits bumping.
+	MaxGasTipCap *big.Int
+	// MaxGasFeeCap is the maximum gas fee cap to use for the tx (optional).
+	// The initial fee caps are clamped to it and fee bumps that would exceed it are skipped,
+	// leaving the previously published tx pending.
+	MaxGasFeeCap *big.Int
+	// MaxBlobFeeCap is the maximum blob fee cap to use for a blob tx (optional).
+	// It is applied to the blob fee cap in the same way as MaxGasFeeCap.
+	MaxBlobFeeCap *big.Int
+	// UrgentAt is the time at which the tx becomes urgent (optional). If the tx is still pending
+	// at that time, its fees are bumped immediately instead of waiting for the resubmission timeout.
+	UrgentAt time.Time
+	// MaxFeeBumps is the maximum number of times the tx fees may be bumped (optional).
+	// Once reached, the last published tx is left pending. Zero means unlimited.
+	MaxFeeBumps uint64
+}
+
+// SendResponse is the result of a transaction sent with [TxManager.SendAsync].
+type SendResponse struct {
+	// Receipt of the confirmed transaction. Nil if Err is set.
+	Receipt *types.Receipt
+	// Nonce assigned to the transaction. Only valid if the transaction was signed.
+	Nonce uint64
+	// Err contains any error that occurred while creating or sending the transaction.
+	Err error
+}
+
+// BlobGas returns the blob gas used by a tx carrying the candidate's blobs.
+// It is zero for candidates without blobs.
+func (c TxCandidate) BlobGas() uint64 {
+	return uint64(len(c.Blobs)) * params.BlobTxBlobGasPerBlob
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -216,13 +366,61 @@ func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*typ
 	return receipt, err
 }
 
+// SendAsync crafts and signs the transaction before returning, so that nonces are assigned in call order, then
+// publishes it and waits for confirmation in the background. The result is sent to ch, which must be buffered.
+func (m *SimpleTxManager) SendAsync(ctx context.Context, candidate TxCandidate, ch chan SendResponse) {
+	if cap(ch) == 0 {
+		panic("SendAsync: channel must be buffered")
+	}
+	// refuse new requests if the tx manager is closed
+	if m.closed.Load() {
+		ch <- SendResponse{Err: ErrClosed}
+		return
+	}
+	m.metr.RecordPendingTx(m.pending.Add(1))
+	ctx, cancel := m.sendContext(ctx)
+	tx, err := m.prepare(ctx, candidate)
+	if err != nil {
+		cancel()
+		m.resetNonce()
+		m.metr.RecordPendingTx(m.pending.Add(-1))
+		ch <- SendResponse{Err: err}
+		return
+	}
+	go func() {
+		defer func() {
+			m.metr.RecordPendingTx(m.pending.Add(-1))
+		}()
+		defer cancel()
+		receipt, err := m.sendTx(ctx, tx, candidateLimits(candidate))
+		if err != nil {
+			m.resetNonce()
+		}
+		ch <- SendResponse{Receipt: receipt, Nonce: tx.Nonce(), Err: err}
+	}()
+}
+
 // send performs the actual transaction creation and sending.
 func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*types.Receipt, error) {
+	ctx, cancel := m.sendContext(ctx)
+	defer cancel()
+	tx, err := m.prepare(ctx, candidate)
+	if err != nil {
+		return nil, err
+	}
+	return m.sendTx(ctx, tx, candidateLimits(candidate))
+}
+
+// sendContext applies the configured TxSendTimeout, if any, to ctx.
+func (m *SimpleTxManager) sendContext(ctx context.Context) (context.Context, context.CancelFunc) {
 	if m.cfg.TxSendTimeout != 0 {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
-		defer cancel()
+		return context.WithTimeout(ctx, m.cfg.TxSendTimeout)
 	}
+	return context.WithCancel(ctx)
+}
+
+// prepare crafts and signs the transaction, retrying on failure.
+func (m *SimpleTxManager) prepare(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
 	tx, err := retry.Do(ctx, 30, retry.Fixed(2*time.Second), func() (*types.Transaction, error) {
 		if m.closed.Load() {
 			return nil, ErrClosed
@@ -234,9 +432,12 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 		return tx, err
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create the tx: %w", err)
+		err = fmt.Errorf("failed to create the tx: %w", err)
+		m.emit(TxEvent{Type: TxFailed, Err: err})
+		return nil, err
 	}
-	return m.sendTx(ctx, tx)
+	m.emit(TxEvent{Type: TxCreated, Tx: tx})
+	return tx, nil
 }
 
 // craftTx creates the signed transaction
@@ -245,13 +446,48 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
 func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
-	m.l.Debug("crafting Transaction", "blobs", len(candidate.Blobs), "calldata_size", len(candidate.TxData))
+	m.l.Debug("crafting Transaction", "blobs", len(candidate.Blobs), "blob_gas", candidate.BlobGas(), "calldata_size", len(candidate.TxData))
 	gasTipCap, baseFee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
 		return nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
+	if candidate.MinGasTipCap != nil && gasTipCap.Cmp(candidate.MinGasTipCap) < 0 {
+		m.l.Debug("Enforcing candidate min tip cap", "minTipCap", candidate.MinGasTipCap, "origTipCap", gasTipCap)
+		gasTipCap = new(big.Int).Set(candidate.MinGasTipCap)
+	}
+	if candidate.MaxGasTipCap != nil && gasTipCap.Cmp(candidate.MaxGasTipCap) > 0 {
+		m.l.Warn("Clamping tip cap to candidate max tip cap", "maxTipCap", candidate.MaxGasTipCap, "origTipCap", gasTipCap)
+		gasTipCap = new(big.Int).Set(candidate.MaxGasTipCap)
+	}
 	gasFeeCap := calcGasFeeCap(baseFee, gasTipCap)
+	if candidate.MaxGasFeeCap != nil && gasFeeCap.Cmp(candidate.MaxGasFeeCap) > 0 {
+		m.l.Warn("Clamping fee cap to candidate max fee cap", "maxFeeCap", candidate.MaxGasFeeCap, "origFeeCap", gasFeeCap)
+		gasFeeCap = new(big.Int).Set(candidate.MaxGasFeeCap)
+		if gasTipCap.Cmp(gasFeeCap) > 0 {
+			gasTipCap = new(big.Int).Set(gasFeeCap)
+		}
+	}
+
+	var sidecar *types.BlobTxSidecar
+	var blobHashes []common.Hash
+	var blobFeeCap *big.Int
+	if len(candidate.Blobs) > 0 {
+		if candidate.To == nil {
+			return nil, errors.New("blob txs cannot deploy contracts")
+		}
+		if blobBaseFee == nil {
+			return nil, fmt.Errorf("expected non-nil blobBaseFee")
+		}
+		if sidecar, blobHashes, err = MakeSidecar(candidate.Blobs); err != nil {
+			return nil, fmt.Errorf("failed to make sidecar: %w", err)
+		}
+		blobFeeCap = calcBlobFeeCap(blobBaseFee)
+		if candidate.MaxBlobFeeCap != nil && blobFeeCap.Cmp(candidate.MaxBlobFeeCap) > 0 {
+			m.l.Warn("Clamping blob fee cap to candidate max blob fee cap", "maxBlobFeeCap", candidate.MaxBlobFeeCap, "origBlobFeeCap", blobFeeCap)
+			blobFeeCap = new(big.Int).Set(candidate.MaxBlobFeeCap)
+		}
+	}
 
 	gasLimit := candidate.GasLimit
 
@@ -272,23 +508,8 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 		gasLimit = gas
 	}
 
-	var sidecar *types.BlobTxSidecar
-	var blobHashes []common.Hash
-	if len(candidate.Blobs) > 0 {
-		if candidate.To == nil {
-			return nil, errors.New("blob txs cannot deploy contracts")
-		}
-		if sidecar, blobHashes, err = MakeSidecar(candidate.Blobs); err != nil {
-			return nil, fmt.Errorf("failed to make sidecar: %w", err)
-		}
-	}
-
 	var txMessage types.TxData
 	if sidecar != nil {
-		if blobBaseFee == nil {
-			return nil, fmt.Errorf("expected non-nil blobBaseFee")
-		}
-		blobFeeCap := calcBlobFeeCap(blobBaseFee)
 		message := &types.BlobTx{
 			To:         *candidate.To,
 			Data:       candidate.TxData,
@@ -390,19 +611,53 @@ func (m *SimpleTxManager) resetNonce() {
 	m.nonce = nil
 }
 
+// sendLimits are the per-transaction fee limits and urgency taken from the [TxCandidate].
+type sendLimits struct {
+	maxGasFeeCap  *big.Int
+	maxGasTipCap  *big.Int
+	maxBlobFeeCap *big.Int
+	maxFeeBumps   uint64
+	urgentAt      time.Time
+}
+
+func candidateLimits(candidate TxCandidate) sendLimits {
+	return sendLimits{
+		maxGasFeeCap:  candidate.MaxGasFeeCap,
+		maxGasTipCap:  candidate.MaxGasTipCap,
+		maxBlobFeeCap: candidate.MaxBlobFeeCap,
+		maxFeeBumps:   candidate.MaxFeeBumps,
+		urgentAt:      candidate.UrgentAt,
+	}
+}
+
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// The latest version of the transaction is kept in the journal until it is confirmed or sending fails,
+// unless sending was interrupted by the context or the transaction manager closing.
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, limits sendLimits) (receipt *types.Receipt, err error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	m.journalTx(tx, limits)
+	callerCtx := ctx
+	defer func() {
+		if err != nil {
+			m.emit(TxEvent{Type: TxFailed, Tx: tx, Err: err})
+		}
+		if err == nil || !(errors.Is(err, ErrClosed) || callerCtx.Err() != nil) {
+			m.removeJournaled(tx)
+		}
+	}()
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	inflight := m.trackInflight(tx, cancel)
+	defer m.untrackInflight(inflight)
 
 	sendState := NewSendState(m.cfg.SafeAbortNonceTooLowCount, m.cfg.TxNotInMempoolTimeout)
 	receiptChan := make(chan *types.Receipt, 1)
 	publishAndWait := func(tx *types.Transaction, bumpFees bool) *types.Transaction {
 		wg.Add(1)
-		tx, published := m.publishTx(ctx, tx, sendState, bumpFees)
+		tx, published := m.publishTx(ctx, tx, sendState, bumpFees, limits)
+		m.updateInflight(inflight, tx)
 		if published {
 			go func() {
 				defer wg.Done()
@@ -420,8 +675,25 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 	ticker := time.NewTicker(m.cfg.ResubmissionTimeout)
 	defer ticker.Stop()
 
+	// A nil channel never fires, so the urgency bump is disabled unless urgentAt is set.
+	var urgent <-chan time.Time
+	if !limits.urgentAt.IsZero() {
+		timer := time.NewTimer(time.Until(limits.urgentAt))
+		defer timer.Stop()
+		urgent = timer.C
+	}
+
 	for {
 		select {
+		case <-urgent:
+			urgent = nil
+			if sendState.IsWaitingForConfirmation() || m.closed.Load() {
+				continue
+			}
+			m.txLogger(tx, false).Warn("Transaction is urgent, bumping fees immediately", "urgentAt", limits.urgentAt)
+			tx = publishAndWait(tx, true)
+			ticker.Reset(m.cfg.ResubmissionTimeout)
+
 		case <-ticker.C:
 			// Don't resubmit a transaction if it has been mined, but we are waiting for the conf depth.
 			if sendState.IsWaitingForConfirmation() {
@@ -440,11 +712,12 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 			tx = publishAndWait(tx, true)
 
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return nil, context.Cause(ctx)
 
 		case receipt := <-receiptChan:
 			m.metr.RecordGasBumpCount(sendState.bumpCount)
 			m.metr.TxConfirmed(receipt)
+			m.emit(TxEvent{Type: TxConfirmed, Tx: tx, Receipt: receipt})
 			return receipt, nil
 		}
 	}
@@ -453,7 +726,9 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 // publishTx publishes the transaction to the transaction pool. If it receives any underpriced errors
 // it will bump the fees and retry.
 // Returns the latest fee bumped tx, and a boolean indicating whether the tx was sent or not
-func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState, bumpFeesImmediately bool) (*types.Transaction, bool) {
+// Bumps that would take the fee cap, tip cap or blob fee cap above the limits (if non-nil), or exceed the
+// maximum number of bumps (if non-zero), are skipped.
+func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sendState *SendState, bumpFeesImmediately bool, limits sendLimits) (*types.Transaction, bool) {
 	l := m.txLogger(tx, true)
 
 	l.Info("Publishing transaction")
@@ -465,15 +740,38 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 			return tx, false
 		}
 		if bumpFeesImmediately {
+			if limits.maxFeeBumps != 0 && uint64(sendState.bumpCount) >= limits.maxFeeBumps {
+				l.Warn("Reached candidate max fee bumps, not bumping", "maxFeeBumps", limits.maxFeeBumps)
+				m.metr.TxPublished("bump_limit_reached")
+				return tx, false
+			}
 			newTx, err := m.increaseGasPrice(ctx, tx)
 			if err != nil {
 				l.Error("unable to increase gas", "err", err)
 				m.metr.TxPublished("bump_failed")
 				return tx, false
 			}
+			if limits.maxGasFeeCap != nil && newTx.GasFeeCap().Cmp(limits.maxGasFeeCap) > 0 {
+				l.Warn("Bumped fee cap exceeds candidate max fee cap, not bumping", "maxFeeCap", limits.maxGasFeeCap, "bumpedFeeCap", newTx.GasFeeCap())
+				m.metr.TxPublished("bump_exceeds_max_fee")
+				return tx, false
+			}
+			if limits.maxGasTipCap != nil && newTx.GasTipCap().Cmp(limits.maxGasTipCap) > 0 {
+				l.Warn("Bumped tip cap exceeds candidate max tip cap, not bumping", "maxTipCap", limits.maxGasTipCap, "bumpedTipCap", newTx.GasTipCap())
+				m.metr.TxPublished("bump_exceeds_max_tip")
+				return tx, false
+			}
+			if limits.maxBlobFeeCap != nil && newTx.BlobGasFeeCap() != nil && newTx.BlobGasFeeCap().Cmp(limits.maxBlobFeeCap) > 0 {
+				l.Warn("Bumped blob fee cap exceeds candidate max blob fee cap, not bumping", "maxBlobFeeCap", limits.maxBlobFeeCap, "bumpedBlobFeeCap", newTx.BlobGasFeeCap())
+				m.metr.TxPublished("bump_exceeds_max_blob_fee")
+				return tx, false
+			}
+			m.emit(TxEvent{Type: TxReplaced, Tx: newTx, Replaced: tx})
 			tx = newTx
 			sendState.bumpCount++
 			l = m.txLogger(tx, true)
+			// journal the bumped tx before publishing so a restart resumes the latest version
+			m.journalTx(tx, limits)
 		}
 		bumpFeesImmediately = true // bump fees next loop
 
@@ -491,6 +789,7 @@ func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction,
 		if err == nil {
 			m.metr.TxPublished("")
 			log.Info("Transaction successfully published")
+			m.emit(TxEvent{Type: TxPublished, Tx: tx})
 			return tx, true
 		}
 
@@ -740,6 +1039,7 @@ func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *b
 	var blobFee *big.Int
 	if head.ExcessBlobGas != nil {
 		blobFee = eip4844.CalcBlobFee(*head.ExcessBlobGas)
+		m.metr.RecordBlobBaseFee(blobFee)
 	}
 	return tip, baseFee, blobFee, nil
 }
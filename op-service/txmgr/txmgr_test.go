@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -359,7 +360,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -387,7 +388,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{})
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -416,7 +417,7 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -450,7 +451,7 @@ func TestTxMgrConfirmsBlobTxAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	// the fee cap for the blob tx at epoch == 3 should end up higher than the min required gas
@@ -484,7 +485,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{})
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -513,6 +514,232 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgr_CraftTxMinGasTipCap ensures that the candidate minimum tip cap is applied when crafting transactions.
+func TestTxMgr_CraftTxMinGasTipCap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("AboveSuggested", func(t *testing.T) {
+		h := newTestHarness(t)
+		candidate := h.createTxCandidate()
+		suggestedTip, _, _ := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
+		candidate.MinGasTipCap = new(big.Int).Mul(suggestedTip, big.NewInt(10))
+
+		tx, err := h.mgr.craftTx(context.Background(), candidate)
+		require.NoError(t, err)
+		require.Equal(t, candidate.MinGasTipCap, tx.GasTipCap())
+		require.Equal(t, calcGasFeeCap(h.gasPricer.baseFee(), candidate.MinGasTipCap), tx.GasFeeCap())
+	})
+
+	t.Run("BelowSuggested", func(t *testing.T) {
+		h := newTestHarness(t)
+		candidate := h.createTxCandidate()
+		candidate.MinGasTipCap = big.NewInt(1)
+		gasTipCap, gasFeeCap, _ := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
+
+		tx, err := h.mgr.craftTx(context.Background(), candidate)
+		require.NoError(t, err)
+		require.Equal(t, gasTipCap, tx.GasTipCap())
+		require.Equal(t, gasFeeCap, tx.GasFeeCap())
+	})
+}
+
+// TestTxMgr_CraftTxMaxGasFeeCap ensures that the candidate maximum fee cap clamps the fees when crafting transactions.
+func TestTxMgr_CraftTxMaxGasFeeCap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("BelowSuggested", func(t *testing.T) {
+		h := newTestHarness(t)
+		candidate := h.createTxCandidate()
+		candidate.MaxGasFeeCap = big.NewInt(1)
+
+		tx, err := h.mgr.craftTx(context.Background(), candidate)
+		require.NoError(t, err)
+		require.Equal(t, candidate.MaxGasFeeCap, tx.GasFeeCap())
+		require.Equal(t, candidate.MaxGasFeeCap, tx.GasTipCap())
+	})
+
+	t.Run("AboveSuggested", func(t *testing.T) {
+		h := newTestHarness(t)
+		candidate := h.createTxCandidate()
+		gasTipCap, gasFeeCap, _ := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
+		candidate.MaxGasFeeCap = new(big.Int).Mul(gasFeeCap, big.NewInt(10))
+
+		tx, err := h.mgr.craftTx(context.Background(), candidate)
+		require.NoError(t, err)
+		require.Equal(t, gasTipCap, tx.GasTipCap())
+		require.Equal(t, gasFeeCap, tx.GasFeeCap())
+	})
+}
+
+// TestTxMgr_SendTxMaxGasFeeCapPreventsBump ensures fee bumps are skipped when they would exceed the max fee cap.
+func TestTxMgr_SendTxMaxGasFeeCapPreventsBump(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	var maxSeenFeeCap atomic.Pointer[big.Int]
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		maxSeenFeeCap.Store(tx.GasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{maxGasFeeCap: gasFeeCap})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Nil(t, receipt)
+	require.Equal(t, gasFeeCap, maxSeenFeeCap.Load())
+}
+
+func TestTxMgr_CraftTxMaxGasTipCap(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	candidate.MinGasTipCap = big.NewInt(1000)
+	candidate.MaxGasTipCap = big.NewInt(2)
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, candidate.MaxGasTipCap, tx.GasTipCap())
+	_, gasFeeCap, _ := h.gasPricer.feesForEpoch(h.gasPricer.epoch)
+	require.Less(t, tx.GasFeeCap().Cmp(gasFeeCap), 0, "fee cap should be calculated from the clamped tip cap")
+}
+
+// TestTxMgr_SendTxMaxGasTipCapPreventsBump ensures fee bumps are skipped when they would exceed the max tip cap.
+func TestTxMgr_SendTxMaxGasTipCapPreventsBump(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	var maxSeenTipCap atomic.Pointer[big.Int]
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		maxSeenTipCap.Store(tx.GasTipCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{maxGasTipCap: gasTipCap})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Nil(t, receipt)
+	require.Equal(t, gasTipCap, maxSeenTipCap.Load())
+}
+
+// TestTxMgr_SendTxMaxFeeBumps ensures fees are bumped at most the candidate's max fee bumps times.
+func TestTxMgr_SendTxMaxFeeBumps(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = 100 * time.Millisecond
+	h := newTestHarnessWithConfig(t, cfg)
+	gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	var sentLock sync.Mutex
+	sent := make(map[common.Hash]bool)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sentLock.Lock()
+		defer sentLock.Unlock()
+		sent[tx.Hash()] = true
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{maxFeeBumps: 2})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Nil(t, receipt)
+	sentLock.Lock()
+	defer sentLock.Unlock()
+	require.Len(t, sent, 3, "should publish the original tx and two replacements")
+}
+
+// TestTxMgr_SendTxUrgentBumpsImmediately ensures an urgent tx has its fees bumped without waiting for the
+// resubmission timeout.
+func TestTxMgr_SendTxUrgentBumpsImmediately(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.ResubmissionTimeout = time.Hour
+	h := newTestHarnessWithConfig(t, cfg)
+	gasTipCap, gasFeeCap, _ := h.gasPricer.sample()
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+	})
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		// Only mine the replacement transaction
+		if tx.GasFeeCap().Cmp(gasFeeCap) > 0 {
+			txHash := tx.Hash()
+			h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{urgentAt: time.Now().Add(100 * time.Millisecond)})
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+}
+
+func TestTxMgr_SendAsync(t *testing.T) {
+	h := newTestHarness(t)
+	var sent []uint64
+	var sentLock sync.Mutex
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		sentLock.Lock()
+		defer sentLock.Unlock()
+		sent = append(sent, tx.Nonce())
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap(), nil)
+		return nil
+	})
+
+	ctx := context.Background()
+	ch1 := make(chan SendResponse, 1)
+	ch2 := make(chan SendResponse, 1)
+	h.mgr.SendAsync(ctx, h.createTxCandidate(), ch1)
+	h.mgr.SendAsync(ctx, h.createTxCandidate(), ch2)
+
+	// Nonces are assigned in call order, even though the txs are sent concurrently
+	resp1 := <-ch1
+	require.NoError(t, resp1.Err)
+	require.NotNil(t, resp1.Receipt)
+	require.Equal(t, uint64(startingNonce), resp1.Nonce)
+	resp2 := <-ch2
+	require.NoError(t, resp2.Err)
+	require.NotNil(t, resp2.Receipt)
+	require.Equal(t, uint64(startingNonce+1), resp2.Nonce)
+	require.ElementsMatch(t, []uint64{startingNonce, startingNonce + 1}, sent)
+}
+
+func TestTxMgr_SendAsyncRequiresBufferedChannel(t *testing.T) {
+	h := newTestHarness(t)
+	require.Panics(t, func() {
+		h.mgr.SendAsync(context.Background(), h.createTxCandidate(), make(chan SendResponse))
+	})
+}
+
+func TestTxMgr_SendAsyncWhenClosed(t *testing.T) {
+	h := newTestHarness(t)
+	h.mgr.Close()
+	ch := make(chan SendResponse, 1)
+	h.mgr.SendAsync(context.Background(), h.createTxCandidate(), ch)
+	require.ErrorIs(t, (<-ch).Err, ErrClosed)
+}
+
 // TestTxMgr_CraftBlobTx ensures that the tx manager will create blob transactions as expected.
 func TestTxMgr_CraftBlobTx(t *testing.T) {
 	t.Parallel()
@@ -559,6 +786,50 @@ func TestTxMgr_CraftBlobTx(t *testing.T) {
 	require.Equal(t, blobData2, d2)
 }
 
+func TestTxMgr_CraftBlobTxMaxBlobFeeCap(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createBlobTxCandidate()
+	candidate.MaxBlobFeeCap = big.NewInt(100)
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.Equal(t, candidate.MaxBlobFeeCap, tx.BlobGasFeeCap())
+}
+
+// TestTxMgr_SendTxMaxBlobFeeCapPreventsBump ensures blob tx fee bumps are skipped when they would exceed the
+// max blob fee cap.
+func TestTxMgr_SendTxMaxBlobFeeCapPreventsBump(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness(t)
+	gasTipCap, gasFeeCap, excessBlobGas := h.gasPricer.sample()
+	blobFeeCap := calcBlobFeeCap(eip4844.CalcBlobFee(excessBlobGas))
+	tx := types.NewTx(&types.BlobTx{
+		GasTipCap:  uint256.MustFromBig(gasTipCap),
+		GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+		BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+	})
+	var maxSeenBlobFeeCap atomic.Pointer[big.Int]
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		maxSeenBlobFeeCap.Store(tx.BlobGasFeeCap())
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{maxBlobFeeCap: blobFeeCap})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Nil(t, receipt)
+	require.Equal(t, blobFeeCap, maxSeenBlobFeeCap.Load())
+}
+
+func TestTxCandidate_BlobGas(t *testing.T) {
+	h := newTestHarness(t)
+	require.Zero(t, h.createTxCandidate().BlobGas())
+	require.Equal(t, uint64(2*params.BlobTxBlobGasPerBlob), h.createBlobTxCandidate().BlobGas())
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -668,7 +939,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{})
 	require.Nil(t, err)
 
 	require.NotNil(t, receipt)
@@ -703,7 +974,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -748,7 +1019,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, sendLimits{})
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -1323,6 +1594,30 @@ func TestMinFees(t *testing.T) {
 }
 
 // TestClose ensures that the tx manager will refuse new work and cancel any in progress
+func TestTxMgr_CheckSigner(t *testing.T) {
+	t.Parallel()
+	signerErr := errors.New("signer unavailable")
+	var failSigning bool
+	cfg := configWithNumConfs(1)
+	cfg.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if failSigning {
+			return nil, signerErr
+		}
+		return tx, nil
+	}
+	h := newTestHarnessWithConfig(t, cfg)
+
+	require.NoError(t, h.mgr.CheckSigner(context.Background()))
+	require.Nil(t, h.mgr.nonce, "should not consume a nonce")
+
+	failSigning = true
+	require.ErrorIs(t, h.mgr.CheckSigner(context.Background()), signerErr)
+
+	failSigning = false
+	h.mgr.Close()
+	require.ErrorIs(t, h.mgr.CheckSigner(context.Background()), ErrClosed)
+}
+
 func TestClose(t *testing.T) {
 	conf := configWithNumConfs(1)
 	conf.SafeAbortNonceTooLowCount = 100